@@ -1,13 +1,27 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
 	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/ai21"        // register AI21 adapter
@@ -43,66 +57,104 @@ import (
 	"github.com/everstacklabs/sentinel/internal/cache"
 	"github.com/everstacklabs/sentinel/internal/catalog"
 	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/dbsync"
 	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/doctor"
+	"github.com/everstacklabs/sentinel/internal/export"
+	"github.com/everstacklabs/sentinel/internal/fsutil"
 	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/importer"
+	"github.com/everstacklabs/sentinel/internal/inference"
+	"github.com/everstacklabs/sentinel/internal/judge"
 	"github.com/everstacklabs/sentinel/internal/pipeline"
+	"github.com/everstacklabs/sentinel/internal/review"
+	"github.com/everstacklabs/sentinel/internal/schema"
+	"github.com/everstacklabs/sentinel/internal/serve"
 	"github.com/everstacklabs/sentinel/internal/validate"
 
 	ai21Adapter "github.com/everstacklabs/sentinel/internal/adapter/providers/ai21"
-	alibabaAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/alibaba"
-	anthropicAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/anthropic"
-	bailingAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/bailing"
-	cerebrasAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/cerebras"
-	cohereAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/cohere"
-	deepinfraAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/deepinfra"
-	deepseekAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/deepseek"
-	fireworksAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/fireworks"
-	friendliAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/friendli"
-	googleAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/google"
-	groqAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/groq"
-	inceptionAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/inception"
-	llamaAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/llama"
-	minimaxAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/minimax"
-	mistralAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/mistral"
-	moonshotaiAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/moonshotai"
-	nebiusAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/nebius"
-	novaAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/nova"
-	novitaaiAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/novitaai"
-	nvidiaAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/nvidia"
-	openaiAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/openai"
-	perplexityAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/perplexity"
-	siliconflowAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/siliconflow"
-	stepfunAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/stepfun"
-	togetheraiAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/togetherai"
-	upstageAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/upstage"
-	veniceAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/venice"
-	xaiAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/xai"
-	zhipuaiAdapter "github.com/everstacklabs/sentinel/internal/adapter/providers/zhipuai"
 )
 
-var cfgFile string
+var (
+	cfgFile  string
+	deadline time.Duration
+	// cancelDeadline releases the --deadline timer once the command returns.
+	cancelDeadline context.CancelFunc = func() {}
+)
 
 func main() {
 	rootCmd := &cobra.Command{
 		Use:   "sentinel",
 		Short: "Keeps your AI model catalog in sync with reality.",
 		Long:  "An open-source tool that discovers AI models from provider APIs and opens PRs to keep your catalog up to date.",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if deadline > 0 {
+				ctx, cancel := context.WithTimeoutCause(cmd.Context(), deadline, pipeline.ErrDeadline)
+				cancelDeadline = cancel
+				cmd.SetContext(ctx)
+			}
+		},
 	}
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: ./config.yaml)")
+	rootCmd.PersistentFlags().DurationVar(&deadline, "deadline", 0, "stop the command after this long (e.g. 15m), reporting what finished; 0 means no limit")
 
 	rootCmd.AddCommand(
 		syncCmd(),
 		diffCmd(),
+		applyCmd(),
+		reviewCmd(),
 		discoverCmd(),
+		healthCmd(),
+		doctorCmd(),
+		configCmd(),
 		validateCmd(),
+		verifyManifestCmd(),
+		promoteCmd(),
+		queryCmd(),
+		dbCmd(),
+		cacheCmd(),
+		judgeLogCmd(),
+		statusPageCmd(),
+		judgeCmd(),
+		exportCmd(),
+		serveCmd(),
+		importCmd(),
+		schemaCmd(),
+		manCmd(rootCmd),
 	)
+	registerCompletions(rootCmd)
 
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	cancelDeadline()
+	if err != nil {
+		var exit *exitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.code)
+		}
 		os.Exit(1)
 	}
 }
 
+// exitError ends a command with a specific exit code. Commands return it
+// rather than calling os.Exit so their deferred cleanup still runs; main
+// turns it into the process exit code.
+type exitError struct {
+	code int
+}
+
+func (e *exitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+// exitWith stops cmd with the given exit code. The command has already
+// reported why, so cobra prints neither the error nor the usage.
+func exitWith(cmd *cobra.Command, code int) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return &exitError{code: code}
+}
+
 func syncCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "sync",
@@ -113,49 +165,408 @@ func syncCmd() *cobra.Command {
 				return err
 			}
 
-			configureAdapters(cfg)
+			if report, _ := cmd.Flags().GetString("report"); report != "" {
+				cfg.ReportPath = report
+			}
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				cfg.DryRun = true
+			}
+			if failFast, _ := cmd.Flags().GetBool("fail-fast"); failFast {
+				cfg.FailFast = true
+			}
+			if cmd.Flags().Changed("fail-on") {
+				cfg.FailOn, _ = cmd.Flags().GetStringSlice("fail-on")
+				if err := cfg.Validate(); err != nil {
+					return err
+				}
+			}
+			if names, _ := cmd.Flags().GetStringSlice("catalog"); len(names) > 0 {
+				if err := selectCatalogs(cfg, names); err != nil {
+					return err
+				}
+			}
+			if providers, _ := cmd.Flags().GetStringSlice("providers"); len(providers) > 0 {
+				selectProviders(cfg, providers)
+			}
+			opts := []pipeline.Option{configureAdapters(cfg)}
+			var filter pipeline.ChangeFilter
+			filter.OnlyNew, _ = cmd.Flags().GetBool("only-new")
+			filter.OnlyUpdates, _ = cmd.Flags().GetBool("only-updates")
+			filter.Fields, _ = cmd.Flags().GetStringSlice("fields")
+			filter.Models, _ = cmd.Flags().GetStringSlice("models")
+			if filter.OnlyNew && filter.OnlyUpdates {
+				return fmt.Errorf("--only-new and --only-updates are mutually exclusive")
+			}
+			opts = append(opts, pipeline.WithChangeFilter(filter))
+			if c := newJudgeCache(cfg); c != nil {
+				opts = append(opts, pipeline.WithJudgeCache(c))
+			}
+			if cfg.Diff.Renames == pipeline.RenamesConfirm && isTerminal(os.Stdin) {
+				opts = append(opts, pipeline.WithRenameConfirm(promptRename(os.Stdin, os.Stderr)))
+			}
+			results, err := pipeline.SyncCatalogs(cmd.Context(), cfg, opts...)
+			if err != nil {
+				return err
+			}
+
+			reportResults(results)
+			code := pipeline.ExitCode(results, cfg.FailOn)
+			logSyncSummary(results, code)
+			if err := deadlineError(cmd.Context(), results); err != nil {
+				return err
+			}
+			if code != pipeline.ExitSuccess {
+				return exitWith(cmd, code)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Show what would change without writing")
+	cmd.Flags().Bool("fail-fast", false, "Stop at the first provider that fails")
+	cmd.Flags().StringSlice("fail-on", nil, "Exit non-zero when a provider fails for these reasons: error, health, validation (default: fail_on)")
+	cmd.Flags().StringSlice("providers", nil, "Providers to sync (default: all configured)")
+	cmd.Flags().String("report", "", "Write a JSON report of the run to this file (default: report_path)")
+	cmd.Flags().StringSlice("catalog", nil, "Catalogs to sync, by name (default: all configured catalogs)")
+	cmd.Flags().Bool("only-new", false, "Only add new models; leave updates, renames and deprecations for a later run")
+	cmd.Flags().Bool("only-updates", false, "Only update existing models; leave new models for a later run")
+	cmd.Flags().StringSlice("fields", nil, "Only update these model fields (e.g. cost,limits); new models are still added whole")
+	cmd.Flags().StringSlice("models", nil, "Only change models matching these globs (e.g. 'gpt-5*') or /regexps/")
+
+	return cmd
+}
+
+// isTerminal reports whether f is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// promptRename asks on out whether each possible rename is real, reading
+// the answer from in. Anything but yes leaves the rename to review.
+func promptRename(in io.Reader, out io.Writer) pipeline.RenameConfirmFunc {
+	r := bufio.NewReader(in)
+	return func(provider string, rp diff.RenamePair) bool {
+		fmt.Fprintf(out, "%s: rename %s to %s (%.0f%% confidence: %s)? [y/N] ", provider, rp.OldName, rp.NewName, rp.Score*100, rp.Reason)
+		answer, _ := r.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true
+		}
+		return false
+	}
+}
+
+// selectCatalogs narrows cfg.Catalogs to the named entries.
+func selectCatalogs(cfg *config.Config, names []string) error {
+	var selected []config.CatalogConfig
+	for _, name := range names {
+		i := slices.IndexFunc(cfg.Catalogs, func(c config.CatalogConfig) bool { return c.Name == name })
+		if i < 0 {
+			return fmt.Errorf("unknown catalog %q (not in catalogs)", name)
+		}
+		selected = append(selected, cfg.Catalogs[i])
+	}
+	cfg.Catalogs = selected
+	return nil
+}
+
+// selectProviders narrows the providers to sync, in every catalog, to the
+// named ones.
+func selectProviders(cfg *config.Config, names []string) {
+	cfg.Providers = names
+	for i := range cfg.Catalogs {
+		cfg.Catalogs[i].Providers = slices.DeleteFunc(slices.Clone(cfg.Catalogs[i].Providers), func(p string) bool {
+			return !slices.Contains(names, p)
+		})
+	}
+}
+
+func applyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply <changeset.json | cache:name>",
+		Short: "Write a changeset saved with diff --save, without re-discovering",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				cfg.DryRun = true
+			}
 
-			p := pipeline.New(cfg)
-			results, err := p.Sync(cmd.Context())
+			snap, err := readSnapshot(cfg, args[0])
+			if err != nil {
+				return err
+			}
+			results, err := pipeline.New(cfg).Apply(cmd.Context(), snap)
 			if err != nil {
 				return err
 			}
 
+			blocked := reportResults(results)
+			if err := deadlineError(cmd.Context(), results); err != nil {
+				return err
+			}
+			if blocked {
+				return exitWith(cmd, pipeline.ExitPolicyBlock)
+			}
 			for _, r := range results {
 				if r.Error != nil {
-					slog.Error("sync failed", "provider", r.Provider, "error", r.Error)
-				} else if r.Skipped {
-					slog.Info("sync skipped", "provider", r.Provider, "reason", r.SkipReason)
-				} else if r.PRNumber > 0 {
-					slog.Info("PR created", "provider", r.Provider, "pr", r.PRNumber, "draft", r.PRDraft)
-				} else {
-					slog.Info("sync complete", "provider", r.Provider)
+					return fmt.Errorf("changeset not fully applied")
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Check the changeset still applies without writing")
+
+	return cmd
+}
+
+func reviewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "review [changeset.json | cache:name]",
+		Short: "Review proposed changes model by model, then write the accepted ones",
+		Long: `Walk through what a sync would change, one model at a time: accept,
+reject, or edit each proposed model in $VISUAL or $EDITOR, then write only
+what was accepted, as apply would.
+
+Without an argument, review runs discovery first. With one, it reviews a
+changeset saved with diff --save. When the judge is enabled, its verdicts
+are shown next to each model as advice; nothing is rejected automatically.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				cfg.DryRun = true
+			}
+
+			var opts []pipeline.Option
+			if c := newJudgeCache(cfg); c != nil {
+				opts = append(opts, pipeline.WithJudgeCache(c))
+			}
+			if len(args) == 0 {
+				opts = append(opts, configureAdapters(cfg))
+			}
+			p := pipeline.New(cfg, opts...)
+			var changesets []diff.ChangeSet
+			if len(args) == 1 {
+				snap, err := readSnapshot(cfg, args[0])
+				if err != nil {
+					return err
+				}
+				if err := p.LoadCatalog(); err != nil {
+					return err
+				}
+				changesets = snap.ChangeSets
+			} else {
+				if changesets, err = p.Diff(cmd.Context()); err != nil {
+					return err
+				}
+				if errors.Is(context.Cause(cmd.Context()), pipeline.ErrDeadline) {
+					return fmt.Errorf("%w after %s: the diff is incomplete", pipeline.ErrDeadline, deadline)
+				}
+			}
+
+			verdicts := make(map[string]*judge.Result)
+			for i := range changesets {
+				cs := &changesets[i]
+				if !cs.HasChanges() {
+					continue
+				}
+				result, err := p.Judge(cmd.Context(), cs)
+				if err != nil {
+					slog.Warn("judge failed, reviewing without its verdicts", "provider", cs.Provider, "error", err)
+					continue
 				}
+				if result != nil {
+					verdicts[cs.Provider] = result
+				}
+			}
+
+			session := review.New(os.Stdin, os.Stdout,
+				review.WithEditor(review.EditInEditor),
+				review.WithVerdicts(verdicts),
+				review.WithCatalog(p.Catalog(), p.DiffOptions()))
+			accepted, err := session.Run(changesets)
+			if errors.Is(err, review.ErrCancelled) {
+				fmt.Fprintln(os.Stderr, err)
+				return nil
+			}
+			if err != nil {
+				return err
 			}
 
+			results, err := pipeline.New(cfg).Apply(cmd.Context(), p.Snapshot(accepted))
+			if err != nil {
+				return err
+			}
+			blocked := reportResults(results)
+			if err := deadlineError(cmd.Context(), results); err != nil {
+				return err
+			}
+			if blocked {
+				return exitWith(cmd, pipeline.ExitPolicyBlock)
+			}
+			for _, r := range results {
+				if r.Error != nil {
+					return fmt.Errorf("accepted changes not fully written")
+				}
+			}
 			return nil
 		},
 	}
 
-	cmd.Flags().Bool("dry-run", false, "Show what would change without writing")
-	cmd.Flags().StringSlice("providers", nil, "Providers to sync (default: all configured)")
+	cmd.Flags().Bool("dry-run", false, "Review without writing the accepted changes")
 
 	return cmd
 }
 
+// writeSnapshot saves a changeset to a file, or to the cache backend when
+// location is cache:<name>.
+func writeSnapshot(cfg *config.Config, location string, snap *pipeline.Snapshot) error {
+	name, ok := strings.CutPrefix(location, pipeline.CacheSnapshotPrefix)
+	if !ok {
+		return pipeline.WriteSnapshot(location, snap)
+	}
+	c, err := newCache(cfg, 0)
+	if err != nil {
+		return fmt.Errorf("opening cache: %w", err)
+	}
+	return pipeline.SaveSnapshot(c, name, snap)
+}
+
+// readSnapshot is the counterpart of writeSnapshot.
+func readSnapshot(cfg *config.Config, location string) (*pipeline.Snapshot, error) {
+	name, ok := strings.CutPrefix(location, pipeline.CacheSnapshotPrefix)
+	if !ok {
+		return pipeline.ReadSnapshot(location)
+	}
+	c, err := newCache(cfg, 0)
+	if err != nil {
+		return nil, fmt.Errorf("opening cache: %w", err)
+	}
+	return pipeline.LoadSnapshot(c, name)
+}
+
+// deadlineError reports a run cut short by --deadline, after reportResults
+// has logged what did finish.
+func deadlineError(ctx context.Context, results []pipeline.SyncResult) error {
+	if !errors.Is(context.Cause(ctx), pipeline.ErrDeadline) {
+		return nil
+	}
+	finished := 0
+	for _, r := range results {
+		if r.Error == nil {
+			finished++
+		}
+	}
+	return fmt.Errorf("%w after %s: %d of %d providers finished", pipeline.ErrDeadline, deadline, finished, len(results))
+}
+
+// reportResults logs the outcome for each provider and reports whether any
+// was blocked by the risk policy.
+func reportResults(results []pipeline.SyncResult) (blocked bool) {
+	for _, r := range results {
+		log := slog.Default()
+		if r.Catalog != "" {
+			log = log.With("catalog", r.Catalog)
+		}
+		if r.TimedOut {
+			log.Error("sync timed out", "provider", r.Provider, "error", r.Error)
+		} else if r.Error != nil {
+			log.Error("sync failed", "provider", r.Provider, "error", r.Error)
+		} else if ee := r.Entitlement; ee != nil {
+			log.Warn("sync skipped: insufficient entitlement", "provider", r.Provider,
+				"status", ee.StatusCode, "message", ee.Message, "hint", ee.Hint())
+		} else if r.Blocked {
+			blocked = true
+			log.Error("sync blocked by risk policy", "provider", r.Provider, "reason", r.SkipReason)
+		} else if r.Skipped {
+			log.Info("sync skipped", "provider", r.Provider, "reason", r.SkipReason)
+		} else if r.PRNumber > 0 {
+			log.Info("PR created", "provider", r.Provider, "pr", r.PRNumber, "draft", r.PRDraft)
+		} else {
+			log.Info("sync complete", "provider", r.Provider)
+		}
+	}
+	return blocked
+}
+
+// logSyncSummary logs the totals of a sync and the exit code it ends with,
+// the last line a CI log shows.
+func logSyncSummary(results []pipeline.SyncResult, code int) {
+	var ok, skipped, failed, models int
+	for _, r := range results {
+		models += r.Discovered
+		switch {
+		case r.Error != nil:
+			failed++
+		case r.Skipped:
+			skipped++
+		default:
+			ok++
+		}
+	}
+	spend := pipeline.JudgeSpend(results)
+	slog.Info("sync summary", "providers", len(results), "synced", ok, "skipped", skipped,
+		"failed", failed, "models", models, "judge_tokens", spend.InputTokens+spend.OutputTokens,
+		"judge_cost_usd", spend.CostUSD, "exit_code", code)
+}
+
 func diffCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "diff",
 		Short: "Show what would change (no writes)",
+		Long: `Show what a sync would change, without writing anything.
+
+With --from, compare two published versions of the catalog from its git
+history instead of running discovery, and print Markdown release notes.
+--to defaults to the catalog as it is on disk.
+
+With --baseline, diff discovery against a past state of the catalog
+instead of the catalog on disk: a git branch, tag, commit or version, or a
+JSON file written by 'sentinel export'.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := loadConfig()
 			if err != nil {
 				return err
 			}
 
-			configureAdapters(cfg)
+			from, _ := cmd.Flags().GetString("from")
+			to, _ := cmd.Flags().GetString("to")
+			baseline, _ := cmd.Flags().GetString("baseline")
+			if from != "" && baseline != "" {
+				return fmt.Errorf("--from and --baseline are mutually exclusive")
+			}
+			if from != "" {
+				return diffVersions(cfg.CatalogPath, from, to)
+			}
+			if to != "" {
+				return fmt.Errorf("--to requires --from")
+			}
+
+			var opts []pipeline.Option
+			if baseline != "" {
+				if save, _ := cmd.Flags().GetString("save"); save != "" {
+					return fmt.Errorf("--save can't be combined with --baseline: the changeset would not apply to the catalog on disk")
+				}
+				cat, err := loadBaseline(cfg.CatalogPath, baseline)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, pipeline.WithBaseline(cat))
+			}
 
-			p := pipeline.New(cfg)
+			opts = append(opts, configureAdapters(cfg))
+
+			p := pipeline.New(cfg, opts...)
 			changesets, err := p.Diff(cmd.Context())
 			if err != nil {
 				return err
@@ -168,32 +579,164 @@ func diffCmd() *cobra.Command {
 					hasChanges = true
 				}
 			}
+			// A partial diff isn't worth saving or acting on.
+			if errors.Is(context.Cause(cmd.Context()), pipeline.ErrDeadline) {
+				return fmt.Errorf("%w after %s: the diff above is incomplete", pipeline.ErrDeadline, deadline)
+			}
+
+			if annotate, _ := cmd.Flags().GetBool("github-annotations"); annotate {
+				summaryFile, _ := cmd.Flags().GetString("summary-file")
+				if summaryFile == "" {
+					summaryFile = os.Getenv("GITHUB_STEP_SUMMARY")
+				}
+				format, err := cfg.CatalogFormat()
+				if err != nil {
+					return err
+				}
+				if err := writeGitHubAnnotations(cfg.CatalogPath, format.Ext(), summaryFile, changesets); err != nil {
+					return err
+				}
+			}
+
+			if path, _ := cmd.Flags().GetString("save"); path != "" {
+				if err := writeSnapshot(cfg, path, p.Snapshot(changesets)); err != nil {
+					return fmt.Errorf("saving changeset: %w", err)
+				}
+				fmt.Fprintf(os.Stderr, "Changeset saved to %s; apply it with: sentinel apply %s\n", path, path)
+			}
 
 			if hasChanges {
-				os.Exit(pipeline.ExitChanges)
+				return exitWith(cmd, pipeline.ExitChanges)
 			}
 			return nil
 		},
 	}
+
+	cmd.Flags().Bool("github-annotations", false, "Also print GitHub Actions annotations and write a job summary")
+	cmd.Flags().String("summary-file", "", "Markdown file the job summary is appended to (default: $GITHUB_STEP_SUMMARY)")
+	cmd.Flags().String("save", "", "Save the changeset to a JSON file (or cache:<name> in the cache backend) for review and a later apply")
+	cmd.Flags().String("from", "", "Compare catalog versions from git history, starting at this version (e.g. v1.42.0)")
+	cmd.Flags().String("to", "", "Catalog version to compare --from against (default: the catalog on disk)")
+	cmd.Flags().String("baseline", "", "Diff against this git ref or catalog version, or a JSON export, instead of the catalog on disk")
+	_ = cmd.RegisterFlagCompletionFunc("from", cobra.NoFileCompletions)
+	_ = cmd.RegisterFlagCompletionFunc("to", cobra.NoFileCompletions)
+
+	return cmd
+}
+
+// writeGitHubAnnotations prints changesets as workflow commands and appends
+// a Markdown job summary to summaryFile. Annotated file paths are relative
+// to the Actions workspace, where the runner resolves them; ext is the
+// extension of the catalog's model files.
+func writeGitHubAnnotations(catalogPath, ext, summaryFile string, changesets []diff.ChangeSet) error {
+	root := catalogPath
+	workspace := os.Getenv("GITHUB_WORKSPACE")
+	if workspace == "" {
+		workspace = "."
+	}
+	if abs, err := filepath.Abs(catalogPath); err == nil {
+		if ws, err := filepath.Abs(workspace); err == nil {
+			if rel, err := filepath.Rel(ws, abs); err == nil {
+				root = rel
+			}
+		}
+	}
+	fmt.Print(diff.RenderAnnotations(changesets, filepath.ToSlash(root), ext))
+
+	if summaryFile == "" {
+		return nil
+	}
+	f, err := os.OpenFile(summaryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening job summary: %w", err)
+	}
+	if _, err := f.WriteString(diff.RenderJobSummary(changesets)); err != nil {
+		f.Close()
+		return fmt.Errorf("writing job summary: %w", err)
+	}
+	return f.Close()
+}
+
+// diffVersions prints release notes for the catalog changes between two
+// versions. An empty to means the catalog on disk.
+func diffVersions(catalogPath, from, to string) error {
+	before, err := pipeline.LoadCatalogVersion(catalogPath, from)
+	if err != nil {
+		return err
+	}
+	var after *catalog.Catalog
+	if to == "" {
+		after, err = catalog.Load(catalogPath)
+	} else {
+		after, err = pipeline.LoadCatalogVersion(catalogPath, to)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Print(diff.RenderReleaseNotes(before.Version, after.Version, diff.CompareCatalogs(before, after)))
+	return nil
+}
+
+// loadBaseline loads the catalog state --baseline names: a JSON export when
+// it is a file, otherwise a git revision or version of the catalog.
+func loadBaseline(catalogPath, baseline string) (*catalog.Catalog, error) {
+	if fi, err := os.Stat(baseline); err == nil && !fi.IsDir() {
+		f, err := os.Open(baseline)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		cat, err := export.Read(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading baseline %s: %w", baseline, err)
+		}
+		return cat, nil
+	}
+	return pipeline.LoadCatalogRef(catalogPath, baseline)
 }
 
 func discoverCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "discover",
 		Short: "Discovery only, print models to stdout",
+		Long: `Discover one provider's models and print them, without diffing.
+
+With --record, every provider response is also saved as a JSON fixture
+under the given directory, one file per request. --replay answers the
+requests from such fixtures instead, offline and without API keys, so a
+parsing bug can be reproduced from someone else's recording and adapter
+tests can run against real responses.
+
+--explain lists each model's capabilities with where they came from: the
+provider's API, a heuristic on the model ID, or a default for every model
+of the provider.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := loadConfig()
 			if err != nil {
 				return err
 			}
 
-			configureAdapters(cfg)
-
 			provider, _ := cmd.Flags().GetString("provider")
 			if provider == "" {
 				return fmt.Errorf("--provider is required")
 			}
 
+			record, _ := cmd.Flags().GetString("record")
+			replay, _ := cmd.Flags().GetString("replay")
+			var extra []httpclient.Option
+			switch {
+			case record != "" && replay != "":
+				return fmt.Errorf("--record and --replay are mutually exclusive")
+			case record != "":
+				extra = append(extra, httpclient.WithRecorder(record))
+			case replay != "":
+				if _, err := os.Stat(replay); err != nil {
+					return fmt.Errorf("--replay: %w", err)
+				}
+				extra = append(extra, httpclient.WithReplay(replay))
+			}
+			configureAdapters(cfg, extra...)
+
 			a, err := adapter.Get(provider)
 			if err != nil {
 				return err
@@ -213,8 +756,15 @@ func discoverCmd() *cobra.Command {
 				return err
 			}
 
+			explain, _ := cmd.Flags().GetBool("explain")
 			for _, m := range models {
 				fmt.Printf("%-40s %-20s %-10s %s\n", m.Name, m.Family, m.Status, m.DiscoveredBy)
+				if !explain {
+					continue
+				}
+				for _, r := range inference.Explain(m.Capabilities, m.CapabilityReasons) {
+					fmt.Printf("    %-20s %-10s %s\n", r.Capability, r.Source, r.Detail)
+				}
 			}
 
 			fmt.Printf("\nTotal: %d models\n", len(models))
@@ -223,400 +773,1497 @@ func discoverCmd() *cobra.Command {
 	}
 
 	cmd.Flags().String("provider", "", "Provider to discover models from")
+	cmd.Flags().String("record", "", "Save every provider response as a fixture in this directory")
+	cmd.Flags().String("replay", "", "Answer requests from fixtures in this directory instead of the network")
+	cmd.Flags().Bool("explain", false, "Print each model's capabilities and why they were inferred")
 	_ = cmd.MarkFlagRequired("provider")
 
 	return cmd
 }
 
-func validateCmd() *cobra.Command {
+func healthCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "validate",
-		Short: "Validate existing catalog (CI check)",
+		Use:   "health",
+		Short: "Check every provider's sources without syncing",
+		Long: `Check every provider's sources without syncing.
+
+Runs each adapter's liveness probe concurrently, then discovers models to
+compare the count against the adapter's minimum (scaled by
+health.threshold). Nothing is diffed or written. With health.status_pages
+set, incidents declared on the providers' status pages are shown too.
+Exits with code 4 when a provider is down, low on models or refuses access.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			catalogPath, _ := cmd.Flags().GetString("catalog-path")
-			if catalogPath == "" {
-				cfg, err := loadConfig()
-				if err != nil {
-					return err
-				}
-				catalogPath = cfg.CatalogPath
-			}
-
-			cat, err := catalog.Load(catalogPath)
+			cfg, err := loadConfig()
 			if err != nil {
-				return fmt.Errorf("loading catalog: %w", err)
+				return err
 			}
+			if providers, _ := cmd.Flags().GetStringSlice("providers"); len(providers) > 0 {
+				cfg.Providers = providers
+			}
+			statusPages := configureAdapters(cfg)
 
-			result := validate.ValidateCatalog(cat)
-			fmt.Println(validate.FormatResult(result))
+			probeOnly, _ := cmd.Flags().GetBool("probe-only")
+			results := pipeline.New(cfg, statusPages).Health(cmd.Context(), !probeOnly)
 
-			if result.HasErrors() {
-				os.Exit(1)
+			healthy := true
+			for _, h := range results {
+				healthy = healthy && h.Healthy()
+			}
+			output, _ := cmd.Flags().GetString("output")
+			if output == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(results); err != nil {
+					return err
+				}
+			} else {
+				printHealth(results, !probeOnly)
+			}
+			if !healthy {
+				return exitWith(cmd, pipeline.ExitSourceHealth)
 			}
 			return nil
 		},
 	}
-
-	cmd.Flags().String("catalog-path", "", "Path to model catalog (default: from config)")
-
+	cmd.Flags().StringSlice("providers", nil, "Providers to check (default: all configured)")
+	cmd.Flags().Bool("probe-only", false, "Only run the liveness probes, skip discovery and the model count")
+	cmd.Flags().String("output", "table", "Output format: table or json")
 	return cmd
 }
 
-func loadConfig() (*config.Config, error) {
-	cfg, err := config.Load(cfgFile)
-	if err != nil {
-		return nil, fmt.Errorf("loading config: %w", err)
-	}
-	return cfg, nil
-}
-
-func configureAdapters(cfg *config.Config) {
-	// Set up cache
-	var fileCache *cache.FileCache
-	if !cfg.NoCache {
-		ttl, err := time.ParseDuration(cfg.CacheTTL)
-		if err != nil {
-			ttl = time.Hour
+func printHealth(results []pipeline.ProviderHealth, counted bool) {
+	fmt.Printf("%-15s %-10s %8s %10s  %s\n", "PROVIDER", "STATUS", "LATENCY", "MODELS", "NOTES")
+	for _, h := range results {
+		latency := "-"
+		if h.LatencyMS > 0 {
+			latency = fmt.Sprintf("%dms", h.LatencyMS)
 		}
-		fc, err := cache.New(cfg.CacheDir, ttl)
-		if err != nil {
-			slog.Warn("failed to create cache, continuing without", "error", err)
-		} else {
-			fileCache = fc
+		models := "-"
+		if counted && (h.Status == pipeline.HealthOK || h.Status == pipeline.HealthLow || h.Status == pipeline.HealthNoProbe) {
+			models = strconv.Itoa(h.Models)
+		}
+		if h.Threshold > 0 {
+			models += "/" + strconv.Itoa(h.Threshold)
 		}
+		note := h.Error
+		switch {
+		case h.Incident != "" && note != "":
+			note = h.Incident + "; " + note
+		case h.Incident != "":
+			note = h.Incident
+		}
+		fmt.Printf("%-15s %-10s %8s %10s  %s\n", h.Provider, h.Status, latency, models, note)
 	}
+}
 
-	// Set up HTTP client
-	opts := []httpclient.Option{
-		httpclient.WithRateLimit(10), // 10 RPS default
-	}
-	if fileCache != nil {
-		opts = append(opts, httpclient.WithCache(fileCache))
-	}
-	if cfg.NoCache {
-		opts = append(opts, httpclient.WithNoCache())
+func doctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the configuration before a sync",
+		Long: `Check the configuration before a sync.
+
+Looks for the mistakes that would make a sync fail part way: providers
+that don't exist or lack an API key, base URLs this host can't reach, a
+missing catalog, a GitHub token that is rejected or can't push to the
+repository, and cache or state directories that can't be written. Each
+problem is printed with its fix. Exits with code 1 when a check fails, so
+CI can run it as a preflight; warnings don't fail.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			var opts []doctor.Option
+			if offline, _ := cmd.Flags().GetBool("offline"); offline {
+				opts = append(opts, doctor.Offline())
+			}
+			checks := doctor.New(cfg, opts...).Run(cmd.Context())
+
+			output, _ := cmd.Flags().GetString("output")
+			if output == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(checks); err != nil {
+					return err
+				}
+			} else {
+				for _, c := range checks {
+					fmt.Printf("%-5s %-24s %s\n", c.Status, c.Name, c.Detail)
+					if c.Fix != "" {
+						fmt.Printf("      %-24s fix: %s\n", "", c.Fix)
+					}
+				}
+			}
+			if doctor.Failed(checks) {
+				return exitWith(cmd, 1)
+			}
+			return nil
+		},
 	}
-	client := httpclient.New(opts...)
+	cmd.Flags().Bool("offline", false, "Skip the checks that need the network")
+	cmd.Flags().String("output", "table", "Output format: table or json")
+	return cmd
+}
+
+func configCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Check the configuration file",
+	}
+
+	validate := &cobra.Command{
+		Use:   "validate",
+		Short: "Report unknown keys and invalid values in the config",
+		Long: `Report unknown keys and invalid values in the config.
+
+Every command refuses a config with a key sentinel doesn't know, such as a
+misspelled provider section, or a setting outside its allowed values, such
+as risk_mode or sources. validate lists every problem at once, with the
+key a typo most likely meant, and exits with code 1 when there is one.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(cfgFile)
+			var invalid *config.InvalidError
+			if errors.As(err, &invalid) {
+				file := invalid.File
+				if file == "" {
+					file = "environment"
+				}
+				for _, p := range invalid.Problems {
+					fmt.Printf("%s: %v\n", file, p)
+				}
+				return exitWith(cmd, 1)
+			}
+			if err != nil {
+				return err
+			}
+			// The judge rubric files are only read when the judge runs.
+			if _, err := cfg.Judge.Rubric(); err != nil {
+				return err
+			}
+			if cfg.File == "" {
+				fmt.Println("no config file found; the defaults and environment are valid")
+				return nil
+			}
+			fmt.Printf("%s: ok\n", cfg.File)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(validate)
+	return cmd
+}
+
+func validateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate existing catalog (CI check)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			catalogPath, _ := cmd.Flags().GetString("catalog-path")
+			if catalogPath == "" {
+				catalogPath = cfg.CatalogPath
+			}
+			rules, err := cfg.Validation.Ruleset(catalogPath)
+			if err != nil {
+				return err
+			}
+
+			var (
+				result *validate.Result
+				files  []string // nil means the whole catalog
+			)
+			if changed, _ := cmd.Flags().GetBool("changed"); changed {
+				base, _ := cmd.Flags().GetString("base")
+				files, err = changedModelFiles(catalogPath, base)
+				if err != nil {
+					return err
+				}
+				slog.Info("validating changed models", "base", base, "files", len(files))
+				if result, err = rules.ValidateFiles(catalogPath, files); err != nil {
+					return err
+				}
+			} else {
+				cat, err := catalog.Load(catalogPath)
+				if err != nil {
+					return fmt.Errorf("loading catalog: %w", err)
+				}
+				result = rules.ValidateCatalog(cat)
+			}
+
+			if useSchema, _ := cmd.Flags().GetBool("schema"); useSchema {
+				if files == nil {
+					if files, err = validate.ModelFiles(catalogPath); err != nil {
+						return err
+					}
+				}
+				schemaResult, err := validate.ValidateSchema(catalogPath, files)
+				if err != nil {
+					return err
+				}
+				result.Issues = append(result.Issues, schemaResult.Issues...)
+			}
+			fmt.Println(validate.FormatResult(result))
+
+			if result.HasErrors() {
+				return exitWith(cmd, 1)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("catalog-path", "", "Path to model catalog (default: from config)")
+	cmd.Flags().Bool("changed", false, "Only validate models changed relative to --base (git diff)")
+	cmd.Flags().String("base", "origin/main", "Git revision to diff against with --changed")
+	cmd.Flags().Bool("schema", false, "Also check raw model YAML against the JSON Schema (unknown fields, wrong types)")
+
+	return cmd
+}
+
+func verifyManifestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-manifest",
+		Short: "Check the catalog's files against the hashes in manifest.yaml",
+		Long: `Recompute the SHA-256 of every provider and model file, and the catalog
+digest, and compare them with manifest.yaml.
+
+Lists files that were modified, are missing, or aren't in the manifest, and
+exits non-zero if there are any. Run it after fetching a catalog to detect
+tampering or a partial sync.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalogPath, _ := cmd.Flags().GetString("catalog-path")
+			if catalogPath == "" {
+				cfg, err := loadConfig()
+				if err != nil {
+					return err
+				}
+				catalogPath = cfg.CatalogPath
+			}
+			mismatches, err := catalog.VerifyManifest(catalogPath)
+			if err != nil {
+				return err
+			}
+			for _, m := range mismatches {
+				fmt.Printf("%-9s %s\n", m.Kind, m.Path)
+			}
+			if len(mismatches) > 0 {
+				return fmt.Errorf("catalog does not match manifest.yaml: %d differences", len(mismatches))
+			}
+			fmt.Println("Catalog matches manifest.yaml.")
+			return nil
+		},
+	}
+
+	cmd.Flags().String("catalog-path", "", "Path to model catalog (default: from config)")
+
+	return cmd
+}
+
+// changedModelFiles lists the model files that differ from base.
+func changedModelFiles(catalogPath, base string) ([]string, error) {
+	repo, err := pipeline.OpenRepo(catalogPath, "")
+	if err != nil {
+		return nil, err
+	}
+	paths, err := repo.ChangedFiles(base, catalogPath)
+	if err != nil {
+		return nil, err
+	}
+	return validate.ChangedModelFiles(catalogPath, paths)
+}
+
+func manCmd(root *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "man",
+		Short: "Generate man pages for sentinel and its subcommands",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, _ := cmd.Flags().GetString("dir")
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return err
+			}
+			root.DisableAutoGenTag = true
+			header := &doc.GenManHeader{Title: "SENTINEL", Section: "1", Source: "sentinel"}
+			if err := doc.GenManTree(root, header, dir); err != nil {
+				return fmt.Errorf("generating man pages: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "Man pages written to %s\n", dir)
+			return nil
+		},
+	}
+	cmd.Flags().String("dir", "man", "Directory to write the man pages to")
+	return cmd
+}
+
+// registerCompletions adds dynamic shell completion for flags shared across
+// commands: provider names come from the adapter registry, model names from
+// the catalog.
+func registerCompletions(root *cobra.Command) {
+	completers := map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+		"provider":      completeProviders,
+		"providers":     completeProviders,
+		"catalog":       completeCatalogs,
+		"model":         completeModels,
+		"to":            completeReadiness,
+		"min-readiness": completeReadiness,
+	}
+	var walk func(*cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for name, fn := range completers {
+			if cmd.LocalNonPersistentFlags().Lookup(name) != nil {
+				_ = cmd.RegisterFlagCompletionFunc(name, fn)
+			}
+		}
+		for _, sub := range cmd.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+}
+
+func completeProviders(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names := adapter.List()
+	sort.Strings(names)
+	return filterPrefix(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCatalogs lists the names of the configured catalogs.
+func completeCatalogs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, c := range cfg.Catalogs {
+		names = append(names, c.Name)
+	}
+	return filterPrefix(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeModels lists the catalog's models, limited to --provider when set.
+func completeModels(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	catalogPath, err := resolveCatalogPath(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cat, err := catalog.Load(catalogPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	provider, _ := cmd.Flags().GetString("provider")
+	var names []string
+	for name, pc := range cat.Providers {
+		if provider != "" && name != provider {
+			continue
+		}
+		names = append(names, sortedKeys(pc.Models)...)
+	}
+	sort.Strings(names)
+	return filterPrefix(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeReadiness(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterPrefix(catalog.ReadinessLevels(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func filterPrefix(values []string, prefix string) []string {
+	var out []string
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func schemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Work with the JSON Schema for model files",
+	}
+
+	generate := &cobra.Command{
+		Use:   "generate",
+		Short: "Print the JSON Schema for model YAML files",
+		Long: "Print the JSON Schema for model YAML files, for editor integration or\n" +
+			"external validators. `sentinel validate --schema` checks against the same schema.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(schema.Generate()); err != nil {
+				return err
+			}
+
+			output, _ := cmd.Flags().GetString("output")
+			if output == "" || output == "-" {
+				_, err := os.Stdout.Write(buf.Bytes())
+				return err
+			}
+			return os.WriteFile(output, buf.Bytes(), 0o644)
+		},
+	}
+	generate.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+
+	cmd.AddCommand(generate)
+	return cmd
+}
+
+func promoteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "promote",
+		Short: "Set a model's readiness level (e.g. after a verification probe)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalogPath, err := resolveCatalogPath(cmd)
+			if err != nil {
+				return err
+			}
+
+			provider, _ := cmd.Flags().GetString("provider")
+			model, _ := cmd.Flags().GetString("model")
+			to, _ := cmd.Flags().GetString("to")
+			force, _ := cmd.Flags().GetBool("force")
+
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			style := cfg.YAML.Style()
+			if err := style.Validate(); err != nil {
+				return err
+			}
+
+			format, err := cfg.CatalogFormat()
+			if err != nil {
+				return err
+			}
+
+			writer := catalog.NewWriter(catalogPath, catalog.WithStyle(style), catalog.WithFormat(format))
+			result, err := writer.SetReadiness(provider, model, to, force)
+			if err != nil {
+				return fmt.Errorf("promoting %s/%s: %w", provider, model, err)
+			}
+			if len(result.Changes) == 0 {
+				fmt.Printf("%s/%s already %s\n", provider, model, to)
+				return nil
+			}
+			c := result.Changes[0]
+			fmt.Printf("%s/%s: readiness %v → %v\n", provider, model, c.OldValue, c.NewValue)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("catalog-path", "", "Path to model catalog (default: from config)")
+	cmd.Flags().String("provider", "", "Provider of the model")
+	cmd.Flags().String("model", "", "Model name")
+	cmd.Flags().String("to", catalog.ReadinessVerified, "Target readiness level (discovered, verified, approved, ga)")
+	cmd.Flags().Bool("force", false, "Allow moving readiness backwards")
+	_ = cmd.MarkFlagRequired("provider")
+	_ = cmd.MarkFlagRequired("model")
+
+	return cmd
+}
+
+func queryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "List catalog models, optionally filtered by readiness",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalogPath, err := resolveCatalogPath(cmd)
+			if err != nil {
+				return err
+			}
+
+			cat, err := catalog.Load(catalogPath)
+			if err != nil {
+				return fmt.Errorf("loading catalog: %w", err)
+			}
+
+			provider, _ := cmd.Flags().GetString("provider")
+			minReadiness, _ := cmd.Flags().GetString("min-readiness")
+			output, _ := cmd.Flags().GetString("output")
+			summary, _ := cmd.Flags().GetBool("summary")
+
+			if minReadiness != "" && !catalog.IsValidReadiness(minReadiness) {
+				return fmt.Errorf("unknown readiness level %q", minReadiness)
+			}
+
+			type row struct {
+				Provider  string `json:"provider"`
+				Name      string `json:"name"`
+				Family    string `json:"family"`
+				Status    string `json:"status"`
+				Readiness string `json:"readiness"`
+			}
+
+			var rows []row
+			counts := make(map[string]map[string]int)
+			for _, pName := range sortedKeys(cat.Providers) {
+				if provider != "" && pName != provider {
+					continue
+				}
+				pc := cat.Providers[pName]
+				for _, name := range sortedKeys(pc.Models) {
+					m := pc.Models[name]
+					if minReadiness != "" && !m.ReadinessAtLeast(minReadiness) {
+						continue
+					}
+					rows = append(rows, row{pName, m.Name, m.Family, m.Status, m.EffectiveReadiness()})
+					if counts[pName] == nil {
+						counts[pName] = make(map[string]int)
+					}
+					counts[pName][m.EffectiveReadiness()]++
+				}
+			}
+
+			if summary {
+				levels := catalog.ReadinessLevels()
+				if output == "json" {
+					return json.NewEncoder(os.Stdout).Encode(counts)
+				}
+				fmt.Printf("%-20s", "PROVIDER")
+				for _, l := range levels {
+					fmt.Printf(" %10s", strings.ToUpper(l))
+				}
+				fmt.Println()
+				for _, pName := range sortedKeys(counts) {
+					fmt.Printf("%-20s", pName)
+					for _, l := range levels {
+						fmt.Printf(" %10d", counts[pName][l])
+					}
+					fmt.Println()
+				}
+				return nil
+			}
+
+			if output == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(rows)
+			}
+			for _, r := range rows {
+				fmt.Printf("%-15s %-45s %-20s %-10s %s\n", r.Provider, r.Name, r.Family, r.Status, r.Readiness)
+			}
+			fmt.Printf("\nTotal: %d models\n", len(rows))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("catalog-path", "", "Path to model catalog (default: from config)")
+	cmd.Flags().String("provider", "", "Only list models for this provider")
+	cmd.Flags().String("min-readiness", "", "Only list models at or above this readiness level (e.g. approved)")
+	cmd.Flags().String("output", "table", "Output format: table or json")
+	cmd.Flags().Bool("summary", false, "Print readiness counts per provider instead of models")
+
+	return cmd
+}
+
+func exportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Flatten the catalog into a single JSON, JSON Lines or CSV file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalogPath, err := resolveCatalogPath(cmd)
+			if err != nil {
+				return err
+			}
+
+			cat, err := catalog.Load(catalogPath)
+			if err != nil {
+				return fmt.Errorf("loading catalog: %w", err)
+			}
+
+			format, _ := cmd.Flags().GetString("format")
+			outPath, _ := cmd.Flags().GetString("out")
+			var f export.Filter
+			f.Providers, _ = cmd.Flags().GetStringSlice("provider")
+			f.Capabilities, _ = cmd.Flags().GetStringSlice("capability")
+			f.Statuses, _ = cmd.Flags().GetStringSlice("status")
+			f.Types, _ = cmd.Flags().GetStringSlice("type")
+
+			records := export.Records(cat, f)
+			hash, err := catalog.ContentHash(catalogPath)
+			if err != nil {
+				return err
+			}
+			meta := export.Meta{Version: cat.Version, CatalogHash: hash}
+
+			if outPath == "" || outPath == "-" {
+				return export.Write(os.Stdout, format, meta, records)
+			}
+
+			file, err := os.Create(outPath)
+			if err != nil {
+				return fmt.Errorf("creating %s: %w", outPath, err)
+			}
+			if err := export.Write(file, format, meta, records); err != nil {
+				_ = file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("writing %s: %w", outPath, err)
+			}
+			slog.Info("export written", "path", outPath, "format", format, "models", len(records))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("catalog-path", "", "Path to model catalog (default: from config)")
+	cmd.Flags().String("format", export.FormatJSON, "Output format: "+strings.Join(export.Formats(), ", "))
+	cmd.Flags().StringP("out", "o", "", "Write to file instead of stdout")
+	cmd.Flags().StringSlice("provider", nil, "Only export these providers")
+	cmd.Flags().StringSlice("capability", nil, "Only export models with all of these capabilities")
+	cmd.Flags().StringSlice("status", nil, "Only export models with one of these statuses")
+	cmd.Flags().StringSlice("type", nil, "Only export models of these types (chat, embedding, image_generation, ...)")
+
+	return cmd
+}
+
+func serveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the catalog over HTTP with ETag-based change detection",
+		Long: `Serve the catalog over HTTP with ETag-based change detection.
+
+The config file and the validation contracts are checked for changes every
+--reload-interval. serve only reads catalog_path from them, so that is all a
+reload applies: a new catalog_path is served without a restart unless
+--catalog-path pins it. Every changed setting is logged; the others take
+effect on the next sync, diff or discover run, which load the config afresh.
+Sentinel has no long-running sync loop to apply them to. A config that fails
+to load is logged and ignored.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			catalogPath, _ := cmd.Flags().GetString("catalog-path")
+			pinned := catalogPath != ""
+			if !pinned {
+				catalogPath = cfg.CatalogPath
+			}
+			addr, _ := cmd.Flags().GetString("addr")
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			server := serve.New(catalogPath)
+			if interval, _ := cmd.Flags().GetDuration("reload-interval"); interval > 0 {
+				w := config.NewWatcher(cfgFile, cfg, interval, func(old, next *config.Config, changes []config.Change) {
+					diff := make([]string, len(changes))
+					for i, c := range changes {
+						diff[i] = c.String()
+					}
+					slog.Info("config changed, reloaded", "file", next.File, "changes", len(changes), "diff", strings.Join(diff, "; "))
+					if !pinned && next.CatalogPath != old.CatalogPath {
+						server.SetCatalogPath(next.CatalogPath)
+						slog.Info("serving catalog", "addr", addr, "catalog", next.CatalogPath)
+					}
+				})
+				go w.Run(ctx)
+			}
+
+			srv := &http.Server{
+				Addr:              addr,
+				Handler:           server.Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			errc := make(chan error, 1)
+			go func() { errc <- srv.ListenAndServe() }()
+			slog.Info("serving catalog", "addr", addr, "catalog", catalogPath)
+
+			select {
+			case err := <-errc:
+				return err
+			case <-ctx.Done():
+			}
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			return srv.Shutdown(shutdownCtx)
+		},
+	}
+
+	cmd.Flags().String("catalog-path", "", "Path to model catalog (default: from config)")
+	cmd.Flags().String("addr", ":8080", "Address to listen on")
+	cmd.Flags().Duration("reload-interval", 5*time.Second, "How often to check the config for changes; 0 disables reloading")
+
+	return cmd
+}
+
+func importCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Convert a LiteLLM, OpenRouter or CSV model list into catalog files",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			var opts importer.Options
+			opts.Provider, _ = cmd.Flags().GetString("provider")
+
+			var data []byte
+			var err error
+			if args[0] == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("reading input: %w", err)
+			}
+
+			res, err := importer.Parse(format, data, opts)
+			if err != nil {
+				return err
+			}
+
+			for _, provider := range res.Providers() {
+				fmt.Printf("%-16s %d models\n", provider, len(res.Models[provider]))
+			}
+			if len(res.Issues) > 0 {
+				fmt.Printf("\nNot imported or needs review (%d):\n", len(res.Issues))
+				for _, issue := range res.Issues {
+					fmt.Printf("  %s\n", issue)
+				}
+			}
+
+			if dryRun {
+				fmt.Printf("\nDry run: %d models would be imported\n", res.Count())
+				return nil
+			}
+
+			catalogPath, err := resolveCatalogPath(cmd)
+			if err != nil {
+				return err
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			style := cfg.YAML.Style()
+			if err := style.Validate(); err != nil {
+				return err
+			}
+
+			catalogFormat, err := cfg.CatalogFormat()
+			if err != nil {
+				return err
+			}
+
+			written, err := importer.Write(catalogPath, res, catalog.NewWriter(catalogPath, catalog.WithStyle(style), catalog.WithFormat(catalogFormat)))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nImported %d models into %s (%d new or changed). Run `sentinel validate` before committing.\n",
+				res.Count(), catalogPath, written)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("catalog-path", "", "Path to model catalog (default: from config)")
+	cmd.Flags().String("format", "", "Input format: "+strings.Join(importer.Formats(), ", "))
+	cmd.Flags().String("provider", "", "Put every model under this provider instead of the one in the source")
+	cmd.Flags().Bool("dry-run", false, "Show what would be imported without writing files")
+	_ = cmd.MarkFlagRequired("format")
+
+	return cmd
+}
+
+func dbCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Manage the database mirror of the catalog",
+	}
+	cmd.PersistentFlags().String("dsn", "", "Database DSN (default: from config)")
+
+	migrate := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply pending schema migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w, _, err := openDatabase(cmd)
+			if err != nil {
+				return err
+			}
+			defer w.Close()
 
-	// Configure OpenAI adapter
-	if a, err := adapter.Get("openai"); err == nil {
-		if oa, ok := a.(*openaiAdapter.OpenAI); ok {
-			apiKey := cfg.OpenAI.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("OPENAI_API_KEY")
+			n, err := w.Migrate(cmd.Context())
+			if err != nil {
+				return err
 			}
-			oa.Configure(apiKey, cfg.OpenAI.BaseURL, client)
-		}
+			fmt.Printf("Applied %d migration(s)\n", n)
+			return nil
+		},
 	}
 
-	// Configure Anthropic adapter
-	if a, err := adapter.Get("anthropic"); err == nil {
-		if aa, ok := a.(*anthropicAdapter.Anthropic); ok {
-			apiKey := cfg.Anthropic.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("ANTHROPIC_API_KEY")
+	sync := &cobra.Command{
+		Use:   "sync",
+		Short: "Mirror the entire catalog into the database (backfill)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w, cfg, err := openDatabase(cmd)
+			if err != nil {
+				return err
 			}
-			aa.Configure(apiKey, cfg.Anthropic.BaseURL, client)
-		}
-	}
+			defer w.Close()
 
-	// Configure Google adapter
-	if a, err := adapter.Get("google"); err == nil {
-		if ga, ok := a.(*googleAdapter.Google); ok {
-			apiKey := cfg.Google.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("GEMINI_API_KEY")
+			if cfg.Database.AutoMigrate {
+				if _, err := w.Migrate(cmd.Context()); err != nil {
+					return err
+				}
 			}
-			ga.Configure(apiKey, cfg.Google.BaseURL, client)
-		}
-	}
 
-	// Configure Mistral adapter
-	if a, err := adapter.Get("mistral"); err == nil {
-		if ma, ok := a.(*mistralAdapter.Mistral); ok {
-			apiKey := cfg.Mistral.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("MISTRAL_API_KEY")
+			catalogPath, _ := cmd.Flags().GetString("catalog-path")
+			if catalogPath == "" {
+				catalogPath = cfg.CatalogPath
+			}
+			cat, err := catalog.Load(catalogPath)
+			if err != nil {
+				return fmt.Errorf("loading catalog: %w", err)
 			}
-			ma.Configure(apiKey, cfg.Mistral.BaseURL, client)
-		}
-	}
 
-	// Configure Cohere adapter
-	if a, err := adapter.Get("cohere"); err == nil {
-		if ca, ok := a.(*cohereAdapter.Cohere); ok {
-			apiKey := cfg.Cohere.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("COHERE_API_KEY")
+			results, err := w.SyncCatalog(cmd.Context(), cat)
+			if err != nil {
+				return err
 			}
-			ca.Configure(apiKey, cfg.Cohere.BaseURL, client)
-		}
+			for _, provider := range sortedKeys(results) {
+				r := results[provider]
+				fmt.Printf("%-20s upserted %4d  removed %4d\n", provider, r.Upserted, r.Removed)
+			}
+			return nil
+		},
 	}
+	sync.Flags().String("catalog-path", "", "Path to model catalog (default: from config)")
 
-	// Configure Groq adapter
-	if a, err := adapter.Get("groq"); err == nil {
-		if ga, ok := a.(*groqAdapter.Groq); ok {
-			apiKey := cfg.Groq.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("GROQ_API_KEY")
-			}
-			ga.Configure(apiKey, cfg.Groq.BaseURL, client)
-		}
+	cmd.AddCommand(migrate, sync)
+	return cmd
+}
+
+func cacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect, prune and warm the response cache",
 	}
 
-	// Configure DeepSeek adapter
-	if a, err := adapter.Get("deepseek"); err == nil {
-		if da, ok := a.(*deepseekAdapter.DeepSeek); ok {
-			apiKey := cfg.DeepSeek.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("DEEPSEEK_API_KEY")
+	stats := &cobra.Command{
+		Use:   "stats",
+		Short: "Show the size and age of the cache",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, cfg, err := openFileCache()
+			if err != nil {
+				return err
 			}
-			da.Configure(apiKey, cfg.DeepSeek.BaseURL, client)
-		}
+			s, err := c.Stats()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Directory: %s\n", cfg.CacheDir)
+			fmt.Printf("Entries:   %d (%d stale, %d unreadable)\n", s.Entries, s.Stale, s.Corrupt)
+			fmt.Printf("Size:      %.1f MB", float64(s.Bytes)/(1<<20))
+			if cfg.Cache.MaxSizeMB > 0 {
+				fmt.Printf(" of %d MB", cfg.Cache.MaxSizeMB)
+			}
+			fmt.Println()
+			if s.Entries > s.Corrupt {
+				fmt.Printf("Oldest:    %s\n", s.Oldest.Format(time.RFC3339))
+				fmt.Printf("Newest:    %s\n", s.Newest.Format(time.RFC3339))
+			}
+			return nil
+		},
 	}
 
-	// Configure xAI adapter
-	if a, err := adapter.Get("xai"); err == nil {
-		if xa, ok := a.(*xaiAdapter.XAI); ok {
-			apiKey := cfg.XAI.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("XAI_API_KEY")
+	prune := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove old entries and shrink the cache to its size limit",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, cfg, err := openFileCache()
+			if err != nil {
+				return err
+			}
+			olderThan := cfg.Cache.Retain
+			if cmd.Flags().Changed("older-than") {
+				olderThan, _ = cmd.Flags().GetString("older-than")
+			}
+			age, err := time.ParseDuration(olderThan)
+			if err != nil {
+				return fmt.Errorf("invalid age %q: %w", olderThan, err)
+			}
+			maxSize := cfg.Cache.MaxSizeMB
+			if cmd.Flags().Changed("max-size-mb") {
+				maxSize, _ = cmd.Flags().GetInt("max-size-mb")
 			}
-			xa.Configure(apiKey, cfg.XAI.BaseURL, client)
-		}
-	}
 
-	// Configure Together AI adapter
-	if a, err := adapter.Get("togetherai"); err == nil {
-		if ta, ok := a.(*togetheraiAdapter.TogetherAI); ok {
-			apiKey := cfg.TogetherAI.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("TOGETHER_API_KEY")
+			removed, freed, err := c.Prune(age, int64(maxSize)<<20)
+			if err != nil {
+				return err
 			}
-			ta.Configure(apiKey, cfg.TogetherAI.BaseURL, client)
-		}
+			fmt.Printf("Removed %d entries, freed %.1f MB\n", removed, float64(freed)/(1<<20))
+			return nil
+		},
 	}
+	prune.Flags().String("older-than", "", "Remove entries cached longer ago than this (default: cache.retain)")
+	prune.Flags().Int("max-size-mb", 0, "Evict least recently used entries down to this size (default: cache.max_size_mb)")
+
+	warm := &cobra.Command{
+		Use:   "warm",
+		Short: "Fetch every provider's models endpoint into the cache ahead of a sync",
+		Long: `Fetch every configured provider's models endpoint concurrently through the
+response cache, so that a sync run soon after answers from the cache, or
+revalidates with ETags, instead of refetching every listing at full rate.
+Run it before a scheduled sync window. Only API sources are fetched; docs
+pages are left to the sync. Works with every cache backend.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if cfg.NoCache {
+				return fmt.Errorf("the response cache is disabled (no_cache); there is nothing to warm")
+			}
+			if providers, _ := cmd.Flags().GetStringSlice("providers"); len(providers) > 0 {
+				cfg.Providers = providers
+			}
+			configureAdapters(cfg)
 
-	// Configure Cerebras adapter
-	if a, err := adapter.Get("cerebras"); err == nil {
-		if ca, ok := a.(*cerebrasAdapter.Cerebras); ok {
-			apiKey := cfg.Cerebras.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("CEREBRAS_API_KEY")
+			results := pipeline.New(cfg).WarmCache(cmd.Context())
+			failed := 0
+			fmt.Printf("%-15s %-8s %8s %10s  %s\n", "PROVIDER", "STATUS", "MODELS", "DURATION", "ERROR")
+			for _, w := range results {
+				status, models := "warmed", strconv.Itoa(w.Models)
+				switch {
+				case w.Error != "":
+					status, models = "failed", "-"
+					failed++
+				case w.Skipped:
+					status, models = "skipped", "-"
+				}
+				fmt.Printf("%-15s %-8s %8s %10s  %s\n", w.Provider, status, models, fmt.Sprintf("%dms", w.DurationMS), w.Error)
 			}
-			ca.Configure(apiKey, cfg.Cerebras.BaseURL, client)
-		}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d providers failed to warm", failed, len(results))
+			}
+			return nil
+		},
 	}
+	warm.Flags().StringSlice("providers", nil, "Providers to warm (default: all configured)")
+
+	cmd.AddCommand(stats, prune, warm)
+	return cmd
+}
 
-	// Configure Fireworks adapter
-	if a, err := adapter.Get("fireworks"); err == nil {
-		if fa, ok := a.(*fireworksAdapter.Fireworks); ok {
-			apiKey := cfg.Fireworks.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("FIREWORKS_API_KEY")
+func statusPageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status-page",
+		Short: "Summarize recent sync runs per provider for a status page",
+		Long: `Summarize recent sync runs per provider for a status page.
+
+Reads the run history every sync appends to (health.history) and reports,
+for each provider, the success rate over its last --runs runs, its last
+success and whether it is quarantined: failed health.quarantine_after runs
+in a row. Error messages are left out, so the output can be published.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			path, _ := cmd.Flags().GetString("file")
+			if path == "" {
+				path = cfg.Health.History
+			}
+			if path == "" {
+				return fmt.Errorf("run history is disabled (health.history)")
+			}
+			runs, _ := cmd.Flags().GetInt("runs")
+			if runs < 1 {
+				return fmt.Errorf("--runs must be at least 1")
 			}
-			fa.Configure(apiKey, cfg.Fireworks.BaseURL, client)
-		}
-	}
 
-	// Configure DeepInfra adapter
-	if a, err := adapter.Get("deepinfra"); err == nil {
-		if da, ok := a.(*deepinfraAdapter.DeepInfra); ok {
-			apiKey := cfg.DeepInfra.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("DEEPINFRA_API_KEY")
+			records, err := pipeline.ReadRunHistory(path)
+			if err != nil {
+				return err
 			}
-			da.Configure(apiKey, cfg.DeepInfra.BaseURL, client)
-		}
-	}
+			page := pipeline.BuildStatusPage(records, runs, cfg.Health.QuarantineAfter, time.Now())
 
-	// Configure NVIDIA adapter
-	if a, err := adapter.Get("nvidia"); err == nil {
-		if na, ok := a.(*nvidiaAdapter.NVIDIA); ok {
-			apiKey := cfg.NVIDIA.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("NVIDIA_API_KEY")
+			var data []byte
+			switch format, _ := cmd.Flags().GetString("format"); format {
+			case "json":
+				if data, err = json.MarshalIndent(page, "", "  "); err != nil {
+					return err
+				}
+				data = append(data, '\n')
+			case "markdown":
+				data = []byte(page.Markdown())
+			default:
+				return fmt.Errorf("unknown format %q (want json or markdown)", format)
 			}
-			na.Configure(apiKey, cfg.NVIDIA.BaseURL, client)
-		}
-	}
 
-	// Configure Alibaba adapter
-	if a, err := adapter.Get("alibaba"); err == nil {
-		if aa, ok := a.(*alibabaAdapter.Alibaba); ok {
-			apiKey := cfg.Alibaba.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("DASHSCOPE_API_KEY")
+			output, _ := cmd.Flags().GetString("output")
+			if output == "" || output == "-" {
+				_, err := os.Stdout.Write(data)
+				return err
 			}
-			aa.Configure(apiKey, cfg.Alibaba.BaseURL, client)
-		}
+			// Atomic, so a web server publishing the file never serves half of it.
+			return fsutil.WriteFileAtomic(output, data, 0o644, false)
+		},
 	}
+	cmd.Flags().String("file", "", "Run history to read (default: health.history)")
+	cmd.Flags().Int("runs", 20, "Runs per provider to compute the success rate over")
+	cmd.Flags().String("format", "json", "Output format: json or markdown")
+	cmd.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+	return cmd
+}
+
+func judgeLogCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "judge-log",
+		Short: "Query the audit log of judge evaluations",
+		Long: `Query the audit log of judge evaluations.
 
-	// Configure MiniMax adapter
-	if a, err := adapter.Get("minimax"); err == nil {
-		if ma, ok := a.(*minimaxAdapter.MiniMax); ok {
-			apiKey := cfg.MiniMax.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("MINIMAX_API_KEY")
+Each evaluation is listed with its judge model, prompt hash and verdicts.
+Without --model or --verdict only flagged and rejected models are shown;
+--json prints the full records, including prompts and raw responses.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			path, _ := cmd.Flags().GetString("file")
+			if path == "" {
+				path = cfg.Judge.AuditLog
+			}
+			if path == "" {
+				return fmt.Errorf("judge audit log is disabled (judge.audit_log)")
 			}
-			ma.Configure(apiKey, cfg.MiniMax.BaseURL, client)
-		}
-	}
 
-	// Configure Moonshot AI adapter
-	if a, err := adapter.Get("moonshotai"); err == nil {
-		if ma, ok := a.(*moonshotaiAdapter.MoonshotAI); ok {
-			apiKey := cfg.MoonshotAI.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("MOONSHOT_API_KEY")
+			var filter judge.AuditFilter
+			filter.Provider, _ = cmd.Flags().GetString("provider")
+			filter.Model, _ = cmd.Flags().GetString("model")
+			verdict, _ := cmd.Flags().GetString("verdict")
+			filter.Verdict = judge.Verdict(verdict)
+			if since, _ := cmd.Flags().GetDuration("since"); since > 0 {
+				filter.Since = time.Now().Add(-since)
 			}
-			ma.Configure(apiKey, cfg.MoonshotAI.BaseURL, client)
-		}
-	}
 
-	// Configure Nebius adapter
-	if a, err := adapter.Get("nebius"); err == nil {
-		if na, ok := a.(*nebiusAdapter.Nebius); ok {
-			apiKey := cfg.Nebius.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("NEBIUS_API_KEY")
+			records, err := judge.ReadAuditLog(path, filter)
+			if err != nil {
+				return err
+			}
+			if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				for i := range records {
+					if err := enc.Encode(&records[i]); err != nil {
+						return err
+					}
+				}
+				return nil
 			}
-			na.Configure(apiKey, cfg.Nebius.BaseURL, client)
-		}
-	}
 
-	// Configure SiliconFlow adapter
-	if a, err := adapter.Get("siliconflow"); err == nil {
-		if sa, ok := a.(*siliconflowAdapter.SiliconFlow); ok {
-			apiKey := cfg.SiliconFlow.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("SILICONFLOW_API_KEY")
+			if len(records) == 0 {
+				fmt.Println("No matching judge evaluations.")
+				return nil
 			}
-			sa.Configure(apiKey, cfg.SiliconFlow.BaseURL, client)
-		}
+			for _, rec := range records {
+				printAuditRecord(&rec, filter)
+			}
+			return nil
+		},
 	}
+	cmd.Flags().String("file", "", "Audit log to read (default: judge.audit_log)")
+	cmd.Flags().String("provider", "", "Only evaluations of this provider's changesets")
+	cmd.Flags().String("model", "", "Only evaluations with a verdict on this model")
+	cmd.Flags().String("verdict", "", "Only evaluations with this verdict (approve, flag, reject)")
+	cmd.Flags().Duration("since", 0, "Only evaluations in this recent window (e.g. 720h)")
+	cmd.Flags().Bool("json", false, "Print matching records as JSON Lines")
+	return cmd
+}
 
-	// Configure Inception adapter
-	if a, err := adapter.Get("inception"); err == nil {
-		if ia, ok := a.(*inceptionAdapter.Inception); ok {
-			apiKey := cfg.Inception.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("INCEPTION_API_KEY")
-			}
-			ia.Configure(apiKey, cfg.Inception.BaseURL, client)
-		}
+func judgeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "judge",
+		Short: "Work with the LLM judge",
 	}
 
-	// Configure Meta Llama adapter
-	if a, err := adapter.Get("llama"); err == nil {
-		if la, ok := a.(*llamaAdapter.Llama); ok {
-			apiKey := cfg.Llama.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("LLAMA_API_KEY")
+	replay := &cobra.Command{
+		Use:   "replay",
+		Short: "Judge a recorded changeset again and compare the verdicts",
+		Long: `Judge a recorded changeset again and compare the verdicts.
+
+The changeset is taken from the judge audit log by its run id, as shown by
+judge-log. It is sent to the judge model and prompt given here, defaulting
+to the configured ones, and each model whose verdict changed is listed.
+Replays bypass the verdict cache and are not written to the audit log.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			path, _ := cmd.Flags().GetString("file")
+			if path == "" {
+				path = cfg.Judge.AuditLog
+			}
+			if path == "" {
+				return fmt.Errorf("judge audit log is disabled (judge.audit_log)")
+			}
+			id, _ := cmd.Flags().GetString("run")
+			rec, err := judge.FindAuditRecord(path, id)
+			if err != nil {
+				return err
 			}
-			la.Configure(apiKey, cfg.Llama.BaseURL, client)
-		}
-	}
 
-	// Configure Upstage adapter
-	if a, err := adapter.Get("upstage"); err == nil {
-		if ua, ok := a.(*upstageAdapter.Upstage); ok {
-			apiKey := cfg.Upstage.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("UPSTAGE_API_KEY")
+			if cmd.Flags().Changed("provider") {
+				cfg.Judge.Provider, _ = cmd.Flags().GetString("provider")
+			}
+			if cmd.Flags().Changed("model") {
+				cfg.Judge.Model, _ = cmd.Flags().GetString("model")
+			}
+			client, err := pipeline.NewJudgeClient(cfg)
+			if err != nil {
+				return err
+			}
+			rubric, err := cfg.Judge.Rubric()
+			if err != nil {
+				return err
+			}
+			opts := []judge.Option{judge.WithRubric(rubric)}
+			if promptFile, _ := cmd.Flags().GetString("prompt-file"); promptFile != "" {
+				prompt, err := os.ReadFile(promptFile)
+				if err != nil {
+					return fmt.Errorf("reading prompt: %w", err)
+				}
+				opts = append(opts, judge.WithSystemPrompt(string(prompt)))
 			}
-			ua.Configure(apiKey, cfg.Upstage.BaseURL, client)
-		}
-	}
 
-	// Configure Amazon Nova adapter
-	if a, err := adapter.Get("nova"); err == nil {
-		if na, ok := a.(*novaAdapter.Nova); ok {
-			apiKey := cfg.Nova.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("NOVA_API_KEY")
+			r, err := judge.New(client, cfg.Judge.Model, false, opts...).Replay(cmd.Context(), rec)
+			if err != nil {
+				return err
+			}
+			if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(r)
 			}
-			na.Configure(apiKey, cfg.Nova.BaseURL, client)
-		}
-	}
 
-	// Configure Novita AI adapter
-	if a, err := adapter.Get("novitaai"); err == nil {
-		if na, ok := a.(*novitaaiAdapter.NovitaAI); ok {
-			apiKey := cfg.NovitaAI.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("NOVITA_API_KEY")
+			fmt.Printf("Run %s: %s changeset of %s\n", rec.ID(), rec.Provider, rec.Time.Local().Format("2006-01-02 15:04"))
+			fmt.Printf("  before: %s, prompt %s\n", rec.Model, rec.PromptHash)
+			fmt.Printf("  after:  %s, prompt %s (%d+%d tokens, %dms)\n", r.Model, r.PromptHash, r.InputTokens, r.OutputTokens, r.LatencyMS)
+			if len(r.Changes) == 0 {
+				fmt.Printf("All %d verdicts unchanged.\n", len(r.Verdicts))
+				return nil
 			}
-			na.Configure(apiKey, cfg.NovitaAI.BaseURL, client)
-		}
+			fmt.Printf("%d verdicts changed:\n", len(r.Changes))
+			for _, c := range r.Changes {
+				fmt.Printf("  %-40s %-7s -> %s\n", c.ModelName, verdictOrNone(c.Before), verdictOrNone(c.After))
+			}
+			return nil
+		},
 	}
+	replay.Flags().String("run", "", "Run id of the evaluation to replay, from judge-log")
+	replay.Flags().String("provider", "", "Judge provider to replay with (default: judge.provider)")
+	replay.Flags().String("model", "", "Judge model to replay with (default: judge.model)")
+	replay.Flags().String("prompt-file", "", "File with a system prompt to try instead of the built-in one")
+	replay.Flags().String("file", "", "Audit log to read (default: judge.audit_log)")
+	replay.Flags().Bool("json", false, "Print the replay as JSON")
+	_ = replay.MarkFlagRequired("run")
+
+	cmd.AddCommand(replay)
+	return cmd
+}
 
-	// Configure Friendli adapter
-	if a, err := adapter.Get("friendli"); err == nil {
-		if fa, ok := a.(*friendliAdapter.Friendli); ok {
-			apiKey := cfg.Friendli.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("FRIENDLI_TOKEN")
-			}
-			fa.Configure(apiKey, cfg.Friendli.BaseURL, client)
-		}
+func verdictOrNone(v judge.Verdict) string {
+	if v == "" {
+		return "(none)"
+	}
+	return string(v)
+}
+
+// printAuditRecord prints one evaluation as a summary line followed by the
+// verdicts worth looking at.
+func printAuditRecord(rec *judge.AuditRecord, filter judge.AuditFilter) {
+	counts := make(map[judge.Verdict]int)
+	for _, v := range rec.Verdicts {
+		counts[v.Verdict]++
 	}
+	line := fmt.Sprintf("%s  run=%s  %-12s %s prompt=%s  %d approved, %d flagged, %d rejected",
+		rec.Time.Local().Format("2006-01-02 15:04"), rec.ID(), rec.Provider, rec.Model, rec.PromptHash,
+		counts[judge.VerdictApprove], counts[judge.VerdictFlag], counts[judge.VerdictReject])
+	switch {
+	case rec.Error != "":
+		line += "  error: " + rec.Error
+	case rec.Cached:
+		line += "  (cached)"
+	case rec.Screened:
+		line += "  (screening rules)"
+	default:
+		line += fmt.Sprintf("  %d+%d tokens, %dms", rec.InputTokens, rec.OutputTokens, rec.LatencyMS)
+	}
+	fmt.Println(line)
 
-	// Configure StepFun adapter
-	if a, err := adapter.Get("stepfun"); err == nil {
-		if sa, ok := a.(*stepfunAdapter.StepFun); ok {
-			apiKey := cfg.StepFun.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("STEPFUN_API_KEY")
+	for _, v := range rec.Verdicts {
+		if filter.Model == "" && filter.Verdict == "" {
+			if v.Verdict == judge.VerdictApprove {
+				continue
 			}
-			sa.Configure(apiKey, cfg.StepFun.BaseURL, client)
+		} else if !filter.MatchVerdict(v) {
+			continue
+		}
+		fmt.Printf("    %-7s %s (%.2f): %s\n", v.Verdict, v.ModelName, v.Confidence, v.Reasoning)
+		for _, c := range v.Concerns {
+			fmt.Printf("            - %s\n", c)
 		}
 	}
+}
+
+// openFileCache opens the file cache for the cache commands. Other backends
+// expire entries on their own.
+func openFileCache() (*cache.FileCache, *config.Config, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if b := cfg.Cache.Backend; b != "" && b != cache.BackendFile {
+		return nil, nil, fmt.Errorf("cache commands work on the file backend, not %q", b)
+	}
+	ttl, err := time.ParseDuration(cfg.CacheTTL)
+	if err != nil {
+		ttl = time.Hour
+	}
+	c, err := newFileCache(cfg, ttl)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, cfg, nil
+}
+
+// openDatabase connects using --dsn or the configured database settings.
+func openDatabase(cmd *cobra.Command) (*dbsync.Writer, *config.Config, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	dsn, _ := cmd.Flags().GetString("dsn")
+	if dsn == "" {
+		dsn = cfg.Database.DSN
+	}
+	w, err := dbsync.Open(cmd.Context(), cfg.Database.Driver, dsn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return w, cfg, nil
+}
+
+// resolveCatalogPath returns --catalog-path if set, otherwise the configured catalog path.
+func resolveCatalogPath(cmd *cobra.Command) (string, error) {
+	catalogPath, _ := cmd.Flags().GetString("catalog-path")
+	if catalogPath != "" {
+		return catalogPath, nil
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return "", err
+	}
+	return cfg.CatalogPath, nil
+}
+
+// sortedKeys returns the keys of a string-keyed map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	return cfg, nil
+}
 
-	// Configure Zhipu AI adapter
-	if a, err := adapter.Get("zhipuai"); err == nil {
-		if za, ok := a.(*zhipuaiAdapter.ZhipuAI); ok {
-			apiKey := cfg.ZhipuAI.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("ZHIPU_API_KEY")
+// newCache opens the configured cache backend with the given freshness TTL.
+func newCache(cfg *config.Config, ttl time.Duration) (cache.Cache, error) {
+	switch cfg.Cache.Backend {
+	case cache.BackendFile, "":
+		return newFileCache(cfg, ttl)
+	case cache.BackendRedis:
+		retain, err := time.ParseDuration(cfg.Cache.Retain)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache.retain %q: %w", cfg.Cache.Retain, err)
+		}
+		return cache.NewRedis(cfg.Cache.Redis.URL, cfg.Cache.Redis.Prefix, ttl, retain)
+	case cache.BackendHTTP:
+		return cache.NewHTTP(cfg.Cache.HTTP.URL, cfg.Cache.HTTP.Token, ttl)
+	case cache.BackendS3, cache.BackendGCS:
+		s3 := cfg.Cache.S3
+		opts := cache.S3Options{
+			Bucket:          s3.Bucket,
+			Prefix:          s3.Prefix,
+			Region:          s3.Region,
+			Endpoint:        s3.Endpoint,
+			AccessKeyID:     s3.AccessKeyID,
+			SecretAccessKey: s3.SecretAccessKey,
+			SessionToken:    s3.SessionToken,
+		}
+		if cfg.Cache.Backend == cache.BackendGCS {
+			if opts.Endpoint == "" {
+				opts.Endpoint = cache.GCSEndpoint
+			}
+			if opts.Region == "" {
+				opts.Region = "auto"
 			}
-			za.Configure(apiKey, cfg.ZhipuAI.BaseURL, client)
 		}
+		return cache.NewS3(opts, ttl)
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", cfg.Cache.Backend)
 	}
+}
 
-	// Configure Venice adapter
-	if a, err := adapter.Get("venice"); err == nil {
-		if va, ok := a.(*veniceAdapter.Venice); ok {
-			apiKey := cfg.Venice.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("VENICE_API_KEY")
-			}
-			va.Configure(apiKey, cfg.Venice.BaseURL, client)
+// newFileCache opens the file cache in cache_dir, bounded by cache.max_size_mb.
+func newFileCache(cfg *config.Config, ttl time.Duration) (*cache.FileCache, error) {
+	c, err := cache.New(cfg.CacheDir, ttl)
+	if err != nil {
+		return nil, err
+	}
+	c.SetMaxSize(int64(cfg.Cache.MaxSizeMB) << 20)
+	return c, nil
+}
+
+// newJudgeCache returns the cache for judge verdicts, or nil when the judge,
+// caching or judge.cache_ttl is off.
+func newJudgeCache(cfg *config.Config) cache.Cache {
+	if !cfg.Judge.Enabled || cfg.NoCache {
+		return nil
+	}
+	ttl, err := time.ParseDuration(cfg.Judge.CacheTTL)
+	if err != nil {
+		slog.Warn("invalid judge.cache_ttl, not caching verdicts", "value", cfg.Judge.CacheTTL, "error", err)
+		return nil
+	}
+	if ttl <= 0 {
+		return nil
+	}
+	c, err := newCache(cfg, ttl)
+	if err != nil {
+		slog.Warn("failed to create judge cache, continuing without", "backend", cfg.Cache.Backend, "error", err)
+		return nil
+	}
+	return c
+}
+
+// configureAdapters configures every registered adapter from cfg. extra
+// options are applied to the shared HTTP client after the configured ones.
+// It returns the pipeline option that fetches status pages with the same
+// settings, uncached and retried once.
+func configureAdapters(cfg *config.Config, extra ...httpclient.Option) pipeline.Option {
+	// Set up cache
+	var respCache cache.Cache
+	if !cfg.NoCache {
+		ttl, err := time.ParseDuration(cfg.CacheTTL)
+		if err != nil {
+			ttl = time.Hour
+		}
+		c, err := newCache(cfg, ttl)
+		if err != nil {
+			slog.Warn("failed to create cache, continuing without", "backend", cfg.Cache.Backend, "error", err)
+		} else {
+			respCache = c
 		}
 	}
 
-	// Configure Bailing adapter
-	if a, err := adapter.Get("bailing"); err == nil {
-		if ba, ok := a.(*bailingAdapter.Bailing); ok {
-			apiKey := cfg.Bailing.APIKey
-			if apiKey == "" {
-				apiKey = os.Getenv("BAILING_API_TOKEN")
-			}
-			ba.Configure(apiKey, cfg.Bailing.BaseURL, client)
+	// Set up HTTP client
+	warmup, err := time.ParseDuration(cfg.HTTP.Warmup)
+	if err != nil {
+		slog.Warn("invalid http.warmup, disabling warm-up", "value", cfg.HTTP.Warmup, "error", err)
+		warmup = 0
+	}
+	cooldown, err := time.ParseDuration(cfg.HTTP.BreakerCooldown)
+	if err != nil {
+		slog.Warn("invalid http.breaker_cooldown, using 2m", "value", cfg.HTTP.BreakerCooldown, "error", err)
+		cooldown = 2 * time.Minute
+	}
+	timeout, err := time.ParseDuration(cfg.HTTP.Timeout)
+	if err != nil {
+		slog.Warn("invalid http.timeout, using 30s", "value", cfg.HTTP.Timeout, "error", err)
+		timeout = 30 * time.Second
+	}
+	idleTimeout, err := time.ParseDuration(cfg.HTTP.IdleConnTimeout)
+	if err != nil {
+		slog.Warn("invalid http.idle_conn_timeout, using 90s", "value", cfg.HTTP.IdleConnTimeout, "error", err)
+		idleTimeout = 90 * time.Second
+	}
+	opts := []httpclient.Option{
+		httpclient.WithRateLimit(cfg.HTTP.RateLimit),
+		httpclient.WithBurst(cfg.HTTP.Burst),
+		httpclient.WithWarmup(warmup),
+		httpclient.WithJitter(cfg.HTTP.Jitter),
+		httpclient.WithCircuitBreaker(cfg.HTTP.BreakerThreshold, cooldown),
+		httpclient.WithTimeout(timeout),
+		httpclient.WithIdleConns(cfg.HTTP.MaxIdleConnsPerHost, idleTimeout),
+		httpclient.WithMaxResponseSize(int64(cfg.HTTP.MaxResponseMB) << 20),
+	}
+	for _, h := range cfg.HTTP.HostTimeouts {
+		d, err := time.ParseDuration(h.Timeout)
+		if err != nil {
+			slog.Warn("ignoring invalid http.host_timeouts entry", "host", h.Host, "value", h.Timeout, "error", err)
+			continue
+		}
+		opts = append(opts, httpclient.WithHostTimeout(h.Host, d))
+	}
+	if chaos, ok, err := httpclient.ChaosFromEnv(); err != nil {
+		slog.Warn("ignoring invalid "+httpclient.ChaosEnv, "error", err)
+	} else if ok {
+		slog.Warn("chaos mode: injecting synthetic HTTP failures", "config", fmt.Sprintf("%+v", chaos))
+		opts = append(opts, httpclient.WithChaos(chaos))
+	}
+	statusOpts := append(opts[:len(opts):len(opts)], httpclient.WithNoCache(), httpclient.WithMaxRetries(1))
+	statusClient := httpclient.New(append(statusOpts, extra...)...)
+	if respCache != nil {
+		opts = append(opts, httpclient.WithCache(respCache))
+	}
+	if cfg.NoCache {
+		opts = append(opts, httpclient.WithNoCache())
+	}
+	client := httpclient.New(append(opts, extra...)...)
+	clientFor := func(provider string) *httpclient.Client {
+		if h := cfg.ExtraHeaders[provider]; len(h) > 0 {
+			return client.WithHeaders(h)
 		}
+		return client
 	}
 
-	// Configure docs-only adapters (no API key needed)
-	if a, err := adapter.Get("perplexity"); err == nil {
-		if pa, ok := a.(*perplexityAdapter.Perplexity); ok {
-			pa.Configure(client)
+	for _, name := range adapter.List() {
+		a, _ := adapter.Get(name)
+		c, ok := a.(adapter.Configurable)
+		if !ok {
+			continue
+		}
+		spec := c.ConfigSpec()
+		if spec.Settings != nil {
+			if err := cfg.DecodeSettings(spec.Key, spec.Settings); err != nil {
+				slog.Warn("ignoring invalid provider settings", "provider", name, "error", err)
+			}
+		}
+		access, _ := cfg.Access(spec.Key)
+		if access.APIKey == "" {
+			access.APIKey = os.Getenv(spec.APIKeyEnv)
 		}
+		if access.BaseURL == "" {
+			access.BaseURL = spec.BaseURL
+		}
+		c.Configure(access.APIKey, access.BaseURL, clientFor(name))
 	}
+
+	// Docs-only adapters only need the HTTP client
 	if a, err := adapter.Get("ai21"); err == nil {
 		if aa, ok := a.(*ai21Adapter.AI21); ok {
-			aa.Configure(client)
+			aa.Configure(clientFor("ai21"))
 		}
 	}
+	return pipeline.WithStatusPageClient(statusClient)
 }
 
 func init() {
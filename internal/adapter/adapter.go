@@ -1,6 +1,11 @@
 package adapter
 
-import "context"
+import (
+	"context"
+
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/inference"
+)
 
 // SourceType represents how a model was discovered.
 type SourceType string
@@ -38,23 +43,137 @@ type HealthChecker interface {
 	MinExpectedModels() int
 }
 
+// FineTunedLister is an optional interface for adapters that can catalog
+// an organization's fine-tuned models under catalog.FineTunedDir. Catalog
+// models there are only compared with discovery when ListsFineTuned is
+// true; otherwise they are left alone.
+type FineTunedLister interface {
+	ListsFineTuned() bool
+}
+
+// Describer is an optional interface for adapters that supply default
+// metadata for their provider's provider.yaml. The pipeline creates the
+// file from it and fills in fields the catalog leaves empty.
+type Describer interface {
+	ProviderInfo() ProviderInfo
+}
+
+// ProviderInfo is an adapter's default provider metadata. AuthType is one
+// of catalog.AuthTypes.
+type ProviderInfo struct {
+	DisplayName string
+	BaseURL     string
+	AuthType    string
+	StatusPage  string
+}
+
+// Configurable is an optional interface for adapters that call their
+// provider's API. The config loader reads the section ConfigSpec describes
+// and the CLI passes it to Configure, so adding such an adapter takes no
+// changes outside its package.
+type Configurable interface {
+	ConfigSpec() ConfigSpec
+	Configure(apiKey, baseURL string, client *httpclient.Client)
+}
+
+// ConfigSpec describes where an adapter's settings come from. Key is the
+// config section holding its api_key and base_url, APIKeyEnv the
+// environment variable the key is also read from and BaseURL the API
+// endpoint used when the config sets none.
+type ConfigSpec struct {
+	Key         string
+	APIKeyEnv   string
+	BaseURL     string
+	KeyOptional bool // the adapter still discovers from the docs without a key
+
+	// Settings points to a struct the section's other keys are decoded
+	// into, named by mapstructure tags, before Configure is called. Keys it
+	// doesn't name are rejected. Nil when the section has none.
+	Settings any
+}
+
 // DiscoveredModel matches the existing catalog YAML schema.
 type DiscoveredModel struct {
-	Name         string     `yaml:"name"`
-	DisplayName  string     `yaml:"display_name"`
-	Family       string     `yaml:"family"`
-	Status       string     `yaml:"status"`
-	Cost         *Cost      `yaml:"cost,omitempty"`
-	Limits       Limits     `yaml:"limits"`
-	Capabilities []string   `yaml:"capabilities"`
-	Modalities   Modalities `yaml:"modalities"`
-	DiscoveredBy SourceType `yaml:"-"` // For PR metadata only, not written to YAML
-}
-
-// Cost represents model pricing.
+	Name         string      `yaml:"name"`
+	DisplayName  string      `yaml:"display_name"`
+	Family       string      `yaml:"family"`
+	Status       string      `yaml:"status"`
+	ModelType    string      `yaml:"model_type,omitempty"` // see catalog.ModelTypes; inferred when empty
+	Cost         *Cost       `yaml:"cost,omitempty"`
+	Batch        *Batch      `yaml:"batch,omitempty"`
+	FineTuning   *FineTuning `yaml:"fine_tuning,omitempty"`
+	Embedding    *Embedding  `yaml:"embedding,omitempty"` // embedding models only
+	Limits       Limits      `yaml:"limits"`
+	RateLimits   []RateLimit `yaml:"rate_limits,omitempty"` // per account tier; nil when unknown
+	Capabilities []string    `yaml:"capabilities"`
+	Modalities   Modalities  `yaml:"modalities"`
+	// Dates as catalog.Model has them; see catalog.FormatDate and
+	// catalog.ParseCutoff.
+	KnowledgeCutoff string `yaml:"knowledge_cutoff,omitempty"`
+	ReleasedAt      string `yaml:"released_at,omitempty"`
+	// XProvider carries provider-specific metadata into the model's
+	// x_provider block. Leave it nil to keep whatever the catalog has.
+	XProvider    map[string]any `yaml:"x_provider,omitempty"`
+	DiscoveredBy SourceType     `yaml:"-"` // For PR metadata only, not written to YAML
+	// FieldSources overrides DiscoveredBy for single top-level fields, by
+	// their YAML keys: an API adapter filling in cost from a pricing page
+	// sets "cost" to SourceDocs. Recorded as the fields' provenance.
+	FieldSources map[string]SourceType `yaml:"-"`
+	// CapabilityReasons says why the model has each capability, for
+	// discover --explain. Adapters set it with SetCapabilities.
+	CapabilityReasons []inference.Reason `yaml:"-"`
+}
+
+// SetCapabilities sets the model's capabilities and their reasons from s.
+func (m *DiscoveredModel) SetCapabilities(s *inference.Set) {
+	m.Capabilities = s.Capabilities()
+	m.CapabilityReasons = s.Reasons()
+}
+
+// SetFieldSource records that the value of field came from source.
+func (m *DiscoveredModel) SetFieldSource(field string, source SourceType) {
+	if source == "" || source == m.DiscoveredBy {
+		delete(m.FieldSources, field)
+		return
+	}
+	if m.FieldSources == nil {
+		m.FieldSources = make(map[string]SourceType)
+	}
+	m.FieldSources[field] = source
+}
+
+// Cost represents model pricing. Adapters may report token prices per
+// million tokens by setting Unit to "per_1m"; the diff engine normalizes
+// everything to per-1K before comparing against the catalog.
 type Cost struct {
-	InputPer1K  float64 `yaml:"input_per_1k"`
-	OutputPer1K float64 `yaml:"output_per_1k"`
+	InputPer1K       float64  `yaml:"input_per_1k"`
+	OutputPer1K      float64  `yaml:"output_per_1k"`
+	CachedInputPer1K float64  `yaml:"cached_input_per_1k,omitempty"`
+	CacheWritePer1K  float64  `yaml:"cache_write_per_1k,omitempty"`
+	CacheReadPer1K   float64  `yaml:"cache_read_per_1k,omitempty"`
+	BatchInputPer1K  float64  `yaml:"batch_input_per_1k,omitempty"`
+	BatchOutputPer1K float64  `yaml:"batch_output_per_1k,omitempty"`
+	ReasoningPer1K   float64  `yaml:"reasoning_per_1k,omitempty"` // reasoning/thinking tokens
+	PerImage         float64  `yaml:"per_image,omitempty"`
+	PerRequest       float64  `yaml:"per_request,omitempty"`
+	Currency         string   `yaml:"currency,omitempty"`
+	Unit             string   `yaml:"unit,omitempty"`
+	OffPeak          *OffPeak `yaml:"off_peak,omitempty"`
+}
+
+// OffPeak reports a discount on token prices during a daily UTC window;
+// see catalog.OffPeak. Leave it nil when the provider has none.
+type OffPeak struct {
+	Discount float64 `yaml:"discount"`
+	Start    string  `yaml:"start"`
+	End      string  `yaml:"end"`
+}
+
+// Batch reports batch API support; see catalog.Batch. Leave it nil when
+// the provider's batch offering is unknown.
+type Batch struct {
+	Supported bool    `yaml:"supported"`
+	Discount  float64 `yaml:"discount,omitempty"`
 }
 
 // Limits represents model token limits.
@@ -63,6 +182,32 @@ type Limits struct {
 	MaxCompletionTokens int `yaml:"max_completion_tokens,omitempty"`
 }
 
+// FineTuning reports fine-tuning support and per-1K prices; see
+// catalog.FineTuning. Leave it nil when unknown.
+type FineTuning struct {
+	Supported     bool    `yaml:"supported"`
+	TrainingPer1K float64 `yaml:"training_per_1k,omitempty"`
+	InputPer1K    float64 `yaml:"input_per_1k,omitempty"`
+	OutputPer1K   float64 `yaml:"output_per_1k,omitempty"`
+}
+
+// Embedding reports an embedding model's vectors; see catalog.Embedding.
+// Leave it nil for other models and when unknown.
+type Embedding struct {
+	Dimensions   int    `yaml:"dimensions,omitempty"`
+	MaxBatchSize int    `yaml:"max_batch_size,omitempty"`
+	Similarity   string `yaml:"similarity,omitempty"`
+}
+
+// RateLimit is a published per-tier limit; see catalog.RateLimit.
+type RateLimit struct {
+	Tier string `yaml:"tier"`
+	RPM  int    `yaml:"rpm,omitempty"`
+	RPD  int    `yaml:"rpd,omitempty"`
+	TPM  int    `yaml:"tpm,omitempty"`
+	TPD  int    `yaml:"tpd,omitempty"`
+}
+
 // Modalities represents input/output modalities.
 type Modalities struct {
 	Input  []string `yaml:"input"`
@@ -0,0 +1,60 @@
+package adapter
+
+import (
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
+)
+
+// Markers in model IDs that identify image generation and speech models.
+// Providers list these next to their chat models under the same API, and
+// the IDs are the only thing that tells them apart.
+var (
+	speechToTextMarkers = []string{"whisper", "transcribe", "paraformer", "sensevoice", "parakeet", "canary", "-asr"}
+	textToSpeechMarkers = []string{"tts", "speech", "cosyvoice", "sambert"}
+	imageMarkers        = []string{"dall-e", "gpt-image", "stable-diffusion", "sdxl", "flux", "wanx", "imagen"}
+)
+
+// InferMediaType classifies image generation and speech models by their
+// ID. It returns "" for other models, which adapters infer as before.
+func InferMediaType(id string) string {
+	lower := strings.ToLower(id)
+	switch {
+	case containsAny(lower, speechToTextMarkers):
+		return catalog.ModelTypeSpeechToText
+	case containsAny(lower, textToSpeechMarkers):
+		return catalog.ModelTypeTextToSpeech
+	case containsAny(lower, imageMarkers),
+		strings.Contains(lower, "image") && !strings.Contains(lower, "vision"):
+		return catalog.ModelTypeImageGeneration
+	}
+	return ""
+}
+
+// MediaModel fills in the type, capabilities and modalities of a model
+// InferMediaType classified. Media models have no token limits.
+func MediaModel(m *DiscoveredModel, modelType string) {
+	m.ModelType = modelType
+	var caps inference.Set
+	caps.Add(modelType, inference.FromHeuristic, "ID names a "+strings.ReplaceAll(modelType, "_", " ")+" model")
+	m.SetCapabilities(&caps)
+	m.Limits = Limits{}
+	switch modelType {
+	case catalog.ModelTypeSpeechToText:
+		m.Modalities = Modalities{Input: []string{"audio"}, Output: []string{"text"}}
+	case catalog.ModelTypeTextToSpeech:
+		m.Modalities = Modalities{Input: []string{"text"}, Output: []string{"audio"}}
+	case catalog.ModelTypeImageGeneration:
+		m.Modalities = Modalities{Input: []string{"text"}, Output: []string{"image"}}
+	}
+}
+
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package adapter
+
+import "testing"
+
+func TestInferMediaType(t *testing.T) {
+	tests := []struct {
+		id, want string
+	}{
+		{"whisper-1", "speech_to_text"},
+		{"gpt-4o-transcribe", "speech_to_text"},
+		{"distil-whisper-large-v3-en", "speech_to_text"},
+		{"tts-1-hd", "text_to_speech"},
+		{"gpt-4o-mini-tts", "text_to_speech"},
+		{"speech-02-hd", "text_to_speech"},
+		{"dall-e-3", "image_generation"},
+		{"gpt-image-1", "image_generation"},
+		{"black-forest-labs/FLUX.1-schnell", "image_generation"},
+		{"grok-2-image-1212", "image_generation"},
+		{"gpt-4o", ""},
+		{"llama-3.2-11b-vision-instruct", ""},
+		{"qwen2.5-vl-72b-instruct", ""},
+	}
+	for _, tt := range tests {
+		if got := InferMediaType(tt.id); got != tt.want {
+			t.Errorf("InferMediaType(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestMediaModel(t *testing.T) {
+	m := DiscoveredModel{
+		Name:         "whisper-large-v3",
+		Capabilities: []string{"chat"},
+		Limits:       Limits{MaxTokens: 8192},
+	}
+	MediaModel(&m, "speech_to_text")
+	if m.ModelType != "speech_to_text" || len(m.Capabilities) != 1 || m.Capabilities[0] != "speech_to_text" ||
+		m.Limits.MaxTokens != 0 || m.Modalities.Input[0] != "audio" || m.Modalities.Output[0] != "text" {
+		t.Errorf("MediaModel = %+v", m)
+	}
+}
@@ -0,0 +1,31 @@
+package adapter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TitleCase turns a model ID into a fallback display name: each
+// hyphen-separated part becomes a capitalized word, so "qwen-max-latest"
+// becomes "Qwen Max Latest". Adapters with nicer names for known models
+// check those first.
+func TitleCase(id string) string {
+	parts := strings.Split(id, "-")
+	for i, p := range parts {
+		parts[i] = Capitalize(p)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Capitalize upper-cases the first letter of s. It works on runes rather
+// than bytes, so accented and non-Latin names stay valid UTF-8, and it
+// doesn't depend on the system locale. Letters without case, such as CJK
+// characters, are left as they are.
+func Capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToTitle(r)) + s[size:]
+}
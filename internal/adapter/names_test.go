@@ -0,0 +1,37 @@
+package adapter
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTitleCase(t *testing.T) {
+	tests := []struct {
+		id, want string
+	}{
+		{"qwen-max-latest", "Qwen Max Latest"},
+		{"gpt-4o", "Gpt 4o"},
+		{"ébène-7b", "Ébène 7b"},
+		{"ölmodell-groß", "Ölmodell Groß"},
+		{"通义千问-max", "通义千问 Max"},
+		{"하이퍼클로바-x", "하이퍼클로바 X"},
+		{"ǆemal-1", "ǅemal 1"}, // title case, not upper case
+		{"double--dash-", "Double  Dash "},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got := TitleCase(tt.id)
+		if got != tt.want {
+			t.Errorf("TitleCase(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("TitleCase(%q) = %q is not valid UTF-8", tt.id, got)
+		}
+	}
+}
+
+func TestCapitalizeLeavesInvalidUTF8Alone(t *testing.T) {
+	if got := Capitalize("\xffabc"); got != "\xffabc" {
+		t.Errorf("Capitalize = %q", got)
+	}
+}
@@ -0,0 +1,141 @@
+// Package openaicompat holds what adapters for providers with an
+// OpenAI-compatible API have in common: credentials, the health probe and
+// discovery from GET /models. An adapter embeds Base and supplies the
+// provider-specific inference through Hooks.
+package openaicompat
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/inference"
+)
+
+// Base is embedded by adapters whose provider serves GET /models with
+// bearer token auth. It holds the credentials and HTTP client and makes the
+// adapter an adapter.HealthChecker. Adapters that know how many models
+// their provider lists override MinExpectedModels.
+//
+// Base also has the default ShouldSkip, InferDisplayName and
+// InferModalities hooks, so an embedding adapter only has to write the
+// hooks its provider needs.
+type Base struct {
+	APIKey  string
+	BaseURL string
+	Client  *httpclient.Client
+}
+
+// Configure sets up the adapter with API credentials and HTTP client.
+func (b *Base) Configure(apiKey, baseURL string, client *httpclient.Client) {
+	b.APIKey = apiKey
+	b.BaseURL = baseURL
+	b.Client = client
+}
+
+// AuthHeaders returns the headers that authenticate an API request.
+func (b *Base) AuthHeaders() map[string]string {
+	return map[string]string{
+		"Authorization": "Bearer " + b.APIKey,
+	}
+}
+
+// HealthCheck performs a lightweight GET to the models endpoint.
+func (b *Base) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := b.Client.Get(ctx, b.BaseURL+"/models", b.AuthHeaders())
+	return err
+}
+
+// MinExpectedModels returns the minimum model count: any provider worth an
+// adapter lists at least one.
+func (b *Base) MinExpectedModels() int { return 1 }
+
+// Hooks turn the IDs of a provider's model listing into catalog models.
+// All of them are given the model ID as listed.
+type Hooks interface {
+	Name() string
+	ShouldSkip(id string) bool // embeddings, moderation and the like
+	InferDisplayName(id string) string
+	InferFamily(id string) string
+	InferCapabilities(id string) []string
+	InferLimits(id string) adapter.Limits
+	InferModalities(id string) adapter.Modalities
+}
+
+// EmbeddingHooks is implemented by adapters whose provider lists embedding
+// models, to describe the vectors they produce. It returns nil for other
+// models.
+type EmbeddingHooks interface {
+	InferEmbedding(id string) *adapter.Embedding
+}
+
+// ExplainingHooks is implemented by adapters that infer capabilities with
+// the inference package, to record why each model has them. It replaces
+// InferCapabilities in DiscoverAPI.
+type ExplainingHooks interface {
+	ExplainCapabilities(id string) *inference.Set
+}
+
+// ShouldSkip keeps every listed model.
+func (b *Base) ShouldSkip(id string) bool { return false }
+
+// InferDisplayName title-cases the model ID.
+func (b *Base) InferDisplayName(id string) string { return adapter.TitleCase(id) }
+
+// InferModalities assumes a text-only model.
+func (b *Base) InferModalities(id string) adapter.Modalities {
+	return adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}}
+}
+
+// listedModel is an element of the data array of the OpenAI-compatible
+// /v1/models response.
+type listedModel struct {
+	ID string `json:"id"`
+}
+
+// DiscoverAPI lists the provider's models and converts those not skipped
+// with the hooks, which are normally the embedding adapter itself. The
+// listing is decoded a model at a time, as some providers list thousands.
+func (b *Base) DiscoverAPI(ctx context.Context, h Hooks) ([]adapter.DiscoveredModel, error) {
+	var models []adapter.DiscoveredModel
+	total := 0
+	err := httpclient.StreamJSONArray(ctx, b.Client, b.BaseURL+"/models", b.AuthHeaders(), "data", func(am listedModel) error {
+		total++
+		if h.ShouldSkip(am.ID) {
+			return nil
+		}
+		m := adapter.DiscoveredModel{
+			Name:         am.ID,
+			DisplayName:  h.InferDisplayName(am.ID),
+			Family:       h.InferFamily(am.ID),
+			Status:       "stable",
+			Capabilities: h.InferCapabilities(am.ID),
+			Limits:       h.InferLimits(am.ID),
+			Modalities:   h.InferModalities(am.ID),
+			DiscoveredBy: adapter.SourceAPI,
+		}
+		if xh, ok := h.(ExplainingHooks); ok {
+			m.SetCapabilities(xh.ExplainCapabilities(am.ID))
+		}
+		// Image and speech models share the listing with the chat models;
+		// the chat hooks would describe them wrongly.
+		if mt := adapter.InferMediaType(am.ID); mt != "" {
+			adapter.MediaModel(&m, mt)
+		}
+		if eh, ok := h.(EmbeddingHooks); ok {
+			m.Embedding = eh.InferEmbedding(am.ID)
+		}
+		models = append(models, m)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info(h.Name()+" API discovery complete", "total_api_models", total, "catalog_models", len(models))
+	return models, nil
+}
@@ -0,0 +1,79 @@
+package openaicompat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+)
+
+// Embedding Base is enough to satisfy adapter.HealthChecker.
+var _ adapter.HealthChecker = (*struct{ Base })(nil)
+
+func TestHealthCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer k" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	client := httpclient.New(httpclient.WithRateLimit(1000), httpclient.WithNoCache(), httpclient.WithMaxRetries(0))
+
+	var b Base
+	b.Configure("k", srv.URL+"/v1", client)
+	if err := b.HealthCheck(context.Background()); err != nil {
+		t.Errorf("HealthCheck: %v", err)
+	}
+
+	b.Configure("wrong", srv.URL+"/v1", client)
+	var se *httpclient.StatusError
+	if err := b.HealthCheck(context.Background()); !errors.As(err, &se) || se.StatusCode != http.StatusUnauthorized {
+		t.Errorf("HealthCheck with a bad key: got %v, want a 401", err)
+	}
+}
+
+// testAdapter is the smallest adapter on Base: the required hooks only.
+type testAdapter struct{ Base }
+
+func (a *testAdapter) Name() string                      { return "test" }
+func (a *testAdapter) ShouldSkip(id string) bool         { return strings.Contains(id, "embed") }
+func (a *testAdapter) InferFamily(id string) string      { return strings.SplitN(id, "-", 2)[0] }
+func (a *testAdapter) InferCapabilities(string) []string { return []string{"chat"} }
+func (a *testAdapter) InferLimits(string) adapter.Limits { return adapter.Limits{MaxTokens: 8192} }
+
+func TestDiscoverAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"alpha-large","object":"model"},{"id":"alpha-embed"},{"id":"beta-mini","owned_by":"x"}]}`))
+	}))
+	defer srv.Close()
+
+	a := &testAdapter{}
+	a.Configure("k", srv.URL, httpclient.New(httpclient.WithRateLimit(1000), httpclient.WithNoCache()))
+	models, err := a.DiscoverAPI(context.Background(), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("got %d models, want 2 (embedding skipped): %+v", len(models), models)
+	}
+	m := models[1]
+	if m.Name != "beta-mini" || m.DisplayName != "Beta Mini" || m.Family != "beta" || m.Status != "stable" ||
+		m.Limits.MaxTokens != 8192 || m.DiscoveredBy != adapter.SourceAPI {
+		t.Errorf("unexpected model: %+v", m)
+	}
+	if len(m.Modalities.Input) != 1 || m.Modalities.Input[0] != "text" {
+		t.Errorf("modalities = %+v, want the text-only default", m.Modalities)
+	}
+}
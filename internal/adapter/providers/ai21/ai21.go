@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
 	"github.com/everstacklabs/sentinel/internal/httpclient"
 )
 
@@ -21,6 +23,15 @@ type AI21 struct {
 
 func (a *AI21) Name() string { return "ai21" }
 
+// ProviderInfo returns the defaults for providers/ai21/provider.yaml.
+func (a *AI21) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "AI21 Labs",
+		BaseURL:     "https://api.ai21.com/studio/v1",
+		AuthType:    catalog.AuthBearer,
+	}
+}
+
 func (a *AI21) SupportedSources() []adapter.SourceType {
 	return []adapter.SourceType{adapter.SourceDocs}
 }
@@ -30,6 +41,18 @@ func (a *AI21) Configure(client *httpclient.Client) {
 	a.client = client
 }
 
+// HealthCheck fetches the models documentation page, the adapter's only
+// source.
+func (a *AI21) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := a.client.Get(ctx, ai21ModelsURL, nil)
+	return err
+}
+
+// MinExpectedModels returns the minimum model count for AI21.
+func (a *AI21) MinExpectedModels() int { return 2 }
+
 func (a *AI21) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
 	var models []adapter.DiscoveredModel
 
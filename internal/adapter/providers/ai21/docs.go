@@ -8,12 +8,13 @@ import (
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
 	"github.com/everstacklabs/sentinel/internal/htmlutil"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 const ai21ModelsURL = "https://docs.ai21.com/docs/jamba-foundation-models"
 
 func (a *AI21) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	doc, err := htmlutil.Fetch(ctx, ai21ModelsURL)
+	doc, err := htmlutil.Fetch(ctx, a.client, ai21ModelsURL)
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +45,7 @@ func (a *AI21) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredModel,
 	}
 
 	if len(models) == 0 {
-		slog.Warn("ai21 docs scraping: no model data found (page may be JS-rendered)")
+		adapter.Warn(ctx, "", "ai21 docs scraping: no model data found (page may be JS-rendered)")
 	} else {
 		slog.Info("ai21 docs scraping complete", "models", len(models))
 	}
@@ -63,10 +64,10 @@ func parseModelRow(row map[string]string) *adapter.DiscoveredModel {
 		DisplayName:  inferDisplayName(name),
 		Family:       inferFamily(name),
 		Status:       "stable",
-		Capabilities: inferCapabilities(name),
 		Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
 		DiscoveredBy: adapter.SourceDocs,
 	}
+	m.SetCapabilities(inference.Infer("ai21", name))
 
 	// Try to extract context window.
 	contextStr := firstNonEmpty(row, "context length", "context window", "context", "max tokens")
@@ -104,17 +105,7 @@ func inferFamily(id string) string {
 }
 
 func inferDisplayName(id string) string {
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
-}
-
-func inferCapabilities(id string) []string {
-	return []string{"chat", "function_calling", "streaming"}
+	return adapter.TitleCase(id)
 }
 
 func parseTokenCount(s string) int {
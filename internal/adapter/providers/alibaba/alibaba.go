@@ -2,50 +2,51 @@ package alibaba
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Alibaba{})
 }
 
+// defaultBaseURL is where Alibaba Cloud is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://dashscope-intl.aliyuncs.com/compatible-mode/v1"
+
 // Alibaba adapter discovers models from the Alibaba/DashScope API (OpenAI-compatible).
 type Alibaba struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (a *Alibaba) Name() string { return "alibaba" }
 
-func (a *Alibaba) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/alibaba/provider.yaml.
+func (a *Alibaba) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Alibaba Cloud",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (a *Alibaba) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	a.apiKey = apiKey
-	a.baseURL = baseURL
-	a.client = client
+// ConfigSpec returns where the Alibaba Cloud adapter's settings come from.
+func (a *Alibaba) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "alibaba",
+		APIKeyEnv: "DASHSCOPE_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (a *Alibaba) HealthCheck(ctx context.Context) error {
-	url := a.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + a.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := a.client.Get(ctx, url, headers)
-	return err
+func (a *Alibaba) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Alibaba.
@@ -57,7 +58,7 @@ func (a *Alibaba) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := a.discoverFromAPI(ctx)
+			apiModels, err := a.DiscoverAPI(ctx, a)
 			if err != nil {
 				return nil, fmt.Errorf("alibaba API discovery: %w", err)
 			}
@@ -70,63 +71,7 @@ func (a *Alibaba) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (a *Alibaba) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := a.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + a.apiKey,
-	}
-
-	resp, err := a.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("alibaba API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (a *Alibaba) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
@@ -134,16 +79,13 @@ func shouldSkip(id string) bool {
 	if strings.Contains(lower, "rerank") {
 		return true
 	}
-	if strings.Contains(lower, "audio") || strings.Contains(lower, "paraformer") {
-		return true
-	}
-	if strings.Contains(lower, "image") || strings.Contains(lower, "wanx") {
+	if strings.Contains(lower, "audio") {
 		return true
 	}
 	return false
 }
 
-func inferFamily(id string) string {
+func (a *Alibaba) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "qwen3"):
@@ -167,29 +109,15 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+func (a *Alibaba) InferCapabilities(id string) []string {
+	return a.ExplainCapabilities(id).Capabilities()
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "function_calling", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "vl") || strings.Contains(lower, "vision") {
-		caps = append(caps, "vision")
-	}
-	if strings.Contains(lower, "coder") || strings.Contains(lower, "code") {
-		caps = append(caps, "fill_in_middle")
-	}
-	return caps
+func (a *Alibaba) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(a.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (a *Alibaba) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "qwen-long"):
@@ -205,7 +133,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (a *Alibaba) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "vl") || strings.Contains(lower, "vision") {
@@ -10,22 +10,56 @@ import (
 	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
 	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Anthropic{})
 }
 
+// defaultBaseURL is where Anthropic is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.anthropic.com/v1"
+
 // Anthropic adapter discovers models from the Anthropic API.
 type Anthropic struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	apiKey   string
+	baseURL  string
+	settings settings
+	base     *httpclient.Client // as configured, without the beta header
+	client   *httpclient.Client
+}
+
+// settings are the keys of the anthropic config section beyond api_key
+// and base_url.
+type settings struct {
+	Betas []string `mapstructure:"betas"`
 }
 
 func (a *Anthropic) Name() string { return "anthropic" }
 
+// ProviderInfo returns the defaults for providers/anthropic/provider.yaml.
+func (a *Anthropic) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Anthropic",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthHeader,
+		StatusPage:  "https://status.anthropic.com",
+	}
+}
+
+// ConfigSpec returns where the Anthropic adapter's settings come from.
+func (a *Anthropic) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "anthropic",
+		APIKeyEnv: "ANTHROPIC_API_KEY",
+		BaseURL:   defaultBaseURL,
+		Settings:  &a.settings,
+	}
+}
+
 func (a *Anthropic) SupportedSources() []adapter.SourceType {
 	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
 }
@@ -34,16 +68,39 @@ func (a *Anthropic) SupportedSources() []adapter.SourceType {
 func (a *Anthropic) Configure(apiKey, baseURL string, client *httpclient.Client) {
 	a.apiKey = apiKey
 	a.baseURL = baseURL
-	a.client = client
+	a.base = client
+	a.useBetas()
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (a *Anthropic) HealthCheck(ctx context.Context) error {
-	url := a.baseURL + "/models?limit=1"
-	headers := map[string]string{
+// SetBetas opts discovery into Anthropic beta features, sent as the
+// anthropic-beta header. Some model metadata is only listed under a beta.
+func (a *Anthropic) SetBetas(betas []string) {
+	a.settings.Betas = betas
+	a.useBetas()
+}
+
+// useBetas derives the client requests go through. The anthropic-beta
+// header is set on the client rather than per request so that it is part of
+// the response cache key: a listing cached under other betas is not reused.
+func (a *Anthropic) useBetas() {
+	a.client = a.base
+	if a.base != nil && len(a.settings.Betas) > 0 {
+		a.client = a.base.WithHeaders(map[string]string{"anthropic-beta": strings.Join(a.settings.Betas, ",")})
+	}
+}
+
+// headers returns the request headers for the Anthropic API.
+func (a *Anthropic) headers() map[string]string {
+	return map[string]string{
 		"x-api-key":         a.apiKey,
 		"anthropic-version": "2023-06-01",
 	}
+}
+
+// HealthCheck performs a lightweight GET to the models endpoint.
+func (a *Anthropic) HealthCheck(ctx context.Context) error {
+	url := a.baseURL + "/models?limit=1"
+	headers := a.headers()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	_, err := a.client.Get(ctx, url, headers)
@@ -54,26 +111,34 @@ func (a *Anthropic) HealthCheck(ctx context.Context) error {
 func (a *Anthropic) MinExpectedModels() int { return 4 }
 
 func (a *Anthropic) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
-	var models []adapter.DiscoveredModel
+	var (
+		apiModels, docModels []adapter.DiscoveredModel
+		prices               map[string]*adapter.Cost
+	)
 
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := a.discoverFromAPI(ctx)
+			models, err := a.discoverFromAPI(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("anthropic API discovery: %w", err)
 			}
-			models = append(models, apiModels...)
+			apiModels = append(apiModels, models...)
 		case adapter.SourceDocs:
-			docModels, err := a.discoverFromDocs(ctx)
+			models, err := a.discoverFromDocs(ctx)
 			if err != nil {
-				slog.Warn("anthropic docs scraping failed, continuing with API data", "error", err)
+				adapter.Warn(ctx, "", "anthropic docs scraping failed, continuing with API data", "error", err)
 			} else {
-				models = append(models, docModels...)
+				docModels = append(docModels, models...)
+			}
+			if prices, err = a.discoverPricing(ctx); err != nil {
+				adapter.Warn(ctx, "", "anthropic pricing scraping failed, continuing without cache prices", "error", err)
 			}
 		}
 	}
 
+	models := a.mergeDocs(apiModels, docModels)
+	applyPricing(models, prices)
 	return models, nil
 }
 
@@ -92,10 +157,7 @@ type apiModel struct {
 }
 
 func (a *Anthropic) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	headers := map[string]string{
-		"x-api-key":         a.apiKey,
-		"anthropic-version": "2023-06-01",
-	}
+	headers := a.headers()
 
 	var allAPIModels []apiModel
 	afterID := ""
@@ -150,22 +212,30 @@ func (a *Anthropic) apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
 	if displayName == "" {
 		displayName = inferDisplayName(id)
 	}
-	capabilities := inferCapabilities(id)
 	modalities := inferModalities(id)
 	limits := inferLimits(id, family)
 
-	return &adapter.DiscoveredModel{
+	m := &adapter.DiscoveredModel{
 		Name:         id,
 		DisplayName:  displayName,
 		Family:       family,
 		Status:       "stable",
-		Capabilities: capabilities,
 		Limits:       adapter.Limits(limits),
 		Modalities:   adapter.Modalities(modalities),
+		Batch:        &adapter.Batch{Supported: true, Discount: batchDiscount},
 		DiscoveredBy: adapter.SourceAPI,
 	}
+	m.SetCapabilities(inference.Infer("anthropic", id))
+	if created, err := time.Parse(time.RFC3339, am.CreatedAt); err == nil {
+		m.ReleasedAt = catalog.FormatDate(created)
+	}
+	return m
 }
 
+// batchDiscount is the Message Batches API price cut, which applies to
+// every current model.
+const batchDiscount = 0.5
+
 // datedSnapshotRe matches dated snapshot IDs like claude-sonnet-4-20250514
 // but NOT base aliases like claude-sonnet-4-0 or claude-3-5-sonnet.
 var datedSnapshotRe = regexp.MustCompile(`-\d{8}$`)
@@ -193,27 +263,7 @@ func inferFamily(id string) string {
 
 func inferDisplayName(id string) string {
 	// Fallback: capitalize segments
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
-}
-
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "function_calling", "vision", "streaming"}
-
-	// All current Claude models support extended_thinking
-	caps = append(caps, "extended_thinking")
-
-	// Claude 4.6 models support adaptive_thinking
-	if strings.Contains(id, "opus-4-6") || strings.Contains(id, "sonnet-4-6") {
-		caps = append(caps, "adaptive_thinking")
-	}
-
-	return caps
+	return adapter.TitleCase(id)
 }
 
 func inferModalities(id string) adapter.Modalities {
@@ -1,7 +1,16 @@
 package anthropic
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/cache"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func TestShouldSkip(t *testing.T) {
@@ -74,13 +83,13 @@ func TestInferCapabilities(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.id, func(t *testing.T) {
-			got := inferCapabilities(tt.id)
+			got := inference.Infer("anthropic", tt.id).Capabilities()
 			if len(got) != len(tt.wantCaps) {
-				t.Fatalf("inferCapabilities(%q) = %v, want %v", tt.id, got, tt.wantCaps)
+				t.Fatalf("Infer(%q) = %v, want %v", tt.id, got, tt.wantCaps)
 			}
 			for i, c := range got {
 				if c != tt.wantCaps[i] {
-					t.Errorf("inferCapabilities(%q)[%d] = %q, want %q", tt.id, i, c, tt.wantCaps[i])
+					t.Errorf("Infer(%q)[%d] = %q, want %q", tt.id, i, c, tt.wantCaps[i])
 				}
 			}
 		})
@@ -128,3 +137,37 @@ func TestInferLimits(t *testing.T) {
 		})
 	}
 }
+
+func TestBetasAreSentAndKeyTheCache(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Header.Get("Anthropic-Beta"))
+		_, _ = w.Write([]byte(`{"data":[{"id":"claude-opus-4-0","type":"model"}],"has_more":false}`))
+	}))
+	defer srv.Close()
+
+	fc, err := cache.New(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &Anthropic{}
+	a.Configure("k", srv.URL, httpclient.New(httpclient.WithRateLimit(1000), httpclient.WithCache(fc)))
+
+	discover := func() {
+		t.Helper()
+		if _, err := a.discoverFromAPI(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	discover()
+	a.SetBetas([]string{"models-2025-01-01", "extended-metadata"})
+	discover()
+	discover() // same betas: served from the cache
+	a.SetBetas([]string{"models-2025-01-01"})
+	discover()
+
+	want := []string{"", "models-2025-01-01,extended-metadata", "models-2025-01-01"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("anthropic-beta per request = %q, want %q", requests, want)
+	}
+}
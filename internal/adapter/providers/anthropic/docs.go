@@ -4,8 +4,12 @@ import (
 	"context"
 	"log/slog"
 	"regexp"
+	"strconv"
+	"strings"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
 	"github.com/everstacklabs/sentinel/internal/htmlutil"
 	"github.com/everstacklabs/sentinel/internal/llmstxt"
 )
@@ -18,29 +22,49 @@ var claudeModelRe = regexp.MustCompile(`(claude-[\w.-]+)`)
 // discoverFromDocs scrapes the Anthropic models documentation page.
 // Falls back to llms-full.txt if the HTML page is JS-rendered and yields no models.
 func (a *Anthropic) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	doc, err := htmlutil.Fetch(ctx, anthropicModelsURL)
+	doc, err := htmlutil.Fetch(ctx, a.client, anthropicModelsURL)
 	if err != nil {
-		slog.Warn("anthropic docs HTML fetch failed, trying llms.txt fallback", "error", err)
+		adapter.Warn(ctx, "", "anthropic docs HTML fetch failed, trying llms.txt fallback", "error", err)
 		return a.discoverFromLLMsTxt(ctx)
 	}
 
+	models := parseDocs(doc)
+	if len(models) == 0 {
+		adapter.Warn(ctx, "", "anthropic docs scraping: no model data found (page may be JS-rendered), trying llms.txt fallback")
+		return a.discoverFromLLMsTxt(ctx)
+	}
+
+	slog.Info("anthropic docs scraping complete", "models_from_docs", len(models))
+	return models, nil
+}
+
+// parseDocs extracts models from the models overview page. The page's
+// comparison tables have one column per model and one row per feature; a
+// table with one row per model is used when there are none.
+func parseDocs(doc *goquery.Document) []adapter.DiscoveredModel {
+	var models []adapter.DiscoveredModel
+	seen := make(map[string]bool)
+	for _, grid := range htmlutil.Tables(doc, "table") {
+		for _, m := range parseComparisonTable(grid) {
+			if !seen[m.Name] {
+				seen[m.Name] = true
+				models = append(models, m)
+			}
+		}
+	}
+	if len(models) > 0 {
+		return models
+	}
+
 	// Try multiple table selectors.
 	selectors := []string{
 		"table",
 		".markdown table",
 		"article table",
 	}
-
-	var models []adapter.DiscoveredModel
 	for _, sel := range selectors {
-		rows := htmlutil.TableRows(doc, sel)
-		if len(rows) == 0 {
-			continue
-		}
-
-		for _, row := range rows {
-			m := parseDocsRow(row)
-			if m != nil {
+		for _, row := range htmlutil.TableRows(doc, sel) {
+			if m := parseDocsRow(row); m != nil {
 				models = append(models, *m)
 			}
 		}
@@ -48,19 +72,168 @@ func (a *Anthropic) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredM
 			break
 		}
 	}
+	return models
+}
 
-	if len(models) == 0 {
-		slog.Warn("anthropic docs scraping: no model data found (page may be JS-rendered), trying llms.txt fallback")
-		return a.discoverFromLLMsTxt(ctx)
+var (
+	inputPriceRe  = regexp.MustCompile(`\$\s*([\d.]+)\s*/\s*input\s+MTok`)
+	outputPriceRe = regexp.MustCompile(`\$\s*([\d.]+)\s*/\s*output\s+MTok`)
+	tokenCountRe  = regexp.MustCompile(`(?i)([\d.,]+)\s*([KM])?\s*tokens`)
+)
+
+// parseComparisonTable reads a table with a "Feature" column followed by
+// one column per model. Tables without a "Claude API ID" row aren't model
+// comparisons and yield nothing.
+func parseComparisonTable(grid [][]string) []adapter.DiscoveredModel {
+	if len(grid) < 2 {
+		return nil
+	}
+	features := make(map[string][]string, len(grid)-1)
+	for _, row := range grid[1:] {
+		features[strings.ToLower(row[0])] = row
 	}
+	ids, ok := features["claude api id"]
+	if !ok {
+		return nil
+	}
+	aliases := features["claude api alias"]
 
-	slog.Info("anthropic docs scraping complete", "models_from_docs", len(models))
-	return models, nil
+	var models []adapter.DiscoveredModel
+	for col := 1; col < len(grid[0]); col++ {
+		name := docsModelName(cell(aliases, col), cell(ids, col))
+		if name == "" {
+			continue
+		}
+		m := adapter.DiscoveredModel{
+			Name:         name,
+			DisplayName:  grid[0][col],
+			DiscoveredBy: adapter.SourceDocs,
+		}
+
+		pricing := cell(features["pricing"], col)
+		in, okIn := parseFloatMatch(inputPriceRe, pricing)
+		out, okOut := parseFloatMatch(outputPriceRe, pricing)
+		if okIn && okOut {
+			// Thinking tokens are billed as output tokens.
+			m.Cost = &adapter.Cost{InputPer1K: in, OutputPer1K: out, ReasoningPer1K: out, Unit: catalog.PriceUnitPer1M}
+		}
+		m.Limits.MaxTokens = parseTokenCount(cell(features["context window"], col))
+		m.Limits.MaxCompletionTokens = parseTokenCount(cell(features["max output"], col))
+
+		// The reliable knowledge cutoff is what the catalog means by a
+		// cutoff; the training data cutoff has no schema field.
+		m.KnowledgeCutoff = catalog.ParseCutoff(cell(features["reliable knowledge cutoff"], col))
+		if v := cell(features["training data cutoff"], col); v != "" {
+			m.XProvider = map[string]any{"training_data_cutoff": v}
+		}
+		models = append(models, m)
+	}
+	return models
+}
+
+// docsModelName picks the catalog name for a docs column: the alias, or the
+// API ID without its date suffix.
+func docsModelName(alias, id string) string {
+	if m := claudeModelRe.FindString(alias); m != "" {
+		return m
+	}
+	return datedSnapshotRe.ReplaceAllString(claudeModelRe.FindString(id), "")
+}
+
+func cell(row []string, col int) string {
+	if col >= 0 && col < len(row) {
+		return row[col]
+	}
+	return ""
+}
+
+func parseFloatMatch(re *regexp.Regexp, s string) (float64, bool) {
+	m := re.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	return v, err == nil
+}
+
+// parseTokenCount reads the first token count in s, such as the "200K" of
+// "200K tokens / 1M tokens (beta)"; beta extensions don't count.
+func parseTokenCount(s string) int {
+	m := tokenCountRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0
+	}
+	v, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	switch strings.ToUpper(m[2]) {
+	case "K":
+		v *= 1_000
+	case "M":
+		v *= 1_000_000
+	}
+	return int(v)
+}
+
+// mergeDocs overlays what the docs know and the API doesn't report onto
+// API models: context window, max output, pricing and cutoff dates. Models
+// only the docs list are added with the same inferred defaults as API models.
+func (a *Anthropic) mergeDocs(api, docs []adapter.DiscoveredModel) []adapter.DiscoveredModel {
+	byName := make(map[string]*adapter.DiscoveredModel, len(docs))
+	for i := range docs {
+		byName[docs[i].Name] = &docs[i]
+	}
+
+	out := make([]adapter.DiscoveredModel, 0, len(api)+len(docs))
+	for _, m := range api {
+		if d, ok := byName[m.Name]; ok {
+			overlayDocs(&m, d)
+			delete(byName, m.Name)
+		}
+		out = append(out, m)
+	}
+	for _, d := range docs {
+		if _, ok := byName[d.Name]; !ok {
+			continue // merged into an API model
+		}
+		m := a.apiModelToDiscovered(apiModel{ID: d.Name, DisplayName: d.DisplayName})
+		if m == nil {
+			continue
+		}
+		m.DiscoveredBy = adapter.SourceDocs
+		overlayDocs(m, &d)
+		out = append(out, *m)
+	}
+	return out
+}
+
+func overlayDocs(m, d *adapter.DiscoveredModel) {
+	if d.Limits.MaxTokens > 0 {
+		m.Limits.MaxTokens = d.Limits.MaxTokens
+	}
+	if d.Limits.MaxCompletionTokens > 0 {
+		m.Limits.MaxCompletionTokens = d.Limits.MaxCompletionTokens
+	}
+	if m.Cost == nil {
+		m.Cost = d.Cost
+	}
+	if m.KnowledgeCutoff == "" {
+		m.KnowledgeCutoff = d.KnowledgeCutoff
+	}
+	for k, v := range d.XProvider {
+		if m.XProvider == nil {
+			m.XProvider = make(map[string]any)
+		}
+		if _, ok := m.XProvider[k]; !ok {
+			m.XProvider[k] = v
+		}
+	}
 }
 
 // discoverFromLLMsTxt fetches the llms-full.txt and extracts claude model IDs.
 func (a *Anthropic) discoverFromLLMsTxt(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	content, err := llmstxt.Fetch(ctx, anthropicLLMsTxtURL)
+	content, err := llmstxt.Fetch(ctx, a.client, anthropicLLMsTxtURL)
 	if err != nil {
 		return nil, err
 	}
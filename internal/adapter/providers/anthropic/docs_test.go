@@ -0,0 +1,111 @@
+package anthropic
+
+import (
+	"os"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/everstacklabs/sentinel/internal/adapter"
+)
+
+func loadFixture(t *testing.T, name string) *goquery.Document {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestParseDocs(t *testing.T) {
+	models := parseDocs(loadFixture(t, "models_overview.html"))
+
+	want := []struct {
+		name, display     string
+		context, output   int
+		input, outputCost float64
+		trainingCutoff    string
+	}{
+		{"claude-opus-4-6", "Claude Opus 4.6", 200000, 128000, 5, 25, "Aug 2025"},
+		{"claude-sonnet-4-6", "Claude Sonnet 4.6", 200000, 64000, 3, 15, "Jan 2026"},
+		{"claude-haiku-4-5", "Claude Haiku 4.5", 200000, 64000, 1, 5, "Jul 2025"},
+		{"claude-sonnet-4-5", "Claude Sonnet 4.5", 200000, 64000, 3, 15, "Jul 2025"},
+		{"claude-opus-4-1", "Claude Opus 4.1", 200000, 32000, 15, 75, "Mar 2025"},
+	}
+	if len(models) != len(want) {
+		t.Fatalf("got %d models, want %d: %+v", len(models), len(want), models)
+	}
+	for i, w := range want {
+		m := models[i]
+		if m.Name != w.name || m.DisplayName != w.display {
+			t.Errorf("model %d = %s (%q), want %s (%q)", i, m.Name, m.DisplayName, w.name, w.display)
+			continue
+		}
+		if m.Limits.MaxTokens != w.context || m.Limits.MaxCompletionTokens != w.output {
+			t.Errorf("%s limits = %+v, want %d/%d", m.Name, m.Limits, w.context, w.output)
+		}
+		if m.Cost == nil || m.Cost.InputPer1K != w.input || m.Cost.OutputPer1K != w.outputCost || m.Cost.Unit != "per_1m" {
+			t.Errorf("%s cost = %+v, want %g/%g per 1M", m.Name, m.Cost, w.input, w.outputCost)
+		}
+		if got := m.XProvider["training_data_cutoff"]; got != w.trainingCutoff {
+			t.Errorf("%s training cutoff = %v, want %s", m.Name, got, w.trainingCutoff)
+		}
+	}
+	if got := models[0].KnowledgeCutoff; got != "2025-05" {
+		t.Errorf("knowledge cutoff = %q, want 2025-05 with footnote markers dropped", got)
+	}
+}
+
+func TestMergeDocs(t *testing.T) {
+	a := &Anthropic{}
+	api := []adapter.DiscoveredModel{
+		*a.apiModelToDiscovered(apiModel{ID: "claude-opus-4-6", DisplayName: "Claude Opus 4.6", CreatedAt: "2026-02-05T00:00:00Z"}),
+		*a.apiModelToDiscovered(apiModel{ID: "claude-3-haiku-latest"}),
+	}
+	docs := []adapter.DiscoveredModel{
+		{
+			Name:            "claude-opus-4-6",
+			Limits:          adapter.Limits{MaxTokens: 200000, MaxCompletionTokens: 96000},
+			Cost:            &adapter.Cost{InputPer1K: 5, OutputPer1K: 25, Unit: "per_1m"},
+			XProvider:       map[string]any{"training_data_cutoff": "Aug 2025"},
+			KnowledgeCutoff: "2025-05",
+			DiscoveredBy:    adapter.SourceDocs,
+		},
+		{Name: "claude-haiku-4-5", DisplayName: "Claude Haiku 4.5", Limits: adapter.Limits{MaxTokens: 200000}, DiscoveredBy: adapter.SourceDocs},
+	}
+
+	got := a.mergeDocs(api, docs)
+	if len(got) != 3 {
+		t.Fatalf("got %d models, want 2 from the API and 1 docs-only", len(got))
+	}
+
+	opus := got[0]
+	if opus.DiscoveredBy != adapter.SourceAPI || opus.DisplayName != "Claude Opus 4.6" {
+		t.Errorf("merged model should stay an API model: %+v", opus)
+	}
+	if opus.Limits.MaxCompletionTokens != 96000 {
+		t.Errorf("docs max output should replace the inferred one, got %d", opus.Limits.MaxCompletionTokens)
+	}
+	if opus.Cost == nil || opus.Cost.InputPer1K != 5 || opus.XProvider["training_data_cutoff"] != "Aug 2025" {
+		t.Errorf("docs pricing and cutoff not merged: %+v %v", opus.Cost, opus.XProvider)
+	}
+	if opus.KnowledgeCutoff != "2025-05" || opus.ReleasedAt != "2026-02-05" {
+		t.Errorf("dates = %q / %q, want the docs cutoff and the API release date", opus.KnowledgeCutoff, opus.ReleasedAt)
+	}
+	if got[1].Cost != nil {
+		t.Errorf("model without docs data should be unchanged: %+v", got[1])
+	}
+
+	haiku := got[2]
+	if haiku.Name != "claude-haiku-4-5" || haiku.DiscoveredBy != adapter.SourceDocs || haiku.Family != "claude-haiku" {
+		t.Errorf("docs-only model = %+v", haiku)
+	}
+	if haiku.Limits.MaxTokens != 200000 || haiku.Limits.MaxCompletionTokens == 0 {
+		t.Errorf("docs-only model should keep inferred limits the docs don't give: %+v", haiku.Limits)
+	}
+}
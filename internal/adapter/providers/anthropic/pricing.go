@@ -0,0 +1,148 @@
+package anthropic
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/htmlutil"
+)
+
+const anthropicPricingURL = "https://platform.claude.com/docs/en/about-claude/pricing"
+
+// discoverPricing scrapes the model pricing table, keyed by pricingKey of
+// the model's display name.
+func (a *Anthropic) discoverPricing(ctx context.Context) (map[string]*adapter.Cost, error) {
+	doc, err := htmlutil.Fetch(ctx, a.client, anthropicPricingURL)
+	if err != nil {
+		return nil, err
+	}
+	prices := parsePricing(htmlutil.Tables(doc, "table"))
+	if len(prices) == 0 {
+		adapter.Warn(ctx, "", "anthropic pricing scraping: no model prices found (page layout may have changed)")
+	} else {
+		slog.Info("anthropic pricing scraping complete", "models", len(prices))
+	}
+	return prices, nil
+}
+
+var (
+	dollarRe = regexp.MustCompile(`\$\s*(\d[\d,]*(?:\.\d+)?|\.\d+)`)
+	noteRe   = regexp.MustCompile(`\(.*?\)`)
+)
+
+// parsePricing reads the model pricing table, with a model column and base
+// input, cache write, cache hit and output columns, and the batch table,
+// with batch input and output columns, all priced per million tokens. The
+// page lists cache writes with a 5 minute and a 1 hour lifetime; the
+// catalog's cache write price is the 5 minute one, the API's default.
+func parsePricing(tables [][][]string) map[string]*adapter.Cost {
+	prices := make(map[string]*adapter.Cost)
+	batch := make(map[string][2]float64)
+	for _, grid := range tables {
+		if len(grid) < 2 {
+			continue
+		}
+		modelCol, inputCol, writeCol, readCol, outputCol := -1, -1, -1, -1, -1
+		batchInCol, batchOutCol := -1, -1
+		for i, h := range grid[0] {
+			h = strings.ToLower(h)
+			switch {
+			case strings.Contains(h, "model") && modelCol < 0:
+				modelCol = i
+			case strings.Contains(h, "cache write") && !strings.Contains(h, "1h") && writeCol < 0:
+				writeCol = i
+			case strings.Contains(h, "cache hit") && readCol < 0:
+				readCol = i
+			case strings.Contains(h, "batch input") && batchInCol < 0:
+				batchInCol = i
+			case strings.Contains(h, "batch output") && batchOutCol < 0:
+				batchOutCol = i
+			case strings.Contains(h, "input") && inputCol < 0:
+				inputCol = i
+			case strings.Contains(h, "output") && outputCol < 0:
+				outputCol = i
+			}
+		}
+		if modelCol < 0 {
+			continue
+		}
+
+		for _, row := range grid[1:] {
+			key := pricingKey(cell(row, modelCol))
+			if key == "" {
+				continue
+			}
+			if in, ok := parseDollars(cell(row, batchInCol)); ok {
+				if out, ok := parseDollars(cell(row, batchOutCol)); ok {
+					batch[key] = [2]float64{in, out}
+				}
+				continue
+			}
+			// Long context prices have a table of their own, without
+			// cache columns.
+			if writeCol < 0 && readCol < 0 {
+				continue
+			}
+			in, okIn := parseDollars(cell(row, inputCol))
+			out, okOut := parseDollars(cell(row, outputCol))
+			if !okIn || !okOut {
+				continue
+			}
+			// Thinking tokens are billed as output tokens.
+			cost := &adapter.Cost{InputPer1K: in, OutputPer1K: out, ReasoningPer1K: out, Unit: catalog.PriceUnitPer1M}
+			if v, ok := parseDollars(cell(row, writeCol)); ok {
+				cost.CacheWritePer1K = v
+			}
+			if v, ok := parseDollars(cell(row, readCol)); ok {
+				cost.CacheReadPer1K = v
+			}
+			prices[key] = cost
+		}
+	}
+	for key, b := range batch {
+		if cost, ok := prices[key]; ok {
+			cost.BatchInputPer1K, cost.BatchOutputPer1K = b[0], b[1]
+		}
+	}
+	return prices
+}
+
+// pricingKey reduces a display name such as "Claude Sonnet 3.7
+// (deprecated)" to the form both the pricing page and the models API use:
+// lowercase and without notes in parentheses.
+func pricingKey(name string) string {
+	name = noteRe.ReplaceAllString(name, "")
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// applyPricing replaces the cost of every model the pricing page lists.
+// The page is the only docs page with cache prices, so it wins over the
+// overview's.
+func applyPricing(models []adapter.DiscoveredModel, prices map[string]*adapter.Cost) {
+	for i := range models {
+		m := &models[i]
+		cost, ok := prices[pricingKey(m.DisplayName)]
+		if !ok {
+			continue
+		}
+		c := *cost
+		m.Cost = &c
+		m.SetFieldSource("cost", adapter.SourceDocs)
+	}
+}
+
+// parseDollars reads the first dollar amount in s, such as the 3.75 of
+// "$3.75 / MTok".
+func parseDollars(s string) (float64, bool) {
+	m := dollarRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+	return v, err == nil
+}
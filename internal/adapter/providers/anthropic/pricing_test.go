@@ -0,0 +1,56 @@
+package anthropic
+
+import (
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/htmlutil"
+)
+
+func TestParsePricing(t *testing.T) {
+	prices := parsePricing(htmlutil.Tables(loadFixture(t, "pricing.html"), "table"))
+
+	want := map[string]adapter.Cost{
+		"claude opus 4.6":   {InputPer1K: 5, OutputPer1K: 25, ReasoningPer1K: 25, CacheWritePer1K: 6.25, CacheReadPer1K: 0.5, BatchInputPer1K: 2.5, BatchOutputPer1K: 12.5, Unit: "per_1m"},
+		"claude sonnet 4.5": {InputPer1K: 3, OutputPer1K: 15, ReasoningPer1K: 15, CacheWritePer1K: 3.75, CacheReadPer1K: 0.3, Unit: "per_1m"},
+		"claude haiku 4.5":  {InputPer1K: 1, OutputPer1K: 5, ReasoningPer1K: 5, CacheWritePer1K: 1.25, CacheReadPer1K: 0.1, Unit: "per_1m"},
+		"claude sonnet 3.7": {InputPer1K: 3, OutputPer1K: 15, ReasoningPer1K: 15, CacheWritePer1K: 3.75, CacheReadPer1K: 0.3, Unit: "per_1m"},
+	}
+	// Only Opus 4.6 is in the fixture's batch table.
+	if len(prices) != len(want) {
+		t.Fatalf("got %d models, want %d: %v", len(prices), len(want), prices)
+	}
+	for key, w := range want {
+		got, ok := prices[key]
+		if !ok {
+			t.Errorf("%s missing", key)
+			continue
+		}
+		if *got != w {
+			t.Errorf("%s = %+v, want %+v", key, *got, w)
+		}
+	}
+}
+
+func TestApplyPricing(t *testing.T) {
+	models := []adapter.DiscoveredModel{
+		{Name: "claude-sonnet-4-5", DisplayName: "Claude Sonnet 4.5", Cost: &adapter.Cost{InputPer1K: 3, OutputPer1K: 15}, DiscoveredBy: adapter.SourceAPI},
+		{Name: "claude-opus-4-1", DisplayName: "Claude Opus 4.1", DiscoveredBy: adapter.SourceAPI},
+	}
+	prices := map[string]*adapter.Cost{"claude sonnet 4.5": {InputPer1K: 3, OutputPer1K: 15, CacheWritePer1K: 3.75, CacheReadPer1K: 0.3}}
+	applyPricing(models, prices)
+
+	if c := models[0].Cost; c == nil || c.CacheWritePer1K != 3.75 || c.CacheReadPer1K != 0.3 {
+		t.Errorf("cost = %+v, want the pricing page's", c)
+	}
+	if models[0].FieldSources["cost"] != adapter.SourceDocs {
+		t.Errorf("field sources = %v, want cost from the docs", models[0].FieldSources)
+	}
+	models[0].Cost.InputPer1K = 1
+	if prices["claude sonnet 4.5"].InputPer1K != 3 {
+		t.Error("applyPricing should copy the price, not share it")
+	}
+	if models[1].Cost != nil {
+		t.Errorf("unlisted model got cost %+v", models[1].Cost)
+	}
+}
@@ -2,50 +2,50 @@ package bailing
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
-	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Bailing{})
 }
 
+// defaultBaseURL is where Bailing is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.tbox.cn/api/llm/v1"
+
 // Bailing adapter discovers models from the Bailing API (OpenAI-compatible).
 type Bailing struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (b *Bailing) Name() string { return "bailing" }
 
-func (b *Bailing) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/bailing/provider.yaml.
+func (b *Bailing) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Bailing",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (b *Bailing) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	b.apiKey = apiKey
-	b.baseURL = baseURL
-	b.client = client
+// ConfigSpec returns where the Bailing adapter's settings come from.
+func (b *Bailing) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "bailing",
+		APIKeyEnv: "BAILING_API_TOKEN",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (b *Bailing) HealthCheck(ctx context.Context) error {
-	url := b.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + b.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := b.client.Get(ctx, url, headers)
-	return err
+func (b *Bailing) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Bailing.
@@ -57,7 +57,7 @@ func (b *Bailing) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := b.discoverFromAPI(ctx)
+			apiModels, err := b.DiscoverAPI(ctx, b)
 			if err != nil {
 				return nil, fmt.Errorf("bailing API discovery: %w", err)
 			}
@@ -70,64 +70,20 @@ func (b *Bailing) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
+// Bailing lists one line of chat models, so every model gets the same family,
+// capabilities and limits.
+func (b *Bailing) InferFamily(string) string {
+	return "bailing"
 }
 
-func (b *Bailing) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := b.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + b.apiKey,
-	}
-
-	resp, err := b.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("bailing API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
+func (b *Bailing) InferCapabilities(id string) []string {
+	return b.ExplainCapabilities(id).Capabilities()
 }
 
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       "bailing",
-		Status:       "stable",
-		Capabilities: []string{"chat", "streaming"},
-		Limits:       adapter.Limits{MaxTokens: 32768, MaxCompletionTokens: 4096},
-		Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
-		DiscoveredBy: adapter.SourceAPI,
-	}
+func (b *Bailing) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(b.Name(), id)
 }
 
-func inferDisplayName(id string) string {
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+func (b *Bailing) InferLimits(string) adapter.Limits {
+	return adapter.Limits{MaxTokens: 32768, MaxCompletionTokens: 4096}
 }
@@ -2,50 +2,51 @@ package cerebras
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Cerebras{})
 }
 
+// defaultBaseURL is where Cerebras is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.cerebras.ai/v1"
+
 // Cerebras adapter discovers models from the Cerebras API (OpenAI-compatible).
 type Cerebras struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (c *Cerebras) Name() string { return "cerebras" }
 
-func (c *Cerebras) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/cerebras/provider.yaml.
+func (c *Cerebras) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Cerebras",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (c *Cerebras) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	c.apiKey = apiKey
-	c.baseURL = baseURL
-	c.client = client
+// ConfigSpec returns where the Cerebras adapter's settings come from.
+func (c *Cerebras) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "cerebras",
+		APIKeyEnv: "CEREBRAS_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (c *Cerebras) HealthCheck(ctx context.Context) error {
-	url := c.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + c.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := c.client.Get(ctx, url, headers)
-	return err
+func (c *Cerebras) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Cerebras.
@@ -57,7 +58,7 @@ func (c *Cerebras) Discover(ctx context.Context, opts adapter.DiscoverOptions) (
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := c.discoverFromAPI(ctx)
+			apiModels, err := c.DiscoverAPI(ctx, c)
 			if err != nil {
 				return nil, fmt.Errorf("cerebras API discovery: %w", err)
 			}
@@ -70,67 +71,11 @@ func (c *Cerebras) Discover(ctx context.Context, opts adapter.DiscoverOptions) (
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (c *Cerebras) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := c.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + c.apiKey,
-	}
-
-	resp, err := c.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("cerebras API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (c *Cerebras) ShouldSkip(id string) bool {
 	return strings.Contains(strings.ToLower(id), "embed")
 }
 
-func inferFamily(id string) string {
+func (c *Cerebras) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama"):
@@ -144,26 +89,15 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+func (c *Cerebras) InferCapabilities(id string) []string {
+	return c.ExplainCapabilities(id).Capabilities()
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "function_calling", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "vision") {
-		caps = append(caps, "vision")
-	}
-	return caps
+func (c *Cerebras) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(c.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (c *Cerebras) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama-3.3-70b"):
@@ -9,13 +9,19 @@ import (
 	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
 	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Cohere{})
 }
 
+// defaultBaseURL is where Cohere is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.cohere.com/v2"
+
 // Cohere adapter discovers models from the Cohere API.
 type Cohere struct {
 	apiKey  string
@@ -25,6 +31,25 @@ type Cohere struct {
 
 func (c *Cohere) Name() string { return "cohere" }
 
+// ProviderInfo returns the defaults for providers/cohere/provider.yaml.
+func (c *Cohere) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Cohere",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+		StatusPage:  "https://status.cohere.com",
+	}
+}
+
+// ConfigSpec returns where the Cohere adapter's settings come from.
+func (c *Cohere) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "cohere",
+		APIKeyEnv: "COHERE_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
+}
+
 func (c *Cohere) SupportedSources() []adapter.SourceType {
 	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
 }
@@ -65,7 +90,7 @@ func (c *Cohere) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]
 		case adapter.SourceDocs:
 			docModels, err := c.discoverFromDocs(ctx)
 			if err != nil {
-				slog.Warn("cohere docs discovery failed, continuing", "error", err)
+				adapter.Warn(ctx, "", "cohere docs discovery failed, continuing", "error", err)
 			} else {
 				models = append(models, docModels...)
 			}
@@ -134,36 +159,25 @@ func (c *Cohere) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel
 }
 
 func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am) {
-		return nil
-	}
-
 	family := inferFamily(am.Name)
-	capabilities := inferCapabilities(am)
 	modalities := inferModalities(am)
+	limits := adapter.Limits{MaxTokens: am.ContextLength}
+	if !isEmbedOnly(am) && !isRerankOnly(am) {
+		limits.MaxCompletionTokens = inferMaxCompletion(am.ContextLength)
+	}
 
-	return &adapter.DiscoveredModel{
+	m := &adapter.DiscoveredModel{
 		Name:         am.Name,
 		DisplayName:  inferDisplayName(am.Name),
 		Family:       family,
 		Status:       "stable",
-		Capabilities: capabilities,
-		Limits:       adapter.Limits{MaxTokens: am.ContextLength, MaxCompletionTokens: inferMaxCompletion(am.ContextLength)},
+		Limits:       limits,
 		Modalities:   modalities,
+		Embedding:    inferEmbedding(am),
 		DiscoveredBy: adapter.SourceAPI,
 	}
-}
-
-func shouldSkip(am apiModel) bool {
-	// Skip embedding-only models
-	if len(am.Endpoints) == 1 && am.Endpoints[0] == "embed" {
-		return true
-	}
-	// Skip rerank-only models
-	if len(am.Endpoints) == 1 && am.Endpoints[0] == "rerank" {
-		return true
-	}
-	return false
+	m.SetCapabilities(inferCapabilities(am))
+	return m
 }
 
 func inferFamily(name string) string {
@@ -177,52 +191,87 @@ func inferFamily(name string) string {
 		return "command-light"
 	case strings.HasPrefix(lower, "command"):
 		return "command"
+	case strings.HasPrefix(lower, "embed"):
+		return "embed"
+	case strings.HasPrefix(lower, "rerank"):
+		return "rerank"
 	default:
 		return "cohere-other"
 	}
 }
 
 func inferDisplayName(name string) string {
-	parts := strings.Split(name, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(name)
 }
 
-func inferCapabilities(am apiModel) []string {
-	caps := []string{}
+// inferCapabilities reads the endpoints a model serves.
+func inferCapabilities(am apiModel) *inference.Set {
+	var s inference.Set
 	for _, ep := range am.Endpoints {
+		detail := fmt.Sprintf("endpoints include %q", ep)
 		switch ep {
 		case "chat":
-			caps = append(caps, "chat")
+			s.Add("chat", inference.FromAPI, detail)
 		case "generate":
-			caps = append(caps, "completion")
+			s.Add("completion", inference.FromAPI, detail)
 		case "embed":
-			caps = append(caps, "embeddings")
+			s.Add("embeddings", inference.FromAPI, detail)
 		case "rerank":
-			caps = append(caps, "rerank")
+			s.Add("rerank", inference.FromAPI, detail)
 		}
 	}
 	// Cohere chat models support function calling and streaming
-	for _, c := range caps {
-		if c == "chat" {
-			caps = append(caps, "function_calling", "streaming")
-			break
-		}
+	if s.Has("chat") {
+		s.Add("function_calling", inference.FromDefault, "every chat model")
+		s.Add("streaming", inference.FromDefault, "every chat model")
 	}
-	return caps
+	return &s
 }
 
 func inferModalities(am apiModel) adapter.Modalities {
+	if isEmbedOnly(am) {
+		return adapter.Modalities{Input: []string{"text"}, Output: []string{"embedding"}}
+	}
+	if isRerankOnly(am) {
+		return adapter.Modalities{Input: []string{"text"}, Output: []string{"score"}}
+	}
 	return adapter.Modalities{
 		Input:  []string{"text"},
 		Output: []string{"text"},
 	}
 }
 
+func isEmbedOnly(am apiModel) bool {
+	return len(am.Endpoints) == 1 && am.Endpoints[0] == "embed"
+}
+
+func isRerankOnly(am apiModel) bool {
+	return len(am.Endpoints) == 1 && am.Endpoints[0] == "rerank"
+}
+
+// embeddingDimensions are the default output sizes of the embed models;
+// embed-v4.0 can also be asked for 256, 512 or 1024.
+var embeddingDimensions = map[string]int{
+	"embed-v4.0":                    1536,
+	"embed-english-v3.0":            1024,
+	"embed-multilingual-v3.0":       1024,
+	"embed-english-light-v3.0":      384,
+	"embed-multilingual-light-v3.0": 384,
+}
+
+// inferEmbedding describes an embed model's vectors. The embed endpoint
+// takes up to 96 texts per request.
+func inferEmbedding(am apiModel) *adapter.Embedding {
+	if !isEmbedOnly(am) {
+		return nil
+	}
+	return &adapter.Embedding{
+		Dimensions:   embeddingDimensions[am.Name],
+		MaxBatchSize: 96,
+		Similarity:   catalog.SimilarityCosine,
+	}
+}
+
 func inferMaxCompletion(contextLength int) int {
 	if contextLength >= 128000 {
 		return 4096
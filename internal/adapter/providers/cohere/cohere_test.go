@@ -0,0 +1,53 @@
+package cohere
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAPIModelToDiscovered(t *testing.T) {
+	tests := []struct {
+		name       string
+		am         apiModel
+		family     string
+		caps       string
+		output     string
+		dimensions int
+	}{
+		{"embed model", apiModel{Name: "embed-v4.0", Endpoints: []string{"embed"}, ContextLength: 128000}, "embed", "embeddings", "embedding", 1536},
+		{"light embed model", apiModel{Name: "embed-english-light-v3.0", Endpoints: []string{"embed"}, ContextLength: 512}, "embed", "embeddings", "embedding", 384},
+		{"unknown embed model", apiModel{Name: "embed-v9.0", Endpoints: []string{"embed"}}, "embed", "embeddings", "embedding", 0},
+		{"rerank model", apiModel{Name: "rerank-v3.5", Endpoints: []string{"rerank"}, ContextLength: 4096}, "rerank", "rerank", "score", 0},
+		{"chat model", apiModel{Name: "command-r-plus", Endpoints: []string{"chat", "generate"}, ContextLength: 128000}, "command-r-plus", "chat,completion,function_calling,streaming", "text", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := apiModelToDiscovered(tt.am)
+			if m.Family != tt.family {
+				t.Errorf("family = %q, want %q", m.Family, tt.family)
+			}
+			if got := strings.Join(m.Capabilities, ","); got != tt.caps {
+				t.Errorf("capabilities = %s, want %s", got, tt.caps)
+			}
+			if got := strings.Join(m.Modalities.Output, ","); got != tt.output {
+				t.Errorf("output modalities = %s, want %s", got, tt.output)
+			}
+			if tt.output != "embedding" {
+				if m.Embedding != nil {
+					t.Errorf("embedding = %+v, want nil", m.Embedding)
+				}
+				if tt.output == "text" && m.Limits.MaxCompletionTokens == 0 {
+					t.Error("chat model has no max_completion_tokens")
+				}
+				return
+			}
+			if m.Embedding == nil || m.Embedding.Dimensions != tt.dimensions || m.Embedding.MaxBatchSize != 96 {
+				t.Errorf("embedding = %+v, want dimensions %d and batch size 96", m.Embedding, tt.dimensions)
+			}
+			if m.Limits.MaxCompletionTokens != 0 {
+				t.Errorf("max_completion_tokens = %d, want 0", m.Limits.MaxCompletionTokens)
+			}
+		})
+	}
+}
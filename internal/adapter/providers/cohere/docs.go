@@ -19,7 +19,7 @@ var cohereModelPatterns = []*regexp.Regexp{
 
 // discoverFromDocs fetches the Cohere llms-full.txt and extracts model IDs.
 func (c *Cohere) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	content, err := llmstxt.Fetch(ctx, cohereLLMsTxtURL)
+	content, err := llmstxt.Fetch(ctx, c.client, cohereLLMsTxtURL)
 	if err != nil {
 		return nil, err
 	}
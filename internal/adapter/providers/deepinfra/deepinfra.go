@@ -2,50 +2,51 @@ package deepinfra
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&DeepInfra{})
 }
 
+// defaultBaseURL is where DeepInfra is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.deepinfra.com/v1/openai"
+
 // DeepInfra adapter discovers models from the DeepInfra API (OpenAI-compatible).
 type DeepInfra struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (d *DeepInfra) Name() string { return "deepinfra" }
 
-func (d *DeepInfra) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/deepinfra/provider.yaml.
+func (d *DeepInfra) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "DeepInfra",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (d *DeepInfra) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	d.apiKey = apiKey
-	d.baseURL = baseURL
-	d.client = client
+// ConfigSpec returns where the DeepInfra adapter's settings come from.
+func (d *DeepInfra) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "deepinfra",
+		APIKeyEnv: "DEEPINFRA_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (d *DeepInfra) HealthCheck(ctx context.Context) error {
-	url := d.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + d.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := d.client.Get(ctx, url, headers)
-	return err
+func (d *DeepInfra) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for DeepInfra.
@@ -57,7 +58,7 @@ func (d *DeepInfra) Discover(ctx context.Context, opts adapter.DiscoverOptions)
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := d.discoverFromAPI(ctx)
+			apiModels, err := d.DiscoverAPI(ctx, d)
 			if err != nil {
 				return nil, fmt.Errorf("deepinfra API discovery: %w", err)
 			}
@@ -70,63 +71,7 @@ func (d *DeepInfra) Discover(ctx context.Context, opts adapter.DiscoverOptions)
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (d *DeepInfra) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := d.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + d.apiKey,
-	}
-
-	resp, err := d.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("deepinfra API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (d *DeepInfra) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
@@ -134,15 +79,6 @@ func shouldSkip(id string) bool {
 	if strings.Contains(lower, "rerank") {
 		return true
 	}
-	if strings.Contains(lower, "whisper") {
-		return true
-	}
-	if strings.Contains(lower, "tts") {
-		return true
-	}
-	if strings.Contains(lower, "stable-diffusion") || strings.Contains(lower, "sdxl") {
-		return true
-	}
 	return false
 }
 
@@ -155,7 +91,7 @@ func stripOrg(id string) string {
 	return id
 }
 
-func inferFamily(id string) string {
+func (d *DeepInfra) InferFamily(id string) string {
 	model := strings.ToLower(stripOrg(id))
 	switch {
 	case strings.Contains(model, "llama-3.3"):
@@ -183,28 +119,20 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (d *DeepInfra) InferDisplayName(id string) string {
 	model := stripOrg(id)
-	parts := strings.Split(model, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(model)
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "vision") {
-		caps = append(caps, "vision")
-	}
-	caps = append(caps, "function_calling")
-	return caps
+func (d *DeepInfra) InferCapabilities(id string) []string {
+	return d.ExplainCapabilities(id).Capabilities()
+}
+
+func (d *DeepInfra) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(d.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (d *DeepInfra) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama-3.1") || strings.Contains(lower, "llama-3.3"):
@@ -216,7 +144,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (d *DeepInfra) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "vision") {
@@ -2,127 +2,88 @@ package deepseek
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&DeepSeek{})
 }
 
+// defaultBaseURL is where DeepSeek is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.deepseek.com"
+
 // DeepSeek adapter discovers models from the DeepSeek API (OpenAI-compatible).
 type DeepSeek struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (d *DeepSeek) Name() string { return "deepseek" }
 
-func (d *DeepSeek) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/deepseek/provider.yaml.
+func (d *DeepSeek) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "DeepSeek",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+		StatusPage:  "https://status.deepseek.com",
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (d *DeepSeek) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	d.apiKey = apiKey
-	d.baseURL = baseURL
-	d.client = client
+// ConfigSpec returns where the DeepSeek adapter's settings come from.
+func (d *DeepSeek) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "deepseek",
+		APIKeyEnv: "DEEPSEEK_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (d *DeepSeek) HealthCheck(ctx context.Context) error {
-	url := d.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + d.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := d.client.Get(ctx, url, headers)
-	return err
+func (d *DeepSeek) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
 }
 
 // MinExpectedModels returns the minimum model count for DeepSeek.
 func (d *DeepSeek) MinExpectedModels() int { return 2 }
 
 func (d *DeepSeek) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
-	var models []adapter.DiscoveredModel
+	var (
+		models []adapter.DiscoveredModel
+		docs   map[string]knownModel
+	)
 
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := d.discoverFromAPI(ctx)
+			apiModels, err := d.DiscoverAPI(ctx, d)
 			if err != nil {
 				return nil, fmt.Errorf("deepseek API discovery: %w", err)
 			}
 			models = append(models, apiModels...)
 		case adapter.SourceDocs:
-			slog.Debug("deepseek docs source not yet implemented")
+			// The docs only add limits and prices to models the API lists.
+			var err error
+			if docs, err = d.discoverFromDocs(ctx); err != nil {
+				adapter.Warn(ctx, "", "deepseek docs scraping failed, continuing with known limits and prices", "error", err)
+			}
 		}
 	}
 
-	return models, nil
-}
-
-// OpenAI-compatible /models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (d *DeepSeek) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := d.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + d.apiKey,
-	}
-
-	resp, err := d.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
+	for i := range models {
+		enrich(&models[i], docs)
 	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("deepseek API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
 	return models, nil
 }
 
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
+// OpenAI-compatible /models response.
 
-func inferFamily(id string) string {
+func (d *DeepSeek) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "reasoner"):
@@ -136,7 +97,7 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (d *DeepSeek) InferDisplayName(id string) string {
 	overrides := map[string]string{
 		"deepseek-chat":     "DeepSeek V3",
 		"deepseek-reasoner": "DeepSeek R1",
@@ -144,33 +105,19 @@ func inferDisplayName(id string) string {
 	if name, ok := overrides[id]; ok {
 		return name
 	}
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
 }
 
-func inferCapabilities(id string) []string {
-	lower := strings.ToLower(id)
-	caps := []string{"chat", "function_calling", "streaming"}
-	if strings.Contains(lower, "reasoner") {
-		caps = append(caps, "reasoning")
-	}
-	if strings.Contains(lower, "coder") {
-		caps = append(caps, "fill_in_middle")
-	}
-	return caps
+func (d *DeepSeek) InferCapabilities(id string) []string {
+	return d.ExplainCapabilities(id).Capabilities()
 }
 
-func inferLimits(id string) adapter.Limits {
-	lower := strings.ToLower(id)
-	switch {
-	case strings.Contains(lower, "reasoner"):
-		return adapter.Limits{MaxTokens: 64000, MaxCompletionTokens: 8192}
-	default:
-		return adapter.Limits{MaxTokens: 64000, MaxCompletionTokens: 8192}
-	}
+func (d *DeepSeek) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(d.Name(), id)
+}
+
+// InferLimits guesses the limits of models not in known, which enrich
+// fills in for the others.
+func (d *DeepSeek) InferLimits(id string) adapter.Limits {
+	return adapter.Limits{MaxTokens: 131072, MaxCompletionTokens: 8192}
 }
@@ -0,0 +1,164 @@
+package deepseek
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/htmlutil"
+)
+
+const deepseekPricingURL = "https://api-docs.deepseek.com/quick_start/pricing"
+
+// discoverFromDocs scrapes the limits and prices of the pricing page.
+func (d *DeepSeek) discoverFromDocs(ctx context.Context) (map[string]knownModel, error) {
+	doc, err := htmlutil.Fetch(ctx, d.Client, deepseekPricingURL)
+	if err != nil {
+		return nil, err
+	}
+	models := parsePricing(htmlutil.Tables(doc, "table"))
+	if len(models) == 0 {
+		adapter.Warn(ctx, "", "deepseek docs scraping: no models found (page layout may have changed)")
+	} else {
+		slog.Info("deepseek docs scraping complete", "models", len(models))
+	}
+	return models, nil
+}
+
+var (
+	tokenCountRe = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*([KM])?`)
+	maximumRe    = regexp.MustCompile(`(?i)maximum\W*(\d+(?:\.\d+)?\s*[KM]?)`)
+	dollarRe     = regexp.MustCompile(`\$\s*(\d+(?:\.\d+)?)`)
+	percentOffRe = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*%\s*off`)
+	windowRe     = regexp.MustCompile(`(\d{2}:\d{2})\s*[-–]\s*(\d{2}:\d{2})`)
+)
+
+// parsePricing reads the pricing table, which has one column per model ID
+// and a row per fact. Labels span several rows, so a row's values are its
+// last cells, one per model. Prices come in a standard group and, while
+// DeepSeek offers one, an off-peak group labelled with its UTC window.
+func parsePricing(tables [][][]string) map[string]knownModel {
+	models := make(map[string]knownModel)
+	for _, grid := range tables {
+		var ids []string
+		for _, c := range grid[0] {
+			if strings.HasPrefix(c, "deepseek-") {
+				ids = append(ids, c)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		standard := make([]tierPrices, len(ids))
+		offPeak := make([]tierPrices, len(ids))
+		limits := make([]adapter.Limits, len(ids))
+		group, start, end := standard, "", ""
+		for _, row := range grid[1:] {
+			if len(row) <= len(ids) {
+				continue
+			}
+			label := strings.ToLower(strings.Join(row[:len(row)-len(ids)], " "))
+			values := row[len(row)-len(ids):]
+			switch {
+			case strings.Contains(label, "discount") || strings.Contains(label, "off-peak"):
+				group = offPeak
+				if w := windowRe.FindStringSubmatch(label); w != nil {
+					start, end = w[1], w[2]
+				}
+			case strings.Contains(label, "standard"):
+				group = standard
+			}
+
+			for i, v := range values {
+				switch {
+				case strings.Contains(label, "context length"):
+					limits[i].MaxTokens = parseTokenCount(v)
+				case strings.Contains(label, "max output"):
+					if m := maximumRe.FindStringSubmatch(v); m != nil {
+						v = m[1]
+					}
+					limits[i].MaxCompletionTokens = parseTokenCount(v)
+				case strings.Contains(label, "cache hit"):
+					group[i].cached = parseDollars(v)
+				case strings.Contains(label, "input"):
+					group[i].input = parseDollars(v)
+				case strings.Contains(label, "output"):
+					group[i].output = parseDollars(v)
+				default:
+					continue
+				}
+				if m := percentOffRe.FindStringSubmatch(v); m != nil {
+					group[i].percentOff, _ = strconv.ParseFloat(m[1], 64)
+				}
+			}
+		}
+
+		for i, id := range ids {
+			k := knownModel{Limits: limits[i]}
+			if p := standard[i]; p.input > 0 && p.output > 0 {
+				k.Cost = &adapter.Cost{InputPer1K: p.input, CachedInputPer1K: p.cached, OutputPer1K: p.output, Unit: catalog.PriceUnitPer1M}
+				if strings.Contains(id, "reasoner") {
+					k.Cost.ReasoningPer1K = p.output
+				}
+				if discount := offPeakDiscount(p, offPeak[i]); discount > 0 && start != "" {
+					k.Cost.OffPeak = &adapter.OffPeak{Discount: discount, Start: start, End: end}
+				}
+			}
+			models[id] = k
+		}
+	}
+	return models
+}
+
+// tierPrices are a model's per-1M prices in one group of the table.
+type tierPrices struct {
+	input, cached, output float64
+	percentOff            float64 // as the page states it, "(50% OFF)"
+}
+
+// offPeakDiscount is the fraction off the standard prices: the one the page
+// states, or the one the off-peak output price works out to.
+func offPeakDiscount(standard, offPeak tierPrices) float64 {
+	if offPeak.percentOff > 0 {
+		return offPeak.percentOff / 100
+	}
+	if offPeak.output <= 0 || offPeak.output >= standard.output {
+		return 0
+	}
+	return math.Round((1-offPeak.output/standard.output)*100) / 100
+}
+
+// parseTokenCount reads counts like "128K" as the power-of-two sizes
+// DeepSeek enforces: 128K is 131072 tokens.
+func parseTokenCount(s string) int {
+	m := tokenCountRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0
+	}
+	switch strings.ToUpper(m[2]) {
+	case "K":
+		v *= 1 << 10
+	case "M":
+		v *= 1 << 20
+	}
+	return int(v)
+}
+
+func parseDollars(s string) float64 {
+	m := dollarRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(m[1], 64)
+	return v
+}
@@ -0,0 +1,93 @@
+package deepseek
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/htmlutil"
+)
+
+func TestParsePricing(t *testing.T) {
+	f, err := os.Open("testdata/pricing.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := parsePricing(htmlutil.Tables(doc, "table"))
+	want := map[string]knownModel{
+		"deepseek-chat": {
+			Limits: adapter.Limits{MaxTokens: 131072, MaxCompletionTokens: 8192},
+			Cost: &adapter.Cost{
+				InputPer1K: 0.27, CachedInputPer1K: 0.07, OutputPer1K: 1.10, Unit: catalog.PriceUnitPer1M,
+				OffPeak: &adapter.OffPeak{Discount: 0.5, Start: "16:30", End: "00:30"},
+			},
+		},
+		"deepseek-reasoner": {
+			Limits: adapter.Limits{MaxTokens: 131072, MaxCompletionTokens: 65536},
+			Cost: &adapter.Cost{
+				InputPer1K: 0.55, CachedInputPer1K: 0.14, OutputPer1K: 2.19, ReasoningPer1K: 2.19, Unit: catalog.PriceUnitPer1M,
+				OffPeak: &adapter.OffPeak{Discount: 0.75, Start: "16:30", End: "00:30"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePricing() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestOffPeakDiscount(t *testing.T) {
+	tests := []struct {
+		name              string
+		standard, offPeak tierPrices
+		want              float64
+	}{
+		{"stated", tierPrices{output: 2.19}, tierPrices{output: 0.55, percentOff: 75}, 0.75},
+		{"from the prices", tierPrices{output: 1.10}, tierPrices{output: 0.55}, 0.5},
+		{"none", tierPrices{output: 1.10}, tierPrices{}, 0},
+	}
+	for _, tt := range tests {
+		if got := offPeakDiscount(tt.standard, tt.offPeak); got != tt.want {
+			t.Errorf("%s: offPeakDiscount() = %g, want %g", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	guessed := func(name string) adapter.DiscoveredModel {
+		return adapter.DiscoveredModel{Name: name, Limits: adapter.Limits{MaxTokens: 131072, MaxCompletionTokens: 8192}, DiscoveredBy: adapter.SourceAPI}
+	}
+
+	// Without the docs, the known values apply.
+	m := guessed("deepseek-reasoner")
+	enrich(&m, nil)
+	if m.Limits.MaxCompletionTokens != 65536 || m.Cost == nil || m.Cost.ReasoningPer1K == 0 {
+		t.Errorf("model = %+v, want the known limits and prices", m)
+	}
+	if m.FieldSources["cost"] != adapter.SourceDocs || m.FieldSources["limits"] != adapter.SourceDocs {
+		t.Errorf("sources = %v, want cost and limits from the docs", m.FieldSources)
+	}
+
+	// The docs win over the known values, field by field.
+	docs := map[string]knownModel{"deepseek-chat": {Limits: adapter.Limits{MaxTokens: 65536}}}
+	m = guessed("deepseek-chat")
+	enrich(&m, docs)
+	if m.Limits.MaxTokens != 65536 || m.Limits.MaxCompletionTokens != 8192 || m.Cost.InputPer1K != known["deepseek-chat"].Cost.InputPer1K {
+		t.Errorf("model = %+v, want the docs' context length over the known values", m)
+	}
+
+	// Unknown models keep the guess.
+	m = guessed("deepseek-v4-preview")
+	enrich(&m, nil)
+	if m.Cost != nil || m.FieldSources != nil {
+		t.Errorf("model = %+v, want it untouched", m)
+	}
+}
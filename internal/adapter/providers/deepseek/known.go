@@ -0,0 +1,49 @@
+package deepseek
+
+import (
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+// knownModel is what DeepSeek publishes about a model and its API doesn't
+// return. Prices are per 1M tokens.
+type knownModel struct {
+	Limits adapter.Limits
+	Cost   *adapter.Cost
+}
+
+// known holds the context windows, output caps and prices from DeepSeek's
+// pricing page, for when the docs source is off or can't be read. Both
+// models run the same weights and share a price list; the reasoner bills
+// its thinking as output. The current list has no off-peak discount; the
+// docs source picks one up if DeepSeek brings it back.
+var known = map[string]knownModel{
+	"deepseek-chat": {
+		Limits: adapter.Limits{MaxTokens: 131072, MaxCompletionTokens: 8192},
+		Cost:   &adapter.Cost{InputPer1K: 0.28, CachedInputPer1K: 0.028, OutputPer1K: 0.42, Unit: catalog.PriceUnitPer1M},
+	},
+	"deepseek-reasoner": {
+		Limits: adapter.Limits{MaxTokens: 131072, MaxCompletionTokens: 65536},
+		Cost:   &adapter.Cost{InputPer1K: 0.28, CachedInputPer1K: 0.028, OutputPer1K: 0.42, ReasoningPer1K: 0.42, Unit: catalog.PriceUnitPer1M},
+	},
+}
+
+// enrich overlays what is known about m onto the API's listing: the known
+// values, then whatever the docs say, which are newer.
+func enrich(m *adapter.DiscoveredModel, docs map[string]knownModel) {
+	for _, k := range []knownModel{known[m.Name], docs[m.Name]} {
+		if k.Limits.MaxTokens > 0 {
+			m.Limits.MaxTokens = k.Limits.MaxTokens
+			m.SetFieldSource("limits", adapter.SourceDocs)
+		}
+		if k.Limits.MaxCompletionTokens > 0 {
+			m.Limits.MaxCompletionTokens = k.Limits.MaxCompletionTokens
+			m.SetFieldSource("limits", adapter.SourceDocs)
+		}
+		if k.Cost != nil {
+			c := *k.Cost
+			m.Cost = &c
+			m.SetFieldSource("cost", adapter.SourceDocs)
+		}
+	}
+}
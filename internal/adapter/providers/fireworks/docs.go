@@ -15,7 +15,7 @@ var fireworksModelRe = regexp.MustCompile(`(accounts/fireworks/models/[\w.-]+)`)
 
 // discoverFromDocs fetches the Fireworks llms-full.txt and extracts model IDs.
 func (f *Fireworks) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	content, err := llmstxt.Fetch(ctx, fireworksLLMsTxtURL)
+	content, err := llmstxt.Fetch(ctx, f.Client, fireworksLLMsTxtURL)
 	if err != nil {
 		return nil, err
 	}
@@ -24,7 +24,7 @@ func (f *Fireworks) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredM
 
 	var models []adapter.DiscoveredModel
 	for _, id := range ids {
-		if shouldSkip(id) {
+		if f.ShouldSkip(id) {
 			continue
 		}
 		models = append(models, adapter.DiscoveredModel{
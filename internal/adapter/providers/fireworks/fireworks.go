@@ -2,50 +2,50 @@ package fireworks
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Fireworks{})
 }
 
+// defaultBaseURL is where Fireworks AI is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.fireworks.ai/inference/v1"
+
 // Fireworks adapter discovers models from the Fireworks AI API (OpenAI-compatible).
 type Fireworks struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (f *Fireworks) Name() string { return "fireworks" }
 
-func (f *Fireworks) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
+// ProviderInfo returns the defaults for providers/fireworks/provider.yaml.
+func (f *Fireworks) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Fireworks AI",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (f *Fireworks) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	f.apiKey = apiKey
-	f.baseURL = baseURL
-	f.client = client
+// ConfigSpec returns where the Fireworks AI adapter's settings come from.
+func (f *Fireworks) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "fireworks",
+		APIKeyEnv: "FIREWORKS_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (f *Fireworks) HealthCheck(ctx context.Context) error {
-	url := f.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + f.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := f.client.Get(ctx, url, headers)
-	return err
+func (f *Fireworks) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
 }
 
 // MinExpectedModels returns the minimum model count for Fireworks.
@@ -57,7 +57,7 @@ func (f *Fireworks) Discover(ctx context.Context, opts adapter.DiscoverOptions)
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := f.discoverFromAPI(ctx)
+			apiModels, err := f.DiscoverAPI(ctx, f)
 			if err != nil {
 				return nil, fmt.Errorf("fireworks API discovery: %w", err)
 			}
@@ -65,7 +65,7 @@ func (f *Fireworks) Discover(ctx context.Context, opts adapter.DiscoverOptions)
 		case adapter.SourceDocs:
 			docModels, err := f.discoverFromDocs(ctx)
 			if err != nil {
-				slog.Warn("fireworks docs discovery failed, continuing", "error", err)
+				adapter.Warn(ctx, "", "fireworks docs discovery failed, continuing", "error", err)
 			} else {
 				models = append(models, docModels...)
 			}
@@ -75,63 +75,7 @@ func (f *Fireworks) Discover(ctx context.Context, opts adapter.DiscoverOptions)
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (f *Fireworks) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := f.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + f.apiKey,
-	}
-
-	resp, err := f.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("fireworks API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (f *Fireworks) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
@@ -139,12 +83,6 @@ func shouldSkip(id string) bool {
 	if strings.Contains(lower, "rerank") {
 		return true
 	}
-	if strings.Contains(lower, "whisper") {
-		return true
-	}
-	if strings.Contains(lower, "tts") {
-		return true
-	}
 	return false
 }
 
@@ -153,7 +91,7 @@ func stripPrefix(id string) string {
 	return strings.TrimPrefix(id, "accounts/fireworks/models/")
 }
 
-func inferFamily(id string) string {
+func (f *Fireworks) InferFamily(id string) string {
 	model := strings.ToLower(stripPrefix(id))
 	switch {
 	case strings.Contains(model, "llama-v3p3"):
@@ -179,28 +117,20 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (f *Fireworks) InferDisplayName(id string) string {
 	model := stripPrefix(id)
-	parts := strings.Split(model, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(model)
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "streaming"}
-	model := strings.ToLower(stripPrefix(id))
-	if strings.Contains(model, "vision") || strings.Contains(model, "11b-vision") {
-		caps = append(caps, "vision")
-	}
-	caps = append(caps, "function_calling")
-	return caps
+func (f *Fireworks) InferCapabilities(id string) []string {
+	return f.ExplainCapabilities(id).Capabilities()
+}
+
+func (f *Fireworks) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(f.Name(), stripPrefix(id))
 }
 
-func inferLimits(id string) adapter.Limits {
+func (f *Fireworks) InferLimits(id string) adapter.Limits {
 	model := strings.ToLower(stripPrefix(id))
 	switch {
 	case strings.Contains(model, "llama-v3p1") || strings.Contains(model, "llama-v3p3"):
@@ -212,7 +142,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (f *Fireworks) InferModalities(id string) adapter.Modalities {
 	model := strings.ToLower(stripPrefix(id))
 	input := []string{"text"}
 	if strings.Contains(model, "vision") {
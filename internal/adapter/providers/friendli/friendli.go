@@ -2,50 +2,51 @@ package friendli
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Friendli{})
 }
 
+// defaultBaseURL is where FriendliAI is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.friendli.ai/serverless/v1"
+
 // Friendli adapter discovers models from the Friendli API (OpenAI-compatible).
 type Friendli struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (f *Friendli) Name() string { return "friendli" }
 
-func (f *Friendli) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/friendli/provider.yaml.
+func (f *Friendli) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "FriendliAI",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (f *Friendli) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	f.apiKey = apiKey
-	f.baseURL = baseURL
-	f.client = client
+// ConfigSpec returns where the FriendliAI adapter's settings come from.
+func (f *Friendli) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "friendli",
+		APIKeyEnv: "FRIENDLI_TOKEN",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (f *Friendli) HealthCheck(ctx context.Context) error {
-	url := f.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + f.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := f.client.Get(ctx, url, headers)
-	return err
+func (f *Friendli) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Friendli.
@@ -57,7 +58,7 @@ func (f *Friendli) Discover(ctx context.Context, opts adapter.DiscoverOptions) (
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := f.discoverFromAPI(ctx)
+			apiModels, err := f.DiscoverAPI(ctx, f)
 			if err != nil {
 				return nil, fmt.Errorf("friendli API discovery: %w", err)
 			}
@@ -70,63 +71,7 @@ func (f *Friendli) Discover(ctx context.Context, opts adapter.DiscoverOptions) (
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (f *Friendli) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := f.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + f.apiKey,
-	}
-
-	resp, err := f.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("friendli API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (f *Friendli) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
@@ -137,7 +82,7 @@ func shouldSkip(id string) bool {
 	return false
 }
 
-func inferFamily(id string) string {
+func (f *Friendli) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama-3.3"):
@@ -163,27 +108,15 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+func (f *Friendli) InferCapabilities(id string) []string {
+	return f.ExplainCapabilities(id).Capabilities()
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "vision") || strings.Contains(lower, "vl") {
-		caps = append(caps, "vision")
-	}
-	caps = append(caps, "function_calling")
-	return caps
+func (f *Friendli) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(f.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (f *Friendli) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama-3.1") || strings.Contains(lower, "llama-3.3"):
@@ -193,7 +126,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (f *Friendli) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "vision") || strings.Contains(lower, "vl") {
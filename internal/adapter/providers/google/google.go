@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
 	"github.com/everstacklabs/sentinel/internal/httpclient"
 )
 
@@ -17,6 +18,10 @@ func init() {
 	adapter.Register(&Google{})
 }
 
+// defaultBaseURL is where Google is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
 // Google adapter discovers models from the Gemini API.
 type Google struct {
 	apiKey  string
@@ -26,6 +31,24 @@ type Google struct {
 
 func (g *Google) Name() string { return "google" }
 
+// ProviderInfo returns the defaults for providers/google/provider.yaml.
+func (g *Google) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Google",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthQuery,
+	}
+}
+
+// ConfigSpec returns where the Google adapter's settings come from.
+func (g *Google) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "google",
+		APIKeyEnv: "GEMINI_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
+}
+
 func (g *Google) SupportedSources() []adapter.SourceType {
 	return []adapter.SourceType{adapter.SourceAPI}
 }
@@ -61,7 +84,7 @@ func (g *Google) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]
 			}
 			models = append(models, apiModels...)
 		case adapter.SourceDocs:
-			slog.Warn("google docs source not implemented in Phase 1")
+			adapter.Warn(ctx, "", "google docs source not implemented in Phase 1")
 		}
 	}
 
@@ -203,13 +226,7 @@ func inferFamily(id string) string {
 }
 
 func inferDisplayName(id string) string {
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
 }
 
 func inferCapabilities(id string, methods []string) []string {
@@ -0,0 +1,131 @@
+package groq
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/htmlutil"
+)
+
+const groqRateLimitsURL = "https://console.groq.com/docs/rate-limits"
+
+// discoverRateLimits scrapes the published rate limits, keyed by model ID.
+func (g *Groq) discoverRateLimits(ctx context.Context) (map[string][]adapter.RateLimit, error) {
+	doc, err := htmlutil.Fetch(ctx, g.Client, groqRateLimitsURL)
+	if err != nil {
+		return nil, err
+	}
+	limits := parseRateLimits(doc)
+	if len(limits) == 0 {
+		adapter.Warn(ctx, "", "groq docs scraping: no rate limits found (page layout may have changed)")
+	} else {
+		slog.Info("groq rate limits scraping complete", "models", len(limits))
+	}
+	return limits, nil
+}
+
+// parseRateLimits reads every table with a model column and at least one
+// limit column. The nearest heading that names a tier ("Developer Tier")
+// labels the table; tables before any such heading are the "free" tier.
+func parseRateLimits(doc *goquery.Document) map[string][]adapter.RateLimit {
+	limits := make(map[string][]adapter.RateLimit)
+	tier := "free"
+	doc.Find("h1, h2, h3, h4, table").Each(func(_ int, s *goquery.Selection) {
+		if !s.Is("table") {
+			if t := tierName(s.Text()); t != "" {
+				tier = t
+			}
+			return
+		}
+
+		rows := s.Find("tr")
+		if rows.Length() < 2 {
+			return
+		}
+		var headers []string
+		rows.First().Find("th, td").Each(func(_ int, c *goquery.Selection) {
+			headers = append(headers, strings.ToLower(strings.TrimSpace(c.Text())))
+		})
+		modelCol := columnOf(headers, "model id", "model", "id")
+		if modelCol < 0 {
+			return
+		}
+		cols := map[string]int{
+			"rpm": columnOf(headers, "rpm", "requests per minute"),
+			"rpd": columnOf(headers, "rpd", "requests per day"),
+			"tpm": columnOf(headers, "tpm", "tokens per minute"),
+			"tpd": columnOf(headers, "tpd", "tokens per day"),
+		}
+		if cols["rpm"] < 0 && cols["rpd"] < 0 && cols["tpm"] < 0 && cols["tpd"] < 0 {
+			return
+		}
+
+		rows.Slice(1, rows.Length()).Each(func(_ int, row *goquery.Selection) {
+			var cells []string
+			row.Find("th, td").Each(func(_ int, c *goquery.Selection) {
+				cells = append(cells, strings.TrimSpace(c.Text()))
+			})
+			value := func(name string) int {
+				if i := cols[name]; i >= 0 && i < len(cells) {
+					return parseLimit(cells[i])
+				}
+				return 0
+			}
+			if modelCol >= len(cells) || cells[modelCol] == "" {
+				return
+			}
+			rl := adapter.RateLimit{Tier: tier, RPM: value("rpm"), RPD: value("rpd"), TPM: value("tpm"), TPD: value("tpd")}
+			if rl.RPM == 0 && rl.RPD == 0 && rl.TPM == 0 && rl.TPD == 0 {
+				return
+			}
+			id := cells[modelCol]
+			limits[id] = append(limits[id], rl)
+		})
+	})
+	return limits
+}
+
+// tierName turns a heading like "Developer Tier" into "developer". Headings
+// that don't name a tier yield "".
+func tierName(heading string) string {
+	fields := strings.Fields(strings.ToLower(heading))
+	for i, f := range fields {
+		if f == "tier" && i > 0 {
+			return strings.Join(fields[:i], "-")
+		}
+	}
+	return ""
+}
+
+func columnOf(headers []string, names ...string) int {
+	for _, name := range names {
+		for i, h := range headers {
+			if h == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseLimit reads counts like "14,400", "6K" or "1.5M". Dashes and
+// "No limit" are 0.
+func parseLimit(s string) int {
+	s = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), ",", ""))
+	scale := 1.0
+	switch {
+	case strings.HasSuffix(s, "K"):
+		scale, s = 1_000, strings.TrimSuffix(s, "K")
+	case strings.HasSuffix(s, "M"):
+		scale, s = 1_000_000, strings.TrimSuffix(s, "M")
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 {
+		return 0
+	}
+	return int(v * scale)
+}
@@ -0,0 +1,51 @@
+package groq
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/everstacklabs/sentinel/internal/adapter"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	f, err := os.Open("testdata/rate_limits.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := parseRateLimits(doc)
+	want := map[string][]adapter.RateLimit{
+		"llama-3.1-8b-instant": {
+			{Tier: "free", RPM: 30, RPD: 14400, TPM: 6000, TPD: 500000},
+			{Tier: "developer", RPM: 1000, RPD: 500000, TPM: 250000},
+		},
+		"llama-3.3-70b-versatile": {{Tier: "free", RPM: 30, RPD: 1000, TPM: 12000, TPD: 100000}},
+		"whisper-large-v3":        {{Tier: "free", RPM: 20, RPD: 2000}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseRateLimits() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := map[string]int{
+		"30":       30,
+		"14,400":   14400,
+		"14.4K":    14400,
+		"1.5M":     1500000,
+		"-":        0,
+		"No limit": 0,
+	}
+	for in, want := range tests {
+		if got := parseLimit(in); got != want {
+			t.Errorf("parseLimit(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
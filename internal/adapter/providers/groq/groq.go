@@ -6,53 +6,59 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Groq{})
 }
 
+// defaultBaseURL is where Groq is reached when the config sets no base_url.
+const defaultBaseURL = "https://api.groq.com/openai/v1"
+
 // Groq adapter discovers models from the Groq API (OpenAI-compatible).
 type Groq struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (g *Groq) Name() string { return "groq" }
 
-func (g *Groq) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/groq/provider.yaml.
+func (g *Groq) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Groq",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+		StatusPage:  "https://groqstatus.com",
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (g *Groq) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	g.apiKey = apiKey
-	g.baseURL = baseURL
-	g.client = client
+// ConfigSpec returns where the Groq adapter's settings come from.
+func (g *Groq) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "groq",
+		APIKeyEnv: "GROQ_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (g *Groq) HealthCheck(ctx context.Context) error {
-	url := g.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + g.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := g.client.Get(ctx, url, headers)
-	return err
+func (g *Groq) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
 }
 
 // MinExpectedModels returns the minimum model count for Groq.
 func (g *Groq) MinExpectedModels() int { return 5 }
 
 func (g *Groq) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
-	var models []adapter.DiscoveredModel
+	var (
+		models     []adapter.DiscoveredModel
+		rateLimits map[string][]adapter.RateLimit
+		prices     map[string]*adapter.Cost
+	)
 
 	for _, src := range opts.Sources {
 		switch src {
@@ -63,10 +69,29 @@ func (g *Groq) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]ad
 			}
 			models = append(models, apiModels...)
 		case adapter.SourceDocs:
-			slog.Debug("groq docs source not yet implemented")
+			// The docs only add rate limits and prices to models the API
+			// lists; the API has neither.
+			limits, err := g.discoverRateLimits(ctx)
+			if err != nil {
+				adapter.Warn(ctx, "", "groq rate limits scraping failed, continuing without them", "error", err)
+			}
+			rateLimits = limits
+			if prices, err = g.discoverPricing(ctx); err != nil {
+				adapter.Warn(ctx, "", "groq pricing scraping failed, continuing without prices", "error", err)
+			}
 		}
 	}
 
+	for i := range models {
+		if rl, ok := rateLimits[models[i].Name]; ok {
+			models[i].RateLimits = rl
+		}
+		if cost, ok := prices[pricingKey(models[i].Name)]; ok {
+			c := *cost
+			models[i].Cost = &c
+			models[i].SetFieldSource("cost", adapter.SourceDocs)
+		}
+	}
 	return models, nil
 }
 
@@ -85,12 +110,10 @@ type apiModel struct {
 }
 
 func (g *Groq) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := g.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + g.apiKey,
-	}
+	url := g.BaseURL + "/models"
+	headers := g.AuthHeaders()
 
-	resp, err := g.client.Get(ctx, url, headers)
+	resp, err := g.Client.Get(ctx, url, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +135,10 @@ func (g *Groq) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel,
 	return models, nil
 }
 
+// batchDiscount is what Groq's Batch API takes off on-demand prices. Every
+// chat model it serves is available in batch.
+const batchDiscount = 0.5
+
 func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
 	if shouldSkip(am) {
 		return nil
@@ -122,24 +149,29 @@ func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
 		contextWindow = 8192
 	}
 
-	return &adapter.DiscoveredModel{
+	m := &adapter.DiscoveredModel{
 		Name:         am.ID,
 		DisplayName:  inferDisplayName(am.ID),
 		Family:       inferFamily(am.ID),
 		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
 		Limits:       adapter.Limits{MaxTokens: contextWindow, MaxCompletionTokens: inferMaxCompletion(contextWindow)},
 		Modalities:   inferModalities(am.ID),
+		Batch:        &adapter.Batch{Supported: true, Discount: batchDiscount},
 		DiscoveredBy: adapter.SourceAPI,
 	}
+	m.SetCapabilities(inference.Infer("groq", am.ID))
+	if mt := adapter.InferMediaType(am.ID); mt != "" {
+		adapter.MediaModel(m, mt)
+	}
+	// Groq serves other labs' weights; record whose model it is.
+	if am.OwnedBy != "" {
+		m.XProvider = map[string]any{"owned_by": am.OwnedBy}
+	}
+	return m
 }
 
 func shouldSkip(am apiModel) bool {
 	lower := strings.ToLower(am.ID)
-	// Skip whisper/audio models
-	if strings.Contains(lower, "whisper") {
-		return true
-	}
 	// Skip embedding models
 	if strings.Contains(lower, "embed") {
 		return true
@@ -178,30 +210,12 @@ func inferFamily(id string) string {
 }
 
 func inferDisplayName(id string) string {
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
-}
-
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "vision") || strings.Contains(lower, "llama-3.2-11b") || strings.Contains(lower, "llama-3.2-90b") {
-		caps = append(caps, "vision")
-	}
-	// Most Groq chat models support function calling
-	caps = append(caps, "function_calling")
-	return caps
+	return adapter.TitleCase(id)
 }
 
 func inferModalities(id string) adapter.Modalities {
-	lower := strings.ToLower(id)
 	input := []string{"text"}
-	if strings.Contains(lower, "vision") || strings.Contains(lower, "llama-3.2-11b") || strings.Contains(lower, "llama-3.2-90b") {
+	if inference.Infer("groq", id).Has("vision") {
 		input = append(input, "image")
 	}
 	return adapter.Modalities{
@@ -0,0 +1,127 @@
+package groq
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/htmlutil"
+)
+
+const groqPricingURL = "https://groq.com/pricing"
+
+// discoverPricing scrapes the published on-demand token prices, keyed by
+// pricingKey of the model.
+func (g *Groq) discoverPricing(ctx context.Context) (map[string]*adapter.Cost, error) {
+	doc, err := htmlutil.Fetch(ctx, g.Client, groqPricingURL)
+	if err != nil {
+		return nil, err
+	}
+	prices := parsePricing(htmlutil.Tables(doc, "table"))
+	if len(prices) == 0 {
+		adapter.Warn(ctx, "", "groq docs scraping: no pricing found (page layout may have changed)")
+	} else {
+		slog.Info("groq pricing scraping complete", "models", len(prices))
+	}
+	return prices, nil
+}
+
+var (
+	dollarRe      = regexp.MustCompile(`\$\s*(\d[\d,]*(?:\.\d+)?|\.\d+)`)
+	contextSizeRe = regexp.MustCompile(`^\d+(\.\d+)?[km]$`)
+	expertsRe     = regexp.MustCompile(`(\d+b)x(\d+e)`)
+)
+
+// parsePricing reads every table with a model column and input and output
+// token price columns; tables priced per hour of audio or per character
+// have neither and are skipped. The page lists models by display name and
+// prices per million tokens.
+func parsePricing(tables [][][]string) map[string]*adapter.Cost {
+	prices := make(map[string]*adapter.Cost)
+	for _, grid := range tables {
+		if len(grid) < 2 {
+			continue
+		}
+		modelCol, inputCol, outputCol, cachedCol := -1, -1, -1, -1
+		for i, h := range grid[0] {
+			h = strings.ToLower(h)
+			switch {
+			case strings.Contains(h, "model") && modelCol < 0:
+				modelCol = i
+			case strings.Contains(h, "cached") && !strings.Contains(h, "uncached") && cachedCol < 0:
+				cachedCol = i
+			case strings.Contains(h, "input") && strings.Contains(h, "token") && inputCol < 0:
+				inputCol = i
+			case strings.Contains(h, "output") && strings.Contains(h, "token") && outputCol < 0:
+				outputCol = i
+			}
+		}
+		if modelCol < 0 || inputCol < 0 || outputCol < 0 {
+			continue
+		}
+
+		for _, row := range grid[1:] {
+			if modelCol >= len(row) {
+				continue
+			}
+			key := pricingKey(row[modelCol])
+			in, okIn := parseDollars(cell(row, inputCol))
+			out, okOut := parseDollars(cell(row, outputCol))
+			if key == "" || !okIn || !okOut {
+				continue
+			}
+			cost := &adapter.Cost{InputPer1K: in, OutputPer1K: out, Unit: catalog.PriceUnitPer1M}
+			if cached, ok := parseDollars(cell(row, cachedCol)); ok {
+				cost.CachedInputPer1K = cached
+			}
+			prices[key] = cost
+		}
+	}
+	return prices
+}
+
+// pricingKey reduces a pricing page name such as "Llama 4 Scout (17Bx16E)
+// 128k" and an API ID such as "meta-llama/llama-4-scout-17b-16e-instruct"
+// to the same key: no owner prefix, context size or "instruct", lowercase
+// and hyphenated.
+func pricingKey(name string) string {
+	name = strings.ToLower(name)
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.NewReplacer("(", " ", ")", " ", "-", " ", "_", " ").Replace(name)
+	var words []string
+	for _, w := range strings.Fields(name) {
+		if w == "instruct" {
+			continue
+		}
+		words = append(words, w)
+	}
+	// The page ends names with the context window.
+	if n := len(words); n > 1 && contextSizeRe.MatchString(words[n-1]) {
+		words = words[:n-1]
+	}
+	return expertsRe.ReplaceAllString(strings.Join(words, "-"), "$1-$2")
+}
+
+// parseDollars reads the first dollar amount in s, such as the 0.05 of
+// "$0.05 (20M / $1)".
+func parseDollars(s string) (float64, bool) {
+	m := dollarRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+	return v, err == nil
+}
+
+func cell(row []string, col int) string {
+	if col >= 0 && col < len(row) {
+		return row[col]
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package groq
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/htmlutil"
+)
+
+func TestParsePricing(t *testing.T) {
+	f, err := os.Open("testdata/pricing.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := parsePricing(htmlutil.Tables(doc, "table"))
+	per1M := func(in, out, cached float64) *adapter.Cost {
+		return &adapter.Cost{InputPer1K: in, OutputPer1K: out, CachedInputPer1K: cached, Unit: catalog.PriceUnitPer1M}
+	}
+	want := map[string]*adapter.Cost{
+		"gpt-oss-120b":            per1M(0.15, 0.60, 0),
+		"llama-4-scout-17b-16e":   per1M(0.11, 0.34, 0),
+		"llama-3.3-70b-versatile": per1M(0.59, 0.79, 0),
+		"llama-3.1-8b-instant":    per1M(0.05, 0.08, 0),
+		"kimi-k2-0905":            per1M(1.00, 3.00, 0.50),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePricing() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestPricingKey(t *testing.T) {
+	// Each API ID and the name the pricing page gives it share a key.
+	tests := map[string]string{
+		"openai/gpt-oss-120b":                       "GPT OSS 120B 128k",
+		"meta-llama/llama-4-scout-17b-16e-instruct": "Llama 4 Scout (17Bx16E) 128k",
+		"llama-3.1-8b-instant":                      "Llama 3.1 8B Instant 128k",
+		"moonshotai/kimi-k2-instruct-0905":          "Kimi K2 0905 256k",
+		"qwen/qwen3-32b":                            "Qwen3 32B 131k",
+	}
+	for id, name := range tests {
+		if a, b := pricingKey(id), pricingKey(name); a != b {
+			t.Errorf("pricingKey(%q) = %q, pricingKey(%q) = %q; want equal", id, a, name, b)
+		}
+	}
+}
@@ -2,50 +2,51 @@ package inception
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Inception{})
 }
 
+// defaultBaseURL is where Inception is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.inceptionlabs.ai/v1"
+
 // Inception adapter discovers models from the Inception Labs API (OpenAI-compatible).
 type Inception struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (i *Inception) Name() string { return "inception" }
 
-func (i *Inception) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/inception/provider.yaml.
+func (i *Inception) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Inception",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (i *Inception) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	i.apiKey = apiKey
-	i.baseURL = baseURL
-	i.client = client
+// ConfigSpec returns where the Inception adapter's settings come from.
+func (i *Inception) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "inception",
+		APIKeyEnv: "INCEPTION_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (i *Inception) HealthCheck(ctx context.Context) error {
-	url := i.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + i.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := i.client.Get(ctx, url, headers)
-	return err
+func (i *Inception) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Inception.
@@ -57,7 +58,7 @@ func (i *Inception) Discover(ctx context.Context, opts adapter.DiscoverOptions)
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := i.discoverFromAPI(ctx)
+			apiModels, err := i.DiscoverAPI(ctx, i)
 			if err != nil {
 				return nil, fmt.Errorf("inception API discovery: %w", err)
 			}
@@ -70,59 +71,12 @@ func (i *Inception) Discover(ctx context.Context, opts adapter.DiscoverOptions)
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (i *Inception) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := i.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + i.apiKey,
-	}
-
-	resp, err := i.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("inception API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       adapter.Limits{MaxTokens: 32768, MaxCompletionTokens: 8192},
-		Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
-		DiscoveredBy: adapter.SourceAPI,
-	}
+// Every Mercury model has the same context window.
+func (i *Inception) InferLimits(string) adapter.Limits {
+	return adapter.Limits{MaxTokens: 32768, MaxCompletionTokens: 8192}
 }
 
-func inferFamily(id string) string {
+func (i *Inception) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "mercury") {
 		return "mercury"
@@ -130,7 +84,7 @@ func inferFamily(id string) string {
 	return "inception-other"
 }
 
-func inferDisplayName(id string) string {
+func (i *Inception) InferDisplayName(id string) string {
 	overrides := map[string]string{
 		"mercury-coder-small": "Mercury Coder Small",
 		"mercury-coder-large": "Mercury Coder Large",
@@ -138,20 +92,13 @@ func inferDisplayName(id string) string {
 	if name, ok := overrides[id]; ok {
 		return name
 	}
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "coder") {
-		caps = append(caps, "fill_in_middle")
-	}
-	return caps
+func (i *Inception) InferCapabilities(id string) []string {
+	return i.ExplainCapabilities(id).Capabilities()
+}
+
+func (i *Inception) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(i.Name(), id)
 }
@@ -2,50 +2,51 @@ package llama
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Llama{})
 }
 
+// defaultBaseURL is where Llama API is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.llama.com/compat/v1"
+
 // Llama adapter discovers models from the Meta Llama API (OpenAI-compatible).
 type Llama struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (l *Llama) Name() string { return "llama" }
 
-func (l *Llama) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/llama/provider.yaml.
+func (l *Llama) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Llama API",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (l *Llama) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	l.apiKey = apiKey
-	l.baseURL = baseURL
-	l.client = client
+// ConfigSpec returns where the Llama API adapter's settings come from.
+func (l *Llama) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "llama",
+		APIKeyEnv: "LLAMA_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (l *Llama) HealthCheck(ctx context.Context) error {
-	url := l.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + l.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := l.client.Get(ctx, url, headers)
-	return err
+func (l *Llama) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Meta Llama.
@@ -57,7 +58,7 @@ func (l *Llama) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]a
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := l.discoverFromAPI(ctx)
+			apiModels, err := l.DiscoverAPI(ctx, l)
 			if err != nil {
 				return nil, fmt.Errorf("llama API discovery: %w", err)
 			}
@@ -70,63 +71,7 @@ func (l *Llama) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]a
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (l *Llama) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := l.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + l.apiKey,
-	}
-
-	resp, err := l.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("llama API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (l *Llama) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "guard") {
 		return true
@@ -137,7 +82,7 @@ func shouldSkip(id string) bool {
 	return false
 }
 
-func inferFamily(id string) string {
+func (l *Llama) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama-4"):
@@ -153,7 +98,7 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (l *Llama) InferDisplayName(id string) string {
 	overrides := map[string]string{
 		"Llama-4-Maverick-17B-128E": "Llama 4 Maverick 17B 128E",
 		"Llama-4-Scout-17B-16E":     "Llama 4 Scout 17B 16E",
@@ -162,25 +107,18 @@ func inferDisplayName(id string) string {
 	if name, ok := overrides[id]; ok {
 		return name
 	}
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "function_calling", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "vision") || strings.Contains(lower, "scout") || strings.Contains(lower, "maverick") {
-		caps = append(caps, "vision")
-	}
-	return caps
+func (l *Llama) InferCapabilities(id string) []string {
+	return l.ExplainCapabilities(id).Capabilities()
+}
+
+func (l *Llama) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(l.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (l *Llama) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama-4"):
@@ -192,7 +130,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (l *Llama) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "vision") || strings.Contains(lower, "scout") || strings.Contains(lower, "maverick") {
@@ -2,50 +2,51 @@ package minimax
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&MiniMax{})
 }
 
+// defaultBaseURL is where MiniMax is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.minimax.io/v1"
+
 // MiniMax adapter discovers models from the MiniMax API (OpenAI-compatible).
 type MiniMax struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (m *MiniMax) Name() string { return "minimax" }
 
-func (m *MiniMax) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/minimax/provider.yaml.
+func (m *MiniMax) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "MiniMax",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (m *MiniMax) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	m.apiKey = apiKey
-	m.baseURL = baseURL
-	m.client = client
+// ConfigSpec returns where the MiniMax adapter's settings come from.
+func (m *MiniMax) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "minimax",
+		APIKeyEnv: "MINIMAX_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (m *MiniMax) HealthCheck(ctx context.Context) error {
-	url := m.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + m.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := m.client.Get(ctx, url, headers)
-	return err
+func (m *MiniMax) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for MiniMax.
@@ -57,7 +58,7 @@ func (m *MiniMax) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := m.discoverFromAPI(ctx)
+			apiModels, err := m.DiscoverAPI(ctx, m)
 			if err != nil {
 				return nil, fmt.Errorf("minimax API discovery: %w", err)
 			}
@@ -70,74 +71,15 @@ func (m *MiniMax) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (m *MiniMax) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := m.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + m.apiKey,
-	}
-
-	resp, err := m.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("minimax API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (m *MiniMax) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
 	}
-	if strings.Contains(lower, "speech") || strings.Contains(lower, "tts") {
-		return true
-	}
 	return false
 }
 
-func inferFamily(id string) string {
+func (m *MiniMax) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "m2"):
@@ -149,7 +91,7 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (m *MiniMax) InferDisplayName(id string) string {
 	overrides := map[string]string{
 		"MiniMax-M1":   "MiniMax M1",
 		"MiniMax-M1-8": "MiniMax M1 8",
@@ -157,19 +99,17 @@ func inferDisplayName(id string) string {
 	if name, ok := overrides[id]; ok {
 		return name
 	}
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
+}
+
+func (m *MiniMax) InferCapabilities(id string) []string {
+	return m.ExplainCapabilities(id).Capabilities()
 }
 
-func inferCapabilities(id string) []string {
-	return []string{"chat", "function_calling", "streaming"}
+func (m *MiniMax) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(m.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (m *MiniMax) InferLimits(id string) adapter.Limits {
 	return adapter.Limits{MaxTokens: 1000000, MaxCompletionTokens: 8192}
 }
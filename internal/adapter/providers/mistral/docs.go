@@ -15,7 +15,7 @@ var mistralModelRe = regexp.MustCompile(`(mistral-[\w-]+)`)
 
 // discoverFromDocs fetches the Mistral llms-full.txt and extracts model IDs.
 func (m *Mistral) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	content, err := llmstxt.Fetch(ctx, mistralLLMsTxtURL)
+	content, err := llmstxt.Fetch(ctx, m.Client, mistralLLMsTxtURL)
 	if err != nil {
 		return nil, err
 	}
@@ -6,46 +6,48 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Mistral{})
 }
 
+// defaultBaseURL is where Mistral AI is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.mistral.ai/v1"
+
 // Mistral adapter discovers models from the Mistral AI API.
 type Mistral struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (m *Mistral) Name() string { return "mistral" }
 
-func (m *Mistral) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
+// ProviderInfo returns the defaults for providers/mistral/provider.yaml.
+func (m *Mistral) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Mistral AI",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (m *Mistral) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	m.apiKey = apiKey
-	m.baseURL = baseURL
-	m.client = client
+// ConfigSpec returns where the Mistral AI adapter's settings come from.
+func (m *Mistral) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "mistral",
+		APIKeyEnv: "MISTRAL_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (m *Mistral) HealthCheck(ctx context.Context) error {
-	url := m.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + m.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := m.client.Get(ctx, url, headers)
-	return err
+func (m *Mistral) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
 }
 
 // MinExpectedModels returns the minimum model count for Mistral.
@@ -65,7 +67,7 @@ func (m *Mistral) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 		case adapter.SourceDocs:
 			docModels, err := m.discoverFromDocs(ctx)
 			if err != nil {
-				slog.Warn("mistral docs discovery failed, continuing", "error", err)
+				adapter.Warn(ctx, "", "mistral docs discovery failed, continuing", "error", err)
 			} else {
 				models = append(models, docModels...)
 			}
@@ -103,13 +105,11 @@ type apiModel struct {
 }
 
 func (m *Mistral) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := m.baseURL + "/models"
+	url := m.BaseURL + "/models"
 
-	headers := map[string]string{
-		"Authorization": "Bearer " + m.apiKey,
-	}
+	headers := m.AuthHeaders()
 
-	resp, err := m.client.Get(ctx, url, headers)
+	resp, err := m.Client.Get(ctx, url, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +142,6 @@ func (m *Mistral) apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
 		displayName = inferDisplayName(am.ID)
 	}
 
-	capabilities := buildCapabilities(am.Capabilities)
 	modalities := inferModalities(am.Capabilities)
 
 	status := "stable"
@@ -150,16 +149,18 @@ func (m *Mistral) apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
 		status = "deprecated"
 	}
 
-	return &adapter.DiscoveredModel{
+	dm := &adapter.DiscoveredModel{
 		Name:         am.ID,
 		DisplayName:  displayName,
 		Family:       family,
 		Status:       status,
-		Capabilities: capabilities,
 		Limits:       adapter.Limits{MaxTokens: am.MaxContextLength, MaxCompletionTokens: inferMaxCompletion(am.ID, am.MaxContextLength)},
 		Modalities:   modalities,
+		FineTuning:   &adapter.FineTuning{Supported: am.Capabilities.FineTuning},
 		DiscoveredBy: adapter.SourceAPI,
 	}
+	dm.SetCapabilities(capabilitySet(am.Capabilities))
+	return dm
 }
 
 func shouldSkip(am apiModel) bool {
@@ -204,34 +205,29 @@ func inferFamily(id string) string {
 }
 
 func inferDisplayName(id string) string {
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
 }
 
-func buildCapabilities(caps apiModelCapabilities) []string {
-	var result []string
+// capabilitySet reads the capabilities block of the models API.
+func capabilitySet(caps apiModelCapabilities) *inference.Set {
+	var s inference.Set
 	if caps.CompletionChat {
-		result = append(result, "chat")
+		s.Add("chat", inference.FromAPI, "capabilities.completion_chat is true")
 	}
 	if caps.FunctionCalling {
-		result = append(result, "function_calling")
+		s.Add("function_calling", inference.FromAPI, "capabilities.function_calling is true")
 	}
 	if caps.Vision {
-		result = append(result, "vision")
+		s.Add("vision", inference.FromAPI, "capabilities.vision is true")
 	}
 	if caps.CompletionFIM {
-		result = append(result, "fill_in_middle")
+		s.Add("fill_in_middle", inference.FromAPI, "capabilities.completion_fim is true")
 	}
 	// All chat models support streaming
 	if caps.CompletionChat {
-		result = append(result, "streaming")
+		s.Add("streaming", inference.FromDefault, "every chat model streams")
 	}
-	return result
+	return &s
 }
 
 func inferModalities(caps apiModelCapabilities) adapter.Modalities {
@@ -58,7 +58,7 @@ func TestInferFamily(t *testing.T) {
 	}
 }
 
-func TestBuildCapabilities(t *testing.T) {
+func TestCapabilitySet(t *testing.T) {
 	tests := []struct {
 		name     string
 		caps     apiModelCapabilities
@@ -83,13 +83,13 @@ func TestBuildCapabilities(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := buildCapabilities(tt.caps)
+			got := capabilitySet(tt.caps).Capabilities()
 			if len(got) != len(tt.wantCaps) {
-				t.Fatalf("buildCapabilities() = %v, want %v", got, tt.wantCaps)
+				t.Fatalf("capabilitySet() = %v, want %v", got, tt.wantCaps)
 			}
 			for i, c := range got {
 				if c != tt.wantCaps[i] {
-					t.Errorf("buildCapabilities()[%d] = %q, want %q", i, c, tt.wantCaps[i])
+					t.Errorf("capabilitySet()[%d] = %q, want %q", i, c, tt.wantCaps[i])
 				}
 			}
 		})
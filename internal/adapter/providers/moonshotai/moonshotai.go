@@ -2,50 +2,51 @@ package moonshotai
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&MoonshotAI{})
 }
 
+// defaultBaseURL is where Moonshot AI is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.moonshot.ai/v1"
+
 // MoonshotAI adapter discovers models from the Moonshot AI (Kimi) API (OpenAI-compatible).
 type MoonshotAI struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (m *MoonshotAI) Name() string { return "moonshotai" }
 
-func (m *MoonshotAI) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/moonshotai/provider.yaml.
+func (m *MoonshotAI) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Moonshot AI",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (m *MoonshotAI) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	m.apiKey = apiKey
-	m.baseURL = baseURL
-	m.client = client
+// ConfigSpec returns where the Moonshot AI adapter's settings come from.
+func (m *MoonshotAI) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "moonshotai",
+		APIKeyEnv: "MOONSHOT_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (m *MoonshotAI) HealthCheck(ctx context.Context) error {
-	url := m.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + m.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := m.client.Get(ctx, url, headers)
-	return err
+func (m *MoonshotAI) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Moonshot AI.
@@ -57,7 +58,7 @@ func (m *MoonshotAI) Discover(ctx context.Context, opts adapter.DiscoverOptions)
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := m.discoverFromAPI(ctx)
+			apiModels, err := m.DiscoverAPI(ctx, m)
 			if err != nil {
 				return nil, fmt.Errorf("moonshotai API discovery: %w", err)
 			}
@@ -70,67 +71,11 @@ func (m *MoonshotAI) Discover(ctx context.Context, opts adapter.DiscoverOptions)
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (m *MoonshotAI) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := m.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + m.apiKey,
-	}
-
-	resp, err := m.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("moonshotai API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (m *MoonshotAI) ShouldSkip(id string) bool {
 	return strings.Contains(strings.ToLower(id), "embed")
 }
 
-func inferFamily(id string) string {
+func (m *MoonshotAI) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "kimi"):
@@ -142,7 +87,7 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (m *MoonshotAI) InferDisplayName(id string) string {
 	overrides := map[string]string{
 		"moonshot-v1-8k":    "Moonshot V1 8K",
 		"moonshot-v1-32k":   "Moonshot V1 32K",
@@ -152,20 +97,18 @@ func inferDisplayName(id string) string {
 	if name, ok := overrides[id]; ok {
 		return name
 	}
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
+}
+
+func (m *MoonshotAI) InferCapabilities(id string) []string {
+	return m.ExplainCapabilities(id).Capabilities()
 }
 
-func inferCapabilities(id string) []string {
-	return []string{"chat", "function_calling", "streaming"}
+func (m *MoonshotAI) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(m.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (m *MoonshotAI) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "128k"):
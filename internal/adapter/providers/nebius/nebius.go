@@ -2,50 +2,51 @@ package nebius
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Nebius{})
 }
 
+// defaultBaseURL is where Nebius is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.tokenfactory.nebius.com/v1"
+
 // Nebius adapter discovers models from the Nebius Token Factory API (OpenAI-compatible).
 type Nebius struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (n *Nebius) Name() string { return "nebius" }
 
-func (n *Nebius) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/nebius/provider.yaml.
+func (n *Nebius) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Nebius",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (n *Nebius) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	n.apiKey = apiKey
-	n.baseURL = baseURL
-	n.client = client
+// ConfigSpec returns where the Nebius adapter's settings come from.
+func (n *Nebius) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "nebius",
+		APIKeyEnv: "NEBIUS_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (n *Nebius) HealthCheck(ctx context.Context) error {
-	url := n.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + n.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := n.client.Get(ctx, url, headers)
-	return err
+func (n *Nebius) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Nebius.
@@ -57,7 +58,7 @@ func (n *Nebius) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := n.discoverFromAPI(ctx)
+			apiModels, err := n.DiscoverAPI(ctx, n)
 			if err != nil {
 				return nil, fmt.Errorf("nebius API discovery: %w", err)
 			}
@@ -70,63 +71,7 @@ func (n *Nebius) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (n *Nebius) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := n.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + n.apiKey,
-	}
-
-	resp, err := n.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("nebius API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (n *Nebius) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
@@ -134,15 +79,6 @@ func shouldSkip(id string) bool {
 	if strings.Contains(lower, "rerank") {
 		return true
 	}
-	if strings.Contains(lower, "whisper") {
-		return true
-	}
-	if strings.Contains(lower, "tts") || strings.Contains(lower, "speech") {
-		return true
-	}
-	if strings.Contains(lower, "stable-diffusion") || strings.Contains(lower, "sdxl") || strings.Contains(lower, "flux") {
-		return true
-	}
 	return false
 }
 
@@ -155,7 +91,7 @@ func stripOrg(id string) string {
 	return id
 }
 
-func inferFamily(id string) string {
+func (n *Nebius) InferFamily(id string) string {
 	model := strings.ToLower(stripOrg(id))
 	switch {
 	case strings.Contains(model, "llama-3.3"):
@@ -183,28 +119,20 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (n *Nebius) InferDisplayName(id string) string {
 	model := stripOrg(id)
-	parts := strings.Split(model, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(model)
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "vision") || strings.Contains(lower, "vl") {
-		caps = append(caps, "vision")
-	}
-	caps = append(caps, "function_calling")
-	return caps
+func (n *Nebius) InferCapabilities(id string) []string {
+	return n.ExplainCapabilities(id).Capabilities()
+}
+
+func (n *Nebius) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(n.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (n *Nebius) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama-3.1") || strings.Contains(lower, "llama-3.3"):
@@ -216,7 +144,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (n *Nebius) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "vision") || strings.Contains(lower, "vl") {
@@ -2,50 +2,51 @@ package nova
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Nova{})
 }
 
+// defaultBaseURL is where Amazon Nova is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.nova.amazon.com/v1"
+
 // Nova adapter discovers models from the Amazon Nova API (OpenAI-compatible).
 type Nova struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (n *Nova) Name() string { return "nova" }
 
-func (n *Nova) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/nova/provider.yaml.
+func (n *Nova) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Amazon Nova",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (n *Nova) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	n.apiKey = apiKey
-	n.baseURL = baseURL
-	n.client = client
+// ConfigSpec returns where the Amazon Nova adapter's settings come from.
+func (n *Nova) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "nova",
+		APIKeyEnv: "NOVA_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (n *Nova) HealthCheck(ctx context.Context) error {
-	url := n.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + n.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := n.client.Get(ctx, url, headers)
-	return err
+func (n *Nova) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Amazon Nova.
@@ -57,7 +58,7 @@ func (n *Nova) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]ad
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := n.discoverFromAPI(ctx)
+			apiModels, err := n.DiscoverAPI(ctx, n)
 			if err != nil {
 				return nil, fmt.Errorf("nova API discovery: %w", err)
 			}
@@ -70,63 +71,24 @@ func (n *Nova) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]ad
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
+// Every Nova model shares the family, capabilities and limits.
+func (n *Nova) InferFamily(string) string {
+	return "nova"
 }
 
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
+func (n *Nova) InferCapabilities(id string) []string {
+	return n.ExplainCapabilities(id).Capabilities()
 }
 
-func (n *Nova) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := n.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + n.apiKey,
-	}
-
-	resp, err := n.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("nova API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
+func (n *Nova) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(n.Name(), id)
 }
 
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       "nova",
-		Status:       "stable",
-		Capabilities: []string{"chat", "function_calling", "streaming"},
-		Limits:       adapter.Limits{MaxTokens: 300000, MaxCompletionTokens: 5120},
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
+func (n *Nova) InferLimits(string) adapter.Limits {
+	return adapter.Limits{MaxTokens: 300000, MaxCompletionTokens: 5120}
 }
 
-func shouldSkip(id string) bool {
+func (n *Nova) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
@@ -137,7 +99,7 @@ func shouldSkip(id string) bool {
 	return false
 }
 
-func inferDisplayName(id string) string {
+func (n *Nova) InferDisplayName(id string) string {
 	overrides := map[string]string{
 		"us.amazon.nova-pro-v1:0":   "Amazon Nova Pro",
 		"us.amazon.nova-lite-v1:0":  "Amazon Nova Lite",
@@ -146,16 +108,10 @@ func inferDisplayName(id string) string {
 	if name, ok := overrides[id]; ok {
 		return name
 	}
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (n *Nova) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "pro") || strings.Contains(lower, "lite") {
@@ -2,50 +2,51 @@ package novitaai
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&NovitaAI{})
 }
 
+// defaultBaseURL is where Novita AI is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.novita.ai/openai"
+
 // NovitaAI adapter discovers models from the Novita AI API (OpenAI-compatible).
 type NovitaAI struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (n *NovitaAI) Name() string { return "novitaai" }
 
-func (n *NovitaAI) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/novitaai/provider.yaml.
+func (n *NovitaAI) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Novita AI",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (n *NovitaAI) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	n.apiKey = apiKey
-	n.baseURL = baseURL
-	n.client = client
+// ConfigSpec returns where the Novita AI adapter's settings come from.
+func (n *NovitaAI) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "novitaai",
+		APIKeyEnv: "NOVITA_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (n *NovitaAI) HealthCheck(ctx context.Context) error {
-	url := n.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + n.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := n.client.Get(ctx, url, headers)
-	return err
+func (n *NovitaAI) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Novita AI.
@@ -57,7 +58,7 @@ func (n *NovitaAI) Discover(ctx context.Context, opts adapter.DiscoverOptions) (
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := n.discoverFromAPI(ctx)
+			apiModels, err := n.DiscoverAPI(ctx, n)
 			if err != nil {
 				return nil, fmt.Errorf("novitaai API discovery: %w", err)
 			}
@@ -70,63 +71,7 @@ func (n *NovitaAI) Discover(ctx context.Context, opts adapter.DiscoverOptions) (
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (n *NovitaAI) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := n.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + n.apiKey,
-	}
-
-	resp, err := n.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("novitaai API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (n *NovitaAI) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
@@ -134,12 +79,6 @@ func shouldSkip(id string) bool {
 	if strings.Contains(lower, "rerank") {
 		return true
 	}
-	if strings.Contains(lower, "whisper") || strings.Contains(lower, "tts") || strings.Contains(lower, "speech") {
-		return true
-	}
-	if strings.Contains(lower, "stable-diffusion") || strings.Contains(lower, "sdxl") || strings.Contains(lower, "flux") {
-		return true
-	}
 	return false
 }
 
@@ -152,7 +91,7 @@ func stripOrg(id string) string {
 	return id
 }
 
-func inferFamily(id string) string {
+func (n *NovitaAI) InferFamily(id string) string {
 	model := strings.ToLower(stripOrg(id))
 	switch {
 	case strings.Contains(model, "llama-3.3"):
@@ -178,28 +117,20 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (n *NovitaAI) InferDisplayName(id string) string {
 	model := stripOrg(id)
-	parts := strings.Split(model, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(model)
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "vision") || strings.Contains(lower, "vl") {
-		caps = append(caps, "vision")
-	}
-	caps = append(caps, "function_calling")
-	return caps
+func (n *NovitaAI) InferCapabilities(id string) []string {
+	return n.ExplainCapabilities(id).Capabilities()
+}
+
+func (n *NovitaAI) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(n.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (n *NovitaAI) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama-3.1") || strings.Contains(lower, "llama-3.3"):
@@ -211,7 +142,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (n *NovitaAI) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "vision") || strings.Contains(lower, "vl") {
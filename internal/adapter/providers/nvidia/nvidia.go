@@ -2,50 +2,51 @@ package nvidia
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&NVIDIA{})
 }
 
+// defaultBaseURL is where NVIDIA is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://integrate.api.nvidia.com/v1"
+
 // NVIDIA adapter discovers models from the NVIDIA NIM API (OpenAI-compatible).
 type NVIDIA struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (n *NVIDIA) Name() string { return "nvidia" }
 
-func (n *NVIDIA) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/nvidia/provider.yaml.
+func (n *NVIDIA) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "NVIDIA",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (n *NVIDIA) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	n.apiKey = apiKey
-	n.baseURL = baseURL
-	n.client = client
+// ConfigSpec returns where the NVIDIA adapter's settings come from.
+func (n *NVIDIA) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "nvidia",
+		APIKeyEnv: "NVIDIA_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (n *NVIDIA) HealthCheck(ctx context.Context) error {
-	url := n.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + n.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := n.client.Get(ctx, url, headers)
-	return err
+func (n *NVIDIA) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for NVIDIA.
@@ -57,7 +58,7 @@ func (n *NVIDIA) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := n.discoverFromAPI(ctx)
+			apiModels, err := n.DiscoverAPI(ctx, n)
 			if err != nil {
 				return nil, fmt.Errorf("nvidia API discovery: %w", err)
 			}
@@ -70,63 +71,7 @@ func (n *NVIDIA) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (n *NVIDIA) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := n.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + n.apiKey,
-	}
-
-	resp, err := n.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("nvidia API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (n *NVIDIA) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	// Skip non-chat models aggressively
 	if strings.Contains(lower, "embed") {
@@ -138,24 +83,12 @@ func shouldSkip(id string) bool {
 	if strings.Contains(lower, "nemo") && !strings.Contains(lower, "chat") && !strings.Contains(lower, "instruct") {
 		return true
 	}
-	if strings.Contains(lower, "tts") || strings.Contains(lower, "speech") {
-		return true
-	}
 	if strings.Contains(lower, "audio") && !strings.Contains(lower, "chat") {
 		return true
 	}
-	if strings.Contains(lower, "stable-diffusion") || strings.Contains(lower, "sdxl") {
-		return true
-	}
-	if strings.Contains(lower, "image") && !strings.Contains(lower, "vision") {
-		return true
-	}
 	if strings.Contains(lower, "video") {
 		return true
 	}
-	if strings.Contains(lower, "parakeet") || strings.Contains(lower, "canary") {
-		return true
-	}
 	if strings.Contains(lower, "grounding") || strings.Contains(lower, "segmentation") {
 		return true
 	}
@@ -174,7 +107,7 @@ func stripOrg(id string) string {
 	return id
 }
 
-func inferFamily(id string) string {
+func (n *NVIDIA) InferFamily(id string) string {
 	model := strings.ToLower(stripOrg(id))
 	switch {
 	case strings.Contains(model, "llama-3.3"):
@@ -204,28 +137,20 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (n *NVIDIA) InferDisplayName(id string) string {
 	model := stripOrg(id)
-	parts := strings.Split(model, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(model)
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "vision") || strings.Contains(lower, "vlm") {
-		caps = append(caps, "vision")
-	}
-	caps = append(caps, "function_calling")
-	return caps
+func (n *NVIDIA) InferCapabilities(id string) []string {
+	return n.ExplainCapabilities(id).Capabilities()
+}
+
+func (n *NVIDIA) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(n.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (n *NVIDIA) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama-3.1") || strings.Contains(lower, "llama-3.3"):
@@ -237,7 +162,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (n *NVIDIA) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "vision") || strings.Contains(lower, "vlm") {
@@ -3,6 +3,9 @@ package openai
 import (
 	"context"
 	"log/slog"
+	"math"
+	"sort"
+	"strings"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
 	"github.com/everstacklabs/sentinel/internal/htmlutil"
@@ -13,7 +16,7 @@ const openAIPricingURL = "https://openai.com/api/pricing/"
 // discoverFromDocs scrapes the OpenAI pricing page for cost data.
 // Returns partial models (cost only) that can supplement API discovery.
 func (o *OpenAI) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	doc, err := htmlutil.Fetch(ctx, openAIPricingURL)
+	doc, err := htmlutil.Fetch(ctx, o.Client, openAIPricingURL)
 	if err != nil {
 		return nil, err
 	}
@@ -43,8 +46,10 @@ func (o *OpenAI) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredMode
 		}
 	}
 
+	models = mergeFineTuning(models, parseFineTuningTables(htmlutil.Tables(doc, "table")))
+
 	if len(models) == 0 {
-		slog.Warn("openai docs scraping: no pricing data found (page may be JS-rendered)")
+		adapter.Warn(ctx, "", "openai docs scraping: no pricing data found (page may be JS-rendered)")
 	} else {
 		slog.Info("openai docs scraping complete", "models_with_pricing", len(models))
 	}
@@ -70,16 +75,53 @@ func parsePricingRow(row map[string]string) *adapter.DiscoveredModel {
 		return nil
 	}
 
+	cost := &adapter.Cost{
+		InputPer1K:     inputCost,
+		OutputPer1K:    outputCost,
+		ReasoningPer1K: parseReasoningPrice(row, name, outputCost),
+	}
+	if v, ok := htmlutil.ParsePriceDollars(firstNonEmpty(row, batchInputColumns...)); ok {
+		cost.BatchInputPer1K = v
+	}
+	if v, ok := htmlutil.ParsePriceDollars(firstNonEmpty(row, "batch output", "batch api output")); ok {
+		cost.BatchOutputPer1K = v
+	}
 	return &adapter.DiscoveredModel{
-		Name: name,
-		Cost: &adapter.Cost{
-			InputPer1K:  inputCost,
-			OutputPer1K: outputCost,
-		},
+		Name:         name,
+		Cost:         cost,
+		Batch:        parseBatchPricing(row, inputCost),
 		DiscoveredBy: adapter.SourceDocs,
 	}
 }
 
+// batchInputColumns are the headers of a pricing table's batch input
+// price; a lone "batch" column prices input.
+var batchInputColumns = []string{"batch input", "batch api input", "batch"}
+
+// parseReasoningPrice reads a row's reasoning token price. OpenAI bills
+// reasoning tokens as output, so a reasoning model without a column of its
+// own is priced at the output rate.
+func parseReasoningPrice(row map[string]string, name string, outputCost float64) float64 {
+	if v, ok := htmlutil.ParsePriceDollars(firstNonEmpty(row, "reasoning", "reasoning output", "thinking")); ok {
+		return v
+	}
+	if isReasoningModel(name) {
+		return outputCost
+	}
+	return 0
+}
+
+// parseBatchPricing derives the batch discount from a row's batch input
+// price, when the table has one.
+func parseBatchPricing(row map[string]string, inputCost float64) *adapter.Batch {
+	batchInput, ok := htmlutil.ParsePriceDollars(firstNonEmpty(row, batchInputColumns...))
+	if !ok || inputCost <= 0 || batchInput <= 0 || batchInput >= inputCost {
+		return nil
+	}
+	discount := math.Round((1-batchInput/inputCost)*100) / 100
+	return &adapter.Batch{Supported: true, Discount: discount}
+}
+
 func firstNonEmpty(m map[string]string, keys ...string) string {
 	for _, k := range keys {
 		if v := m[k]; v != "" {
@@ -88,3 +130,75 @@ func firstNonEmpty(m map[string]string, keys ...string) string {
 	}
 	return ""
 }
+
+// parseFineTuningTables reads the fine-tuning price tables: a model column
+// and a training price column, plus input and output prices for the
+// fine-tuned variants. Snapshot names are mapped to their alias.
+func parseFineTuningTables(tables [][][]string) map[string]*adapter.FineTuning {
+	out := make(map[string]*adapter.FineTuning)
+	for _, grid := range tables {
+		if len(grid) < 2 {
+			continue
+		}
+		col := make(map[string]int)
+		for i, h := range grid[0] {
+			h = strings.ToLower(strings.TrimSpace(h))
+			for _, key := range []string{"model", "training", "input", "output"} {
+				if _, seen := col[key]; !seen && strings.HasPrefix(h, key) {
+					col[key] = i
+				}
+			}
+		}
+		if _, ok := col["training"]; !ok {
+			continue
+		}
+		if _, ok := col["model"]; !ok {
+			continue
+		}
+
+		price := func(row []string, key string) float64 {
+			i, ok := col[key]
+			if !ok || i >= len(row) {
+				return 0
+			}
+			v, _ := htmlutil.ParsePriceDollars(row[i])
+			return v
+		}
+		for _, row := range grid[1:] {
+			if col["model"] >= len(row) {
+				continue
+			}
+			name := stripSnapshotDate(strings.TrimSpace(row[col["model"]]))
+			if name == "" || out[name] != nil {
+				continue
+			}
+			out[name] = &adapter.FineTuning{
+				Supported:     true,
+				TrainingPer1K: price(row, "training"),
+				InputPer1K:    price(row, "input"),
+				OutputPer1K:   price(row, "output"),
+			}
+		}
+	}
+	return out
+}
+
+// mergeFineTuning attaches fine-tuning data to the pricing entry of the
+// same model, adding an entry for models without one.
+func mergeFineTuning(models []adapter.DiscoveredModel, ft map[string]*adapter.FineTuning) []adapter.DiscoveredModel {
+	for i := range models {
+		if f, ok := ft[models[i].Name]; ok {
+			models[i].FineTuning = f
+			delete(ft, models[i].Name)
+		}
+	}
+	names := make([]string, 0, len(ft))
+	for name := range ft {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		models = append(models, adapter.DiscoveredModel{Name: name, FineTuning: ft[name], DiscoveredBy: adapter.SourceDocs})
+	}
+	return models
+}
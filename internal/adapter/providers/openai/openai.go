@@ -5,49 +5,77 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"regexp"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
 )
 
 func init() {
 	adapter.Register(&OpenAI{})
 }
 
+// defaultBaseURL is where OpenAI is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.openai.com/v1"
+
 // OpenAI adapter discovers models from the OpenAI API.
 type OpenAI struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
+
+	settings settings
+}
+
+// settings are the keys of the openai config section beyond api_key and
+// base_url.
+type settings struct {
+	IncludeFineTunable bool `mapstructure:"include_fine_tunable"`
+	FineTunedModels    bool `mapstructure:"fine_tuned_models"`
 }
 
 func (o *OpenAI) Name() string { return "openai" }
 
+// ProviderInfo returns the defaults for providers/openai/provider.yaml.
+func (o *OpenAI) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "OpenAI",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+		StatusPage:  "https://status.openai.com",
+	}
+}
+
+// ConfigSpec returns where the OpenAI adapter's settings come from.
+func (o *OpenAI) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "openai",
+		APIKeyEnv: "OPENAI_API_KEY",
+		BaseURL:   defaultBaseURL,
+		Settings:  &o.settings,
+	}
+}
+
 func (o *OpenAI) SupportedSources() []adapter.SourceType {
 	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (o *OpenAI) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	o.apiKey = apiKey
-	o.baseURL = baseURL
-	o.client = client
+// SetIncludeFineTunable keeps the legacy base models that exist only to be
+// fine-tuned (babbage-002, davinci-002), which discovery otherwise skips.
+func (o *OpenAI) SetIncludeFineTunable(include bool) {
+	o.settings.IncludeFineTunable = include
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (o *OpenAI) HealthCheck(ctx context.Context) error {
-	url := o.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + o.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := o.client.Get(ctx, url, headers)
-	return err
+// SetFineTunedModels catalogs the account's fine-tuned models under
+// catalog.FineTunedDir instead of skipping them.
+func (o *OpenAI) SetFineTunedModels(enabled bool) {
+	o.settings.FineTunedModels = enabled
 }
 
+// ListsFineTuned implements adapter.FineTunedLister.
+func (o *OpenAI) ListsFineTuned() bool { return o.settings.FineTunedModels }
+
 // MinExpectedModels returns the minimum model count for OpenAI.
 func (o *OpenAI) MinExpectedModels() int { return 8 }
 
@@ -65,7 +93,7 @@ func (o *OpenAI) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]
 		case adapter.SourceDocs:
 			docModels, err := o.discoverFromDocs(ctx)
 			if err != nil {
-				slog.Warn("openai docs scraping failed, continuing with API data", "error", err)
+				adapter.Warn(ctx, "", "openai docs scraping failed, continuing with API data", "error", err)
 			} else {
 				models = append(models, docModels...)
 			}
@@ -88,13 +116,11 @@ type apiModel struct {
 }
 
 func (o *OpenAI) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := o.baseURL + "/models"
+	url := o.BaseURL + "/models"
 
-	headers := map[string]string{
-		"Authorization": "Bearer " + o.apiKey,
-	}
+	headers := o.AuthHeaders()
 
-	resp, err := o.client.Get(ctx, url, headers)
+	resp, err := o.Client.Get(ctx, url, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -105,12 +131,23 @@ func (o *OpenAI) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel
 	}
 
 	var models []adapter.DiscoveredModel
+	tuned := make(map[string]bool)
 	for _, am := range modelsResp.Data {
+		if base, ok := fineTunedBase(am.ID); ok {
+			tuned[base] = true
+		}
 		m := o.apiModelToDiscovered(am)
 		if m != nil {
 			models = append(models, *m)
 		}
 	}
+	// The account's own fine-tuned models are skipped, but they prove their
+	// base model can be fine-tuned.
+	for i := range models {
+		if tuned[models[i].Name] && models[i].FineTuning == nil {
+			models[i].FineTuning = &adapter.FineTuning{Supported: true}
+		}
+	}
 
 	slog.Info("openai API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
 	return models, nil
@@ -121,6 +158,10 @@ func (o *OpenAI) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel
 func (o *OpenAI) apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
 	id := am.ID
 
+	if base, ok := fineTunedBase(id); ok && o.settings.FineTunedModels {
+		return fineTunedModel(am, base)
+	}
+
 	// Skip system/internal models
 	if o.shouldSkip(id) {
 		return nil
@@ -132,7 +173,7 @@ func (o *OpenAI) apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
 	modalities := inferModalities(id, capabilities)
 	limits := inferLimits(id, family)
 
-	return &adapter.DiscoveredModel{
+	m := &adapter.DiscoveredModel{
 		Name:         id,
 		DisplayName:  displayName,
 		Family:       family,
@@ -140,21 +181,39 @@ func (o *OpenAI) apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
 		Capabilities: capabilities,
 		Limits:       adapter.Limits(limits),
 		Modalities:   adapter.Modalities(modalities),
+		Batch:        inferBatch(id),
+		Embedding:    inferEmbedding(id),
+		ReleasedAt:   catalog.UnixDate(am.Created),
 		DiscoveredBy: adapter.SourceAPI,
 	}
+	if fineTunableBases[id] {
+		m.FineTuning = &adapter.FineTuning{Supported: true}
+	}
+	if mt := adapter.InferMediaType(id); mt != "" {
+		adapter.MediaModel(m, mt)
+		m.Family = mt
+	}
+	return m
 }
 
+// fineTunableBases are base models offered only for fine-tuning. They are
+// skipped unless SetIncludeFineTunable is on.
+var fineTunableBases = map[string]bool{"babbage-002": true, "davinci-002": true}
+
 func (o *OpenAI) shouldSkip(id string) bool {
 	// Skip fine-tuned models
 	if strings.HasPrefix(id, "ft:") {
 		return true
 	}
+	if fineTunableBases[id] {
+		return !o.settings.IncludeFineTunable
+	}
 	// Skip dated snapshots (e.g., gpt-4-0613) — keep only the base alias
 	if isDateSnapshot(id) {
 		return true
 	}
 	// Skip internal/system models
-	skipPrefixes := []string{"dall-e", "tts-", "whisper", "text-moderation", "babbage", "davinci", "curie", "ada-"}
+	skipPrefixes := []string{"text-moderation", "babbage", "davinci", "curie", "ada-"}
 	for _, prefix := range skipPrefixes {
 		if strings.HasPrefix(id, prefix) {
 			return true
@@ -163,6 +222,73 @@ func (o *OpenAI) shouldSkip(id string) bool {
 	return false
 }
 
+// fineTunedModel describes one of the account's fine-tuned models after its
+// base model. The catalog name can't be the ID, whose colons file systems
+// reject, so the ID is kept in x_provider for gateways to call it by.
+func fineTunedModel(am apiModel, base string) *adapter.DiscoveredModel {
+	// ft:<base>:<org>:<suffix>:<job id>, where the suffix may be empty.
+	parts := strings.Split(strings.TrimPrefix(am.ID, "ft:"), ":")
+	var label []string
+	for _, p := range parts[1:] {
+		if p != "" {
+			label = append(label, p)
+		}
+	}
+
+	family := inferFamily(base)
+	capabilities := inferCapabilities(base)
+	xp := map[string]any{"model_id": am.ID, "base_model": base}
+	if len(parts) > 1 && parts[1] != "" {
+		xp["organization"] = parts[1]
+	}
+	return &adapter.DiscoveredModel{
+		Name:         catalog.FineTunedDir + "/" + fineTunedFileName(parts),
+		DisplayName:  fmt.Sprintf("%s (fine-tuned %s)", inferDisplayName(base), strings.Join(label, " ")),
+		Family:       family,
+		Status:       "stable",
+		Capabilities: capabilities,
+		Limits:       inferLimits(base, family),
+		Modalities:   inferModalities(base, capabilities),
+		ReleasedAt:   catalog.UnixDate(am.Created),
+		XProvider:    xp,
+		DiscoveredBy: adapter.SourceAPI,
+	}
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// fineTunedFileName joins the non-empty parts of a fine-tuned model ID
+// with dashes, e.g. gpt-4o-mini-2024-07-18-acme-abc123.
+func fineTunedFileName(parts []string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = unsafeNameChars.ReplaceAllString(p, "-"); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, "-")
+}
+
+// fineTunedBase returns the catalog name of the base model of a fine-tuned
+// model ID like "ft:gpt-4o-mini-2024-07-18:acme::abc123".
+func fineTunedBase(id string) (string, bool) {
+	rest, ok := strings.CutPrefix(id, "ft:")
+	if !ok {
+		return "", false
+	}
+	base, _, _ := strings.Cut(rest, ":")
+	return stripSnapshotDate(base), base != ""
+}
+
+// snapshotDateRe matches the date suffix of a snapshot ID, as in
+// gpt-4o-2024-08-06 or gpt-3.5-turbo-0125.
+var snapshotDateRe = regexp.MustCompile(`-(\d{4}-\d{2}-\d{2}|\d{4})$`)
+
+// stripSnapshotDate maps a dated snapshot to the alias the catalog uses.
+func stripSnapshotDate(id string) string {
+	return snapshotDateRe.ReplaceAllString(id, "")
+}
+
 func isDateSnapshot(id string) bool {
 	// Pattern: any segment that looks like a date (MMDD or YYYYMMDD)
 	// e.g., gpt-4-0613, gpt-4-1106-preview, gpt-4o-2024-05-13, gpt-5-2025-08-07
@@ -277,13 +403,7 @@ func inferDisplayName(id string) string {
 	}
 
 	// Fallback: capitalize segments
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
 }
 
 func inferCapabilities(id string) []string {
@@ -305,9 +425,62 @@ func inferCapabilities(id string) []string {
 		caps = append(caps, "vision")
 	}
 
+	if isReasoningModel(id) {
+		caps = append(caps, "reasoning")
+		// The first o1 releases predate the reasoning_effort parameter.
+		if id != "o1-mini" && id != "o1-preview" {
+			caps = append(caps, "supports_reasoning_effort")
+		}
+	}
+
 	return caps
 }
 
+// isReasoningModel reports whether a model bills hidden reasoning tokens:
+// the o-series and GPT-5, except the non-reasoning gpt-5-chat variants.
+func isReasoningModel(id string) bool {
+	if len(id) > 1 && id[0] == 'o' && id[1] >= '1' && id[1] <= '9' {
+		return true
+	}
+	return strings.HasPrefix(id, "gpt-5") && !strings.Contains(id, "-chat")
+}
+
+// noBatchMarkers are model IDs the Batch API doesn't serve: realtime and
+// audio sessions, and search models that need a live request.
+var noBatchMarkers = []string{"realtime", "audio", "search", "transcribe", "tts"}
+
+// inferBatch reports batch support. The discount isn't in the API; the
+// pricing page fills it in when docs are scraped.
+func inferBatch(id string) *adapter.Batch {
+	for _, marker := range noBatchMarkers {
+		if strings.Contains(id, marker) {
+			return &adapter.Batch{Supported: false}
+		}
+	}
+	return &adapter.Batch{Supported: true}
+}
+
+// embeddingDimensions are the default output sizes of the embedding models.
+// The v3 models can be asked for fewer through the dimensions parameter.
+var embeddingDimensions = map[string]int{
+	"text-embedding-3-large": 3072,
+	"text-embedding-3-small": 1536,
+	"text-embedding-ada-002": 1536,
+}
+
+// inferEmbedding returns the embedding metadata of an embedding model. The
+// API takes up to 2048 inputs per request and returns normalized vectors.
+func inferEmbedding(id string) *adapter.Embedding {
+	if !strings.Contains(id, "embedding") {
+		return nil
+	}
+	return &adapter.Embedding{
+		Dimensions:   embeddingDimensions[id],
+		MaxBatchSize: 2048,
+		Similarity:   catalog.SimilarityCosine,
+	}
+}
+
 func inferModalities(id string, capabilities []string) adapter.Modalities {
 	for _, c := range capabilities {
 		if c == "embeddings" {
@@ -1,6 +1,7 @@
 package openai
 
 import (
+	"math"
 	"testing"
 )
 
@@ -19,10 +20,6 @@ func TestShouldSkip(t *testing.T) {
 		{"gpt-4o-2024-05-13", true},
 		{"gpt-5-2025-08-07", true},
 		{"gpt-4o-mini-2024-07-18", true},
-		{"dall-e-3", true},
-		{"tts-1", true},
-		{"tts-1-hd", true},
-		{"whisper-1", true},
 		{"text-moderation-latest", true},
 		{"babbage-002", true},
 		{"davinci-002", true},
@@ -41,6 +38,9 @@ func TestShouldSkip(t *testing.T) {
 		{"text-embedding-3-large", false},
 		{"gpt-5", false},
 		{"gpt-5.1-codex", false},
+		{"dall-e-3", false},
+		{"tts-1", false},
+		{"whisper-1", false},
 	}
 
 	for _, tt := range tests {
@@ -51,6 +51,58 @@ func TestShouldSkip(t *testing.T) {
 			}
 		})
 	}
+
+	o.SetIncludeFineTunable(true)
+	for _, id := range []string{"babbage-002", "davinci-002"} {
+		if o.shouldSkip(id) {
+			t.Errorf("shouldSkip(%q) with fine-tunable bases included = true, want false", id)
+		}
+	}
+	if !o.shouldSkip("curie-001") {
+		t.Error("including fine-tunable bases should not keep other legacy models")
+	}
+}
+
+func TestFineTunedBase(t *testing.T) {
+	tests := []struct {
+		id   string
+		base string
+		ok   bool
+	}{
+		{"ft:gpt-4o-mini-2024-07-18:acme::abc123", "gpt-4o-mini", true},
+		{"ft:gpt-3.5-turbo-0125:acme:suffix:id", "gpt-3.5-turbo", true},
+		{"ft:davinci-002:acme::id", "davinci-002", true},
+		{"gpt-4o", "", false},
+	}
+	for _, tt := range tests {
+		base, ok := fineTunedBase(tt.id)
+		if base != tt.base || ok != tt.ok {
+			t.Errorf("fineTunedBase(%q) = %q, %v, want %q, %v", tt.id, base, ok, tt.base, tt.ok)
+		}
+	}
+}
+
+func TestFineTunedModels(t *testing.T) {
+	o := &OpenAI{}
+	am := apiModel{ID: "ft:gpt-4o-mini-2024-07-18:acme:support-bot:abc123", Created: 1720000000}
+	if m := o.apiModelToDiscovered(am); m != nil {
+		t.Fatalf("fine-tuned model kept without SetFineTunedModels: %+v", m)
+	}
+
+	o.SetFineTunedModels(true)
+	m := o.apiModelToDiscovered(am)
+	if m == nil {
+		t.Fatal("fine-tuned model skipped")
+	}
+	if m.Name != "fine_tuned/gpt-4o-mini-2024-07-18-acme-support-bot-abc123" {
+		t.Errorf("Name = %q", m.Name)
+	}
+	if m.DisplayName != "GPT-4o Mini (fine-tuned acme support-bot abc123)" || m.Family != "gpt-4" {
+		t.Errorf("DisplayName = %q, Family = %q", m.DisplayName, m.Family)
+	}
+	if m.XProvider["model_id"] != am.ID || m.XProvider["base_model"] != "gpt-4o-mini" || m.XProvider["organization"] != "acme" {
+		t.Errorf("XProvider = %v", m.XProvider)
+	}
 }
 
 func TestIsDateSnapshot(t *testing.T) {
@@ -179,10 +231,12 @@ func TestInferCapabilities(t *testing.T) {
 		{"text-embedding-3-large", []string{"embeddings"}},
 		{"gpt-4o", []string{"chat", "function_calling", "vision"}},
 		{"gpt-4-turbo", []string{"chat", "function_calling", "vision"}},
-		{"gpt-5", []string{"chat", "function_calling", "vision"}},
+		{"gpt-5", []string{"chat", "function_calling", "vision", "reasoning", "supports_reasoning_effort"}},
+		{"gpt-5-chat-latest", []string{"chat", "function_calling", "vision"}},
 		{"gpt-4.1", []string{"chat", "function_calling", "vision"}},
 		{"gpt-3.5-turbo", []string{"chat", "function_calling"}},
-		{"o3", []string{"chat", "function_calling"}},
+		{"o3", []string{"chat", "function_calling", "reasoning", "supports_reasoning_effort"}},
+		{"o1-mini", []string{"chat", "function_calling", "reasoning"}},
 		{"gpt-3.5-turbo-instruct", []string{"chat"}},
 	}
 
@@ -264,3 +318,98 @@ func TestInferLimits(t *testing.T) {
 		})
 	}
 }
+
+func TestInferBatch(t *testing.T) {
+	tests := []struct {
+		id        string
+		supported bool
+	}{
+		{"gpt-4o", true},
+		{"o3", true},
+		{"text-embedding-3-small", true},
+		{"gpt-4o-realtime-preview", false},
+		{"gpt-4o-audio-preview", false},
+		{"gpt-4o-search-preview", false},
+	}
+	for _, tt := range tests {
+		b := inferBatch(tt.id)
+		if b == nil || b.Supported != tt.supported || b.Discount != 0 {
+			t.Errorf("inferBatch(%q) = %+v, want supported=%v without a discount", tt.id, b, tt.supported)
+		}
+	}
+}
+
+func TestInferEmbedding(t *testing.T) {
+	tests := []struct {
+		id         string
+		dimensions int
+	}{
+		{"text-embedding-3-large", 3072},
+		{"text-embedding-3-small", 1536},
+		{"text-embedding-ada-002", 1536},
+		{"text-embedding-4", 0},
+	}
+	for _, tt := range tests {
+		e := inferEmbedding(tt.id)
+		if e == nil || e.Dimensions != tt.dimensions || e.MaxBatchSize != 2048 || e.Similarity != "cosine" {
+			t.Errorf("inferEmbedding(%q) = %+v, want %d dimensions", tt.id, e, tt.dimensions)
+		}
+	}
+	if e := inferEmbedding("gpt-4o"); e != nil {
+		t.Errorf("inferEmbedding(gpt-4o) = %+v, want nil", e)
+	}
+}
+
+func TestParseBatchPricing(t *testing.T) {
+	row := map[string]string{"model": "gpt-4o", "input": "$2.50 / 1M tokens", "output": "$10.00 / 1M tokens", "batch input": "$1.25 / 1M tokens", "batch output": "$5.00 / 1M tokens"}
+	m := parsePricingRow(row)
+	if m == nil || m.Batch == nil || !m.Batch.Supported || m.Batch.Discount != 0.5 {
+		t.Fatalf("batch = %+v, want supported at 0.5", m)
+	}
+	if m.Cost.BatchInputPer1K != 0.00125 || m.Cost.BatchOutputPer1K != 0.005 {
+		t.Errorf("cost = %+v, want batch prices 0.00125/0.005 per 1K", m.Cost)
+	}
+
+	delete(row, "batch input")
+	delete(row, "batch output")
+	if m := parsePricingRow(row); m.Batch != nil || m.Cost.BatchInputPer1K != 0 || m.Cost.BatchOutputPer1K != 0 {
+		t.Errorf("row without batch pricing should leave batch unknown, got %+v %+v", m.Batch, m.Cost)
+	}
+}
+
+func TestParseFineTuningTables(t *testing.T) {
+	tables := [][][]string{
+		{{"Model", "Input", "Output"}, {"gpt-4o", "$2.50", "$10.00"}},
+		{
+			{"Model", "Training", "Input", "Output"},
+			{"gpt-4o-2024-08-06", "$25.00 / 1M tokens", "$3.75 / 1M tokens", "$15.00 / 1M tokens"},
+			{"gpt-4o-mini-2024-07-18", "$3.00 / 1M tokens", "$0.30 / 1M tokens", "$1.20 / 1M tokens"},
+		},
+	}
+	ft := parseFineTuningTables(tables)
+	if len(ft) != 2 {
+		t.Fatalf("got %d models, want 2 (the pricing table has no training column): %v", len(ft), ft)
+	}
+	f := ft["gpt-4o"]
+	if f == nil || !f.Supported || f.TrainingPer1K != 0.025 || f.InputPer1K != 0.00375 || f.OutputPer1K != 0.015 {
+		t.Errorf("gpt-4o fine-tuning = %+v", f)
+	}
+}
+
+func TestParseReasoningPrice(t *testing.T) {
+	tests := []struct {
+		name string
+		row  map[string]string
+		want float64
+	}{
+		{"o3", map[string]string{"model": "o3", "input": "$2.00 / 1M tokens", "output": "$8.00 / 1M tokens"}, 0.008},
+		{"gpt-4o", map[string]string{"model": "gpt-4o", "input": "$2.50 / 1M tokens", "output": "$10.00 / 1M tokens"}, 0},
+		{"own column", map[string]string{"model": "x-think", "input": "$1.00 / 1M tokens", "output": "$4.00 / 1M tokens", "reasoning": "$3.00 / 1M tokens"}, 0.003},
+	}
+	for _, tt := range tests {
+		m := parsePricingRow(tt.row)
+		if m == nil || math.Abs(m.Cost.ReasoningPer1K-tt.want) > 1e-12 {
+			t.Errorf("%s: reasoning price = %+v, want %g", tt.name, m, tt.want)
+		}
+	}
+}
@@ -8,12 +8,13 @@ import (
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
 	"github.com/everstacklabs/sentinel/internal/htmlutil"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 const perplexityModelsURL = "https://docs.perplexity.ai/getting-started/models"
 
 func (p *Perplexity) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	doc, err := htmlutil.Fetch(ctx, perplexityModelsURL)
+	doc, err := htmlutil.Fetch(ctx, p.Client, perplexityModelsURL)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +44,7 @@ func (p *Perplexity) discoverFromDocs(ctx context.Context) ([]adapter.Discovered
 	}
 
 	if len(models) == 0 {
-		slog.Warn("perplexity docs scraping: no model data found (page may be JS-rendered)")
+		adapter.Warn(ctx, "", "perplexity docs scraping: no model data found (page may be JS-rendered)")
 	} else {
 		slog.Info("perplexity docs scraping complete", "models", len(models))
 	}
@@ -62,10 +63,10 @@ func parseModelRow(row map[string]string) *adapter.DiscoveredModel {
 		DisplayName:  inferDisplayName(name),
 		Family:       inferFamily(name),
 		Status:       "stable",
-		Capabilities: inferCapabilities(name),
 		Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
 		DiscoveredBy: adapter.SourceDocs,
 	}
+	m.SetCapabilities(inference.Infer("perplexity", name))
 
 	// Try to extract context window from docs.
 	contextStr := firstNonEmpty(row, "context length", "context window", "context", "max tokens")
@@ -105,26 +106,7 @@ func inferFamily(id string) string {
 }
 
 func inferDisplayName(id string) string {
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
-}
-
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "reasoning") {
-		caps = append(caps, "reasoning")
-	}
-	// All Sonar models have search/citation capability.
-	if strings.Contains(lower, "sonar") {
-		caps = append(caps, "search")
-	}
-	return caps
+	return adapter.TitleCase(id)
 }
 
 // parseTokenCount tries to extract a token count from strings like "127k", "128,000", "200000".
@@ -4,32 +4,69 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Perplexity{})
 }
 
-// Perplexity adapter discovers models from Perplexity's documentation.
-// Perplexity does not expose a public /models API endpoint.
+// defaultBaseURL is where Perplexity is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.perplexity.ai"
+
+// Perplexity adapter discovers models from Perplexity's OpenAI-compatible
+// API and its documentation. The API lists model IDs only; context windows
+// and prices come from the docs, which need no API key.
 type Perplexity struct {
-	client *httpclient.Client
+	openaicompat.Base
 }
 
 func (p *Perplexity) Name() string { return "perplexity" }
 
+// ProviderInfo returns the defaults for providers/perplexity/provider.yaml.
+func (p *Perplexity) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Perplexity",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
+}
+
+// ConfigSpec returns where the Perplexity adapter's settings come from.
+func (p *Perplexity) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:         "perplexity",
+		APIKeyEnv:   "PERPLEXITY_API_KEY",
+		BaseURL:     defaultBaseURL,
+		KeyOptional: true,
+	}
+}
+
 func (p *Perplexity) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceDocs}
+	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
 }
 
-// Configure sets up the adapter with an HTTP client. No API key needed.
-func (p *Perplexity) Configure(client *httpclient.Client) {
-	p.client = client
+// HealthCheck probes the models endpoint, or without an API key the models
+// documentation page, the only source left.
+func (p *Perplexity) HealthCheck(ctx context.Context) error {
+	if p.APIKey != "" {
+		return p.Base.HealthCheck(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := p.Client.Get(ctx, perplexityModelsURL, nil)
+	return err
 }
 
+// MinExpectedModels returns the minimum model count for Perplexity.
+func (p *Perplexity) MinExpectedModels() int { return 3 }
+
 func (p *Perplexity) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
 	var models []adapter.DiscoveredModel
 
@@ -42,9 +79,31 @@ func (p *Perplexity) Discover(ctx context.Context, opts adapter.DiscoverOptions)
 			}
 			models = append(models, docModels...)
 		case adapter.SourceAPI:
-			slog.Debug("perplexity has no models API, skipping API source")
+			if p.APIKey == "" {
+				slog.Info("perplexity API key not set, skipping API source")
+				continue
+			}
+			apiModels, err := p.DiscoverAPI(ctx, p)
+			if err != nil {
+				return nil, fmt.Errorf("perplexity API discovery: %w", err)
+			}
+			models = append(models, apiModels...)
 		}
 	}
 
 	return models, nil
 }
+
+func (p *Perplexity) InferFamily(id string) string { return inferFamily(id) }
+
+func (p *Perplexity) InferCapabilities(id string) []string {
+	return p.ExplainCapabilities(id).Capabilities()
+}
+
+func (p *Perplexity) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(p.Name(), id)
+}
+
+// InferLimits leaves the limits unknown: the API doesn't report them, and
+// the docs entry for the model fills them in during deduplication.
+func (p *Perplexity) InferLimits(id string) adapter.Limits { return adapter.Limits{} }
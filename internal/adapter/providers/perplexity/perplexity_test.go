@@ -0,0 +1,68 @@
+package perplexity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+)
+
+func TestDiscoverAPI(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/models" || r.Header.Get("Authorization") != "Bearer k" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"sonar-pro"},{"id":"sonar-reasoning-pro"}]}`))
+	}))
+	defer srv.Close()
+	client := httpclient.New(httpclient.WithRateLimit(1000), httpclient.WithNoCache(), httpclient.WithMaxRetries(0))
+	opts := adapter.DiscoverOptions{Sources: []adapter.SourceType{adapter.SourceAPI}}
+
+	p := &Perplexity{}
+	p.Configure("", srv.URL, client)
+	if models, err := p.Discover(context.Background(), opts); err != nil || len(models) != 0 || requests != 0 {
+		t.Fatalf("without a key: got %d models, %d requests, err %v; want the API skipped", len(models), requests, err)
+	}
+
+	p.Configure("k", srv.URL, client)
+	models, err := p.Discover(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("got %d models, want 2", len(models))
+	}
+	m := models[1]
+	if m.Family != "sonar-reasoning" || m.DiscoveredBy != adapter.SourceAPI || m.Limits.MaxTokens != 0 {
+		t.Errorf("got %+v, want an API model with its limits left to the docs", m)
+	}
+}
+
+// The API listing and the docs infer capabilities the same way, search
+// before reasoning, so a model found by both keeps one order.
+func TestInferCapabilities(t *testing.T) {
+	tests := []struct {
+		id   string
+		want []string
+	}{
+		{"sonar", []string{"chat", "streaming", "search"}},
+		{"sonar-reasoning-pro", []string{"chat", "streaming", "search", "reasoning"}},
+		{"r1-1776", []string{"chat", "streaming"}},
+	}
+	p := &Perplexity{}
+	for _, tt := range tests {
+		if got := p.InferCapabilities(tt.id); !slices.Equal(got, tt.want) {
+			t.Errorf("InferCapabilities(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+		if got := parseModelRow(map[string]string{"model": tt.id}).Capabilities; !slices.Equal(got, tt.want) {
+			t.Errorf("docs capabilities of %q = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
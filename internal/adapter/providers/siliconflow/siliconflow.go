@@ -2,50 +2,51 @@ package siliconflow
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&SiliconFlow{})
 }
 
+// defaultBaseURL is where SiliconFlow is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.siliconflow.com/v1"
+
 // SiliconFlow adapter discovers models from the SiliconFlow API (OpenAI-compatible).
 type SiliconFlow struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (s *SiliconFlow) Name() string { return "siliconflow" }
 
-func (s *SiliconFlow) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/siliconflow/provider.yaml.
+func (s *SiliconFlow) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "SiliconFlow",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (s *SiliconFlow) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	s.apiKey = apiKey
-	s.baseURL = baseURL
-	s.client = client
+// ConfigSpec returns where the SiliconFlow adapter's settings come from.
+func (s *SiliconFlow) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "siliconflow",
+		APIKeyEnv: "SILICONFLOW_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (s *SiliconFlow) HealthCheck(ctx context.Context) error {
-	url := s.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + s.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := s.client.Get(ctx, url, headers)
-	return err
+func (s *SiliconFlow) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for SiliconFlow.
@@ -57,7 +58,7 @@ func (s *SiliconFlow) Discover(ctx context.Context, opts adapter.DiscoverOptions
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := s.discoverFromAPI(ctx)
+			apiModels, err := s.DiscoverAPI(ctx, s)
 			if err != nil {
 				return nil, fmt.Errorf("siliconflow API discovery: %w", err)
 			}
@@ -70,77 +71,19 @@ func (s *SiliconFlow) Discover(ctx context.Context, opts adapter.DiscoverOptions
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (s *SiliconFlow) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := s.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + s.apiKey,
-	}
-
-	resp, err := s.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("siliconflow API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
+// isEmbedding reports whether id is an embedding model. The BGE models
+// don't say so in their names.
+func isEmbedding(id string) bool {
+	model := strings.ToLower(stripOrg(id))
+	if isRerank(id) {
+		return false
 	}
+	return strings.Contains(model, "embed") || strings.HasPrefix(model, "bge-")
 }
 
-func shouldSkip(id string) bool {
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "embed") {
-		return true
-	}
-	if strings.Contains(lower, "rerank") {
-		return true
-	}
-	if strings.Contains(lower, "whisper") || strings.Contains(lower, "tts") || strings.Contains(lower, "speech") {
-		return true
-	}
-	if strings.Contains(lower, "stable-diffusion") || strings.Contains(lower, "sdxl") || strings.Contains(lower, "flux") {
-		return true
-	}
-	return false
+// isRerank reports whether id is a reranker, such as bge-reranker-v2-m3.
+func isRerank(id string) bool {
+	return strings.Contains(strings.ToLower(id), "rerank")
 }
 
 // stripOrg removes the org/ prefix from model IDs.
@@ -152,9 +95,13 @@ func stripOrg(id string) string {
 	return id
 }
 
-func inferFamily(id string) string {
+func (s *SiliconFlow) InferFamily(id string) string {
 	model := strings.ToLower(stripOrg(id))
 	switch {
+	case isEmbedding(id):
+		return "embedding"
+	case isRerank(id):
+		return "rerank"
 	case strings.Contains(model, "llama-3.3"):
 		return "llama-3.3"
 	case strings.Contains(model, "llama-3.2"):
@@ -178,30 +125,43 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (s *SiliconFlow) InferDisplayName(id string) string {
 	model := stripOrg(id)
-	parts := strings.Split(model, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(model)
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "vision") || strings.Contains(lower, "vl") {
-		caps = append(caps, "vision")
+func (s *SiliconFlow) InferCapabilities(id string) []string {
+	return s.ExplainCapabilities(id).Capabilities()
+}
+
+// ExplainCapabilities gives embedding and rerank models only that
+// capability; the others get the chat heuristics.
+func (s *SiliconFlow) ExplainCapabilities(id string) *inference.Set {
+	var caps inference.Set
+	switch {
+	case isEmbedding(id):
+		caps.Add("embeddings", inference.FromHeuristic, "ID names an embedding model")
+	case isRerank(id):
+		caps.Add("rerank", inference.FromHeuristic, "ID names a rerank model")
+	default:
+		return inference.Infer(s.Name(), id)
 	}
-	caps = append(caps, "function_calling")
-	return caps
+	return &caps
 }
 
-func inferLimits(id string) adapter.Limits {
+func (s *SiliconFlow) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
+	case strings.Contains(lower, "bge-m3") || strings.Contains(lower, "qwen3-embedding"):
+		return adapter.Limits{MaxTokens: 8192}
+	case isEmbedding(id):
+		return adapter.Limits{MaxTokens: 512}
+	case strings.Contains(lower, "qwen3-reranker"):
+		return adapter.Limits{MaxTokens: 32768}
+	case strings.Contains(lower, "bge-reranker-v2-m3"):
+		return adapter.Limits{MaxTokens: 8192}
+	case isRerank(id):
+		return adapter.Limits{MaxTokens: 512}
 	case strings.Contains(lower, "llama-3.1") || strings.Contains(lower, "llama-3.3"):
 		return adapter.Limits{MaxTokens: 131072, MaxCompletionTokens: 8192}
 	case strings.Contains(lower, "qwen"):
@@ -211,7 +171,13 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (s *SiliconFlow) InferModalities(id string) adapter.Modalities {
+	if isEmbedding(id) {
+		return adapter.Modalities{Input: []string{"text"}, Output: []string{"embedding"}}
+	}
+	if isRerank(id) {
+		return adapter.Modalities{Input: []string{"text"}, Output: []string{"score"}}
+	}
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "vision") || strings.Contains(lower, "vl") {
@@ -222,3 +188,27 @@ func inferModalities(id string) adapter.Modalities {
 		Output: []string{"text"},
 	}
 }
+
+// embeddingDimensions are the output sizes of the embedding models, by ID
+// without the org prefix.
+var embeddingDimensions = map[string]int{
+	"bge-m3":                1024,
+	"bge-large-en-v1.5":     1024,
+	"bge-large-zh-v1.5":     1024,
+	"bce-embedding-base_v1": 768,
+	"qwen3-embedding-8b":    4096,
+	"qwen3-embedding-4b":    2560,
+	"qwen3-embedding-0.6b":  1024,
+}
+
+// InferEmbedding describes the embedding models' vectors. SiliconFlow
+// doesn't publish a batch limit.
+func (s *SiliconFlow) InferEmbedding(id string) *adapter.Embedding {
+	if !isEmbedding(id) {
+		return nil
+	}
+	return &adapter.Embedding{
+		Dimensions: embeddingDimensions[strings.ToLower(stripOrg(id))],
+		Similarity: catalog.SimilarityCosine,
+	}
+}
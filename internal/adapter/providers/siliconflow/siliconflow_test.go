@@ -0,0 +1,61 @@
+package siliconflow
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmbeddingAndRerankModels(t *testing.T) {
+	tests := []struct {
+		id         string
+		family     string
+		caps       string
+		output     string
+		dimensions int
+	}{
+		{"BAAI/bge-m3", "embedding", "embeddings", "embedding", 1024},
+		{"Qwen/Qwen3-Embedding-8B", "embedding", "embeddings", "embedding", 4096},
+		{"netease-youdao/bce-embedding-base_v1", "embedding", "embeddings", "embedding", 768},
+		{"Pro/BAAI/bge-new-v9", "embedding", "embeddings", "embedding", 0},
+		{"BAAI/bge-reranker-v2-m3", "rerank", "rerank", "score", 0},
+		{"Qwen/Qwen3-Reranker-8B", "rerank", "rerank", "score", 0},
+	}
+
+	s := &SiliconFlow{}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := s.InferFamily(tt.id); got != tt.family {
+				t.Errorf("InferFamily() = %q, want %q", got, tt.family)
+			}
+			if got := strings.Join(s.InferCapabilities(tt.id), ","); got != tt.caps {
+				t.Errorf("InferCapabilities() = %s, want %s", got, tt.caps)
+			}
+			if got := strings.Join(s.InferModalities(tt.id).Output, ","); got != tt.output {
+				t.Errorf("output modalities = %s, want %s", got, tt.output)
+			}
+			emb := s.InferEmbedding(tt.id)
+			if tt.family != "embedding" {
+				if emb != nil {
+					t.Errorf("InferEmbedding() = %+v, want nil", emb)
+				}
+				return
+			}
+			if emb == nil || emb.Dimensions != tt.dimensions {
+				t.Errorf("InferEmbedding() = %+v, want dimensions %d", emb, tt.dimensions)
+			}
+		})
+	}
+}
+
+func TestChatModelsAreNotEmbeddings(t *testing.T) {
+	s := &SiliconFlow{}
+	for _, id := range []string{"Qwen/Qwen2.5-72B-Instruct", "deepseek-ai/DeepSeek-V3"} {
+		caps := s.InferCapabilities(id)
+		if !strings.Contains(strings.Join(caps, ","), "chat") {
+			t.Errorf("InferCapabilities(%q) = %v, want chat", id, caps)
+		}
+		if emb := s.InferEmbedding(id); emb != nil {
+			t.Errorf("InferEmbedding(%q) = %+v, want nil", id, emb)
+		}
+	}
+}
@@ -2,50 +2,51 @@ package stepfun
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&StepFun{})
 }
 
+// defaultBaseURL is where StepFun is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.stepfun.com/v1"
+
 // StepFun adapter discovers models from the StepFun API (OpenAI-compatible).
 type StepFun struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (s *StepFun) Name() string { return "stepfun" }
 
-func (s *StepFun) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/stepfun/provider.yaml.
+func (s *StepFun) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "StepFun",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (s *StepFun) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	s.apiKey = apiKey
-	s.baseURL = baseURL
-	s.client = client
+// ConfigSpec returns where the StepFun adapter's settings come from.
+func (s *StepFun) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "stepfun",
+		APIKeyEnv: "STEPFUN_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (s *StepFun) HealthCheck(ctx context.Context) error {
-	url := s.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + s.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := s.client.Get(ctx, url, headers)
-	return err
+func (s *StepFun) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for StepFun.
@@ -57,7 +58,7 @@ func (s *StepFun) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := s.discoverFromAPI(ctx)
+			apiModels, err := s.DiscoverAPI(ctx, s)
 			if err != nil {
 				return nil, fmt.Errorf("stepfun API discovery: %w", err)
 			}
@@ -70,74 +71,20 @@ func (s *StepFun) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
+// Every StepFun model belongs to the step family.
+func (s *StepFun) InferFamily(string) string {
+	return "step"
 }
 
-func (s *StepFun) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := s.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + s.apiKey,
-	}
-
-	resp, err := s.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("stepfun API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       "step",
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (s *StepFun) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
 	}
-	if strings.Contains(lower, "image") && !strings.Contains(lower, "vision") {
-		return true
-	}
 	return false
 }
 
-func inferDisplayName(id string) string {
+func (s *StepFun) InferDisplayName(id string) string {
 	overrides := map[string]string{
 		"step-1-8k":    "Step 1 8K",
 		"step-1-32k":   "Step 1 32K",
@@ -150,25 +97,18 @@ func inferDisplayName(id string) string {
 	if name, ok := overrides[id]; ok {
 		return name
 	}
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "function_calling", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "1v") || strings.Contains(lower, "vision") {
-		caps = append(caps, "vision")
-	}
-	return caps
+func (s *StepFun) InferCapabilities(id string) []string {
+	return s.ExplainCapabilities(id).Capabilities()
+}
+
+func (s *StepFun) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(s.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (s *StepFun) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "256k"):
@@ -184,7 +124,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (s *StepFun) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "1v") || strings.Contains(lower, "vision") {
@@ -4,19 +4,27 @@ import (
 	"context"
 	"log/slog"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/htmlutil"
 	"github.com/everstacklabs/sentinel/internal/llmstxt"
 )
 
-const togetheraiLLMsTxtURL = "https://docs.together.ai/llms-full.txt"
+const (
+	togetheraiLLMsTxtURL         = "https://docs.together.ai/llms-full.txt"
+	togetheraiFineTuningURL      = "https://docs.together.ai/docs/fine-tuning-models"
+	togetheraiModelsURL          = "https://docs.together.ai/docs/serverless-models"
+	togetheraiFunctionCallingURL = "https://docs.together.ai/docs/function-calling"
+)
 
 var togetheraiModelRe = regexp.MustCompile(`([\w-]+/[\w.-]+)`)
 
 // discoverFromDocs fetches the Together AI llms-full.txt and extracts model IDs.
 func (t *TogetherAI) discoverFromDocs(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	content, err := llmstxt.Fetch(ctx, togetheraiLLMsTxtURL)
+	content, err := llmstxt.Fetch(ctx, t.Client, togetheraiLLMsTxtURL)
 	if err != nil {
 		return nil, err
 	}
@@ -38,10 +46,11 @@ func (t *TogetherAI) discoverFromDocs(ctx context.Context) ([]adapter.Discovered
 	return models, nil
 }
 
-// shouldSkipDocsModel filters out non-chat models from llms.txt results.
+// shouldSkipDocsModel filters out the model types the API source skips
+// from llms.txt results.
 func shouldSkipDocsModel(id string) bool {
 	lower := strings.ToLower(id)
-	skipPatterns := []string{"embed", "rerank", "whisper", "tts", "image", "stable-diffusion", "flux", "dall-e"}
+	skipPatterns := []string{"embed", "rerank"}
 	for _, p := range skipPatterns {
 		if strings.Contains(lower, p) {
 			return true
@@ -49,3 +58,191 @@ func shouldSkipDocsModel(id string) bool {
 	}
 	return false
 }
+
+// discoverFineTunable scrapes the list of models Together can fine-tune.
+func (t *TogetherAI) discoverFineTunable(ctx context.Context) (map[string]bool, error) {
+	doc, err := htmlutil.Fetch(ctx, t.Client, togetheraiFineTuningURL)
+	if err != nil {
+		return nil, err
+	}
+	ids := parseFineTunable(htmlutil.Tables(doc, "table"))
+	slog.Info("togetherai fine-tuning models scraping complete", "models", len(ids))
+	return ids, nil
+}
+
+// parseFineTunable collects the API model strings from tables that have a
+// column for them ("Model String for API" or "Model ID").
+func parseFineTunable(tables [][][]string) map[string]bool {
+	ids := make(map[string]bool)
+	for _, grid := range tables {
+		if len(grid) < 2 {
+			continue
+		}
+		col := -1
+		for i, h := range grid[0] {
+			h = strings.ToLower(h)
+			if strings.Contains(h, "model string") || strings.Contains(h, "model id") {
+				col = i
+				break
+			}
+		}
+		if col < 0 {
+			continue
+		}
+		for _, row := range grid[1:] {
+			if col < len(row) && strings.Contains(row[col], "/") {
+				ids[strings.TrimSpace(row[col])] = true
+			}
+		}
+	}
+	return ids
+}
+
+// modelDetails is what the serverless models page says about a model.
+type modelDetails struct {
+	Type          string // in the API's terms: "chat", "image", "embedding", ...
+	Vision        bool
+	Quantization  string // e.g. "FP8"
+	ContextLength int
+}
+
+// discoverDetails scrapes the serverless models page and the list of models
+// that support function calling. Either is nil when its page can't be read.
+func (t *TogetherAI) discoverDetails(ctx context.Context) (map[string]modelDetails, map[string]bool) {
+	var (
+		details map[string]modelDetails
+		tools   map[string]bool
+	)
+	if doc, err := htmlutil.Fetch(ctx, t.Client, togetheraiModelsURL); err != nil {
+		adapter.Warn(ctx, "", "togetherai models page scraping failed, continuing", "error", err)
+	} else {
+		details = parseModelDetails(doc)
+		slog.Info("togetherai models page scraping complete", "models", len(details))
+	}
+	if doc, err := htmlutil.Fetch(ctx, t.Client, togetheraiFunctionCallingURL); err != nil {
+		adapter.Warn(ctx, "", "togetherai function calling page scraping failed, continuing", "error", err)
+	} else if tools = parseToolModels(doc); len(tools) == 0 {
+		adapter.Warn(ctx, "", "togetherai docs scraping: no function calling models found (page layout may have changed)")
+		tools = nil
+	}
+	return details, tools
+}
+
+// parseModelDetails reads every table with an API model string column. The
+// nearest heading before a table ("Vision models", "Image models") gives
+// the type of the models in it.
+func parseModelDetails(doc *goquery.Document) map[string]modelDetails {
+	details := make(map[string]modelDetails)
+	section := modelDetails{Type: "chat"}
+	doc.Find("h1, h2, h3, h4, table").Each(func(_ int, s *goquery.Selection) {
+		if !s.Is("table") {
+			if d, ok := sectionOf(s.Text()); ok {
+				section = d
+			}
+			return
+		}
+
+		rows := s.Find("tr")
+		if rows.Length() < 2 {
+			return
+		}
+		var headers []string
+		rows.First().Find("th, td").Each(func(_ int, c *goquery.Selection) {
+			headers = append(headers, strings.ToLower(strings.TrimSpace(c.Text())))
+		})
+		idCol := columnOf(headers, "model string", "model id")
+		if idCol < 0 {
+			return
+		}
+		quantCol := columnOf(headers, "quantization")
+		contextCol := columnOf(headers, "context")
+
+		rows.Slice(1, rows.Length()).Each(func(_ int, row *goquery.Selection) {
+			var cells []string
+			row.Find("th, td").Each(func(_ int, c *goquery.Selection) {
+				cells = append(cells, strings.TrimSpace(c.Text()))
+			})
+			if idCol >= len(cells) || !strings.Contains(cells[idCol], "/") {
+				return
+			}
+			d := section
+			if quantCol >= 0 && quantCol < len(cells) && cells[quantCol] != "-" {
+				d.Quantization = cells[quantCol]
+			}
+			if contextCol >= 0 && contextCol < len(cells) {
+				d.ContextLength = parseContextLength(cells[contextCol])
+			}
+			details[cells[idCol]] = d
+		})
+	})
+	return details
+}
+
+// sectionOf maps a heading of the models page to the type of the models
+// listed under it. Headings that name no kind of model are not sections.
+func sectionOf(heading string) (modelDetails, bool) {
+	h := strings.ToLower(heading)
+	switch {
+	case strings.Contains(h, "vision"):
+		return modelDetails{Type: "chat", Vision: true}, true
+	case strings.Contains(h, "embedding"):
+		return modelDetails{Type: "embedding"}, true
+	case strings.Contains(h, "rerank"):
+		return modelDetails{Type: "rerank"}, true
+	case strings.Contains(h, "moderation"):
+		return modelDetails{Type: "moderation"}, true
+	case strings.Contains(h, "image"):
+		return modelDetails{Type: "image"}, true
+	case strings.Contains(h, "transcription"), strings.Contains(h, "speech-to-text"):
+		return modelDetails{Type: "transcribe"}, true
+	case strings.Contains(h, "audio"), strings.Contains(h, "text-to-speech"):
+		return modelDetails{Type: "audio"}, true
+	case strings.Contains(h, "code"):
+		return modelDetails{Type: "code"}, true
+	case strings.Contains(h, "chat"), strings.Contains(h, "language"):
+		return modelDetails{Type: "chat"}, true
+	}
+	return modelDetails{}, false
+}
+
+var modelStringRe = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// parseToolModels collects the model strings the function calling page
+// lists as supported, from its lists, tables and code spans.
+func parseToolModels(doc *goquery.Document) map[string]bool {
+	ids := make(map[string]bool)
+	doc.Find("li, td, code").Each(func(_ int, s *goquery.Selection) {
+		if id := strings.TrimSpace(s.Text()); modelStringRe.MatchString(id) {
+			ids[id] = true
+		}
+	})
+	return ids
+}
+
+// parseContextLength reads lengths like "131072", "128K" or "1M".
+func parseContextLength(s string) int {
+	s = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), ",", ""))
+	scale := 1.0
+	switch {
+	case strings.HasSuffix(s, "K"):
+		scale, s = 1024, strings.TrimSuffix(s, "K")
+	case strings.HasSuffix(s, "M"):
+		scale, s = 1024*1024, strings.TrimSuffix(s, "M")
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v <= 0 {
+		return 0
+	}
+	return int(v * scale)
+}
+
+func columnOf(headers []string, names ...string) int {
+	for _, name := range names {
+		for i, h := range headers {
+			if strings.Contains(h, name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
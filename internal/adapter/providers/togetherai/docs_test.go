@@ -0,0 +1,90 @@
+package togetherai
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/everstacklabs/sentinel/internal/adapter"
+)
+
+func loadDoc(t *testing.T, path string) *goquery.Document {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestParseModelDetails(t *testing.T) {
+	got := parseModelDetails(loadDoc(t, "testdata/serverless_models.html"))
+	want := map[string]modelDetails{
+		"meta-llama/Llama-3.3-70B-Instruct-Turbo":           {Type: "chat", Quantization: "FP8", ContextLength: 131072},
+		"Qwen/Qwen2.5-7B-Instruct-Turbo":                    {Type: "chat", ContextLength: 32768},
+		"meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8": {Type: "chat", Vision: true, ContextLength: 1048576},
+		"black-forest-labs/FLUX.1-schnell":                  {Type: "image"},
+		"BAAI/bge-base-en-v1.5":                             {Type: "embedding"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseModelDetails() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseToolModels(t *testing.T) {
+	got := parseToolModels(loadDoc(t, "testdata/function_calling.html"))
+	want := map[string]bool{
+		"meta-llama/Llama-3.3-70B-Instruct-Turbo":           true,
+		"meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8": true,
+		"Qwen/Qwen2.5-72B-Instruct-Turbo":                   true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseToolModels() = %v, want %v", got, want)
+	}
+}
+
+func TestDetailsFillInTheAPI(t *testing.T) {
+	details := parseModelDetails(loadDoc(t, "testdata/serverless_models.html"))
+	tools := parseToolModels(loadDoc(t, "testdata/function_calling.html"))
+	discover := func(am apiModel) *adapter.DiscoveredModel {
+		m := apiModelToDiscovered(withDetails(am, details))
+		if m != nil {
+			applyDetails(m, details[am.ID], tools)
+		}
+		return m
+	}
+
+	// An untyped embedding model is skipped, an untyped image model typed.
+	if m := discover(apiModel{ID: "BAAI/bge-base-en-v1.5"}); m != nil {
+		t.Errorf("embedding model not skipped: %+v", m)
+	}
+	if m := discover(apiModel{ID: "black-forest-labs/FLUX.1-schnell"}); m == nil || m.ModelType != "image_generation" {
+		t.Errorf("image model = %+v, want image_generation", m)
+	}
+
+	vision := discover(apiModel{ID: "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8"})
+	want := []string{"chat", "streaming", "function_calling", "vision"}
+	if !reflect.DeepEqual(vision.Capabilities, want) || !reflect.DeepEqual(vision.Modalities.Input, []string{"text", "image"}) {
+		t.Errorf("vision model = %v, %v; want vision with image input", vision.Capabilities, vision.Modalities.Input)
+	}
+	if vision.Limits.MaxTokens != 1048576 || vision.FieldSources["capabilities"] != adapter.SourceDocs {
+		t.Errorf("vision model = %+v, want the docs' context length and capabilities", vision)
+	}
+
+	// Not on the function calling list: the context length guess is dropped.
+	qwen := discover(apiModel{ID: "Qwen/Qwen2.5-7B-Instruct-Turbo", Type: "chat"})
+	if !reflect.DeepEqual(qwen.Capabilities, []string{"chat", "streaming"}) {
+		t.Errorf("capabilities = %v, want no function_calling", qwen.Capabilities)
+	}
+
+	llama := discover(apiModel{ID: "meta-llama/Llama-3.3-70B-Instruct-Turbo", Type: "chat", ContextLength: 131072})
+	if llama.XProvider["quantization"] != "FP8" {
+		t.Errorf("x_provider = %v, want the quantization", llama.XProvider)
+	}
+}
@@ -5,59 +5,73 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
 )
 
 func init() {
 	adapter.Register(&TogetherAI{})
 }
 
+// defaultBaseURL is where Together AI is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.together.xyz/v1"
+
 // TogetherAI adapter discovers models from the Together AI API.
 type TogetherAI struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (t *TogetherAI) Name() string { return "togetherai" }
 
-func (t *TogetherAI) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
+// ProviderInfo returns the defaults for providers/togetherai/provider.yaml.
+func (t *TogetherAI) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Together AI",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+		StatusPage:  "https://status.together.ai",
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (t *TogetherAI) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	t.apiKey = apiKey
-	t.baseURL = baseURL
-	t.client = client
+// ConfigSpec returns where the Together AI adapter's settings come from.
+func (t *TogetherAI) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "togetherai",
+		APIKeyEnv: "TOGETHER_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (t *TogetherAI) HealthCheck(ctx context.Context) error {
-	url := t.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + t.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := t.client.Get(ctx, url, headers)
-	return err
+func (t *TogetherAI) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
 }
 
 // MinExpectedModels returns the minimum model count for Together AI.
 func (t *TogetherAI) MinExpectedModels() int { return 20 }
 
 func (t *TogetherAI) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
-	var models []adapter.DiscoveredModel
+	var (
+		models     []adapter.DiscoveredModel
+		fineTuning map[string]bool
+		details    map[string]modelDetails
+		tools      map[string]bool
+	)
+
+	// The models page fills in what the API leaves out, such as an empty
+	// type, so it is read before the API.
+	if slices.Contains(opts.Sources, adapter.SourceDocs) {
+		details, tools = t.discoverDetails(ctx)
+	}
 
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := t.discoverFromAPI(ctx)
+			apiModels, err := t.discoverFromAPI(ctx, details)
 			if err != nil {
 				return nil, fmt.Errorf("togetherai API discovery: %w", err)
 			}
@@ -65,16 +79,69 @@ func (t *TogetherAI) Discover(ctx context.Context, opts adapter.DiscoverOptions)
 		case adapter.SourceDocs:
 			docModels, err := t.discoverFromDocs(ctx)
 			if err != nil {
-				slog.Warn("togetherai docs discovery failed, continuing", "error", err)
+				adapter.Warn(ctx, "", "togetherai docs discovery failed, continuing", "error", err)
 			} else {
 				models = append(models, docModels...)
 			}
+			if fineTuning, err = t.discoverFineTunable(ctx); err != nil {
+				adapter.Warn(ctx, "", "togetherai fine-tuning models scraping failed, continuing", "error", err)
+			}
 		}
 	}
 
+	// The list only says which models can be fine-tuned; the others are
+	// left unknown rather than marked unsupported.
+	for i := range models {
+		if fineTuning[models[i].Name] {
+			models[i].FineTuning = &adapter.FineTuning{Supported: true}
+		}
+		applyDetails(&models[i], details[models[i].Name], tools)
+	}
 	return models, nil
 }
 
+// applyDetails adds what the docs say about a chat model to what the API
+// reported: vision support, quantization and, when the function calling
+// list could be read, whether the model is on it. The list replaces the
+// guess from the context length.
+func applyDetails(m *adapter.DiscoveredModel, d modelDetails, tools map[string]bool) {
+	if m.ModelType != "" {
+		return
+	}
+	fromDocs := false
+	if d.Vision {
+		if !slices.Contains(m.Capabilities, "vision") {
+			m.Capabilities = append(m.Capabilities, "vision")
+			fromDocs = true
+		}
+		if !slices.Contains(m.Modalities.Input, "image") {
+			m.Modalities.Input = append(m.Modalities.Input, "image")
+			m.SetFieldSource("modalities", adapter.SourceDocs)
+		}
+	}
+	if tools != nil {
+		has := slices.Contains(m.Capabilities, "function_calling")
+		switch {
+		case tools[m.Name] && !has:
+			m.Capabilities = append(m.Capabilities, "function_calling")
+			fromDocs = true
+		case !tools[m.Name] && has:
+			m.Capabilities = slices.DeleteFunc(m.Capabilities, func(c string) bool { return c == "function_calling" })
+			fromDocs = true
+		}
+	}
+	if fromDocs {
+		m.SetFieldSource("capabilities", adapter.SourceDocs)
+	}
+	if d.Quantization != "" {
+		if m.XProvider == nil {
+			m.XProvider = make(map[string]any)
+		}
+		m.XProvider["quantization"] = d.Quantization
+		m.SetFieldSource("x_provider", adapter.SourceDocs)
+	}
+}
+
 // Together AI /v1/models response — returns a flat array.
 type apiModel struct {
 	ID            string `json:"id"`
@@ -90,13 +157,13 @@ type apiModel struct {
 	} `json:"pricing"`
 }
 
-func (t *TogetherAI) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := t.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + t.apiKey,
-	}
+// discoverFromAPI lists the API's models. The API often leaves type and
+// context_length empty; details from the models page fill them in.
+func (t *TogetherAI) discoverFromAPI(ctx context.Context, details map[string]modelDetails) ([]adapter.DiscoveredModel, error) {
+	url := t.BaseURL + "/models"
+	headers := t.AuthHeaders()
 
-	resp, err := t.client.Get(ctx, url, headers)
+	resp, err := t.Client.Get(ctx, url, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +175,7 @@ func (t *TogetherAI) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredM
 
 	var models []adapter.DiscoveredModel
 	for _, am := range allModels {
-		m := apiModelToDiscovered(am)
+		m := apiModelToDiscovered(withDetails(am, details))
 		if m != nil {
 			models = append(models, *m)
 		}
@@ -118,6 +185,18 @@ func (t *TogetherAI) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredM
 	return models, nil
 }
 
+// withDetails fills in the type and context length the API left empty.
+func withDetails(am apiModel, details map[string]modelDetails) apiModel {
+	d := details[am.ID]
+	if am.Type == "" {
+		am.Type = d.Type
+	}
+	if am.ContextLength == 0 {
+		am.ContextLength = d.ContextLength
+	}
+	return am
+}
+
 func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
 	if shouldSkip(am) {
 		return nil
@@ -143,6 +222,9 @@ func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
 		Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
 		DiscoveredBy: adapter.SourceAPI,
 	}
+	if mt := mediaType(am); mt != "" {
+		adapter.MediaModel(m, mt)
+	}
 
 	// Together AI returns pricing per token; convert to per 1K.
 	if am.Pricing != nil && (am.Pricing.Input > 0 || am.Pricing.Output > 0) {
@@ -160,7 +242,9 @@ func shouldSkip(am apiModel) bool {
 	switch am.Type {
 	case "chat", "language", "code":
 		return false
-	case "image", "embedding", "moderation", "rerank", "audio":
+	case "image", "audio", "transcribe":
+		return false
+	case "embedding", "moderation", "rerank":
 		return true
 	}
 	// If type is empty, check the ID for hints
@@ -171,6 +255,23 @@ func shouldSkip(am apiModel) bool {
 	return false
 }
 
+// mediaType is the catalog type of an image or audio model, from the
+// type the API reports.
+func mediaType(am apiModel) string {
+	switch am.Type {
+	case "image":
+		return catalog.ModelTypeImageGeneration
+	case "transcribe":
+		return catalog.ModelTypeSpeechToText
+	case "audio":
+		if mt := adapter.InferMediaType(am.ID); mt != "" {
+			return mt
+		}
+		return catalog.ModelTypeTextToSpeech
+	}
+	return adapter.InferMediaType(am.ID)
+}
+
 func inferFamily(id string) string {
 	lower := strings.ToLower(id)
 	// Together AI uses org/model format
@@ -214,13 +315,7 @@ func inferDisplayName(id string) string {
 	// Strip org prefix for display
 	parts := strings.Split(id, "/")
 	name := parts[len(parts)-1]
-	segments := strings.Split(name, "-")
-	for i, p := range segments {
-		if len(p) > 0 {
-			segments[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(segments, " ")
+	return adapter.TitleCase(name)
 }
 
 func inferCapabilities(am apiModel) []string {
@@ -2,50 +2,51 @@ package upstage
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Upstage{})
 }
 
+// defaultBaseURL is where Upstage is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.upstage.ai/v1/solar"
+
 // Upstage adapter discovers models from the Upstage Solar API (OpenAI-compatible).
 type Upstage struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (u *Upstage) Name() string { return "upstage" }
 
-func (u *Upstage) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/upstage/provider.yaml.
+func (u *Upstage) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Upstage",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (u *Upstage) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	u.apiKey = apiKey
-	u.baseURL = baseURL
-	u.client = client
+// ConfigSpec returns where the Upstage adapter's settings come from.
+func (u *Upstage) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "upstage",
+		APIKeyEnv: "UPSTAGE_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (u *Upstage) HealthCheck(ctx context.Context) error {
-	url := u.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + u.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := u.client.Get(ctx, url, headers)
-	return err
+func (u *Upstage) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Upstage.
@@ -57,7 +58,7 @@ func (u *Upstage) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := u.discoverFromAPI(ctx)
+			apiModels, err := u.DiscoverAPI(ctx, u)
 			if err != nil {
 				return nil, fmt.Errorf("upstage API discovery: %w", err)
 			}
@@ -70,63 +71,20 @@ func (u *Upstage) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
+// Every Solar model shares the capabilities and limits.
+func (u *Upstage) InferCapabilities(id string) []string {
+	return u.ExplainCapabilities(id).Capabilities()
 }
 
-func (u *Upstage) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := u.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + u.apiKey,
-	}
-
-	resp, err := u.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("upstage API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
+func (u *Upstage) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(u.Name(), id)
 }
 
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: []string{"chat", "function_calling", "streaming"},
-		Limits:       adapter.Limits{MaxTokens: 32768, MaxCompletionTokens: 4096},
-		Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
-		DiscoveredBy: adapter.SourceAPI,
-	}
+func (u *Upstage) InferLimits(string) adapter.Limits {
+	return adapter.Limits{MaxTokens: 32768, MaxCompletionTokens: 4096}
 }
 
-func shouldSkip(id string) bool {
+func (u *Upstage) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
@@ -137,20 +95,10 @@ func shouldSkip(id string) bool {
 	return false
 }
 
-func inferFamily(id string) string {
+func (u *Upstage) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "solar") {
 		return "solar"
 	}
 	return "upstage-other"
 }
-
-func inferDisplayName(id string) string {
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
-}
@@ -2,50 +2,51 @@ package venice
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&Venice{})
 }
 
+// defaultBaseURL is where Venice is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://api.venice.ai/api/v1"
+
 // Venice adapter discovers models from the Venice AI API (OpenAI-compatible).
 type Venice struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (v *Venice) Name() string { return "venice" }
 
-func (v *Venice) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/venice/provider.yaml.
+func (v *Venice) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Venice",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (v *Venice) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	v.apiKey = apiKey
-	v.baseURL = baseURL
-	v.client = client
+// ConfigSpec returns where the Venice adapter's settings come from.
+func (v *Venice) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "venice",
+		APIKeyEnv: "VENICE_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (v *Venice) HealthCheck(ctx context.Context) error {
-	url := v.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + v.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := v.client.Get(ctx, url, headers)
-	return err
+func (v *Venice) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Venice.
@@ -57,7 +58,7 @@ func (v *Venice) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := v.discoverFromAPI(ctx)
+			apiModels, err := v.DiscoverAPI(ctx, v)
 			if err != nil {
 				return nil, fmt.Errorf("venice API discovery: %w", err)
 			}
@@ -70,77 +71,15 @@ func (v *Venice) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (v *Venice) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := v.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + v.apiKey,
-	}
-
-	resp, err := v.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("venice API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (v *Venice) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
 	}
-	if strings.Contains(lower, "stable-diffusion") || strings.Contains(lower, "sdxl") || strings.Contains(lower, "flux") {
-		return true
-	}
-	if strings.Contains(lower, "whisper") || strings.Contains(lower, "tts") {
-		return true
-	}
 	return false
 }
 
-func inferFamily(id string) string {
+func (v *Venice) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama-3.3"):
@@ -162,27 +101,15 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+func (v *Venice) InferCapabilities(id string) []string {
+	return v.ExplainCapabilities(id).Capabilities()
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "vision") || strings.Contains(lower, "vl") {
-		caps = append(caps, "vision")
-	}
-	caps = append(caps, "function_calling")
-	return caps
+func (v *Venice) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(v.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (v *Venice) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "llama-3.1") || strings.Contains(lower, "llama-3.3"):
@@ -192,7 +119,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (v *Venice) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "vision") || strings.Contains(lower, "vl") {
@@ -2,50 +2,51 @@ package xai
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&XAI{})
 }
 
+// defaultBaseURL is where xAI is reached when the config sets no base_url.
+const defaultBaseURL = "https://api.x.ai/v1"
+
 // XAI adapter discovers models from the xAI (Grok) API.
 type XAI struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (x *XAI) Name() string { return "xai" }
 
-func (x *XAI) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/xai/provider.yaml.
+func (x *XAI) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "xAI",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+		StatusPage:  "https://status.x.ai",
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (x *XAI) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	x.apiKey = apiKey
-	x.baseURL = baseURL
-	x.client = client
+// ConfigSpec returns where the xAI adapter's settings come from.
+func (x *XAI) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "xai",
+		APIKeyEnv: "XAI_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (x *XAI) HealthCheck(ctx context.Context) error {
-	url := x.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + x.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := x.client.Get(ctx, url, headers)
-	return err
+func (x *XAI) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for xAI.
@@ -57,7 +58,7 @@ func (x *XAI) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]ada
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := x.discoverFromAPI(ctx)
+			apiModels, err := x.DiscoverAPI(ctx, x)
 			if err != nil {
 				return nil, fmt.Errorf("xai API discovery: %w", err)
 			}
@@ -70,69 +71,8 @@ func (x *XAI) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]ada
 	return models, nil
 }
 
-// OpenAI-compatible /v1/models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	Created int64  `json:"created"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (x *XAI) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := x.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + x.apiKey,
-	}
-
-	resp, err := x.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("xai API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
-
-func shouldSkip(id string) bool {
+func (x *XAI) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
-	// Skip image generation models
-	if strings.Contains(lower, "image") {
-		return true
-	}
 	// Skip embedding models
 	if strings.Contains(lower, "embed") {
 		return true
@@ -140,7 +80,7 @@ func shouldSkip(id string) bool {
 	return false
 }
 
-func inferFamily(id string) string {
+func (x *XAI) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.HasPrefix(lower, "grok-4"):
@@ -156,7 +96,7 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (x *XAI) InferDisplayName(id string) string {
 	overrides := map[string]string{
 		"grok-4":        "Grok 4",
 		"grok-4-fast":   "Grok 4 Fast",
@@ -170,28 +110,18 @@ func inferDisplayName(id string) string {
 	if name, ok := overrides[id]; ok {
 		return name
 	}
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
 }
 
-func inferCapabilities(id string) []string {
-	lower := strings.ToLower(id)
-	caps := []string{"chat", "function_calling", "streaming"}
-	if strings.Contains(lower, "vision") {
-		caps = append(caps, "vision")
-	}
-	if strings.Contains(lower, "mini") {
-		caps = append(caps, "reasoning")
-	}
-	return caps
+func (x *XAI) InferCapabilities(id string) []string {
+	return x.ExplainCapabilities(id).Capabilities()
+}
+
+func (x *XAI) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(x.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (x *XAI) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "grok-4"):
@@ -205,7 +135,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (x *XAI) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "vision") {
@@ -2,51 +2,52 @@ package zhipuai
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
-	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/adapter/openaicompat"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/inference"
 )
 
 func init() {
 	adapter.Register(&ZhipuAI{})
 }
 
+// defaultBaseURL is where Zhipu AI is reached when the config sets no
+// base_url.
+const defaultBaseURL = "https://open.bigmodel.cn/api/paas/v4"
+
 // ZhipuAI adapter discovers models from the Zhipu AI API.
 // Uses /v4 API path (not standard /v1).
 type ZhipuAI struct {
-	apiKey  string
-	baseURL string
-	client  *httpclient.Client
+	openaicompat.Base
 }
 
 func (z *ZhipuAI) Name() string { return "zhipuai" }
 
-func (z *ZhipuAI) SupportedSources() []adapter.SourceType {
-	return []adapter.SourceType{adapter.SourceAPI}
+// ProviderInfo returns the defaults for providers/zhipuai/provider.yaml.
+func (z *ZhipuAI) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{
+		DisplayName: "Zhipu AI",
+		BaseURL:     defaultBaseURL,
+		AuthType:    catalog.AuthBearer,
+	}
 }
 
-// Configure sets up the adapter with API credentials and HTTP client.
-func (z *ZhipuAI) Configure(apiKey, baseURL string, client *httpclient.Client) {
-	z.apiKey = apiKey
-	z.baseURL = baseURL
-	z.client = client
+// ConfigSpec returns where the Zhipu AI adapter's settings come from.
+func (z *ZhipuAI) ConfigSpec() adapter.ConfigSpec {
+	return adapter.ConfigSpec{
+		Key:       "zhipuai",
+		APIKeyEnv: "ZHIPU_API_KEY",
+		BaseURL:   defaultBaseURL,
+	}
 }
 
-// HealthCheck performs a lightweight GET to the models endpoint.
-func (z *ZhipuAI) HealthCheck(ctx context.Context) error {
-	url := z.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + z.apiKey,
-	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err := z.client.Get(ctx, url, headers)
-	return err
+func (z *ZhipuAI) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
 }
 
 // MinExpectedModels returns the minimum model count for Zhipu AI.
@@ -58,7 +59,7 @@ func (z *ZhipuAI) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 	for _, src := range opts.Sources {
 		switch src {
 		case adapter.SourceAPI:
-			apiModels, err := z.discoverFromAPI(ctx)
+			apiModels, err := z.DiscoverAPI(ctx, z)
 			if err != nil {
 				return nil, fmt.Errorf("zhipuai API discovery: %w", err)
 			}
@@ -72,62 +73,8 @@ func (z *ZhipuAI) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([
 }
 
 // OpenAI-compatible models response.
-type modelsResponse struct {
-	Data []apiModel `json:"data"`
-}
-
-type apiModel struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	OwnedBy string `json:"owned_by"`
-}
-
-func (z *ZhipuAI) discoverFromAPI(ctx context.Context) ([]adapter.DiscoveredModel, error) {
-	url := z.baseURL + "/models"
-	headers := map[string]string{
-		"Authorization": "Bearer " + z.apiKey,
-	}
-
-	resp, err := z.client.Get(ctx, url, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelsResp modelsResponse
-	if err := json.Unmarshal(resp.Body, &modelsResp); err != nil {
-		return nil, fmt.Errorf("parsing models response: %w", err)
-	}
-
-	var models []adapter.DiscoveredModel
-	for _, am := range modelsResp.Data {
-		m := apiModelToDiscovered(am)
-		if m != nil {
-			models = append(models, *m)
-		}
-	}
-
-	slog.Info("zhipuai API discovery complete", "total_api_models", len(modelsResp.Data), "catalog_models", len(models))
-	return models, nil
-}
-
-func apiModelToDiscovered(am apiModel) *adapter.DiscoveredModel {
-	if shouldSkip(am.ID) {
-		return nil
-	}
-
-	return &adapter.DiscoveredModel{
-		Name:         am.ID,
-		DisplayName:  inferDisplayName(am.ID),
-		Family:       inferFamily(am.ID),
-		Status:       "stable",
-		Capabilities: inferCapabilities(am.ID),
-		Limits:       inferLimits(am.ID),
-		Modalities:   inferModalities(am.ID),
-		DiscoveredBy: adapter.SourceAPI,
-	}
-}
 
-func shouldSkip(id string) bool {
+func (z *ZhipuAI) ShouldSkip(id string) bool {
 	lower := strings.ToLower(id)
 	if strings.Contains(lower, "embed") {
 		return true
@@ -138,7 +85,7 @@ func shouldSkip(id string) bool {
 	return false
 }
 
-func inferFamily(id string) string {
+func (z *ZhipuAI) InferFamily(id string) string {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "glm-4"):
@@ -152,7 +99,7 @@ func inferFamily(id string) string {
 	}
 }
 
-func inferDisplayName(id string) string {
+func (z *ZhipuAI) InferDisplayName(id string) string {
 	overrides := map[string]string{
 		"glm-4":       "GLM 4",
 		"glm-4-plus":  "GLM 4 Plus",
@@ -166,25 +113,18 @@ func inferDisplayName(id string) string {
 	if name, ok := overrides[id]; ok {
 		return name
 	}
-	parts := strings.Split(id, "-")
-	for i, p := range parts {
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + p[1:]
-		}
-	}
-	return strings.Join(parts, " ")
+	return adapter.TitleCase(id)
 }
 
-func inferCapabilities(id string) []string {
-	caps := []string{"chat", "function_calling", "streaming"}
-	lower := strings.ToLower(id)
-	if strings.Contains(lower, "4v") || strings.Contains(lower, "vision") {
-		caps = append(caps, "vision")
-	}
-	return caps
+func (z *ZhipuAI) InferCapabilities(id string) []string {
+	return z.ExplainCapabilities(id).Capabilities()
+}
+
+func (z *ZhipuAI) ExplainCapabilities(id string) *inference.Set {
+	return inference.Infer(z.Name(), id)
 }
 
-func inferLimits(id string) adapter.Limits {
+func (z *ZhipuAI) InferLimits(id string) adapter.Limits {
 	lower := strings.ToLower(id)
 	switch {
 	case strings.Contains(lower, "long"):
@@ -196,7 +136,7 @@ func inferLimits(id string) adapter.Limits {
 	}
 }
 
-func inferModalities(id string) adapter.Modalities {
+func (z *ZhipuAI) InferModalities(id string) adapter.Modalities {
 	lower := strings.ToLower(id)
 	input := []string{"text"}
 	if strings.Contains(lower, "4v") || strings.Contains(lower, "vision") {
@@ -2,6 +2,7 @@ package adapter
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -38,3 +39,18 @@ func List() []string {
 	}
 	return names
 }
+
+// ConfigSpecs returns the config specs of the registered Configurable
+// adapters, sorted by config key.
+func ConfigSpecs() []ConfigSpec {
+	mu.RLock()
+	defer mu.RUnlock()
+	var specs []ConfigSpec
+	for _, a := range adapters {
+		if c, ok := a.(Configurable); ok {
+			specs = append(specs, c.ConfigSpec())
+		}
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Key < specs[j].Key })
+	return specs
+}
@@ -0,0 +1,70 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// Warning is something odd an adapter noticed during discovery, such as a
+// model of unknown type or a docs page without data. Warnings don't stop a
+// sync; they are carried into the run report and the PR body so reviewers
+// see them.
+type Warning struct {
+	Model   string // empty when the warning isn't about one model
+	Message string
+}
+
+func (w Warning) String() string {
+	if w.Model == "" {
+		return w.Message
+	}
+	return w.Model + ": " + w.Message
+}
+
+type warningsKey struct{}
+
+type warningCollector struct {
+	mu       sync.Mutex
+	warnings []Warning
+}
+
+// WithWarnings returns a context that collects the warnings adapters report
+// with Warn, and a function returning those collected so far.
+func WithWarnings(ctx context.Context) (context.Context, func() []Warning) {
+	c := &warningCollector{}
+	collected := func() []Warning {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return append([]Warning(nil), c.warnings...)
+	}
+	return context.WithValue(ctx, warningsKey{}, c), collected
+}
+
+// Warn logs a discovery warning and records it when ctx comes from
+// WithWarnings. model may be empty. args are slog key-value pairs; they are
+// also appended to the recorded message.
+func Warn(ctx context.Context, model, msg string, args ...any) {
+	logArgs := args
+	if model != "" {
+		logArgs = append([]any{"model", model}, args...)
+	}
+	slog.Warn(msg, logArgs...)
+
+	c, ok := ctx.Value(warningsKey{}).(*warningCollector)
+	if !ok {
+		return
+	}
+	var details []string
+	for i := 0; i+1 < len(args); i += 2 {
+		details = append(details, fmt.Sprintf("%v: %v", args[i], args[i+1]))
+	}
+	if len(details) > 0 {
+		msg += " (" + strings.Join(details, ", ") + ")"
+	}
+	c.mu.Lock()
+	c.warnings = append(c.warnings, Warning{Model: model, Message: msg})
+	c.mu.Unlock()
+}
@@ -0,0 +1,25 @@
+package adapter
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWarnCollects(t *testing.T) {
+	Warn(context.Background(), "", "not collected")
+
+	ctx, warnings := WithWarnings(context.Background())
+	Warn(ctx, "", "docs scraping failed", "error", "status 503")
+	Warn(ctx, "gpt-x", "unknown model type")
+
+	got := warnings()
+	if len(got) != 2 {
+		t.Fatalf("warnings = %v, want 2", got)
+	}
+	if got[0].String() != "docs scraping failed (error: status 503)" {
+		t.Errorf("warnings[0] = %q", got[0])
+	}
+	if got[1].String() != "gpt-x: unknown model type" {
+		t.Errorf("warnings[1] = %q", got[1])
+	}
+}
@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Cache stores HTTP responses for conditional fetching. Get returns the
+// entry (possibly stale, so its ETag/Last-Modified can be revalidated) and
+// whether it is still fresh.
+type Cache interface {
+	Get(key string) (*Entry, bool)
+	Set(key string, entry *Entry) error
+}
+
+// Backend names accepted by the cache.backend setting.
+const (
+	BackendFile  = "file"
+	BackendRedis = "redis"
+	BackendHTTP  = "http"
+	BackendS3    = "s3"
+	BackendGCS   = "gcs"
+)
+
+// hashKey maps a cache key (usually a URL) to a fixed-length storage key.
+func hashKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
+
+// isFresh reports whether an entry is younger than ttl.
+func isFresh(entry *Entry, ttl time.Duration) bool {
+	return time.Since(entry.CachedAt) <= ttl
+}
@@ -0,0 +1,280 @@
+package cache
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileCacheRoundTrip(t *testing.T) {
+	c, err := New(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, fresh := c.Get("https://api.example.com/models"); fresh {
+		t.Fatal("expected miss on empty cache")
+	}
+
+	if err := c.Set("https://api.example.com/models", &Entry{Body: []byte(`{"data":[]}`), ETag: `"abc"`, StatusCode: 200}); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, fresh := c.Get("https://api.example.com/models")
+	if !fresh || entry == nil {
+		t.Fatal("expected fresh hit")
+	}
+	if entry.ETag != `"abc"` || string(entry.Body) != `{"data":[]}` {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestFileCacheReturnsStaleEntry(t *testing.T) {
+	c, err := New(t.TempDir(), time.Nanosecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Set("k", &Entry{Body: []byte("x"), ETag: `"v1"`, StatusCode: 200})
+	time.Sleep(time.Millisecond)
+
+	entry, fresh := c.Get("k")
+	if fresh {
+		t.Error("expected stale entry")
+	}
+	if entry == nil || entry.ETag != `"v1"` {
+		t.Error("expected stale entry to be returned for revalidation")
+	}
+}
+
+func TestFileCacheCompressesAndReadsPlainEntries(t *testing.T) {
+	dir := t.TempDir()
+	c, err := New(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(strings.Repeat(`{"id":"gpt-4o","object":"model"},`, 200))
+	if err := c.Set("k", &Entry{Body: body, StatusCode: 200}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, hashKey("k")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[0] != 0x1f || data[1] != 0x8b || len(data) >= len(body) {
+		t.Errorf("entry should be gzip-compressed, got %d bytes for a %d-byte body", len(data), len(body))
+	}
+
+	// Entries written by earlier versions are plain JSON.
+	legacy := `{"body":"eA==","etag":"\"v1\"","status_code":200,"cached_at":"` + time.Now().Format(time.RFC3339Nano) + `"}`
+	if err := os.WriteFile(filepath.Join(dir, hashKey("old")), []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if entry, fresh := c.Get("old"); !fresh || entry == nil || entry.ETag != `"v1"` {
+		t.Errorf("legacy entry = %+v fresh=%v", entry, fresh)
+	}
+}
+
+func TestFileCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	c, err := New(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"a", "b", "c"} {
+		if err := c.Set(k, &Entry{Body: []byte(k), StatusCode: 200}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Age the entries, then use "a" so "b" becomes the least recently used.
+	past := time.Now().Add(-time.Hour)
+	for i, k := range []string{"a", "b", "c"} {
+		at := past.Add(time.Duration(i) * time.Minute)
+		_ = os.Chtimes(filepath.Join(dir, hashKey(k)), at, at)
+	}
+	c.Get("a")
+
+	s, err := c.Stats()
+	if err != nil || s.Entries != 3 {
+		t.Fatalf("stats = %+v, %v", s, err)
+	}
+	c.SetMaxSize(s.Bytes - 1)
+	if err := c.Set("d", &Entry{Body: []byte("d"), StatusCode: 200}); err != nil {
+		t.Fatal(err)
+	}
+	for k, want := range map[string]bool{"a": true, "b": false, "d": true} {
+		if entry, _ := c.Get(k); (entry != nil) != want {
+			t.Errorf("after eviction, %q cached = %v, want %v", k, entry != nil, want)
+		}
+	}
+}
+
+func TestFileCachePrune(t *testing.T) {
+	dir := t.TempDir()
+	c, err := New(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Set("new", &Entry{Body: []byte("x"), StatusCode: 200})
+	_ = c.Set("old", &Entry{Body: []byte("x"), StatusCode: 200})
+	old, _ := c.Get("old")
+	old.CachedAt = time.Now().Add(-48 * time.Hour)
+	data, _ := json.Marshal(old)
+	_ = os.WriteFile(filepath.Join(dir, hashKey("old")), data, 0o644)
+	_ = os.WriteFile(filepath.Join(dir, hashKey("bad")), []byte("not json"), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "README"), []byte("not a cache entry"), 0o644)
+
+	s, _ := c.Stats()
+	if s.Entries != 3 || s.Stale != 1 || s.Corrupt != 1 {
+		t.Errorf("stats = %+v, want 3 entries, 1 stale, 1 unreadable", s)
+	}
+
+	removed, _, err := c.Prune(24*time.Hour, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 2 {
+		t.Errorf("removed %d entries, want the old and the unreadable one", removed)
+	}
+	if entry, _ := c.Get("new"); entry == nil {
+		t.Error("recent entry should survive pruning")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "README")); err != nil {
+		t.Error("prune should leave files that aren't cache entries alone")
+	}
+}
+
+// kvServer is a minimal GET/PUT key/value server for HTTPCache tests.
+type kvServer struct {
+	mu   sync.Mutex
+	data map[string][]byte
+	auth string
+}
+
+func (s *kvServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.auth = r.Header.Get("Authorization")
+
+	key := strings.TrimPrefix(r.URL.Path, "/cache/")
+	switch r.Method {
+	case http.MethodGet:
+		v, ok := s.data[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(v)
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		s.data[key] = body
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHTTPCacheRoundTrip(t *testing.T) {
+	kv := &kvServer{data: make(map[string][]byte)}
+	srv := httptest.NewServer(kv)
+	defer srv.Close()
+
+	c, err := NewHTTP(srv.URL+"/cache/", "secret", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry, fresh := c.Get("https://api.example.com/models"); entry != nil || fresh {
+		t.Fatal("expected miss on empty server")
+	}
+
+	if err := c.Set("https://api.example.com/models", &Entry{Body: []byte("body"), ETag: `"e1"`, StatusCode: 200}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if kv.auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want bearer token", kv.auth)
+	}
+	if _, ok := kv.data[hashKey("https://api.example.com/models")]; !ok {
+		t.Error("expected entry stored under hashed key")
+	}
+
+	entry, fresh := c.Get("https://api.example.com/models")
+	if !fresh || entry == nil || string(entry.Body) != "body" || entry.ETag != `"e1"` {
+		t.Errorf("unexpected Get result: %+v fresh=%v", entry, fresh)
+	}
+}
+
+func TestHTTPCacheServerErrorIsMiss(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c, _ := NewHTTP(srv.URL, "", time.Hour)
+	if entry, fresh := c.Get("k"); entry != nil || fresh {
+		t.Error("expected miss on server error")
+	}
+	if err := c.Set("k", &Entry{Body: []byte("x")}); err == nil {
+		t.Error("expected error on failed PUT")
+	}
+}
+
+func TestS3CacheRoundTrip(t *testing.T) {
+	kv := &kvServer{data: make(map[string][]byte)}
+	srv := httptest.NewServer(kv)
+	defer srv.Close()
+
+	c, err := NewS3(S3Options{
+		Bucket:          "cache",
+		Endpoint:        srv.URL,
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+	}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Set("https://api.example.com/models", &Entry{Body: []byte("body"), ETag: `"e1"`, StatusCode: 200}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if !strings.HasPrefix(kv.auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || !strings.Contains(kv.auth, "/us-east-1/s3/aws4_request") {
+		t.Errorf("Authorization = %q, want a SigV4 signature", kv.auth)
+	}
+	if _, ok := kv.data[hashKey("https://api.example.com/models")]; !ok {
+		t.Error("expected object stored under the bucket path and hashed key")
+	}
+
+	entry, fresh := c.Get("https://api.example.com/models")
+	if !fresh || entry == nil || string(entry.Body) != "body" {
+		t.Errorf("unexpected Get result: %+v fresh=%v", entry, fresh)
+	}
+}
+
+func TestS3SignatureIsDeterministic(t *testing.T) {
+	c, err := NewS3(S3Options{Bucket: "b", AccessKeyID: "AKID", SecretAccessKey: "secret"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	sign := func(secret string) string {
+		c.opts.SecretAccessKey = secret
+		req, _ := http.NewRequest(http.MethodGet, c.base+"key", nil)
+		c.sign(req, nil, now)
+		return req.Header.Get("Authorization")
+	}
+	if sign("secret") != sign("secret") {
+		t.Error("signing the same request twice should give the same signature")
+	}
+	if sign("secret") == sign("other") {
+		t.Error("the signature should depend on the secret key")
+	}
+	if !strings.Contains(sign("secret"), "Credential=AKID/20260102/us-east-1/s3/aws4_request") {
+		t.Errorf("unexpected credential scope: %s", sign("secret"))
+	}
+}
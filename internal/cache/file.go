@@ -1,13 +1,18 @@
 package cache
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 	"time"
+
+	"github.com/everstacklabs/sentinel/internal/fsutil"
 )
 
 // Entry represents a cached HTTP response.
@@ -19,10 +24,16 @@ type Entry struct {
 	CachedAt   time.Time `json:"cached_at"`
 }
 
-// FileCache provides TTL-based file caching for HTTP responses.
+// FileCache provides TTL-based file caching for HTTP responses. Entries
+// are stored gzip-compressed. A file's modification time records when it
+// was last used, so eviction under a size limit drops the least recently
+// used entries first.
 type FileCache struct {
-	dir string
-	ttl time.Duration
+	dir     string
+	ttl     time.Duration
+	maxSize int64
+
+	mu sync.Mutex // serializes eviction
 }
 
 // New creates a new file cache.
@@ -33,26 +44,34 @@ func New(dir string, ttl time.Duration) (*FileCache, error) {
 	return &FileCache{dir: dir, ttl: ttl}, nil
 }
 
+// SetMaxSize bounds the total size of the cache directory in bytes. Each
+// Set evicts least recently used entries until the cache fits. Zero, the
+// default, means unbounded.
+func (c *FileCache) SetMaxSize(n int64) {
+	c.maxSize = n
+}
+
 // Get retrieves a cached entry if it exists and hasn't expired.
 func (c *FileCache) Get(key string) (*Entry, bool) {
 	path := c.path(key)
-	data, err := os.ReadFile(path)
-	if err != nil {
+	entry, err := readEntry(path)
+	if os.IsNotExist(err) {
 		return nil, false
 	}
-
-	var entry Entry
-	if err := json.Unmarshal(data, &entry); err != nil {
+	if err != nil {
 		_ = os.Remove(path)
 		return nil, false
 	}
 
-	if time.Since(entry.CachedAt) > c.ttl {
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	if !isFresh(entry, c.ttl) {
 		// Expired but return for conditional fetch (ETag/If-Modified-Since)
-		return &entry, false
+		return entry, false
 	}
 
-	return &entry, true
+	return entry, true
 }
 
 // Set stores an entry in the cache.
@@ -62,14 +81,181 @@ func (c *FileCache) Set(key string, entry *Entry) error {
 	if err != nil {
 		return fmt.Errorf("marshaling cache entry: %w", err)
 	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return fmt.Errorf("compressing cache entry: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("compressing cache entry: %w", err)
+	}
+
 	path := c.path(key)
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
-	return os.WriteFile(path, data, 0o644)
+	// Renaming over the entry means a concurrent Get never reads a partial
+	// one.
+	if err := fsutil.WriteFileAtomic(path, buf.Bytes(), 0o644, false); err != nil {
+		return err
+	}
+	if c.maxSize > 0 {
+		_, _, err = c.Prune(0, c.maxSize)
+	}
+	return err
+}
+
+// Stats describes the contents of a file cache.
+type Stats struct {
+	Entries int
+	Bytes   int64
+	Stale   int // entries older than the cache TTL
+	Corrupt int // entries that can't be read
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// Stats reads every entry in the cache.
+func (c *FileCache) Stats() (Stats, error) {
+	files, err := c.files()
+	if err != nil {
+		return Stats{}, err
+	}
+	var s Stats
+	for _, f := range files {
+		s.Entries++
+		s.Bytes += f.size
+		entry, err := readEntry(f.path)
+		if err != nil {
+			s.Corrupt++
+			continue
+		}
+		if !isFresh(entry, c.ttl) {
+			s.Stale++
+		}
+		if s.Oldest.IsZero() || entry.CachedAt.Before(s.Oldest) {
+			s.Oldest = entry.CachedAt
+		}
+		if entry.CachedAt.After(s.Newest) {
+			s.Newest = entry.CachedAt
+		}
+	}
+	return s, nil
+}
+
+// Prune removes unreadable entries and entries cached more than olderThan
+// ago, then evicts least recently used entries until the cache holds at
+// most maxSize bytes. A zero olderThan or maxSize skips that step. It
+// returns how many entries were removed and how many bytes were freed.
+func (c *FileCache) Prune(olderThan time.Duration, maxSize int64) (removed int, freed int64, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files, err := c.files()
+	if err != nil {
+		return 0, 0, err
+	}
+	remove := func(f cacheFile) {
+		if os.Remove(f.path) == nil {
+			removed++
+			freed += f.size
+		}
+	}
+
+	kept := files[:0]
+	var total int64
+	for _, f := range files {
+		if olderThan > 0 {
+			entry, err := readEntry(f.path)
+			if err != nil || time.Since(entry.CachedAt) > olderThan {
+				remove(f)
+				continue
+			}
+		}
+		kept = append(kept, f)
+		total += f.size
+	}
+
+	if maxSize > 0 && total > maxSize {
+		sort.Slice(kept, func(i, j int) bool { return kept[i].used.Before(kept[j].used) })
+		for _, f := range kept {
+			if total <= maxSize {
+				break
+			}
+			remove(f)
+			total -= f.size
+		}
+	}
+	return removed, freed, nil
+}
+
+type cacheFile struct {
+	path string
+	size int64
+	used time.Time
+}
+
+// files lists the cache entries in the directory. Other files that happen
+// to live there are left alone.
+func (c *FileCache) files() ([]cacheFile, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading cache dir: %w", err)
+	}
+	var files []cacheFile
+	for _, de := range dirEntries {
+		if de.IsDir() || !isHashKey(de.Name()) {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cacheFile{
+			path: filepath.Join(c.dir, de.Name()),
+			size: info.Size(),
+			used: info.ModTime(),
+		})
+	}
+	return files, nil
+}
+
+// readEntry decodes an entry file. Entries written before compression was
+// added are plain JSON and still read.
+func readEntry(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, err
+		}
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
 }
 
 func (c *FileCache) path(key string) string {
-	h := sha256.Sum256([]byte(key))
-	return filepath.Join(c.dir, hex.EncodeToString(h[:]))
+	return filepath.Join(c.dir, hashKey(key))
+}
+
+// isHashKey reports whether name looks like a key made by hashKey.
+func isHashKey(name string) bool {
+	if len(name) != 64 {
+		return false
+	}
+	for _, r := range name {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// httpOpTimeout bounds each cache server round trip, including the body.
+const httpOpTimeout = 5 * time.Second
+
+// HTTPCache stores entries on a plain HTTP key/value server: entries are
+// read with GET {base}/{key} and written with PUT {base}/{key}. This fits
+// WebDAV-style cache servers and object-store buckets fronted by a proxy.
+// Retention of stale entries is left to the server.
+type HTTPCache struct {
+	http    *http.Client
+	baseURL string
+	token   string
+	ttl     time.Duration
+}
+
+// NewHTTP creates a cache backed by the server at baseURL. When token is set
+// it is sent as a bearer token.
+func NewHTTP(baseURL, token string, ttl time.Duration) (*HTTPCache, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("http cache url is required")
+	}
+	return &HTTPCache{
+		http:    &http.Client{Timeout: httpOpTimeout},
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		ttl:     ttl,
+	}, nil
+}
+
+// Get retrieves an entry. Server errors are treated as a miss.
+func (c *HTTPCache) Get(key string) (*Entry, bool) {
+	resp, err := c.do(http.MethodGet, key, nil)
+	if err != nil {
+		slog.Debug("http cache get failed", "key", key, "error", err)
+		return nil, false
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode != http.StatusNotFound {
+			slog.Debug("http cache get failed", "key", key, "status", resp.StatusCode)
+		}
+		return nil, false
+	}
+
+	var entry Entry
+	if err := json.NewDecoder(resp.Body).Decode(&entry); err != nil {
+		return nil, false
+	}
+	return &entry, isFresh(&entry, c.ttl)
+}
+
+// Set uploads an entry.
+func (c *HTTPCache) Set(key string, entry *Entry) error {
+	entry.CachedAt = time.Now()
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshaling cache entry: %w", err)
+	}
+
+	resp, err := c.do(http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("http cache put: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *HTTPCache) do(method, key string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, c.baseURL+"/"+hashKey(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return c.http.Do(req)
+}
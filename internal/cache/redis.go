@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRedisPrefix namespaces sentinel keys in a shared Redis.
+const DefaultRedisPrefix = "sentinel:cache:"
+
+// redisOpTimeout bounds each Redis round trip so a slow cache never stalls discovery.
+const redisOpTimeout = 2 * time.Second
+
+// RedisCache stores entries in Redis so a fleet of runners shares
+// conditional-request state and bodies.
+type RedisCache struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+	retain time.Duration
+}
+
+// NewRedis connects to the Redis server at url (redis://[user:pass@]host:port/db).
+// Entries are fresh for ttl and kept for retain so stale entries can still
+// be revalidated with ETag/If-Modified-Since. retain is raised to ttl if smaller.
+func NewRedis(url, prefix string, ttl, retain time.Duration) (*RedisCache, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("parsing redis url: %w", err)
+	}
+	if prefix == "" {
+		prefix = DefaultRedisPrefix
+	}
+	if retain < ttl {
+		retain = ttl
+	}
+
+	client := redis.NewClient(opts)
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("connecting to redis: %w", err)
+	}
+
+	return &RedisCache{client: client, prefix: prefix, ttl: ttl, retain: retain}, nil
+}
+
+// Get retrieves an entry. Redis errors are treated as a miss.
+func (c *RedisCache) Get(key string) (*Entry, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+
+	data, err := c.client.Get(ctx, c.prefix+hashKey(key)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			slog.Debug("redis cache get failed", "key", key, "error", err)
+		}
+		return nil, false
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, isFresh(&entry, c.ttl)
+}
+
+// Set stores an entry with the retention period as its Redis expiry.
+func (c *RedisCache) Set(key string, entry *Entry) error {
+	entry.CachedAt = time.Now()
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshaling cache entry: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+	return c.client.Set(ctx, c.prefix+hashKey(key), data, c.retain).Err()
+}
+
+// Close releases the Redis connection pool.
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
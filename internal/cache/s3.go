@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GCSEndpoint is the S3-compatible XML API of Google Cloud Storage. It
+// takes HMAC keys in place of AWS access keys.
+const GCSEndpoint = "https://storage.googleapis.com"
+
+// S3Options configures NewS3.
+type S3Options struct {
+	Bucket string
+	Prefix string // prepended to every object key, e.g. "sentinel/cache/"
+	Region string // defaults to us-east-1; GCS accepts "auto"
+	// Endpoint overrides the AWS endpoint for S3-compatible stores such as
+	// GCS or MinIO. Objects are then addressed path-style.
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// S3Cache stores entries as objects in an S3 bucket, or any store that
+// speaks the S3 API with Signature Version 4. Retention of stale entries is
+// left to the bucket's lifecycle rules.
+type S3Cache struct {
+	http *http.Client
+	opts S3Options
+	base string // URL objects are addressed under, ending in "/"
+	ttl  time.Duration
+}
+
+// NewS3 creates a cache in the bucket described by opts.
+func NewS3(opts S3Options, ttl time.Duration) (*S3Cache, error) {
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("s3 cache bucket is required")
+	}
+	if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, fmt.Errorf("s3 cache credentials are required")
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+
+	var base string
+	if opts.Endpoint == "" {
+		base = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/", opts.Bucket, opts.Region)
+	} else {
+		base = strings.TrimRight(opts.Endpoint, "/") + "/" + opts.Bucket + "/"
+	}
+	if _, err := url.Parse(base); err != nil {
+		return nil, fmt.Errorf("parsing s3 endpoint: %w", err)
+	}
+	return &S3Cache{
+		http: &http.Client{Timeout: httpOpTimeout},
+		opts: opts,
+		base: base,
+		ttl:  ttl,
+	}, nil
+}
+
+// Get retrieves an entry. Store errors are treated as a miss.
+func (c *S3Cache) Get(key string) (*Entry, bool) {
+	resp, err := c.do(http.MethodGet, key, nil)
+	if err != nil {
+		slog.Debug("s3 cache get failed", "key", key, "error", err)
+		return nil, false
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode != http.StatusNotFound {
+			slog.Debug("s3 cache get failed", "key", key, "status", resp.StatusCode)
+		}
+		return nil, false
+	}
+
+	var entry Entry
+	if err := json.NewDecoder(resp.Body).Decode(&entry); err != nil {
+		return nil, false
+	}
+	return &entry, isFresh(&entry, c.ttl)
+}
+
+// Set uploads an entry.
+func (c *S3Cache) Set(key string, entry *Entry) error {
+	entry.CachedAt = time.Now()
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshaling cache entry: %w", err)
+	}
+
+	resp, err := c.do(http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("s3 cache put: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *S3Cache) do(method, key string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, c.base+c.opts.Prefix+hashKey(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.sign(req, body, time.Now())
+	return c.http.Do(req)
+}
+
+// sign adds AWS Signature Version 4 headers to req. Only the headers set by
+// do are signed, and object URLs never carry a query string.
+func (c *S3Cache) sign(req *http.Request, body []byte, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if c.opts.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.opts.SessionToken)
+	}
+
+	headers := []string{"host"}
+	values := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		headers = append(headers, lower)
+		values[lower] = strings.TrimSpace(req.Header.Get(name))
+	}
+	sort.Strings(headers)
+	var canonicalHeaders strings.Builder
+	for _, h := range headers {
+		canonicalHeaders.WriteString(h + ":" + values[h] + "\n")
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"", // no query string
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + c.opts.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+c.opts.SecretAccessKey), date)
+	key = hmacSHA256(key, c.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.opts.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
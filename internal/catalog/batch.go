@@ -0,0 +1,30 @@
+package catalog
+
+// Batch describes whether a model can be used through the provider's
+// asynchronous batch API, and at what price.
+type Batch struct {
+	Supported bool `yaml:"supported"`
+	// Discount is the fraction taken off the regular token prices for
+	// batch requests, e.g. 0.5 for half price. Zero means unknown.
+	Discount float64 `yaml:"discount,omitempty"`
+}
+
+// BatchChanges compares batch support. A nil discovered block means the
+// adapter has no opinion, and an unknown discount keeps the catalog's.
+func BatchChanges(existing, discovered *Batch) []FieldChange {
+	if discovered == nil {
+		return nil
+	}
+	if existing == nil {
+		return []FieldChange{{Field: "batch", OldValue: nil, NewValue: discovered}}
+	}
+
+	var changes []FieldChange
+	if existing.Supported != discovered.Supported {
+		changes = append(changes, FieldChange{Field: "batch.supported", OldValue: existing.Supported, NewValue: discovered.Supported})
+	}
+	if discovered.Discount != 0 && !priceEqual(existing.Discount, discovered.Discount) {
+		changes = append(changes, FieldChange{Field: "batch.discount", OldValue: existing.Discount, NewValue: discovered.Discount})
+	}
+	return changes
+}
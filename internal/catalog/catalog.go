@@ -3,8 +3,12 @@ package catalog
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"gopkg.in/yaml.v3"
 )
@@ -20,10 +24,46 @@ type Catalog struct {
 type ProviderCatalog struct {
 	Provider Provider
 	Models   map[string]*Model // keyed by model name
+	// Files maps model names to their files, relative to the catalog and
+	// with forward slashes. Load fills it in.
+	Files map[string]string
 }
 
-// Load reads the entire catalog from disk.
-func Load(basePath string) (*Catalog, error) {
+// LoadOption configures Load.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	providers map[string]bool // nil loads every provider
+	workers   int
+}
+
+// WithProviders loads only the named providers, for a run that only
+// touches those. The others are absent from Catalog.Providers, so a
+// catalog loaded this way must not be used to regenerate the manifest or
+// anything else that covers every provider. Names the catalog doesn't have
+// are ignored.
+func WithProviders(names ...string) LoadOption {
+	return func(o *loadOptions) {
+		o.providers = make(map[string]bool, len(names))
+		for _, n := range names {
+			o.providers[n] = true
+		}
+	}
+}
+
+// WithWorkers sets how many model files are parsed at once. Defaults to
+// GOMAXPROCS.
+func WithWorkers(n int) LoadOption {
+	return func(o *loadOptions) { o.workers = n }
+}
+
+// Load reads the catalog from disk: every provider, or those given with
+// WithProviders. Model files are parsed in parallel.
+func Load(basePath string, opts ...LoadOption) (*Catalog, error) {
+	o := loadOptions{workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&o)
+	}
 	cat := &Catalog{
 		BasePath:  basePath,
 		Providers: make(map[string]*ProviderCatalog),
@@ -43,67 +83,134 @@ func Load(basePath string) (*Catalog, error) {
 		return nil, fmt.Errorf("reading providers dir: %w", err)
 	}
 
+	var files []modelFile
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
 		}
 		providerName := entry.Name()
-		pc, err := loadProvider(providersDir, providerName)
+		if o.providers != nil && !o.providers[providerName] {
+			continue
+		}
+		pc, paths, err := loadProvider(providersDir, providerName)
 		if err != nil {
 			return nil, fmt.Errorf("loading provider %s: %w", providerName, err)
 		}
 		cat.Providers[providerName] = pc
+		for _, path := range paths {
+			files = append(files, modelFile{provider: providerName, path: path})
+		}
+	}
+
+	parseModelFiles(files, o.workers)
+	for _, f := range files {
+		if f.err != nil {
+			return nil, fmt.Errorf("loading provider %s: %w", f.provider, f.err)
+		}
+		pc := cat.Providers[f.provider]
+		pc.Models[f.model.Name] = f.model
+		if rel, err := filepath.Rel(basePath, f.path); err == nil {
+			pc.Files[f.model.Name] = filepath.ToSlash(rel)
+		}
 	}
 
 	return cat, nil
 }
 
-func loadProvider(providersDir, name string) (*ProviderCatalog, error) {
+// modelFile is a model file to parse, and once parsed its model or error.
+type modelFile struct {
+	provider string
+	path     string
+	model    *Model
+	err      error
+}
+
+// parseModelFiles parses files with a pool of workers, in place.
+func parseModelFiles(files []modelFile, workers int) {
+	workers = max(1, min(workers, len(files)))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= len(files) {
+					return
+				}
+				files[i].model, files[i].err = LoadModelFile(files[i].path)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// loadProvider reads a provider's provider.yaml and lists its model files,
+// leaving them to be parsed.
+func loadProvider(providersDir, name string) (*ProviderCatalog, []string, error) {
 	providerDir := filepath.Join(providersDir, name)
 	pc := &ProviderCatalog{
 		Models: make(map[string]*Model),
+		Files:  make(map[string]string),
 	}
 
 	// Load provider.yaml
 	providerFile := filepath.Join(providerDir, "provider.yaml")
 	data, err := os.ReadFile(providerFile)
 	if err != nil {
-		return nil, fmt.Errorf("reading provider.yaml: %w", err)
+		return nil, nil, fmt.Errorf("reading provider.yaml: %w", err)
 	}
 	if err := yaml.Unmarshal(data, &pc.Provider); err != nil {
-		return nil, fmt.Errorf("parsing provider.yaml: %w", err)
+		return nil, nil, fmt.Errorf("parsing provider.yaml: %w", err)
 	}
 
-	// Load models
+	// List models
 	modelsDir := filepath.Join(providerDir, "models")
 	if _, err := os.Stat(modelsDir); os.IsNotExist(err) {
-		return pc, nil // Meta-providers may not have models
+		return pc, nil, nil // Meta-providers may not have models
 	}
 
-	modelFiles, err := os.ReadDir(modelsDir)
+	modelFiles, err := ModelFileNames(modelsDir)
 	if err != nil {
-		return nil, fmt.Errorf("reading models dir: %w", err)
+		return nil, nil, fmt.Errorf("reading models dir: %w", err)
+	}
+	paths := make([]string, len(modelFiles))
+	for i, f := range modelFiles {
+		paths[i] = filepath.Join(modelsDir, filepath.FromSlash(f))
 	}
+	return pc, paths, nil
+}
 
-	for _, f := range modelFiles {
-		if f.IsDir() || !strings.HasSuffix(f.Name(), ".yaml") {
-			continue
-		}
+// LoadModelFile reads and parses a single model file, in the format its
+// extension names. Files with any other extension are read as YAML.
+func LoadModelFile(path string) (*Model, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+	}
 
-		modelPath := filepath.Join(modelsDir, f.Name())
-		data, err := os.ReadFile(modelPath)
-		if err != nil {
-			return nil, fmt.Errorf("reading %s: %w", f.Name(), err)
-		}
+	f, ok := formatForPath(path)
+	if !ok {
+		f = yamlFormat{}
+	}
+	m, err := decodeModel(f, data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+	}
+	return m, nil
+}
 
-		var m Model
-		if err := yaml.Unmarshal(data, &m); err != nil {
-			return nil, fmt.Errorf("parsing %s: %w", f.Name(), err)
+// ModelPath returns the file of a provider's model relative to the
+// catalog, with forward slashes: the one it was loaded from, or where a
+// YAML file for it would go.
+func (c *Catalog) ModelPath(provider, name string) string {
+	if pc := c.Providers[provider]; pc != nil {
+		if rel, ok := pc.Files[name]; ok {
+			return rel
 		}
-		pc.Models[m.Name] = &m
 	}
-
-	return pc, nil
+	return path.Join("providers", provider, "models", name+yamlFormat{}.Ext())
 }
 
 // ModelNames returns sorted model names for a provider.
@@ -0,0 +1,110 @@
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestCatalog writes a catalog of providers with models each under
+// dir.
+func writeTestCatalog(tb testing.TB, dir string, providers, models int) {
+	tb.Helper()
+	write := func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			tb.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	write(filepath.Join(dir, "version.txt"), "1.2.3\n")
+	for p := range providers {
+		name := fmt.Sprintf("provider-%d", p)
+		providerDir := filepath.Join(dir, "providers", name)
+		write(filepath.Join(providerDir, "provider.yaml"), "name: "+name+"\ndisplay_name: Provider\n")
+		for m := range models {
+			write(filepath.Join(providerDir, "models", fmt.Sprintf("model-%d.yaml", m)), fmt.Sprintf(`name: model-%d
+display_name: Model %d
+family: model
+status: stable
+cost:
+  input_per_1k: 0.001
+  output_per_1k: 0.002
+limits:
+  max_tokens: 128000
+  max_completion_tokens: 4096
+capabilities: [chat, function_calling, vision]
+modalities:
+  input: [text, image]
+  output: [text]
+`, m, m))
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCatalog(t, dir, 3, 20)
+
+	tests := []struct {
+		name          string
+		opts          []LoadOption
+		wantProviders int
+	}{
+		{"all", nil, 3},
+		{"one worker", []LoadOption{WithWorkers(1)}, 3},
+		{"more workers than files", []LoadOption{WithWorkers(1000)}, 3},
+		{"scoped", []LoadOption{WithProviders("provider-1", "provider-9")}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cat, err := Load(dir, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cat.Version != "1.2.3" || len(cat.Providers) != tt.wantProviders {
+				t.Fatalf("version %q, %d providers; want %d", cat.Version, len(cat.Providers), tt.wantProviders)
+			}
+			pc := cat.Providers["provider-1"]
+			if pc == nil || len(pc.Models) != 20 || pc.Models["model-7"].DisplayName != "Model 7" || pc.Provider.Name != "provider-1" {
+				t.Errorf("provider-1 = %+v", pc)
+			}
+		})
+	}
+
+	bad := filepath.Join(dir, "providers", "provider-2", "models", "broken.yaml")
+	if err := os.WriteFile(bad, []byte("name: [unclosed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "loading provider provider-2: parsing broken.yaml") {
+		t.Errorf("err = %v, want the broken file named", err)
+	}
+	if _, err := Load(dir, WithProviders("provider-1")); err != nil {
+		t.Errorf("scoped load read a provider outside its scope: %v", err)
+	}
+}
+
+// BenchmarkLoad loads a catalog of 5,000 model files.
+func BenchmarkLoad(b *testing.B) {
+	dir := b.TempDir()
+	writeTestCatalog(b, dir, 10, 500)
+	for _, bb := range []struct {
+		name string
+		opts []LoadOption
+	}{
+		{"serial", []LoadOption{WithWorkers(1)}},
+		{"parallel", nil},
+		{"one provider", []LoadOption{WithProviders("provider-3")}},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			for b.Loop() {
+				if _, err := Load(dir, bb.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package catalog
+
+import "strings"
+
+// Price units for token-based Cost fields.
+const (
+	PriceUnitPer1K = "per_1k"
+	PriceUnitPer1M = "per_1m"
+)
+
+// DefaultCurrency is assumed when a Cost has no currency set.
+const DefaultCurrency = "USD"
+
+// unitScale returns the multiplier that converts a per-1K price into the given unit.
+func unitScale(unit string) float64 {
+	if NormalizeUnit(unit) == PriceUnitPer1M {
+		return 1000
+	}
+	return 1
+}
+
+// NormalizeUnit maps unit spellings ("1m", "per_1M", "per-million") to a
+// canonical PriceUnit constant. Unknown or empty units are treated as per-1K.
+func NormalizeUnit(unit string) string {
+	u := strings.ToLower(strings.TrimSpace(unit))
+	u = strings.NewReplacer("-", "_", " ", "_").Replace(u)
+	switch u {
+	case "per_1m", "1m", "per_million", "million", "per_1000000":
+		return PriceUnitPer1M
+	default:
+		return PriceUnitPer1K
+	}
+}
+
+// CurrencyOrDefault returns the cost currency, defaulting to USD.
+func (c *Cost) CurrencyOrDefault() string {
+	if c == nil || c.Currency == "" {
+		return DefaultCurrency
+	}
+	return strings.ToUpper(c.Currency)
+}
+
+// Normalize returns a copy of the cost with token prices expressed per 1K
+// tokens. The unit field is cleared so the result matches the default
+// catalog representation. Per-image and per-request prices are unit-independent.
+func (c *Cost) Normalize() *Cost {
+	if c == nil {
+		return nil
+	}
+	n := *c
+	scale := unitScale(c.Unit)
+	n.InputPer1K = c.InputPer1K / scale
+	n.OutputPer1K = c.OutputPer1K / scale
+	n.CachedInputPer1K = c.CachedInputPer1K / scale
+	n.CacheWritePer1K = c.CacheWritePer1K / scale
+	n.CacheReadPer1K = c.CacheReadPer1K / scale
+	n.BatchInputPer1K = c.BatchInputPer1K / scale
+	n.BatchOutputPer1K = c.BatchOutputPer1K / scale
+	n.ReasoningPer1K = c.ReasoningPer1K / scale
+	n.Unit = ""
+	return &n
+}
+
+// InUnit returns a copy of the cost with token prices expressed in unit.
+// An empty unit yields the per-1K representation.
+func (c *Cost) InUnit(unit string) *Cost {
+	if c == nil {
+		return nil
+	}
+	n := c.Normalize()
+	if NormalizeUnit(unit) != PriceUnitPer1M {
+		return n
+	}
+	n.InputPer1K *= 1000
+	n.OutputPer1K *= 1000
+	n.CachedInputPer1K *= 1000
+	n.CacheWritePer1K *= 1000
+	n.CacheReadPer1K *= 1000
+	n.BatchInputPer1K *= 1000
+	n.BatchOutputPer1K *= 1000
+	n.ReasoningPer1K *= 1000
+	n.Unit = PriceUnitPer1M
+	return n
+}
+
+// IsZero reports whether the cost carries no pricing data at all,
+// which usually indicates missing data rather than a free model.
+func (c *Cost) IsZero() bool {
+	return c.InputPer1K == 0 && c.OutputPer1K == 0 && c.CachedInputPer1K == 0 &&
+		c.CacheWritePer1K == 0 && c.CacheReadPer1K == 0 && c.BatchInputPer1K == 0 &&
+		c.BatchOutputPer1K == 0 && c.ReasoningPer1K == 0 && c.PerImage == 0 && c.PerRequest == 0
+}
+
+// CostChanges compares two costs after normalizing both to per-1K USD-default
+// form and returns field-level changes. Values are reported per 1K tokens.
+func CostChanges(existing, discovered *Cost) []FieldChange {
+	if discovered == nil {
+		return nil
+	}
+	if existing == nil {
+		return []FieldChange{{Field: "cost", OldValue: nil, NewValue: discovered.Normalize()}}
+	}
+
+	e, d := existing.Normalize(), discovered.Normalize()
+	var changes []FieldChange
+
+	if e.CurrencyOrDefault() != d.CurrencyOrDefault() {
+		changes = append(changes, FieldChange{Field: "cost.currency", OldValue: e.CurrencyOrDefault(), NewValue: d.CurrencyOrDefault()})
+	}
+	if !priceEqual(e.InputPer1K, d.InputPer1K) {
+		changes = append(changes, FieldChange{Field: "cost.input_per_1k", OldValue: e.InputPer1K, NewValue: d.InputPer1K})
+	}
+	if !priceEqual(e.OutputPer1K, d.OutputPer1K) {
+		changes = append(changes, FieldChange{Field: "cost.output_per_1k", OldValue: e.OutputPer1K, NewValue: d.OutputPer1K})
+	}
+	// Optional prices are only compared when the discovered side reports them,
+	// so adapters that don't know about cached/image/request pricing never
+	// clobber values maintained by hand.
+	if d.CachedInputPer1K != 0 && !priceEqual(e.CachedInputPer1K, d.CachedInputPer1K) {
+		changes = append(changes, FieldChange{Field: "cost.cached_input_per_1k", OldValue: e.CachedInputPer1K, NewValue: d.CachedInputPer1K})
+	}
+	if d.CacheWritePer1K != 0 && !priceEqual(e.CacheWritePer1K, d.CacheWritePer1K) {
+		changes = append(changes, FieldChange{Field: "cost.cache_write_per_1k", OldValue: e.CacheWritePer1K, NewValue: d.CacheWritePer1K})
+	}
+	if d.CacheReadPer1K != 0 && !priceEqual(e.CacheReadPer1K, d.CacheReadPer1K) {
+		changes = append(changes, FieldChange{Field: "cost.cache_read_per_1k", OldValue: e.CacheReadPer1K, NewValue: d.CacheReadPer1K})
+	}
+	if d.BatchInputPer1K != 0 && !priceEqual(e.BatchInputPer1K, d.BatchInputPer1K) {
+		changes = append(changes, FieldChange{Field: "cost.batch_input_per_1k", OldValue: e.BatchInputPer1K, NewValue: d.BatchInputPer1K})
+	}
+	if d.BatchOutputPer1K != 0 && !priceEqual(e.BatchOutputPer1K, d.BatchOutputPer1K) {
+		changes = append(changes, FieldChange{Field: "cost.batch_output_per_1k", OldValue: e.BatchOutputPer1K, NewValue: d.BatchOutputPer1K})
+	}
+	if d.ReasoningPer1K != 0 && !priceEqual(e.ReasoningPer1K, d.ReasoningPer1K) {
+		changes = append(changes, FieldChange{Field: "cost.reasoning_per_1k", OldValue: e.ReasoningPer1K, NewValue: d.ReasoningPer1K})
+	}
+	if d.PerImage != 0 && !priceEqual(e.PerImage, d.PerImage) {
+		changes = append(changes, FieldChange{Field: "cost.per_image", OldValue: e.PerImage, NewValue: d.PerImage})
+	}
+	if d.PerRequest != 0 && !priceEqual(e.PerRequest, d.PerRequest) {
+		changes = append(changes, FieldChange{Field: "cost.per_request", OldValue: e.PerRequest, NewValue: d.PerRequest})
+	}
+	changes = append(changes, OffPeakChanges(e.OffPeak, d.OffPeak)...)
+
+	return changes
+}
+
+// priceEqual compares prices with a small relative tolerance so that
+// per-1M → per-1K conversions don't produce spurious float diffs.
+func priceEqual(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	scale := a
+	if b > scale {
+		scale = b
+	}
+	if scale < 0 {
+		scale = -scale
+	}
+	return diff <= scale*1e-9
+}
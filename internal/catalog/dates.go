@@ -0,0 +1,72 @@
+package catalog
+
+import (
+	"strings"
+	"time"
+)
+
+// Date formats of released_at and knowledge_cutoff. A cutoff is often only
+// known to the month.
+const (
+	DateLayout  = "2006-01-02"
+	MonthLayout = "2006-01"
+)
+
+// FormatDate formats t as a released_at date, in UTC. The zero time
+// formats as "".
+func FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(DateLayout)
+}
+
+// UnixDate formats a Unix timestamp in seconds as a released_at date.
+// Zero and negative timestamps format as "".
+func UnixDate(sec int64) string {
+	if sec <= 0 {
+		return ""
+	}
+	return FormatDate(time.Unix(sec, 0))
+}
+
+// cutoffLayouts are the ways providers write cutoff dates in their docs,
+// tried in order.
+var cutoffLayouts = []struct {
+	layout, format string
+}{
+	{DateLayout, DateLayout},
+	{MonthLayout, MonthLayout},
+	{"January 2, 2006", DateLayout},
+	{"Jan 2, 2006", DateLayout},
+	{"January 2006", MonthLayout},
+	{"Jan 2006", MonthLayout},
+	{"Jan. 2006", MonthLayout},
+}
+
+// ParseCutoff reads a knowledge cutoff as written in provider docs, such as
+// "March 2025" or "Jan 2025", and returns it as YYYY-MM (or YYYY-MM-DD when
+// the day is given). It returns "" when s isn't a recognizable date.
+func ParseCutoff(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	// "Sept" is common in docs but not a Go month abbreviation.
+	s = strings.Replace(s, "Sept ", "Sep ", 1)
+	for _, l := range cutoffLayouts {
+		if t, err := time.Parse(l.layout, s); err == nil {
+			return t.Format(l.format)
+		}
+	}
+	return ""
+}
+
+// ValidDate reports whether s is a YYYY-MM-DD date.
+func ValidDate(s string) bool {
+	_, err := time.Parse(DateLayout, s)
+	return err == nil
+}
+
+// ValidCutoff reports whether s is a YYYY-MM or YYYY-MM-DD date.
+func ValidCutoff(s string) bool {
+	_, err := time.Parse(MonthLayout, s)
+	return err == nil || ValidDate(s)
+}
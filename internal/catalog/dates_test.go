@@ -0,0 +1,33 @@
+package catalog
+
+import "testing"
+
+func TestParseCutoff(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"March 2025", "2025-03"},
+		{"Mar 2025", "2025-03"},
+		{"Sept 2024", "2024-09"},
+		{"  July   2024 ", "2024-07"},
+		{"2025-01", "2025-01"},
+		{"2025-01-31", "2025-01-31"},
+		{"January 15, 2025", "2025-01-15"},
+		{"early 2025", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ParseCutoff(tt.in); got != tt.want {
+			t.Errorf("ParseCutoff(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestUnixDate(t *testing.T) {
+	if got := UnixDate(1715367049); got != "2024-05-10" {
+		t.Errorf("UnixDate = %q, want 2024-05-10", got)
+	}
+	if got := UnixDate(0); got != "" {
+		t.Errorf("UnixDate(0) = %q, want empty", got)
+	}
+}
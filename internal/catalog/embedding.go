@@ -0,0 +1,46 @@
+package catalog
+
+// Similarity metrics an embedding model's vectors are meant to be compared
+// with.
+const (
+	SimilarityCosine     = "cosine"
+	SimilarityDotProduct = "dot_product"
+	SimilarityEuclidean  = "euclidean"
+)
+
+// SimilarityMetrics returns the valid similarity values.
+func SimilarityMetrics() []string {
+	return []string{SimilarityCosine, SimilarityDotProduct, SimilarityEuclidean}
+}
+
+// Embedding describes the vectors an embedding model produces. Only models
+// with the embeddings capability carry it. Zero values mean unknown.
+type Embedding struct {
+	Dimensions int `yaml:"dimensions,omitempty"`
+	// MaxBatchSize is how many inputs one request may embed.
+	MaxBatchSize int    `yaml:"max_batch_size,omitempty"`
+	Similarity   string `yaml:"similarity,omitempty"`
+}
+
+// EmbeddingChanges compares embedding metadata. A nil discovered block
+// means the adapter has no opinion, and unknown values keep the catalog's.
+func EmbeddingChanges(existing, discovered *Embedding) []FieldChange {
+	if discovered == nil {
+		return nil
+	}
+	if existing == nil {
+		return []FieldChange{{Field: "embedding", OldValue: nil, NewValue: discovered}}
+	}
+
+	var changes []FieldChange
+	if discovered.Dimensions != 0 && existing.Dimensions != discovered.Dimensions {
+		changes = append(changes, FieldChange{Field: "embedding.dimensions", OldValue: existing.Dimensions, NewValue: discovered.Dimensions})
+	}
+	if discovered.MaxBatchSize != 0 && existing.MaxBatchSize != discovered.MaxBatchSize {
+		changes = append(changes, FieldChange{Field: "embedding.max_batch_size", OldValue: existing.MaxBatchSize, NewValue: discovered.MaxBatchSize})
+	}
+	if discovered.Similarity != "" && existing.Similarity != discovered.Similarity {
+		changes = append(changes, FieldChange{Field: "embedding.similarity", OldValue: existing.Similarity, NewValue: discovered.Similarity})
+	}
+	return changes
+}
@@ -0,0 +1,40 @@
+package catalog
+
+// FineTuning describes whether a model can be fine-tuned, and what training
+// and running the fine-tuned variants cost, in USD per 1K tokens.
+type FineTuning struct {
+	Supported     bool    `yaml:"supported"`
+	TrainingPer1K float64 `yaml:"training_per_1k,omitempty"`
+	InputPer1K    float64 `yaml:"input_per_1k,omitempty"`  // inference on a fine-tuned variant
+	OutputPer1K   float64 `yaml:"output_per_1k,omitempty"` // inference on a fine-tuned variant
+}
+
+// FineTuningChanges compares fine-tuning support. A nil discovered block
+// means the adapter has no opinion; prices are only compared when the
+// discovered side reports them, as in CostChanges.
+func FineTuningChanges(existing, discovered *FineTuning) []FieldChange {
+	if discovered == nil {
+		return nil
+	}
+	if existing == nil {
+		return []FieldChange{{Field: "fine_tuning", OldValue: nil, NewValue: discovered}}
+	}
+
+	var changes []FieldChange
+	if existing.Supported != discovered.Supported {
+		changes = append(changes, FieldChange{Field: "fine_tuning.supported", OldValue: existing.Supported, NewValue: discovered.Supported})
+	}
+	for _, p := range []struct {
+		field    string
+		old, new float64
+	}{
+		{"fine_tuning.training_per_1k", existing.TrainingPer1K, discovered.TrainingPer1K},
+		{"fine_tuning.input_per_1k", existing.InputPer1K, discovered.InputPer1K},
+		{"fine_tuning.output_per_1k", existing.OutputPer1K, discovered.OutputPer1K},
+	} {
+		if p.new != 0 && !priceEqual(p.old, p.new) {
+			changes = append(changes, FieldChange{Field: p.field, OldValue: p.old, NewValue: p.new})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,246 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Model file formats a catalog can be stored in.
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// Format reads and writes model files in one serialization. Every format
+// goes through a yaml.Node tree, whose mappings keep their key order, so
+// smart merge preserves field order and hand-added fields whatever the
+// files are stored as.
+type Format interface {
+	// Ext is the file extension, with the dot.
+	Ext() string
+	// Parse reads a file into a document node.
+	Parse(data []byte) (*yaml.Node, error)
+	// Encode serializes a document node in the given style.
+	Encode(doc *yaml.Node, style Style) ([]byte, error)
+}
+
+// FormatByName returns the format called name, YAML when it is empty.
+func FormatByName(name string) (Format, error) {
+	switch name {
+	case "", FormatYAML:
+		return yamlFormat{}, nil
+	case FormatJSON:
+		return jsonFormat{}, nil
+	}
+	return nil, fmt.Errorf("unknown catalog format %q (want %q or %q)", name, FormatYAML, FormatJSON)
+}
+
+// formatForPath returns the format of a model file from its extension.
+func formatForPath(path string) (Format, bool) {
+	for _, f := range []Format{yamlFormat{}, jsonFormat{}} {
+		if strings.HasSuffix(path, f.Ext()) {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// IsModelFile reports whether name has the extension of a model file in
+// any format.
+func IsModelFile(name string) bool {
+	_, ok := formatForPath(name)
+	return ok
+}
+
+// decodeModel parses a model file in format f.
+func decodeModel(f Format, data []byte) (*Model, error) {
+	doc, err := f.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	var m Model
+	if err := doc.Decode(&m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+type yamlFormat struct{}
+
+func (yamlFormat) Ext() string { return ".yaml" }
+
+func (yamlFormat) Parse(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (yamlFormat) Encode(doc *yaml.Node, style Style) ([]byte, error) {
+	return style.encode(doc)
+}
+
+// jsonFormat stores model files as JSON objects, indented by the style's
+// indent (two spaces by default). Quoting and comments don't apply.
+type jsonFormat struct{}
+
+// defaultJSONIndent is the indentation of JSON files when none is set.
+const defaultJSONIndent = 2
+
+func (jsonFormat) Ext() string { return ".json" }
+
+func (jsonFormat) Parse(data []byte) (*yaml.Node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	root, err := jsonNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the top-level value")
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}, nil
+}
+
+// jsonNode reads the next JSON value from dec as a node.
+func jsonNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch v := tok.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if v == '{' {
+			node.Kind, node.Tag = yaml.MappingNode, "!!map"
+		}
+		for dec.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			child, err := jsonNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		if _, err := dec.Token(); err != nil { // the closing delimiter
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(v)}, nil
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+}
+
+func (jsonFormat) Encode(doc *yaml.Node, style Style) ([]byte, error) {
+	indent := style.Indent
+	if indent == 0 {
+		indent = defaultJSONIndent
+	}
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, doc, strings.Repeat(" ", indent), "\n"); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// writeJSON writes node as JSON, keeping the order of mapping keys.
+func writeJSON(buf *bytes.Buffer, node *yaml.Node, indent, newline string) error {
+	inner := newline + indent
+	switch node.Kind {
+	case yaml.AliasNode:
+		return writeJSON(buf, node.Alias, indent, newline)
+	case yaml.MappingNode, yaml.SequenceNode:
+		open, end := byte('['), byte(']')
+		step := 1
+		if node.Kind == yaml.MappingNode {
+			open, end, step = '{', '}', 2
+		}
+		buf.WriteByte(open)
+		for i := 0; i+step-1 < len(node.Content); i += step {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(inner)
+			if step == 2 {
+				writeJSONString(buf, node.Content[i].Value)
+				buf.WriteString(": ")
+			}
+			if err := writeJSON(buf, node.Content[i+step-1], indent, inner); err != nil {
+				return err
+			}
+		}
+		if len(node.Content) > 0 {
+			buf.WriteString(newline)
+		}
+		buf.WriteByte(end)
+		return nil
+	case yaml.ScalarNode:
+		return writeJSONScalar(buf, node)
+	}
+	return fmt.Errorf("cannot write YAML node kind %d as JSON", node.Kind)
+}
+
+func writeJSONScalar(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.ShortTag() {
+	case "!!str", "!!timestamp":
+		writeJSONString(buf, node.Value)
+		return nil
+	case "!!null":
+		buf.WriteString("null")
+		return nil
+	}
+	// Decode through YAML so spellings such as 0x1F or True come out as
+	// valid JSON.
+	var v any
+	if err := node.Decode(&v); err != nil {
+		return err
+	}
+	if _, ok := v.(string); ok {
+		writeJSONString(buf, node.Value)
+		return nil
+	}
+	if node.ShortTag() == "!!float" && json.Valid([]byte(node.Value)) {
+		buf.WriteString(node.Value) // as written, e.g. 0.0010 rather than 0.001
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("writing %q as JSON: %w", node.Value, err)
+	}
+	buf.Write(data)
+	return nil
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	buf.Truncate(buf.Len() - 1) // Encode adds a newline
+}
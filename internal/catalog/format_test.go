@@ -0,0 +1,88 @@
+package catalog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJSONFormat(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "version.txt", "1.0.0\n")
+	writeFile(t, dir, filepath.Join("providers", "openai", "provider.yaml"), "name: openai\n")
+	// Hand-maintained: its own key order and a field sentinel doesn't know.
+	existing := `{
+  "name": "gpt-5",
+  "status": "stable",
+  "display_name": "GPT-5",
+  "x_notes": {"owner": "platform", "tags": ["a", "b"]},
+  "family": "gpt-5",
+  "cost": {"input_per_1k": 0.00125, "output_per_1k": 0.01},
+  "limits": {"max_tokens": 128000},
+  "capabilities": ["chat"],
+  "modalities": {"input": ["text"], "output": ["text"]}
+}
+`
+	writeFile(t, dir, filepath.Join("providers", "openai", "models", "gpt-5.json"), existing)
+
+	f, err := FormatByName(FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewWriter(dir, WithFormat(f), WithStyle(Style{Indent: 2}))
+	res, err := w.WriteModel("openai", &Model{
+		Name: "gpt-5", DisplayName: "GPT-5", Family: "gpt-5", Status: "stable",
+		Cost:         &Cost{InputPer1K: 0.00125, OutputPer1K: 0.01},
+		Limits:       Limits{MaxTokens: 400000},
+		Capabilities: []string{"chat", "vision"},
+		Modalities:   Modalities{Input: []string{"text"}, Output: []string{"text"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Changes) == 0 || filepath.Ext(res.Path) != ".json" {
+		t.Fatalf("result = %+v", res)
+	}
+	data, err := os.ReadFile(res.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(data) {
+		t.Fatalf("not valid JSON:\n%s", data)
+	}
+	got := string(data)
+	for _, want := range []string{
+		`"x_notes": {
+    "owner": "platform",`,
+		`"max_tokens": 400000`,
+		`"vision"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("merged file lacks %s:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, `"status"`) > strings.Index(got, `"display_name"`) {
+		t.Errorf("merge reordered keys:\n%s", got)
+	}
+
+	if _, err := w.WriteModel("openai", &Model{Name: "gpt-5-mini", Status: "stable", Capabilities: []string{"chat"}}); err != nil {
+		t.Fatal(err)
+	}
+	cat, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc := cat.Providers["openai"]
+	if m := pc.Models["gpt-5"]; m == nil || m.Limits.MaxTokens != 400000 || len(m.Capabilities) != 2 {
+		t.Errorf("loaded gpt-5 = %+v", m)
+	}
+	if got := cat.ModelPath("openai", "gpt-5-mini"); got != "providers/openai/models/gpt-5-mini.json" {
+		t.Errorf("ModelPath = %s", got)
+	}
+
+	if _, err := f.Parse([]byte(`{"name": "x"} {}`)); err == nil {
+		t.Error("trailing data parsed")
+	}
+}
@@ -0,0 +1,67 @@
+package catalog
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ContentHash returns a SHA-256 over the catalog's content: version.txt and
+// every YAML or JSON file under providers/, in path order. It changes exactly when
+// a consumer would see different data, so it works as an ETag. The manifest
+// is left out because it records when it was generated. Line endings are
+// normalized, so a Windows checkout with CRLF files hashes the same.
+func ContentHash(basePath string) (string, error) {
+	files := []string{"version.txt"}
+	err := filepath.WalkDir(filepath.Join(basePath, "providers"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && IsModelFile(d.Name()) {
+			rel, err := filepath.Rel(basePath, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("listing catalog files: %w", err)
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(basePath, filepath.FromSlash(f)))
+		if err != nil {
+			return "", fmt.Errorf("hashing catalog: %w", err)
+		}
+		data = normalizeEOL(data)
+		// Length-prefix both parts so file boundaries can't be shifted.
+		fmt.Fprintf(h, "%d:%s\n%d:", len(f), f, len(data))
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FileHash returns the SHA-256 of one catalog file, with line endings
+// normalized as in ContentHash. The manifest records it for every provider
+// and model file.
+func FileHash(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("hashing %s: %w", path, err)
+	}
+	sum := sha256.Sum256(normalizeEOL(data))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func normalizeEOL(data []byte) []byte {
+	return bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+}
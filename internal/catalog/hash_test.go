@@ -0,0 +1,46 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestContentHash(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("version.txt", "1.0.0\n")
+	write("providers/openai/provider.yaml", "name: openai\n")
+	write("providers/openai/models/gpt-5.yaml", "name: gpt-5\n")
+
+	before, err := ContentHash(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateManifest(dir); err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := ContentHash(dir); after != before {
+		t.Error("generating the manifest changed the content hash")
+	}
+
+	write("providers/openai/models/gpt-5.yaml", "name: gpt-5\nstatus: stable\n")
+	if after, _ := ContentHash(dir); after == before {
+		t.Error("editing a model file did not change the content hash")
+	}
+
+	edited, _ := ContentHash(dir)
+	write("providers/openai/models/gpt-5.yaml", "name: gpt-5\r\nstatus: stable\r\n")
+	if crlf, _ := ContentHash(dir); crlf != edited {
+		t.Error("CRLF line endings changed the content hash")
+	}
+}
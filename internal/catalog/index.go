@@ -0,0 +1,29 @@
+package catalog
+
+import "sort"
+
+// CapabilityIndex maps provider → capability → sorted model names.
+type CapabilityIndex map[string]map[string][]string
+
+// BuildCapabilityIndex indexes every model in the catalog by capability.
+func BuildCapabilityIndex(cat *Catalog) CapabilityIndex {
+	idx := make(CapabilityIndex, len(cat.Providers))
+	for provider, pc := range cat.Providers {
+		byCap := make(map[string][]string)
+		for name, m := range pc.Models {
+			for _, c := range m.Capabilities {
+				byCap[c] = append(byCap[c], name)
+			}
+		}
+		for _, names := range byCap {
+			sort.Strings(names)
+		}
+		idx[provider] = byCap
+	}
+	return idx
+}
+
+// Models returns the models from provider that have capability.
+func (idx CapabilityIndex) Models(provider, capability string) []string {
+	return idx[provider][capability]
+}
@@ -0,0 +1,173 @@
+package catalog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Index subdirectories, under the configured indexes directory.
+const (
+	IndexByCapability = "by-capability"
+	IndexByFamily     = "by-family"
+)
+
+// Index is one generated index file: every model with a capability, or
+// every model in a family.
+type Index struct {
+	Capability string       `yaml:"capability,omitempty"`
+	Family     string       `yaml:"family,omitempty"`
+	Models     []IndexEntry `yaml:"models"`
+}
+
+// IndexEntry is a model in an index, with enough of it to filter on
+// without opening the model file. Prices are per 1K tokens; they are
+// omitted for models without a cost.
+type IndexEntry struct {
+	Provider    string   `yaml:"provider"`
+	Model       string   `yaml:"model"`
+	Path        string   `yaml:"path"` // the model file, relative to the catalog
+	Family      string   `yaml:"family,omitempty"`
+	Status      string   `yaml:"status,omitempty"`
+	InputPer1K  *float64 `yaml:"input_per_1k,omitempty"`
+	OutputPer1K *float64 `yaml:"output_per_1k,omitempty"`
+	Currency    string   `yaml:"currency,omitempty"`
+}
+
+// BuildIndexes groups the catalog's models by capability and by family,
+// keyed by index file path relative to the indexes directory, such as
+// by-capability/vision.yaml. Entries are sorted by input price, cheapest
+// first and unpriced last, so a price ceiling is a prefix of the list.
+func BuildIndexes(cat *Catalog) map[string]*Index {
+	indexes := make(map[string]*Index)
+	add := func(dir, key string, entry IndexEntry, newIndex func(name string) *Index) {
+		name := indexFileName(key)
+		if name == "" {
+			return
+		}
+		file := path.Join(dir, name+".yaml")
+		idx, ok := indexes[file]
+		if !ok {
+			idx = newIndex(name)
+			indexes[file] = idx
+		}
+		idx.Models = append(idx.Models, entry)
+	}
+	for provider, pc := range cat.Providers {
+		for name, m := range pc.Models {
+			entry := IndexEntry{
+				Provider: provider,
+				Model:    name,
+				Path:     cat.ModelPath(provider, name),
+				Family:   m.Family,
+				Status:   m.Status,
+			}
+			if c := m.Cost.Normalize(); c != nil {
+				entry.InputPer1K, entry.OutputPer1K = &c.InputPer1K, &c.OutputPer1K
+				entry.Currency = c.CurrencyOrDefault()
+			}
+			for _, c := range m.Capabilities {
+				add(IndexByCapability, c, entry, func(name string) *Index { return &Index{Capability: name} })
+			}
+			add(IndexByFamily, m.Family, entry, func(name string) *Index { return &Index{Family: name} })
+		}
+	}
+	for _, idx := range indexes {
+		sort.Slice(idx.Models, func(i, j int) bool {
+			a, b := idx.Models[i], idx.Models[j]
+			if (a.InputPer1K == nil) != (b.InputPer1K == nil) {
+				return b.InputPer1K == nil
+			}
+			if a.InputPer1K != nil && *a.InputPer1K != *b.InputPer1K {
+				return *a.InputPer1K < *b.InputPer1K
+			}
+			if a.Provider != b.Provider {
+				return a.Provider < b.Provider
+			}
+			return a.Model < b.Model
+		})
+	}
+	return indexes
+}
+
+// indexFileName turns a capability or family into a file name: lower
+// case, with anything but letters, digits, dots and dashes replaced by a
+// dash.
+func indexFileName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '-'
+	}, strings.ToLower(strings.TrimSpace(key)))
+	return strings.Trim(name, "-.")
+}
+
+// WriteIndexes regenerates the index files under dir, relative to the
+// catalog, from cat, and removes index files for capabilities and
+// families no model has any more. Files that didn't change are left
+// alone. Like model files, every file is written atomically and
+// journaled, so a failed sync rolls the indexes back with the manifest.
+func (w *SmartMergeWriter) WriteIndexes(cat *Catalog, dir string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	root := filepath.Join(w.basePath, dir)
+	indexes := BuildIndexes(cat)
+	for _, sub := range []string{IndexByCapability, IndexByFamily} {
+		entries, err := os.ReadDir(filepath.Join(root, sub))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading indexes: %w", err)
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), ".yaml") || indexes[path.Join(sub, e.Name())] != nil {
+				continue
+			}
+			stale := filepath.Join(root, sub, e.Name())
+			if w.journal != nil {
+				if err := w.journal.Track(stale); err != nil {
+					return err
+				}
+			}
+			if err := os.Remove(stale); err != nil {
+				return fmt.Errorf("removing stale index: %w", err)
+			}
+		}
+	}
+
+	files := make([]string, 0, len(indexes))
+	for file := range indexes {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		data, err := yaml.Marshal(indexes[file])
+		if err != nil {
+			return fmt.Errorf("marshaling index %s: %w", file, err)
+		}
+		data = append([]byte("# Auto-generated with the manifest - DO NOT EDIT MANUALLY\n\n"), data...)
+		full := filepath.Join(root, filepath.FromSlash(file))
+		if existing, err := os.ReadFile(full); err == nil && bytes.Equal(existing, data) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			return fmt.Errorf("creating index dir: %w", err)
+		}
+		if err := w.writeFile(full, data); err != nil {
+			return fmt.Errorf("writing index %s: %w", file, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestWriteIndexes(t *testing.T) {
+	dir := t.TempDir()
+	cat := &Catalog{Providers: map[string]*ProviderCatalog{
+		"groq": {Models: map[string]*Model{
+			"llama-3.3-70b": {Family: "Llama 3.3", Status: "stable", Capabilities: []string{"chat"}, Cost: &Cost{InputPer1K: 0.59, OutputPer1K: 0.79, Unit: "per_1m"}},
+		}},
+		"openai": {Models: map[string]*Model{
+			"gpt-5":      {Family: "gpt-5", Status: "stable", Capabilities: []string{"chat", "vision"}, Cost: &Cost{InputPer1K: 0.00125, OutputPer1K: 0.01}},
+			"gpt-5-mini": {Family: "gpt-5", Status: "stable", Capabilities: []string{"chat", "vision"}, Cost: &Cost{InputPer1K: 0.00025, OutputPer1K: 0.002}},
+			"gpt-5-free": {Family: "gpt-5", Status: "preview", Capabilities: []string{"vision"}},
+		}},
+	}}
+	journal := NewJournal()
+	w := NewWriter(dir, WithJournal(journal))
+	stale := filepath.Join(dir, "indexes", IndexByCapability, "audio.yaml")
+	if err := os.MkdirAll(filepath.Dir(stale), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stale, []byte("models: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteIndexes(cat, "indexes"); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(file string) *Index {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, "indexes", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var idx Index
+		if err := yaml.Unmarshal(data, &idx); err != nil {
+			t.Fatal(err)
+		}
+		return &idx
+	}
+	models := func(idx *Index) []string {
+		var names []string
+		for _, e := range idx.Models {
+			names = append(names, e.Provider+"/"+e.Model)
+		}
+		return names
+	}
+
+	vision := read("by-capability/vision.yaml")
+	if want := []string{"openai/gpt-5-mini", "openai/gpt-5", "openai/gpt-5-free"}; vision.Capability != "vision" || !reflect.DeepEqual(models(vision), want) {
+		t.Errorf("vision index = %s %v, want cheapest first, unpriced last: %v", vision.Capability, models(vision), want)
+	}
+	if e := vision.Models[0]; e.Path != "providers/openai/models/gpt-5-mini.yaml" || *e.InputPer1K != 0.00025 || e.Currency != "USD" {
+		t.Errorf("entry = %+v", e)
+	}
+	if vision.Models[2].InputPer1K != nil {
+		t.Error("model without a cost has a price in the index")
+	}
+	if chat := read("by-capability/chat.yaml"); !reflect.DeepEqual(models(chat), []string{"openai/gpt-5-mini", "groq/llama-3.3-70b", "openai/gpt-5"}) {
+		t.Errorf("chat index = %v, want per_1m prices normalized", models(chat))
+	}
+	if llama := read("by-family/llama-3.3.yaml"); llama.Family != "llama-3.3" || len(llama.Models) != 1 {
+		t.Errorf("family index = %+v", llama)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("index for a capability no model has was not removed")
+	}
+
+	if err := journal.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "indexes", "by-capability", "vision.yaml")); !os.IsNotExist(err) {
+		t.Error("rollback left a new index behind")
+	}
+	if _, err := os.Stat(stale); err != nil {
+		t.Errorf("rollback didn't restore the removed index: %v", err)
+	}
+}
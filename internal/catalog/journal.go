@@ -0,0 +1,81 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/everstacklabs/sentinel/internal/fsutil"
+)
+
+// Journal remembers the content of catalog files before they are first
+// written, so a run that fails partway can put the catalog back the way it
+// found it. It is safe for concurrent use.
+type Journal struct {
+	mu    sync.Mutex
+	files map[string][]byte // nil content: the file didn't exist
+	order []string
+}
+
+// NewJournal returns an empty journal.
+func NewJournal() *Journal {
+	return &Journal{files: make(map[string][]byte)}
+}
+
+// Track records the current content of path, unless it is already
+// tracked. It must be called before the file is changed.
+func (j *Journal) Track(path string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.files[path]; ok {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		data, err = nil, nil
+	}
+	if err != nil {
+		return fmt.Errorf("journaling %s: %w", path, err)
+	}
+	j.files[path] = data
+	j.order = append(j.order, path)
+	return nil
+}
+
+// Files returns the tracked paths in the order they were first touched.
+func (j *Journal) Files() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]string(nil), j.order...)
+}
+
+// Rollback restores every tracked file, removing those that didn't exist,
+// and empties the journal. It keeps going past failures and returns them
+// together.
+func (j *Journal) Rollback() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var errs []error
+	for i := len(j.order) - 1; i >= 0; i-- {
+		path := j.order[i]
+		data := j.files[path]
+		var err error
+		if data == nil {
+			err = os.Remove(path)
+			if errors.Is(err, fs.ErrNotExist) {
+				err = nil
+			}
+		} else {
+			err = fsutil.WriteFileAtomic(path, data, 0o644, false)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("restoring %s: %w", path, err))
+		}
+	}
+	j.files = make(map[string][]byte)
+	j.order = nil
+	return errors.Join(errs...)
+}
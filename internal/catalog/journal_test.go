@@ -0,0 +1,57 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournalRollback(t *testing.T) {
+	dir := t.TempDir()
+	j := NewJournal()
+	w := NewWriter(dir, WithJournal(j), WithFsync(true))
+
+	existing := &Model{Name: "gpt-4o", DisplayName: "GPT-4o", Family: "gpt-4o", Status: "stable", Limits: Limits{MaxTokens: 128000}}
+	if _, err := NewWriter(dir).WriteModel("openai", existing); err != nil {
+		t.Fatal(err)
+	}
+	oldPath := filepath.Join(dir, "providers", "openai", "models", "gpt-4o.yaml")
+	before, err := os.ReadFile(oldPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated := *existing
+	updated.Limits.MaxTokens = 256000
+	if _, err := w.WriteModel("openai", &updated); err != nil {
+		t.Fatal(err)
+	}
+	updated.Status = "deprecated"
+	if _, err := w.WriteModel("openai", &updated); err != nil {
+		t.Fatal(err)
+	}
+	added := &Model{Name: "gpt-5", DisplayName: "GPT-5", Family: "gpt-5", Status: "stable"}
+	if _, err := w.WriteModel("openai", added); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(j.Files()); got != 2 {
+		t.Errorf("journal tracks %d files, want 2", got)
+	}
+
+	if err := j.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	after, err := os.ReadFile(oldPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Errorf("after rollback:\n%s\nwant the original:\n%s", after, before)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "providers", "openai", "models", "gpt-5.yaml")); !os.IsNotExist(err) {
+		t.Errorf("new model file still exists after rollback: %v", err)
+	}
+	if len(j.Files()) != 0 {
+		t.Error("journal not emptied by rollback")
+	}
+}
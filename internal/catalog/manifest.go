@@ -3,12 +3,16 @@ package catalog
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/everstacklabs/sentinel/internal/fsutil"
 )
 
 // ManifestProvider describes a provider entry in the manifest.
@@ -16,6 +20,8 @@ type ManifestProvider struct {
 	Name   string   `yaml:"name"`
 	Files  []string `yaml:"files"`
 	Models []string `yaml:"models,omitempty"`
+	// Hashes maps each of Files and Models to its FileHash.
+	Hashes map[string]string `yaml:"hashes,omitempty"`
 }
 
 // ManifestStats holds aggregate counts.
@@ -31,6 +37,7 @@ type Manifest struct {
 	Version       string             `yaml:"version"`
 	GeneratedAt   string             `yaml:"generated_at"`
 	SchemaVersion string             `yaml:"schema_version"`
+	ContentHash   string             `yaml:"content_hash"` // see ContentHash; the digest VerifyManifest checks
 	Providers     []ManifestProvider `yaml:"providers"`
 	Stats         ManifestStats      `yaml:"stats"`
 }
@@ -66,15 +73,8 @@ func GenerateManifest(basePath string) error {
 		providerDir := filepath.Join(providersDir, name)
 
 		mp := ManifestProvider{Name: name}
-
-		// Add standard provider files
-		for _, f := range []string{"provider.yaml", "categories.yaml", "templates.yaml"} {
-			relPath := filepath.Join("providers", name, f)
-			absPath := filepath.Join(basePath, relPath)
-			if _, err := os.Stat(absPath); err == nil {
-				mp.Files = append(mp.Files, relPath)
-			}
-		}
+		mp.Files, mp.Models = manifestFiles(basePath, name)
+		totalModels += len(mp.Models)
 
 		// Detect provider type
 		providerYAML := filepath.Join(providerDir, "provider.yaml")
@@ -89,18 +89,13 @@ func GenerateManifest(basePath string) error {
 			}
 		}
 
-		// Scan models
-		modelsDir := filepath.Join(providerDir, "models")
-		if modelEntries, err := os.ReadDir(modelsDir); err == nil {
-			var modelFiles []string
-			for _, mf := range modelEntries {
-				if !mf.IsDir() && strings.HasSuffix(mf.Name(), ".yaml") {
-					modelFiles = append(modelFiles, filepath.Join("providers", name, "models", mf.Name()))
-				}
+		mp.Hashes = make(map[string]string, len(mp.Files)+len(mp.Models))
+		for _, rel := range append(slices.Clone(mp.Files), mp.Models...) {
+			sum, err := FileHash(filepath.Join(basePath, filepath.FromSlash(rel)))
+			if err != nil {
+				return err
 			}
-			sort.Strings(modelFiles)
-			mp.Models = modelFiles
-			totalModels += len(modelFiles)
+			mp.Hashes[rel] = sum
 		}
 
 		providers = append(providers, mp)
@@ -110,10 +105,16 @@ func GenerateManifest(basePath string) error {
 		return providers[i].Name < providers[j].Name
 	})
 
+	contentHash, err := ContentHash(basePath)
+	if err != nil {
+		return err
+	}
+
 	manifest := Manifest{
 		Version:       version,
 		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
-		SchemaVersion: "1.0",
+		SchemaVersion: "1.1",
+		ContentHash:   contentHash,
 		Providers:     providers,
 		Stats: ManifestStats{
 			TotalProviders:  len(providers),
@@ -132,5 +133,26 @@ func GenerateManifest(basePath string) error {
 	header := "# Model Catalog Manifest\n# Auto-generated - DO NOT EDIT MANUALLY\n# Run: sentinel sync or ./scripts/generate-manifest.sh to regenerate\n\n"
 	output := header + string(data)
 
-	return os.WriteFile(filepath.Join(basePath, "manifest.yaml"), []byte(output), 0o644)
+	return fsutil.WriteFileAtomic(filepath.Join(basePath, "manifest.yaml"), []byte(output), 0o644, false)
+}
+
+// manifestFiles lists the files the manifest records for a provider, as
+// slash-separated paths relative to basePath, so a manifest generated on
+// one OS verifies on another: its standard provider files and its model
+// files.
+func manifestFiles(basePath, name string) (files, models []string) {
+	for _, f := range []string{"provider.yaml", "categories.yaml", "templates.yaml"} {
+		relPath := path.Join("providers", name, f)
+		if _, err := os.Stat(filepath.Join(basePath, filepath.FromSlash(relPath))); err == nil {
+			files = append(files, relPath)
+		}
+	}
+	modelsDir := filepath.Join(basePath, "providers", name, "models")
+	if modelNames, err := ModelFileNames(modelsDir); err == nil {
+		for _, mf := range modelNames {
+			models = append(models, path.Join("providers", name, "models", mf))
+		}
+		sort.Strings(models)
+	}
+	return files, models
 }
@@ -1,23 +1,63 @@
 package catalog
 
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
 // Model represents a model YAML file in the catalog.
 // Fields match the existing catalog schema exactly.
 type Model struct {
-	Name         string     `yaml:"name"`
-	DisplayName  string     `yaml:"display_name"`
-	Family       string     `yaml:"family"`
-	Status       string     `yaml:"status"`
-	Cost         *Cost      `yaml:"cost,omitempty"`
-	Limits       Limits     `yaml:"limits"`
-	Capabilities []string   `yaml:"capabilities"`
-	Modalities   Modalities `yaml:"modalities"`
-	XUpdater     *XUpdater  `yaml:"x_updater,omitempty"`
-}
-
-// Cost represents model pricing.
+	Name         string      `yaml:"name"`
+	Aliases      []string    `yaml:"aliases,omitempty" merge:"merge"` // former names, kept by renames
+	DisplayName  string      `yaml:"display_name"`
+	Family       string      `yaml:"family"`
+	Status       string      `yaml:"status"`
+	ModelType    string      `yaml:"model_type,omitempty"`
+	Readiness    string      `yaml:"readiness,omitempty"`
+	Cost         *Cost       `yaml:"cost,omitempty"`
+	Batch        *Batch      `yaml:"batch,omitempty"`
+	FineTuning   *FineTuning `yaml:"fine_tuning,omitempty"`
+	Embedding    *Embedding  `yaml:"embedding,omitempty"`
+	Limits       Limits      `yaml:"limits"`
+	RateLimits   []RateLimit `yaml:"rate_limits,omitempty"`
+	Capabilities []string    `yaml:"capabilities"`
+	Modalities   Modalities  `yaml:"modalities"`
+	// KnowledgeCutoff is YYYY-MM or YYYY-MM-DD; ReleasedAt is YYYY-MM-DD.
+	KnowledgeCutoff string    `yaml:"knowledge_cutoff,omitempty"`
+	ReleasedAt      string    `yaml:"released_at,omitempty"`
+	XProvider       XProvider `yaml:"x_provider,omitempty" merge:"replace"`
+	XUpdater        *XUpdater `yaml:"x_updater,omitempty" merge:"replace"`
+	// Locked lists fields Sentinel must never change, such as "cost" or
+	// "limits.max_tokens", for values a maintainer corrected by hand.
+	Locked []string `yaml:"x_locked,omitempty"`
+}
+
+// Cost represents model pricing. Token prices are expressed in Unit
+// (per 1K tokens when empty) and Currency (USD when empty).
 type Cost struct {
-	InputPer1K  float64 `yaml:"input_per_1k"`
-	OutputPer1K float64 `yaml:"output_per_1k"`
+	InputPer1K       float64 `yaml:"input_per_1k"`
+	OutputPer1K      float64 `yaml:"output_per_1k"`
+	CachedInputPer1K float64 `yaml:"cached_input_per_1k,omitempty"`
+	// Explicit prompt caching, billed apart from input: writing a prefix
+	// to the cache and reading it back on a hit.
+	CacheWritePer1K float64 `yaml:"cache_write_per_1k,omitempty"`
+	CacheReadPer1K  float64 `yaml:"cache_read_per_1k,omitempty"`
+	// Token prices through the provider's batch API, as published. The
+	// batch block's discount is the same saving as a fraction.
+	BatchInputPer1K  float64 `yaml:"batch_input_per_1k,omitempty"`
+	BatchOutputPer1K float64 `yaml:"batch_output_per_1k,omitempty"`
+	ReasoningPer1K   float64 `yaml:"reasoning_per_1k,omitempty"` // reasoning/thinking tokens
+	PerImage         float64 `yaml:"per_image,omitempty"`
+	PerRequest       float64 `yaml:"per_request,omitempty"`
+	Currency         string  `yaml:"currency,omitempty"`
+	Unit             string  `yaml:"unit,omitempty"`
+	// OffPeak is a discount on the token prices above during a daily
+	// window; nil when the provider has none.
+	OffPeak *OffPeak `yaml:"off_peak,omitempty"`
 }
 
 // Limits represents model token limits.
@@ -32,10 +72,153 @@ type Modalities struct {
 	Output []string `yaml:"output"`
 }
 
+// XProvider holds provider-specific metadata (e.g. a speed or latency tier)
+// that has no place in the shared schema. Sentinel treats the block as
+// opaque: adapters own its contents, and it is compared and replaced whole.
+type XProvider map[string]any
+
+// XProviderChanges reports a change when discovered has an x_provider block
+// that differs from existing. A nil discovered block means the adapter has no
+// opinion, so whatever is in the catalog is kept.
+func XProviderChanges(existing, discovered XProvider) []FieldChange {
+	if discovered == nil || canonicalYAML(existing) == canonicalYAML(discovered) {
+		return nil
+	}
+	return []FieldChange{{Field: "x_provider", OldValue: existing, NewValue: discovered}}
+}
+
+// canonicalYAML serializes v with sorted keys, so values decoded from a file
+// and values built by an adapter (int vs int64, say) compare equal.
+func canonicalYAML(v XProvider) string {
+	if len(v) == 0 {
+		return ""
+	}
+	data, err := yaml.Marshal(map[string]any(v))
+	if err != nil {
+		return fmt.Sprintf("%v", map[string]any(v))
+	}
+	return string(data)
+}
+
 // XUpdater holds updater-specific metadata appended to model files.
 type XUpdater struct {
 	LastVerifiedAt string   `yaml:"last_verified_at"`
 	Sources        []string `yaml:"sources"`
+	// Fields records where the value of each top-level field came from and
+	// when Sentinel last set it. A field without an entry was set by hand
+	// or predates provenance tracking.
+	Fields map[string]FieldSource `yaml:"fields,omitempty"`
+}
+
+// SourceManual is the provenance of a value a person set, such as a model
+// edited during sentinel review. Discovered values have the adapter source
+// they came from: api, docs or llm.
+const SourceManual = "manual"
+
+// FieldSource is the provenance of one model field.
+type FieldSource struct {
+	Source    string `yaml:"source"`
+	UpdatedAt string `yaml:"updated_at,omitempty"` // RFC 3339; empty until written
+}
+
+// IsLocked reports whether field, a path such as "cost.input_per_1k", is
+// pinned by x_locked: listed itself or nested under a listed field.
+func (m *Model) IsLocked(field string) bool {
+	for _, l := range m.Locked {
+		if field == l || strings.HasPrefix(field, l+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsModelField reports whether path, such as "limits.max_tokens", names a
+// field of the model schema. Any key under an opaque block like x_provider
+// counts.
+func IsModelField(path string) bool {
+	t := reflect.TypeOf(Model{})
+	for _, key := range strings.Split(path, ".") {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Map:
+			return true
+		case reflect.Struct:
+		default:
+			return false
+		}
+		f, ok := yamlField(t, key)
+		if !ok {
+			return false
+		}
+		t = f.Type
+	}
+	return true
+}
+
+func yamlField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if name, _, _ := strings.Cut(f.Tag.Get("yaml"), ","); name == key {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// WithoutLocked drops the changes to fields m locks.
+func (m *Model) WithoutLocked(changes []FieldChange) []FieldChange {
+	if len(m.Locked) == 0 {
+		return changes
+	}
+	var out []FieldChange
+	for _, c := range changes {
+		if !m.IsLocked(c.Field) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// FieldSources returns the provenance recorded for each field of m.
+func (m *Model) FieldSources() map[string]FieldSource {
+	if m.XUpdater == nil {
+		return nil
+	}
+	return m.XUpdater.Fields
+}
+
+// PopulatedFields lists the top-level fields of m that have a value, by
+// their YAML keys.
+func (m *Model) PopulatedFields() []string {
+	set := []struct {
+		key string
+		ok  bool
+	}{
+		{"display_name", m.DisplayName != ""},
+		{"family", m.Family != ""},
+		{"status", m.Status != ""},
+		{"model_type", m.ModelType != ""},
+		{"cost", m.Cost != nil},
+		{"batch", m.Batch != nil},
+		{"fine_tuning", m.FineTuning != nil},
+		{"embedding", m.Embedding != nil},
+		{"limits", m.Limits != (Limits{})},
+		{"rate_limits", len(m.RateLimits) > 0},
+		{"capabilities", len(m.Capabilities) > 0},
+		{"modalities", len(m.Modalities.Input)+len(m.Modalities.Output) > 0},
+		{"knowledge_cutoff", m.KnowledgeCutoff != ""},
+		{"released_at", m.ReleasedAt != ""},
+		{"x_provider", len(m.XProvider) > 0},
+	}
+	var fields []string
+	for _, f := range set {
+		if f.ok {
+			fields = append(fields, f.key)
+		}
+	}
+	return fields
 }
 
 // Provider represents a provider.yaml file.
@@ -44,4 +227,18 @@ type Provider struct {
 	DisplayName             string `yaml:"display_name"`
 	ProviderType            string `yaml:"provider_type"`
 	SupportsModelDiscovery  bool   `yaml:"supports_model_discovery"`
+	BaseURL                 string `yaml:"base_url,omitempty"`
+	AuthType                string `yaml:"auth_type,omitempty"`
+	StatusPage              string `yaml:"status_page,omitempty"`
 }
+
+// Auth types of provider.yaml: how a client passes its API key.
+const (
+	AuthBearer = "bearer" // Authorization: Bearer <key>
+	AuthHeader = "header" // a provider-specific header, such as x-api-key
+	AuthQuery  = "query"  // a query parameter, such as ?key=
+	AuthNone   = "none"
+)
+
+// AuthTypes lists the valid auth_type values.
+var AuthTypes = []string{AuthBearer, AuthHeader, AuthQuery, AuthNone}
@@ -0,0 +1,68 @@
+package catalog
+
+import "slices"
+
+// Model types. A model's type decides which validation rules apply to it
+// and lets a catalog keep only the kinds of models it serves.
+const (
+	ModelTypeChat            = "chat"
+	ModelTypeEmbedding       = "embedding"
+	ModelTypeImageGeneration = "image_generation"
+	ModelTypeSpeechToText    = "speech_to_text"
+	ModelTypeTextToSpeech    = "text_to_speech"
+	ModelTypeRerank          = "rerank"
+)
+
+// ModelTypes returns the valid model_type values.
+func ModelTypes() []string {
+	return []string{
+		ModelTypeChat, ModelTypeEmbedding, ModelTypeImageGeneration,
+		ModelTypeSpeechToText, ModelTypeTextToSpeech, ModelTypeRerank,
+	}
+}
+
+// IsValidModelType reports whether t is a known model type.
+func IsValidModelType(t string) bool {
+	return slices.Contains(ModelTypes(), t)
+}
+
+// TokenLimited reports whether models of type t are sized in tokens, so
+// that limits.max_tokens applies to them. Image and speech models are
+// limited by other means, if at all.
+func TokenLimited(t string) bool {
+	switch t {
+	case ModelTypeImageGeneration, ModelTypeSpeechToText, ModelTypeTextToSpeech:
+		return false
+	}
+	return true
+}
+
+// InferModelType derives a model type from capabilities and modalities,
+// for model files written before model_type existed.
+func InferModelType(capabilities []string, mod Modalities) string {
+	chat := slices.Contains(capabilities, "chat") || slices.Contains(capabilities, "completions")
+	switch {
+	case slices.Contains(capabilities, ModelTypeImageGeneration):
+		return ModelTypeImageGeneration
+	case slices.Contains(capabilities, ModelTypeSpeechToText):
+		return ModelTypeSpeechToText
+	case slices.Contains(capabilities, ModelTypeTextToSpeech):
+		return ModelTypeTextToSpeech
+	case chat:
+		return ModelTypeChat
+	case slices.Contains(capabilities, "embeddings") || slices.Contains(mod.Output, "embedding"):
+		return ModelTypeEmbedding
+	case slices.Contains(capabilities, "rerank") || slices.Contains(mod.Output, "score"):
+		return ModelTypeRerank
+	}
+	return ModelTypeChat
+}
+
+// Type returns the model's type: model_type when it is set, otherwise the
+// type its capabilities and modalities imply.
+func (m *Model) Type() string {
+	if m.ModelType != "" {
+		return m.ModelType
+	}
+	return InferModelType(m.Capabilities, m.Modalities)
+}
@@ -0,0 +1,26 @@
+package catalog
+
+import "testing"
+
+func TestModelType(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Model
+		want string
+	}{
+		{"chat", Model{Capabilities: []string{"chat", "vision"}}, ModelTypeChat},
+		{"embedding", Model{Capabilities: []string{"embeddings"}}, ModelTypeEmbedding},
+		{"embedding output", Model{Modalities: Modalities{Output: []string{"embedding"}}}, ModelTypeEmbedding},
+		{"rerank", Model{Capabilities: []string{"rerank"}}, ModelTypeRerank},
+		{"score output", Model{Modalities: Modalities{Output: []string{"score"}}}, ModelTypeRerank},
+		{"chat and embeddings", Model{Capabilities: []string{"chat", "embeddings"}}, ModelTypeChat},
+		{"speech", Model{Capabilities: []string{"text_to_speech"}}, ModelTypeTextToSpeech},
+		{"explicit", Model{ModelType: ModelTypeImageGeneration, Capabilities: []string{"chat"}}, ModelTypeImageGeneration},
+		{"nothing to go on", Model{}, ModelTypeChat},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Type(); got != tt.want {
+			t.Errorf("%s: Type() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
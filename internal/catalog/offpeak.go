@@ -0,0 +1,67 @@
+package catalog
+
+import (
+	"fmt"
+	"time"
+)
+
+// OffPeak is a discount on a model's token prices during a daily window,
+// such as DeepSeek's overnight pricing. Start and End are "HH:MM" in UTC;
+// a window that crosses midnight has End before Start.
+type OffPeak struct {
+	// Discount is the fraction taken off the token prices in the window,
+	// e.g. 0.75 for a quarter of the price.
+	Discount float64 `yaml:"discount"`
+	Start    string  `yaml:"start"`
+	End      string  `yaml:"end"`
+}
+
+// Contains reports whether t falls in the window.
+func (o *OffPeak) Contains(t time.Time) bool {
+	start, err := ParseClock(o.Start)
+	if err != nil {
+		return false
+	}
+	end, err := ParseClock(o.End)
+	if err != nil {
+		return false
+	}
+	t = t.UTC()
+	now := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if start <= end {
+		return now >= start && now < end
+	}
+	return now >= start || now < end
+}
+
+// ParseClock parses an "HH:MM" time of day as the time since midnight.
+func ParseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an HH:MM time", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// OffPeakChanges compares off-peak pricing. A nil discovered block means
+// the adapter has no opinion, as for BatchChanges.
+func OffPeakChanges(existing, discovered *OffPeak) []FieldChange {
+	if discovered == nil {
+		return nil
+	}
+	if existing == nil {
+		return []FieldChange{{Field: "cost.off_peak", OldValue: nil, NewValue: discovered}}
+	}
+
+	var changes []FieldChange
+	if !priceEqual(existing.Discount, discovered.Discount) {
+		changes = append(changes, FieldChange{Field: "cost.off_peak.discount", OldValue: existing.Discount, NewValue: discovered.Discount})
+	}
+	if existing.Start != discovered.Start {
+		changes = append(changes, FieldChange{Field: "cost.off_peak.start", OldValue: existing.Start, NewValue: discovered.Start})
+	}
+	if existing.End != discovered.End {
+		changes = append(changes, FieldChange{Field: "cost.off_peak.end", OldValue: existing.End, NewValue: discovered.End})
+	}
+	return changes
+}
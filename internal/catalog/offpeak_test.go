@@ -0,0 +1,72 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOffPeakChanges(t *testing.T) {
+	deepseek := &OffPeak{Discount: 0.5, Start: "16:30", End: "00:30"}
+	tests := []struct {
+		name       string
+		existing   *OffPeak
+		discovered *OffPeak
+		want       []string
+	}{
+		{"no opinion", deepseek, nil, nil},
+		{"block added", nil, deepseek, []string{"cost.off_peak"}},
+		{"unchanged", deepseek, &OffPeak{Discount: 0.5, Start: "16:30", End: "00:30"}, nil},
+		{"discount and window changed", deepseek, &OffPeak{Discount: 0.75, Start: "16:30", End: "01:00"}, []string{"cost.off_peak.discount", "cost.off_peak.end"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range OffPeakChanges(tt.existing, tt.discovered) {
+				got = append(got, c.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("OffPeakChanges() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Cost comparisons include the block.
+	changes := CostChanges(&Cost{InputPer1K: 0.001, OutputPer1K: 0.002}, &Cost{InputPer1K: 0.001, OutputPer1K: 0.002, OffPeak: deepseek})
+	if len(changes) != 1 || changes[0].Field != "cost.off_peak" {
+		t.Errorf("CostChanges() = %+v, want the off-peak block added", changes)
+	}
+}
+
+func TestOffPeakContains(t *testing.T) {
+	overnight := &OffPeak{Discount: 0.5, Start: "16:30", End: "00:30"}
+	daytime := &OffPeak{Discount: 0.5, Start: "09:00", End: "17:00"}
+	at := func(clock string) time.Time {
+		t, _ := time.Parse("15:04", clock)
+		return t
+	}
+	tests := []struct {
+		window *OffPeak
+		clock  string
+		want   bool
+	}{
+		{overnight, "16:30", true},
+		{overnight, "23:59", true},
+		{overnight, "00:29", true},
+		{overnight, "00:30", false},
+		{overnight, "12:00", false},
+		{daytime, "12:00", true},
+		{daytime, "17:00", false},
+		{&OffPeak{Start: "bad", End: "00:30"}, "00:00", false},
+	}
+	for _, tt := range tests {
+		if got := tt.window.Contains(at(tt.clock)); got != tt.want {
+			t.Errorf("%s-%s contains %s = %v, want %v", tt.window.Start, tt.window.End, tt.clock, got, tt.want)
+		}
+	}
+
+	// 01:00 in Beijing is 17:00 UTC.
+	if !overnight.Contains(time.Date(2026, 1, 1, 1, 0, 0, 0, time.FixedZone("CST", 8*3600))) {
+		t.Error("times should be compared in UTC")
+	}
+}
@@ -0,0 +1,103 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrCaseCollision is returned when writing a model whose file name differs
+// only in case from an existing one. On case-insensitive filesystems, the
+// default on Windows and macOS, both names refer to the same file.
+var ErrCaseCollision = errors.New("model file name collides with another model on case-insensitive filesystems")
+
+// CaseCollisions groups names that are equal ignoring case. Each group is
+// sorted; names without a collision are left out.
+func CaseCollisions(names []string) [][]string {
+	byFold := make(map[string][]string)
+	for _, n := range names {
+		key := strings.ToLower(n)
+		byFold[key] = append(byFold[key], n)
+	}
+	var groups [][]string
+	for _, g := range byFold {
+		if len(g) > 1 {
+			sort.Strings(g)
+			groups = append(groups, g)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+	return groups
+}
+
+// checkCaseCollision returns ErrCaseCollision if dir holds a file named
+// like name but for case.
+func checkCaseCollision(dir, name string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.Name() != name && strings.EqualFold(e.Name(), name) {
+			return fmt.Errorf("%s and %s: %w", name, e.Name(), ErrCaseCollision)
+		}
+	}
+	return nil
+}
+
+// FineTunedDir is the subdirectory of a provider's models directory that
+// holds an organization's fine-tuned models. Their names carry it as a
+// prefix, as in "fine_tuned/gpt-4o-mini-acme-abc123", so every model's file
+// is still models/<name>.yaml.
+const FineTunedDir = "fine_tuned"
+
+// IsFineTuned reports whether name is the name of a fine-tuned model.
+func IsFineTuned(name string) bool {
+	return strings.HasPrefix(name, FineTunedDir+"/")
+}
+
+// ErrInvalidModelName is returned when writing a model whose name can't be
+// a model file name: one with a slash, other than after the FineTunedDir
+// prefix, or that is "." or "..". Its file would land where Load never
+// reads it, or outside the provider.
+var ErrInvalidModelName = errors.New("model name is not a valid file name")
+
+// checkModelName returns ErrInvalidModelName unless name's file is directly
+// in the models directory or in FineTunedDir, where ModelFileNames looks.
+func checkModelName(name string) error {
+	base := strings.TrimPrefix(name, FineTunedDir+"/")
+	if base == "" || base == "." || base == ".." || strings.ContainsAny(base, `/\`) {
+		return fmt.Errorf("%q: %w", name, ErrInvalidModelName)
+	}
+	return nil
+}
+
+// ModelFileNames lists the model files in a provider's models directory,
+// in any format and including those under FineTunedDir, relative to it and
+// with forward slashes. A missing directory has no files.
+func ModelFileNames(modelsDir string) ([]string, error) {
+	var names []string
+	for _, sub := range []string{"", FineTunedDir} {
+		entries, err := os.ReadDir(filepath.Join(modelsDir, sub))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() && IsModelFile(e.Name()) {
+				names = append(names, path.Join(sub, e.Name()))
+			}
+		}
+	}
+	return names, nil
+}
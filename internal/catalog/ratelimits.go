@@ -0,0 +1,57 @@
+package catalog
+
+import "fmt"
+
+// RateLimit is a provider's published limit for a model on one account
+// tier. Zero means the provider publishes no limit for that dimension.
+type RateLimit struct {
+	Tier string `yaml:"tier"`
+	RPM  int    `yaml:"rpm,omitempty"` // requests per minute
+	RPD  int    `yaml:"rpd,omitempty"` // requests per day
+	TPM  int    `yaml:"tpm,omitempty"` // tokens per minute
+	TPD  int    `yaml:"tpd,omitempty"` // tokens per day
+}
+
+// RateLimitChanges compares rate limits tier by tier. A nil discovered list
+// means the adapter has no opinion. Otherwise the discovered list replaces
+// the catalog's on write, so tiers it lacks are reported as removed.
+func RateLimitChanges(existing, discovered []RateLimit) []FieldChange {
+	if discovered == nil {
+		return nil
+	}
+	old := make(map[string]RateLimit, len(existing))
+	for _, rl := range existing {
+		old[rl.Tier] = rl
+	}
+
+	var changes []FieldChange
+	seen := make(map[string]bool, len(discovered))
+	for _, d := range discovered {
+		seen[d.Tier] = true
+		prefix := "rate_limits." + d.Tier
+		e, ok := old[d.Tier]
+		if !ok {
+			changes = append(changes, FieldChange{Field: prefix, OldValue: nil, NewValue: d})
+			continue
+		}
+		for _, f := range []struct {
+			name     string
+			old, new int
+		}{
+			{"rpm", e.RPM, d.RPM},
+			{"rpd", e.RPD, d.RPD},
+			{"tpm", e.TPM, d.TPM},
+			{"tpd", e.TPD, d.TPD},
+		} {
+			if f.old != f.new {
+				changes = append(changes, FieldChange{Field: fmt.Sprintf("%s.%s", prefix, f.name), OldValue: f.old, NewValue: f.new})
+			}
+		}
+	}
+	for _, e := range existing {
+		if !seen[e.Tier] {
+			changes = append(changes, FieldChange{Field: "rate_limits." + e.Tier, OldValue: e, NewValue: nil})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,74 @@
+package catalog
+
+import "fmt"
+
+// Readiness levels track how far a model has progressed from discovery to
+// general availability. Sentinel sets discovered on new models, probes (or
+// operators) promote to verified, and a merged PR promotes to approved.
+const (
+	ReadinessDiscovered = "discovered"
+	ReadinessVerified   = "verified"
+	ReadinessApproved   = "approved"
+	ReadinessGA         = "ga"
+)
+
+// readinessRank orders readiness levels from least to most trusted.
+var readinessRank = map[string]int{
+	ReadinessDiscovered: 1,
+	ReadinessVerified:   2,
+	ReadinessApproved:   3,
+	ReadinessGA:         4,
+}
+
+// ReadinessLevels returns all readiness levels in promotion order.
+func ReadinessLevels() []string {
+	return []string{ReadinessDiscovered, ReadinessVerified, ReadinessApproved, ReadinessGA}
+}
+
+// IsValidReadiness reports whether s is a known readiness level.
+func IsValidReadiness(s string) bool {
+	_, ok := readinessRank[s]
+	return ok
+}
+
+// EffectiveReadiness returns the model's readiness level. Models written
+// before readiness tracking existed have no level and are treated as approved,
+// since they were merged by a human.
+func (m *Model) EffectiveReadiness() string {
+	if m.Readiness == "" {
+		return ReadinessApproved
+	}
+	return m.Readiness
+}
+
+// ReadinessAtLeast reports whether the model's effective readiness is at or
+// above min. Unknown levels never satisfy a minimum.
+func (m *Model) ReadinessAtLeast(min string) bool {
+	have, ok := readinessRank[m.EffectiveReadiness()]
+	if !ok {
+		return false
+	}
+	return have >= readinessRank[min]
+}
+
+// CheckPromotion validates a readiness transition. Only forward moves are
+// allowed unless force is set; staying at the same level is not a move. A
+// model without a level counts as approved, as in EffectiveReadiness.
+func CheckPromotion(from, to string, force bool) error {
+	if !IsValidReadiness(to) {
+		return fmt.Errorf("unknown readiness level %q", to)
+	}
+	if force {
+		return nil
+	}
+	if from == "" {
+		from = ReadinessApproved
+	}
+	if !IsValidReadiness(from) {
+		return fmt.Errorf("current readiness %q is unknown, use force to overwrite", from)
+	}
+	if readinessRank[to] < readinessRank[from] {
+		return fmt.Errorf("cannot move readiness from %s to %s", from, to)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package catalog
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultIndent matches yaml.v3's default indentation.
+const DefaultIndent = 4
+
+// Quote styles for string values written by sentinel.
+const (
+	QuotePlain  = ""       // quote only when YAML requires it
+	QuoteSingle = "single" // 'value'
+	QuoteDouble = "double" // "value"
+)
+
+// Line endings of written files.
+const (
+	LineEndingAuto = ""     // keep each existing file's; LF for new files
+	LineEndingLF   = "lf"   // \n
+	LineEndingCRLF = "crlf" // \r\n, for catalogs edited on Windows without autocrlf
+)
+
+// Style controls how sentinel formats the YAML it writes so generated files
+// match the conventions of a hand-maintained catalog repo.
+type Style struct {
+	// Indent is the number of spaces per nesting level (0 means DefaultIndent).
+	Indent int
+	// Quote is applied to string values sentinel writes. Fields it doesn't
+	// own, such as hand-added keys, keep whatever quoting they had.
+	Quote string
+	// KeyOrder lists keys in the order new files should use. Nested keys
+	// are dotted ("cost.input_per_1k"). Unlisted keys follow the listed
+	// ones in their default order.
+	KeyOrder []string
+	// LineEnding is LineEndingAuto, LineEndingLF or LineEndingCRLF.
+	LineEnding string
+}
+
+// Validate reports settings yaml.v3 can't honor.
+func (s Style) Validate() error {
+	if s.Indent != 0 && (s.Indent < 2 || s.Indent > 9) {
+		return fmt.Errorf("yaml indent must be between 2 and 9, got %d", s.Indent)
+	}
+	switch s.Quote {
+	case QuotePlain, QuoteSingle, QuoteDouble:
+	default:
+		return fmt.Errorf("unknown yaml quote style %q (want %q or %q)", s.Quote, QuoteSingle, QuoteDouble)
+	}
+	switch s.LineEnding {
+	case LineEndingAuto, LineEndingLF, LineEndingCRLF:
+	default:
+		return fmt.Errorf("unknown yaml line ending %q (want %q or %q)", s.LineEnding, LineEndingLF, LineEndingCRLF)
+	}
+	return nil
+}
+
+// lineEndings converts encoded YAML, which always uses \n, to the
+// configured line endings. existing is the file's previous content, if any.
+func (s Style) lineEndings(data, existing []byte) []byte {
+	crlf := s.LineEnding == LineEndingCRLF ||
+		s.LineEnding == LineEndingAuto && bytes.Contains(existing, []byte("\r\n"))
+	if !crlf {
+		return data
+	}
+	return bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+}
+
+// encode serializes a node tree with the configured indentation.
+func (s Style) encode(node *yaml.Node) ([]byte, error) {
+	indent := s.Indent
+	if indent == 0 {
+		indent = DefaultIndent
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// applyQuotes sets the quote style on every string value under node.
+// Mapping keys are left plain.
+func (s Style) applyQuotes(node *yaml.Node) {
+	var style yaml.Style
+	switch s.Quote {
+	case QuoteSingle:
+		style = yaml.SingleQuotedStyle
+	case QuoteDouble:
+		style = yaml.DoubleQuotedStyle
+	default:
+		return
+	}
+	quoteValues(node, style)
+}
+
+func quoteValues(node *yaml.Node, style yaml.Style) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, c := range node.Content {
+			quoteValues(c, style)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			quoteValues(node.Content[i], style)
+		}
+	case yaml.ScalarNode:
+		if node.Tag == "!!str" {
+			node.Style = style
+		}
+	}
+}
+
+// applyKeyOrder reorders mapping keys according to KeyOrder.
+func (s Style) applyKeyOrder(node *yaml.Node) {
+	if len(s.KeyOrder) == 0 {
+		return
+	}
+	rank := make(map[string]int, len(s.KeyOrder))
+	for i, k := range s.KeyOrder {
+		rank[k] = i
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	orderMapping(node, "", rank)
+}
+
+func orderMapping(node *yaml.Node, prefix string, rank map[string]int) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	type pair struct{ key, value *yaml.Node }
+	pairs := make([]pair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, pair{node.Content[i], node.Content[i+1]})
+	}
+
+	position := func(p pair) int {
+		if r, ok := rank[prefix+p.key.Value]; ok {
+			return r
+		}
+		return len(rank)
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return position(pairs[i]) < position(pairs[j])
+	})
+
+	node.Content = node.Content[:0]
+	for _, p := range pairs {
+		node.Content = append(node.Content, p.key, p.value)
+		orderMapping(p.value, prefix+p.key.Value+".", rank)
+	}
+}
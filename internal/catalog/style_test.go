@@ -0,0 +1,207 @@
+package catalog
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteNewModelWithStyle(t *testing.T) {
+	tmpDir := t.TempDir()
+	w := NewWriter(tmpDir, WithStyle(Style{
+		Indent:   2,
+		Quote:    QuoteDouble,
+		KeyOrder: []string{"name", "status", "display_name", "limits", "cost", "cost.output_per_1k"},
+	}))
+
+	m := &Model{
+		Name:         "gpt-5",
+		DisplayName:  "GPT-5",
+		Family:       "gpt-5",
+		Status:       "stable",
+		Cost:         &Cost{InputPer1K: 0.00125, OutputPer1K: 0.01},
+		Capabilities: []string{"chat"},
+		Limits:       Limits{MaxTokens: 400000},
+		Modalities:   Modalities{Input: []string{"text"}, Output: []string{"text"}},
+	}
+
+	result, err := w.WriteModel("openai", m)
+	if err != nil {
+		t.Fatalf("WriteModel failed: %v", err)
+	}
+	data, err := os.ReadFile(result.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `name: "gpt-5"
+status: "stable"
+display_name: "GPT-5"
+limits:
+  max_tokens: 400000
+cost:
+  output_per_1k: 0.01
+  input_per_1k: 0.00125
+family: "gpt-5"
+capabilities:
+  - "chat"
+modalities:
+  input:
+    - "text"
+  output:
+    - "text"
+`
+	if string(data) != want {
+		t.Errorf("written YAML:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestWriteUpdatedModelQuotesOnlyWrittenValues(t *testing.T) {
+	tmpDir := t.TempDir()
+	modelsDir := filepath.Join(tmpDir, "providers", "openai", "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	existing := `name: gpt-4o
+display_name: GPT-4o
+family: gpt-4o
+status: preview
+limits:
+  max_tokens: 128000
+capabilities:
+  - chat
+modalities:
+  input:
+    - text
+  output:
+    - text
+internal_notes: keep as is
+`
+	path := filepath.Join(modelsDir, "gpt-4o.yaml")
+	if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewWriter(tmpDir, WithStyle(Style{Indent: 2, Quote: QuoteSingle}))
+	_, err := w.WriteModel("openai", &Model{
+		Name:         "gpt-4o",
+		DisplayName:  "GPT-4o",
+		Family:       "gpt-4o",
+		Status:       "stable",
+		Limits:       Limits{MaxTokens: 128000},
+		Capabilities: []string{"chat"},
+		Modalities:   Modalities{Input: []string{"text"}, Output: []string{"text"}},
+	})
+	if err != nil {
+		t.Fatalf("WriteModel failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `name: 'gpt-4o'
+display_name: 'GPT-4o'
+family: 'gpt-4o'
+status: 'stable'
+limits:
+  max_tokens: 128000
+capabilities:
+  - 'chat'
+modalities:
+  input:
+    - 'text'
+  output:
+    - 'text'
+internal_notes: keep as is
+`
+	if string(data) != want {
+		t.Errorf("written YAML:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestStyleValidate(t *testing.T) {
+	tests := []struct {
+		style   Style
+		wantErr bool
+	}{
+		{Style{}, false},
+		{Style{Indent: 2, Quote: QuoteDouble}, false},
+		{Style{Indent: 1}, true},
+		{Style{Indent: 12}, true},
+		{Style{Quote: "backtick"}, true},
+		{Style{LineEnding: LineEndingCRLF}, false},
+		{Style{LineEnding: "cr"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.style.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) = %v, wantErr %v", tt.style, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWriteModelLineEndings(t *testing.T) {
+	m := &Model{Name: "glm-4", DisplayName: "GLM-4", Family: "glm", Status: "stable", Limits: Limits{MaxTokens: 128000}}
+	crlf := []byte("\r\n")
+
+	tests := []struct {
+		name     string
+		ending   string
+		existing string // "" for a new file
+		wantCRLF bool
+	}{
+		{"new file defaults to LF", LineEndingAuto, "", false},
+		{"new file with crlf", LineEndingCRLF, "", true},
+		{"auto keeps CRLF", LineEndingAuto, "name: glm-4\r\nfamily: glm\r\nx_notes: hand written\r\n", true},
+		{"auto keeps LF", LineEndingAuto, "name: glm-4\nfamily: glm\n", false},
+		{"lf converts CRLF", LineEndingLF, "name: glm-4\r\nfamily: glm\r\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "providers", "zhipuai", "models", "glm-4.yaml")
+			if tt.existing != "" {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(tt.existing), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := NewWriter(dir, WithStyle(Style{LineEnding: tt.ending})).WriteModel("zhipuai", m); err != nil {
+				t.Fatal(err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := bytes.Contains(data, crlf); got != tt.wantCRLF {
+				t.Errorf("CRLF = %v, want %v:\n%q", got, tt.wantCRLF, data)
+			}
+			if tt.wantCRLF && bytes.Count(data, []byte("\n")) != bytes.Count(data, crlf) {
+				t.Errorf("mixed line endings:\n%q", data)
+			}
+			loaded, err := LoadModelFile(path)
+			if err != nil || loaded.DisplayName != "GLM-4" {
+				t.Errorf("reloaded model = %+v, %v", loaded, err)
+			}
+		})
+	}
+}
+
+func TestWriteModelRefusesCaseCollision(t *testing.T) {
+	dir := t.TempDir()
+	w := NewWriter(dir)
+	if _, err := w.WriteModel("minimax", &Model{Name: "MiniMax-M1", Family: "minimax", Status: "stable"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := w.WriteModel("minimax", &Model{Name: "minimax-m1", Family: "minimax", Status: "stable"})
+	if !errors.Is(err, ErrCaseCollision) {
+		t.Fatalf("err = %v, want ErrCaseCollision", err)
+	}
+	if _, err := w.WriteModel("minimax", &Model{Name: "MiniMax-M1", Family: "minimax", Status: "preview"}); err != nil {
+		t.Errorf("updating the existing file: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Ways a catalog on disk can differ from its manifest.
+const (
+	MismatchModified = "modified" // the file's hash differs from the manifest's
+	MismatchMissing  = "missing"  // the manifest lists a file that isn't there
+	MismatchUnlisted = "unlisted" // a provider or model file the manifest doesn't list
+	MismatchVersion  = "version"  // version.txt differs from the manifest's version
+	MismatchDigest   = "digest"   // the catalog's content hash differs from content_hash
+)
+
+// ManifestMismatch is one difference between the catalog on disk and its
+// manifest.
+type ManifestMismatch struct {
+	Path string // relative to the catalog root
+	Kind string // one of the Mismatch constants
+}
+
+func (m ManifestMismatch) String() string {
+	return m.Kind + ": " + m.Path
+}
+
+// ErrNoHashes means the manifest predates per-file hashes and can't be
+// verified until it is regenerated.
+var ErrNoHashes = errors.New("manifest has no file hashes; regenerate it with sentinel sync")
+
+// VerifyManifest checks the catalog at basePath against its manifest.yaml,
+// returning every file that was changed, removed or added since the
+// manifest was generated, sorted by path. None means the catalog is exactly
+// what the manifest describes. A consumer can run it after a checkout to
+// catch hand edits or a sync that was cut short.
+func VerifyManifest(basePath string) ([]ManifestMismatch, error) {
+	data, err := os.ReadFile(filepath.Join(basePath, "manifest.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+
+	// Paths are compared slash-separated. Manifests generated on Windows
+	// before that was the rule have backslashes.
+	want := make(map[string]string)
+	for _, p := range m.Providers {
+		for _, rel := range append(append([]string(nil), p.Files...), p.Models...) {
+			sum, ok := p.Hashes[rel]
+			if !ok {
+				return nil, ErrNoHashes
+			}
+			want[strings.ReplaceAll(rel, `\`, "/")] = sum
+		}
+	}
+
+	var mismatches []ManifestMismatch
+	for rel, sum := range want {
+		got, err := FileHash(filepath.Join(basePath, filepath.FromSlash(rel)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			mismatches = append(mismatches, ManifestMismatch{Path: rel, Kind: MismatchMissing})
+		case err != nil:
+			return nil, err
+		case got != sum:
+			mismatches = append(mismatches, ManifestMismatch{Path: rel, Kind: MismatchModified})
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(basePath, "providers"))
+	if err != nil {
+		return nil, fmt.Errorf("reading providers dir: %w", err)
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		files, models := manifestFiles(basePath, e.Name())
+		for _, rel := range append(files, models...) {
+			if _, ok := want[rel]; !ok {
+				mismatches = append(mismatches, ManifestMismatch{Path: rel, Kind: MismatchUnlisted})
+			}
+		}
+	}
+
+	version, err := os.ReadFile(filepath.Join(basePath, "version.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("reading version.txt: %w", err)
+	}
+	if strings.TrimSpace(string(version)) != m.Version {
+		mismatches = append(mismatches, ManifestMismatch{Path: "version.txt", Kind: MismatchVersion})
+	}
+
+	// The digest also covers YAML files the manifest doesn't list, so it
+	// can differ when every listed file matches.
+	digest, err := ContentHash(basePath)
+	if err != nil {
+		return nil, err
+	}
+	if digest != m.ContentHash {
+		mismatches = append(mismatches, ManifestMismatch{Path: "manifest.yaml", Kind: MismatchDigest})
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].Path != mismatches[j].Path {
+			return mismatches[i].Path < mismatches[j].Path
+		}
+		return mismatches[i].Kind < mismatches[j].Kind
+	})
+	return mismatches, nil
+}
@@ -0,0 +1,120 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVerifyManifest(t *testing.T) {
+	// Manifest paths are slash-separated on every OS.
+	gpt5 := "providers/openai/models/gpt-5.yaml"
+	tests := []struct {
+		name   string
+		change func(t *testing.T, dir string)
+		want   []ManifestMismatch
+	}{
+		{"unchanged", func(t *testing.T, dir string) {}, nil},
+		{"crlf checkout", func(t *testing.T, dir string) {
+			writeFile(t, dir, gpt5, "name: gpt-5\r\n")
+		}, nil},
+		{"edited model", func(t *testing.T, dir string) {
+			writeFile(t, dir, gpt5, "name: gpt-5\nstatus: deprecated\n")
+		}, []ManifestMismatch{
+			{Path: "manifest.yaml", Kind: MismatchDigest},
+			{Path: gpt5, Kind: MismatchModified},
+		}},
+		{"partial sync", func(t *testing.T, dir string) {
+			if err := os.Remove(filepath.Join(dir, gpt5)); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, dir, "providers/openai/models/gpt-6.yaml", "name: gpt-6\n")
+			writeFile(t, dir, "version.txt", "1.1.0\n")
+		}, []ManifestMismatch{
+			{Path: "manifest.yaml", Kind: MismatchDigest},
+			{Path: gpt5, Kind: MismatchMissing},
+			{Path: "providers/openai/models/gpt-6.yaml", Kind: MismatchUnlisted},
+			{Path: "version.txt", Kind: MismatchVersion},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, dir, "version.txt", "1.0.0\n")
+			writeFile(t, dir, filepath.Join("providers", "openai", "provider.yaml"), "name: openai\n")
+			writeFile(t, dir, gpt5, "name: gpt-5\n")
+			if err := GenerateManifest(dir); err != nil {
+				t.Fatal(err)
+			}
+			tt.change(t, dir)
+
+			got, err := VerifyManifest(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mismatches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A manifest written by another OS, including one from before paths
+	// were slash-separated on Windows.
+	for _, sep := range []string{"/", `\`} {
+		t.Run("manifest with "+sep+" separators", func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, dir, "version.txt", "1.0.0\n")
+			writeFile(t, dir, "providers/openai/provider.yaml", "name: openai\n")
+			writeFile(t, dir, gpt5, "name: gpt-5\n")
+			digest, err := ContentHash(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			entry := func(rel string) (string, string) {
+				sum, err := FileHash(filepath.Join(dir, filepath.FromSlash(rel)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return strings.ReplaceAll(rel, "/", sep), sum
+			}
+			provider, providerSum := entry("providers/openai/provider.yaml")
+			model, modelSum := entry(gpt5)
+			writeFile(t, dir, "manifest.yaml", fmt.Sprintf(
+				"version: 1.0.0\ncontent_hash: %s\nproviders:\n  - name: openai\n    files: [%q]\n    models: [%q]\n    hashes:\n      %q: %s\n      %q: %s\n",
+				digest, provider, model, provider, providerSum, model, modelSum))
+
+			got, err := VerifyManifest(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 0 {
+				t.Errorf("mismatches = %v, want none", got)
+			}
+		})
+	}
+
+	t.Run("manifest without hashes", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "version.txt", "1.0.0\n")
+		writeFile(t, dir, gpt5, "name: gpt-5\n")
+		writeFile(t, dir, "manifest.yaml", "version: 1.0.0\nproviders:\n  - name: openai\n    files: []\n    models: [providers/openai/models/gpt-5.yaml]\n")
+		if _, err := VerifyManifest(dir); !errors.Is(err, ErrNoHashes) {
+			t.Errorf("err = %v, want ErrNoHashes", err)
+		}
+	})
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -4,8 +4,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/everstacklabs/sentinel/internal/fsutil"
 )
 
 // FieldChange records a single field change for diff reporting.
@@ -26,26 +32,72 @@ type WriteResult struct {
 // - Preserves manually-added fields not in the discovered model
 // - Preserves field ordering from existing file
 // - Only updates fields the adapter has authoritative data for
+//
+// Files are replaced atomically, so a crash never leaves a half-written
+// model behind. A writer is safe for concurrent use.
 type SmartMergeWriter struct {
 	basePath string
+	style    Style
+	format   Format
+	fsync    bool
+	journal  *Journal
+
+	mu sync.Mutex // serializes read-merge-write of model files
+}
+
+// WriterOption configures a SmartMergeWriter.
+type WriterOption func(*SmartMergeWriter)
+
+// WithStyle sets the indentation, quoting and key order of written YAML.
+func WithStyle(s Style) WriterOption {
+	return func(w *SmartMergeWriter) { w.style = s }
+}
+
+// WithFormat writes model files in f; YAML by default. Provider files are
+// always YAML.
+func WithFormat(f Format) WriterOption {
+	return func(w *SmartMergeWriter) { w.format = f }
+}
+
+// WithFsync flushes every written file to disk before the write returns.
+func WithFsync(enabled bool) WriterOption {
+	return func(w *SmartMergeWriter) { w.fsync = enabled }
+}
+
+// WithJournal records each file's content in j before it is first
+// written, so the caller can roll the writes back.
+func WithJournal(j *Journal) WriterOption {
+	return func(w *SmartMergeWriter) { w.journal = j }
 }
 
 // NewWriter creates a new SmartMergeWriter.
-func NewWriter(basePath string) *SmartMergeWriter {
-	return &SmartMergeWriter{basePath: basePath}
+func NewWriter(basePath string, opts ...WriterOption) *SmartMergeWriter {
+	w := &SmartMergeWriter{basePath: basePath, format: yamlFormat{}}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
 }
 
 // WriteModel performs a smart merge of a discovered model into the catalog.
 // It loads the existing YAML as a node tree (preserving order and unknown fields),
 // overlays the discovered fields, and writes back.
 func (w *SmartMergeWriter) WriteModel(provider string, discovered *Model) (*WriteResult, error) {
-	modelsDir := filepath.Join(w.basePath, "providers", provider, "models")
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Fine-tuned models' names put their files in a subdirectory.
+	if err := checkModelName(discovered.Name); err != nil {
+		return nil, err
+	}
+	filePath := filepath.Join(w.basePath, "providers", provider, "models", filepath.FromSlash(discovered.Name)+w.format.Ext())
+	modelsDir, filename := filepath.Split(filePath)
 	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
 		return nil, fmt.Errorf("creating models dir: %w", err)
 	}
-
-	filename := discovered.Name + ".yaml"
-	filePath := filepath.Join(modelsDir, filename)
+	if err := checkCaseCollision(modelsDir, filename); err != nil {
+		return nil, err
+	}
 
 	result := &WriteResult{Path: filePath}
 
@@ -60,14 +112,14 @@ func (w *SmartMergeWriter) WriteModel(provider string, discovered *Model) (*Writ
 	}
 
 	// Smart merge: parse existing as yaml.Node to preserve structure
-	var existingDoc yaml.Node
-	if err := yaml.Unmarshal(existingData, &existingDoc); err != nil {
-		return nil, fmt.Errorf("parsing existing YAML: %w", err)
+	existingDoc, err := w.format.Parse(existingData)
+	if err != nil {
+		return nil, fmt.Errorf("parsing existing file: %w", err)
 	}
 
-	// Also parse existing into a Model for comparison
+	// Also decode existing into a Model for comparison
 	var existingModel Model
-	if err := yaml.Unmarshal(existingData, &existingModel); err != nil {
+	if err := existingDoc.Decode(&existingModel); err != nil {
 		return nil, fmt.Errorf("parsing existing model: %w", err)
 	}
 
@@ -77,8 +129,19 @@ func (w *SmartMergeWriter) WriteModel(provider string, discovered *Model) (*Writ
 		return result, nil // No changes needed
 	}
 
+	// Keep the price unit the existing file was written in, so a catalog
+	// maintained in per-1M prices isn't silently rewritten to per-1K.
+	toWrite := discovered
+	if existingModel.Cost != nil && discovered.Cost != nil &&
+		NormalizeUnit(existingModel.Cost.Unit) != NormalizeUnit(discovered.Cost.Unit) {
+		c := *discovered
+		c.Cost = discovered.Cost.InUnit(existingModel.Cost.Unit)
+		c.Cost.Unit = existingModel.Cost.Unit
+		toWrite = &c
+	}
+
 	// Merge: serialize discovered to a node, then overlay onto existing
-	discoveredData, err := yaml.Marshal(discovered)
+	discoveredData, err := yaml.Marshal(toWrite)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling discovered model: %w", err)
 	}
@@ -87,32 +150,375 @@ func (w *SmartMergeWriter) WriteModel(provider string, discovered *Model) (*Writ
 	if err := yaml.Unmarshal(discoveredData, &discoveredDoc); err != nil {
 		return nil, fmt.Errorf("parsing discovered YAML: %w", err)
 	}
+	w.style.applyQuotes(&discoveredDoc)
+	for _, field := range existingModel.Locked {
+		pinPath(&discoveredDoc, existingDoc, strings.Split(field, "."))
+	}
 
-	merged := mergeNodes(&existingDoc, &discoveredDoc)
+	merged := mergeNodes(existingDoc, &discoveredDoc)
 
-	out, err := yaml.Marshal(merged)
+	out, err := w.format.Encode(merged, w.style)
 	if err != nil {
-		return nil, fmt.Errorf("marshaling merged YAML: %w", err)
+		return nil, fmt.Errorf("marshaling merged model: %w", err)
 	}
 
-	if err := os.WriteFile(filePath, out, 0o644); err != nil {
+	if err := w.writeFile(filePath, w.style.lineEndings(out, existingData)); err != nil {
 		return nil, fmt.Errorf("writing merged file: %w", err)
 	}
 
 	return result, nil
 }
 
+// SetReadiness updates only the readiness key of an existing model file,
+// leaving every other field untouched. Transitions are checked with
+// CheckPromotion unless force is set.
+func (w *SmartMergeWriter) SetReadiness(provider, name, level string, force bool) (*WriteResult, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := checkModelName(name); err != nil {
+		return nil, err
+	}
+	filePath := filepath.Join(w.basePath, "providers", provider, "models", name+w.format.Ext())
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading model file: %w", err)
+	}
+
+	doc, err := w.format.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing model file: %w", err)
+	}
+	var m Model
+	if err := doc.Decode(&m); err != nil {
+		return nil, fmt.Errorf("parsing model: %w", err)
+	}
+	if err := CheckPromotion(m.Readiness, level, force); err != nil {
+		return nil, err
+	}
+
+	result := &WriteResult{Path: filePath}
+	if m.EffectiveReadiness() == level {
+		return result, nil
+	}
+	result.Changes = []FieldChange{{"readiness", m.Readiness, level}}
+
+	setMappingScalar(doc, "readiness", level, "status")
+
+	out, err := w.format.Encode(doc, w.style)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling model file: %w", err)
+	}
+	if err := w.writeFile(filePath, w.style.lineEndings(out, data)); err != nil {
+		return nil, fmt.Errorf("writing model file: %w", err)
+	}
+	return result, nil
+}
+
+// SetUpdater replaces the x_updater block of an existing model file,
+// leaving every other field untouched. Unlike WriteModel, it writes even
+// when no catalog field changed.
+func (w *SmartMergeWriter) SetUpdater(provider, name string, u *XUpdater) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := checkModelName(name); err != nil {
+		return err
+	}
+	filePath := filepath.Join(w.basePath, "providers", provider, "models", filepath.FromSlash(name)+w.format.Ext())
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("reading model file: %w", err)
+	}
+	doc, err := w.format.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parsing model file: %w", err)
+	}
+	var value yaml.Node
+	if err := value.Encode(u); err != nil {
+		return fmt.Errorf("marshaling x_updater: %w", err)
+	}
+	w.style.applyQuotes(&value)
+	setMappingNode(doc, "x_updater", &value)
+
+	out, err := w.format.Encode(doc, w.style)
+	if err != nil {
+		return fmt.Errorf("marshaling model file: %w", err)
+	}
+	if err := w.writeFile(filePath, w.style.lineEndings(out, data)); err != nil {
+		return fmt.Errorf("writing model file: %w", err)
+	}
+	return nil
+}
+
+// WriteProvider creates or fills in providers/<name>/provider.yaml from
+// defaults. Fields already set in the file are kept, so manual edits win,
+// and only missing or empty ones are taken from defaults. It reports
+// whether the file was written.
+func (w *SmartMergeWriter) WriteProvider(defaults *Provider) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	dir := filepath.Join(w.basePath, "providers", defaults.Name)
+	filePath := filepath.Join(dir, "provider.yaml")
+	var src yaml.Node
+	if err := src.Encode(defaults); err != nil {
+		return false, fmt.Errorf("marshaling provider: %w", err)
+	}
+	w.style.applyQuotes(&src)
+
+	existing, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return false, fmt.Errorf("creating provider dir: %w", err)
+		}
+		out, err := w.style.encode(&src)
+		if err != nil {
+			return false, fmt.Errorf("marshaling provider: %w", err)
+		}
+		return true, w.writeFile(filePath, w.style.lineEndings(out, nil))
+	} else if err != nil {
+		return false, fmt.Errorf("reading provider.yaml: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(existing, &doc); err != nil {
+		return false, fmt.Errorf("parsing provider.yaml: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return false, fmt.Errorf("provider.yaml is not a mapping")
+	}
+	changed := false
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i].Value, src.Content[i+1]
+		if j := mappingIndex(root, key); j >= 0 && root.Content[j+1].Value != "" {
+			continue
+		}
+		if value.Kind == yaml.ScalarNode && value.Value == "" {
+			continue
+		}
+		setMappingNode(&doc, key, value)
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+
+	out, err := w.style.encode(&doc)
+	if err != nil {
+		return false, fmt.Errorf("marshaling provider: %w", err)
+	}
+	return true, w.writeFile(filePath, w.style.lineEndings(out, existing))
+}
+
+// RenameModel moves a model to a new name. The file of oldName is carried
+// over to discovered.Name with its manual fields and oldName recorded under
+// aliases, then discovered is merged into it as by WriteModel. The old file
+// stays, marked deprecated, for consumers still using the old name.
+func (w *SmartMergeWriter) RenameModel(provider, oldName string, discovered *Model) (*WriteResult, error) {
+	if err := w.carryOver(provider, oldName, discovered.Name); err != nil {
+		return nil, err
+	}
+	return w.WriteModel(provider, discovered)
+}
+
+// carryOver copies the file of oldName to newName, renamed and aliased,
+// and deprecates the original.
+func (w *SmartMergeWriter) carryOver(provider, oldName, newName string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, name := range []string{oldName, newName} {
+		if err := checkModelName(name); err != nil {
+			return err
+		}
+	}
+	modelsDir := filepath.Join(w.basePath, "providers", provider, "models")
+	oldPath := filepath.Join(modelsDir, filepath.FromSlash(oldName)+w.format.Ext())
+	newPath := filepath.Join(modelsDir, filepath.FromSlash(newName)+w.format.Ext())
+	data, err := os.ReadFile(oldPath)
+	if err != nil {
+		return fmt.Errorf("reading model file: %w", err)
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("renaming %s: %s already exists", oldName, newName)
+	}
+	dir, filename := filepath.Split(newPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating models dir: %w", err)
+	}
+	if err := checkCaseCollision(dir, filename); err != nil {
+		return err
+	}
+
+	doc, err := w.format.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parsing model file: %w", err)
+	}
+	setMappingScalar(doc, "name", newName, "")
+	addAlias(doc, oldName)
+	out, err := w.format.Encode(doc, w.style)
+	if err != nil {
+		return fmt.Errorf("marshaling model file: %w", err)
+	}
+	if err := w.writeFile(newPath, w.style.lineEndings(out, data)); err != nil {
+		return fmt.Errorf("writing renamed model: %w", err)
+	}
+
+	old, err := w.format.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parsing model file: %w", err)
+	}
+	setMappingScalar(old, "status", "deprecated", "family")
+	if out, err = w.format.Encode(old, w.style); err != nil {
+		return fmt.Errorf("marshaling model file: %w", err)
+	}
+	if err := w.writeFile(oldPath, w.style.lineEndings(out, data)); err != nil {
+		return fmt.Errorf("deprecating %s: %w", oldName, err)
+	}
+	return nil
+}
+
+// addAlias appends alias to the aliases sequence of doc, creating it after
+// name when missing.
+func addAlias(doc *yaml.Node, alias string) {
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return
+	}
+
+	item := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: alias}
+	insertAt := len(root.Content)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		switch root.Content[i].Value {
+		case "aliases":
+			seq := root.Content[i+1]
+			if seq.Kind != yaml.SequenceNode {
+				*seq = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			}
+			for _, n := range seq.Content {
+				if n.Value == alias {
+					return
+				}
+			}
+			seq.Content = append(seq.Content, item)
+			return
+		case "name":
+			insertAt = i + 2
+		}
+	}
+
+	k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "aliases"}
+	v := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{item}}
+	root.Content = append(root.Content[:insertAt], append([]*yaml.Node{k, v}, root.Content[insertAt:]...)...)
+}
+
+// setMappingScalar sets key to a string value in the top-level mapping of doc.
+// New keys are inserted directly after the key named after, or appended.
+func setMappingScalar(doc *yaml.Node, key, value, after string) {
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return
+	}
+
+	insertAt := len(root.Content)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		switch root.Content[i].Value {
+		case key:
+			root.Content[i+1].Kind = yaml.ScalarNode
+			root.Content[i+1].Tag = "!!str"
+			root.Content[i+1].Value = value
+			return
+		case after:
+			insertAt = i + 2
+		}
+	}
+
+	k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	v := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	root.Content = append(root.Content[:insertAt], append([]*yaml.Node{k, v}, root.Content[insertAt:]...)...)
+}
+
+// setMappingNode sets key to value in the top-level mapping of doc,
+// appending it when missing.
+func setMappingNode(doc *yaml.Node, key string, value *yaml.Node) {
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			keepComments(root.Content[i+1], value)
+			root.Content[i+1] = value
+			liftLineComment(root.Content[i], value)
+			return
+		}
+	}
+	k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	foot := takeClosingComment(root)
+	root.Content = append(root.Content, k, value)
+	setClosingComment(root, foot)
+}
+
 func (w *SmartMergeWriter) writeNewModel(path string, m *Model) error {
-	data, err := yaml.Marshal(m)
+	var doc yaml.Node
+	if err := doc.Encode(m); err != nil {
+		return fmt.Errorf("marshaling model: %w", err)
+	}
+	w.style.applyQuotes(&doc)
+	w.style.applyKeyOrder(&doc)
+
+	data, err := w.format.Encode(&doc, w.style)
 	if err != nil {
 		return fmt.Errorf("marshaling model: %w", err)
 	}
-	return os.WriteFile(path, data, 0o644)
+	return w.writeFile(path, w.style.lineEndings(data, nil))
+}
+
+// writeFile journals path, then replaces it atomically.
+func (w *SmartMergeWriter) writeFile(path string, data []byte) error {
+	if w.journal != nil {
+		if err := w.journal.Track(path); err != nil {
+			return err
+		}
+	}
+	return fsutil.WriteFileAtomic(path, data, 0o644, w.fsync)
 }
 
 // mergeNodes overlays src mapping keys onto dst mapping, preserving dst order
-// and any keys in dst not present in src.
+// and any keys in dst not present in src. Nested mappings are merged the
+// same way, so hand-added keys under cost or limits survive; sequences are
+// replaced. Model fields can change that with a merge struct tag.
 func mergeNodes(dst, src *yaml.Node) *yaml.Node {
+	return mergeMapping(dst, src, reflect.TypeOf(Model{}))
+}
+
+// Merge strategies for the merge struct tag on Model fields.
+const (
+	// MergeReplace replaces the file's value with the discovered one whole,
+	// for blocks Sentinel owns outright, such as x_provider.
+	MergeReplace = "replace"
+	// MergeMerge merges a mapping key by key, the default, or a sequence by
+	// appending the discovered items the file doesn't have.
+	MergeMerge = "merge"
+)
+
+// mergeMapping is mergeNodes for a mapping decoded into t, which is nil
+// when the mapping has no Go type.
+func mergeMapping(dst, src *yaml.Node, t reflect.Type) *yaml.Node {
 	// Handle document nodes
 	if dst.Kind == yaml.DocumentNode && len(dst.Content) > 0 {
 		dst = dst.Content[0]
@@ -136,22 +542,221 @@ func mergeNodes(dst, src *yaml.Node) *yaml.Node {
 	for i := 0; i+1 < len(dst.Content); i += 2 {
 		key := dst.Content[i].Value
 		if srcVal, ok := srcMap[key]; ok {
-			dst.Content[i+1] = srcVal
+			ft, strategy := mergeField(t, key)
+			dst.Content[i+1] = mergeValue(dst.Content[i+1], srcVal, ft, strategy)
+			liftLineComment(dst.Content[i], dst.Content[i+1])
 			seen[key] = true
 		}
 	}
 
-	// Append new keys from src not in dst
+	// Append new keys from src not in dst, below the existing keys but
+	// above a comment closing the mapping, such as one at the end of the
+	// file.
+	foot := takeClosingComment(dst)
 	for i := 0; i+1 < len(src.Content); i += 2 {
 		key := src.Content[i].Value
 		if !seen[key] {
 			dst.Content = append(dst.Content, src.Content[i], src.Content[i+1])
 		}
 	}
+	setClosingComment(dst, foot)
 
 	return dst
 }
 
+// mergeValue merges the value of one key. A value that is replaced keeps
+// the comments of the one it replaces, unless it has its own.
+func mergeValue(dst, src *yaml.Node, t reflect.Type, strategy string) *yaml.Node {
+	switch {
+	case dst == src, strategy == MergeReplace:
+	case dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode:
+		return mergeMapping(dst, src, t)
+	case strategy == MergeMerge && dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode:
+		foot := takeClosingComment(dst)
+		for _, item := range src.Content {
+			if !slices.ContainsFunc(dst.Content, func(n *yaml.Node) bool { return sameScalar(n, item) }) {
+				dst.Content = append(dst.Content, item)
+			}
+		}
+		setClosingComment(dst, foot)
+		return dst
+	}
+	keepComments(dst, src)
+	return src
+}
+
+// keepComments carries the comments of dst over to src, the value
+// replacing it, wherever src has none of its own: onto the node itself,
+// onto the keys and values of a mapping matched by key, and onto the items
+// of a sequence, matched by value when they are scalars and by position
+// otherwise. Comments on keys and items src drops go with them, except the
+// one closing the block, which moves to src's last key or item.
+func keepComments(dst, src *yaml.Node) {
+	if dst == src {
+		return
+	}
+	copyComments(dst, src)
+	if dst.Kind != src.Kind {
+		return
+	}
+	foot := takeClosingComment(dst)
+	switch src.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			if j := mappingIndex(dst, src.Content[i].Value); j >= 0 {
+				copyComments(dst.Content[j], src.Content[i])
+				keepComments(dst.Content[j+1], src.Content[i+1])
+				liftLineComment(src.Content[i], src.Content[i+1])
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range src.Content {
+			j := slices.IndexFunc(dst.Content, func(n *yaml.Node) bool { return sameScalar(n, item) })
+			if j < 0 && item.Kind != yaml.ScalarNode && i < len(dst.Content) {
+				j = i
+			}
+			if j >= 0 {
+				keepComments(dst.Content[j], item)
+			}
+		}
+	}
+	setClosingComment(src, foot)
+}
+
+// copyComments sets each comment of src that is empty to dst's.
+func copyComments(dst, src *yaml.Node) {
+	if src.HeadComment == "" {
+		src.HeadComment = dst.HeadComment
+	}
+	if src.LineComment == "" {
+		src.LineComment = dst.LineComment
+	}
+	if src.FootComment == "" {
+		src.FootComment = dst.FootComment
+	}
+}
+
+// liftLineComment moves the line comment of a block mapping or sequence,
+// such as one carried over from a flow sequence it replaced, onto its key:
+// the line it belongs on, and where yaml.v3 puts it when parsing. yaml.v3
+// would write it after the value's first line instead.
+func liftLineComment(key, value *yaml.Node) {
+	if value.Kind != yaml.MappingNode && value.Kind != yaml.SequenceNode ||
+		value.Style&yaml.FlowStyle != 0 || value.LineComment == "" {
+		return
+	}
+	if key.LineComment == "" {
+		key.LineComment = value.LineComment
+	}
+	value.LineComment = ""
+}
+
+// closingNode returns the node yaml.v3 attaches a comment below a mapping
+// or sequence to: its last key or item. It is nil when node is empty or a
+// scalar.
+func closingNode(node *yaml.Node) *yaml.Node {
+	switch {
+	case node.Kind == yaml.MappingNode && len(node.Content) >= 2:
+		return node.Content[len(node.Content)-2]
+	case node.Kind == yaml.SequenceNode && len(node.Content) > 0:
+		return node.Content[len(node.Content)-1]
+	}
+	return nil
+}
+
+// takeClosingComment removes the comment below the last key or item of
+// node and returns it.
+func takeClosingComment(node *yaml.Node) string {
+	last := closingNode(node)
+	if last == nil {
+		return ""
+	}
+	foot := last.FootComment
+	last.FootComment = ""
+	return foot
+}
+
+// setClosingComment puts foot below the last key or item of node, unless
+// that already has a comment of its own.
+func setClosingComment(node *yaml.Node, foot string) {
+	if last := closingNode(node); last != nil && last.FootComment == "" {
+		last.FootComment = foot
+	}
+}
+
+// sameScalar reports whether two sequence items are the same scalar.
+// Mappings and sequences never match, so they are always appended.
+func sameScalar(a, b *yaml.Node) bool {
+	return a.Kind == yaml.ScalarNode && b.Kind == yaml.ScalarNode && a.Value == b.Value
+}
+
+// mergeField returns the type of the field of t that key decodes into and
+// its merge strategy. A key of a map type has the map's element type; an
+// unknown key has neither.
+func mergeField(t reflect.Type, key string) (reflect.Type, string) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil, ""
+	}
+	switch t.Kind() {
+	case reflect.Map:
+		return t.Elem(), ""
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if name, _, _ := strings.Cut(f.Tag.Get("yaml"), ","); name == key {
+				return f.Type, f.Tag.Get("merge")
+			}
+		}
+	}
+	return nil, ""
+}
+
+// pinPath makes the value at path in src the one dst has, so merging src
+// into dst leaves it alone. A path dst doesn't have is removed from src.
+func pinPath(src, dst *yaml.Node, path []string) {
+	if src.Kind == yaml.DocumentNode && len(src.Content) > 0 {
+		src = src.Content[0]
+	}
+	if dst != nil && dst.Kind == yaml.DocumentNode && len(dst.Content) > 0 {
+		dst = dst.Content[0]
+	}
+	if src.Kind != yaml.MappingNode {
+		return
+	}
+	i := mappingIndex(src, path[0])
+	var dstVal *yaml.Node
+	if dst != nil && dst.Kind == yaml.MappingNode {
+		if j := mappingIndex(dst, path[0]); j >= 0 {
+			dstVal = dst.Content[j+1]
+		}
+	}
+	switch {
+	case len(path) > 1 && i >= 0:
+		pinPath(src.Content[i+1], dstVal, path[1:])
+	case len(path) > 1:
+		// src lacks the parent; dst's value for it is kept whole.
+	case dstVal != nil && i >= 0:
+		src.Content[i+1] = dstVal
+	case dstVal != nil:
+		src.Content = append(src.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}, dstVal)
+	case i >= 0:
+		src.Content = append(src.Content[:i], src.Content[i+2:]...)
+	}
+}
+
+// mappingIndex returns the index of key in a mapping node's content, or -1.
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
 func computeChanges(existing, discovered *Model) []FieldChange {
 	var changes []FieldChange
 
@@ -164,20 +769,20 @@ func computeChanges(existing, discovered *Model) []FieldChange {
 	if existing.Status != discovered.Status && discovered.Status != "" {
 		changes = append(changes, FieldChange{"status", existing.Status, discovered.Status})
 	}
-
-	// Cost changes
-	if discovered.Cost != nil {
-		if existing.Cost == nil {
-			changes = append(changes, FieldChange{"cost", nil, discovered.Cost})
-		} else {
-			if existing.Cost.InputPer1K != discovered.Cost.InputPer1K {
-				changes = append(changes, FieldChange{"cost.input_per_1k", existing.Cost.InputPer1K, discovered.Cost.InputPer1K})
-			}
-			if existing.Cost.OutputPer1K != discovered.Cost.OutputPer1K {
-				changes = append(changes, FieldChange{"cost.output_per_1k", existing.Cost.OutputPer1K, discovered.Cost.OutputPer1K})
-			}
-		}
+	// A file without model_type has the type its capabilities imply, so the
+	// key is only written when that type is wrong.
+	if discovered.ModelType != "" && existing.Type() != discovered.ModelType {
+		changes = append(changes, FieldChange{"model_type", existing.ModelType, discovered.ModelType})
 	}
+	if existing.Readiness != discovered.Readiness && discovered.Readiness != "" {
+		changes = append(changes, FieldChange{"readiness", existing.Readiness, discovered.Readiness})
+	}
+
+	// Cost changes (compared in normalized per-1K form)
+	changes = append(changes, CostChanges(existing.Cost, discovered.Cost)...)
+	changes = append(changes, BatchChanges(existing.Batch, discovered.Batch)...)
+	changes = append(changes, FineTuningChanges(existing.FineTuning, discovered.FineTuning)...)
+	changes = append(changes, EmbeddingChanges(existing.Embedding, discovered.Embedding)...)
 
 	// Limits changes
 	if discovered.Limits.MaxTokens != 0 && existing.Limits.MaxTokens != discovered.Limits.MaxTokens {
@@ -186,6 +791,16 @@ func computeChanges(existing, discovered *Model) []FieldChange {
 	if discovered.Limits.MaxCompletionTokens != 0 && existing.Limits.MaxCompletionTokens != discovered.Limits.MaxCompletionTokens {
 		changes = append(changes, FieldChange{"limits.max_completion_tokens", existing.Limits.MaxCompletionTokens, discovered.Limits.MaxCompletionTokens})
 	}
+	changes = append(changes, RateLimitChanges(existing.RateLimits, discovered.RateLimits)...)
+
+	if discovered.KnowledgeCutoff != "" && existing.KnowledgeCutoff != discovered.KnowledgeCutoff {
+		changes = append(changes, FieldChange{"knowledge_cutoff", existing.KnowledgeCutoff, discovered.KnowledgeCutoff})
+	}
+	if discovered.ReleasedAt != "" && existing.ReleasedAt != discovered.ReleasedAt {
+		changes = append(changes, FieldChange{"released_at", existing.ReleasedAt, discovered.ReleasedAt})
+	}
+
+	changes = append(changes, XProviderChanges(existing.XProvider, discovered.XProvider)...)
 
 	// Capabilities — check for additions
 	existingCaps := toSet(existing.Capabilities)
@@ -196,7 +811,7 @@ func computeChanges(existing, discovered *Model) []FieldChange {
 		}
 	}
 
-	return changes
+	return existing.WithoutLocked(changes)
 }
 
 func toSet(items []string) map[string]bool {
@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -49,6 +50,68 @@ func TestWriteNewModel(t *testing.T) {
 	}
 }
 
+func TestWriteFineTunedModel(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "providers", "openai"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range map[string]string{
+		"version.txt":                    "1.0.0\n",
+		"providers/openai/provider.yaml": "name: openai\n",
+	} {
+		if err := os.WriteFile(filepath.Join(tmpDir, path), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := NewWriter(tmpDir)
+	for _, name := range []string{"gpt-4o-mini", FineTunedDir + "/gpt-4o-mini-2024-07-18-acme-abc123"} {
+		m := &Model{Name: name, DisplayName: name, Status: "stable", Capabilities: []string{"chat"}}
+		if _, err := w.WriteModel("openai", m); err != nil {
+			t.Fatalf("WriteModel(%s): %v", name, err)
+		}
+	}
+	want := filepath.Join(tmpDir, "providers", "openai", "models", "fine_tuned", "gpt-4o-mini-2024-07-18-acme-abc123.yaml")
+	if _, err := os.Stat(want); err != nil {
+		t.Fatalf("fine-tuned model not written to its subdirectory: %v", err)
+	}
+
+	cat, err := Load(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	models := cat.Providers["openai"].Models
+	if len(models) != 2 || models[FineTunedDir+"/gpt-4o-mini-2024-07-18-acme-abc123"] == nil {
+		t.Errorf("loaded %d models, want both: %v", len(models), cat.ModelNames("openai"))
+	}
+}
+
+func TestWriteModelRejectsUnloadableNames(t *testing.T) {
+	tmpDir := t.TempDir()
+	w := NewWriter(tmpDir)
+	for _, name := range []string{
+		"Qwen/Qwen2.5-72B-Instruct",
+		"meta-llama/Llama-3.3-70B-Instruct-Turbo",
+		"../../escaped",
+		"..",
+		FineTunedDir + "/acme/gpt-4o-mini",
+		FineTunedDir + "/../escaped",
+		FineTunedDir + "/",
+	} {
+		m := &Model{Name: name, DisplayName: name, Status: "stable"}
+		if _, err := w.WriteModel("togetherai", m); !errors.Is(err, ErrInvalidModelName) {
+			t.Errorf("WriteModel(%s) = %v, want ErrInvalidModelName", name, err)
+		}
+	}
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("rejected names left files behind: %v", entries)
+	}
+}
+
 func TestWriteUpdatedModelPreservesManualFields(t *testing.T) {
 	tmpDir := t.TempDir()
 	modelsDir := filepath.Join(tmpDir, "providers", "openai", "models")
@@ -254,6 +317,192 @@ status: beta
 	}
 }
 
+func TestWriteModelMergesNestedMaps(t *testing.T) {
+	tmpDir := t.TempDir()
+	modelsDir := filepath.Join(tmpDir, "providers", "openai", "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	existingYAML := `name: gpt-5
+aliases:
+    - gpt-5-preview
+display_name: GPT-5
+family: gpt-5
+status: stable
+# Prices from the enterprise contract.
+cost:
+    output_per_1k: 0.01 # list price
+    input_per_1k: 0.00125
+    cached_input_per_1k: 0.000125
+    contract_id: ent-42
+limits:
+    max_tokens: 400000
+    # Measured, not published.
+    effective_context: 200000
+capabilities:
+    - chat
+    - vision
+modalities:
+    input:
+        - text
+    output:
+        - text
+x_provider:
+    owned_by: openai
+    tier: legacy
+`
+	path := filepath.Join(modelsDir, "gpt-5.yaml")
+	if err := os.WriteFile(path, []byte(existingYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	discovered := &Model{
+		Name:         "gpt-5",
+		Aliases:      []string{"gpt-5-2025-08-07"},
+		DisplayName:  "GPT-5",
+		Family:       "gpt-5",
+		Status:       "stable",
+		Cost:         &Cost{InputPer1K: 0.001, OutputPer1K: 0.008},
+		Limits:       Limits{MaxTokens: 400000, MaxCompletionTokens: 128000},
+		Capabilities: []string{"chat"},
+		Modalities:   Modalities{Input: []string{"text"}, Output: []string{"text"}},
+		XProvider:    XProvider{"owned_by": "openai"},
+	}
+	if _, err := NewWriter(tmpDir).WriteModel("openai", discovered); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+
+	for _, want := range []string{
+		// Nested keys: updated in place, in the file's order, with
+		// hand-added ones and known ones discovery didn't report kept.
+		"cost:\n    output_per_1k: 0.008 # list price\n    input_per_1k: 0.001\n    cached_input_per_1k: 0.000125\n    contract_id: ent-42\n",
+		"    # Measured, not published.\n    effective_context: 200000\n    max_completion_tokens: 128000\n",
+		"# Prices from the enterprise contract.\ncost:",
+		// aliases merge; capabilities and x_provider are replaced.
+		"aliases:\n    - gpt-5-preview\n    - gpt-5-2025-08-07\n",
+		"capabilities:\n    - chat\nmodalities:",
+		"x_provider:\n    owned_by: openai\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("merged file lacks\n%s\ngot:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "tier: legacy") {
+		t.Errorf("x_provider was merged, not replaced:\n%s", got)
+	}
+}
+
+func TestWriteModelPreservesComments(t *testing.T) {
+	tmpDir := t.TempDir()
+	modelsDir := filepath.Join(tmpDir, "providers", "openai", "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	existingYAML := `# Maintained by the platform team.
+name: gpt-5 # canonical id
+display_name: GPT-5
+family: gpt-5
+status: beta # until GA
+# Prices from the pricing page.
+cost:
+    input_per_1k: 0.00125
+    output_per_1k: 0.01 # list price
+limits:
+    max_tokens: 400000
+capabilities:
+    # Core
+    - chat # always on
+    - vision
+    # audio is pending review
+modalities:
+    input: [text] # images soon
+    output:
+        - text
+x_provider:
+    # From the models endpoint.
+    owned_by: openai # org
+    tier: legacy
+x_updater:
+    last_verified_at: "2026-01-01"
+    sources: [api] # first sync
+# End of file.
+`
+	path := filepath.Join(modelsDir, "gpt-5.yaml")
+	if err := os.WriteFile(path, []byte(existingYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	discovered := &Model{
+		Name:         "gpt-5",
+		DisplayName:  "GPT-5",
+		Family:       "gpt-5",
+		Status:       "stable",
+		Cost:         &Cost{InputPer1K: 0.001, OutputPer1K: 0.008},
+		Limits:       Limits{MaxTokens: 400000, MaxCompletionTokens: 128000},
+		Capabilities: []string{"chat", "tools"},
+		Modalities:   Modalities{Input: []string{"text", "image"}, Output: []string{"text"}},
+		XProvider:    XProvider{"owned_by": "openai-internal"},
+		Readiness:    "experimental",
+	}
+	w := NewWriter(tmpDir)
+	if _, err := w.WriteModel("openai", discovered); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetUpdater("openai", "gpt-5", &XUpdater{LastVerifiedAt: "2026-10-16", Sources: []string{"api", "docs"}}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Comments stay on the keys and items they annotate, including ones
+	// whose values were replaced; those on dropped items (vision, tier)
+	// go with them, and the comment closing the file stays last.
+	want := `# Maintained by the platform team.
+name: gpt-5 # canonical id
+display_name: GPT-5
+family: gpt-5
+status: stable # until GA
+# Prices from the pricing page.
+cost:
+    input_per_1k: 0.001
+    output_per_1k: 0.008 # list price
+limits:
+    max_tokens: 400000
+    max_completion_tokens: 128000
+capabilities:
+    # Core
+    - chat # always on
+    - tools
+    # audio is pending review
+modalities:
+    input: # images soon
+        - text
+        - image
+    output:
+        - text
+x_provider:
+    # From the models endpoint.
+    owned_by: openai-internal # org
+x_updater:
+    last_verified_at: "2026-10-16"
+    sources: # first sync
+        - api
+        - docs
+readiness: experimental
+# End of file.
+`
+	if got := string(data); got != want {
+		t.Errorf("merged file:\n%s\nwant:\n%s", got, want)
+	}
+}
+
 func TestComputeChanges(t *testing.T) {
 	existing := &Model{
 		Name:         "gpt-4o",
@@ -297,3 +546,615 @@ func TestComputeChanges(t *testing.T) {
 		t.Error("cost.output_per_1k should not change (same value)")
 	}
 }
+
+func TestWriteUpdatedModelPreservesPriceUnit(t *testing.T) {
+	tmpDir := t.TempDir()
+	modelsDir := filepath.Join(tmpDir, "providers", "openai", "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	existingYAML := `name: gpt-4o
+display_name: GPT-4O
+family: gpt-4
+status: stable
+cost:
+    input_per_1k: 2.5
+    output_per_1k: 10
+    unit: per_1m
+capabilities:
+    - chat
+limits:
+    max_tokens: 128000
+modalities:
+    input:
+        - text
+    output:
+        - text
+`
+	existingPath := filepath.Join(modelsDir, "gpt-4o.yaml")
+	if err := os.WriteFile(existingPath, []byte(existingYAML), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	w := NewWriter(tmpDir)
+
+	// Same input price expressed per 1K, new output price.
+	discovered := &Model{
+		Name:         "gpt-4o",
+		DisplayName:  "GPT-4O",
+		Family:       "gpt-4",
+		Status:       "stable",
+		Capabilities: []string{"chat"},
+		Limits:       Limits{MaxTokens: 128000},
+		Modalities:   Modalities{Input: []string{"text"}, Output: []string{"text"}},
+		Cost:         &Cost{InputPer1K: 0.0025, OutputPer1K: 0.008},
+	}
+
+	result, err := w.WriteModel("openai", discovered)
+	if err != nil {
+		t.Fatalf("WriteModel failed: %v", err)
+	}
+	if len(result.Changes) != 1 || result.Changes[0].Field != "cost.output_per_1k" {
+		t.Fatalf("expected only cost.output_per_1k change, got %v", result.Changes)
+	}
+
+	data, err := os.ReadFile(result.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded Model
+	if err := yaml.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("parsing merged YAML: %v", err)
+	}
+	if loaded.Cost.Unit != PriceUnitPer1M {
+		t.Errorf("unit = %q, want %q", loaded.Cost.Unit, PriceUnitPer1M)
+	}
+	if loaded.Cost.InputPer1K != 2.5 || loaded.Cost.OutputPer1K != 8 {
+		t.Errorf("cost = %+v, want per-1M prices 2.5/8", loaded.Cost)
+	}
+}
+
+func TestCostChangesNormalizesUnitsAndCurrency(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   *Cost
+		discovered *Cost
+		want       []string
+	}{
+		{
+			name:       "per-1M equals per-1K",
+			existing:   &Cost{InputPer1K: 3, OutputPer1K: 15, Unit: "per_1m"},
+			discovered: &Cost{InputPer1K: 0.003, OutputPer1K: 0.015},
+			want:       nil,
+		},
+		{
+			name:       "currency change",
+			existing:   &Cost{InputPer1K: 0.001, OutputPer1K: 0.002},
+			discovered: &Cost{InputPer1K: 0.001, OutputPer1K: 0.002, Currency: "CNY"},
+			want:       []string{"cost.currency"},
+		},
+		{
+			name:       "cached input added",
+			existing:   &Cost{InputPer1K: 0.001, OutputPer1K: 0.002},
+			discovered: &Cost{InputPer1K: 0.001, OutputPer1K: 0.002, CachedInputPer1K: 0.0005},
+			want:       []string{"cost.cached_input_per_1k"},
+		},
+		{
+			name:       "per-1M reasoning price changed",
+			existing:   &Cost{InputPer1K: 2, OutputPer1K: 8, ReasoningPer1K: 8, Unit: "per_1m"},
+			discovered: &Cost{InputPer1K: 0.002, OutputPer1K: 0.008, ReasoningPer1K: 0.004},
+			want:       []string{"cost.reasoning_per_1k"},
+		},
+		{
+			name:       "per-1M cache write price changed, read price added",
+			existing:   &Cost{InputPer1K: 3, OutputPer1K: 15, CacheWritePer1K: 3.75, Unit: "per_1m"},
+			discovered: &Cost{InputPer1K: 0.003, OutputPer1K: 0.015, CacheWritePer1K: 0.006, CacheReadPer1K: 0.0003},
+			want:       []string{"cost.cache_write_per_1k", "cost.cache_read_per_1k"},
+		},
+		{
+			name:       "per-1M batch prices added",
+			existing:   &Cost{InputPer1K: 2.5, OutputPer1K: 10, Unit: "per_1m"},
+			discovered: &Cost{InputPer1K: 0.0025, OutputPer1K: 0.01, BatchInputPer1K: 0.00125, BatchOutputPer1K: 0.005},
+			want:       []string{"cost.batch_input_per_1k", "cost.batch_output_per_1k"},
+		},
+		{
+			name:       "missing optional prices don't clobber",
+			existing:   &Cost{InputPer1K: 0.001, OutputPer1K: 0.002, PerImage: 0.04},
+			discovered: &Cost{InputPer1K: 0.001, OutputPer1K: 0.002},
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := CostChanges(tt.existing, tt.discovered)
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("CostChanges() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   *Batch
+		discovered *Batch
+		want       []string
+	}{
+		{"no opinion", &Batch{Supported: true, Discount: 0.5}, nil, nil},
+		{"block added", nil, &Batch{Supported: true, Discount: 0.5}, []string{"batch"}},
+		{"support dropped", &Batch{Supported: true, Discount: 0.5}, &Batch{Supported: false}, []string{"batch.supported"}},
+		{"unknown discount keeps catalog's", &Batch{Supported: true, Discount: 0.5}, &Batch{Supported: true}, nil},
+		{"discount changed", &Batch{Supported: true, Discount: 0.5}, &Batch{Supported: true, Discount: 0.25}, []string{"batch.discount"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range BatchChanges(tt.existing, tt.discovered) {
+				got = append(got, c.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("BatchChanges() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFineTuningChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   *FineTuning
+		discovered *FineTuning
+		want       []string
+	}{
+		{"no opinion", &FineTuning{Supported: true, TrainingPer1K: 0.025}, nil, nil},
+		{"block added", nil, &FineTuning{Supported: true}, []string{"fine_tuning"}},
+		{"unknown prices keep catalog's", &FineTuning{Supported: true, TrainingPer1K: 0.025}, &FineTuning{Supported: true}, nil},
+		{"training price changed", &FineTuning{Supported: true, TrainingPer1K: 0.025}, &FineTuning{Supported: true, TrainingPer1K: 0.003}, []string{"fine_tuning.training_per_1k"}},
+		{"support dropped", &FineTuning{Supported: true}, &FineTuning{Supported: false}, []string{"fine_tuning.supported"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range FineTuningChanges(tt.existing, tt.discovered) {
+				got = append(got, c.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("FineTuningChanges() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmbeddingChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   *Embedding
+		discovered *Embedding
+		want       []string
+	}{
+		{"no opinion", &Embedding{Dimensions: 1536}, nil, nil},
+		{"block added", nil, &Embedding{Dimensions: 3072, Similarity: SimilarityCosine}, []string{"embedding"}},
+		{"unknown values keep catalog's", &Embedding{Dimensions: 1536, MaxBatchSize: 2048}, &Embedding{Similarity: SimilarityCosine}, []string{"embedding.similarity"}},
+		{"dimensions changed", &Embedding{Dimensions: 1536}, &Embedding{Dimensions: 3072}, []string{"embedding.dimensions"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range EmbeddingChanges(tt.existing, tt.discovered) {
+				got = append(got, c.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("EmbeddingChanges() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitChanges(t *testing.T) {
+	existing := []RateLimit{{Tier: "free", RPM: 30, TPM: 6000}, {Tier: "developer", RPM: 1000}}
+	tests := []struct {
+		name       string
+		discovered []RateLimit
+		want       []string
+	}{
+		{"no opinion", nil, nil},
+		{"same", []RateLimit{{Tier: "free", RPM: 30, TPM: 6000}, {Tier: "developer", RPM: 1000}}, nil},
+		{"limit raised", []RateLimit{{Tier: "free", RPM: 30, TPM: 12000}, {Tier: "developer", RPM: 1000}}, []string{"rate_limits.free.tpm"}},
+		{"tier added and removed", []RateLimit{{Tier: "free", RPM: 30, TPM: 6000}, {Tier: "enterprise", RPM: 10000}}, []string{"rate_limits.enterprise", "rate_limits.developer"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range RateLimitChanges(existing, tt.discovered) {
+				got = append(got, c.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("RateLimitChanges() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetReadiness(t *testing.T) {
+	tmpDir := t.TempDir()
+	modelsDir := filepath.Join(tmpDir, "providers", "openai", "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	existingYAML := `name: gpt-5
+display_name: GPT-5
+status: stable
+readiness: discovered
+custom_notes: keep
+`
+	if err := os.WriteFile(filepath.Join(modelsDir, "gpt-5.yaml"), []byte(existingYAML), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	w := NewWriter(tmpDir)
+
+	if _, err := w.SetReadiness("openai", "gpt-5", ReadinessVerified, false); err != nil {
+		t.Fatalf("SetReadiness verified: %v", err)
+	}
+	if _, err := w.SetReadiness("openai", "gpt-5", ReadinessDiscovered, false); err == nil {
+		t.Error("expected error moving readiness backwards without force")
+	}
+	if _, err := w.SetReadiness("openai", "gpt-5", "bogus", true); err == nil {
+		t.Error("expected error for unknown readiness level")
+	}
+	if result, err := w.SetReadiness("openai", "gpt-5", ReadinessVerified, false); err != nil || len(result.Changes) != 0 {
+		t.Errorf("SetReadiness to the current level = %+v, %v; want no changes", result, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(modelsDir, "gpt-5.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "readiness: verified") {
+		t.Errorf("expected readiness: verified, got:\n%s", content)
+	}
+	if !strings.Contains(content, "custom_notes: keep") {
+		t.Error("custom_notes should be preserved")
+	}
+}
+
+func TestCheckPromotion(t *testing.T) {
+	tests := []struct {
+		from, to string
+		force    bool
+		wantErr  bool
+	}{
+		{ReadinessDiscovered, ReadinessVerified, false, false},
+		{ReadinessVerified, ReadinessVerified, false, false},
+		{ReadinessApproved, ReadinessDiscovered, false, true},
+		{ReadinessApproved, ReadinessDiscovered, true, false},
+		// No level is approved: promotable to ga, not demotable.
+		{"", ReadinessApproved, false, false},
+		{"", ReadinessGA, false, false},
+		{"", ReadinessVerified, false, true},
+		{"", ReadinessVerified, true, false},
+		{"beta", ReadinessGA, false, true},
+		{ReadinessVerified, "bogus", true, true},
+	}
+	for _, tt := range tests {
+		err := CheckPromotion(tt.from, tt.to, tt.force)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckPromotion(%q, %q, %v) = %v, want error %v", tt.from, tt.to, tt.force, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSetUpdater(t *testing.T) {
+	tmpDir := t.TempDir()
+	modelsDir := filepath.Join(tmpDir, "providers", "openai", "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	existingYAML := `name: gpt-5
+status: stable
+x_updater:
+  last_verified_at: "2025-01-01T00:00:00Z"
+  sources: [docs]
+custom_notes: keep
+`
+	path := filepath.Join(modelsDir, "gpt-5.yaml")
+	if err := os.WriteFile(path, []byte(existingYAML), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	u := &XUpdater{
+		LastVerifiedAt: "2026-02-01T00:00:00Z",
+		Sources:        []string{"api"},
+		Fields:         map[string]FieldSource{"cost": {Source: "docs", UpdatedAt: "2026-02-01T00:00:00Z"}},
+	}
+	if err := NewWriter(tmpDir).SetUpdater("openai", "gpt-5", u); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Model
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.XUpdater == nil || got.XUpdater.LastVerifiedAt != u.LastVerifiedAt || got.FieldSources()["cost"].Source != "docs" {
+		t.Errorf("x_updater = %+v, want it replaced", got.XUpdater)
+	}
+	if !strings.Contains(string(data), "custom_notes: keep") || strings.Count(string(data), "x_updater") != 1 {
+		t.Errorf("other fields should be untouched and x_updater replaced in place:\n%s", data)
+	}
+}
+
+func TestWriteProvider(t *testing.T) {
+	tmpDir := t.TempDir()
+	w := NewWriter(tmpDir)
+	defaults := &Provider{
+		Name: "openai", DisplayName: "OpenAI", ProviderType: "llm", SupportsModelDiscovery: true,
+		BaseURL: "https://api.openai.com/v1", AuthType: AuthBearer, StatusPage: "https://status.openai.com",
+	}
+	path := filepath.Join(tmpDir, "providers", "openai", "provider.yaml")
+
+	// A missing file is created whole.
+	if written, err := w.WriteProvider(defaults); err != nil || !written {
+		t.Fatalf("written = %v, err = %v; want a new file", written, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Provider
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != *defaults {
+		t.Errorf("provider = %+v, want %+v", got, *defaults)
+	}
+
+	// An existing file keeps what is set and gains only the missing fields.
+	existing := "name: openai\ndisplay_name: OpenAI (Azure proxy)\nprovider_type: llm\nsupports_model_discovery: false\nbase_url: \"\"\nnotes: keep\n"
+	if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if written, err := w.WriteProvider(defaults); err != nil || !written {
+		t.Fatalf("written = %v, err = %v; want the file filled in", written, err)
+	}
+	if data, err = os.ReadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	got = Provider{}
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.DisplayName != "OpenAI (Azure proxy)" || got.SupportsModelDiscovery {
+		t.Errorf("provider = %+v, want the manual fields kept", got)
+	}
+	if got.BaseURL != defaults.BaseURL || got.AuthType != AuthBearer || got.StatusPage != defaults.StatusPage {
+		t.Errorf("provider = %+v, want the empty fields filled in", got)
+	}
+	if !strings.Contains(string(data), "notes: keep") {
+		t.Errorf("unknown fields should be kept:\n%s", data)
+	}
+
+	// Nothing left to fill in: no write.
+	if written, err := w.WriteProvider(defaults); err != nil || written {
+		t.Errorf("written = %v, err = %v; want no write", written, err)
+	}
+}
+
+func TestRenameModel(t *testing.T) {
+	tmpDir := t.TempDir()
+	modelsDir := filepath.Join(tmpDir, "providers", "openai", "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	existingYAML := `name: gpt-4o-v1
+display_name: GPT-4o V1
+family: gpt-4
+status: stable
+limits:
+  max_tokens: 128000
+capabilities:
+  - chat
+custom_notes: keep
+`
+	if err := os.WriteFile(filepath.Join(modelsDir, "gpt-4o-v1.yaml"), []byte(existingYAML), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	w := NewWriter(tmpDir)
+	discovered := &Model{
+		Name:         "gpt-4o-v2",
+		DisplayName:  "GPT-4o V2",
+		Family:       "gpt-4",
+		Status:       "stable",
+		Limits:       Limits{MaxTokens: 128000},
+		Capabilities: []string{"chat", "vision"},
+	}
+	if _, err := w.RenameModel("openai", "gpt-4o-v1", discovered); err != nil {
+		t.Fatalf("RenameModel: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(modelsDir, "gpt-4o-v2.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var renamed Model
+	if err := yaml.Unmarshal(data, &renamed); err != nil {
+		t.Fatal(err)
+	}
+	if renamed.Name != "gpt-4o-v2" || len(renamed.Aliases) != 1 || renamed.Aliases[0] != "gpt-4o-v1" {
+		t.Errorf("renamed model = %+v, want name gpt-4o-v2 with alias gpt-4o-v1", renamed)
+	}
+	if len(renamed.Capabilities) != 2 || renamed.DisplayName != "GPT-4o V2" {
+		t.Errorf("discovered fields not merged: %+v", renamed)
+	}
+	content := string(data)
+	if !strings.Contains(content, "custom_notes: keep") {
+		t.Errorf("custom_notes should carry over, got:\n%s", content)
+	}
+	if strings.Index(content, "aliases:") > strings.Index(content, "display_name:") {
+		t.Errorf("aliases should follow name, got:\n%s", content)
+	}
+
+	old, err := os.ReadFile(filepath.Join(modelsDir, "gpt-4o-v1.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(old), "status: deprecated") || !strings.Contains(string(old), "name: gpt-4o-v1") {
+		t.Errorf("old model should stay, deprecated, got:\n%s", old)
+	}
+
+	if _, err := w.RenameModel("openai", "gpt-4o-v1", discovered); err == nil {
+		t.Error("expected error renaming onto an existing model")
+	}
+}
+
+func TestWriteModelReplacesXProviderBlock(t *testing.T) {
+	tmpDir := t.TempDir()
+	modelsDir := filepath.Join(tmpDir, "providers", "groq", "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	existingYAML := `name: llama-3.3-70b-versatile
+display_name: Llama 3.3 70B
+family: llama-3.3
+status: stable
+limits:
+    max_tokens: 131072
+capabilities:
+    - chat
+modalities:
+    input:
+        - text
+    output:
+        - text
+x_provider:
+    owned_by: Meta
+    speed_class: fast
+notes: keep me
+`
+	path := filepath.Join(modelsDir, "llama-3.3-70b-versatile.yaml")
+	if err := os.WriteFile(path, []byte(existingYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	discovered := &Model{
+		Name:         "llama-3.3-70b-versatile",
+		DisplayName:  "Llama 3.3 70B",
+		Family:       "llama-3.3",
+		Status:       "stable",
+		Limits:       Limits{MaxTokens: 131072},
+		Capabilities: []string{"chat"},
+		Modalities:   Modalities{Input: []string{"text"}, Output: []string{"text"}},
+	}
+
+	w := NewWriter(tmpDir)
+	if res, err := w.WriteModel("groq", discovered); err != nil || len(res.Changes) != 0 {
+		t.Fatalf("nil x_provider should leave the file alone, got %+v (err %v)", res, err)
+	}
+
+	discovered.XProvider = XProvider{"owned_by": "Meta", "speed_class": "standard"}
+	res, err := w.WriteModel("groq", discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Changes) != 1 || res.Changes[0].Field != "x_provider" {
+		t.Fatalf("changes = %+v, want one x_provider change", res.Changes)
+	}
+
+	got, err := LoadModelFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.XProvider["speed_class"] != "standard" || got.XProvider["owned_by"] != "Meta" {
+		t.Errorf("x_provider = %v", got.XProvider)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "notes: keep me") {
+		t.Error("manual fields should survive the merge")
+	}
+}
+
+func TestWriteModelRespectsLockedFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	modelsDir := filepath.Join(tmpDir, "providers", "openai", "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	existingYAML := `name: gpt-4o
+display_name: GPT-4o
+family: gpt-4o
+status: beta
+cost:
+    input_per_1k: 0.005
+    output_per_1k: 0.015
+limits:
+    max_tokens: 128000
+capabilities:
+    - chat
+x_locked:
+    - cost.input_per_1k
+    - limits
+`
+	path := filepath.Join(modelsDir, "gpt-4o.yaml")
+	if err := os.WriteFile(path, []byte(existingYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	discovered := &Model{
+		Name: "gpt-4o", DisplayName: "GPT-4o", Family: "gpt-4o", Status: "stable",
+		Cost:         &Cost{InputPer1K: 0.0025, OutputPer1K: 0.01},
+		Limits:       Limits{MaxTokens: 256000, MaxCompletionTokens: 16384},
+		Capabilities: []string{"chat"},
+	}
+	res, err := NewWriter(tmpDir).WriteModel("openai", discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range res.Changes {
+		if c.Field == "cost.input_per_1k" || strings.HasPrefix(c.Field, "limits.") {
+			t.Errorf("locked field reported as changed: %+v", c)
+		}
+	}
+
+	got, err := LoadModelFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Cost.InputPer1K != 0.005 || got.Cost.OutputPer1K != 0.01 {
+		t.Errorf("cost = %+v, want the locked input price and the new output price", got.Cost)
+	}
+	if got.Limits != (Limits{MaxTokens: 128000}) {
+		t.Errorf("limits = %+v, want them untouched", got.Limits)
+	}
+	if got.Status != "stable" || len(got.Locked) != 2 {
+		t.Errorf("status %q, x_locked %v; want stable and the lock kept", got.Status, got.Locked)
+	}
+}
+
+func TestIsModelField(t *testing.T) {
+	for path, want := range map[string]bool{
+		"cost":              true,
+		"limits.max_tokens": true,
+		"x_provider.speed":  true,
+		"capabilities":      true,
+		"costs":             false,
+		"limits.max":        false,
+		"family.name":       false,
+	} {
+		if got := IsModelField(path); got != want {
+			t.Errorf("IsModelField(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
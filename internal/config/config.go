@@ -4,8 +4,20 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/judge"
+	"github.com/everstacklabs/sentinel/internal/validate"
 )
 
 // Config holds all configuration for the sentinel.
@@ -13,220 +25,465 @@ type Config struct {
 	CatalogPath string          `mapstructure:"catalog_path"`
 	CacheDir    string          `mapstructure:"cache_dir"`
 	CacheTTL    string          `mapstructure:"cache_ttl"`
+	Cache       CacheConfig     `mapstructure:"cache"`
+	HTTP        HTTPConfig      `mapstructure:"http"`
+
+	// ExtraHeaders adds headers to every discovery request for a provider,
+	// keyed by provider name, e.g. to opt into preview APIs.
+	ExtraHeaders map[string]map[string]string `mapstructure:"extra_headers"`
+
+	// Filters limits which discovered models a provider contributes to the
+	// catalog, keyed by provider name.
+	Filters map[string]ModelFilterConfig `mapstructure:"filters"`
+
+	// ModelTypes limits every provider to models of these types, e.g.
+	// [chat] for a catalog of chat models only. Empty keeps every type.
+	ModelTypes []string `mapstructure:"model_types"`
+
+	// DiscoveryTimeout bounds each provider's discovery, e.g. "10m", so one
+	// slow provider can't stall a run; "0" disables it.
+	// DiscoveryTimeouts overrides it per provider name.
+	DiscoveryTimeout  string            `mapstructure:"discovery_timeout"`
+	DiscoveryTimeouts map[string]string `mapstructure:"discovery_timeouts"`
+
 	Providers   []string        `mapstructure:"providers"`
 	Sources     []string        `mapstructure:"sources"`
 	DryRun      bool            `mapstructure:"dry_run"`
 	NoCache     bool            `mapstructure:"no_cache"`
 	RiskMode    string          `mapstructure:"risk_mode"`
+	Risk        RiskConfig      `mapstructure:"risk"`
 	GitHub      GitHubConfig    `mapstructure:"github"`
-	OpenAI      OpenAIConfig    `mapstructure:"openai"`
-	Anthropic   AnthropicConfig `mapstructure:"anthropic"`
-	Google      GoogleConfig    `mapstructure:"google"`
-	Mistral     MistralConfig   `mapstructure:"mistral"`
-	Cohere      CohereConfig    `mapstructure:"cohere"`
-	Groq        GroqConfig      `mapstructure:"groq"`
-	DeepSeek    DeepSeekConfig  `mapstructure:"deepseek"`
-	XAI         XAIConfig       `mapstructure:"xai"`
-	TogetherAI  TogetherAIConfig  `mapstructure:"togetherai"`
-	Cerebras    CerebrasConfig   `mapstructure:"cerebras"`
-	Fireworks   FireworksConfig  `mapstructure:"fireworks"`
-	DeepInfra   DeepInfraConfig  `mapstructure:"deepinfra"`
-	NVIDIA      NVIDIAConfig     `mapstructure:"nvidia"`
-	Alibaba     AlibabaConfig    `mapstructure:"alibaba"`
-	MiniMax     MiniMaxConfig    `mapstructure:"minimax"`
-	MoonshotAI  MoonshotAIConfig `mapstructure:"moonshotai"`
-	Nebius      NebiusConfig     `mapstructure:"nebius"`
-	SiliconFlow SiliconFlowConfig `mapstructure:"siliconflow"`
-	Inception   InceptionConfig  `mapstructure:"inception"`
-	Llama       LlamaConfig      `mapstructure:"llama"`
-	Upstage     UpstageConfig    `mapstructure:"upstage"`
-	Nova        NovaConfig       `mapstructure:"nova"`
-	NovitaAI    NovitaAIConfig   `mapstructure:"novitaai"`
-	Friendli    FriendliConfig   `mapstructure:"friendli"`
-	StepFun     StepFunConfig    `mapstructure:"stepfun"`
-	ZhipuAI     ZhipuAIConfig    `mapstructure:"zhipuai"`
-	Venice      VeniceConfig     `mapstructure:"venice"`
-	Bailing     BailingConfig    `mapstructure:"bailing"`
 	Judge       JudgeConfig      `mapstructure:"judge"`
 	Diff        DiffConfig      `mapstructure:"diff"`
 	Health      HealthConfig    `mapstructure:"health"`
+	Readiness   ReadinessConfig `mapstructure:"readiness"`
+	Database    DatabaseConfig  `mapstructure:"database"`
+	YAML        YAMLConfig      `mapstructure:"yaml"`
+	Validation  ValidationConfig `mapstructure:"validation"`
 	LogLevel    string          `mapstructure:"log_level"`
-}
 
-// GitHubConfig holds GitHub-related settings.
-type GitHubConfig struct {
-	Token      string `mapstructure:"token"`
-	Owner      string `mapstructure:"owner"`
-	Repo       string `mapstructure:"repo"`
-	BaseBranch string `mapstructure:"base_branch"`
-}
+	// ProviderSections holds each adapter's section, keyed by its
+	// ConfigSpec().Key; Load fills it from the keys of the same name.
+	ProviderSections map[string]ProviderConfig `mapstructure:"-"`
 
-// OpenAIConfig holds OpenAI-specific settings.
-type OpenAIConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
-}
+	// ReportPath is where sync writes a JSON report of the run; empty
+	// disables it.
+	ReportPath string `mapstructure:"report_path"`
 
-// AnthropicConfig holds Anthropic-specific settings.
-type AnthropicConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
-}
+	// FailOn lists the provider failures that make sync exit non-zero:
+	// error, health and validation. Empty exits 0 unless a risk gate
+	// blocked a changeset.
+	FailOn []string `mapstructure:"fail_on"`
 
-// GoogleConfig holds Google/Gemini-specific settings.
-type GoogleConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
-}
+	// FailFast stops a sync at the first provider that fails.
+	FailFast bool `mapstructure:"fail_fast"`
 
-// MistralConfig holds Mistral-specific settings.
-type MistralConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+	Versioning VersioningConfig `mapstructure:"versioning"`
+
+	// Changelog is the file, relative to the catalog, that each version
+	// bump adds a release entry to; empty disables it.
+	Changelog string `mapstructure:"changelog"`
+
+	// Indexes is the directory, relative to the catalog, that by-capability
+	// and by-family index files are generated in with the manifest; empty
+	// disables them.
+	Indexes string `mapstructure:"indexes"`
+
+	// Format is what the catalog's model files are stored as: "yaml" or
+	// "json". Provider files are YAML either way.
+	Format string `mapstructure:"format"`
+
+	// Catalogs syncs subsets of the providers to separate catalogs, e.g.
+	// internal models to an internal repository. When set, it replaces
+	// catalog_path, providers and github for sync.
+	Catalogs []CatalogConfig `mapstructure:"catalogs"`
+
+	// CatalogName is the name of the catalogs entry a config was derived
+	// from by ForCatalogs, empty otherwise.
+	CatalogName string `mapstructure:"-"`
+
+	// File is the config file the settings were read from, empty when
+	// there was none.
+	File string `mapstructure:"-"`
 }
 
-// CohereConfig holds Cohere-specific settings.
-type CohereConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// DiscoveryTimeoutFor returns how long provider's discovery may take, 0
+// for no limit. Load has already rejected timeouts that don't parse.
+func (c *Config) DiscoveryTimeoutFor(provider string) time.Duration {
+	s := c.DiscoveryTimeout
+	if override, ok := c.DiscoveryTimeouts[provider]; ok {
+		s = override
+	}
+	d, _ := time.ParseDuration(s)
+	return max(d, 0)
+}
+
+// VersioningConfig is the policy for bumping the catalog version. New
+// models bump MINOR and other updates PATCH; the breaking changes listed in
+// MajorOn bump MAJOR: model_removal, capability_removal and
+// limit_reduction. An empty list never bumps MAJOR.
+type VersioningConfig struct {
+	MajorOn []string `mapstructure:"major_on"`
+}
+
+// CatalogConfig is one target catalog. GitHub settings it doesn't set are
+// inherited from the top-level github section.
+type CatalogConfig struct {
+	Name      string       `mapstructure:"name"`
+	Path      string       `mapstructure:"path"`
+	Providers []string     `mapstructure:"providers"`
+	GitHub    GitHubConfig `mapstructure:"github"`
+	Format    string       `mapstructure:"format"` // empty inherits the top-level format
+}
+
+// ForCatalogs returns a config per catalogs entry, each with that catalog's
+// path, providers, GitHub settings and format in place of the top-level
+// ones.
+// Without catalogs it returns c alone.
+func (c *Config) ForCatalogs() []*Config {
+	if len(c.Catalogs) == 0 {
+		return []*Config{c}
+	}
+	configs := make([]*Config, len(c.Catalogs))
+	for i, cat := range c.Catalogs {
+		cc := *c
+		cc.CatalogName = cat.Name
+		cc.CatalogPath = cat.Path
+		cc.Providers = cat.Providers
+		cc.GitHub = cat.GitHub
+		if cat.Format != "" {
+			cc.Format = cat.Format
+		}
+		cc.Catalogs = nil
+		configs[i] = &cc
+	}
+	return configs
 }
 
-// GroqConfig holds Groq-specific settings.
-type GroqConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// CacheConfig selects where HTTP responses are cached. The file backend
+// uses cache_dir; redis, http, s3 and gcs let a fleet of CI runners share
+// one cache.
+type CacheConfig struct {
+	Backend   string           `mapstructure:"backend"`
+	Retain    string           `mapstructure:"retain"`
+	MaxSizeMB int              `mapstructure:"max_size_mb"` // file backend; 0 means unbounded
+	Redis     RedisCacheConfig `mapstructure:"redis"`
+	HTTP      HTTPCacheConfig  `mapstructure:"http"`
+	S3        S3CacheConfig    `mapstructure:"s3"`
 }
 
-// DeepSeekConfig holds DeepSeek-specific settings.
-type DeepSeekConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// RedisCacheConfig holds Redis cache backend settings.
+type RedisCacheConfig struct {
+	URL    string `mapstructure:"url"`
+	Prefix string `mapstructure:"prefix"`
 }
 
-// XAIConfig holds xAI (Grok)-specific settings.
-type XAIConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// HTTPCacheConfig holds HTTP cache backend settings.
+type HTTPCacheConfig struct {
+	URL   string `mapstructure:"url"`
+	Token string `mapstructure:"token"`
 }
 
-// TogetherAIConfig holds Together AI-specific settings.
-type TogetherAIConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// S3CacheConfig holds settings for the s3 and gcs cache backends. The gcs
+// backend defaults the endpoint to GCS's S3-compatible API and takes HMAC
+// keys as the access key pair.
+type S3CacheConfig struct {
+	Bucket          string `mapstructure:"bucket"`
+	Prefix          string `mapstructure:"prefix"`
+	Region          string `mapstructure:"region"`
+	Endpoint        string `mapstructure:"endpoint"` // for S3-compatible stores, e.g. MinIO
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	SessionToken    string `mapstructure:"session_token"`
 }
 
-// CerebrasConfig holds Cerebras-specific settings.
-type CerebrasConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// HTTPConfig holds per-host rate limiting, timeout and connection settings
+// for provider requests.
+type HTTPConfig struct {
+	RateLimit float64 `mapstructure:"rate_limit"` // requests per second per host
+	Burst     int     `mapstructure:"burst"`
+	Warmup    string  `mapstructure:"warmup"` // ramp-up period after a 429; "0" disables
+	Jitter    float64 `mapstructure:"jitter"` // max random fraction added to retry backoff
+
+	BreakerThreshold int    `mapstructure:"breaker_threshold"` // consecutive failures before a host fails fast; 0 disables
+	BreakerCooldown  string `mapstructure:"breaker_cooldown"`
+
+	Timeout      string        `mapstructure:"timeout"` // per request attempt; "0" disables
+	HostTimeouts []HostTimeout `mapstructure:"host_timeouts"`
+
+	MaxIdleConnsPerHost int    `mapstructure:"max_idle_conns_per_host"` // keep-alive connections kept per host
+	IdleConnTimeout     string `mapstructure:"idle_conn_timeout"`
+
+	MaxResponseMB int `mapstructure:"max_response_mb"` // largest response body read; 0 disables
 }
 
-// FireworksConfig holds Fireworks AI-specific settings.
-type FireworksConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// HostTimeout overrides http.timeout for one host. It is a list entry
+// rather than a map key because viper splits keys on dots.
+type HostTimeout struct {
+	Host    string `mapstructure:"host"` // e.g. docs.anthropic.com
+	Timeout string `mapstructure:"timeout"`
 }
 
-// DeepInfraConfig holds DeepInfra-specific settings.
-type DeepInfraConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// YAMLConfig controls the formatting of model files sentinel writes, so
+// generated YAML matches the catalog repo's own conventions.
+type YAMLConfig struct {
+	Indent   int      `mapstructure:"indent"`
+	Quote    string   `mapstructure:"quote"`     // "", "single" or "double"
+	KeyOrder []string `mapstructure:"key_order"` // applied to new files; dotted for nested keys
+	Fsync    bool     `mapstructure:"fsync"`     // flush catalog files to disk before moving on
+	// LineEnding is "lf" or "crlf"; empty keeps each file's own, LF for new files.
+	LineEnding string `mapstructure:"line_ending"`
 }
 
-// NVIDIAConfig holds NVIDIA NIM-specific settings.
-type NVIDIAConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// CatalogFormat returns the format of the catalog's model files.
+func (c *Config) CatalogFormat() (catalog.Format, error) {
+	return catalog.FormatByName(c.Format)
 }
 
-// AlibabaConfig holds Alibaba/DashScope-specific settings.
-type AlibabaConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// Style returns the writer style for these settings.
+func (c YAMLConfig) Style() catalog.Style {
+	return catalog.Style{Indent: c.Indent, Quote: c.Quote, KeyOrder: c.KeyOrder, LineEnding: c.LineEnding}
 }
 
-// MiniMaxConfig holds MiniMax-specific settings.
-type MiniMaxConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// ValidationConfig overrides the validation thresholds and adds checks.
+// Providers entries inherit anything they don't set; their required fields
+// and patterns are added to the top-level ones.
+type ValidationConfig struct {
+	ValidationRules `mapstructure:",squash"`
+	Providers       map[string]ValidationRules `mapstructure:"providers"`
+
+	// ContractsFile declares downstream contracts, relative to the catalog.
+	// Empty uses the catalog's contracts.yaml if there is one.
+	ContractsFile string `mapstructure:"contracts_file"`
 }
 
-// MoonshotAIConfig holds Moonshot AI-specific settings.
-type MoonshotAIConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// ValidationRules is one set of validation overrides. Unset thresholds
+// inherit from the level above.
+type ValidationRules struct {
+	MaxPricePer1K         *float64        `mapstructure:"max_price_per_1k"` // USD
+	MinMaxTokens          *int            `mapstructure:"min_max_tokens"`
+	MinEmbeddingMaxTokens *int            `mapstructure:"min_embedding_max_tokens"`
+	MaxMaxTokens          *int            `mapstructure:"max_max_tokens"`
+	RequiredFields        []string        `mapstructure:"required_fields"` // dotted, e.g. "cost.cached_input_per_1k"
+	Patterns              []PatternConfig `mapstructure:"patterns"`
 }
 
-// NebiusConfig holds Nebius-specific settings.
-type NebiusConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// PatternConfig requires a model field to match a regular expression.
+type PatternConfig struct {
+	Field    string `mapstructure:"field"`
+	Pattern  string `mapstructure:"pattern"`
+	Message  string `mapstructure:"message"`
+	Severity string `mapstructure:"severity"` // "error" (default) or "warning"
 }
 
-// SiliconFlowConfig holds SiliconFlow-specific settings.
-type SiliconFlowConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// Ruleset resolves the overrides against the built-in defaults and loads
+// the contracts of the catalog at catalogPath.
+func (c ValidationConfig) Ruleset(catalogPath string) (*validate.Ruleset, error) {
+	def, err := c.ValidationRules.apply(validate.DefaultRules())
+	if err != nil {
+		return nil, err
+	}
+	providers := make(map[string]validate.Rules, len(c.Providers))
+	for name, pr := range c.Providers {
+		r, err := pr.apply(def)
+		if err != nil {
+			return nil, fmt.Errorf("validation.providers.%s: %w", name, err)
+		}
+		providers[name] = r
+	}
+	rs, err := validate.NewRuleset(def, providers)
+	if err != nil {
+		return nil, err
+	}
+
+	path := c.ContractsFile
+	if path == "" {
+		path = validate.ContractsFile
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(catalogPath, path)
+	}
+	if rs.Contracts, err = validate.LoadContracts(path, c.ContractsFile != ""); err != nil {
+		return nil, err
+	}
+	return rs, nil
 }
 
-// InceptionConfig holds Inception Labs-specific settings.
-type InceptionConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+func (c ValidationRules) apply(base validate.Rules) (validate.Rules, error) {
+	r := base
+	if c.MaxPricePer1K != nil {
+		r.MaxPricePer1K = *c.MaxPricePer1K
+	}
+	if c.MinMaxTokens != nil {
+		r.MinMaxTokens = *c.MinMaxTokens
+	}
+	if c.MinEmbeddingMaxTokens != nil {
+		r.MinEmbeddingMaxTokens = *c.MinEmbeddingMaxTokens
+	}
+	if c.MaxMaxTokens != nil {
+		r.MaxMaxTokens = *c.MaxMaxTokens
+	}
+	r.Required = append(append([]string(nil), base.Required...), c.RequiredFields...)
+	r.Patterns = append([]validate.Pattern(nil), base.Patterns...)
+	for _, p := range c.Patterns {
+		sev := validate.SeverityError
+		switch p.Severity {
+		case "", "error":
+		case "warning", "warn":
+			sev = validate.SeverityWarning
+		default:
+			return r, fmt.Errorf("pattern for %s: unknown severity %q", p.Field, p.Severity)
+		}
+		r.Patterns = append(r.Patterns, validate.Pattern{Field: p.Field, Regex: p.Pattern, Message: p.Message, Severity: sev})
+	}
+	return r, nil
 }
 
-// LlamaConfig holds Meta Llama API-specific settings.
-type LlamaConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// GitHubConfig holds GitHub-related settings.
+type GitHubConfig struct {
+	Token      string `mapstructure:"token"`
+	Owner      string `mapstructure:"owner"`
+	Repo       string `mapstructure:"repo"`
+	BaseBranch string `mapstructure:"base_branch"`
+
+	// AutoClone clones the catalog repository to catalog_path when there
+	// is no checkout there yet, from CloneURL or else owner/repo on
+	// github.com, checked out at BaseBranch.
+	AutoClone bool   `mapstructure:"auto_clone"`
+	CloneURL  string `mapstructure:"clone_url"`
+
+	// CommitName and CommitEmail are the author of sentinel's commits. For
+	// GitHub to show signed commits as verified, the email must be one of
+	// the signing key owner's verified addresses.
+	CommitName  string        `mapstructure:"commit_name"`
+	CommitEmail string        `mapstructure:"commit_email"`
+	Signing     SigningConfig `mapstructure:"signing"`
+
+	// MaxPRModels splits a changeset with more new and updated models than
+	// this into a chain of PRs; 0 disables splitting.
+	MaxPRModels int    `mapstructure:"max_pr_models"`
+	SplitBy     string `mapstructure:"split_by"` // "family" or "chunk"
+
+	// AggregatePRs opens one PR per run instead of one per provider: each
+	// provider is a commit on a shared branch. Changesets are not split.
+	AggregatePRs bool `mapstructure:"aggregate_prs"`
+
+	// UpdateOpenPRs force-pushes to the branch of a sentinel PR that is
+	// still open from an earlier run and updates its body, instead of
+	// opening a duplicate.
+	UpdateOpenPRs bool `mapstructure:"update_open_prs"`
+
+	// AlertIssues opens an issue labelled sentinel-alert when a provider
+	// stops listing a whole model family.
+	AlertIssues bool `mapstructure:"alert_issues"`
+
+	// Rate limiting of GitHub API calls. Content-creating requests are
+	// spaced by WriteInterval; rate-limited responses are retried up to
+	// RateLimitRetries times, waiting at most MaxRateLimitWait each time.
+	WriteInterval    string `mapstructure:"write_interval"`
+	RateLimitRetries int    `mapstructure:"rate_limit_retries"`
+	MaxRateLimitWait string `mapstructure:"max_rate_limit_wait"`
+}
+
+// SigningConfig signs sentinel's commits, for repositories that require
+// signed commits. Key is a key file, or the armored key itself.
+type SigningConfig struct {
+	Format     string `mapstructure:"format"` // "gpg" or "ssh"; empty disables signing
+	Key        string `mapstructure:"key"`
+	Passphrase string `mapstructure:"passphrase"`
+}
+
+// RepoURL returns the URL the catalog repository is cloned from.
+func (c GitHubConfig) RepoURL() string {
+	switch {
+	case c.CloneURL != "":
+		return c.CloneURL
+	case c.Owner != "" && c.Repo != "":
+		return fmt.Sprintf("https://github.com/%s/%s.git", c.Owner, c.Repo)
+	}
+	return ""
 }
 
-// UpstageConfig holds Upstage-specific settings.
-type UpstageConfig struct {
+// ProviderAccess is how a provider's section reaches its API.
+type ProviderAccess struct {
 	APIKey  string `mapstructure:"api_key"`
 	BaseURL string `mapstructure:"base_url"`
 }
 
-// NovaConfig holds Amazon Nova-specific settings.
-type NovaConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// ProviderConfig is a provider's config section, keyed by its adapter's
+// ConfigSpec().Key. Settings holds the keys the adapter declares in
+// ConfigSpec().Settings, still undecoded.
+type ProviderConfig struct {
+	ProviderAccess `mapstructure:",squash"`
+	Settings       map[string]any `mapstructure:",remain"`
 }
 
-// NovitaAIConfig holds Novita AI-specific settings.
-type NovitaAIConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// Access returns the API key and base URL in the config section key, as
+// named by the adapter's ConfigSpec. It reports false when no registered
+// adapter has such a section.
+func (c *Config) Access(key string) (ProviderAccess, bool) {
+	section, ok := c.ProviderSections[key]
+	return section.ProviderAccess, ok
 }
 
-// FriendliConfig holds Friendli-specific settings.
-type FriendliConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// DecodeSettings decodes the settings of the section key into dst, the
+// adapter's ConfigSpec().Settings.
+func (c *Config) DecodeSettings(key string, dst any) error {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.ComposeDecodeHookFunc(mapstructure.StringToTimeDurationHookFunc(), mapstructure.StringToSliceHookFunc(",")),
+		WeaklyTypedInput: true,
+		Result:           dst,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(c.ProviderSections[key].Settings); err != nil {
+		return fmt.Errorf("decoding %s settings: %w", key, err)
+	}
+	return nil
 }
 
-// StepFunConfig holds StepFun-specific settings.
-type StepFunConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// providerSections decodes the section of every registered adapter.
+func providerSections(settings map[string]any) (map[string]ProviderConfig, error) {
+	sections := make(map[string]ProviderConfig)
+	for _, spec := range adapter.ConfigSpecs() {
+		var section ProviderConfig
+		if err := mapstructure.WeakDecode(settings[spec.Key], &section); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", spec.Key, err)
+		}
+		sections[spec.Key] = section
+	}
+	return sections, nil
 }
 
-// ZhipuAIConfig holds Zhipu AI-specific settings.
-type ZhipuAIConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// ModelFilterConfig selects models by name. Patterns are globs where * and ?
+// match any characters, including "/"; a pattern wrapped in slashes, like
+// /-\d{4}$/, is a regular expression. With include patterns only matching
+// models are kept; exclude patterns then drop models from what is left.
+type ModelFilterConfig struct {
+	IncludePatterns []string `mapstructure:"include_patterns"`
+	ExcludePatterns []string `mapstructure:"exclude_patterns"`
 }
 
-// VeniceConfig holds Venice AI-specific settings.
-type VeniceConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// RiskConfig sets the gates a changeset is checked against before a PR is
+// opened. Crossing a draft threshold opens the PR as a draft; crossing a
+// block threshold skips the provider and makes sync exit with code 3. With
+// risk_mode "relaxed" block thresholds only force a draft.
+type RiskConfig struct {
+	Changes      RiskGateConfig `mapstructure:"changes"`      // new + updated models
+	Deprecations RiskGateConfig `mapstructure:"deprecations"` // deprecation candidates
+	PriceDelta   RiskGateConfig `mapstructure:"price_delta"`  // relative token price change, 0.35 = 35%
+
+	// FamilyRemoved is what a changeset does when a whole model family is
+	// no longer listed: "block" (the default), "draft" or "off".
+	FamilyRemoved string `mapstructure:"family_removed"`
 }
 
-// BailingConfig holds Bailing-specific settings.
-type BailingConfig struct {
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+// RiskGateConfig holds the thresholds for one risk condition. A value is
+// only acted on when it is above a threshold; 0 disables the threshold.
+type RiskGateConfig struct {
+	Draft float64 `mapstructure:"draft"`
+	Block float64 `mapstructure:"block"`
 }
 
 // JudgeConfig holds LLM-as-judge settings.
@@ -236,17 +493,142 @@ type JudgeConfig struct {
 	Model     string `mapstructure:"model"`
 	OnReject  string `mapstructure:"on_reject"`
 	MaxTokens int    `mapstructure:"max_tokens"`
+	CacheTTL  string `mapstructure:"cache_ttl"` // reuse verdicts for identical changesets; "0" disables
+	AuditLog  string `mapstructure:"audit_log"` // JSON Lines record of every evaluation; "" disables
+
+	// ReviewComments posts each flagged or rejected verdict as a file-level
+	// review comment on the model's YAML file, in addition to the PR body.
+	ReviewComments bool `mapstructure:"review_comments"`
+
+	// Rules and Examples are the organization's review policy, added to
+	// the system prompt. RulesFile holds more rules, one per line, and
+	// ExamplesFile more examples, as a YAML list.
+	Rules        []string       `mapstructure:"rules"`
+	RulesFile    string         `mapstructure:"rules_file"`
+	Examples     []JudgeExample `mapstructure:"examples"`
+	ExamplesFile string         `mapstructure:"examples_file"`
+
+	// StructuredOutput holds the judge model to the verdict schema, with
+	// OpenAI structured outputs or an Anthropic tool call. Turn it off for
+	// compatible APIs without support; the verdicts are then parsed out of
+	// the response text.
+	StructuredOutput bool `mapstructure:"structured_output"`
+
+	// Screening settles clear-cut models with deterministic rules, such
+	// as an embedding model with the chat capability, before the LLM is
+	// called, and sends it only the rest.
+	Screening bool `mapstructure:"screening"`
+
+	// MaxSpendUSD caps what one run spends on judge calls. Once reached,
+	// the remaining changesets' models are flagged without evaluation.
+	// 0 disables the cap.
+	MaxSpendUSD float64 `mapstructure:"max_spend_usd"`
+	// InputPricePer1M and OutputPricePer1M price the judge model's tokens
+	// in USD. Unset, the judge model's cost in the catalog is used.
+	InputPricePer1M  float64 `mapstructure:"input_price_per_1m"`
+	OutputPricePer1M float64 `mapstructure:"output_price_per_1m"`
+}
+
+// JudgeExample is an example verdict shown to the judge.
+type JudgeExample struct {
+	Model     string   `mapstructure:"model"` // the model data, as JSON or in prose
+	Verdict   string   `mapstructure:"verdict"`
+	Concerns  []string `mapstructure:"concerns"`
+	Reasoning string   `mapstructure:"reasoning"`
+}
+
+// Rubric collects the inline rules and examples and those in RulesFile and
+// ExamplesFile. Blank lines and lines starting with # in RulesFile are
+// skipped.
+func (c JudgeConfig) Rubric() (judge.Rubric, error) {
+	r := judge.Rubric{Rules: c.Rules}
+	for _, ex := range c.Examples {
+		r.Examples = append(r.Examples, judge.Example{
+			Model:     ex.Model,
+			Verdict:   judge.Verdict(ex.Verdict),
+			Concerns:  ex.Concerns,
+			Reasoning: ex.Reasoning,
+		})
+	}
+	if c.RulesFile != "" {
+		data, err := os.ReadFile(c.RulesFile)
+		if err != nil {
+			return judge.Rubric{}, fmt.Errorf("reading judge.rules_file: %w", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				r.Rules = append(r.Rules, line)
+			}
+		}
+	}
+	if c.ExamplesFile != "" {
+		data, err := os.ReadFile(c.ExamplesFile)
+		if err != nil {
+			return judge.Rubric{}, fmt.Errorf("reading judge.examples_file: %w", err)
+		}
+		var examples []judge.Example
+		if err := yaml.Unmarshal(data, &examples); err != nil {
+			return judge.Rubric{}, fmt.Errorf("parsing judge.examples_file %s: %w", c.ExamplesFile, err)
+		}
+		for i, ex := range examples {
+			if !slices.Contains(judgeVerdicts, string(ex.Verdict)) {
+				return judge.Rubric{}, fmt.Errorf("judge.examples_file %s: example %d: %q is not one of %s", c.ExamplesFile, i+1, ex.Verdict, strings.Join(judgeVerdicts, ", "))
+			}
+		}
+		r.Examples = append(r.Examples, examples...)
+	}
+	return r, nil
 }
 
 // DiffConfig holds diff behavior settings.
 type DiffConfig struct {
 	TrackDisplayName bool `mapstructure:"track_display_name"`
+
+	// Renames decides what becomes of possible renames: "report" only
+	// lists them, "auto" applies every unambiguous one and "confirm" asks
+	// on the terminal, falling back to report when there is none.
+	Renames string `mapstructure:"renames"`
 }
 
 // HealthConfig holds source health check settings.
 type HealthConfig struct {
 	Enabled   bool    `mapstructure:"enabled"`
 	Threshold float64 `mapstructure:"threshold"`
+
+	// History is a JSON Lines file each sync appends every provider's
+	// outcome to, for `sentinel status-page`. Empty disables it.
+	History string `mapstructure:"history"`
+	// QuarantineAfter is how many failed runs in a row mark a provider
+	// as quarantined on the status page. 0 disables quarantine.
+	QuarantineAfter int `mapstructure:"quarantine_after"`
+
+	// StatusPages checks each provider's statuspage.io page before
+	// discovery. During a declared incident OnIncident decides what
+	// happens: "annotate" syncs anyway without failing on the model count
+	// and notes the incident in the PR, "skip" leaves the provider alone.
+	StatusPages bool   `mapstructure:"status_pages"`
+	OnIncident  string `mapstructure:"on_incident"`
+
+	// AnomalyDeviation is how far, as a fraction, a provider's model count
+	// may stray from its median over the last AnomalyRuns runs in History
+	// before OnAnomaly applies: "warn" notes it on the changeset, "block"
+	// fails the provider. 0 disables the check.
+	AnomalyDeviation float64 `mapstructure:"anomaly_deviation"`
+	AnomalyRuns      int     `mapstructure:"anomaly_runs"`
+	OnAnomaly        string  `mapstructure:"on_anomaly"`
+}
+
+// ReadinessConfig holds model readiness tracking settings.
+type ReadinessConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// DatabaseConfig holds settings for mirroring the catalog into a SQL database.
+type DatabaseConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	Driver      string `mapstructure:"driver"`
+	DSN         string `mapstructure:"dsn"`
+	AutoMigrate bool   `mapstructure:"auto_migrate"`
 }
 
 // Load reads configuration from file, environment, and defaults.
@@ -257,49 +639,91 @@ func Load(cfgFile string) (*Config, error) {
 	v.SetDefault("catalog_path", "../model-catalog")
 	v.SetDefault("cache_dir", defaultCacheDir())
 	v.SetDefault("cache_ttl", "1h")
+	v.SetDefault("cache.backend", "file")
+	v.SetDefault("cache.retain", "168h")
+	v.SetDefault("cache.redis.prefix", "sentinel:cache:")
+	v.SetDefault("cache.s3.prefix", "sentinel/cache/")
+	v.SetDefault("http.rate_limit", 10)
+	v.SetDefault("http.burst", 5)
+	v.SetDefault("http.warmup", "30s")
+	v.SetDefault("http.jitter", 0.2)
+	v.SetDefault("http.breaker_threshold", 5)
+	v.SetDefault("http.breaker_cooldown", "2m")
+	v.SetDefault("http.timeout", "30s")
+	v.SetDefault("http.max_idle_conns_per_host", 10)
+	v.SetDefault("http.idle_conn_timeout", "90s")
+	v.SetDefault("http.max_response_mb", 64)
 	v.SetDefault("providers", []string{"openai"})
 	v.SetDefault("sources", []string{"api", "docs"})
 	v.SetDefault("dry_run", false)
+	v.SetDefault("fail_on", []string{})
+	v.SetDefault("fail_fast", false)
+	v.SetDefault("discovery_timeout", "10m")
 	v.SetDefault("no_cache", false)
 	v.SetDefault("risk_mode", "strict")
+	v.SetDefault("risk.changes.draft", 25)
+	v.SetDefault("risk.changes.block", 0)
+	v.SetDefault("risk.deprecations.draft", 3)
+	v.SetDefault("risk.deprecations.block", 0)
+	v.SetDefault("risk.price_delta.draft", 0.35)
+	v.SetDefault("risk.price_delta.block", 0)
+	v.SetDefault("risk.family_removed", "block")
 	v.SetDefault("log_level", "info")
+	v.SetDefault("changelog", "CHANGELOG.md")
+	v.SetDefault("format", catalog.FormatYAML)
+	v.SetDefault("indexes", "indexes")
+	v.SetDefault("versioning.major_on", []string{"model_removal", "capability_removal", "limit_reduction"})
 	v.SetDefault("github.base_branch", "main")
-	v.SetDefault("openai.base_url", "https://api.openai.com/v1")
-	v.SetDefault("anthropic.base_url", "https://api.anthropic.com/v1")
-	v.SetDefault("google.base_url", "https://generativelanguage.googleapis.com/v1beta")
-	v.SetDefault("mistral.base_url", "https://api.mistral.ai/v1")
-	v.SetDefault("cohere.base_url", "https://api.cohere.com/v2")
-	v.SetDefault("groq.base_url", "https://api.groq.com/openai/v1")
-	v.SetDefault("deepseek.base_url", "https://api.deepseek.com")
-	v.SetDefault("xai.base_url", "https://api.x.ai/v1")
-	v.SetDefault("togetherai.base_url", "https://api.together.xyz/v1")
-	v.SetDefault("cerebras.base_url", "https://api.cerebras.ai/v1")
-	v.SetDefault("fireworks.base_url", "https://api.fireworks.ai/inference/v1")
-	v.SetDefault("deepinfra.base_url", "https://api.deepinfra.com/v1/openai")
-	v.SetDefault("nvidia.base_url", "https://integrate.api.nvidia.com/v1")
-	v.SetDefault("alibaba.base_url", "https://dashscope-intl.aliyuncs.com/compatible-mode/v1")
-	v.SetDefault("minimax.base_url", "https://api.minimax.io/v1")
-	v.SetDefault("moonshotai.base_url", "https://api.moonshot.ai/v1")
-	v.SetDefault("nebius.base_url", "https://api.tokenfactory.nebius.com/v1")
-	v.SetDefault("siliconflow.base_url", "https://api.siliconflow.com/v1")
-	v.SetDefault("inception.base_url", "https://api.inceptionlabs.ai/v1")
-	v.SetDefault("llama.base_url", "https://api.llama.com/compat/v1")
-	v.SetDefault("upstage.base_url", "https://api.upstage.ai/v1/solar")
-	v.SetDefault("nova.base_url", "https://api.nova.amazon.com/v1")
-	v.SetDefault("novitaai.base_url", "https://api.novita.ai/openai")
-	v.SetDefault("friendli.base_url", "https://api.friendli.ai/serverless/v1")
-	v.SetDefault("stepfun.base_url", "https://api.stepfun.com/v1")
-	v.SetDefault("zhipuai.base_url", "https://open.bigmodel.cn/api/paas/v4")
-	v.SetDefault("venice.base_url", "https://api.venice.ai/api/v1")
-	v.SetDefault("bailing.base_url", "https://api.tbox.cn/api/llm/v1")
+	v.SetDefault("github.max_pr_models", 100)
+	v.SetDefault("github.split_by", "family")
+	v.SetDefault("github.auto_clone", false)
+	v.SetDefault("github.commit_name", "sentinel")
+	v.SetDefault("github.commit_email", "sentinel@everstack.dev")
+	v.SetDefault("github.aggregate_prs", false)
+	v.SetDefault("github.update_open_prs", true)
+	v.SetDefault("github.alert_issues", true)
+	v.SetDefault("github.write_interval", "1s")
+	v.SetDefault("github.rate_limit_retries", 5)
+	v.SetDefault("github.max_rate_limit_wait", "15m")
+	for _, spec := range adapter.ConfigSpecs() {
+		env := "SENTINEL_" + strings.ToUpper(spec.Key) + "_"
+		v.SetDefault(spec.Key+".base_url", spec.BaseURL)
+		_ = v.BindEnv(spec.Key+".api_key", spec.APIKeyEnv)
+		_ = v.BindEnv(spec.Key+".base_url", env+"BASE_URL")
+		if spec.Settings != nil {
+			for k := range structKeys(reflect.TypeOf(spec.Settings).Elem()) {
+				_ = v.BindEnv(spec.Key+"."+k, env+strings.ToUpper(k))
+			}
+		}
+	}
 	v.SetDefault("diff.track_display_name", false)
+	v.SetDefault("diff.renames", "report")
 	v.SetDefault("health.enabled", true)
 	v.SetDefault("health.threshold", 0.90)
+	v.SetDefault("health.history", filepath.Join(defaultStateDir(), "runs.jsonl"))
+	v.SetDefault("health.quarantine_after", 3)
+	v.SetDefault("health.status_pages", false)
+	v.SetDefault("health.on_incident", "annotate")
+	v.SetDefault("health.anomaly_deviation", 0.30)
+	v.SetDefault("health.anomaly_runs", 10)
+	v.SetDefault("health.on_anomaly", "warn")
+	v.SetDefault("readiness.enabled", false)
+	v.SetDefault("database.enabled", false)
+	v.SetDefault("database.driver", "pgx")
+	v.SetDefault("database.auto_migrate", true)
+	v.SetDefault("yaml.indent", catalog.DefaultIndent)
+	v.SetDefault("yaml.quote", catalog.QuotePlain)
 	v.SetDefault("judge.enabled", false)
 	v.SetDefault("judge.provider", "anthropic")
 	v.SetDefault("judge.model", "claude-sonnet-4-20250514")
 	v.SetDefault("judge.on_reject", "draft")
 	v.SetDefault("judge.max_tokens", 4096)
+	v.SetDefault("judge.cache_ttl", "24h")
+	v.SetDefault("judge.audit_log", filepath.Join(defaultStateDir(), "judge-audit.jsonl"))
+	v.SetDefault("judge.review_comments", true)
+	v.SetDefault("judge.max_spend_usd", 0)
+	v.SetDefault("judge.screening", true)
+	v.SetDefault("judge.structured_output", true)
 
 	// Config file
 	if cfgFile != "" {
@@ -317,42 +741,33 @@ func Load(cfgFile string) (*Config, error) {
 
 	// Bind specific env vars
 	_ = v.BindEnv("github.token", "GITHUB_TOKEN")
-	_ = v.BindEnv("openai.api_key", "OPENAI_API_KEY")
-	_ = v.BindEnv("anthropic.api_key", "ANTHROPIC_API_KEY")
-	_ = v.BindEnv("anthropic.base_url", "SENTINEL_ANTHROPIC_BASE_URL")
-	_ = v.BindEnv("google.api_key", "GEMINI_API_KEY")
-	_ = v.BindEnv("google.base_url", "SENTINEL_GOOGLE_BASE_URL")
-	_ = v.BindEnv("mistral.api_key", "MISTRAL_API_KEY")
-	_ = v.BindEnv("mistral.base_url", "SENTINEL_MISTRAL_BASE_URL")
-	_ = v.BindEnv("cohere.api_key", "COHERE_API_KEY")
-	_ = v.BindEnv("groq.api_key", "GROQ_API_KEY")
-	_ = v.BindEnv("deepseek.api_key", "DEEPSEEK_API_KEY")
-	_ = v.BindEnv("xai.api_key", "XAI_API_KEY")
-	_ = v.BindEnv("togetherai.api_key", "TOGETHER_API_KEY")
-	_ = v.BindEnv("cerebras.api_key", "CEREBRAS_API_KEY")
-	_ = v.BindEnv("fireworks.api_key", "FIREWORKS_API_KEY")
-	_ = v.BindEnv("deepinfra.api_key", "DEEPINFRA_API_KEY")
-	_ = v.BindEnv("nvidia.api_key", "NVIDIA_API_KEY")
-	_ = v.BindEnv("alibaba.api_key", "DASHSCOPE_API_KEY")
-	_ = v.BindEnv("minimax.api_key", "MINIMAX_API_KEY")
-	_ = v.BindEnv("moonshotai.api_key", "MOONSHOT_API_KEY")
-	_ = v.BindEnv("nebius.api_key", "NEBIUS_API_KEY")
-	_ = v.BindEnv("siliconflow.api_key", "SILICONFLOW_API_KEY")
-	_ = v.BindEnv("inception.api_key", "INCEPTION_API_KEY")
-	_ = v.BindEnv("llama.api_key", "LLAMA_API_KEY")
-	_ = v.BindEnv("upstage.api_key", "UPSTAGE_API_KEY")
-	_ = v.BindEnv("nova.api_key", "NOVA_API_KEY")
-	_ = v.BindEnv("novitaai.api_key", "NOVITA_API_KEY")
-	_ = v.BindEnv("friendli.api_key", "FRIENDLI_TOKEN")
-	_ = v.BindEnv("stepfun.api_key", "STEPFUN_API_KEY")
-	_ = v.BindEnv("zhipuai.api_key", "ZHIPU_API_KEY")
-	_ = v.BindEnv("venice.api_key", "VENICE_API_KEY")
-	_ = v.BindEnv("bailing.api_key", "BAILING_API_TOKEN")
+	_ = v.BindEnv("report_path", "SENTINEL_REPORT_PATH")
+	_ = v.BindEnv("fail_on", "SENTINEL_FAIL_ON")
+	_ = v.BindEnv("fail_fast", "SENTINEL_FAIL_FAST")
+	_ = v.BindEnv("github.auto_clone", "SENTINEL_GITHUB_AUTO_CLONE")
+	_ = v.BindEnv("github.signing.format", "SENTINEL_SIGNING_FORMAT")
+	_ = v.BindEnv("github.signing.key", "SENTINEL_SIGNING_KEY")
+	_ = v.BindEnv("github.signing.passphrase", "SENTINEL_SIGNING_PASSPHRASE")
+	_ = v.BindEnv("cache.backend", "SENTINEL_CACHE_BACKEND")
+	_ = v.BindEnv("cache.redis.url", "SENTINEL_CACHE_REDIS_URL", "REDIS_URL")
+	_ = v.BindEnv("cache.http.url", "SENTINEL_CACHE_HTTP_URL")
+	_ = v.BindEnv("cache.http.token", "SENTINEL_CACHE_HTTP_TOKEN")
+	_ = v.BindEnv("cache.s3.bucket", "SENTINEL_CACHE_S3_BUCKET")
+	_ = v.BindEnv("cache.s3.region", "SENTINEL_CACHE_S3_REGION", "AWS_REGION")
+	_ = v.BindEnv("cache.s3.access_key_id", "SENTINEL_CACHE_S3_ACCESS_KEY_ID", "AWS_ACCESS_KEY_ID")
+	_ = v.BindEnv("cache.s3.secret_access_key", "SENTINEL_CACHE_S3_SECRET_ACCESS_KEY", "AWS_SECRET_ACCESS_KEY")
+	_ = v.BindEnv("cache.s3.session_token", "SENTINEL_CACHE_S3_SESSION_TOKEN", "AWS_SESSION_TOKEN")
+	_ = v.BindEnv("database.dsn", "SENTINEL_DATABASE_DSN", "DATABASE_URL")
 	_ = v.BindEnv("judge.enabled", "SENTINEL_JUDGE_ENABLED")
 	_ = v.BindEnv("judge.provider", "SENTINEL_JUDGE_PROVIDER")
 	_ = v.BindEnv("judge.model", "SENTINEL_JUDGE_MODEL")
 	_ = v.BindEnv("judge.on_reject", "SENTINEL_JUDGE_ON_REJECT")
 	_ = v.BindEnv("judge.max_tokens", "SENTINEL_JUDGE_MAX_TOKENS")
+	_ = v.BindEnv("judge.review_comments", "SENTINEL_JUDGE_REVIEW_COMMENTS")
+	_ = v.BindEnv("judge.audit_log", "SENTINEL_JUDGE_AUDIT_LOG")
+	_ = v.BindEnv("judge.max_spend_usd", "SENTINEL_JUDGE_MAX_SPEND_USD")
+	_ = v.BindEnv("judge.screening", "SENTINEL_JUDGE_SCREENING")
+	_ = v.BindEnv("health.history", "SENTINEL_HEALTH_HISTORY")
 
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -364,6 +779,14 @@ func Load(cfgFile string) (*Config, error) {
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("unmarshaling config: %w", err)
 	}
+	if problems := append(checkKeys(v.AllSettings()), cfg.validate()...); len(problems) > 0 {
+		return nil, &InvalidError{File: v.ConfigFileUsed(), Problems: problems}
+	}
+	sections, err := providerSections(v.AllSettings())
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling config: %w", err)
+	}
+	cfg.ProviderSections = sections
 
 	// Resolve catalog path to absolute
 	if !filepath.IsAbs(cfg.CatalogPath) {
@@ -373,14 +796,95 @@ func Load(cfgFile string) (*Config, error) {
 		}
 		cfg.CatalogPath = abs
 	}
+	if err := resolveCatalogs(v, &cfg); err != nil {
+		return nil, err
+	}
+	cfg.File = v.ConfigFileUsed()
 
 	return &cfg, nil
 }
 
+// resolveCatalogs checks the catalogs entries, makes their paths absolute
+// and fills in the GitHub settings each one inherits.
+func resolveCatalogs(v *viper.Viper, cfg *Config) error {
+	raw, _ := v.Get("catalogs").([]any)
+	seen := make(map[string]bool, len(cfg.Catalogs))
+	for i := range cfg.Catalogs {
+		cat := &cfg.Catalogs[i]
+		switch {
+		case cat.Name == "":
+			return fmt.Errorf("catalogs[%d]: name is required", i)
+		case seen[cat.Name]:
+			return fmt.Errorf("catalogs: duplicate name %q", cat.Name)
+		case cat.Path == "":
+			return fmt.Errorf("catalogs.%s: path is required", cat.Name)
+		case len(cat.Providers) == 0:
+			return fmt.Errorf("catalogs.%s: providers is required", cat.Name)
+		}
+		seen[cat.Name] = true
+
+		abs, err := filepath.Abs(cat.Path)
+		if err != nil {
+			return fmt.Errorf("resolving catalogs.%s path: %w", cat.Name, err)
+		}
+		cat.Path = abs
+
+		// Decode the catalog's github keys over the top-level settings, so
+		// unset keys, false and 0 included, keep the inherited value.
+		github := make(map[string]any)
+		if top, ok := v.AllSettings()["github"].(map[string]any); ok {
+			for k, val := range top {
+				github[k] = val
+			}
+		}
+		if i < len(raw) {
+			if entry, ok := raw[i].(map[string]any); ok {
+				if own, ok := entry["github"].(map[string]any); ok {
+					for k, val := range own {
+						github[k] = val
+					}
+				}
+			}
+		}
+		sub := viper.New()
+		if err := sub.MergeConfigMap(map[string]any{"github": github}); err != nil {
+			return fmt.Errorf("catalogs.%s github: %w", cat.Name, err)
+		}
+		if err := sub.UnmarshalKey("github", &cat.GitHub); err != nil {
+			return fmt.Errorf("catalogs.%s github: %w", cat.Name, err)
+		}
+	}
+	return nil
+}
+
+// defaultStateDir is where sentinel keeps records that outlive the cache,
+// following the XDG base directory spec. On Windows it lives under
+// %LocalAppData%, next to the cache.
+func defaultStateDir() string {
+	if runtime.GOOS == "windows" {
+		if dir, err := os.UserCacheDir(); err == nil {
+			return filepath.Join(dir, "sentinel", "state")
+		}
+	}
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "sentinel")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "sentinel-state")
+	}
+	return filepath.Join(home, ".local", "state", "sentinel")
+}
+
 func defaultCacheDir() string {
+	if runtime.GOOS == "windows" {
+		if dir, err := os.UserCacheDir(); err == nil {
+			return filepath.Join(dir, "sentinel", "cache")
+		}
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return "/tmp/sentinel-cache"
+		return filepath.Join(os.TempDir(), "sentinel-cache")
 	}
 	return filepath.Join(home, ".cache", "sentinel")
 }
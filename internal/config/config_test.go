@@ -0,0 +1,232 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/judge"
+)
+
+func TestLoadCatalogs(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "ghp-public")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := `catalog_path: ` + dir + `
+github:
+  owner: acme
+  repo: model-catalog
+  aggregate_prs: true
+catalogs:
+  - name: public
+    path: ` + filepath.Join(dir, "public") + `
+    providers: [openai, mistral]
+  - name: internal
+    path: ` + filepath.Join(dir, "internal") + `
+    providers: [openai]
+    github:
+      repo: internal-catalog
+      token: ghp-internal
+      aggregate_prs: false
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configs := cfg.ForCatalogs()
+	if len(configs) != 2 {
+		t.Fatalf("ForCatalogs returned %d configs, want 2", len(configs))
+	}
+
+	public, internal := configs[0], configs[1]
+	if public.CatalogName != "public" || public.CatalogPath != filepath.Join(dir, "public") || len(public.Providers) != 2 {
+		t.Errorf("public = %q %q %v", public.CatalogName, public.CatalogPath, public.Providers)
+	}
+	if gh := public.GitHub; gh.Owner != "acme" || gh.Repo != "model-catalog" || gh.Token != "ghp-public" || !gh.AggregatePRs || gh.BaseBranch != "main" {
+		t.Errorf("public github = %+v", gh)
+	}
+	if gh := internal.GitHub; gh.Owner != "acme" || gh.Repo != "internal-catalog" || gh.Token != "ghp-internal" || gh.AggregatePRs || gh.MaxPRModels != 100 {
+		t.Errorf("internal github = %+v", gh)
+	}
+	if len(internal.Catalogs) != 0 || len(cfg.Catalogs) != 2 {
+		t.Error("derived configs should not list catalogs, and the original should keep them")
+	}
+}
+
+func TestLoadCatalogsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"no name":        "catalogs:\n  - path: a\n    providers: [openai]\n",
+		"no path":        "catalogs:\n  - name: a\n    providers: [openai]\n",
+		"no providers":   "catalogs:\n  - name: a\n    path: a\n",
+		"duplicate name": "catalogs:\n  - name: a\n    path: a\n    providers: [openai]\n  - name: a\n    path: b\n    providers: [groq]\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, "config.yaml")
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+type specAdapter struct{ spec adapter.ConfigSpec }
+
+func (a *specAdapter) Name() string                           { return a.spec.Key }
+func (a *specAdapter) SupportedSources() []adapter.SourceType { return nil }
+func (a *specAdapter) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
+	return nil, nil
+}
+func (a *specAdapter) ConfigSpec() adapter.ConfigSpec                              { return a.spec }
+func (a *specAdapter) Configure(apiKey, baseURL string, client *httpclient.Client) {}
+
+func TestLoadAdapterSpecs(t *testing.T) {
+	adapter.Register(&specAdapter{adapter.ConfigSpec{Key: "acme", APIKeyEnv: "TEST_ACME_KEY", BaseURL: "https://acme.test/v1"}})
+	adapter.Register(&specAdapter{adapter.ConfigSpec{Key: "globex", APIKeyEnv: "TEST_GLOBEX_KEY", BaseURL: "https://globex.test/v1"}})
+	t.Setenv("TEST_ACME_KEY", "acme-env")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("globex:\n  api_key: from-file\n  base_url: https://proxy.test\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		key    string
+		want   ProviderAccess
+		wantOK bool
+	}{
+		{"acme", ProviderAccess{APIKey: "acme-env", BaseURL: "https://acme.test/v1"}, true},
+		{"globex", ProviderAccess{APIKey: "from-file", BaseURL: "https://proxy.test"}, true},
+		{"github", ProviderAccess{}, false},
+		{"nope", ProviderAccess{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := cfg.Access(tt.key)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Access(%q) = %+v, %v; want %+v, %v", tt.key, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+type initechSettings struct {
+	Region string   `mapstructure:"region"`
+	Models []string `mapstructure:"models"`
+}
+
+func TestLoadAdapterSettings(t *testing.T) {
+	var settings initechSettings
+	adapter.Register(&specAdapter{adapter.ConfigSpec{Key: "initech", APIKeyEnv: "TEST_INITECH_KEY", Settings: &settings}})
+	t.Setenv("SENTINEL_INITECH_MODELS", "tps,report")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("initech:\n  api_key: k\n  region: eu\n")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.DecodeSettings("initech", &settings); err != nil {
+		t.Fatal(err)
+	}
+	want := initechSettings{Region: "eu", Models: []string{"tps", "report"}}
+	if settings.Region != want.Region || !slices.Equal(settings.Models, want.Models) {
+		t.Errorf("settings = %+v, want %+v", settings, want)
+	}
+	if access, _ := cfg.Access("initech"); access.APIKey != "k" {
+		t.Errorf("api key = %q, want k", access.APIKey)
+	}
+
+	write("initech:\n  regoin: eu\n")
+	_, err = Load(path)
+	if err == nil || !strings.Contains(err.Error(), `unknown key "initech.regoin" (did you mean "region"?)`) {
+		t.Errorf("err = %v, want the misspelled setting rejected", err)
+	}
+}
+
+func TestDiscoveryTimeoutFor(t *testing.T) {
+	cfg := &Config{
+		DiscoveryTimeout:  "10m",
+		DiscoveryTimeouts: map[string]string{"openai": "20m", "groq": "0"},
+	}
+	tests := []struct {
+		provider string
+		want     time.Duration
+	}{
+		{"mistral", 10 * time.Minute},
+		{"openai", 20 * time.Minute},
+		{"groq", 0},
+	}
+	for _, tt := range tests {
+		if got := cfg.DiscoveryTimeoutFor(tt.provider); got != tt.want {
+			t.Errorf("DiscoveryTimeoutFor(%q) = %s, want %s", tt.provider, got, tt.want)
+		}
+	}
+	if got := (&Config{}).DiscoveryTimeoutFor("openai"); got != 0 {
+		t.Errorf("unset timeout = %s, want 0", got)
+	}
+}
+
+func TestJudgeRubric(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	rules := write("rules.txt", "# pricing\nReject models from acme without pricing.\n\n  Flag previews.  \n")
+	examples := write("examples.yaml", "- model: acme-embed-1 with chat capability\n  verdict: reject\n  concerns: [embedding model marked as chat]\n")
+	badExamples := write("bad.yaml", "- model: x\n  verdict: maybe\n")
+
+	c := JudgeConfig{
+		Rules:        []string{"Approve price cuts from openai."},
+		RulesFile:    rules,
+		Examples:     []JudgeExample{{Model: "gpt-x", Verdict: "flag", Reasoning: "unreleased"}},
+		ExamplesFile: examples,
+	}
+	r, err := c.Rubric()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRules := []string{"Approve price cuts from openai.", "Reject models from acme without pricing.", "Flag previews."}
+	if !slices.Equal(r.Rules, wantRules) {
+		t.Errorf("rules = %q, want %q", r.Rules, wantRules)
+	}
+	if len(r.Examples) != 2 || r.Examples[0].Verdict != judge.VerdictFlag || r.Examples[1].Verdict != judge.VerdictReject || r.Examples[1].Concerns[0] != "embedding model marked as chat" {
+		t.Errorf("examples = %+v", r.Examples)
+	}
+
+	for _, bad := range []JudgeConfig{
+		{RulesFile: filepath.Join(dir, "missing.txt")},
+		{ExamplesFile: badExamples},
+	} {
+		if _, err := bad.Rubric(); err == nil {
+			t.Errorf("Rubric() with %+v succeeded, want an error", bad)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/textutil"
+)
+
+// Values accepted by the enumerated settings. They are spelled out rather
+// than taken from the packages that interpret them, which config would
+// otherwise import for a list of names; a test keeps them in step. The
+// pipeline checks the settings only it interprets, such as diff.renames.
+var (
+	riskModes     = []string{"strict", "relaxed", "permissive"}
+	judgeOnReject = []string{"draft", "exclude"}
+	judgeClients  = []string{"anthropic", "openai"}
+	judgeVerdicts = []string{"approve", "flag", "reject"}
+	sourceTypes   = []string{"api", "docs", "llm"}
+	cacheBackends = []string{"file", "redis", "http", "s3", "gcs"}
+	failOnKinds   = []string{"error", "health", "validation"}
+	formats       = []string{"yaml", "json"}
+)
+
+// InvalidError lists everything wrong with a configuration: unknown keys
+// and settings outside their allowed values.
+type InvalidError struct {
+	File     string // empty when there was no config file
+	Problems []error
+}
+
+func (e *InvalidError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid config")
+	if e.File != "" {
+		b.WriteString(" " + e.File)
+	}
+	b.WriteString(":")
+	for _, p := range e.Problems {
+		b.WriteString("\n  " + p.Error())
+	}
+	return b.String()
+}
+
+func (e *InvalidError) Unwrap() []error { return e.Problems }
+
+// Validate checks the enumerated settings, reporting every bad value at
+// once. Load already does; this is for configs built or changed in code.
+func (c *Config) Validate() error {
+	if problems := c.validate(); len(problems) > 0 {
+		return &InvalidError{File: c.File, Problems: problems}
+	}
+	return nil
+}
+
+func (c *Config) validate() []error {
+	var errs []error
+	oneOf := func(key, value string, allowed []string) {
+		if value != "" && !slices.Contains(allowed, value) {
+			errs = append(errs, fmt.Errorf("%s: %q is not one of %s", key, value, strings.Join(allowed, ", ")))
+		}
+	}
+	oneOf("risk_mode", c.RiskMode, riskModes)
+	oneOf("judge.on_reject", c.Judge.OnReject, judgeOnReject)
+	oneOf("judge.provider", c.Judge.Provider, judgeClients)
+	oneOf("cache.backend", c.Cache.Backend, cacheBackends)
+	oneOf("format", c.Format, formats)
+	for i, cat := range c.Catalogs {
+		oneOf(fmt.Sprintf("catalogs[%d].format", i), cat.Format, formats)
+	}
+	for i, ex := range c.Judge.Examples {
+		key := fmt.Sprintf("judge.examples[%d].verdict", i)
+		if ex.Verdict == "" {
+			errs = append(errs, fmt.Errorf("%s: required, one of %s", key, strings.Join(judgeVerdicts, ", ")))
+		}
+		oneOf(key, ex.Verdict, judgeVerdicts)
+	}
+	for i, s := range c.Sources {
+		oneOf(fmt.Sprintf("sources[%d]", i), s, sourceTypes)
+	}
+	for i, k := range c.FailOn {
+		oneOf(fmt.Sprintf("fail_on[%d]", i), k, failOnKinds)
+	}
+	duration := func(key, value string) {
+		if _, err := time.ParseDuration(value); value != "" && err != nil {
+			errs = append(errs, fmt.Errorf("%s: %q is not a duration, such as 10m", key, value))
+		}
+	}
+	duration("discovery_timeout", c.DiscoveryTimeout)
+	for _, provider := range slices.Sorted(maps.Keys(c.DiscoveryTimeouts)) {
+		duration("discovery_timeouts."+provider, c.DiscoveryTimeouts[provider])
+	}
+	duration("http.timeout", c.HTTP.Timeout)
+	duration("http.idle_conn_timeout", c.HTTP.IdleConnTimeout)
+	for i, h := range c.HTTP.HostTimeouts {
+		duration(fmt.Sprintf("http.host_timeouts[%d].timeout", i), h.Timeout)
+	}
+	return errs
+}
+
+// checkKeys reports the keys in settings that neither a Config field nor
+// a registered adapter's section decodes, which viper would otherwise drop
+// without a word, suggesting the known key each one is likely a typo of.
+func checkKeys(settings map[string]any) []error {
+	fields := structKeys(reflect.TypeOf(Config{}))
+	for _, spec := range adapter.ConfigSpecs() {
+		fields[spec.Key] = sectionType(spec)
+	}
+	var errs []error
+	for _, u := range unknownFieldKeys(settings, fields, "") {
+		msg := fmt.Sprintf("unknown key %q", u.path)
+		if u.suggestion != "" {
+			msg += fmt.Sprintf(" (did you mean %q?)", u.suggestion)
+		}
+		errs = append(errs, errors.New(msg))
+	}
+	return errs
+}
+
+type unknownKey struct {
+	path       string
+	suggestion string
+}
+
+// unknownKeys walks settings alongside the struct type t. Maps with
+// arbitrary keys, such as extra_headers, are only walked into when their
+// values are structs.
+func unknownKeys(settings map[string]any, t reflect.Type, prefix string) []unknownKey {
+	return unknownFieldKeys(settings, structKeys(t), prefix)
+}
+
+func unknownFieldKeys(settings map[string]any, fields map[string]reflect.Type, prefix string) []unknownKey {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var unknown []unknownKey
+	for _, k := range keys {
+		ft, ok := fields[k]
+		if !ok {
+			known := make([]string, 0, len(fields))
+			for name := range fields {
+				known = append(known, name)
+			}
+			unknown = append(unknown, unknownKey{path: prefix + k, suggestion: closest(k, known)})
+			continue
+		}
+		unknown = append(unknown, unknownValueKeys(settings[k], ft, prefix+k)...)
+	}
+	return unknown
+}
+
+func unknownValueKeys(value any, t reflect.Type, path string) []unknownKey {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if m, ok := value.(map[string]any); ok {
+			return unknownKeys(m, t, path+".")
+		}
+	case reflect.Slice:
+		items, _ := value.([]any)
+		var unknown []unknownKey
+		for i, item := range items {
+			unknown = append(unknown, unknownValueKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return unknown
+	case reflect.Map:
+		m, _ := value.(map[string]any)
+		var unknown []unknownKey
+		for k, item := range m {
+			unknown = append(unknown, unknownValueKeys(item, t.Elem(), path+"."+k)...)
+		}
+		sort.Slice(unknown, func(i, k int) bool { return unknown[i].path < unknown[k].path })
+		return unknown
+	}
+	return nil
+}
+
+// structKeys maps the mapstructure keys of t to their field types,
+// including the fields of squashed embedded structs.
+func structKeys(t reflect.Type) map[string]reflect.Type {
+	keys := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		switch {
+		case name == "-", opts == "remain":
+		case opts == "squash":
+			for k, ft := range structKeys(f.Type) {
+				keys[k] = ft
+			}
+		case name != "":
+			keys[name] = f.Type
+		case f.IsExported():
+			keys[strings.ToLower(f.Name)] = f.Type
+		}
+	}
+	return keys
+}
+
+// sectionType is the struct an adapter's section decodes into: its
+// api_key and base_url plus the settings the adapter declares.
+func sectionType(spec adapter.ConfigSpec) reflect.Type {
+	fields := []reflect.StructField{{
+		Name: "Access",
+		Type: reflect.TypeOf(ProviderAccess{}),
+		Tag:  `mapstructure:",squash"`,
+	}}
+	if spec.Settings != nil {
+		fields = append(fields, reflect.StructField{
+			Name: "Settings",
+			Type: reflect.TypeOf(spec.Settings).Elem(),
+			Tag:  `mapstructure:",squash"`,
+		})
+	}
+	return reflect.StructOf(fields)
+}
+
+// closest returns the candidate nearest to key by edit distance, or ""
+// when none is close enough to be a likely typo.
+func closest(key string, candidates []string) string {
+	best, bestDist := "", len(key)/3+2
+	sort.Strings(candidates)
+	for _, c := range candidates {
+		if d := textutil.EditDistance(key, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/cache"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/judge"
+
+	// Register the adapters whose sections config.yaml sets.
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/ai21"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/alibaba"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/anthropic"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/bailing"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/cerebras"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/cohere"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/deepinfra"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/deepseek"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/fireworks"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/friendli"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/google"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/groq"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/inception"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/llama"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/minimax"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/mistral"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/moonshotai"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/nebius"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/nova"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/novitaai"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/nvidia"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/openai"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/perplexity"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/siliconflow"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/stepfun"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/togetherai"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/upstage"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/venice"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/xai"
+	_ "github.com/everstacklabs/sentinel/internal/adapter/providers/zhipuai"
+)
+
+func TestLoadStrict(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		want    []string // one per problem; none for a valid config
+	}{
+		{"valid", "risk_mode: relaxed\nsources: [api, docs]\nfilters:\n  openai:\n    include_patterns: [gpt-*]\nextra_headers:\n  anthropic:\n    anthropic-beta: x\n", nil},
+		{"misspelled provider", "togeterai:\n  api_key: x\n", []string{`unknown key "togeterai" (did you mean "togetherai"?)`}},
+		{"nested key", "github:\n  ownr: acme\n", []string{`unknown key "github.ownr" (did you mean "owner"?)`}},
+		{"no suggestion", "frobnicate: true\n", []string{`unknown key "frobnicate"`}},
+		{"map of structs", "filters:\n  openai:\n    include_pattern: [gpt-*]\n", []string{`unknown key "filters.openai.include_pattern" (did you mean "include_patterns"?)`}},
+		{"list of structs", "catalogs:\n  - name: a\n    path: a\n    providers: [openai]\n    provider: [groq]\n", []string{`unknown key "catalogs[0].provider" (did you mean "providers"?)`}},
+		{"provider-specific key", "anthropic:\n  betas: [x]\nopenai:\n  betas: [x]\n", []string{`unknown key "openai.betas"`}},
+		{"squashed fields", "validation:\n  min_max_tokens: 1000\n  max_price_per_1m: 1\n", []string{`unknown key "validation.max_price_per_1m" (did you mean "max_price_per_1k"?)`}},
+		{"enums", "risk_mode: lax\nformat: toml\njudge:\n  on_reject: block\n  examples:\n    - model: gpt-x\n      verdict: deny\nsources: [api, web]\nfail_on: [errors]\n", []string{
+			`risk_mode: "lax" is not one of strict, relaxed, permissive`,
+			`judge.on_reject: "block" is not one of draft, exclude`,
+			`format: "toml" is not one of yaml, json`,
+			`judge.examples[0].verdict: "deny" is not one of approve, flag, reject`,
+			`sources[1]: "web" is not one of api, docs, llm`,
+			`fail_on[0]: "errors" is not one of error, health, validation`,
+		}},
+		{"durations", "discovery_timeout: 10\ndiscovery_timeouts:\n  groq: 2 minutes\n  openai: 20m\nhttp:\n  host_timeouts:\n    - host: docs.anthropic.com\n      timeout: 2m\n    - host: groq.com\n      timeout: soon\n", []string{
+			`discovery_timeout: "10" is not a duration, such as 10m`,
+			`discovery_timeouts.groq: "2 minutes" is not a duration, such as 10m`,
+			`http.host_timeouts[1].timeout: "soon" is not a duration, such as 10m`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			var invalid *InvalidError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("err = %v, want an InvalidError", err)
+			}
+			var got []string
+			for _, p := range invalid.Problems {
+				got = append(got, p.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("problems:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestExampleConfigIsValid(t *testing.T) {
+	if _, err := Load(filepath.Join("..", "..", "config.yaml")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// The enumerated values are spelled out in strict.go; they must match the
+// names the packages interpreting them use.
+func TestEnumsMatchTheirPackages(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want []string
+	}{
+		{"judge.on_reject", judgeOnReject, []string{string(judge.OnRejectDraft), string(judge.OnRejectExclude)}},
+		{"judge verdicts", judgeVerdicts, []string{string(judge.VerdictApprove), string(judge.VerdictFlag), string(judge.VerdictReject)}},
+		{"sources", sourceTypes, []string{string(adapter.SourceAPI), string(adapter.SourceDocs), string(adapter.SourceLLM)}},
+		{"cache.backend", cacheBackends, []string{cache.BackendFile, cache.BackendRedis, cache.BackendHTTP, cache.BackendS3, cache.BackendGCS}},
+		{"format", formats, []string{catalog.FormatYAML, catalog.FormatJSON}},
+	}
+	for _, tt := range tests {
+		if !slices.Equal(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
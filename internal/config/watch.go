@@ -0,0 +1,254 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/validate"
+)
+
+// Files returns the files the settings depend on: the config file and the
+// validation contracts. A contracts file that doesn't exist yet is listed
+// too, so it is noticed when it appears.
+func (c *Config) Files() []string {
+	var files []string
+	if c.File != "" {
+		files = append(files, c.File)
+	}
+	contracts := c.Validation.ContractsFile
+	if contracts == "" {
+		contracts = validate.ContractsFile
+	}
+	if !filepath.IsAbs(contracts) {
+		contracts = filepath.Join(c.CatalogPath, contracts)
+	}
+	return append(files, contracts)
+}
+
+// Change is one effective setting that differs between two configs, by
+// its dotted key.
+type Change struct {
+	Key string `json:"key"`
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s → %s", c.Key, orUnset(c.Old), orUnset(c.New))
+}
+
+func orUnset(s string) string {
+	if s == "" {
+		return "(unset)"
+	}
+	return s
+}
+
+// Diff lists the settings that differ between old and next, sorted by key.
+// Credentials are compared but never shown.
+func Diff(old, next *Config) []Change {
+	before, after := make(map[string]string), make(map[string]string)
+	flatten("", reflect.ValueOf(*old), before)
+	flatten("", reflect.ValueOf(*next), after)
+	flatten("", reflect.ValueOf(old.ProviderSections), before)
+	flatten("", reflect.ValueOf(next.ProviderSections), after)
+
+	var changes []Change
+	for key, v := range after {
+		if before[key] != v {
+			changes = append(changes, Change{Key: key, Old: before[key], New: v})
+		}
+	}
+	for key, v := range before {
+		if _, ok := after[key]; !ok {
+			changes = append(changes, Change{Key: key, Old: v})
+		}
+	}
+	for i := range changes {
+		if isSecret(changes[i].Key) {
+			changes[i].Old, changes[i].New = redact(changes[i].Old), redact(changes[i].New)
+		}
+	}
+	sort.Slice(changes, func(i, k int) bool { return changes[i].Key < changes[k].Key })
+	return changes
+}
+
+// flatten records v under dotted keys named after the mapstructure tags,
+// the same keys the config file uses.
+func flatten(prefix string, v reflect.Value, out map[string]string) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			flatten(prefix, v.Elem(), out)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+			switch {
+			case name == "-":
+				continue
+			case opts == "squash", opts == "remain":
+				flatten(prefix, v.Field(i), out)
+				continue
+			case name == "":
+				name = strings.ToLower(f.Name)
+			}
+			flatten(join(name), v.Field(i), out)
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			flatten(join(fmt.Sprint(k.Interface())), v.MapIndex(k), out)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return
+		}
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			data = []byte(fmt.Sprint(v.Interface()))
+		}
+		out[prefix] = string(data)
+	default:
+		if !v.IsZero() {
+			out[prefix] = fmt.Sprint(v.Interface())
+		}
+	}
+}
+
+// isSecret reports whether a setting holds a credential.
+func isSecret(key string) bool {
+	last := key[strings.LastIndex(key, ".")+1:]
+	for _, s := range []string{"api_key", "token", "secret", "password", "dsn", "access_key"} {
+		if strings.Contains(last, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "<redacted>"
+}
+
+// Watcher reloads the config when one of its files changes. It polls
+// rather than subscribing to file events, which misses nothing on network
+// mounts and Kubernetes ConfigMap volumes, where files are swapped through
+// symlinks.
+type Watcher struct {
+	cfgFile  string
+	interval time.Duration
+	onChange func(old, next *Config, changes []Change)
+
+	current *Config
+	stamps  map[string]string
+}
+
+// NewWatcher returns a watcher for cfg, which was loaded from cfgFile
+// (empty for the default search path). onChange is called from the
+// watcher's goroutine, one reload at a time.
+func NewWatcher(cfgFile string, cfg *Config, interval time.Duration, onChange func(old, next *Config, changes []Change)) *Watcher {
+	w := &Watcher{cfgFile: cfgFile, interval: interval, onChange: onChange, current: cfg}
+	w.stamps = stampFiles(cfg.Files())
+	return w
+}
+
+// Run polls the config's files until ctx is done.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Check()
+		}
+	}
+}
+
+// Check reloads the config if its files changed since the last check and
+// reports whether the effective settings changed. A config that no longer
+// loads is logged and the current one is kept.
+func (w *Watcher) Check() bool {
+	stamps := stampFiles(w.current.Files())
+	if maps.Equal(stamps, w.stamps) {
+		return false
+	}
+
+	next, err := Load(w.cfgFile)
+	if err != nil {
+		w.stamps = stamps
+		slog.Error("config changed but failed to load, keeping the current settings", "error", err)
+		return false
+	}
+
+	changes := Diff(w.current, next)
+	// Referenced files count as settings of their own; the new config may
+	// also point at other files, such as a moved catalog's contracts.
+	nextStamps := stampFiles(next.Files())
+	for path, stamp := range nextStamps {
+		if path != next.File && stamp != w.stamps[path] {
+			changes = append(changes, Change{Key: path, Old: shortHash(w.stamps[path]), New: shortHash(stamp)})
+		}
+	}
+	w.stamps = nextStamps
+	sort.Slice(changes, func(i, k int) bool { return changes[i].Key < changes[k].Key })
+
+	if len(changes) == 0 {
+		slog.Debug("config files changed, effective settings did not")
+		return false
+	}
+	old := w.current
+	w.current = next
+	w.onChange(old, next, changes)
+	return true
+}
+
+// stampFiles returns a content hash of each file; a missing or unreadable
+// file hashes to "".
+func stampFiles(paths []string) map[string]string {
+	stamps := make(map[string]string, len(paths))
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			stamps[p] = ""
+			continue
+		}
+		sum := sha256.Sum256(data)
+		stamps[p] = hex.EncodeToString(sum[:])
+	}
+	return stamps
+}
+
+// shortHash abbreviates a file stamp for the change log.
+func shortHash(stamp string) string {
+	if len(stamp) > 12 {
+		return stamp[:12]
+	}
+	return stamp
+}
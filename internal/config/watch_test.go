@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatcherReloadsChangedSettings(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("catalog_path: " + dir + "\nproviders: [openai]\nhealth:\n  threshold: 0.9\nopenai:\n  api_key: sk-old\n")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.File != path {
+		t.Fatalf("File = %q, want %q", cfg.File, path)
+	}
+
+	var got []Change
+	w := NewWatcher(path, cfg, time.Second, func(old, next *Config, changes []Change) { got = changes })
+
+	if w.Check() {
+		t.Fatal("reloaded without a change")
+	}
+
+	// A comment changes the file but no setting.
+	write("# comment\ncatalog_path: " + dir + "\nproviders: [openai]\nhealth:\n  threshold: 0.9\nopenai:\n  api_key: sk-old\n")
+	if w.Check() {
+		t.Fatal("reloaded for a comment")
+	}
+
+	write("catalog_path: " + dir + "\nproviders: [openai, mistral]\nhealth:\n  threshold: 0.5\nopenai:\n  api_key: sk-new\n")
+	if !w.Check() {
+		t.Fatal("change not picked up")
+	}
+	var lines []string
+	for _, c := range got {
+		lines = append(lines, c.String())
+	}
+	want := []string{
+		`health.threshold: 0.9 → 0.5`,
+		`openai.api_key: <redacted> → <redacted>`,
+		`providers: ["openai"] → ["openai","mistral"]`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("changes:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	// A broken config is ignored, and so is the same broken config later.
+	write("providers: [openai\n")
+	if w.Check() || w.Check() {
+		t.Error("reloaded a config that doesn't load")
+	}
+
+	// Contracts are watched too.
+	if err := os.WriteFile(filepath.Join(dir, "contracts.yaml"), []byte("contracts: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	write("catalog_path: " + dir + "\nproviders: [openai, mistral]\nhealth:\n  threshold: 0.5\nopenai:\n  api_key: sk-new\n")
+	if !w.Check() {
+		t.Fatal("new contracts file not picked up")
+	}
+	if len(got) != 1 || got[0].Key != filepath.Join(dir, "contracts.yaml") {
+		t.Errorf("changes = %v, want only the contracts file", got)
+	}
+}
@@ -0,0 +1,275 @@
+// Package dbsync mirrors the file catalog into a SQL database.
+//
+// The YAML catalog remains the source of truth: rows are always built from the
+// models as they exist on disk after a write, so the database never holds a
+// representation the files don't. The schema targets PostgreSQL.
+package dbsync
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+
+	// Registers the "pgx" database/sql driver.
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// DefaultDriver is the database/sql driver used when none is configured.
+const DefaultDriver = "pgx"
+
+// Writer upserts catalog models into a SQL database.
+type Writer struct {
+	db *sql.DB
+}
+
+// Open connects to the database and verifies the connection.
+func Open(ctx context.Context, driver, dsn string) (*Writer, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("database dsn is required")
+	}
+	if driver == "" {
+		driver = DefaultDriver
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("connecting to database: %w", err)
+	}
+	return &Writer{db: db}, nil
+}
+
+// NewWriter wraps an existing database handle.
+func NewWriter(db *sql.DB) *Writer {
+	return &Writer{db: db}
+}
+
+// Close releases the underlying connection pool.
+func (w *Writer) Close() error {
+	return w.db.Close()
+}
+
+// SyncResult summarizes a provider sync.
+type SyncResult struct {
+	Upserted int
+	Removed  int
+}
+
+// SyncProvider makes the database rows for a provider match the given models
+// in a single transaction: every model is upserted and rows for models no
+// longer present are deleted.
+func (w *Writer) SyncProvider(ctx context.Context, provider, version string, models map[string]*catalog.Model) (*SyncResult, error) {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := &SyncResult{}
+	for _, name := range names {
+		row, err := newModelRow(provider, version, models[name])
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, upsertModelSQL, row.args()...); err != nil {
+			return nil, fmt.Errorf("upserting %s/%s: %w", provider, name, err)
+		}
+		result.Upserted++
+	}
+
+	removed, err := deleteStale(ctx, tx, provider, names)
+	if err != nil {
+		return nil, err
+	}
+	result.Removed = removed
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO catalog_sync_runs (provider, catalog_version, models_upserted, models_removed) VALUES ($1, $2, $3, $4)`,
+		provider, version, result.Upserted, result.Removed); err != nil {
+		return nil, fmt.Errorf("recording sync run: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing: %w", err)
+	}
+	return result, nil
+}
+
+// SyncCatalog runs SyncProvider for every provider in the catalog.
+func (w *Writer) SyncCatalog(ctx context.Context, cat *catalog.Catalog) (map[string]*SyncResult, error) {
+	providers := make([]string, 0, len(cat.Providers))
+	for name := range cat.Providers {
+		providers = append(providers, name)
+	}
+	sort.Strings(providers)
+
+	results := make(map[string]*SyncResult, len(providers))
+	for _, name := range providers {
+		r, err := w.SyncProvider(ctx, name, cat.Version, cat.Providers[name].Models)
+		if err != nil {
+			return results, fmt.Errorf("syncing %s: %w", name, err)
+		}
+		results[name] = r
+	}
+	return results, nil
+}
+
+func deleteStale(ctx context.Context, tx *sql.Tx, provider string, keep []string) (int, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT name FROM catalog_models WHERE provider = $1`, provider)
+	if err != nil {
+		return 0, fmt.Errorf("listing %s rows: %w", provider, err)
+	}
+	keepSet := make(map[string]bool, len(keep))
+	for _, k := range keep {
+		keepSet[k] = true
+	}
+	var stale []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scanning %s rows: %w", provider, err)
+		}
+		if !keepSet[name] {
+			stale = append(stale, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, name := range stale {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM catalog_models WHERE provider = $1 AND name = $2`, provider, name); err != nil {
+			return 0, fmt.Errorf("deleting %s/%s: %w", provider, name, err)
+		}
+	}
+	return len(stale), nil
+}
+
+const upsertModelSQL = `INSERT INTO catalog_models (
+	provider, name, display_name, family, status, readiness, currency,
+	input_per_1k, output_per_1k, cached_input_per_1k, per_image, per_request,
+	max_tokens, max_completion_tokens,
+	capabilities, input_modalities, output_modalities,
+	catalog_version, synced_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, now())
+ON CONFLICT (provider, name) DO UPDATE SET
+	display_name          = EXCLUDED.display_name,
+	family                = EXCLUDED.family,
+	status                = EXCLUDED.status,
+	readiness             = EXCLUDED.readiness,
+	currency              = EXCLUDED.currency,
+	input_per_1k          = EXCLUDED.input_per_1k,
+	output_per_1k         = EXCLUDED.output_per_1k,
+	cached_input_per_1k   = EXCLUDED.cached_input_per_1k,
+	per_image             = EXCLUDED.per_image,
+	per_request           = EXCLUDED.per_request,
+	max_tokens            = EXCLUDED.max_tokens,
+	max_completion_tokens = EXCLUDED.max_completion_tokens,
+	capabilities          = EXCLUDED.capabilities,
+	input_modalities      = EXCLUDED.input_modalities,
+	output_modalities     = EXCLUDED.output_modalities,
+	catalog_version       = EXCLUDED.catalog_version,
+	synced_at             = now()`
+
+// modelRow is the column representation of a catalog model.
+type modelRow struct {
+	Provider            string
+	Name                string
+	DisplayName         string
+	Family              string
+	Status              string
+	Readiness           string
+	Currency            string
+	InputPer1K          sql.NullFloat64
+	OutputPer1K         sql.NullFloat64
+	CachedInputPer1K    sql.NullFloat64
+	PerImage            sql.NullFloat64
+	PerRequest          sql.NullFloat64
+	MaxTokens           int
+	MaxCompletionTokens int
+	Capabilities        string
+	InputModalities     string
+	OutputModalities    string
+	CatalogVersion      string
+}
+
+// newModelRow converts a model to its row form. Prices are normalized to
+// per-1K tokens; models without a cost block get NULL prices.
+func newModelRow(provider, version string, m *catalog.Model) (*modelRow, error) {
+	row := &modelRow{
+		Provider:            provider,
+		Name:                m.Name,
+		DisplayName:         m.DisplayName,
+		Family:              m.Family,
+		Status:              m.Status,
+		Readiness:           m.Readiness,
+		Currency:            catalog.DefaultCurrency,
+		MaxTokens:           m.Limits.MaxTokens,
+		MaxCompletionTokens: m.Limits.MaxCompletionTokens,
+		CatalogVersion:      version,
+	}
+
+	if m.Cost != nil {
+		c := m.Cost.Normalize()
+		row.Currency = c.CurrencyOrDefault()
+		row.InputPer1K = sql.NullFloat64{Float64: c.InputPer1K, Valid: true}
+		row.OutputPer1K = sql.NullFloat64{Float64: c.OutputPer1K, Valid: true}
+		row.CachedInputPer1K = optionalPrice(c.CachedInputPer1K)
+		row.PerImage = optionalPrice(c.PerImage)
+		row.PerRequest = optionalPrice(c.PerRequest)
+	}
+
+	var err error
+	if row.Capabilities, err = jsonList(m.Capabilities); err != nil {
+		return nil, fmt.Errorf("encoding capabilities for %s: %w", m.Name, err)
+	}
+	if row.InputModalities, err = jsonList(m.Modalities.Input); err != nil {
+		return nil, fmt.Errorf("encoding input modalities for %s: %w", m.Name, err)
+	}
+	if row.OutputModalities, err = jsonList(m.Modalities.Output); err != nil {
+		return nil, fmt.Errorf("encoding output modalities for %s: %w", m.Name, err)
+	}
+	return row, nil
+}
+
+// args returns the row values in upsertModelSQL placeholder order.
+func (r *modelRow) args() []any {
+	return []any{
+		r.Provider, r.Name, r.DisplayName, r.Family, r.Status, r.Readiness, r.Currency,
+		r.InputPer1K, r.OutputPer1K, r.CachedInputPer1K, r.PerImage, r.PerRequest,
+		r.MaxTokens, r.MaxCompletionTokens,
+		r.Capabilities, r.InputModalities, r.OutputModalities,
+		r.CatalogVersion,
+	}
+}
+
+func optionalPrice(v float64) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: v, Valid: v != 0}
+}
+
+// jsonList encodes a string slice as a JSON array, using [] for nil.
+func jsonList(values []string) (string, error) {
+	if values == nil {
+		values = []string{}
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
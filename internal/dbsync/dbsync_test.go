@@ -0,0 +1,106 @@
+package dbsync
+
+import (
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+func TestMigrationsOrdered(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Migrations: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("expected embedded migrations")
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("migration %d has version %d, want contiguous versions starting at 1", i, m.Version)
+		}
+		if m.SQL == "" {
+			t.Errorf("migration %d (%s) is empty", m.Version, m.Name)
+		}
+	}
+}
+
+func TestParseMigrationName(t *testing.T) {
+	tests := []struct {
+		filename string
+		version  int
+		name     string
+		wantErr  bool
+	}{
+		{"001_create_models.sql", 1, "create_models", false},
+		{"12_add_index.sql", 12, "add_index", false},
+		{"create_models.sql", 0, "", true},
+		{"000_zero.sql", 0, "", true},
+		{"001_.sql", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		version, name, err := parseMigrationName(tt.filename)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.filename, err, tt.wantErr)
+			continue
+		}
+		if version != tt.version || name != tt.name {
+			t.Errorf("%s: got (%d, %q), want (%d, %q)", tt.filename, version, name, tt.version, tt.name)
+		}
+	}
+}
+
+func TestNewModelRowNormalizesCost(t *testing.T) {
+	m := &catalog.Model{
+		Name:        "gpt-5",
+		DisplayName: "GPT-5",
+		Family:      "gpt-5",
+		Status:      "stable",
+		Cost: &catalog.Cost{
+			InputPer1K:       1.25,
+			OutputPer1K:      10,
+			CachedInputPer1K: 0.125,
+			Unit:             catalog.PriceUnitPer1M,
+		},
+		Limits:       catalog.Limits{MaxTokens: 400000, MaxCompletionTokens: 128000},
+		Capabilities: []string{"chat", "vision"},
+		Modalities:   catalog.Modalities{Input: []string{"text", "image"}},
+	}
+
+	row, err := newModelRow("openai", "1.2.3", m)
+	if err != nil {
+		t.Fatalf("newModelRow: %v", err)
+	}
+
+	if row.InputPer1K.Float64 != 0.00125 || !row.InputPer1K.Valid {
+		t.Errorf("input_per_1k = %+v, want 0.00125", row.InputPer1K)
+	}
+	if row.OutputPer1K.Float64 != 0.01 {
+		t.Errorf("output_per_1k = %v, want 0.01", row.OutputPer1K.Float64)
+	}
+	if row.PerImage.Valid || row.PerRequest.Valid {
+		t.Error("expected unset per-image/per-request prices to be NULL")
+	}
+	if row.Currency != "USD" {
+		t.Errorf("currency = %q, want USD", row.Currency)
+	}
+	if row.Capabilities != `["chat","vision"]` {
+		t.Errorf("capabilities = %s", row.Capabilities)
+	}
+	if row.OutputModalities != `[]` {
+		t.Errorf("output modalities = %s, want []", row.OutputModalities)
+	}
+	if got := len(row.args()); got != 18 {
+		t.Errorf("args() returned %d values, want 18 to match upsertModelSQL", got)
+	}
+}
+
+func TestNewModelRowWithoutCost(t *testing.T) {
+	row, err := newModelRow("anthropic", "1.0.0", &catalog.Model{Name: "claude-x"})
+	if err != nil {
+		t.Fatalf("newModelRow: %v", err)
+	}
+	if row.InputPer1K.Valid || row.OutputPer1K.Valid {
+		t.Error("expected NULL prices for a model without cost")
+	}
+}
@@ -0,0 +1,143 @@
+package dbsync
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// Migration is a single versioned schema change.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// Migrations returns the embedded migrations ordered by version.
+func Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("reading migrations: %w", err)
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		version, name, err := parseMigrationName(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if prev, ok := seen[version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, prev, e.Name())
+		}
+		seen[version] = e.Name()
+
+		data, err := migrationFS.ReadFile(path.Join("migrations", e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading migration %s: %w", e.Name(), err)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name, SQL: string(data)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// parseMigrationName splits "001_create_models.sql" into (1, "create_models").
+func parseMigrationName(filename string) (int, string, error) {
+	base := strings.TrimSuffix(filename, ".sql")
+	prefix, name, ok := strings.Cut(base, "_")
+	if !ok || name == "" {
+		return 0, "", fmt.Errorf("invalid migration filename %q: want NNN_name.sql", filename)
+	}
+	version, err := strconv.Atoi(prefix)
+	if err != nil || version <= 0 {
+		return 0, "", fmt.Errorf("invalid migration filename %q: version must be a positive integer", filename)
+	}
+	return version, name, nil
+}
+
+// Migrate applies any pending migrations, each in its own transaction.
+// Returns the number of migrations applied.
+func (w *Writer) Migrate(ctx context.Context) (int, error) {
+	if _, err := w.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    INTEGER     PRIMARY KEY,
+	name       TEXT        NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`); err != nil {
+		return 0, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	applied, err := w.appliedVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	migrations, err := Migrations()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+		if err := w.applyMigration(ctx, m); err != nil {
+			return count, err
+		}
+		slog.Info("applied migration", "version", m.Version, "name", m.Name)
+		count++
+	}
+	return count, nil
+}
+
+func (w *Writer) appliedVersions(ctx context.Context) (map[int]bool, error) {
+	rows, err := w.db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, fmt.Errorf("scanning schema_migrations: %w", err)
+		}
+		applied[v] = true
+	}
+	return applied, rows.Err()
+}
+
+func (w *Writer) applyMigration(ctx context.Context, m Migration) error {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("migration %d: %w", m.Version, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return fmt.Errorf("migration %d (%s): %w", m.Version, m.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`,
+		m.Version, m.Name); err != nil {
+		return fmt.Errorf("recording migration %d: %w", m.Version, err)
+	}
+	return tx.Commit()
+}
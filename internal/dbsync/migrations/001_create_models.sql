@@ -0,0 +1,28 @@
+-- Models mirrored from the file catalog. One row per provider/model pair.
+-- Token prices are always stored per 1K tokens, regardless of the unit used
+-- in the YAML file.
+CREATE TABLE IF NOT EXISTS catalog_models (
+    provider              TEXT        NOT NULL,
+    name                  TEXT        NOT NULL,
+    display_name          TEXT        NOT NULL,
+    family                TEXT        NOT NULL DEFAULT '',
+    status                TEXT        NOT NULL DEFAULT '',
+    readiness             TEXT        NOT NULL DEFAULT '',
+    currency              TEXT        NOT NULL DEFAULT 'USD',
+    input_per_1k          DOUBLE PRECISION,
+    output_per_1k         DOUBLE PRECISION,
+    cached_input_per_1k   DOUBLE PRECISION,
+    per_image             DOUBLE PRECISION,
+    per_request           DOUBLE PRECISION,
+    max_tokens            INTEGER     NOT NULL DEFAULT 0,
+    max_completion_tokens INTEGER     NOT NULL DEFAULT 0,
+    capabilities          JSONB       NOT NULL DEFAULT '[]',
+    input_modalities      JSONB       NOT NULL DEFAULT '[]',
+    output_modalities     JSONB       NOT NULL DEFAULT '[]',
+    catalog_version       TEXT        NOT NULL,
+    synced_at             TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider, name)
+);
+
+CREATE INDEX IF NOT EXISTS catalog_models_family_idx ON catalog_models (family);
+CREATE INDEX IF NOT EXISTS catalog_models_status_idx ON catalog_models (status);
@@ -0,0 +1,12 @@
+-- One row per provider sync, so consumers can tell which catalog version the
+-- table reflects for each provider.
+CREATE TABLE IF NOT EXISTS catalog_sync_runs (
+    id              BIGSERIAL   PRIMARY KEY,
+    provider        TEXT        NOT NULL,
+    catalog_version TEXT        NOT NULL,
+    models_upserted INTEGER     NOT NULL,
+    models_removed  INTEGER     NOT NULL,
+    synced_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS catalog_sync_runs_provider_idx ON catalog_sync_runs (provider, synced_at DESC);
@@ -0,0 +1,135 @@
+package diff
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// RenderAnnotations renders changesets as GitHub Actions workflow commands,
+// so a dry run shows up inline in the Actions UI. Updated and deprecated
+// models are annotated on their file; root is the catalog's path relative
+// to the workspace, with forward slashes, and ext the extension of its
+// model files.
+func RenderAnnotations(changesets []ChangeSet, root, ext string) string {
+	var b strings.Builder
+	for _, cs := range changesets {
+		file := func(name string) string {
+			return path.Join(root, "providers", cs.Provider, "models", name+ext)
+		}
+		for _, f := range cs.RemovedFamilies {
+			writeCommand(&b, "error", "", "Model family removed ("+cs.Provider+")",
+				fmt.Sprintf("%s no longer lists any %s model (%s): a provider retirement or an adapter regression",
+					cs.Provider, f.Family, strings.Join(f.Models, ", ")))
+		}
+		for _, m := range cs.New {
+			writeCommand(&b, "notice", "", "New model",
+				fmt.Sprintf("%s/%s would be added", cs.Provider, m.Name))
+		}
+		for _, u := range cs.Updated {
+			fields := make([]string, 0, len(u.Changes))
+			for _, c := range u.Changes {
+				fields = append(fields, fmt.Sprintf("%s: %v → %v", c.Field, FormatValue(c.OldValue), FormatValue(c.NewValue)))
+			}
+			writeCommand(&b, "notice", file(u.Name), "Model update",
+				fmt.Sprintf("%s/%s would change:\n%s", cs.Provider, u.Name, strings.Join(fields, "\n")))
+		}
+		for _, m := range cs.DeprecationCandidates {
+			writeCommand(&b, "warning", file(m.Name), "Deprecation candidate",
+				fmt.Sprintf("%s/%s is no longer listed by the provider", cs.Provider, m.Name))
+		}
+		for _, r := range cs.Renamed {
+			writeCommand(&b, "notice", file(r.OldName), "Model rename",
+				fmt.Sprintf("%s/%s would be renamed to %s", cs.Provider, r.OldName, r.Name))
+		}
+		for _, r := range cs.PossibleRenames {
+			writeCommand(&b, "notice", file(r.OldName), "Possible rename",
+				fmt.Sprintf("%s/%s may have been renamed to %s (%.0f%% confidence: %s)", cs.Provider, r.OldName, r.NewName, r.Score*100, r.Reason))
+		}
+		for _, w := range cs.Warnings {
+			writeCommand(&b, "warning", "", "Discovery warning ("+cs.Provider+")", w.String())
+		}
+	}
+	return b.String()
+}
+
+// writeCommand writes one ::<level>:: workflow command.
+func writeCommand(b *strings.Builder, level, file, title, message string) {
+	var props []string
+	if file != "" {
+		props = append(props, "file="+escapeProperty(file))
+	}
+	if title != "" {
+		props = append(props, "title="+escapeProperty(title))
+	}
+	b.WriteString("::" + level)
+	if len(props) > 0 {
+		b.WriteString(" " + strings.Join(props, ","))
+	}
+	b.WriteString("::" + escapeData(message) + "\n")
+}
+
+// escapeData and escapeProperty encode values the way the Actions runner
+// decodes them, so messages can span lines and contain separators.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// RenderJobSummary renders changesets as Markdown for an Actions job
+// summary: a table of counts per provider, then each provider's changes.
+func RenderJobSummary(changesets []ChangeSet) string {
+	var b strings.Builder
+	b.WriteString("## Sentinel dry run\n\n")
+
+	changed := 0
+	b.WriteString("| Provider | New | Updated | Deprecation candidates | Unchanged | Warnings |\n")
+	b.WriteString("|---|---:|---:|---:|---:|---:|\n")
+	for _, cs := range changesets {
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %d | %d |\n", cs.Provider,
+			len(cs.New), len(cs.Updated), len(cs.DeprecationCandidates), cs.Unchanged, len(cs.Warnings))
+		if cs.HasChanges() {
+			changed++
+		}
+	}
+	b.WriteString("\n")
+	if changed == 0 {
+		b.WriteString("No changes.\n")
+		return b.String()
+	}
+
+	for _, cs := range changesets {
+		if !cs.HasChanges() && len(cs.Warnings) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "### %s\n\n", cs.Provider)
+		for _, f := range cs.RemovedFamilies {
+			fmt.Fprintf(&b, "> [!CAUTION]\n> The whole `%s` family is gone (%d models). Check for a provider retirement or an adapter regression.\n\n",
+				f.Family, len(f.Models))
+		}
+		for _, m := range cs.New {
+			fmt.Fprintf(&b, "- Add `%s`\n", m.Name)
+		}
+		for _, u := range cs.Updated {
+			fields := make([]string, 0, len(u.Changes))
+			for _, c := range u.Changes {
+				fields = append(fields, fmt.Sprintf("%s: %v → %v", c.Field, FormatValue(c.OldValue), FormatValue(c.NewValue)))
+			}
+			fmt.Fprintf(&b, "- Change `%s` (%s)\n", u.Name, strings.Join(fields, "; "))
+		}
+		for _, r := range cs.Renamed {
+			fmt.Fprintf(&b, "- Rename `%s` to `%s`\n", r.OldName, r.Name)
+		}
+		for _, m := range cs.DeprecationCandidates {
+			fmt.Fprintf(&b, "- Deprecation candidate `%s`\n", m.Name)
+		}
+		for _, w := range cs.Warnings {
+			fmt.Fprintf(&b, "- :warning: %s\n", w)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Kinds of breaking change: ones that can break a consumer relying on the
+// catalog as it was.
+const (
+	BreakingModelRemoval      = "model_removal"      // a model is deprecated
+	BreakingCapabilityRemoval = "capability_removal" // a capability or modality is dropped
+	BreakingLimitReduction    = "limit_reduction"    // a token limit goes down
+)
+
+// BreakingKinds returns the kinds of breaking change.
+func BreakingKinds() []string {
+	return []string{BreakingModelRemoval, BreakingCapabilityRemoval, BreakingLimitReduction}
+}
+
+// BreakingChange is one breaking change to a model.
+type BreakingChange struct {
+	Kind   string
+	Model  string
+	Detail string
+}
+
+func (b BreakingChange) String() string {
+	return fmt.Sprintf("%s: %s", b.Model, b.Detail)
+}
+
+// Breaking returns the breaking changes among a changeset's updates.
+// Deprecation candidates aren't included: a sync only reports them.
+func Breaking(cs *ChangeSet) []BreakingChange {
+	var out []BreakingChange
+	for _, u := range cs.Updated {
+		for _, c := range u.Changes {
+			switch c.Field {
+			case "status":
+				if c.NewValue == "deprecated" {
+					out = append(out, BreakingChange{BreakingModelRemoval, u.Name, "deprecated"})
+				}
+			case "capabilities", "modalities.input", "modalities.output":
+				old, _ := c.OldValue.([]string)
+				cur, _ := c.NewValue.([]string)
+				var dropped []string
+				for _, v := range old {
+					if !slices.Contains(cur, v) {
+						dropped = append(dropped, v)
+					}
+				}
+				if len(dropped) > 0 {
+					out = append(out, BreakingChange{BreakingCapabilityRemoval, u.Name, c.Field + " lost " + strings.Join(dropped, ", ")})
+				}
+			case "limits.max_tokens", "limits.max_completion_tokens":
+				old, _ := c.OldValue.(int)
+				cur, _ := c.NewValue.(int)
+				if cur < old {
+					out = append(out, BreakingChange{BreakingLimitReduction, u.Name, fmt.Sprintf("%s %d → %d", c.Field, old, cur)})
+				}
+			}
+		}
+	}
+	return out
+}
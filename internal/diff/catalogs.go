@@ -0,0 +1,189 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+// CompareCatalogs diffs two versions of a catalog, one changeset per
+// provider that changed, sorted by provider. Unlike Compute, both sides are
+// catalog files: there is no discovery, so models missing from to were
+// removed from the catalog and are reported as DeprecationCandidates.
+func CompareCatalogs(from, to *catalog.Catalog) []ChangeSet {
+	providers := make(map[string]bool)
+	for name := range from.Providers {
+		providers[name] = true
+	}
+	for name := range to.Providers {
+		providers[name] = true
+	}
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Display names are hand-edited in the catalog, so they count here.
+	opts := DiffOptions{TrackDisplayName: true}
+
+	var out []ChangeSet
+	for _, provider := range names {
+		before, after := modelsOf(from, provider), modelsOf(to, provider)
+		cs := ChangeSet{Provider: provider}
+		for _, name := range sortedNames(after) {
+			m := after[name]
+			old, ok := before[name]
+			if !ok {
+				cs.New = append(cs.New, ModelChange{Name: name, Model: m})
+				continue
+			}
+			changes := computeFieldChanges(old, m, opts)
+			if old.Readiness != m.Readiness {
+				changes = append(changes, catalog.FieldChange{Field: "readiness", OldValue: old.Readiness, NewValue: m.Readiness})
+			}
+			if len(changes) > 0 {
+				cs.Updated = append(cs.Updated, ModelUpdate{Name: name, Model: m, Changes: changes})
+			} else {
+				cs.Unchanged++
+			}
+		}
+		for _, name := range sortedNames(before) {
+			if _, ok := after[name]; !ok {
+				cs.DeprecationCandidates = append(cs.DeprecationCandidates, ModelChange{Name: name, Model: before[name]})
+			}
+		}
+		if cs.HasChanges() {
+			out = append(out, cs)
+		}
+	}
+	return out
+}
+
+func modelsOf(c *catalog.Catalog, provider string) map[string]*catalog.Model {
+	if pc, ok := c.Providers[provider]; ok {
+		return pc.Models
+	}
+	return nil
+}
+
+func sortedNames(models map[string]*catalog.Model) []string {
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RenderReleaseNotes renders changesets from CompareCatalogs as Markdown
+// release notes for the from → to version range.
+func RenderReleaseNotes(fromVersion, toVersion string, changesets []ChangeSet) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## Catalog changes %s → %s\n\n", fromVersion, toVersion)
+	if len(changesets) == 0 {
+		b.WriteString("No model changes.\n")
+		return b.String()
+	}
+
+	for _, cs := range changesets {
+		fmt.Fprintf(&b, "### %s\n\n", cs.Provider)
+		for _, m := range cs.New {
+			fmt.Fprintf(&b, "- Added `%s`\n", m.Name)
+		}
+		for _, u := range cs.Updated {
+			fields := make([]string, 0, len(u.Changes))
+			for _, c := range u.Changes {
+				fields = append(fields, fmt.Sprintf("%s: %v → %v", c.Field, FormatValue(c.OldValue), FormatValue(c.NewValue)))
+			}
+			fmt.Fprintf(&b, "- Changed `%s` (%s)\n", u.Name, strings.Join(fields, "; "))
+		}
+		for _, m := range cs.DeprecationCandidates {
+			fmt.Fprintf(&b, "- Removed `%s`\n", m.Name)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// RenderChangelogEntry renders a CHANGELOG.md entry for a catalog release:
+// a heading for version and date, then the models each changeset added,
+// changed and deprecated. Deprecation candidates are left out, since a sync
+// only reports them, and so are sections with no models. A release with
+// nothing to list renders as "".
+func RenderChangelogEntry(version string, date time.Time, changesets []ChangeSet) string {
+	var b strings.Builder
+	for _, cs := range changesets {
+		var added, changed, renamed, deprecated []string
+		for _, m := range cs.New {
+			added = append(added, fmt.Sprintf("- `%s`", m.Name))
+		}
+		for _, r := range cs.Renamed {
+			renamed = append(renamed, fmt.Sprintf("- `%s` → `%s`", r.OldName, r.Name))
+		}
+		for _, u := range cs.Updated {
+			fields := make([]string, 0, len(u.Changes))
+			for _, c := range u.Changes {
+				if c.Field == "status" && c.NewValue == "deprecated" {
+					deprecated = append(deprecated, fmt.Sprintf("- `%s`", u.Name))
+					continue
+				}
+				fields = append(fields, fmt.Sprintf("%s: %v → %v", c.Field, FormatValue(c.OldValue), FormatValue(c.NewValue)))
+			}
+			if len(fields) > 0 {
+				changed = append(changed, fmt.Sprintf("- `%s` (%s)", u.Name, strings.Join(fields, "; ")))
+			}
+		}
+		if len(added)+len(changed)+len(renamed)+len(deprecated) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "### %s\n\n", cs.Provider)
+		for _, section := range []struct {
+			title string
+			lines []string
+		}{
+			{"Added", added},
+			{"Changed", changed},
+			{"Renamed", renamed},
+			{"Deprecated", deprecated},
+		} {
+			if len(section.lines) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "#### %s\n\n%s\n\n", section.title, strings.Join(section.lines, "\n"))
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return fmt.Sprintf("## %s (%s)\n\n", version, date.Format("2006-01-02")) + b.String()
+}
+
+// FormatValue prints a field change value for people, with empty values as
+// "none" so a field being set or cleared reads naturally.
+func FormatValue(v any) any {
+	switch x := v.(type) {
+	case nil:
+		return "none"
+	case string:
+		if x == "" {
+			return "none"
+		}
+	case []string:
+		if len(x) == 0 {
+			return "none"
+		}
+		return strings.Join(x, ", ")
+	case *catalog.Cost:
+		if x == nil {
+			return "none"
+		}
+		return fmt.Sprintf("%g in / %g out per 1K", x.InputPer1K, x.OutputPer1K)
+	}
+	return v
+}
@@ -1,6 +1,15 @@
 package diff
 
-import "github.com/everstacklabs/sentinel/internal/catalog"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
 
 // ChangeSet represents the complete diff between discovered and existing models.
 type ChangeSet struct {
@@ -10,6 +19,18 @@ type ChangeSet struct {
 	DeprecationCandidates []ModelChange
 	PossibleRenames       []RenamePair
 	Unchanged             int
+
+	// Renamed are possible renames that were confirmed: the old model's
+	// file moves to the new name instead of the new model being added.
+	Renamed []ModelRename
+
+	// RemovedFamilies are catalog families of which the provider no longer
+	// lists a single model. Their models are also deprecation candidates.
+	RemovedFamilies []FamilyRemoval
+
+	// Warnings are discovery oddities reported by the adapter or noticed by
+	// the pipeline. They are informational and don't count as changes.
+	Warnings []adapter.Warning
 }
 
 // ModelChange represents a new or deprecated model.
@@ -23,21 +44,185 @@ type ModelUpdate struct {
 	Name    string
 	Model   *catalog.Model
 	Changes []catalog.FieldChange
+	// Provenance is the catalog's record of where the values being
+	// replaced came from, by top-level field.
+	Provenance map[string]catalog.FieldSource `json:",omitempty"`
 }
 
 // RenamePair represents a possible rename (old model disappeared, new appeared).
 type RenamePair struct {
 	OldName string
 	NewName string
-	Reason  string // e.g., "same family, similar limits"
+	Score   float64 // confidence from 0 to 1
+	Reason  string  // e.g., "same family, name 89%, limits 100%"
+}
+
+// ModelRename is a confirmed rename. Model is the discovered model under
+// its new name.
+type ModelRename struct {
+	OldName string
+	Name    string
+	Model   *catalog.Model
+}
+
+// FamilyRemoval is a model family that disappeared from discovery as a
+// whole. That is either a major retirement by the provider or an adapter
+// that stopped parsing part of its source, and needs a human either way.
+type FamilyRemoval struct {
+	Family string
+	Models []string // the family's catalog models, sorted
 }
 
 // HasChanges reports whether the changeset has any modifications.
 func (cs *ChangeSet) HasChanges() bool {
-	return len(cs.New) > 0 || len(cs.Updated) > 0 || len(cs.DeprecationCandidates) > 0
+	return len(cs.New) > 0 || len(cs.Updated) > 0 || len(cs.Renamed) > 0 || len(cs.DeprecationCandidates) > 0
 }
 
-// TotalChanged returns the count of new + updated models.
+// TotalChanged returns the count of new, updated and renamed models.
 func (cs *ChangeSet) TotalChanged() int {
-	return len(cs.New) + len(cs.Updated)
+	return len(cs.New) + len(cs.Updated) + len(cs.Renamed)
+}
+
+// ApplyRenames confirms the possible renames confirm accepts. A confirmed
+// rename's new model moves from New to Renamed. Pairs sharing a model with
+// another pair are ambiguous and stay possible renames without asking.
+func (cs *ChangeSet) ApplyRenames(confirm func(RenamePair) bool) {
+	seen := make(map[string]int)
+	for _, r := range cs.PossibleRenames {
+		seen["old:"+r.OldName]++
+		seen["new:"+r.NewName]++
+	}
+	var pending []RenamePair
+	confirmed := make(map[string]string) // new name -> old name
+	for _, r := range cs.PossibleRenames {
+		if seen["old:"+r.OldName] == 1 && seen["new:"+r.NewName] == 1 && confirm(r) {
+			confirmed[r.NewName] = r.OldName
+			continue
+		}
+		pending = append(pending, r)
+	}
+	if len(confirmed) == 0 {
+		return
+	}
+	cs.PossibleRenames = pending
+
+	var kept []ModelChange
+	for _, m := range cs.New {
+		if old, ok := confirmed[m.Name]; ok {
+			cs.Renamed = append(cs.Renamed, ModelRename{OldName: old, Name: m.Name, Model: m.Model})
+			continue
+		}
+		kept = append(kept, m)
+	}
+	cs.New = kept
+}
+
+// Hash returns a stable SHA-256 of the changeset's content, for caching
+// work that depends only on what changed. The unchanged count and updater
+// metadata (verification timestamps), warnings and removed families, which
+// follow from the deprecation candidates, are left out so they don't bust
+// caches.
+func (cs *ChangeSet) Hash() (string, error) {
+	stripped := *cs
+	stripped.Unchanged = 0
+	stripped.Warnings = nil
+	stripped.RemovedFamilies = nil
+	stripped.New = stripChanges(cs.New)
+	stripped.DeprecationCandidates = stripChanges(cs.DeprecationCandidates)
+	stripped.Renamed = make([]ModelRename, len(cs.Renamed))
+	for i, r := range cs.Renamed {
+		r.Model = stripModel(r.Model)
+		stripped.Renamed[i] = r
+	}
+	stripped.Updated = make([]ModelUpdate, len(cs.Updated))
+	for i, u := range cs.Updated {
+		u.Model, u.Provenance = stripModel(u.Model), nil
+		stripped.Updated[i] = u
+	}
+
+	data, err := json.Marshal(&stripped)
+	if err != nil {
+		return "", fmt.Errorf("serializing changeset: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func stripChanges(changes []ModelChange) []ModelChange {
+	out := make([]ModelChange, len(changes))
+	for i, c := range changes {
+		c.Model = stripModel(c.Model)
+		out[i] = c
+	}
+	return out
+}
+
+func stripModel(m *catalog.Model) *catalog.Model {
+	if m == nil || m.XUpdater == nil {
+		return m
+	}
+	c := *m
+	c.XUpdater = nil
+	return &c
+}
+
+// Reconcile checks a changeset computed earlier, such as one loaded from a
+// saved snapshot, against the provider's models as they are now. It fails
+// when the catalog has moved on since: a new model already exists, a model
+// to update or deprecate is gone, or an update's old values no longer match.
+// On success the field changes of updated models are recomputed, so their
+// values have the types a fresh diff would produce.
+func Reconcile(cs *ChangeSet, existing map[string]*catalog.Model, opts DiffOptions) error {
+	var drift []string
+	for _, m := range cs.New {
+		if _, ok := existing[m.Name]; ok {
+			drift = append(drift, m.Name+": already in the catalog")
+		}
+	}
+	for i := range cs.Updated {
+		u := &cs.Updated[i]
+		cur, ok := existing[u.Name]
+		if !ok {
+			drift = append(drift, u.Name+": no longer in the catalog")
+			continue
+		}
+		changes := computeFieldChanges(cur, u.Model, opts)
+		if canonicalJSON(changes) != canonicalJSON(u.Changes) {
+			drift = append(drift, u.Name+": changed in the catalog since the changeset was computed")
+			continue
+		}
+		u.Changes = changes
+	}
+	for _, m := range cs.DeprecationCandidates {
+		if _, ok := existing[m.Name]; !ok {
+			drift = append(drift, m.Name+": no longer in the catalog")
+		}
+	}
+	for _, r := range cs.Renamed {
+		if _, ok := existing[r.OldName]; !ok {
+			drift = append(drift, r.OldName+": no longer in the catalog")
+		}
+		if _, ok := existing[r.Name]; ok {
+			drift = append(drift, r.Name+": already in the catalog")
+		}
+	}
+	if len(drift) > 0 {
+		return fmt.Errorf("changeset for %s no longer applies:\n  %s", cs.Provider, strings.Join(drift, "\n  "))
+	}
+	return nil
+}
+
+// canonicalJSON serializes v with map keys sorted, so typed values and the
+// same values decoded from JSON into interfaces compare equal.
+func canonicalJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return string(data)
+	}
+	data, _ = json.Marshal(generic)
+	return string(data)
 }
@@ -1,7 +1,6 @@
 package diff
 
 import (
-	"math"
 	"sort"
 	"strings"
 
@@ -14,6 +13,11 @@ type DiffOptions struct {
 	// TrackDisplayName enables reporting display_name changes for existing models.
 	// Default false preserves the current behavior where catalog display_name is authoritative.
 	TrackDisplayName bool
+
+	// ManageReadiness marks new models as "discovered" and promotes existing
+	// discovered/verified models to "approved": anything already in the
+	// catalog was merged by a human.
+	ManageReadiness bool
 }
 
 // Compute compares discovered models against the existing catalog for a provider.
@@ -28,6 +32,9 @@ func Compute(provider string, discovered []adapter.DiscoveredModel, existing map
 
 		existingModel, exists := existing[d.Name]
 		if !exists {
+			if opts.ManageReadiness {
+				catalogModel.Readiness = catalog.ReadinessDiscovered
+			}
 			cs.New = append(cs.New, ModelChange{Name: d.Name, Model: catalogModel})
 			continue
 		}
@@ -36,9 +43,10 @@ func Compute(provider string, discovered []adapter.DiscoveredModel, existing map
 		changes := computeFieldChanges(existingModel, catalogModel, opts)
 		if len(changes) > 0 {
 			cs.Updated = append(cs.Updated, ModelUpdate{
-				Name:    d.Name,
-				Model:   catalogModel,
-				Changes: changes,
+				Name:       d.Name,
+				Model:      catalogModel,
+				Changes:    changes,
+				Provenance: fieldProvenance(existingModel, changes),
 			})
 		} else {
 			cs.Unchanged++
@@ -48,9 +56,19 @@ func Compute(provider string, discovered []adapter.DiscoveredModel, existing map
 	// Find deprecation candidates: in catalog but not discovered.
 	// Skip dated snapshots — they are filtered during discovery and
 	// should not be flagged as deprecation candidates.
+	// Skip, too, the old names of renamed models: their files stay behind,
+	// deprecated, for consumers still using them.
+	renamedFrom := make(map[string]bool)
+	for name, model := range existing {
+		if discoveredSet[name] {
+			for _, alias := range model.Aliases {
+				renamedFrom[alias] = true
+			}
+		}
+	}
 	var disappeared []ModelChange
 	for name, model := range existing {
-		if !discoveredSet[name] && !looksLikeDatedSnapshot(name) {
+		if !discoveredSet[name] && !looksLikeDatedSnapshot(name) && !renamedFrom[name] {
 			disappeared = append(disappeared, ModelChange{Name: name, Model: model})
 		}
 	}
@@ -69,16 +87,68 @@ func Compute(provider string, discovered []adapter.DiscoveredModel, existing map
 			cs.DeprecationCandidates = append(cs.DeprecationCandidates, mc)
 		}
 	}
+	cs.RemovedFamilies = removedFamilies(discovered, existing, cs.DeprecationCandidates)
 
 	return cs
 }
 
+// minRemovedFamily is the smallest family whose disappearance is reported
+// as a family removal. A single model going away is an ordinary
+// deprecation candidate.
+const minRemovedFamily = 2
+
+// removedFamilies finds the catalog families that no discovered model
+// belongs to and whose every model is a deprecation candidate, so renamed
+// models and dated snapshots keep a family alive. Models already
+// deprecated in the catalog are not counted. An empty discovery reports
+// nothing: that is for the health checks to catch.
+func removedFamilies(discovered []adapter.DiscoveredModel, existing map[string]*catalog.Model, candidates []ModelChange) []FamilyRemoval {
+	if len(discovered) == 0 {
+		return nil
+	}
+	listed := make(map[string]bool)
+	for _, d := range discovered {
+		listed[d.Family] = true
+	}
+	gone := make(map[string]bool, len(candidates))
+	for _, mc := range candidates {
+		gone[mc.Name] = true
+	}
+
+	members := make(map[string][]string)
+	for name, m := range existing {
+		if m.Family == "" || m.Status == "deprecated" {
+			continue
+		}
+		members[m.Family] = append(members[m.Family], name)
+	}
+
+	var removed []FamilyRemoval
+	for family, names := range members {
+		if listed[family] || len(names) < minRemovedFamily {
+			continue
+		}
+		allGone := true
+		for _, name := range names {
+			allGone = allGone && gone[name]
+		}
+		if !allGone {
+			continue
+		}
+		sort.Strings(names)
+		removed = append(removed, FamilyRemoval{Family: family, Models: names})
+	}
+	sort.Slice(removed, func(i, k int) bool { return removed[i].Family < removed[k].Family })
+	return removed
+}
+
 func toCatalogModel(d *adapter.DiscoveredModel) *catalog.Model {
 	m := &catalog.Model{
 		Name:         d.Name,
 		DisplayName:  d.DisplayName,
 		Family:       d.Family,
 		Status:       d.Status,
+		ModelType:    d.ModelType,
 		Capabilities: d.Capabilities,
 		Limits: catalog.Limits{
 			MaxTokens:           d.Limits.MaxTokens,
@@ -88,16 +158,103 @@ func toCatalogModel(d *adapter.DiscoveredModel) *catalog.Model {
 			Input:  d.Modalities.Input,
 			Output: d.Modalities.Output,
 		},
+		KnowledgeCutoff: d.KnowledgeCutoff,
+		ReleasedAt:      d.ReleasedAt,
+		XProvider:       d.XProvider,
+	}
+	if m.ModelType == "" && len(d.Capabilities) > 0 {
+		m.ModelType = catalog.InferModelType(d.Capabilities, m.Modalities)
 	}
 	if d.Cost != nil {
-		m.Cost = &catalog.Cost{
-			InputPer1K:  d.Cost.InputPer1K,
-			OutputPer1K: d.Cost.OutputPer1K,
+		// Adapters may report per-1M prices; the catalog stores per-1K.
+		m.Cost = (&catalog.Cost{
+			InputPer1K:       d.Cost.InputPer1K,
+			OutputPer1K:      d.Cost.OutputPer1K,
+			CachedInputPer1K: d.Cost.CachedInputPer1K,
+			CacheWritePer1K:  d.Cost.CacheWritePer1K,
+			CacheReadPer1K:   d.Cost.CacheReadPer1K,
+			BatchInputPer1K:  d.Cost.BatchInputPer1K,
+			BatchOutputPer1K: d.Cost.BatchOutputPer1K,
+			ReasoningPer1K:   d.Cost.ReasoningPer1K,
+			PerImage:         d.Cost.PerImage,
+			PerRequest:       d.Cost.PerRequest,
+			Currency:         d.Cost.Currency,
+			Unit:             d.Cost.Unit,
+		}).Normalize()
+		if d.Cost.OffPeak != nil {
+			op := catalog.OffPeak(*d.Cost.OffPeak)
+			m.Cost.OffPeak = &op
 		}
 	}
+	if d.Batch != nil {
+		m.Batch = &catalog.Batch{Supported: d.Batch.Supported, Discount: d.Batch.Discount}
+	}
+	if d.FineTuning != nil {
+		ft := catalog.FineTuning(*d.FineTuning)
+		m.FineTuning = &ft
+	}
+	if d.Embedding != nil {
+		e := catalog.Embedding(*d.Embedding)
+		m.Embedding = &e
+	}
+	for _, rl := range d.RateLimits {
+		m.RateLimits = append(m.RateLimits, catalog.RateLimit(rl))
+	}
+	m.XUpdater = provenance(d, m)
 	return m
 }
 
+// provenance records the source of each populated field of m, discovered
+// as d: the adapter's per-field source, or the one it found the model
+// with. Adapters that don't say are API adapters. The pipeline timestamps
+// the fields it writes.
+func provenance(d *adapter.DiscoveredModel, m *catalog.Model) *catalog.XUpdater {
+	fields := make(map[string]catalog.FieldSource)
+	for _, f := range m.PopulatedFields() {
+		source, ok := d.FieldSources[f]
+		if !ok {
+			source = d.DiscoveredBy
+		}
+		if source == "" {
+			source = adapter.SourceAPI
+		}
+		fields[f] = catalog.FieldSource{Source: string(source)}
+	}
+	return &catalog.XUpdater{Fields: fields}
+}
+
+// fieldProvenance picks the catalog's provenance for the fields changes
+// touch, so a reviewer can tell what a change replaces.
+func fieldProvenance(existing *catalog.Model, changes []catalog.FieldChange) map[string]catalog.FieldSource {
+	recorded := existing.FieldSources()
+	var out map[string]catalog.FieldSource
+	for _, c := range changes {
+		field := TopField(c.Field)
+		fs, ok := recorded[field]
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]catalog.FieldSource)
+		}
+		out[field] = fs
+	}
+	return out
+}
+
+// TopField is the top-level model field a change is to, as in "cost" for
+// "cost.input_per_1k".
+func TopField(field string) string {
+	top, _, _ := strings.Cut(field, ".")
+	return top
+}
+
+// ModelChanges returns the field changes from existing to updated, as
+// Compute would report them for an update.
+func ModelChanges(existing, updated *catalog.Model, opts DiffOptions) []catalog.FieldChange {
+	return computeFieldChanges(existing, updated, opts)
+}
+
 func computeFieldChanges(existing, discovered *catalog.Model, opts DiffOptions) []catalog.FieldChange {
 	var changes []catalog.FieldChange
 
@@ -114,20 +271,27 @@ func computeFieldChanges(existing, discovered *catalog.Model, opts DiffOptions)
 	if discovered.Status != "" && existing.Status != discovered.Status {
 		changes = append(changes, catalog.FieldChange{Field: "status", OldValue: existing.Status, NewValue: discovered.Status})
 	}
+	if discovered.ModelType != "" && existing.Type() != discovered.ModelType {
+		changes = append(changes, catalog.FieldChange{Field: "model_type", OldValue: existing.ModelType, NewValue: discovered.ModelType})
+	}
+
+	// Readiness: promote merged models that are still below approved.
+	if opts.ManageReadiness {
+		switch existing.Readiness {
+		case catalog.ReadinessDiscovered, catalog.ReadinessVerified:
+			discovered.Readiness = catalog.ReadinessApproved
+			changes = append(changes, catalog.FieldChange{Field: "readiness", OldValue: existing.Readiness, NewValue: discovered.Readiness})
+		}
+	}
 
 	// Cost: skip zero-cost discovered data (likely missing pricing, not actually free).
+	// Both sides are normalized to per-1K so catalogs storing per-1M prices compare correctly.
 	if discovered.Cost != nil && !zeroCost(discovered.Cost) {
-		if existing.Cost == nil {
-			changes = append(changes, catalog.FieldChange{Field: "cost", OldValue: nil, NewValue: discovered.Cost})
-		} else {
-			if existing.Cost.InputPer1K != discovered.Cost.InputPer1K {
-				changes = append(changes, catalog.FieldChange{Field: "cost.input_per_1k", OldValue: existing.Cost.InputPer1K, NewValue: discovered.Cost.InputPer1K})
-			}
-			if existing.Cost.OutputPer1K != discovered.Cost.OutputPer1K {
-				changes = append(changes, catalog.FieldChange{Field: "cost.output_per_1k", OldValue: existing.Cost.OutputPer1K, NewValue: discovered.Cost.OutputPer1K})
-			}
-		}
+		changes = append(changes, catalog.CostChanges(existing.Cost, discovered.Cost)...)
 	}
+	changes = append(changes, catalog.BatchChanges(existing.Batch, discovered.Batch)...)
+	changes = append(changes, catalog.FineTuningChanges(existing.FineTuning, discovered.FineTuning)...)
+	changes = append(changes, catalog.EmbeddingChanges(existing.Embedding, discovered.Embedding)...)
 
 	if discovered.Limits.MaxTokens != 0 && existing.Limits.MaxTokens != discovered.Limits.MaxTokens {
 		changes = append(changes, catalog.FieldChange{Field: "limits.max_tokens", OldValue: existing.Limits.MaxTokens, NewValue: discovered.Limits.MaxTokens})
@@ -135,6 +299,7 @@ func computeFieldChanges(existing, discovered *catalog.Model, opts DiffOptions)
 	if discovered.Limits.MaxCompletionTokens != 0 && existing.Limits.MaxCompletionTokens != discovered.Limits.MaxCompletionTokens {
 		changes = append(changes, catalog.FieldChange{Field: "limits.max_completion_tokens", OldValue: existing.Limits.MaxCompletionTokens, NewValue: discovered.Limits.MaxCompletionTokens})
 	}
+	changes = append(changes, catalog.RateLimitChanges(existing.RateLimits, discovered.RateLimits)...)
 
 	// Capabilities: symmetric set diff (detect both additions and removals).
 	if capabilitiesChanged(existing.Capabilities, discovered.Capabilities) {
@@ -149,13 +314,23 @@ func computeFieldChanges(existing, discovered *catalog.Model, opts DiffOptions)
 		changes = append(changes, catalog.FieldChange{Field: "modalities.output", OldValue: existing.Modalities.Output, NewValue: discovered.Modalities.Output})
 	}
 
-	return changes
+	if discovered.KnowledgeCutoff != "" && existing.KnowledgeCutoff != discovered.KnowledgeCutoff {
+		changes = append(changes, catalog.FieldChange{Field: "knowledge_cutoff", OldValue: existing.KnowledgeCutoff, NewValue: discovered.KnowledgeCutoff})
+	}
+	if discovered.ReleasedAt != "" && existing.ReleasedAt != discovered.ReleasedAt {
+		changes = append(changes, catalog.FieldChange{Field: "released_at", OldValue: existing.ReleasedAt, NewValue: discovered.ReleasedAt})
+	}
+
+	// Provider extension block: compared as a whole, never field by field.
+	changes = append(changes, catalog.XProviderChanges(existing.XProvider, discovered.XProvider)...)
+
+	return existing.WithoutLocked(changes)
 }
 
-// zeroCost returns true if both input and output costs are zero,
+// zeroCost returns true if all prices are zero,
 // indicating missing data rather than a genuinely free model.
 func zeroCost(c *catalog.Cost) bool {
-	return c.InputPer1K == 0 && c.OutputPer1K == 0
+	return c.IsZero()
 }
 
 // capabilitiesChanged returns true if the two capability slices differ
@@ -206,46 +381,6 @@ func equalStringSlices(a, b []string) bool {
 	return true
 }
 
-// detectRenames finds potential renames by matching disappeared + new models
-// with same family and similar limits/cost.
-func detectRenames(newModels []ModelChange, disappeared []ModelChange) []RenamePair {
-	var renames []RenamePair
-
-	for _, newM := range newModels {
-		for _, oldM := range disappeared {
-			if newM.Model.Family != oldM.Model.Family || newM.Model.Family == "" {
-				continue
-			}
-
-			// Check limits similarity (within 10%)
-			if oldM.Model.Limits.MaxTokens > 0 && newM.Model.Limits.MaxTokens > 0 {
-				ratio := float64(newM.Model.Limits.MaxTokens) / float64(oldM.Model.Limits.MaxTokens)
-				if math.Abs(ratio-1.0) > 0.1 {
-					continue
-				}
-			}
-
-			// Check cost similarity (within 20%)
-			if oldM.Model.Cost != nil && newM.Model.Cost != nil {
-				if oldM.Model.Cost.InputPer1K > 0 {
-					ratio := newM.Model.Cost.InputPer1K / oldM.Model.Cost.InputPer1K
-					if math.Abs(ratio-1.0) > 0.2 {
-						continue
-					}
-				}
-			}
-
-			renames = append(renames, RenamePair{
-				OldName: oldM.Name,
-				NewName: newM.Name,
-				Reason:  "same family, similar limits/cost",
-			})
-		}
-	}
-
-	return renames
-}
-
 // looksLikeDatedSnapshot checks if a model name contains a date-like segment.
 // Used to avoid flagging dated snapshots already in the catalog as deprecation candidates.
 func looksLikeDatedSnapshot(name string) bool {
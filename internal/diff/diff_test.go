@@ -1,7 +1,10 @@
 package diff
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
 	"github.com/everstacklabs/sentinel/internal/catalog"
@@ -81,6 +84,75 @@ func TestUpdatedModelDetected(t *testing.T) {
 	}
 }
 
+func TestProvenance(t *testing.T) {
+	discovered := []adapter.DiscoveredModel{
+		{
+			Name: "gpt-4o", Family: "gpt-4", Status: "stable",
+			Cost:         &adapter.Cost{InputPer1K: 0.0025, OutputPer1K: 0.01},
+			Limits:       adapter.Limits{MaxTokens: 128000},
+			DiscoveredBy: adapter.SourceAPI,
+			FieldSources: map[string]adapter.SourceType{"cost": adapter.SourceDocs},
+		},
+		{Name: "gpt-5", Family: "gpt-5", Status: "stable"},
+	}
+	existing := map[string]*catalog.Model{
+		"gpt-4o": {
+			Name: "gpt-4o", Family: "gpt-4", Status: "stable",
+			Cost:   &catalog.Cost{InputPer1K: 0.005, OutputPer1K: 0.01},
+			Limits: catalog.Limits{MaxTokens: 128000},
+			XUpdater: &catalog.XUpdater{Fields: map[string]catalog.FieldSource{
+				"cost":   {Source: catalog.SourceManual},
+				"limits": {Source: "api"},
+			}},
+		},
+	}
+
+	cs := Compute("openai", discovered, existing, DiffOptions{})
+	if len(cs.Updated) != 1 || len(cs.New) != 1 {
+		t.Fatalf("updated %d, new %d; want 1 each", len(cs.Updated), len(cs.New))
+	}
+	u := cs.Updated[0]
+	if got := u.Model.FieldSources(); got["cost"].Source != "docs" || got["limits"].Source != "api" {
+		t.Errorf("update sources = %v, want cost from the docs and limits from the API", got)
+	}
+	if len(u.Provenance) != 1 || u.Provenance["cost"].Source != catalog.SourceManual {
+		t.Errorf("provenance = %v, want the manual cost being replaced", u.Provenance)
+	}
+	// Adapters that don't name a source are API adapters.
+	if got := cs.New[0].Model.FieldSources(); got["family"].Source != "api" || len(got) != 2 {
+		t.Errorf("new model sources = %v, want family and status from the API", got)
+	}
+}
+
+func TestLockedFieldsNotReported(t *testing.T) {
+	discovered := []adapter.DiscoveredModel{{
+		Name: "gpt-4o", Family: "gpt-4o", Status: "stable",
+		Cost:         &adapter.Cost{InputPer1K: 0.0025, OutputPer1K: 0.01},
+		Limits:       adapter.Limits{MaxTokens: 256000},
+		Capabilities: []string{"chat", "vision"},
+	}}
+	existing := map[string]*catalog.Model{"gpt-4o": {
+		Name: "gpt-4o", Family: "gpt-4o", Status: "stable",
+		Cost:         &catalog.Cost{InputPer1K: 0.005, OutputPer1K: 0.01},
+		Limits:       catalog.Limits{MaxTokens: 128000},
+		Capabilities: []string{"chat"},
+		Locked:       []string{"cost", "limits.max_tokens"},
+	}}
+
+	cs := Compute("openai", discovered, existing, DiffOptions{})
+	if len(cs.Updated) != 1 {
+		t.Fatalf("expected 1 updated, got %d", len(cs.Updated))
+	}
+	if changes := cs.Updated[0].Changes; len(changes) != 1 || changes[0].Field != "capabilities" {
+		t.Errorf("changes = %+v, want only capabilities", changes)
+	}
+
+	existing["gpt-4o"].Locked = append(existing["gpt-4o"].Locked, "capabilities")
+	if cs := Compute("openai", discovered, existing, DiffOptions{}); len(cs.Updated) != 0 || cs.Unchanged != 1 {
+		t.Errorf("fully locked model reported as updated: %+v", cs.Updated)
+	}
+}
+
 func TestDisplayNameChangeIgnored(t *testing.T) {
 	// display_name differences should NOT be reported as changes for existing models
 	discovered := []adapter.DiscoveredModel{
@@ -217,6 +289,47 @@ func TestDeprecationCandidate(t *testing.T) {
 	}
 }
 
+func TestRemovedFamilies(t *testing.T) {
+	model := func(name, family, status string) *catalog.Model {
+		return &catalog.Model{Name: name, Family: family, Status: status}
+	}
+	existing := map[string]*catalog.Model{
+		"claude-3-opus":   model("claude-3-opus", "claude-3", "stable"),
+		"claude-3-sonnet": model("claude-3-sonnet", "claude-3", "stable"),
+		"claude-3-haiku":  model("claude-3-haiku", "claude-3", "deprecated"),
+		"claude-2.1":      model("claude-2.1", "claude-2", "stable"),
+		"claude-2.0":      model("claude-2.0", "claude-2", "deprecated"),
+		"claude-instant":  model("claude-instant", "claude-instant", "stable"),
+		"claude-4-opus":   model("claude-4-opus", "claude-4", "stable"),
+		"claude-4-sonnet": model("claude-4-sonnet", "claude-4", "stable"),
+	}
+	discovered := []adapter.DiscoveredModel{
+		{Name: "claude-4-opus", Family: "claude-4"},
+		{Name: "claude-4-sonnet", Family: "claude-4"},
+	}
+
+	// claude-3 is gone; claude-2 and claude-instant have one live model
+	// each, which is an ordinary deprecation.
+	cs := Compute("anthropic", discovered, existing, DiffOptions{})
+	if len(cs.RemovedFamilies) != 1 {
+		t.Fatalf("removed families = %+v, want claude-3 only", cs.RemovedFamilies)
+	}
+	if f := cs.RemovedFamilies[0]; f.Family != "claude-3" || strings.Join(f.Models, ",") != "claude-3-opus,claude-3-sonnet" {
+		t.Errorf("removed family = %+v", f)
+	}
+
+	// A newly listed model of the family keeps it alive.
+	discovered = append(discovered, adapter.DiscoveredModel{Name: "claude-3-7-sonnet", Family: "claude-3"})
+	if cs := Compute("anthropic", discovered, existing, DiffOptions{}); len(cs.RemovedFamilies) != 0 {
+		t.Errorf("removed families = %+v, want none", cs.RemovedFamilies)
+	}
+
+	// An empty discovery is a health problem, not a removal.
+	if cs := Compute("anthropic", nil, existing, DiffOptions{}); len(cs.RemovedFamilies) != 0 {
+		t.Errorf("removed families on empty discovery = %+v", cs.RemovedFamilies)
+	}
+}
+
 func TestRenameDetection(t *testing.T) {
 	// Same family, same limits → should detect rename
 	discovered := []adapter.DiscoveredModel{
@@ -247,6 +360,9 @@ func TestRenameDetection(t *testing.T) {
 	if len(cs.PossibleRenames) != 1 {
 		t.Fatalf("expected 1 rename, got %d", len(cs.PossibleRenames))
 	}
+	if cs.PossibleRenames[0].Score < 0.9 {
+		t.Errorf("rename score = %.2f, want at least 0.9", cs.PossibleRenames[0].Score)
+	}
 	if cs.PossibleRenames[0].OldName != "gpt-4o-v1" || cs.PossibleRenames[0].NewName != "gpt-4o-v2" {
 		t.Errorf("expected rename gpt-4o-v1 → gpt-4o-v2, got %s → %s",
 			cs.PossibleRenames[0].OldName, cs.PossibleRenames[0].NewName)
@@ -257,6 +373,116 @@ func TestRenameDetection(t *testing.T) {
 	}
 }
 
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		min, max float64
+	}{
+		{"gpt-4o", "gpt-4o", 1, 1},
+		{"GPT-4o", "gpt-4o", 1, 1},
+		{"gpt-4o-v1", "gpt-4o-v2", 0.85, 0.95},
+		{"claude-3-sonnet", "claude-sonnet-3", 1, 1}, // same tokens, reordered
+		{"gpt-4", "gpt-4o-mini", 0.4, 0.5},
+		{"llama-3.1-8b", "mixtral-8x7b", 0, 0.4},
+	}
+	for _, tt := range tests {
+		if got := nameSimilarity(tt.a, tt.b); got < tt.min || got > tt.max {
+			t.Errorf("nameSimilarity(%q, %q) = %.2f, want %.2f–%.2f", tt.a, tt.b, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestRenameScoring(t *testing.T) {
+	model := func(name string, maxTokens int, input float64) ModelChange {
+		m := &catalog.Model{Name: name, Family: "gpt-4", Limits: catalog.Limits{MaxTokens: maxTokens}}
+		if input > 0 {
+			m.Cost = &catalog.Cost{InputPer1K: input}
+		}
+		return ModelChange{Name: name, Model: m}
+	}
+
+	// A changed context length lowers the score but doesn't rule the pair out.
+	renames := detectRenames(
+		[]ModelChange{model("gpt-4o-v2", 256000, 0.0025)},
+		[]ModelChange{model("gpt-4o-v1", 128000, 0.0025)})
+	if len(renames) != 1 || renames[0].Score < minRenameScore || renames[0].Score >= 0.95 {
+		t.Fatalf("renames = %+v, want one pair scored below a perfect match", renames)
+	}
+	if !strings.Contains(renames[0].Reason, "limits 50%") {
+		t.Errorf("reason %q should show the limits similarity", renames[0].Reason)
+	}
+
+	// Each model pairs at most once, with its best match.
+	renames = detectRenames(
+		[]ModelChange{model("gpt-4o-mini-v2", 128000, 0.00015), model("gpt-4o-v2", 128000, 0.0025)},
+		[]ModelChange{model("gpt-4o-v1", 128000, 0.0025)})
+	if len(renames) != 1 || renames[0].NewName != "gpt-4o-v2" {
+		t.Errorf("renames = %+v, want only gpt-4o-v1 → gpt-4o-v2", renames)
+	}
+
+	// Unrelated names in a family aren't paired on matching limits alone.
+	renames = detectRenames(
+		[]ModelChange{model("gpt-4-turbo-preview", 0, 0)},
+		[]ModelChange{model("gpt-4o", 0, 0)})
+	if len(renames) != 0 {
+		t.Errorf("renames = %+v, want none", renames)
+	}
+}
+
+func TestApplyRenames(t *testing.T) {
+	newModel := func(name string) ModelChange {
+		return ModelChange{Name: name, Model: &catalog.Model{Name: name}}
+	}
+	cs := &ChangeSet{
+		New: []ModelChange{newModel("a-v2"), newModel("b-v2"), newModel("b-v3"), newModel("c")},
+		PossibleRenames: []RenamePair{
+			{OldName: "a-v1", NewName: "a-v2"},
+			{OldName: "b-v1", NewName: "b-v2"},
+			{OldName: "b-v1", NewName: "b-v3"}, // ambiguous with the one above
+		},
+	}
+
+	var asked []string
+	cs.ApplyRenames(func(r RenamePair) bool {
+		asked = append(asked, r.OldName)
+		return true
+	})
+
+	if len(asked) != 1 || asked[0] != "a-v1" {
+		t.Errorf("asked about %v, want only the unambiguous a-v1", asked)
+	}
+	if len(cs.Renamed) != 1 || cs.Renamed[0].OldName != "a-v1" || cs.Renamed[0].Name != "a-v2" || cs.Renamed[0].Model == nil {
+		t.Errorf("Renamed = %+v, want a-v1 → a-v2", cs.Renamed)
+	}
+	if len(cs.New) != 3 || len(cs.PossibleRenames) != 2 {
+		t.Errorf("New = %d, PossibleRenames = %d, want 3 and 2", len(cs.New), len(cs.PossibleRenames))
+	}
+	if cs.TotalChanged() != 4 {
+		t.Errorf("TotalChanged = %d, want 4", cs.TotalChanged())
+	}
+
+	declined := &ChangeSet{New: []ModelChange{newModel("a-v2")}, PossibleRenames: []RenamePair{{OldName: "a-v1", NewName: "a-v2"}}}
+	declined.ApplyRenames(func(RenamePair) bool { return false })
+	if len(declined.Renamed) != 0 || len(declined.New) != 1 || len(declined.PossibleRenames) != 1 {
+		t.Errorf("declined rename changed the changeset: %+v", declined)
+	}
+}
+
+func TestRenamedFromNotDeprecated(t *testing.T) {
+	discovered := []adapter.DiscoveredModel{
+		{Name: "gpt-4o-v2", Family: "gpt-4", Status: "stable", Capabilities: []string{"chat"}},
+	}
+	existing := map[string]*catalog.Model{
+		"gpt-4o-v1": {Name: "gpt-4o-v1", Family: "gpt-4", Status: "deprecated", Capabilities: []string{"chat"}},
+		"gpt-4o-v2": {Name: "gpt-4o-v2", Aliases: []string{"gpt-4o-v1"}, Family: "gpt-4", Status: "stable", Capabilities: []string{"chat"}},
+	}
+
+	cs := Compute("openai", discovered, existing, DiffOptions{})
+	if len(cs.DeprecationCandidates) != 0 || len(cs.PossibleRenames) != 0 {
+		t.Errorf("old name of a renamed model reported: candidates %v, renames %v", cs.DeprecationCandidates, cs.PossibleRenames)
+	}
+}
+
 func TestRenameMiss_DifferentFamily(t *testing.T) {
 	// Different family → no rename, separate new + deprecation
 	discovered := []adapter.DiscoveredModel{
@@ -502,3 +728,420 @@ func TestModalityChangeDetected(t *testing.T) {
 		t.Error("expected modalities.input change")
 	}
 }
+
+func TestDateChanges(t *testing.T) {
+	existing := map[string]*catalog.Model{
+		"gpt-4o": {
+			Name:            "gpt-4o",
+			DisplayName:     "GPT-4O",
+			Family:          "gpt-4",
+			Status:          "stable",
+			Capabilities:    []string{"chat"},
+			Limits:          catalog.Limits{MaxTokens: 128000},
+			Modalities:      catalog.Modalities{Input: []string{"text"}, Output: []string{"text"}},
+			KnowledgeCutoff: "2023-10",
+		},
+	}
+	discovered := func(cutoff, released string) []adapter.DiscoveredModel {
+		return []adapter.DiscoveredModel{{
+			Name:            "gpt-4o",
+			DisplayName:     "GPT-4O",
+			Family:          "gpt-4",
+			Status:          "stable",
+			Capabilities:    []string{"chat"},
+			Limits:          adapter.Limits{MaxTokens: 128000},
+			Modalities:      adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
+			KnowledgeCutoff: cutoff,
+			ReleasedAt:      released,
+		}}
+	}
+
+	// A source without dates leaves the catalog's alone.
+	if cs := Compute("openai", discovered("", ""), existing, DiffOptions{}); cs.Unchanged != 1 {
+		t.Errorf("missing dates should not count as changes: %+v", cs.Updated)
+	}
+
+	cs := Compute("openai", discovered("2024-06", "2024-05-13"), existing, DiffOptions{})
+	if len(cs.Updated) != 1 {
+		t.Fatalf("expected 1 updated, got %d", len(cs.Updated))
+	}
+	fields := map[string]catalog.FieldChange{}
+	for _, c := range cs.Updated[0].Changes {
+		fields[c.Field] = c
+	}
+	if c := fields["knowledge_cutoff"]; c.OldValue != "2023-10" || c.NewValue != "2024-06" {
+		t.Errorf("knowledge_cutoff change = %+v", c)
+	}
+	if c := fields["released_at"]; c.OldValue != "" || c.NewValue != "2024-05-13" {
+		t.Errorf("released_at change = %+v", c)
+	}
+}
+
+func TestPerMillionCostNormalized(t *testing.T) {
+	discovered := []adapter.DiscoveredModel{
+		{
+			Name:         "gpt-4o",
+			DisplayName:  "GPT-4O",
+			Family:       "gpt-4",
+			Status:       "stable",
+			Capabilities: []string{"chat"},
+			Limits:       adapter.Limits{MaxTokens: 128000},
+			Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
+			Cost:         &adapter.Cost{InputPer1K: 2.5, OutputPer1K: 10, Unit: "per_1m"},
+		},
+	}
+	existing := map[string]*catalog.Model{
+		"gpt-4o": {
+			Name:         "gpt-4o",
+			DisplayName:  "GPT-4O",
+			Family:       "gpt-4",
+			Status:       "stable",
+			Capabilities: []string{"chat"},
+			Limits:       catalog.Limits{MaxTokens: 128000},
+			Modalities:   catalog.Modalities{Input: []string{"text"}, Output: []string{"text"}},
+			Cost:         &catalog.Cost{InputPer1K: 0.0025, OutputPer1K: 0.01},
+		},
+	}
+
+	cs := Compute("openai", discovered, existing, DiffOptions{})
+
+	if len(cs.Updated) != 0 {
+		t.Errorf("expected no updates for equivalent per-1M pricing, got %v", cs.Updated[0].Changes)
+	}
+	if cs.Unchanged != 1 {
+		t.Errorf("expected 1 unchanged, got %d", cs.Unchanged)
+	}
+}
+
+func TestManageReadiness(t *testing.T) {
+	model := func(name string) adapter.DiscoveredModel {
+		return adapter.DiscoveredModel{
+			Name:         name,
+			DisplayName:  name,
+			Family:       "gpt-4",
+			Status:       "stable",
+			Capabilities: []string{"chat"},
+			Limits:       adapter.Limits{MaxTokens: 128000},
+			Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
+		}
+	}
+	existingModel := func(name, readiness string) *catalog.Model {
+		return &catalog.Model{
+			Name:         name,
+			DisplayName:  name,
+			Family:       "gpt-4",
+			Status:       "stable",
+			Readiness:    readiness,
+			Capabilities: []string{"chat"},
+			Limits:       catalog.Limits{MaxTokens: 128000},
+			Modalities:   catalog.Modalities{Input: []string{"text"}, Output: []string{"text"}},
+		}
+	}
+
+	discovered := []adapter.DiscoveredModel{model("new-model"), model("merged"), model("legacy"), model("ga-model")}
+	existing := map[string]*catalog.Model{
+		"merged":   existingModel("merged", catalog.ReadinessVerified),
+		"legacy":   existingModel("legacy", ""),
+		"ga-model": existingModel("ga-model", catalog.ReadinessGA),
+	}
+
+	cs := Compute("openai", discovered, existing, DiffOptions{ManageReadiness: true})
+
+	if len(cs.New) != 1 || cs.New[0].Model.Readiness != catalog.ReadinessDiscovered {
+		t.Fatalf("expected new model with readiness discovered, got %+v", cs.New)
+	}
+	if len(cs.Updated) != 1 || cs.Updated[0].Name != "merged" {
+		t.Fatalf("expected only 'merged' to be promoted, got %+v", cs.Updated)
+	}
+	if got := cs.Updated[0].Model.Readiness; got != catalog.ReadinessApproved {
+		t.Errorf("promoted readiness = %q, want approved", got)
+	}
+	if cs.Unchanged != 2 {
+		t.Errorf("expected 2 unchanged, got %d", cs.Unchanged)
+	}
+
+	cs = Compute("openai", discovered, existing, DiffOptions{})
+	if cs.New[0].Model.Readiness != "" || len(cs.Updated) != 0 {
+		t.Error("readiness should not be touched when ManageReadiness is off")
+	}
+}
+
+func TestChangeSetHash(t *testing.T) {
+	build := func() *ChangeSet {
+		return &ChangeSet{
+			Provider: "openai",
+			New: []ModelChange{{Name: "gpt-5", Model: &catalog.Model{
+				Name: "gpt-5", Limits: catalog.Limits{MaxTokens: 128000},
+			}}},
+			Updated: []ModelUpdate{{Name: "gpt-4o", Model: &catalog.Model{Name: "gpt-4o"}, Changes: []catalog.FieldChange{
+				{Field: "cost.input_per_1k", OldValue: 0.005, NewValue: 0.0025},
+			}}},
+			Unchanged: 10,
+		}
+	}
+	hash := func(cs *ChangeSet) string {
+		t.Helper()
+		h, err := cs.Hash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	base := hash(build())
+
+	same := build()
+	same.Unchanged = 99
+	same.New[0].Model.XUpdater = &catalog.XUpdater{LastVerifiedAt: "2026-01-01T00:00:00Z"}
+	if hash(same) != base {
+		t.Error("hash should ignore the unchanged count and updater metadata")
+	}
+	if same.New[0].Model.XUpdater == nil {
+		t.Error("Hash must not modify the changeset")
+	}
+
+	changed := build()
+	changed.Updated[0].Changes[0].NewValue = 0.003
+	if hash(changed) == base {
+		t.Error("hash should change when a field change differs")
+	}
+}
+
+func TestXProviderBlockChanges(t *testing.T) {
+	base := func(x map[string]any) adapter.DiscoveredModel {
+		return adapter.DiscoveredModel{
+			Name:         "llama-3.3-70b-versatile",
+			DisplayName:  "Llama 3.3 70B",
+			Family:       "llama-3.3",
+			Status:       "stable",
+			Capabilities: []string{"chat"},
+			Limits:       adapter.Limits{MaxTokens: 131072},
+			Modalities:   adapter.Modalities{Input: []string{"text"}, Output: []string{"text"}},
+			XProvider:    x,
+		}
+	}
+	existing := map[string]*catalog.Model{
+		"llama-3.3-70b-versatile": {
+			Name:         "llama-3.3-70b-versatile",
+			DisplayName:  "Llama 3.3 70B",
+			Family:       "llama-3.3",
+			Status:       "stable",
+			Capabilities: []string{"chat"},
+			Limits:       catalog.Limits{MaxTokens: 131072},
+			Modalities:   catalog.Modalities{Input: []string{"text"}, Output: []string{"text"}},
+			// As decoded from YAML: ints, nested maps.
+			XProvider: catalog.XProvider{"speed_class": "fast", "tokens_per_second": 275},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		x          map[string]any
+		wantChange bool
+	}{
+		{"nil keeps catalog block", nil, false},
+		{"equal with different int type", map[string]any{"tokens_per_second": int64(275), "speed_class": "fast"}, false},
+		{"value changed", map[string]any{"speed_class": "fast", "tokens_per_second": 300}, true},
+		{"key removed", map[string]any{"speed_class": "fast"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := Compute("groq", []adapter.DiscoveredModel{base(tt.x)}, existing, DiffOptions{})
+			if !tt.wantChange {
+				if len(cs.Updated) != 0 {
+					t.Errorf("expected no changes, got %+v", cs.Updated[0].Changes)
+				}
+				return
+			}
+			if len(cs.Updated) != 1 || len(cs.Updated[0].Changes) != 1 {
+				t.Fatalf("expected one block-level change, got %+v", cs.Updated)
+			}
+			if c := cs.Updated[0].Changes[0]; c.Field != "x_provider" {
+				t.Errorf("field = %q, want x_provider", c.Field)
+			}
+		})
+	}
+}
+
+func TestReconcileSavedChangeSet(t *testing.T) {
+	existing := map[string]*catalog.Model{
+		"gpt-4o": {
+			Name:   "gpt-4o",
+			Status: "stable",
+			Cost:   &catalog.Cost{InputPer1K: 0.005, OutputPer1K: 0.015},
+			Limits: catalog.Limits{MaxTokens: 128000},
+		},
+	}
+	discovered := []adapter.DiscoveredModel{
+		{Name: "gpt-4o", Status: "stable", Cost: &adapter.Cost{InputPer1K: 0.0025, OutputPer1K: 0.01}, Limits: adapter.Limits{MaxTokens: 200000}},
+		{Name: "gpt-5", Status: "stable", Limits: adapter.Limits{MaxTokens: 400000}},
+	}
+	cs := Compute("openai", discovered, existing, DiffOptions{})
+
+	// Round trip through JSON as diff --save / apply do.
+	data, err := json.Marshal(cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved ChangeSet
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reconcile(&saved, existing, DiffOptions{}); err != nil {
+		t.Fatalf("Reconcile on unchanged catalog: %v", err)
+	}
+	found := false
+	for _, c := range saved.Updated[0].Changes {
+		if c.Field == "limits.max_tokens" {
+			found = true
+			if _, ok := c.NewValue.(int); !ok {
+				t.Errorf("max_tokens change value is %T, want int", c.NewValue)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected limits.max_tokens change")
+	}
+
+	// Someone edits the catalog between review and apply.
+	existing["gpt-4o"].Limits.MaxTokens = 150000
+	existing["gpt-5"] = &catalog.Model{Name: "gpt-5"}
+	var stale ChangeSet
+	if err := json.Unmarshal(data, &stale); err != nil {
+		t.Fatal(err)
+	}
+	err = Reconcile(&stale, existing, DiffOptions{})
+	if err == nil {
+		t.Fatal("expected drift error")
+	}
+	for _, want := range []string{"gpt-4o: changed", "gpt-5: already in the catalog"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestCompareCatalogs(t *testing.T) {
+	from := &catalog.Catalog{Version: "1.0.0", Providers: map[string]*catalog.ProviderCatalog{
+		"openai": {Models: map[string]*catalog.Model{
+			"gpt-4o":        {Name: "gpt-4o", Status: "stable", Limits: catalog.Limits{MaxTokens: 128000}},
+			"gpt-3.5-turbo": {Name: "gpt-3.5-turbo", Status: "stable"},
+		}},
+		"groq": {Models: map[string]*catalog.Model{"llama-3": {Name: "llama-3"}}},
+	}}
+	to := &catalog.Catalog{Version: "1.1.0", Providers: map[string]*catalog.ProviderCatalog{
+		"openai": {Models: map[string]*catalog.Model{
+			"gpt-4o": {Name: "gpt-4o", Status: "deprecated", Limits: catalog.Limits{MaxTokens: 128000}},
+			"gpt-5":  {Name: "gpt-5", Status: "stable"},
+		}},
+		"groq": {Models: map[string]*catalog.Model{"llama-3": {Name: "llama-3"}}},
+	}}
+
+	sets := CompareCatalogs(from, to)
+	if len(sets) != 1 || sets[0].Provider != "openai" {
+		t.Fatalf("expected one changeset for openai, got %+v", sets)
+	}
+	cs := sets[0]
+	if len(cs.New) != 1 || len(cs.Updated) != 1 || len(cs.DeprecationCandidates) != 1 {
+		t.Fatalf("got %d new, %d updated, %d removed", len(cs.New), len(cs.Updated), len(cs.DeprecationCandidates))
+	}
+
+	notes := RenderReleaseNotes(from.Version, to.Version, sets)
+	for _, want := range []string{
+		"## Catalog changes 1.0.0 → 1.1.0",
+		"- Added `gpt-5`",
+		"- Changed `gpt-4o` (status: stable → deprecated)",
+		"- Removed `gpt-3.5-turbo`",
+	} {
+		if !strings.Contains(notes, want) {
+			t.Errorf("release notes missing %q:\n%s", want, notes)
+		}
+	}
+}
+
+func TestRenderChangelogEntry(t *testing.T) {
+	changesets := []ChangeSet{
+		{
+			Provider: "openai",
+			New:      []ModelChange{{Name: "gpt-5"}},
+			Updated: []ModelUpdate{
+				{Name: "gpt-4o", Changes: []catalog.FieldChange{{Field: "limits.max_tokens", OldValue: 128000, NewValue: 256000}}},
+				{Name: "gpt-4", Changes: []catalog.FieldChange{{Field: "status", OldValue: "stable", NewValue: "deprecated"}}},
+			},
+			DeprecationCandidates: []ModelChange{{Name: "gpt-3.5-turbo"}},
+		},
+		{Provider: "groq", DeprecationCandidates: []ModelChange{{Name: "llama2-70b"}}},
+	}
+	got := RenderChangelogEntry("1.5.0", time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC), changesets)
+	want := "## 1.5.0 (2026-03-01)\n\n" +
+		"### openai\n\n" +
+		"#### Added\n\n- `gpt-5`\n\n" +
+		"#### Changed\n\n- `gpt-4o` (limits.max_tokens: 128000 → 256000)\n\n" +
+		"#### Deprecated\n\n- `gpt-4`\n\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderChangelogEntrySkipsEmptySections(t *testing.T) {
+	date := time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC)
+	renamed := []ChangeSet{{
+		Provider: "openai",
+		Renamed:  []ModelRename{{OldName: "gpt-4o-preview", Name: "gpt-4o"}},
+		Updated:  []ModelUpdate{{Name: "gpt-4o-mini"}},
+	}}
+	want := "## 1.5.0 (2026-03-01)\n\n### openai\n\n#### Renamed\n\n- `gpt-4o-preview` → `gpt-4o`\n\n"
+	if got := RenderChangelogEntry("1.5.0", date, renamed); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	nothing := []ChangeSet{{Provider: "groq", DeprecationCandidates: []ModelChange{{Name: "llama2-70b"}}}}
+	if got := RenderChangelogEntry("1.5.1", date, nothing); got != "" {
+		t.Errorf("got %q for a release with nothing to list, want \"\"", got)
+	}
+}
+
+func TestRenderAnnotations(t *testing.T) {
+	changesets := []ChangeSet{{
+		Provider: "openai",
+		New:      []ModelChange{{Name: "gpt-5"}},
+		Updated: []ModelUpdate{{Name: "gpt-4o", Changes: []catalog.FieldChange{
+			{Field: "cost.input_per_1k", OldValue: 0.005, NewValue: 0.0025},
+			{Field: "limits.max_tokens", OldValue: 128000, NewValue: 256000},
+		}}},
+		DeprecationCandidates: []ModelChange{{Name: "gpt-3.5-turbo"}},
+		Warnings:              []adapter.Warning{{Model: "o3", Message: "no price: 100% off?"}},
+		RemovedFamilies:       []FamilyRemoval{{Family: "gpt-3.5", Models: []string{"gpt-3.5-turbo"}}},
+	}}
+
+	got := RenderAnnotations(changesets, "catalog", ".yaml")
+	want := []string{
+		"::error title=Model family removed (openai)::openai no longer lists any gpt-3.5 model (gpt-3.5-turbo): a provider retirement or an adapter regression",
+		"::notice title=New model::openai/gpt-5 would be added",
+		"::notice file=catalog/providers/openai/models/gpt-4o.yaml,title=Model update::openai/gpt-4o would change:%0Acost.input_per_1k: 0.005 → 0.0025%0Alimits.max_tokens: 128000 → 256000",
+		"::warning file=catalog/providers/openai/models/gpt-3.5-turbo.yaml,title=Deprecation candidate::openai/gpt-3.5-turbo is no longer listed by the provider",
+		"::warning title=Discovery warning (openai)::o3: no price: 100%25 off?",
+	}
+	if lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n"); strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("annotations:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+
+	summary := RenderJobSummary(append(changesets, ChangeSet{Provider: "groq", Unchanged: 12}))
+	for _, s := range []string{
+		"| openai | 1 | 1 | 1 | 0 | 1 |",
+		"| groq | 0 | 0 | 0 | 12 | 0 |",
+		"- Change `gpt-4o` (cost.input_per_1k: 0.005 → 0.0025; limits.max_tokens: 128000 → 256000)",
+		"- Deprecation candidate `gpt-3.5-turbo`",
+		"> The whole `gpt-3.5` family is gone (1 models).",
+	} {
+		if !strings.Contains(summary, s) {
+			t.Errorf("job summary missing %q:\n%s", s, summary)
+		}
+	}
+	if strings.Contains(summary, "### groq") {
+		t.Error("job summary lists a provider without changes")
+	}
+	if got := RenderJobSummary([]ChangeSet{{Provider: "groq"}}); !strings.Contains(got, "No changes.") {
+		t.Errorf("summary without changes:\n%s", got)
+	}
+}
@@ -0,0 +1,136 @@
+package diff
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/everstacklabs/sentinel/internal/textutil"
+)
+
+// minRenameScore is the confidence below which a pair of models is not
+// reported as a possible rename.
+const minRenameScore = 0.7
+
+// Weights of the signals a rename score combines. Signals one of the
+// models has no data for are left out and the rest weighed up.
+const (
+	renameNameWeight   = 0.5
+	renameLimitsWeight = 0.25
+	renameCostWeight   = 0.25
+)
+
+// detectRenames pairs new models with disappeared models of the same
+// family, scored by renameScore. Each model ends up in at most one pair,
+// the highest scoring pairs taken first, so a family with several new
+// models doesn't pair every one with every disappeared model.
+func detectRenames(newModels []ModelChange, disappeared []ModelChange) []RenamePair {
+	var candidates []RenamePair
+	for _, newM := range newModels {
+		for _, oldM := range disappeared {
+			if newM.Model.Family != oldM.Model.Family || newM.Model.Family == "" {
+				continue
+			}
+			score, reason := renameScore(oldM, newM)
+			if score < minRenameScore {
+				continue
+			}
+			candidates = append(candidates, RenamePair{
+				OldName: oldM.Name,
+				NewName: newM.Name,
+				Score:   score,
+				Reason:  reason,
+			})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.NewName != b.NewName {
+			return a.NewName < b.NewName
+		}
+		return a.OldName < b.OldName
+	})
+
+	var renames []RenamePair
+	paired := make(map[string]bool)
+	for _, c := range candidates {
+		if paired["old:"+c.OldName] || paired["new:"+c.NewName] {
+			continue
+		}
+		paired["old:"+c.OldName] = true
+		paired["new:"+c.NewName] = true
+		renames = append(renames, c)
+	}
+	return renames
+}
+
+// renameScore rates from 0 to 1 how likely newM is oldM renamed, from the
+// similarity of their names, context lengths and input prices. The reason
+// lists the signals that went into it.
+func renameScore(oldM, newM ModelChange) (float64, string) {
+	var total, weights float64
+	reasons := []string{"same family"}
+	add := func(what string, sim, weight float64) {
+		total += sim * weight
+		weights += weight
+		reasons = append(reasons, fmt.Sprintf("%s %.0f%%", what, sim*100))
+	}
+
+	add("name", nameSimilarity(oldM.Name, newM.Name), renameNameWeight)
+	if a, b := oldM.Model.Limits.MaxTokens, newM.Model.Limits.MaxTokens; a > 0 && b > 0 {
+		add("limits", ratio(float64(a), float64(b)), renameLimitsWeight)
+	}
+	if a, b := oldM.Model.Cost.Normalize(), newM.Model.Cost.Normalize(); a != nil && b != nil && a.InputPer1K > 0 && b.InputPer1K > 0 {
+		add("input price", ratio(a.InputPer1K, b.InputPer1K), renameCostWeight)
+	}
+	return math.Round(total/weights*100) / 100, strings.Join(reasons, ", ")
+}
+
+// ratio is the smaller of two positive values over the larger.
+func ratio(a, b float64) float64 {
+	return math.Min(a, b) / math.Max(a, b)
+}
+
+// nameSimilarity rates from 0 to 1 how alike two model names are: the
+// better of their edit distance and the overlap of their tokens, so both
+// "gpt-4o-v1" → "gpt-4o-v2" and "claude-3-sonnet" → "claude-sonnet-3"
+// count as similar. Case is ignored.
+func nameSimilarity(a, b string) float64 {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	if a == b {
+		return 1
+	}
+	longest := max(len([]rune(a)), len([]rune(b)))
+	edit := 1 - float64(textutil.EditDistance(a, b))/float64(longest)
+	return math.Max(edit, tokenOverlap(a, b))
+}
+
+// tokenOverlap is the Jaccard index of the names' tokens, split at
+// anything that isn't a letter or digit.
+func tokenOverlap(a, b string) float64 {
+	split := func(s string) map[string]bool {
+		set := make(map[string]bool)
+		for _, t := range strings.FieldsFunc(s, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			set[t] = true
+		}
+		return set
+	}
+	ta, tb := split(a), split(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
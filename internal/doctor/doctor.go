@@ -0,0 +1,310 @@
+// Package doctor checks a configuration for the mistakes that would make a
+// sync fail part way: missing API keys, unreachable endpoints, a missing
+// catalog, a GitHub token that can't push and directories that can't be
+// written. Each finding comes with the fix.
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/cache"
+	"github.com/everstacklabs/sentinel/internal/config"
+)
+
+// Check outcomes.
+const (
+	StatusOK   = "ok"
+	StatusWarn = "warn" // works, but probably not as intended
+	StatusFail = "fail" // a sync would fail
+)
+
+// Check is the outcome of one diagnostic.
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Fix    string `json:"fix,omitempty"` // what to change; set unless the check passed
+}
+
+// Failed reports whether any check failed.
+func Failed(checks []Check) bool {
+	return slices.ContainsFunc(checks, func(c Check) bool { return c.Status == StatusFail })
+}
+
+// Doctor runs the diagnostics for one configuration.
+type Doctor struct {
+	cfg       *config.Config
+	client    *http.Client
+	githubAPI string
+	offline   bool
+}
+
+// Option configures a Doctor.
+type Option func(*Doctor)
+
+// WithHTTPClient sends the network checks through c.
+func WithHTTPClient(c *http.Client) Option {
+	return func(d *Doctor) { d.client = c }
+}
+
+// WithGitHubAPI points the token checks at another GitHub API, such as a
+// GitHub Enterprise server's.
+func WithGitHubAPI(url string) Option {
+	return func(d *Doctor) { d.githubAPI = strings.TrimSuffix(url, "/") }
+}
+
+// Offline skips the checks that need the network.
+func Offline() Option {
+	return func(d *Doctor) { d.offline = true }
+}
+
+// New creates a Doctor for cfg.
+func New(cfg *config.Config, opts ...Option) *Doctor {
+	d := &Doctor{
+		cfg:       cfg,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		githubAPI: "https://api.github.com",
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Run runs every check, in a fixed order.
+func (d *Doctor) Run(ctx context.Context) []Check {
+	checks := []Check{d.checkConfigFile()}
+	checks = append(checks, d.checkProviders(ctx)...)
+	checks = append(checks, d.checkCatalog())
+	checks = append(checks, d.checkGitHub(ctx)...)
+	checks = append(checks, d.checkCacheDir())
+	if d.cfg.Health.History != "" {
+		checks = append(checks, checkWritable("run history directory", filepath.Dir(d.cfg.Health.History), "set health.history to a writable path, or empty to disable the history"))
+	}
+	return checks
+}
+
+func (d *Doctor) checkConfigFile() Check {
+	c := Check{Name: "config file", Status: StatusOK, Detail: d.cfg.File}
+	if d.cfg.File == "" {
+		c.Status, c.Detail = StatusWarn, "none found, using defaults and the environment"
+		c.Fix = "copy config.yaml to the working directory or ~/.config/sentinel, or pass --config"
+	}
+	return c
+}
+
+// checkProviders checks that each configured provider exists, has its API
+// key and answers at its base URL. Base URLs are probed concurrently.
+func (d *Doctor) checkProviders(ctx context.Context) []Check {
+	if len(d.cfg.Providers) == 0 {
+		return []Check{{Name: "providers", Status: StatusFail, Detail: "none configured",
+			Fix: "list providers in the config or set SENTINEL_PROVIDERS"}}
+	}
+	perProvider := make([][]Check, len(d.cfg.Providers))
+	var wg sync.WaitGroup
+	for i, name := range d.cfg.Providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			perProvider[i] = d.checkProvider(ctx, name)
+		}()
+	}
+	wg.Wait()
+	return slices.Concat(perProvider...)
+}
+
+func (d *Doctor) checkProvider(ctx context.Context, name string) []Check {
+	a, err := adapter.Get(name)
+	if err != nil {
+		known := adapter.List()
+		slices.Sort(known)
+		return []Check{{Name: name, Status: StatusFail, Detail: "unknown provider",
+			Fix: fmt.Sprintf("remove it from providers; known providers: %s", strings.Join(known, ", "))}}
+	}
+	configurable, ok := a.(adapter.Configurable)
+	if !ok {
+		return []Check{{Name: name, Status: StatusOK, Detail: "docs only, no API key needed"}}
+	}
+	spec := configurable.ConfigSpec()
+	access, _ := d.cfg.Access(spec.Key)
+
+	key := Check{Name: name + " api key", Status: StatusOK, Detail: "set"}
+	if access.APIKey == "" {
+		key.Status, key.Detail = StatusFail, "not set"
+		if spec.KeyOptional {
+			key.Status, key.Detail = StatusWarn, "not set, discovering from the docs only"
+		}
+		key.Fix = fmt.Sprintf("set %s or %s.api_key in the config", spec.APIKeyEnv, spec.Key)
+	}
+	checks := []Check{key}
+	if access.BaseURL != "" && !d.offline {
+		checks = append(checks, d.checkReachable(ctx, name+" base url", access.BaseURL,
+			fmt.Sprintf("check %s.base_url and that this host can reach it (proxy, firewall)", spec.Key)))
+	}
+	return checks
+}
+
+// checkReachable passes when url answers with any HTTP response: an
+// unauthenticated request to an API root is expected to be refused.
+func (d *Doctor) checkReachable(ctx context.Context, name, url, fix string) Check {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Check{Name: name, Status: StatusFail, Detail: err.Error(), Fix: fix}
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return Check{Name: name, Status: StatusFail, Detail: fmt.Sprintf("%s unreachable: %v", url, err), Fix: fix}
+	}
+	resp.Body.Close()
+	return Check{Name: name, Status: StatusOK, Detail: fmt.Sprintf("%s answered %d", url, resp.StatusCode)}
+}
+
+func (d *Doctor) checkCatalog() Check {
+	c := Check{Name: "catalog path", Status: StatusOK, Detail: d.cfg.CatalogPath}
+	info, err := os.Stat(d.cfg.CatalogPath)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && d.cfg.GitHub.AutoClone:
+		c.Detail = d.cfg.CatalogPath + " missing, will be cloned (github.auto_clone)"
+	case errors.Is(err, fs.ErrNotExist):
+		c.Status, c.Detail = StatusFail, d.cfg.CatalogPath+" does not exist"
+		c.Fix = "set catalog_path to a checkout of the catalog, or set github.auto_clone to clone it"
+	case err != nil:
+		c.Status, c.Detail, c.Fix = StatusFail, err.Error(), "check the permissions of catalog_path"
+	case !info.IsDir():
+		c.Status, c.Detail = StatusFail, d.cfg.CatalogPath+" is not a directory"
+		c.Fix = "set catalog_path to the catalog's directory"
+	}
+	return c
+}
+
+// checkGitHub checks that the token is accepted, has the scopes to open
+// PRs and can push to the configured repository. Without a token only dry
+// runs work.
+func (d *Doctor) checkGitHub(ctx context.Context) []Check {
+	gh := d.cfg.GitHub
+	if gh.Token == "" {
+		c := Check{Name: "github token", Status: StatusWarn, Detail: "not set, syncs can't open PRs",
+			Fix: "set GITHUB_TOKEN or github.token, or run with --dry-run"}
+		if d.cfg.DryRun {
+			c.Status, c.Detail, c.Fix = StatusOK, "not set, not needed for dry runs", ""
+		}
+		return []Check{c}
+	}
+	if d.offline {
+		return []Check{{Name: "github token", Status: StatusOK, Detail: "set, not verified offline"}}
+	}
+
+	token := Check{Name: "github token", Status: StatusOK}
+	resp, err := d.githubGet(ctx, "/user")
+	if err != nil {
+		token.Status, token.Detail = StatusFail, err.Error()
+		token.Fix = "check network access to the GitHub API"
+		return []Check{token}
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		token.Status, token.Detail = StatusFail, "rejected (HTTP 401), expired or revoked"
+		token.Fix = "create a new token and set GITHUB_TOKEN"
+		return []Check{token}
+	}
+	// Classic tokens list their scopes; fine-grained tokens have
+	// per-repository permissions instead, checked below.
+	if scopes, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		list := strings.Split(strings.Join(scopes, ","), ",")
+		for i := range list {
+			list[i] = strings.TrimSpace(list[i])
+		}
+		token.Detail = "scopes: " + strings.Join(list, ", ")
+		if !slices.Contains(list, "repo") && !slices.Contains(list, "public_repo") {
+			token.Status = StatusFail
+			token.Fix = "give the token the repo scope (public_repo for a public catalog)"
+		}
+	} else {
+		token.Detail = "fine-grained token"
+	}
+	checks := []Check{token}
+	if gh.Owner != "" && gh.Repo != "" {
+		checks = append(checks, d.checkRepoAccess(ctx, gh.Owner, gh.Repo))
+	}
+	return checks
+}
+
+func (d *Doctor) checkRepoAccess(ctx context.Context, owner, repo string) Check {
+	c := Check{Name: "github repo", Status: StatusOK, Detail: owner + "/" + repo}
+	resp, err := d.githubGet(ctx, "/repos/"+owner+"/"+repo)
+	if err != nil {
+		c.Status, c.Detail, c.Fix = StatusFail, err.Error(), "check network access to the GitHub API"
+		return c
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		c.Status, c.Detail = StatusFail, owner+"/"+repo+" not found or not visible to the token"
+		c.Fix = "check github.owner and github.repo, and grant the token access to the repository"
+		return c
+	}
+	var body struct {
+		Permissions struct {
+			Push bool `json:"push"`
+		} `json:"permissions"`
+	}
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&body) != nil {
+		c.Status, c.Detail = StatusWarn, fmt.Sprintf("couldn't read permissions (HTTP %d)", resp.StatusCode)
+		c.Fix = "check that the token can push to the repository"
+		return c
+	}
+	if !body.Permissions.Push {
+		c.Status, c.Detail = StatusFail, owner+"/"+repo+" is read-only for the token"
+		c.Fix = "grant the token write access (contents and pull requests for a fine-grained token)"
+	}
+	return c
+}
+
+func (d *Doctor) githubGet(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.githubAPI+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+d.cfg.GitHub.Token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	return d.client.Do(req)
+}
+
+func (d *Doctor) checkCacheDir() Check {
+	if d.cfg.NoCache {
+		return Check{Name: "cache directory", Status: StatusOK, Detail: "caching disabled"}
+	}
+	if b := d.cfg.Cache.Backend; b != "" && b != cache.BackendFile {
+		return Check{Name: "cache directory", Status: StatusOK, Detail: "not used by the " + b + " backend"}
+	}
+	return checkWritable("cache directory", d.cfg.CacheDir, "set cache_dir to a writable directory, or run with --no-cache")
+}
+
+// checkWritable checks that dir exists or can be created, and that a file
+// can be written in it.
+func checkWritable(name, dir, fix string) Check {
+	c := Check{Name: name, Status: StatusOK, Detail: dir}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		c.Status, c.Detail, c.Fix = StatusFail, err.Error(), fix
+		return c
+	}
+	f, err := os.CreateTemp(dir, ".sentinel-doctor-*")
+	if err != nil {
+		c.Status, c.Detail, c.Fix = StatusFail, dir+" is not writable: "+err.Error(), fix
+		return c
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return c
+}
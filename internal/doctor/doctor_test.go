@@ -0,0 +1,152 @@
+package doctor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+)
+
+type stubAdapter struct{ name string }
+
+func (a *stubAdapter) Name() string                           { return a.name }
+func (a *stubAdapter) SupportedSources() []adapter.SourceType { return nil }
+func (a *stubAdapter) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
+	return nil, nil
+}
+
+// apiAdapter is a stub that calls its provider's API.
+type apiAdapter struct {
+	stubAdapter
+	spec adapter.ConfigSpec
+}
+
+func (a *apiAdapter) ConfigSpec() adapter.ConfigSpec                              { return a.spec }
+func (a *apiAdapter) Configure(apiKey, baseURL string, client *httpclient.Client) {}
+
+func TestRun(t *testing.T) {
+	adapter.Register(&apiAdapter{stubAdapter{"openai"}, adapter.ConfigSpec{Key: "openai", APIKeyEnv: "OPENAI_API_KEY"}})
+	adapter.Register(&apiAdapter{stubAdapter{"anthropic"}, adapter.ConfigSpec{Key: "anthropic", APIKeyEnv: "ANTHROPIC_API_KEY"}})
+	adapter.Register(&apiAdapter{stubAdapter{"perplexity"}, adapter.ConfigSpec{Key: "perplexity", APIKeyEnv: "PERPLEXITY_API_KEY", KeyOptional: true}})
+	adapter.Register(&stubAdapter{name: "ai21"})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user":
+			if r.Header.Get("Authorization") != "Bearer good" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("X-OAuth-Scopes", "read:org, public_repo")
+		case "/repos/acme/catalog":
+			w.Write([]byte(`{"permissions":{"push":false}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	catalogFile := filepath.Join(dir, "catalog.yaml")
+	if err := os.WriteFile(catalogFile, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  func(*config.Config)
+		want map[string]string // check name to status
+	}{
+		{
+			name: "healthy",
+			cfg:  func(c *config.Config) {},
+			want: map[string]string{
+				"config file": StatusOK, "openai api key": StatusOK, "openai base url": StatusOK,
+				"catalog path": StatusOK, "github token": StatusOK, "github repo": StatusFail,
+				"cache directory": StatusOK, "run history directory": StatusOK,
+			},
+		},
+		{
+			name: "missing pieces",
+			cfg: func(c *config.Config) {
+				c.File = ""
+				c.Providers = []string{"anthropic", "perplexity", "ai21", "nope"}
+				c.CatalogPath = filepath.Join(dir, "missing")
+				c.GitHub.Token = "bad"
+				c.ProviderSections["anthropic"] = config.ProviderConfig{ProviderAccess: config.ProviderAccess{BaseURL: "http://127.0.0.1:0"}}
+			},
+			want: map[string]string{
+				"config file": StatusWarn, "anthropic api key": StatusFail, "anthropic base url": StatusFail,
+				"perplexity api key": StatusWarn, "ai21": StatusOK, "nope": StatusFail, "catalog path": StatusFail, "github token": StatusFail,
+			},
+		},
+		{
+			name: "auto clone and dry run",
+			cfg: func(c *config.Config) {
+				c.CatalogPath = filepath.Join(dir, "missing")
+				c.GitHub.AutoClone = true
+				c.GitHub.Token = ""
+				c.DryRun = true
+			},
+			want: map[string]string{"catalog path": StatusOK, "github token": StatusOK},
+		},
+		{
+			name: "catalog path is a file",
+			cfg:  func(c *config.Config) { c.CatalogPath = catalogFile },
+			want: map[string]string{"catalog path": StatusFail},
+		},
+		{
+			name: "unwritable cache",
+			cfg:  func(c *config.Config) { c.CacheDir = filepath.Join(catalogFile, "cache") },
+			want: map[string]string{"cache directory": StatusFail},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				File:        "config.yaml",
+				Providers:   []string{"openai"},
+				CatalogPath: dir,
+				CacheDir:    filepath.Join(dir, "cache"),
+				GitHub:      config.GitHubConfig{Token: "good", Owner: "acme", Repo: "catalog"},
+				Health:      config.HealthConfig{History: filepath.Join(dir, "state", "runs.jsonl")},
+				ProviderSections: map[string]config.ProviderConfig{
+					"openai": {ProviderAccess: config.ProviderAccess{APIKey: "sk-test", BaseURL: srv.URL}},
+				},
+			}
+			tt.cfg(cfg)
+			checks := New(cfg, WithGitHubAPI(srv.URL)).Run(context.Background())
+
+			got := make(map[string]Check)
+			for _, c := range checks {
+				got[c.Name] = c
+			}
+			for name, status := range tt.want {
+				c, ok := got[name]
+				if !ok {
+					t.Errorf("no %q check in %+v", name, checks)
+					continue
+				}
+				if c.Status != status {
+					t.Errorf("%s: status %s (%s), want %s", name, c.Status, c.Detail, status)
+				}
+				if c.Status != StatusOK && c.Fix == "" {
+					t.Errorf("%s: no fix for a %s check", name, c.Status)
+				}
+			}
+			wantFailed := false
+			for _, s := range tt.want {
+				wantFailed = wantFailed || s == StatusFail
+			}
+			if Failed(checks) != wantFailed {
+				t.Errorf("Failed = %v, want %v", Failed(checks), wantFailed)
+			}
+		})
+	}
+}
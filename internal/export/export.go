@@ -0,0 +1,292 @@
+// Package export flattens the YAML catalog into single-file artifacts
+// (JSON, JSON Lines, CSV) for gateways and dashboards.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+// Supported formats.
+const (
+	FormatJSON  = "json"
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// Formats lists the supported export formats.
+func Formats() []string {
+	return []string{FormatJSON, FormatJSONL, FormatCSV}
+}
+
+// Filter selects which models are exported. Empty fields match everything.
+// Providers, Statuses and Types match any listed value; a model must have
+// every listed capability.
+type Filter struct {
+	Providers    []string
+	Capabilities []string
+	Statuses     []string
+	Types        []string
+}
+
+// Record is one flattened model. Prices are normalized to per-1K tokens.
+type Record struct {
+	Provider            string   `json:"provider"`
+	Name                string   `json:"name"`
+	DisplayName         string   `json:"display_name"`
+	Family              string   `json:"family"`
+	Status              string   `json:"status"`
+	ModelType           string   `json:"model_type"`
+	Readiness           string   `json:"readiness,omitempty"`
+	Currency            string   `json:"currency,omitempty"`
+	InputPer1K          *float64 `json:"input_per_1k,omitempty"`
+	OutputPer1K         *float64 `json:"output_per_1k,omitempty"`
+	CachedInputPer1K    *float64 `json:"cached_input_per_1k,omitempty"`
+	PerImage            *float64 `json:"per_image,omitempty"`
+	PerRequest          *float64 `json:"per_request,omitempty"`
+	MaxTokens           int      `json:"max_tokens"`
+	MaxCompletionTokens int      `json:"max_completion_tokens,omitempty"`
+	Capabilities        []string `json:"capabilities"`
+	InputModalities     []string `json:"input_modalities"`
+	OutputModalities    []string `json:"output_modalities"`
+}
+
+// Document is the top-level JSON export.
+type Document struct {
+	Version     string   `json:"version"`
+	CatalogHash string   `json:"catalog_hash,omitempty"` // catalog.ContentHash of the source catalog
+	Count       int      `json:"count"`
+	Models      []Record `json:"models"`
+}
+
+// Meta describes the catalog an export was made from.
+type Meta struct {
+	Version     string
+	CatalogHash string
+}
+
+// Records flattens the catalog, applying the filter. Records are sorted by
+// provider, then model name.
+func Records(cat *catalog.Catalog, f Filter) []Record {
+	var records []Record
+	for provider, pc := range cat.Providers {
+		if !matchAny(f.Providers, provider) {
+			continue
+		}
+		for _, m := range pc.Models {
+			if !matchAny(f.Statuses, m.Status) || !matchAny(f.Types, m.Type()) || !hasAll(m.Capabilities, f.Capabilities) {
+				continue
+			}
+			records = append(records, newRecord(provider, m))
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Provider != records[j].Provider {
+			return records[i].Provider < records[j].Provider
+		}
+		return records[i].Name < records[j].Name
+	})
+	return records
+}
+
+func newRecord(provider string, m *catalog.Model) Record {
+	r := Record{
+		Provider:            provider,
+		Name:                m.Name,
+		DisplayName:         m.DisplayName,
+		Family:              m.Family,
+		Status:              m.Status,
+		ModelType:           m.Type(),
+		Readiness:           m.Readiness,
+		MaxTokens:           m.Limits.MaxTokens,
+		MaxCompletionTokens: m.Limits.MaxCompletionTokens,
+		Capabilities:        nonNil(m.Capabilities),
+		InputModalities:     nonNil(m.Modalities.Input),
+		OutputModalities:    nonNil(m.Modalities.Output),
+	}
+	if m.Cost != nil {
+		c := m.Cost.Normalize()
+		r.Currency = c.CurrencyOrDefault()
+		r.InputPer1K = &c.InputPer1K
+		r.OutputPer1K = &c.OutputPer1K
+		r.CachedInputPer1K = optional(c.CachedInputPer1K)
+		r.PerImage = optional(c.PerImage)
+		r.PerRequest = optional(c.PerRequest)
+	}
+	return r
+}
+
+// Write encodes records in the given format. Only the JSON document has
+// room for meta; JSON Lines and CSV hold records alone.
+func Write(w io.Writer, format string, meta Meta, records []Record) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(Document{
+			Version:     meta.Version,
+			CatalogHash: meta.CatalogHash,
+			Count:       len(records),
+			Models:      nonNilRecords(records),
+		})
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		for _, r := range records {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatCSV:
+		return writeCSV(w, records)
+	default:
+		return fmt.Errorf("unsupported export format %q (want one of: %s)", format, strings.Join(Formats(), ", "))
+	}
+}
+
+// Read loads a JSON export back into a catalog, for comparing against a
+// catalog state that was only kept as an export. The models have the fields
+// a Record holds, with prices per 1K tokens.
+func Read(r io.Reader) (*catalog.Catalog, error) {
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decoding JSON export: %w", err)
+	}
+	cat := &catalog.Catalog{Version: doc.Version, Providers: make(map[string]*catalog.ProviderCatalog)}
+	for _, rec := range doc.Models {
+		pc, ok := cat.Providers[rec.Provider]
+		if !ok {
+			pc = &catalog.ProviderCatalog{
+				Provider: catalog.Provider{Name: rec.Provider},
+				Models:   make(map[string]*catalog.Model),
+			}
+			cat.Providers[rec.Provider] = pc
+		}
+		pc.Models[rec.Name] = rec.model()
+	}
+	return cat, nil
+}
+
+// model turns a record back into a catalog model.
+func (r Record) model() *catalog.Model {
+	m := &catalog.Model{
+		Name:         r.Name,
+		DisplayName:  r.DisplayName,
+		Family:       r.Family,
+		Status:       r.Status,
+		ModelType:    r.ModelType,
+		Readiness:    r.Readiness,
+		Limits:       catalog.Limits{MaxTokens: r.MaxTokens, MaxCompletionTokens: r.MaxCompletionTokens},
+		Capabilities: r.Capabilities,
+		Modalities:   catalog.Modalities{Input: r.InputModalities, Output: r.OutputModalities},
+	}
+	if r.InputPer1K != nil || r.OutputPer1K != nil {
+		m.Cost = &catalog.Cost{
+			InputPer1K:       deref(r.InputPer1K),
+			OutputPer1K:      deref(r.OutputPer1K),
+			CachedInputPer1K: deref(r.CachedInputPer1K),
+			PerImage:         deref(r.PerImage),
+			PerRequest:       deref(r.PerRequest),
+		}
+		if r.Currency != "USD" {
+			m.Cost.Currency = r.Currency
+		}
+	}
+	return m
+}
+
+func deref(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+var csvHeader = []string{
+	"provider", "name", "display_name", "family", "status", "model_type", "readiness", "currency",
+	"input_per_1k", "output_per_1k", "cached_input_per_1k", "per_image", "per_request",
+	"max_tokens", "max_completion_tokens",
+	"capabilities", "input_modalities", "output_modalities",
+}
+
+// writeCSV writes one row per record; list fields are joined with ";".
+func writeCSV(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range records {
+		row := []string{
+			r.Provider, r.Name, r.DisplayName, r.Family, r.Status, r.ModelType, r.Readiness, r.Currency,
+			formatFloat(r.InputPer1K), formatFloat(r.OutputPer1K), formatFloat(r.CachedInputPer1K),
+			formatFloat(r.PerImage), formatFloat(r.PerRequest),
+			strconv.Itoa(r.MaxTokens), strconv.Itoa(r.MaxCompletionTokens),
+			strings.Join(r.Capabilities, ";"),
+			strings.Join(r.InputModalities, ";"),
+			strings.Join(r.OutputModalities, ";"),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+func optional(v float64) *float64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+// matchAny reports whether v is in allowed; an empty list allows everything.
+func matchAny(allowed []string, v string) bool {
+	return len(allowed) == 0 || contains(allowed, v)
+}
+
+func hasAll(have, want []string) bool {
+	for _, w := range want {
+		if !contains(have, w) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func nonNilRecords(r []Record) []Record {
+	if r == nil {
+		return []Record{}
+	}
+	return r
+}
@@ -0,0 +1,153 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+func testCatalog() *catalog.Catalog {
+	return &catalog.Catalog{
+		Version: "1.2.3",
+		Providers: map[string]*catalog.ProviderCatalog{
+			"openai": {Models: map[string]*catalog.Model{
+				"gpt-5": {
+					Name: "gpt-5", DisplayName: "GPT-5", Family: "gpt-5", Status: "stable",
+					Cost:         &catalog.Cost{InputPer1K: 1.25, OutputPer1K: 10, Unit: catalog.PriceUnitPer1M},
+					Limits:       catalog.Limits{MaxTokens: 400000},
+					Capabilities: []string{"chat", "vision"},
+					Modalities:   catalog.Modalities{Input: []string{"text", "image"}, Output: []string{"text"}},
+				},
+				"gpt-4o-mini": {
+					Name: "gpt-4o-mini", Family: "gpt-4o", Status: "stable",
+					Capabilities: []string{"chat"},
+				},
+			}},
+			"anthropic": {Models: map[string]*catalog.Model{
+				"claude-old": {Name: "claude-old", Status: "deprecated", Capabilities: []string{"chat", "vision"}},
+			}},
+		},
+	}
+}
+
+func TestRecordsFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"all", Filter{}, []string{"claude-old", "gpt-4o-mini", "gpt-5"}},
+		{"provider", Filter{Providers: []string{"openai"}}, []string{"gpt-4o-mini", "gpt-5"}},
+		{"capability", Filter{Capabilities: []string{"vision"}}, []string{"claude-old", "gpt-5"}},
+		{"all capabilities required", Filter{Capabilities: []string{"vision", "chat"}, Providers: []string{"openai"}}, []string{"gpt-5"}},
+		{"status", Filter{Statuses: []string{"stable"}}, []string{"gpt-4o-mini", "gpt-5"}},
+		{"type inferred from capabilities", Filter{Types: []string{"chat"}, Providers: []string{"openai"}}, []string{"gpt-4o-mini", "gpt-5"}},
+		{"type", Filter{Types: []string{"speech_to_text"}}, nil},
+		{"no match", Filter{Capabilities: []string{"audio"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range Records(testCatalog(), tt.filter) {
+				got = append(got, r.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteJSONNormalizesPrices(t *testing.T) {
+	var buf bytes.Buffer
+	records := Records(testCatalog(), Filter{Providers: []string{"openai"}})
+	if err := Write(&buf, FormatJSON, Meta{Version: "1.2.3", CatalogHash: "abc123"}, records); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc Document
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if doc.Version != "1.2.3" || doc.CatalogHash != "abc123" || doc.Count != 2 {
+		t.Errorf("unexpected header: version=%s hash=%s count=%d", doc.Version, doc.CatalogHash, doc.Count)
+	}
+	gpt5 := doc.Models[1]
+	if gpt5.InputPer1K == nil || *gpt5.InputPer1K != 0.00125 {
+		t.Errorf("input_per_1k = %v, want 0.00125", gpt5.InputPer1K)
+	}
+	if doc.Models[0].InputPer1K != nil {
+		t.Error("expected no price for model without cost")
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSONL, Meta{Version: "1.2.3"}, Records(testCatalog(), Filter{})); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(lines))
+	}
+	var r Record
+	if err := json.Unmarshal([]byte(lines[0]), &r); err != nil || r.Provider != "anthropic" {
+		t.Errorf("unexpected first line %q: %v", lines[0], err)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, Meta{Version: "1.2.3"}, Records(testCatalog(), Filter{Providers: []string{"openai"}})); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want header + 2 rows", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "provider,name,display_name") {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	want := "openai,gpt-5,GPT-5,gpt-5,stable,chat,,USD,0.00125,0.01,,,,400000,0,chat;vision,text;image,text"
+	if lines[2] != want {
+		t.Errorf("row = %q\nwant  %q", lines[2], want)
+	}
+}
+
+func TestReadRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, Meta{Version: "1.2.3"}, Records(testCatalog(), Filter{})); err != nil {
+		t.Fatal(err)
+	}
+	cat, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cat.Version != "1.2.3" || len(cat.Providers) != 2 || len(cat.Providers["openai"].Models) != 2 {
+		t.Fatalf("read back %+v", cat)
+	}
+	m := cat.Providers["openai"].Models["gpt-5"]
+	if m.Cost == nil || m.Cost.InputPer1K != 0.00125 || m.Cost.Currency != "" || m.Limits.MaxTokens != 400000 {
+		t.Errorf("gpt-5 = %+v, cost %+v", m, m.Cost)
+	}
+	if len(m.Capabilities) != 2 || m.Modalities.Input[1] != "image" || m.ModelType != "chat" {
+		t.Errorf("gpt-5 = %+v", m)
+	}
+	if c := cat.Providers["openai"].Models["gpt-4o-mini"]; c.Cost != nil {
+		t.Errorf("model without prices got cost %+v", c.Cost)
+	}
+
+	if _, err := Read(strings.NewReader("not json")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, "xml", Meta{Version: "1.0.0"}, nil); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,68 @@
+// Package fsutil holds file system helpers shared by the packages that
+// write files: the catalog writer, the caches and the pipeline's reports.
+package fsutil
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic writes data to path so that readers, and a crash, only
+// ever see the old or the new content: it writes a temporary file in the
+// same directory and renames it over path. With sync set the file and its
+// directory are flushed to disk before returning.
+func WriteFileAtomic(path string, data []byte, perm fs.FileMode, sync bool) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	ok := false
+	defer func() {
+		if !ok {
+			_ = tmp.Close()
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return err
+	}
+	if sync {
+		if err := tmp.Sync(); err != nil {
+			return err
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	ok = true
+
+	if sync {
+		return syncDir(dir)
+	}
+	return nil
+}
+
+// syncDir flushes a directory so a rename in it survives a crash. Platforms
+// that can't sync directories are not an error.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil && !errors.Is(err, os.ErrInvalid) {
+		return err
+	}
+	return nil
+}
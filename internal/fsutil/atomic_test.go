@@ -0,0 +1,36 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "version.txt")
+	for _, content := range []string{"1.0.0\n", "1.1.0\n"} {
+		if err := WriteFileAtomic(path, []byte(content), 0o644, true); err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != content {
+			t.Errorf("content = %q, want %q", got, content)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d files, want no temporary files left", len(entries))
+	}
+
+	if err := WriteFileAtomic(filepath.Join(dir, "missing", "x.yaml"), []byte("x"), 0o644, false); err == nil {
+		t.Error("expected error writing into a missing directory")
+	}
+}
@@ -101,3 +101,60 @@ func ParsePriceDollars(s string) (float64, bool) {
 func normalizeHeader(s string) string {
 	return strings.ToLower(strings.TrimSpace(s))
 }
+
+// Tables returns every table matching selector as a grid of cell texts,
+// header row included. Unlike TableRows it keeps column order and the
+// original case, for tables laid out with one column per item. Inside
+// cells, <br> and block elements become newlines and footnote markers
+// (<sup>) are dropped.
+func Tables(doc *goquery.Document, selector string) [][][]string {
+	var tables [][][]string
+	doc.Find(selector).Each(func(_ int, table *goquery.Selection) {
+		var grid [][]string
+		table.Find("tr").Each(func(_ int, row *goquery.Selection) {
+			var cells []string
+			row.Find("th, td").Each(func(_ int, cell *goquery.Selection) {
+				cells = append(cells, cellText(cell))
+			})
+			if len(cells) > 0 {
+				grid = append(grid, cells)
+			}
+		})
+		if len(grid) > 0 {
+			tables = append(tables, grid)
+		}
+	})
+	return tables
+}
+
+func cellText(cell *goquery.Selection) string {
+	var b strings.Builder
+	var walk func(*goquery.Selection)
+	walk = func(s *goquery.Selection) {
+		s.Contents().Each(func(_ int, n *goquery.Selection) {
+			switch goquery.NodeName(n) {
+			case "#text":
+				b.WriteString(n.Text())
+			case "br":
+				b.WriteString("\n")
+			case "sup":
+				// Footnote markers would run into the value ("May 20253").
+			case "p", "div", "li":
+				walk(n)
+				b.WriteString("\n")
+			default:
+				walk(n)
+			}
+		})
+	}
+	walk(cell)
+
+	lines := strings.Split(b.String(), "\n")
+	out := lines[:0]
+	for _, l := range lines {
+		if l = strings.Join(strings.Fields(l), " "); l != "" {
+			out = append(out, l)
+		}
+	}
+	return strings.Join(out, "\n")
+}
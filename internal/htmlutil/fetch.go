@@ -1,36 +1,36 @@
 package htmlutil
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/PuerkitoBio/goquery"
+
+	"github.com/everstacklabs/sentinel/internal/httpclient"
 )
 
-// Fetch performs an HTTP GET with a browser-like User-Agent and returns
-// the parsed HTML document.
-func Fetch(ctx context.Context, url string) (*goquery.Document, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; Sentinel/1.0; +https://github.com/everstacklabs/sentinel)")
-	req.Header.Set("Accept", "text/html")
+// UserAgent is the browser-like User-Agent docs pages are fetched with.
+const UserAgent = "Mozilla/5.0 (compatible; Sentinel/1.0; +https://github.com/everstacklabs/sentinel)"
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+// Fetch performs an HTTP GET through client with a browser-like User-Agent
+// and returns the parsed HTML document. Going through the adapter's client
+// gives docs pages the configured timeouts (http.host_timeouts included),
+// retries, cache and recording.
+func Fetch(ctx context.Context, client *httpclient.Client, url string) (*goquery.Document, error) {
+	resp, err := client.Get(ctx, url, map[string]string{
+		"User-Agent": UserAgent,
+		"Accept":     "text/html",
+	})
 	if err != nil {
 		return nil, fmt.Errorf("fetching %s: %w", url, err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("fetching %s: status %d", url, resp.StatusCode)
 	}
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(resp.Body))
 	if err != nil {
 		return nil, fmt.Errorf("parsing HTML from %s: %w", url, err)
 	}
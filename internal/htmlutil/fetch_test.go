@@ -0,0 +1,37 @@
+package htmlutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+)
+
+func TestFetchUsesHostTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != UserAgent {
+			t.Errorf("User-Agent = %q", r.Header.Get("User-Agent"))
+		}
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte("<html><body><h1>Models</h1></body></html>"))
+	}))
+	defer srv.Close()
+
+	base := []httpclient.Option{httpclient.WithRateLimit(1000), httpclient.WithMaxRetries(0), httpclient.WithTimeout(20 * time.Millisecond)}
+
+	if _, err := Fetch(context.Background(), httpclient.New(base...), srv.URL); err == nil {
+		t.Error("slow docs page fetched within a 20ms timeout")
+	}
+
+	slow := httpclient.New(append(base, httpclient.WithHostTimeout("127.0.0.1", time.Second))...)
+	doc, err := Fetch(context.Background(), slow, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := doc.Find("h1").Text(); got != "Models" {
+		t.Errorf("h1 = %q", got)
+	}
+}
@@ -0,0 +1,107 @@
+package httpclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitOpenError is returned without making a request when a host's
+// circuit breaker is open after repeated failures.
+type CircuitOpenError struct {
+	Host     string
+	Failures int
+	Until    time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit open for %s after %d consecutive failures (retry after %s)",
+		e.Host, e.Failures, e.Until.Format(time.RFC3339))
+}
+
+// hostBreaker trips after threshold consecutive failures and rejects
+// requests until cooldown has passed. It then lets a single trial request
+// through: success closes the circuit, failure re-opens it, and a trial
+// that ends without telling either way, such as one cancelled by its
+// caller, is released so the next request becomes the trial.
+type hostBreaker struct {
+	host      string
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+func newHostBreaker(host string, threshold int, cooldown time.Duration) *hostBreaker {
+	return &hostBreaker{
+		host:      host,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow returns a *CircuitOpenError if the request should fail fast. It
+// reports whether the request is the trial after a cool-down, which must
+// be settled with Success, Failure or Release, or no other request gets
+// through.
+func (b *hostBreaker) Allow() (trial bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return false, nil
+	}
+	if b.now().Before(b.openUntil) || b.trial {
+		return false, &CircuitOpenError{Host: b.host, Failures: b.failures, Until: b.openUntil}
+	}
+	// Cool-down over: let one trial request through.
+	b.trial = true
+	return true, nil
+}
+
+// Release gives up the trial without closing or re-opening the circuit,
+// for a trial request that ended before the host could answer. It does
+// nothing for a request that isn't the trial.
+func (b *hostBreaker) Release(trial bool) {
+	if b == nil || !trial {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+// Success closes the circuit and resets the failure count.
+func (b *hostBreaker) Success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+	b.trial = false
+}
+
+// Failure records a failed request and opens the circuit at the threshold
+// or when a trial request fails.
+func (b *hostBreaker) Failure() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.trial || b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+		b.trial = false
+	}
+}
@@ -0,0 +1,154 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHostBreakerOpensAndRecovers(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newHostBreaker("api.example.com", 3, time.Minute)
+	b.now = func() time.Time { return now }
+
+	for range 2 {
+		b.Failure()
+	}
+	if _, err := b.Allow(); err != nil {
+		t.Fatalf("breaker open after 2 failures, want closed: %v", err)
+	}
+
+	b.Failure()
+	var openErr *CircuitOpenError
+	if _, err := b.Allow(); !errors.As(err, &openErr) {
+		t.Fatalf("expected *CircuitOpenError after threshold, got %v", err)
+	}
+	if openErr.Host != "api.example.com" || openErr.Failures != 3 {
+		t.Errorf("unexpected error fields: %+v", openErr)
+	}
+
+	// Cool-down over: exactly one trial request is allowed.
+	now = now.Add(time.Minute)
+	if _, err := b.Allow(); err != nil {
+		t.Fatalf("expected trial request after cool-down, got %v", err)
+	}
+	if _, err := b.Allow(); err == nil {
+		t.Fatal("expected concurrent request during trial to fail fast")
+	}
+
+	// Failed trial re-opens immediately.
+	b.Failure()
+	if _, err := b.Allow(); err == nil {
+		t.Fatal("expected breaker to re-open after failed trial")
+	}
+
+	now = now.Add(time.Minute)
+	_, _ = b.Allow()
+	b.Success()
+	if _, err := b.Allow(); err != nil {
+		t.Fatalf("expected closed breaker after successful trial, got %v", err)
+	}
+}
+
+func TestNilBreakerAlwaysAllows(t *testing.T) {
+	var b *hostBreaker
+	if _, err := b.Allow(); err != nil {
+		t.Fatal(err)
+	}
+	b.Failure()
+	b.Success()
+}
+
+func TestClientFailsFastWhenCircuitOpen(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c := New(
+		WithRateLimit(1000),
+		WithMaxRetries(5),
+		WithBaseBackoff(time.Millisecond),
+		WithCircuitBreaker(2, time.Hour),
+	)
+
+	_, err := c.Get(context.Background(), srv.URL+"/models", nil)
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) {
+		t.Fatalf("expected *CircuitOpenError, got %v", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("server hit %d times, want 2 (retries stop once the circuit opens)", got)
+	}
+
+	_, err = c.Get(context.Background(), srv.URL+"/other", nil)
+	if !errors.As(err, &openErr) {
+		t.Fatalf("expected fail-fast on second request, got %v", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("server hit %d times after fail-fast, want 2", got)
+	}
+}
+
+func TestClientSettlesTrialRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		trial func(c *Client, url string) error
+	}{
+		{"4xx", func(c *Client, url string) error {
+			_, err := c.Get(context.Background(), url+"/missing", nil)
+			return err
+		}},
+		{"cancelled", func(c *Client, url string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			_, err := c.Get(ctx, url+"/slow", nil)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/down":
+					w.WriteHeader(http.StatusBadGateway)
+				case "/missing":
+					w.WriteHeader(http.StatusNotFound)
+				case "/slow":
+					select {
+					case <-r.Context().Done():
+					case <-time.After(time.Second):
+					}
+				default:
+					_, _ = w.Write([]byte("{}"))
+				}
+			}))
+			defer srv.Close()
+
+			c := New(
+				WithRateLimit(1000),
+				WithMaxRetries(0),
+				WithCircuitBreaker(1, 10*time.Millisecond),
+			)
+			if _, err := c.Get(context.Background(), srv.URL+"/down", nil); err == nil {
+				t.Fatal("expected an error from the failing host")
+			}
+			time.Sleep(20 * time.Millisecond)
+
+			err := tt.trial(c, srv.URL)
+			var openErr *CircuitOpenError
+			if err == nil || errors.As(err, &openErr) {
+				t.Fatalf("trial request: got %v, want its own error", err)
+			}
+			if _, err := c.Get(context.Background(), srv.URL+"/models", nil); err != nil {
+				t.Fatalf("request after the trial: %v", err)
+			}
+		})
+	}
+}
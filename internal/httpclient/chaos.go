@@ -0,0 +1,179 @@
+package httpclient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ChaosEnv is the environment variable that enables failure injection, e.g.
+// SENTINEL_CHAOS="429=0.1,5xx=0.05,truncate=0.05,slow=0.2,delay=2s,seed=42".
+// It is meant for resilience testing only.
+const ChaosEnv = "SENTINEL_CHAOS"
+
+// ChaosConfig sets how often synthetic failures are injected. Rates are
+// probabilities per request attempt. RateLimit, ServerError and Truncate are
+// mutually exclusive and must sum to at most 1; Slow applies independently.
+type ChaosConfig struct {
+	RateLimit   float64       // respond 429 without reaching the server
+	ServerError float64       // respond 503 without reaching the server
+	Truncate    float64       // cut a successful response body in half
+	Slow        float64       // wait Delay before sending the request
+	Delay       time.Duration // defaults to 2s
+	Seed        uint64        // non-zero makes the sequence of faults reproducible
+}
+
+// ChaosFromEnv reads ChaosEnv. ok is false when it is unset.
+func ChaosFromEnv() (cfg ChaosConfig, ok bool, err error) {
+	spec := os.Getenv(ChaosEnv)
+	if spec == "" {
+		return ChaosConfig{}, false, nil
+	}
+	cfg, err = ParseChaos(spec)
+	return cfg, err == nil, err
+}
+
+// ParseChaos parses a comma-separated list of key=value settings. Keys are
+// 429, 5xx, truncate, slow (rates between 0 and 1), delay (a duration) and
+// seed.
+func ParseChaos(spec string) (ChaosConfig, error) {
+	cfg := ChaosConfig{Delay: 2 * time.Second}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return ChaosConfig{}, fmt.Errorf("chaos setting %q: want key=value", part)
+		}
+
+		var err error
+		switch key {
+		case "429":
+			cfg.RateLimit, err = parseRate(val)
+		case "5xx":
+			cfg.ServerError, err = parseRate(val)
+		case "truncate":
+			cfg.Truncate, err = parseRate(val)
+		case "slow":
+			cfg.Slow, err = parseRate(val)
+		case "delay":
+			cfg.Delay, err = time.ParseDuration(val)
+		case "seed":
+			cfg.Seed, err = strconv.ParseUint(val, 10, 64)
+		default:
+			err = fmt.Errorf("unknown key")
+		}
+		if err != nil {
+			return ChaosConfig{}, fmt.Errorf("chaos setting %q: %w", part, err)
+		}
+	}
+	if sum := cfg.RateLimit + cfg.ServerError + cfg.Truncate; sum > 1 {
+		return ChaosConfig{}, fmt.Errorf("chaos rates for 429, 5xx and truncate add up to %g, more than 1", sum)
+	}
+	return cfg, nil
+}
+
+func parseRate(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 || v > 1 {
+		return 0, fmt.Errorf("rate must be between 0 and 1")
+	}
+	return v, nil
+}
+
+// WithChaos injects failures at the transport, below retries, rate limiting
+// and the circuit breaker, so those paths run as they would against a
+// misbehaving provider. It also disables caching: cached responses would
+// bypass the faults, and truncated bodies must not outlive the test.
+func WithChaos(cfg ChaosConfig) Option {
+	return func(cl *Client) {
+		next := cl.http.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		seed := cfg.Seed
+		if seed == 0 {
+			seed = uint64(time.Now().UnixNano())
+		}
+		cl.http.Transport = &chaosTransport{next: next, cfg: cfg, rng: rand.New(rand.NewPCG(seed, seed))}
+		cl.noCache = true
+	}
+}
+
+// chaosTransport is an http.RoundTripper that injects ChaosConfig faults.
+type chaosTransport struct {
+	next http.RoundTripper
+	cfg  ChaosConfig
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func (t *chaosTransport) rolls() (fault, slow float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rng.Float64(), t.rng.Float64()
+}
+
+func (t *chaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault, slow := t.rolls()
+
+	if slow < t.cfg.Slow {
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(t.cfg.Delay):
+		}
+	}
+
+	switch {
+	case fault < t.cfg.RateLimit:
+		return syntheticResponse(req, http.StatusTooManyRequests), nil
+	case fault < t.cfg.RateLimit+t.cfg.ServerError:
+		return syntheticResponse(req, http.StatusServiceUnavailable), nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || fault >= t.cfg.RateLimit+t.cfg.ServerError+t.cfg.Truncate || resp.StatusCode >= 300 {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	body = body[:len(body)/2]
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	resp.Header.Del("ETag")
+	return resp, nil
+}
+
+func syntheticResponse(req *http.Request, status int) *http.Response {
+	body := fmt.Sprintf(`{"error":"chaos: injected %d"}`, status)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
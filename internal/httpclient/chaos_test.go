@@ -0,0 +1,115 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseChaos(t *testing.T) {
+	cfg, err := ParseChaos("429=0.1, 5xx=0.05,truncate=0.05,slow=0.2,delay=50ms,seed=7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ChaosConfig{RateLimit: 0.1, ServerError: 0.05, Truncate: 0.05, Slow: 0.2, Delay: 50 * time.Millisecond, Seed: 7}
+	if cfg != want {
+		t.Errorf("got %+v, want %+v", cfg, want)
+	}
+
+	for _, bad := range []string{"429", "429=2", "404=0.1", "delay=soon", "429=0.6,5xx=0.6"} {
+		if _, err := ParseChaos(bad); err == nil {
+			t.Errorf("ParseChaos(%q): expected error", bad)
+		}
+	}
+}
+
+func TestChaosServerErrorsExhaustRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := New(WithRateLimit(1000), WithMaxRetries(2), WithBaseBackoff(time.Millisecond),
+		WithChaos(ChaosConfig{ServerError: 1}))
+	_, err := c.Get(context.Background(), srv.URL, nil)
+	if err == nil || !strings.Contains(err.Error(), "max retries exceeded") || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected retries to be exhausted on injected 503s, got %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("injected failures should not reach the server, got %d calls", calls.Load())
+	}
+}
+
+func TestChaosRateLimitTripsBreaker(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	c := New(WithRateLimit(1000), WithMaxRetries(1), WithBaseBackoff(time.Millisecond),
+		WithCircuitBreaker(2, time.Minute), WithChaos(ChaosConfig{RateLimit: 1}))
+	_, _ = c.Get(context.Background(), srv.URL, nil)
+
+	var open *CircuitOpenError
+	if _, err := c.Get(context.Background(), srv.URL, nil); !errors.As(err, &open) {
+		t.Errorf("expected circuit to open after injected 429s, got %v", err)
+	}
+}
+
+func TestChaosTruncatesBodies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"id":"gpt-5"},{"id":"gpt-4o"}]}`))
+	}))
+	defer srv.Close()
+
+	c := New(WithRateLimit(1000), WithChaos(ChaosConfig{Truncate: 1}))
+	resp, err := c.Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v modelList
+	if err := json.Unmarshal(resp.Body, &v); err == nil {
+		t.Errorf("expected truncated JSON, got %s", resp.Body)
+	}
+}
+
+func TestChaosSlowRespectsContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	c := New(WithRateLimit(1000), WithMaxRetries(0), WithChaos(ChaosConfig{Slow: 1, Delay: time.Minute}))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.Get(ctx, srv.URL, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestChaosSeedIsReproducible(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	run := func() string {
+		c := New(WithRateLimit(1000), WithMaxRetries(0), WithBaseBackoff(time.Millisecond),
+			WithChaos(ChaosConfig{ServerError: 0.5, Seed: 42}))
+		var b strings.Builder
+		for i := 0; i < 20; i++ {
+			if _, err := c.Get(context.Background(), srv.URL, nil); err != nil {
+				b.WriteByte('x')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		return b.String()
+	}
+	first, second := run(), run()
+	if first != second || !strings.Contains(first, "x") || !strings.Contains(first, ".") {
+		t.Errorf("expected the same mix of failures for the same seed, got %s and %s", first, second)
+	}
+}
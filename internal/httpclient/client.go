@@ -1,6 +1,7 @@
 package httpclient
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -9,31 +10,54 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/everstacklabs/sentinel/internal/cache"
-	"golang.org/x/time/rate"
 )
 
 // Client is an HTTP client with caching, per-host rate limiting, and retry.
 type Client struct {
-	http         *http.Client
-	cache        *cache.FileCache
-	noCache      bool
-	defaultRPS   float64
-	maxRetries   int
-	baseBackoff  time.Duration
-	hostLimiters map[string]*rate.Limiter
-	mu           sync.RWMutex
+	http        *http.Client
+	transport   *http.Transport // the connection pool under any wrapping transports
+	cache       cache.Cache
+	noCache     bool
+	defaultRPS  float64
+	burst       int
+	warmup      time.Duration
+	jitter      float64
+	maxRetries  int
+	baseBackoff time.Duration
+	headers     map[string]string
+
+	breakerThreshold int
+	breakerCooldown  time.Duration
+
+	timeout      time.Duration            // per attempt; 0 means none
+	hostTimeouts map[string]time.Duration // overrides timeout, by host
+
+	maxResponseSize int64 // bytes; 0 means no limit
+
+	// hosts is shared by clients derived with WithHeaders, so they draw on
+	// the same per-host rate limits and circuit breakers.
+	hosts *hostState
+}
+
+// hostState holds the per-host limiters and breakers.
+type hostState struct {
+	mu       sync.RWMutex
+	limiters map[string]*hostLimiter
+	breakers map[string]*hostBreaker
 }
 
 // Option configures the Client.
 type Option func(*Client)
 
-// WithCache enables file-based caching.
-func WithCache(c *cache.FileCache) Option {
+// WithCache enables response caching with the given backend.
+func WithCache(c cache.Cache) Option {
 	return func(cl *Client) { cl.cache = c }
 }
 
@@ -42,6 +66,68 @@ func WithRateLimit(rps float64) Option {
 	return func(cl *Client) { cl.defaultRPS = rps }
 }
 
+// WithBurst sets how many requests a host may issue back-to-back before the
+// rate limit applies. Defaults to 1.
+func WithBurst(n int) Option {
+	return func(cl *Client) { cl.burst = n }
+}
+
+// WithWarmup enables adaptive slow-down: after a 429 a host's rate is halved
+// and ramps back to the configured rate over d. Zero disables it.
+func WithWarmup(d time.Duration) Option {
+	return func(cl *Client) { cl.warmup = d }
+}
+
+// WithJitter adds up to fraction×backoff of random delay to retry waits.
+func WithJitter(fraction float64) Option {
+	return func(cl *Client) { cl.jitter = fraction }
+}
+
+// WithCircuitBreaker fails requests to a host fast with *CircuitOpenError
+// after threshold consecutive failures (transport errors, 429s, 5xx), until
+// cooldown has passed. A threshold of zero disables the breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(cl *Client) {
+		cl.breakerThreshold = threshold
+		cl.breakerCooldown = cooldown
+	}
+}
+
+// WithTimeout bounds each attempt of a request, reading the body
+// included. Defaults to 30s; zero disables it.
+func WithTimeout(d time.Duration) Option {
+	return func(cl *Client) { cl.timeout = d }
+}
+
+// WithHostTimeout overrides the timeout for requests to host, such as a
+// slow docs site. host is matched with and without its port.
+func WithHostTimeout(host string, d time.Duration) Option {
+	return func(cl *Client) {
+		if cl.hostTimeouts == nil {
+			cl.hostTimeouts = make(map[string]time.Duration)
+		}
+		cl.hostTimeouts[host] = d
+	}
+}
+
+// WithIdleConns tunes connection reuse: how many idle keep-alive
+// connections are kept per host, and for how long. HTTP/2 hosts multiplex
+// requests over one connection regardless.
+func WithIdleConns(perHost int, idleTimeout time.Duration) Option {
+	return func(cl *Client) {
+		cl.transport.MaxIdleConnsPerHost = perHost
+		cl.transport.MaxIdleConns = max(cl.transport.MaxIdleConns, perHost)
+		cl.transport.IdleConnTimeout = idleTimeout
+	}
+}
+
+// WithMaxResponseSize fails requests whose response body is larger than n
+// bytes with *ResponseTooLargeError, instead of reading it all into memory.
+// Defaults to 64 MiB; zero disables the limit.
+func WithMaxResponseSize(n int64) Option {
+	return func(cl *Client) { cl.maxResponseSize = n }
+}
+
 // WithNoCache disables caching.
 func WithNoCache() Option {
 	return func(cl *Client) { cl.noCache = true }
@@ -59,12 +145,22 @@ func WithBaseBackoff(d time.Duration) Option {
 
 // New creates a new HTTP client.
 func New(opts ...Option) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ForceAttemptHTTP2 = true
+	transport.MaxIdleConnsPerHost = 10
 	c := &Client{
-		http:         &http.Client{Timeout: 30 * time.Second},
-		defaultRPS:   5,
-		maxRetries:   3,
-		baseBackoff:  500 * time.Millisecond,
-		hostLimiters: make(map[string]*rate.Limiter),
+		http:            &http.Client{Transport: transport},
+		transport:       transport,
+		timeout:         30 * time.Second,
+		maxResponseSize: 64 << 20,
+		defaultRPS:      5,
+		burst:           1,
+		maxRetries:      3,
+		baseBackoff:     500 * time.Millisecond,
+		hosts: &hostState{
+			limiters: make(map[string]*hostLimiter),
+			breakers: make(map[string]*hostBreaker),
+		},
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -72,6 +168,22 @@ func New(opts ...Option) *Client {
 	return c
 }
 
+// WithHeaders returns a client that sends h on every request, for provider
+// specific needs such as beta feature flags. It shares the receiver's cache,
+// rate limiters and circuit breakers. Per-request headers take precedence.
+func (c *Client) WithHeaders(h map[string]string) *Client {
+	merged := make(map[string]string, len(c.headers)+len(h))
+	for k, v := range c.headers {
+		merged[k] = v
+	}
+	for k, v := range h {
+		merged[k] = v
+	}
+	cp := *c
+	cp.headers = merged
+	return &cp
+}
+
 // Response wraps an HTTP response body and metadata.
 type Response struct {
 	Body       []byte
@@ -92,32 +204,185 @@ func (e *retryableError) Error() string {
 
 func (e *retryableError) Unwrap() error { return e.err }
 
+// ResponseTooLargeError means a response body was larger than the
+// client's maximum response size. It is not retried.
+type ResponseTooLargeError struct {
+	URL   string
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response from %s is larger than %d bytes", e.URL, e.Limit)
+}
+
+// limitReader fails with *ResponseTooLargeError once more than limit bytes
+// have been read.
+type limitReader struct {
+	r     io.Reader
+	url   string
+	limit int64
+	read  int64
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n, &ResponseTooLargeError{URL: l.url, Limit: l.limit}
+	}
+	return n, err
+}
+
+// limitBody bounds body by the client's maximum response size.
+func (c *Client) limitBody(body io.Reader, rawURL string) io.Reader {
+	if c.maxResponseSize <= 0 {
+		return body
+	}
+	return &limitReader{r: body, url: rawURL, limit: c.maxResponseSize}
+}
+
+// StatusError is returned for non-retryable HTTP error responses (4xx other than 429).
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP %s %s: status %d: %s", e.Method, e.URL, e.StatusCode, string(e.Body))
+}
+
 // limiterForHost returns the per-host rate limiter, creating one if needed.
-func (c *Client) limiterForHost(host string) *rate.Limiter {
-	c.mu.RLock()
-	lim, ok := c.hostLimiters[host]
-	c.mu.RUnlock()
+func (c *Client) limiterForHost(host string) *hostLimiter {
+	c.hosts.mu.RLock()
+	lim, ok := c.hosts.limiters[host]
+	c.hosts.mu.RUnlock()
 	if ok {
 		return lim
 	}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.hosts.mu.Lock()
+	defer c.hosts.mu.Unlock()
 	// Double-check after acquiring write lock.
-	if lim, ok = c.hostLimiters[host]; ok {
+	if lim, ok = c.hosts.limiters[host]; ok {
 		return lim
 	}
-	lim = rate.NewLimiter(rate.Limit(c.defaultRPS), 1)
-	c.hostLimiters[host] = lim
+	lim = newHostLimiter(c.defaultRPS, c.burst, c.warmup)
+	c.hosts.limiters[host] = lim
 	return lim
 }
 
+// breakerForHost returns the per-host circuit breaker, or nil when disabled.
+func (c *Client) breakerForHost(host string) *hostBreaker {
+	if c.breakerThreshold <= 0 {
+		return nil
+	}
+
+	c.hosts.mu.RLock()
+	b, ok := c.hosts.breakers[host]
+	c.hosts.mu.RUnlock()
+	if ok {
+		return b
+	}
+
+	c.hosts.mu.Lock()
+	defer c.hosts.mu.Unlock()
+	if b, ok = c.hosts.breakers[host]; ok {
+		return b
+	}
+	b = newHostBreaker(host, c.breakerThreshold, c.breakerCooldown)
+	c.hosts.breakers[host] = b
+	return b
+}
+
+// request describes a single logical request, which may be attempted
+// several times.
+type request struct {
+	method  string
+	url     string
+	headers map[string]string
+	body    []byte
+	timeout *time.Duration // from RequestTimeout; nil uses the client's
+
+	// stream, when set, is handed the body of a successful response that
+	// isn't to be cached, instead of the body being buffered.
+	stream func(io.Reader) error
+}
+
+// RequestOption adjusts a single request.
+type RequestOption func(*request)
+
+// RequestTimeout bounds each attempt of this request instead of the
+// client's and host's timeouts; zero disables it.
+func RequestTimeout(d time.Duration) RequestOption {
+	return func(r *request) { r.timeout = &d }
+}
+
+// timeoutFor returns the timeout of each attempt of r to host.
+func (c *Client) timeoutFor(r *request, u *url.URL) time.Duration {
+	if r.timeout != nil {
+		return *r.timeout
+	}
+	if d, ok := c.hostTimeouts[u.Host]; ok {
+		return d
+	}
+	if d, ok := c.hostTimeouts[u.Hostname()]; ok {
+		return d
+	}
+	return c.timeout
+}
+
+// cacheable reports whether responses to r may be cached. Only GETs are:
+// POSTs are used for probes and queries that must reach the server.
+func (r *request) cacheable() bool {
+	return r.method == http.MethodGet
+}
+
 // Get performs an HTTP GET with per-host rate limiting, caching, and retry.
-func (c *Client) Get(ctx context.Context, rawURL string, headers map[string]string) (*Response, error) {
+func (c *Client) Get(ctx context.Context, rawURL string, headers map[string]string, opts ...RequestOption) (*Response, error) {
+	return c.do(ctx, newRequest(http.MethodGet, rawURL, headers, nil, opts))
+}
+
+// Post performs an HTTP POST with per-host rate limiting, circuit breaking,
+// and retry. Responses are never cached.
+func (c *Client) Post(ctx context.Context, rawURL string, headers map[string]string, body []byte, opts ...RequestOption) (*Response, error) {
+	return c.do(ctx, newRequest(http.MethodPost, rawURL, headers, body, opts))
+}
+
+// GetStream performs a GET like Get, but hands the body of a successful
+// response to fn instead of returning it, so a large response can be
+// decoded as it arrives. Responses the client caches are buffered first.
+// An error from fn is returned as is and the request is not retried.
+func (c *Client) GetStream(ctx context.Context, rawURL string, headers map[string]string, fn func(io.Reader) error, opts ...RequestOption) error {
+	r := newRequest(http.MethodGet, rawURL, headers, nil, opts)
+	streamed := false
+	r.stream = func(body io.Reader) error {
+		streamed = true
+		return fn(body)
+	}
+	resp, err := c.do(ctx, r)
+	if err != nil || streamed {
+		return err
+	}
+	return fn(bytes.NewReader(resp.Body))
+}
+
+func newRequest(method, rawURL string, headers map[string]string, body []byte, opts []RequestOption) *request {
+	r := &request{method: method, url: rawURL, headers: headers, body: body}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (c *Client) do(ctx context.Context, r *request) (*Response, error) {
+	rawURL := r.url
+
 	// Check cache first (before rate-limiting or retrying).
 	var staleEntry *cache.Entry
-	if c.cache != nil && !c.noCache {
-		entry, fresh := c.cache.Get(rawURL)
+	if r.cacheable() && c.cache != nil && !c.noCache {
+		entry, fresh := c.cache.Get(c.cacheKey(rawURL))
 		if fresh {
 			return &Response{Body: entry.Body, StatusCode: entry.StatusCode, FromCache: true}, nil
 		}
@@ -130,6 +395,7 @@ func (c *Client) Get(ctx context.Context, rawURL string, headers map[string]stri
 		return nil, fmt.Errorf("parsing URL: %w", err)
 	}
 	lim := c.limiterForHost(parsed.Host)
+	breaker := c.breakerForHost(parsed.Host)
 
 	var lastErr error
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
@@ -137,27 +403,49 @@ func (c *Client) Get(ctx context.Context, rawURL string, headers map[string]stri
 			slog.Debug("retrying request", "url", rawURL, "attempt", attempt)
 		}
 
+		trial, err := breaker.Allow()
+		if err != nil {
+			return nil, err
+		}
+
 		if err := lim.Wait(ctx); err != nil {
+			breaker.Release(trial)
 			return nil, fmt.Errorf("rate limit wait: %w", err)
 		}
 
-		resp, err := c.doRequest(ctx, rawURL, headers, staleEntry)
+		resp, err := c.doRequest(ctx, r, c.timeoutFor(r, parsed), staleEntry)
 		if err == nil {
+			breaker.Success()
 			return resp, nil
 		}
 
 		var retryErr *retryableError
 		if !errors.As(err, &retryErr) {
+			switch {
+			case isTransportError(ctx, err):
+				breaker.Failure()
+			case ctx.Err() != nil:
+				breaker.Release(trial) // cancelled: says nothing about the host
+			default:
+				// The host answered, with a 4xx, a body too large or one
+				// that didn't decode.
+				breaker.Success()
+			}
 			return nil, err // Non-retryable error.
 		}
 
+		breaker.Failure()
 		lastErr = retryErr
+		if retryErr.statusCode == http.StatusTooManyRequests {
+			lim.Throttle()
+		}
 
 		// Determine backoff.
 		backoff := retryErr.retryAfter
 		if backoff == 0 {
 			backoff = c.baseBackoff * time.Duration(math.Pow(2, float64(attempt)))
 		}
+		backoff = withJitter(backoff, c.jitter)
 
 		slog.Warn("retryable error, backing off",
 			"url", rawURL,
@@ -176,14 +464,34 @@ func (c *Client) Get(ctx context.Context, rawURL string, headers map[string]stri
 	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
-// doRequest performs a single HTTP GET request.
-func (c *Client) doRequest(ctx context.Context, rawURL string, headers map[string]string, staleEntry *cache.Entry) (*Response, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+// doRequest performs a single HTTP request attempt within timeout.
+func (c *Client) doRequest(ctx context.Context, r *request, timeout time.Duration, staleEntry *cache.Entry) (*Response, error) {
+	rawURL := r.url
+	var body io.Reader
+	if r.body != nil {
+		body = bytes.NewReader(r.body)
+	}
+	attemptCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	timedOut := func(err error) error {
+		if ctx.Err() == nil && attemptCtx.Err() != nil {
+			return fmt.Errorf("HTTP %s %s: timed out after %s: %w", r.method, rawURL, timeout, err)
+		}
+		return nil
+	}
+	req, err := http.NewRequestWithContext(attemptCtx, r.method, rawURL, body)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	for k, v := range headers {
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	for k, v := range r.headers {
 		req.Header.Set(k, v)
 	}
 
@@ -199,20 +507,40 @@ func (c *Client) doRequest(ctx context.Context, rawURL string, headers map[strin
 
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP GET %s: %w", rawURL, err)
+		if terr := timedOut(err); terr != nil {
+			return nil, terr
+		}
+		return nil, fmt.Errorf("HTTP %s %s: %w", r.method, rawURL, err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	// Not modified — refresh cache TTL.
 	if resp.StatusCode == http.StatusNotModified && staleEntry != nil {
 		if c.cache != nil {
-			_ = c.cache.Set(rawURL, staleEntry)
+			_ = c.cache.Set(c.cacheKey(rawURL), staleEntry)
 		}
 		return &Response{Body: staleEntry.Body, StatusCode: staleEntry.StatusCode, FromCache: true}, nil
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	if r.stream != nil && resp.StatusCode < 300 && (!r.cacheable() || c.cache == nil || c.noCache) {
+		if err := r.stream(c.limitBody(resp.Body, rawURL)); err != nil {
+			if terr := timedOut(err); terr != nil {
+				return nil, terr
+			}
+			return nil, err
+		}
+		return &Response{StatusCode: resp.StatusCode}, nil
+	}
+
+	respBody, err := io.ReadAll(c.limitBody(resp.Body, rawURL))
+	var tooLarge *ResponseTooLargeError
+	if errors.As(err, &tooLarge) {
+		return nil, tooLarge
+	}
 	if err != nil {
+		if terr := timedOut(err); terr != nil {
+			return nil, terr
+		}
 		return nil, fmt.Errorf("reading response body: %w", err)
 	}
 
@@ -222,7 +550,7 @@ func (c *Client) doRequest(ctx context.Context, rawURL string, headers map[strin
 		return nil, &retryableError{
 			statusCode: resp.StatusCode,
 			retryAfter: ra,
-			err:        fmt.Errorf("HTTP GET %s: status 429: %s", rawURL, string(body)),
+			err:        fmt.Errorf("HTTP %s %s: status 429: %s", r.method, rawURL, string(respBody)),
 		}
 	}
 
@@ -230,26 +558,51 @@ func (c *Client) doRequest(ctx context.Context, rawURL string, headers map[strin
 	if resp.StatusCode >= 500 {
 		return nil, &retryableError{
 			statusCode: resp.StatusCode,
-			err:        fmt.Errorf("HTTP GET %s: status %d: %s", rawURL, resp.StatusCode, string(body)),
+			err:        fmt.Errorf("HTTP %s %s: status %d: %s", r.method, rawURL, resp.StatusCode, string(respBody)),
 		}
 	}
 
 	// Other 4xx — non-retryable.
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP GET %s: status %d: %s", rawURL, resp.StatusCode, string(body))
+		return nil, &StatusError{Method: r.method, URL: rawURL, StatusCode: resp.StatusCode, Body: respBody}
 	}
 
 	// Store in cache.
-	if c.cache != nil && !c.noCache {
-		_ = c.cache.Set(rawURL, &cache.Entry{
-			Body:       body,
+	if r.cacheable() && c.cache != nil && !c.noCache {
+		_ = c.cache.Set(c.cacheKey(rawURL), &cache.Entry{
+			Body:       respBody,
 			ETag:       resp.Header.Get("ETag"),
 			LastMod:    resp.Header.Get("Last-Modified"),
 			StatusCode: resp.StatusCode,
 		})
 	}
 
-	return &Response{Body: body, StatusCode: resp.StatusCode}, nil
+	return &Response{Body: respBody, StatusCode: resp.StatusCode}, nil
+}
+
+// cacheKey returns the cache key for a GET of rawURL. Client-level headers
+// are part of the key because they can change the response (beta flags
+// expose extra models); per-request headers such as API keys are not.
+func (c *Client) cacheKey(rawURL string) string {
+	if len(c.headers) == 0 {
+		return rawURL
+	}
+	lines := make([]string, 0, len(c.headers))
+	for k, v := range c.headers {
+		lines = append(lines, strings.ToLower(k)+": "+v)
+	}
+	sort.Strings(lines)
+	return rawURL + "\n" + strings.Join(lines, "\n")
+}
+
+// isTransportError reports whether err is a connection-level failure rather
+// than an HTTP status or the caller's context ending.
+func isTransportError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
 }
 
 // parseRetryAfter parses the Retry-After header value.
@@ -0,0 +1,236 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/cache"
+)
+
+type modelList struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+func TestGetJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer k" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"gpt-5"},{"id":"gpt-4o"}]}`))
+	}))
+	defer srv.Close()
+
+	c := New(WithRateLimit(1000))
+	got, err := GetJSON[modelList](context.Background(), c, srv.URL, map[string]string{"Authorization": "Bearer k"})
+	if err != nil {
+		t.Fatalf("GetJSON: %v", err)
+	}
+	if len(got.Data) != 2 || got.Data[0].ID != "gpt-5" {
+		t.Errorf("unexpected result: %+v", got)
+	}
+}
+
+func TestPostJSONSendsBodyAndRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		var req struct {
+			Model string `json:"model"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &req); err != nil || req.Model != "gpt-5" {
+			t.Errorf("unexpected body %s", body)
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	c := New(WithRateLimit(1000), WithBaseBackoff(time.Millisecond))
+	got, err := PostJSON[struct {
+		OK bool `json:"ok"`
+	}](context.Background(), c, srv.URL, nil, map[string]string{"model": "gpt-5"})
+	if err != nil {
+		t.Fatalf("PostJSON: %v", err)
+	}
+	if !got.OK {
+		t.Error("expected ok response")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("server called %d times, want 2 (one retry)", calls.Load())
+	}
+}
+
+func TestPostIsNotCached(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	fc, err := cache.New(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(WithRateLimit(1000), WithCache(fc))
+
+	for range 2 {
+		if _, err := c.Post(context.Background(), srv.URL, nil, []byte(`{}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range 2 {
+		if _, err := c.Get(context.Background(), srv.URL, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server called %d times, want 3 (2 POSTs + 1 uncached GET)", got)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("no such model"))
+	}))
+	defer srv.Close()
+
+	_, err := New(WithRateLimit(1000)).Get(context.Background(), srv.URL, nil)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected *StatusError, got %v", err)
+	}
+	if statusErr.StatusCode != http.StatusNotFound || string(statusErr.Body) != "no such model" {
+		t.Errorf("unexpected error: %+v", statusErr)
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Anthropic-Beta") + "|" + r.Header.Get("X-Team")))
+	}))
+	defer srv.Close()
+
+	fc, err := cache.New(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := New(WithRateLimit(1000), WithCache(fc))
+	beta := base.WithHeaders(map[string]string{"anthropic-beta": "models-2025", "X-Team": "infra"})
+
+	ctx := context.Background()
+	resp, err := beta.Get(ctx, srv.URL, map[string]string{"X-Team": "override"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(resp.Body); got != "models-2025|override" {
+		t.Errorf("beta client body = %q, want per-request header to win", got)
+	}
+
+	// The plain client must not be served the beta client's cached response.
+	resp, err = base.Get(ctx, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.FromCache || string(resp.Body) != "|" {
+		t.Errorf("base client got %q (from cache: %v), want an uncached response without headers", resp.Body, resp.FromCache)
+	}
+
+	if beta.hosts != base.hosts {
+		t.Error("derived client should share per-host limiters and breakers")
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name        string
+		opts        []Option
+		reqOpts     []RequestOption
+		wantTimeout bool
+	}{
+		{"client timeout", []Option{WithTimeout(20 * time.Millisecond)}, nil, true},
+		{"no timeout", []Option{WithTimeout(0)}, nil, false},
+		{"host override", []Option{WithTimeout(20 * time.Millisecond), WithHostTimeout("127.0.0.1", time.Second)}, nil, false},
+		{"other host", []Option{WithTimeout(20 * time.Millisecond), WithHostTimeout("docs.example.com", time.Second)}, nil, true},
+		{"request override", []Option{WithTimeout(time.Second), WithHostTimeout("127.0.0.1", time.Second)}, []RequestOption{RequestTimeout(20 * time.Millisecond)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(append([]Option{WithRateLimit(1000), WithMaxRetries(0), WithIdleConns(4, time.Minute)}, tt.opts...)...)
+			_, err := c.Get(context.Background(), srv.URL, nil, tt.reqOpts...)
+			if gotTimeout := err != nil && strings.Contains(err.Error(), "timed out after 20ms"); gotTimeout != tt.wantTimeout {
+				t.Errorf("err = %v, want timeout %v", err, tt.wantTimeout)
+			}
+			if !tt.wantTimeout && err != nil {
+				t.Errorf("err = %v", err)
+			}
+		})
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		limit   int64
+		stream  bool
+		wantErr bool
+	}{
+		{"under limit", 100, false, false},
+		{"over limit", 99, false, true},
+		{"no limit", 0, false, false},
+		{"streamed over limit", 99, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(WithRateLimit(1000), WithMaxRetries(0), WithMaxResponseSize(tt.limit))
+			var err error
+			if tt.stream {
+				err = c.GetStream(context.Background(), srv.URL, nil, func(body io.Reader) error {
+					_, err := io.ReadAll(body)
+					return err
+				})
+			} else {
+				_, err = c.Get(context.Background(), srv.URL, nil)
+			}
+			var tooLarge *ResponseTooLargeError
+			if errors.As(err, &tooLarge) != tt.wantErr {
+				t.Fatalf("err = %v, want too large %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package httpclient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GitHubLimits configures NewGitHubTransport.
+type GitHubLimits struct {
+	// WriteInterval is the minimum gap between requests that create or
+	// change content (POST, PATCH, PUT, DELETE). GitHub asks for at least a
+	// second to stay clear of secondary rate limits.
+	WriteInterval time.Duration
+	// MaxRetries is how often a rate-limited request is retried.
+	MaxRetries int
+	// MaxWait caps a single wait. A limit that resets later than this fails
+	// the request instead of stalling the run.
+	MaxWait time.Duration
+	// SecondaryBackoff is the first wait after a secondary rate limit
+	// response without a Retry-After header; it doubles on each retry.
+	// GitHub documents at least a minute. Defaults to 60s.
+	SecondaryBackoff time.Duration
+}
+
+// NewGitHubTransport returns an http.RoundTripper for the GitHub API that
+// spaces out content-creating requests and retries responses that signal
+// the primary or secondary (abuse detection) rate limit, after the wait
+// GitHub asks for. GitHub Enterprise proxies send the same signals.
+func NewGitHubTransport(next http.RoundTripper, limits GitHubLimits) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if limits.SecondaryBackoff <= 0 {
+		limits.SecondaryBackoff = time.Minute
+	}
+	return &githubTransport{next: next, limits: limits}
+}
+
+type githubTransport struct {
+	next   http.RoundTripper
+	limits GitHubLimits
+
+	mu        sync.Mutex
+	lastWrite time.Time
+}
+
+func (t *githubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if isWrite(req.Method) {
+			if err := t.paceWrite(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		wait, limited := t.rateLimitWait(resp, attempt)
+		if !limited || attempt >= t.limits.MaxRetries {
+			return resp, nil
+		}
+		if t.limits.MaxWait > 0 && wait > t.limits.MaxWait {
+			slog.Warn("GitHub rate limit resets too late, giving up",
+				"url", req.URL.String(), "wait", wait, "max_wait", t.limits.MaxWait)
+			return resp, nil
+		}
+		// A body that can't be replayed can't be retried.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		slog.Warn("GitHub rate limit hit, backing off",
+			"method", req.Method, "url", req.URL.String(), "status", resp.StatusCode,
+			"wait", wait, "attempt", attempt+1, "max_retries", t.limits.MaxRetries)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewinding request body: %w", err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// paceWrite waits until WriteInterval has passed since the previous write.
+func (t *githubTransport) paceWrite(req *http.Request) error {
+	t.mu.Lock()
+	wait := time.Until(t.lastWrite.Add(t.limits.WriteInterval))
+	if wait < 0 {
+		wait = 0
+	}
+	t.lastWrite = time.Now().Add(wait)
+	t.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// rateLimitWait reports whether resp is a rate limit response and how long
+// to wait before retrying. In order of preference: Retry-After, the primary
+// limit's reset time, then exponential backoff for secondary limits.
+func (t *githubTransport) rateLimitWait(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if ra := parseRetryAfter(resp.Header.Get("Retry-After")); ra > 0 {
+		return ra, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Until(time.Unix(reset, 0)), 0) + time.Second, true
+		}
+	}
+
+	// A 403 is also what a missing permission looks like; only the body
+	// tells a secondary rate limit apart.
+	if resp.StatusCode == http.StatusForbidden && !secondaryLimitBody(resp) {
+		return 0, false
+	}
+	return t.limits.SecondaryBackoff << attempt, true
+}
+
+// secondaryLimitBody checks the error message of a 403 for GitHub's
+// secondary rate limit wording, leaving the body readable for the caller.
+func secondaryLimitBody(resp *http.Response) bool {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	msg := strings.ToLower(string(body))
+	return strings.Contains(msg, "secondary rate limit") || strings.Contains(msg, "abuse")
+}
+
+func isWrite(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGitHubTransportRetriesRateLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		limited   func(w http.ResponseWriter)
+		wantCalls int32
+	}{
+		{
+			name: "secondary limit",
+			limited: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message":"You have exceeded a secondary rate limit."}`))
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "retry after",
+			limited: func(w http.ResponseWriter) {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "primary limit reset",
+			limited: func(w http.ResponseWriter) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+				w.WriteHeader(http.StatusForbidden)
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "missing permission",
+			limited: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+			},
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != `{"title":"x"}` {
+					t.Errorf("attempt %d got body %q", calls.Load()+1, body)
+				}
+				if calls.Add(1) == 1 {
+					tt.limited(w)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer srv.Close()
+
+			client := &http.Client{Transport: NewGitHubTransport(nil, GitHubLimits{MaxRetries: 3, SecondaryBackoff: time.Millisecond})}
+			resp, err := client.Post(srv.URL, "application/json", strings.NewReader(`{"title":"x"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", got, tt.wantCalls)
+			}
+			if tt.wantCalls == 1 && resp.StatusCode != http.StatusForbidden {
+				t.Errorf("status = %d, the 403 should be passed through", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestGitHubTransportGivesUpOnLongWaits(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewGitHubTransport(nil, GitHubLimits{MaxRetries: 3, MaxWait: time.Minute})}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if calls.Load() != 1 || resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("got %d calls and status %d, want the 429 returned without waiting an hour", calls.Load(), resp.StatusCode)
+	}
+}
+
+func TestGitHubTransportPacesWrites(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewGitHubTransport(nil, GitHubLimits{WriteInterval: 50 * time.Millisecond})}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Post(srv.URL, "application/json", strings.NewReader(`{}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	// Reads aren't paced.
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond || elapsed > time.Second {
+		t.Errorf("three writes took %v, want about two intervals", elapsed)
+	}
+}
@@ -0,0 +1,123 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// GetJSON performs a GET through c and decodes the response body into T.
+func GetJSON[T any](ctx context.Context, c *Client, rawURL string, headers map[string]string, opts ...RequestOption) (*T, error) {
+	resp, err := c.Get(ctx, rawURL, headers, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[T](resp, rawURL)
+}
+
+// PostJSON encodes body as JSON, POSTs it through c and decodes the response
+// body into T. Content-Type defaults to application/json.
+func PostJSON[T any](ctx context.Context, c *Client, rawURL string, headers map[string]string, body any, opts ...RequestOption) (*T, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	h := make(map[string]string, len(headers)+1)
+	h["Content-Type"] = "application/json"
+	for k, v := range headers {
+		h[k] = v
+	}
+
+	resp, err := c.Post(ctx, rawURL, h, data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[T](resp, rawURL)
+}
+
+func decodeJSON[T any](resp *Response, rawURL string) (*T, error) {
+	var v T
+	if err := json.Unmarshal(resp.Body, &v); err != nil {
+		return nil, fmt.Errorf("decoding response from %s: %w", rawURL, err)
+	}
+	return &v, nil
+}
+
+// StreamJSONArray performs a GET through c and calls fn with each element of
+// the JSON array at the top-level key of the response, decoding one element
+// at a time rather than the whole response. An empty key means the response
+// is itself an array. A response without key, or with key null, has no
+// elements. An error from fn stops the decode and is returned as is.
+func StreamJSONArray[T any](ctx context.Context, c *Client, rawURL string, headers map[string]string, key string, fn func(T) error, opts ...RequestOption) error {
+	var fnErr error
+	err := c.GetStream(ctx, rawURL, headers, func(body io.Reader) error {
+		return decodeArray(body, key, func(v T) error {
+			fnErr = fn(v)
+			return fnErr
+		})
+	}, opts...)
+	var tooLarge *ResponseTooLargeError
+	if err != nil && fnErr == nil && !errors.As(err, &tooLarge) {
+		return fmt.Errorf("decoding response from %s: %w", rawURL, err)
+	}
+	return err
+}
+
+func decodeArray[T any](r io.Reader, key string, fn func(T) error) error {
+	dec := json.NewDecoder(r)
+	if key != "" {
+		if err := expectDelim(dec, '{'); err != nil {
+			return err
+		}
+		for {
+			if !dec.More() {
+				return nil
+			}
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if tok == key {
+				break
+			}
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("expected an array, got %v", tok)
+	}
+	for dec.More() {
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("expected %v, got %v", want, tok)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/cache"
+)
+
+func TestStreamJSONArray(t *testing.T) {
+	type item struct {
+		ID string `json:"id"`
+	}
+	tests := []struct {
+		name    string
+		body    string
+		key     string
+		want    []string
+		wantErr bool
+	}{
+		{"keyed", `{"object":"list","meta":{"data":[{"id":"no"}]},"data":[{"id":"a"},{"id":"b"}],"more":true}`, "data", []string{"a", "b"}, false},
+		{"top-level array", `[{"id":"a"}]`, "", []string{"a"}, false},
+		{"missing key", `{"error":{"message":"nope"}}`, "data", nil, false},
+		{"null", `{"data":null}`, "data", nil, false},
+		{"not an array", `{"data":{"id":"a"}}`, "data", nil, true},
+		{"truncated", `{"data":[{"id":"a"},{"id":`, "data", []string{"a"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			fc, err := cache.New(t.TempDir(), time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Streamed straight from the response, then buffered through the cache.
+			for _, c := range []*Client{New(WithRateLimit(1000)), New(WithRateLimit(1000), WithCache(fc))} {
+				var got []string
+				err := StreamJSONArray(context.Background(), c, srv.URL, nil, tt.key, func(v item) error {
+					got = append(got, v.ID)
+					return nil
+				})
+				if (err != nil) != tt.wantErr {
+					t.Errorf("err = %v, want error %v", err, tt.wantErr)
+				}
+				if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+					t.Errorf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamJSONArrayStops(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[1, 2, 3]`))
+	}))
+	defer srv.Close()
+
+	stop := errors.New("stop")
+	n := 0
+	err := StreamJSONArray(context.Background(), New(WithRateLimit(1000)), srv.URL, nil, "", func(v int) error {
+		n++
+		return stop
+	})
+	if err != stop || n != 1 {
+		t.Errorf("err = %v after %d elements, want stop after 1", err, n)
+	}
+}
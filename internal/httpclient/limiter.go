@@ -0,0 +1,93 @@
+package httpclient
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// throttleFactor is the fraction of the current rate a host drops to after a 429.
+const throttleFactor = 0.5
+
+// minRateFraction keeps repeated 429s from driving a host's rate to zero.
+const minRateFraction = 0.05
+
+// hostLimiter is a per-host token bucket that slows down after a 429 and
+// ramps linearly back to its configured rate over the warm-up period.
+type hostLimiter struct {
+	lim    *rate.Limiter
+	base   rate.Limit
+	warmup time.Duration
+	now    func() time.Time
+
+	mu          sync.Mutex
+	throttledAt time.Time
+	floor       rate.Limit
+}
+
+func newHostLimiter(rps float64, burst int, warmup time.Duration) *hostLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &hostLimiter{
+		lim:    rate.NewLimiter(rate.Limit(rps), burst),
+		base:   rate.Limit(rps),
+		warmup: warmup,
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until the host's bucket allows another request.
+func (h *hostLimiter) Wait(ctx context.Context) error {
+	h.mu.Lock()
+	if limit := h.currentLimit(); limit != h.lim.Limit() {
+		h.lim.SetLimit(limit)
+	}
+	h.mu.Unlock()
+	return h.lim.Wait(ctx)
+}
+
+// Throttle records a 429: the rate drops to a fraction of its current value
+// and warms back up from there. It is a no-op when warm-up is disabled.
+func (h *hostLimiter) Throttle() {
+	if h.warmup <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	floor := h.currentLimit() * throttleFactor
+	if min := h.base * minRateFraction; floor < min {
+		floor = min
+	}
+	h.floor = floor
+	h.throttledAt = h.now()
+	h.lim.SetLimit(floor)
+}
+
+// currentLimit returns the rate for this moment of the warm-up ramp.
+// Callers must hold h.mu.
+func (h *hostLimiter) currentLimit() rate.Limit {
+	if h.throttledAt.IsZero() || h.warmup <= 0 {
+		return h.base
+	}
+	elapsed := h.now().Sub(h.throttledAt)
+	if elapsed >= h.warmup {
+		h.throttledAt = time.Time{}
+		return h.base
+	}
+	progress := rate.Limit(float64(elapsed) / float64(h.warmup))
+	return h.floor + (h.base-h.floor)*progress
+}
+
+// withJitter adds up to fraction×d of random delay so parallel syncs that
+// hit the same host don't retry in lockstep.
+func withJitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || d <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Float64()*fraction*float64(d))
+}
@@ -0,0 +1,73 @@
+package httpclient
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestHostLimiterWarmup(t *testing.T) {
+	now := time.Unix(0, 0)
+	h := newHostLimiter(10, 5, 10*time.Second)
+	h.now = func() time.Time { return now }
+
+	if got := h.lim.Burst(); got != 5 {
+		t.Errorf("burst = %d, want 5", got)
+	}
+
+	h.Throttle()
+	if got := h.lim.Limit(); got != 5 {
+		t.Fatalf("limit after 429 = %v, want 5", got)
+	}
+
+	tests := []struct {
+		elapsed time.Duration
+		want    rate.Limit
+	}{
+		{0, 5},
+		{5 * time.Second, 7.5},
+		{10 * time.Second, 10},
+		{20 * time.Second, 10},
+	}
+	for _, tt := range tests {
+		now = time.Unix(0, 0).Add(tt.elapsed)
+		h.mu.Lock()
+		got := h.currentLimit()
+		h.mu.Unlock()
+		if got != tt.want {
+			t.Errorf("after %v: limit = %v, want %v", tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+func TestHostLimiterRepeatedThrottleHasFloor(t *testing.T) {
+	h := newHostLimiter(10, 1, time.Minute)
+	for range 20 {
+		h.Throttle()
+	}
+	if got := h.lim.Limit(); got != 0.5 {
+		t.Errorf("limit after repeated 429s = %v, want floor 0.5", got)
+	}
+}
+
+func TestHostLimiterWarmupDisabled(t *testing.T) {
+	h := newHostLimiter(10, 1, 0)
+	h.Throttle()
+	if got := h.lim.Limit(); got != 10 {
+		t.Errorf("limit = %v, want unchanged 10 when warm-up disabled", got)
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	d := time.Second
+	for range 100 {
+		got := withJitter(d, 0.2)
+		if got < d || got > d+200*time.Millisecond {
+			t.Fatalf("withJitter(%v, 0.2) = %v, out of range", d, got)
+		}
+	}
+	if got := withJitter(d, 0); got != d {
+		t.Errorf("withJitter with zero fraction = %v, want %v", got, d)
+	}
+}
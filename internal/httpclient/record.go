@@ -0,0 +1,213 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Fixture is a recorded HTTP exchange, stored as one JSON file per request
+// under <dir>/<host>/. Bodies that are JSON are stored as JSON, so fixtures
+// read and diff like the provider's responses.
+type Fixture struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"` // with credentials in the query redacted
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	JSON   json.RawMessage `json:"json,omitempty"`
+	Body   string          `json:"body,omitempty"` // set when the body isn't JSON
+}
+
+// fixtureHeaders are the response headers kept in fixtures: the ones
+// parsing depends on. Cookies and account identifiers stay out.
+var fixtureHeaders = []string{"Content-Type", "Link"}
+
+// secretParams are query parameters redacted from fixture URLs and keys,
+// so a recording made with a key in the URL replays without one.
+var secretParams = []string{"key", "api_key", "apikey", "access_token", "token"}
+
+// WithRecorder saves every response to a fixture in dir, for WithReplay.
+// Caching is disabled so every request is recorded. Rate limited and
+// server error responses are retried rather than recorded.
+func WithRecorder(dir string) Option {
+	return func(cl *Client) {
+		next := cl.http.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		cl.http.Transport = &recordTransport{next: next, dir: dir}
+		cl.noCache = true
+	}
+}
+
+// WithReplay answers requests from fixtures recorded in dir, without any
+// network access. A request that wasn't recorded fails with
+// *FixtureMissingError.
+func WithReplay(dir string) Option {
+	return func(cl *Client) {
+		cl.http.Transport = &replayTransport{dir: dir}
+		cl.noCache = true
+	}
+}
+
+// FixtureMissingError is returned in replay mode for a request that has no
+// fixture.
+type FixtureMissingError struct {
+	Method string
+	URL    string
+	Path   string
+}
+
+func (e *FixtureMissingError) Error() string {
+	return fmt.Sprintf("no recorded response for %s %s (expected %s)", e.Method, e.URL, e.Path)
+}
+
+type recordTransport struct {
+	next http.RoundTripper
+	dir  string
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	f := Fixture{Method: req.Method, URL: redactURL(req.URL), Status: resp.StatusCode, Header: http.Header{}}
+	for _, h := range fixtureHeaders {
+		if v := resp.Header.Values(h); len(v) > 0 {
+			f.Header[h] = v
+		}
+	}
+	var indented bytes.Buffer
+	if json.Valid(body) && json.Indent(&indented, body, "", "  ") == nil {
+		f.JSON = indented.Bytes()
+	} else {
+		f.Body = string(body)
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fixturePath(t.dir, req, reqBody)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("recording %s: %w", req.URL.Redacted(), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return nil, fmt.Errorf("recording %s: %w", req.URL.Redacted(), err)
+	}
+	return resp, nil
+}
+
+type replayTransport struct {
+	dir string
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	path := fixturePath(t.dir, req, reqBody)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, &FixtureMissingError{Method: req.Method, URL: redactURL(req.URL), Path: path}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("reading fixture %s: %w", path, err)
+	}
+
+	body := []byte(f.Body)
+	if len(f.JSON) > 0 {
+		body = f.JSON
+	}
+	header := f.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+		StatusCode:    f.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody returns the request body and leaves it readable again.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// fixturePath names the fixture of a request after its host, method and
+// path, with a hash of the redacted URL and body to tell apart requests
+// that differ only in query or body. Request headers are not part of the
+// name: they carry the credentials.
+func fixturePath(dir string, req *http.Request, body []byte) string {
+	u := redactURL(req.URL)
+	sum := sha256.Sum256([]byte(req.Method + " " + u + "\n" + string(body)))
+
+	name := strings.Trim(unsafePathChars.ReplaceAllString(req.URL.Path, "_"), "_")
+	if len(name) > 80 {
+		name = name[:80]
+	}
+	if name == "" {
+		name = "root"
+	}
+	host := unsafePathChars.ReplaceAllString(req.URL.Host, "_")
+	return filepath.Join(dir, host, fmt.Sprintf("%s-%s-%s.json", strings.ToLower(req.Method), name, hex.EncodeToString(sum[:4])))
+}
+
+// redactURL returns u with its credentials removed and its query sorted.
+func redactURL(u *url.URL) string {
+	cp := *u
+	cp.User = nil
+	q := cp.Query()
+	for _, p := range secretParams {
+		if q.Has(p) {
+			q.Set(p, "REDACTED")
+		}
+	}
+	cp.RawQuery = q.Encode() // sorted by key
+	return cp.String()
+}
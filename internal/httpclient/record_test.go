@@ -0,0 +1,86 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/models":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Set-Cookie", "session=abc")
+			_, _ = w.Write([]byte(`{"data":[{"id":"` + r.URL.Query().Get("page") + `"}]}`))
+		case "/pricing":
+			_, _ = w.Write([]byte("<table><tr><td>$1</td></tr></table>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	get := func(c *Client, path string) (string, error) {
+		resp, err := c.Get(ctx, srv.URL+path, map[string]string{"Authorization": "Bearer sk-secret"})
+		if err != nil {
+			return "", err
+		}
+		return string(resp.Body), nil
+	}
+
+	rec := New(WithRateLimit(1000), WithRecorder(dir))
+	var want []string
+	for _, path := range []string{"/v1/models?page=1&key=sk-secret", "/v1/models?page=2&key=sk-secret", "/pricing"} {
+		body, err := get(rec, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, body)
+	}
+	var status *StatusError
+	if _, err := get(rec, "/gone"); !errors.As(err, &status) || status.StatusCode != 404 {
+		t.Fatalf("recording /gone: got %v, want 404", err)
+	}
+	srv.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	if len(files) != 4 {
+		t.Fatalf("recorded %d fixtures, want 4: %v", len(files), files)
+	}
+	for _, f := range files {
+		data, _ := os.ReadFile(f)
+		for _, secret := range []string{"sk-secret", "session=abc"} {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("%s contains %q:\n%s", f, secret, data)
+			}
+		}
+	}
+
+	// Replay works offline and with a different key.
+	replay := New(WithRateLimit(1000), WithReplay(dir))
+	for i, path := range []string{"/v1/models?key=other&page=1", "/v1/models?page=2&key=", "/pricing"} {
+		body, err := get(replay, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// JSON comes back indented, as stored.
+		if strings.Join(strings.Fields(body), "") != want[i] {
+			t.Errorf("replay %s = %q, want %q", path, body, want[i])
+		}
+	}
+	if _, err := get(replay, "/gone"); !errors.As(err, &status) || status.StatusCode != 404 {
+		t.Errorf("replaying /gone: got %v, want 404", err)
+	}
+
+	var missing *FixtureMissingError
+	if _, err := get(replay, "/v1/models?page=3"); !errors.As(err, &missing) {
+		t.Errorf("unrecorded request: got %v, want FixtureMissingError", err)
+	}
+}
@@ -0,0 +1,126 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+// csvColumns are the columns parseCSV understands. They match the output of
+// `sentinel export --format=csv`, so an exported catalog round-trips. Prices
+// are per 1K tokens and list fields are ";"-separated.
+var csvColumns = map[string]bool{
+	"provider": true, "name": true, "display_name": true, "family": true,
+	"status": true, "model_type": true, "readiness": true, "currency": true,
+	"input_per_1k": true, "output_per_1k": true, "cached_input_per_1k": true,
+	"per_image": true, "per_request": true,
+	"max_tokens": true, "max_completion_tokens": true,
+	"capabilities": true, "input_modalities": true, "output_modalities": true,
+}
+
+func parseCSV(data []byte, r *Result) error {
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("empty input")
+	}
+
+	col := make(map[string]int)
+	for i, name := range rows[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		col[name] = i
+		if !csvColumns[name] {
+			r.flag("", name, "unknown column; not mapped")
+		}
+	}
+	for _, required := range []string{"provider", "name"} {
+		if _, ok := col[required]; !ok {
+			return fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	for line, row := range rows[1:] {
+		get := func(name string) string {
+			if i, ok := col[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		ref := fmt.Sprintf("line %d", line+2)
+		provider, name := get("provider"), get("name")
+		if provider == "" || name == "" {
+			r.flag(ref, "provider/name", "empty; skipped")
+			continue
+		}
+		ref = provider + "/" + name
+
+		m := newModel(name, get("display_name"))
+		if f := get("family"); f != "" {
+			m.Family = f
+		}
+		if s := get("status"); s != "" {
+			m.Status = s
+		}
+		m.ModelType = get("model_type")
+		m.Readiness = get("readiness")
+		m.Limits.MaxTokens = csvInt(r, ref, "max_tokens", get("max_tokens"))
+		m.Limits.MaxCompletionTokens = csvInt(r, ref, "max_completion_tokens", get("max_completion_tokens"))
+		m.Capabilities = splitList(get("capabilities"))
+		m.Modalities.Input = splitList(get("input_modalities"))
+		m.Modalities.Output = splitList(get("output_modalities"))
+
+		if get("input_per_1k") != "" || get("output_per_1k") != "" {
+			m.Cost = &catalog.Cost{
+				InputPer1K:       csvFloat(r, ref, "input_per_1k", get("input_per_1k")),
+				OutputPer1K:      csvFloat(r, ref, "output_per_1k", get("output_per_1k")),
+				CachedInputPer1K: csvFloat(r, ref, "cached_input_per_1k", get("cached_input_per_1k")),
+				PerImage:         csvFloat(r, ref, "per_image", get("per_image")),
+				PerRequest:       csvFloat(r, ref, "per_request", get("per_request")),
+			}
+			if c := get("currency"); c != "" && c != catalog.DefaultCurrency {
+				m.Cost.Currency = c
+			}
+		}
+
+		r.add(provider, m)
+	}
+	return nil
+}
+
+func csvInt(r *Result, ref, field, s string) int {
+	if s == "" {
+		return 0
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		r.flag(ref, field, "not an integer: %q", s)
+	}
+	return v
+}
+
+func csvFloat(r *Result, ref, field, s string) float64 {
+	if s == "" {
+		return 0
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		r.flag(ref, field, "not a number: %q", s)
+	}
+	return v
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,265 @@
+// Package importer converts model catalogs kept in other tools' formats
+// (LiteLLM, OpenRouter, CSV) into sentinel's catalog layout. It is meant for
+// one-time migrations: anything that has no catalog equivalent is reported
+// as an Issue rather than silently dropped.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+// Supported input formats.
+const (
+	FormatLiteLLM    = "litellm"
+	FormatOpenRouter = "openrouter"
+	FormatCSV        = "csv"
+)
+
+// Formats lists the supported input formats.
+func Formats() []string {
+	return []string{FormatLiteLLM, FormatOpenRouter, FormatCSV}
+}
+
+// Options controls how imported models are mapped.
+type Options struct {
+	// Provider puts every model under this provider instead of the one
+	// derived from the source (e.g. "openrouter" to keep a router's view).
+	Provider string
+}
+
+// Issue flags source data that could not be mapped onto the catalog.
+type Issue struct {
+	Model   string // empty for format-wide issues
+	Field   string
+	Message string
+}
+
+func (i Issue) String() string {
+	if i.Model == "" {
+		return fmt.Sprintf("%s: %s", i.Field, i.Message)
+	}
+	return fmt.Sprintf("%s: %s — %s", i.Model, i.Field, i.Message)
+}
+
+// Result holds the converted models grouped by provider.
+type Result struct {
+	Models map[string][]*catalog.Model
+	Issues []Issue
+
+	opts     Options
+	seen     map[string]bool
+	unmapped map[string]int
+}
+
+func newResult(opts Options) *Result {
+	return &Result{
+		Models:   make(map[string][]*catalog.Model),
+		opts:     opts,
+		seen:     make(map[string]bool),
+		unmapped: make(map[string]int),
+	}
+}
+
+// Count returns the number of converted models.
+func (r *Result) Count() int {
+	n := 0
+	for _, models := range r.Models {
+		n += len(models)
+	}
+	return n
+}
+
+// Providers returns the provider names in sorted order.
+func (r *Result) Providers() []string {
+	names := make([]string, 0, len(r.Models))
+	for name := range r.Models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// add records a converted model. Models whose name can't be a catalog
+// filename, and duplicates, are flagged and skipped.
+func (r *Result) add(provider string, m *catalog.Model) {
+	if r.opts.Provider != "" {
+		provider = r.opts.Provider
+	}
+	if strings.ContainsAny(m.Name, `/\:`) {
+		r.flag(provider+"/"+m.Name, "name", "contains a path or variant separator; skipped")
+		return
+	}
+	key := provider + "/" + m.Name
+	if r.seen[key] {
+		r.flag(key, "name", "duplicate entry; kept the first")
+		return
+	}
+	r.seen[key] = true
+	r.Models[provider] = append(r.Models[provider], m)
+}
+
+func (r *Result) flag(model, field, format string, args ...any) {
+	r.Issues = append(r.Issues, Issue{Model: model, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// unmappedField counts a source field with no catalog equivalent. Counts are
+// reported once per field by finish rather than once per model.
+func (r *Result) unmappedField(field string) {
+	r.unmapped[field]++
+}
+
+func (r *Result) finish() {
+	fields := make([]string, 0, len(r.unmapped))
+	for f := range r.unmapped {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	for _, f := range fields {
+		r.flag("", f, "not mapped (present on %d models)", r.unmapped[f])
+	}
+	for _, models := range r.Models {
+		sort.Slice(models, func(i, j int) bool { return models[i].Name < models[j].Name })
+	}
+}
+
+// Parse converts data in the given format.
+func Parse(format string, data []byte, opts Options) (*Result, error) {
+	r := newResult(opts)
+	var err error
+	switch format {
+	case FormatLiteLLM:
+		err = parseLiteLLM(data, r)
+	case FormatOpenRouter:
+		err = parseOpenRouter(data, r)
+	case FormatCSV:
+		err = parseCSV(data, r)
+	default:
+		return nil, fmt.Errorf("unknown import format %q (want one of %s)", format, strings.Join(Formats(), ", "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s input: %w", format, err)
+	}
+	r.finish()
+	return r, nil
+}
+
+// Write stores the result in the catalog at basePath through w, so existing
+// model files are smart-merged rather than replaced. Providers without a
+// provider.yaml get a minimal one, and a missing version.txt is created.
+func Write(basePath string, r *Result, w *catalog.SmartMergeWriter) (written int, err error) {
+	if err := ensureVersion(basePath); err != nil {
+		return 0, err
+	}
+	for _, provider := range r.Providers() {
+		if err := ensureProvider(basePath, provider); err != nil {
+			return written, err
+		}
+		for _, m := range r.Models[provider] {
+			res, err := w.WriteModel(provider, m)
+			if err != nil {
+				return written, fmt.Errorf("writing %s/%s: %w", provider, m.Name, err)
+			}
+			if res.IsNew || len(res.Changes) > 0 {
+				written++
+			}
+		}
+	}
+	return written, nil
+}
+
+func ensureVersion(basePath string) error {
+	path := filepath.Join(basePath, "version.txt")
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(basePath, 0o755); err != nil {
+		return fmt.Errorf("creating catalog dir: %w", err)
+	}
+	return os.WriteFile(path, []byte("0.1.0\n"), 0o644)
+}
+
+func ensureProvider(basePath, provider string) error {
+	dir := filepath.Join(basePath, "providers", provider)
+	path := filepath.Join(dir, "provider.yaml")
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating provider dir: %w", err)
+	}
+	data, err := yaml.Marshal(&catalog.Provider{
+		Name:         provider,
+		DisplayName:  provider,
+		ProviderType: "llm",
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// perMillion converts a per-token price to per-1M, rounding away float noise
+// (2.5e-06 × 1e6 is not exactly 2.5).
+func perMillion(perToken float64) float64 {
+	return math.Round(perToken*1e12) / 1e6
+}
+
+// newModel returns a model with the fields every import fills in.
+func newModel(name, displayName string) *catalog.Model {
+	if displayName == "" {
+		displayName = name
+	}
+	return &catalog.Model{
+		Name:        name,
+		DisplayName: displayName,
+		Family:      inferFamily(name),
+		Status:      "stable",
+	}
+}
+
+// inferFamily strips trailing date and size suffixes from a model name
+// ("gpt-4o-2024-08-06" → "gpt-4o"). It's only a starting point for review.
+func inferFamily(name string) string {
+	parts := strings.Split(name, "-")
+	for len(parts) > 1 {
+		last := parts[len(parts)-1]
+		if !isDigits(last) && last != "latest" && last != "preview" {
+			break
+		}
+		parts = parts[:len(parts)-1]
+	}
+	return strings.Join(parts, "-")
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// appendUnique appends v to list unless it's already present.
+func appendUnique(list []string, v string) []string {
+	for _, x := range list {
+		if x == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
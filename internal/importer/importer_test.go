@@ -0,0 +1,249 @@
+package importer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/export"
+)
+
+func parseFixture(t *testing.T, format, file string, opts Options) *Result {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Parse(format, data, opts)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return res
+}
+
+func findModel(res *Result, provider, name string) *catalog.Model {
+	for _, m := range res.Models[provider] {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+func hasIssue(res *Result, model, field string) bool {
+	for _, i := range res.Issues {
+		if i.Model == model && i.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func TestParseLiteLLM(t *testing.T) {
+	res := parseFixture(t, FormatLiteLLM, "litellm.json", Options{})
+
+	gpt := findModel(res, "openai", "gpt-4o")
+	if gpt == nil {
+		t.Fatalf("gpt-4o not imported; providers: %v", res.Providers())
+	}
+	if gpt.Limits.MaxTokens != 128000 || gpt.Limits.MaxCompletionTokens != 16384 {
+		t.Errorf("limits = %+v", gpt.Limits)
+	}
+	if gpt.Cost == nil || gpt.Cost.InputPer1K != 2.5 || gpt.Cost.OutputPer1K != 10 ||
+		gpt.Cost.CachedInputPer1K != 1.25 || gpt.Cost.Unit != catalog.PriceUnitPer1M {
+		t.Errorf("cost = %+v, want per-1M 2.5/10/1.25", gpt.Cost)
+	}
+	if strings.Join(gpt.Capabilities, ",") != "chat,function_calling,vision" {
+		t.Errorf("capabilities = %v", gpt.Capabilities)
+	}
+	if strings.Join(gpt.Modalities.Input, ",") != "text,image" {
+		t.Errorf("input modalities = %v", gpt.Modalities.Input)
+	}
+
+	emb := findModel(res, "openai", "text-embedding-3-small")
+	if emb == nil || emb.Capabilities[0] != "embeddings" || emb.Modalities.Output[0] != "embedding" {
+		t.Errorf("embedding model = %+v", emb)
+	}
+	if !hasIssue(res, "openai/text-embedding-3-small", "limits.max_tokens") {
+		t.Error("expected legacy max_tokens to be flagged")
+	}
+
+	opus := findModel(res, "anthropic", "claude-3-opus-20240229")
+	if opus == nil || opus.Status != "deprecated" || opus.Family != "claude-3-opus" {
+		t.Errorf("claude-3-opus = %+v", opus)
+	}
+
+	if !hasIssue(res, "openai/gpt-4o", "name") {
+		t.Error("expected openai/gpt-4o duplicate to be flagged")
+	}
+	if findModel(res, "bedrock", "us-east-1/anthropic.claude-v2") != nil || len(res.Models["bedrock"]) != 0 {
+		t.Error("nested bedrock name should be skipped")
+	}
+	if !hasIssue(res, "togetherai/meta-llama/Llama-3-70b", "name") {
+		t.Errorf("expected nested together_ai name to be flagged under togetherai, issues: %v", res.Issues)
+	}
+	if !hasIssue(res, "openai/dall-e-3", "capabilities") {
+		t.Error("expected image model without capabilities to be flagged")
+	}
+	if !hasIssue(res, "", "supports_prompt_caching") {
+		t.Error("expected unmapped supports_prompt_caching to be reported")
+	}
+}
+
+func TestParseLiteLLMProxyConfig(t *testing.T) {
+	res := parseFixture(t, FormatLiteLLM, "litellm_proxy.yaml", Options{})
+
+	m := findModel(res, "groq", "llama-3.1-8b-instant")
+	if m == nil {
+		t.Fatalf("model not imported; providers: %v", res.Providers())
+	}
+	if m.Cost == nil || m.Cost.InputPer1K != 0.05 || m.Cost.OutputPer1K != 0.08 {
+		t.Errorf("cost = %+v", m.Cost)
+	}
+	if m.Limits.MaxTokens != 131072 {
+		t.Errorf("max_tokens = %d", m.Limits.MaxTokens)
+	}
+	if !hasIssue(res, "model_list[1]", "litellm_params.model") {
+		t.Error("expected entry without litellm_params.model to be flagged")
+	}
+}
+
+func TestParseOpenRouter(t *testing.T) {
+	res := parseFixture(t, FormatOpenRouter, "openrouter.json", Options{})
+
+	large := findModel(res, "mistral", "mistral-large")
+	if large == nil {
+		t.Fatalf("mistral-large not imported; providers: %v", res.Providers())
+	}
+	if large.DisplayName != "Mistral Large" || large.Limits.MaxCompletionTokens != 8192 {
+		t.Errorf("mistral-large = %+v", large)
+	}
+	if strings.Join(large.Capabilities, ",") != "chat,function_calling" {
+		t.Errorf("capabilities = %v", large.Capabilities)
+	}
+
+	gemini := findModel(res, "google", "gemini-2.5-pro")
+	if gemini == nil || gemini.Cost.CachedInputPer1K != 0.31 {
+		t.Fatalf("gemini = %+v", gemini)
+	}
+	if strings.Join(gemini.Capabilities, ",") != "chat,vision,function_calling,reasoning" {
+		t.Errorf("capabilities = %v", gemini.Capabilities)
+	}
+	if strings.Join(gemini.Modalities.Input, ",") != "text,image" {
+		t.Errorf("input modalities = %v, want file dropped", gemini.Modalities.Input)
+	}
+
+	if !hasIssue(res, "mistralai/mistral-large:free", "id") {
+		t.Error("expected :free variant to be flagged")
+	}
+	if auto := findModel(res, "openrouter", "auto"); auto == nil || auto.Cost != nil {
+		t.Errorf("auto router = %+v, want imported without cost", auto)
+	}
+	if !hasIssue(res, "openrouter/auto", "pricing") {
+		t.Error("expected variable pricing to be flagged")
+	}
+	for _, field := range []string{"pricing.input_cache_write", "architecture.input_modalities: file"} {
+		if !hasIssue(res, "", field) {
+			t.Errorf("expected unmapped %s to be reported", field)
+		}
+	}
+}
+
+func TestParseProviderOverride(t *testing.T) {
+	res := parseFixture(t, FormatOpenRouter, "openrouter.json", Options{Provider: "openrouter"})
+	if got := res.Providers(); len(got) != 1 || got[0] != "openrouter" {
+		t.Errorf("providers = %v, want [openrouter]", got)
+	}
+}
+
+func TestCSVRoundTripsExport(t *testing.T) {
+	cat := &catalog.Catalog{
+		Version: "1.0.0",
+		Providers: map[string]*catalog.ProviderCatalog{
+			"openai": {Models: map[string]*catalog.Model{
+				"gpt-5": {
+					Name: "gpt-5", DisplayName: "GPT-5", Family: "gpt-5", Status: "stable",
+					Cost:         &catalog.Cost{InputPer1K: 0.00125, OutputPer1K: 0.01},
+					Limits:       catalog.Limits{MaxTokens: 400000, MaxCompletionTokens: 128000},
+					Capabilities: []string{"chat", "vision"},
+					Modalities:   catalog.Modalities{Input: []string{"text", "image"}, Output: []string{"text"}},
+				},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := export.Write(&buf, export.FormatCSV, export.Meta{Version: cat.Version}, export.Records(cat, export.Filter{})); err != nil {
+		t.Fatal(err)
+	}
+	res, err := Parse(FormatCSV, buf.Bytes(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Issues) != 0 {
+		t.Errorf("unexpected issues: %v", res.Issues)
+	}
+
+	got := findModel(res, "openai", "gpt-5")
+	want := cat.Providers["openai"].Models["gpt-5"]
+	if got == nil {
+		t.Fatal("gpt-5 not imported")
+	}
+	if got.DisplayName != want.DisplayName || got.Limits != want.Limits ||
+		*got.Cost != *want.Cost ||
+		strings.Join(got.Capabilities, ",") != "chat,vision" ||
+		strings.Join(got.Modalities.Input, ",") != "text,image" {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	if _, err := Parse(FormatCSV, []byte("name,status\ngpt-5,stable\n"), Options{}); err == nil {
+		t.Error("expected error for missing provider column")
+	}
+	res, err := Parse(FormatCSV, []byte("provider,name,max_tokens,notes\nopenai,gpt-5,lots,hi\n"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasIssue(res, "", "notes") || !hasIssue(res, "openai/gpt-5", "max_tokens") {
+		t.Errorf("issues = %v", res.Issues)
+	}
+}
+
+func TestWriteCreatesCatalogLayout(t *testing.T) {
+	res := parseFixture(t, FormatLiteLLM, "litellm_proxy.yaml", Options{})
+	dir := t.TempDir()
+
+	n, err := Write(dir, res, catalog.NewWriter(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("written = %d, want 1", n)
+	}
+
+	cat, err := catalog.Load(dir)
+	if err != nil {
+		t.Fatalf("imported catalog doesn't load: %v", err)
+	}
+	if cat.Version != "0.1.0" {
+		t.Errorf("version = %q", cat.Version)
+	}
+	if _, ok := cat.Providers["groq"].Models["llama-3.1-8b-instant"]; !ok {
+		t.Error("model missing from loaded catalog")
+	}
+
+	// A second import of the same data changes nothing.
+	if n, err := Write(dir, res, catalog.NewWriter(dir)); err != nil || n != 0 {
+		t.Errorf("re-import wrote %d models (err %v), want 0", n, err)
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if _, err := Parse("xml", nil, Options{}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,226 @@
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+// liteLLMProviders maps litellm_provider values to sentinel provider names.
+var liteLLMProviders = map[string]string{
+	"text-completion-openai":    "openai",
+	"gemini":                    "google",
+	"vertex_ai-language-models": "google",
+	"cohere_chat":               "cohere",
+	"together_ai":               "togetherai",
+	"fireworks_ai":              "fireworks",
+	"nvidia_nim":                "nvidia",
+	"dashscope":                 "alibaba",
+	"moonshot":                  "moonshotai",
+	"novita":                    "novitaai",
+	"friendliai":                "friendli",
+}
+
+// liteLLMInfo is a model entry from model_prices_and_context_window.json or
+// the model_info block of a proxy config.
+type liteLLMInfo map[string]any
+
+// parseLiteLLM accepts either LiteLLM's pricing map (model → info) or a proxy
+// config.yaml with a model_list. JSON is valid YAML, so one decoder serves both.
+func parseLiteLLM(data []byte, r *Result) error {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	if list, ok := doc["model_list"].([]any); ok {
+		for i, entry := range list {
+			e, _ := entry.(map[string]any)
+			params, _ := e["litellm_params"].(map[string]any)
+			model, _ := params["model"].(string)
+			if model == "" {
+				r.flag(fmt.Sprintf("model_list[%d]", i), "litellm_params.model", "missing; skipped")
+				continue
+			}
+			info, _ := e["model_info"].(map[string]any)
+			if info == nil {
+				info = map[string]any{}
+			}
+			provider, name, _ := strings.Cut(model, "/")
+			if name == "" {
+				provider, name = "", model
+			}
+			convertLiteLLM(r, name, provider, liteLLMInfo(info))
+		}
+		return nil
+	}
+
+	keys := make([]string, 0, len(doc))
+	for k := range doc {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if key == "sample_spec" {
+			continue
+		}
+		info, ok := doc[key].(map[string]any)
+		if !ok {
+			r.flag(key, "entry", "not an object; skipped")
+			continue
+		}
+		convertLiteLLM(r, key, "", liteLLMInfo(info))
+	}
+	return nil
+}
+
+// liteLLMMapped lists info keys convertLiteLLM understands; anything else is
+// reported as unmapped.
+var liteLLMMapped = map[string]bool{
+	"litellm_provider": true, "mode": true,
+	"max_tokens": true, "max_input_tokens": true, "max_output_tokens": true,
+	"input_cost_per_token": true, "output_cost_per_token": true,
+	"cache_read_input_token_cost": true, "input_cost_per_image": true, "input_cost_per_request": true,
+	"supports_function_calling": true, "supports_parallel_function_calling": true,
+	"supports_vision": true, "supports_reasoning": true, "supports_audio_input": true,
+	"supports_audio_output": true, "supports_computer_use": true, "deprecation_date": true,
+	"source": true, "id": true, "db_model": true,
+}
+
+func convertLiteLLM(r *Result, key, provider string, info liteLLMInfo) {
+	if p := info.str("litellm_provider"); p != "" {
+		provider = p
+	}
+	name := key
+	if prefix, rest, ok := strings.Cut(key, "/"); ok && (prefix == provider || provider == "") {
+		if provider == "" {
+			provider = prefix
+		}
+		name = rest
+	}
+	if provider == "" {
+		r.flag(key, "litellm_provider", "missing; skipped")
+		return
+	}
+	if mapped, ok := liteLLMProviders[provider]; ok {
+		provider = mapped
+	}
+
+	m := newModel(name, "")
+	m.Limits.MaxTokens = info.int("max_input_tokens")
+	m.Limits.MaxCompletionTokens = info.int("max_output_tokens")
+	if m.Limits.MaxTokens == 0 {
+		// max_tokens is the legacy key and means output tokens for most
+		// entries, so it's only a fallback for the context window.
+		if m.Limits.MaxTokens = info.int("max_tokens"); m.Limits.MaxTokens != 0 {
+			r.flag(provider+"/"+name, "limits.max_tokens", "taken from legacy max_tokens; verify it is the context window")
+		}
+	}
+
+	if info.has("input_cost_per_token") || info.has("output_cost_per_token") {
+		m.Cost = &catalog.Cost{
+			InputPer1K:       perMillion(info.float("input_cost_per_token")),
+			OutputPer1K:      perMillion(info.float("output_cost_per_token")),
+			CachedInputPer1K: perMillion(info.float("cache_read_input_token_cost")),
+			PerImage:         info.float("input_cost_per_image"),
+			PerRequest:       info.float("input_cost_per_request"),
+			Unit:             catalog.PriceUnitPer1M,
+		}
+	}
+
+	m.Modalities.Input = []string{"text"}
+	m.Modalities.Output = []string{"text"}
+	switch mode := info.str("mode"); mode {
+	case "", "chat":
+		m.Capabilities = []string{"chat"}
+	case "completion":
+		m.Capabilities = []string{"completions"}
+	case "embedding":
+		m.Capabilities = []string{"embeddings"}
+		m.Modalities.Output = []string{"embedding"}
+	case "image_generation":
+		m.Modalities.Output = []string{"image"}
+	case "audio_transcription":
+		m.Modalities.Input = []string{"audio"}
+	case "audio_speech":
+		m.Modalities.Output = []string{"audio"}
+	case "rerank":
+		m.Capabilities = []string{"rerank"}
+	default:
+		r.flag(provider+"/"+name, "mode", "unknown mode %q; no capabilities mapped", mode)
+	}
+
+	if info.bool("supports_function_calling") {
+		m.Capabilities = appendUnique(m.Capabilities, "function_calling")
+	}
+	if info.bool("supports_vision") {
+		m.Capabilities = appendUnique(m.Capabilities, "vision")
+		m.Modalities.Input = appendUnique(m.Modalities.Input, "image")
+	}
+	if info.bool("supports_reasoning") {
+		m.Capabilities = appendUnique(m.Capabilities, "reasoning")
+	}
+	if info.bool("supports_computer_use") {
+		m.Capabilities = appendUnique(m.Capabilities, "computer_use")
+	}
+	if info.bool("supports_audio_input") {
+		m.Modalities.Input = appendUnique(m.Modalities.Input, "audio")
+	}
+	if info.bool("supports_audio_output") {
+		m.Modalities.Output = appendUnique(m.Modalities.Output, "audio")
+	}
+
+	if len(m.Capabilities) == 0 {
+		r.flag(provider+"/"+name, "capabilities", "no catalog capability for this mode; add one before validating")
+	}
+
+	if d := info.str("deprecation_date"); d != "" {
+		if t, err := time.Parse("2006-01-02", d); err == nil && t.Before(time.Now()) {
+			m.Status = "deprecated"
+		} else {
+			r.flag(provider+"/"+name, "deprecation_date", "%s has no catalog field; status left as %s", d, m.Status)
+		}
+	}
+
+	for k := range info {
+		if !liteLLMMapped[k] {
+			r.unmappedField(k)
+		}
+	}
+
+	r.add(provider, m)
+}
+
+func (i liteLLMInfo) has(key string) bool {
+	_, ok := i[key]
+	return ok
+}
+
+func (i liteLLMInfo) str(key string) string {
+	s, _ := i[key].(string)
+	return s
+}
+
+func (i liteLLMInfo) bool(key string) bool {
+	b, _ := i[key].(bool)
+	return b
+}
+
+func (i liteLLMInfo) float(key string) float64 {
+	switch v := i[key].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	}
+	return 0
+}
+
+func (i liteLLMInfo) int(key string) int {
+	return int(i.float(key))
+}
@@ -0,0 +1,155 @@
+package importer
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+// openRouterVendors maps OpenRouter vendor prefixes to sentinel provider names.
+var openRouterVendors = map[string]string{
+	"x-ai":       "xai",
+	"mistralai":  "mistral",
+	"meta-llama": "llama",
+	"qwen":       "alibaba",
+	"amazon":     "nova",
+	"z-ai":       "zhipuai",
+	"stepfun-ai": "stepfun",
+}
+
+// openRouterExport is the response of OpenRouter's /api/v1/models.
+type openRouterExport struct {
+	Data []openRouterModel `json:"data"`
+}
+
+type openRouterModel struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	ContextLength int    `json:"context_length"`
+	Architecture  struct {
+		InputModalities  []string `json:"input_modalities"`
+		OutputModalities []string `json:"output_modalities"`
+	} `json:"architecture"`
+	Pricing struct {
+		Prompt          string `json:"prompt"`
+		Completion      string `json:"completion"`
+		Image           string `json:"image"`
+		Request         string `json:"request"`
+		InputCacheRead  string `json:"input_cache_read"`
+		InputCacheWrite string `json:"input_cache_write"`
+		WebSearch       string `json:"web_search"`
+	} `json:"pricing"`
+	TopProvider struct {
+		MaxCompletionTokens int `json:"max_completion_tokens"`
+	} `json:"top_provider"`
+	SupportedParameters []string `json:"supported_parameters"`
+}
+
+func parseOpenRouter(data []byte, r *Result) error {
+	var export openRouterExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return err
+	}
+	for _, om := range export.Data {
+		convertOpenRouter(r, om)
+	}
+	return nil
+}
+
+func convertOpenRouter(r *Result, om openRouterModel) {
+	vendor, name, ok := strings.Cut(om.ID, "/")
+	if !ok {
+		r.flag(om.ID, "id", "no vendor prefix; skipped")
+		return
+	}
+	if strings.Contains(name, ":") {
+		r.flag(om.ID, "id", "routing variant of another model; skipped")
+		return
+	}
+	provider := vendor
+	if mapped, ok := openRouterVendors[vendor]; ok {
+		provider = mapped
+	}
+
+	// OpenRouter names are "Vendor: Model".
+	displayName := om.Name
+	if _, rest, ok := strings.Cut(displayName, ": "); ok {
+		displayName = rest
+	}
+	m := newModel(name, displayName)
+	m.Limits.MaxTokens = om.ContextLength
+	m.Limits.MaxCompletionTokens = om.TopProvider.MaxCompletionTokens
+
+	prompt, okIn := parsePrice(om.Pricing.Prompt)
+	completion, okOut := parsePrice(om.Pricing.Completion)
+	if okIn && okOut {
+		cacheRead, _ := parsePrice(om.Pricing.InputCacheRead)
+		image, _ := parsePrice(om.Pricing.Image)
+		request, _ := parsePrice(om.Pricing.Request)
+		m.Cost = &catalog.Cost{
+			InputPer1K:       perMillion(prompt),
+			OutputPer1K:      perMillion(completion),
+			CachedInputPer1K: perMillion(cacheRead),
+			PerImage:         image,
+			PerRequest:       request,
+			Unit:             catalog.PriceUnitPer1M,
+		}
+	} else if om.Pricing.Prompt != "" || om.Pricing.Completion != "" {
+		r.flag(om.ID, "pricing", "variable or unparseable token prices; cost left empty")
+	}
+	if p, _ := parsePrice(om.Pricing.InputCacheWrite); p > 0 {
+		r.unmappedField("pricing.input_cache_write")
+	}
+	if p, _ := parsePrice(om.Pricing.WebSearch); p > 0 {
+		r.unmappedField("pricing.web_search")
+	}
+
+	for _, mod := range om.Architecture.InputModalities {
+		if mod == "file" {
+			r.unmappedField("architecture.input_modalities: file")
+			continue
+		}
+		m.Modalities.Input = appendUnique(m.Modalities.Input, mod)
+	}
+	for _, mod := range om.Architecture.OutputModalities {
+		m.Modalities.Output = appendUnique(m.Modalities.Output, mod)
+	}
+
+	for _, mod := range m.Modalities.Output {
+		if mod == "text" {
+			m.Capabilities = append(m.Capabilities, "chat")
+			break
+		}
+	}
+	for _, mod := range m.Modalities.Input {
+		if mod == "image" {
+			m.Capabilities = append(m.Capabilities, "vision")
+			break
+		}
+	}
+	for _, p := range om.SupportedParameters {
+		switch p {
+		case "tools":
+			m.Capabilities = appendUnique(m.Capabilities, "function_calling")
+		case "reasoning":
+			m.Capabilities = appendUnique(m.Capabilities, "reasoning")
+		}
+	}
+
+	r.add(provider, m)
+}
+
+// parsePrice parses an OpenRouter per-token price string. Negative prices
+// mark routers whose price depends on the model picked.
+func parsePrice(s string) (float64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,123 @@
+// Package inference decides which capabilities a discovered model has and
+// records why. Some providers report capabilities in their model listings,
+// such as Mistral's capabilities block or Cohere's endpoints; for the
+// others they are guessed from the model ID. The guesses live here, as
+// rules shared by every provider with per-provider overrides, so that
+// adapters agree and `sentinel discover --explain` can show the reason
+// behind each capability. A few adapters, such as OpenAI's and Google's,
+// guess from more than substrings of the ID; they keep their own rules, and
+// --explain shows their capabilities as set by the adapter.
+package inference
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Source is where a capability came from.
+type Source string
+
+const (
+	FromAPI       Source = "api"       // reported by the provider's API
+	FromHeuristic Source = "heuristic" // matched a rule on the model ID
+	FromDefault   Source = "default"   // given to every model of the provider
+	FromAdapter   Source = "adapter"   // set by an adapter that records no reason
+)
+
+// Reason explains one capability of a model.
+type Reason struct {
+	Capability string
+	Source     Source
+	Detail     string // e.g. `ID contains "vision"`
+}
+
+func (r Reason) String() string {
+	return fmt.Sprintf("%s: %s, %s", r.Capability, r.Source, r.Detail)
+}
+
+// Set collects a model's capabilities in the order they were added, with
+// the first reason given for each.
+type Set struct {
+	reasons []Reason
+}
+
+// Add adds capability unless the set has it already.
+func (s *Set) Add(capability string, source Source, detail string) {
+	if s.Has(capability) {
+		return
+	}
+	s.reasons = append(s.reasons, Reason{Capability: capability, Source: source, Detail: detail})
+}
+
+// Has reports whether the set has capability.
+func (s *Set) Has(capability string) bool {
+	return slices.ContainsFunc(s.reasons, func(r Reason) bool { return r.Capability == capability })
+}
+
+// Capabilities returns the capabilities in the order they were added.
+func (s *Set) Capabilities() []string {
+	caps := make([]string, 0, len(s.reasons))
+	for _, r := range s.reasons {
+		caps = append(caps, r.Capability)
+	}
+	return caps
+}
+
+// Reasons returns the reason for each capability, in the same order.
+func (s *Set) Reasons() []Reason {
+	return slices.Clone(s.reasons)
+}
+
+// Rule gives Capability to models whose lowercased ID contains any of
+// Match.
+type Rule struct {
+	Capability string
+	Match      []string
+}
+
+// Heuristics infer capabilities from a model ID: Defaults are given to
+// every model, then each matching rule adds its capability.
+type Heuristics struct {
+	Defaults []string
+	Rules    []Rule
+}
+
+// Apply adds what h infers for id to s.
+func (h Heuristics) Apply(s *Set, id string) {
+	for _, c := range h.Defaults {
+		s.Add(c, FromDefault, "every model of the provider")
+	}
+	lower := strings.ToLower(id)
+	for _, r := range h.Rules {
+		for _, m := range r.Match {
+			if strings.Contains(lower, m) {
+				s.Add(r.Capability, FromHeuristic, fmt.Sprintf("ID contains %q", m))
+				break
+			}
+		}
+	}
+}
+
+// Infer returns the capabilities the heuristics of provider give id.
+func Infer(provider, id string) *Set {
+	var s Set
+	For(provider).Apply(&s, id)
+	return &s
+}
+
+// Explain returns a reason for each of capabilities: its reason from
+// reasons, or FromAdapter when there is none. Capabilities that were
+// edited after inference have no reason.
+func Explain(capabilities []string, reasons []Reason) []Reason {
+	out := make([]Reason, 0, len(capabilities))
+	for _, c := range capabilities {
+		i := slices.IndexFunc(reasons, func(r Reason) bool { return r.Capability == c })
+		if i < 0 {
+			out = append(out, Reason{Capability: c, Source: FromAdapter, Detail: "no reason recorded"})
+			continue
+		}
+		out = append(out, reasons[i])
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package inference
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInfer(t *testing.T) {
+	tests := []struct {
+		provider, id string
+		want         []string
+	}{
+		{"groq", "llama-3.3-70b-versatile", []string{"chat", "streaming", "function_calling"}},
+		{"groq", "llama-3.2-90b-vision-preview", []string{"chat", "streaming", "function_calling", "vision"}},
+		{"deepseek", "deepseek-reasoner", []string{"chat", "function_calling", "streaming", "reasoning"}},
+		{"deepseek", "deepseek-coder", []string{"chat", "function_calling", "streaming", "fill_in_middle"}},
+		{"perplexity", "sonar-reasoning-pro", []string{"chat", "streaming", "search", "reasoning"}},
+		{"acme", "acme-vl-large", []string{"chat", "streaming", "vision"}},
+		// The common rules apply to providers with overrides too.
+		{"groq", "kimi-k2-thinking", []string{"chat", "streaming", "function_calling", "reasoning"}},
+		{"groq", "qwen2.5-vl-32b-instruct", []string{"chat", "streaming", "function_calling", "vision"}},
+		{"deepseek", "deepseek-v3-thinking", []string{"chat", "function_calling", "streaming", "reasoning"}},
+		{"deepseek", "deepseek-vl2", []string{"chat", "function_calling", "streaming", "vision"}},
+		{"xai", "grok-3-mini", []string{"chat", "function_calling", "streaming", "reasoning"}},
+		{"stepfun", "step-1v-8k", []string{"chat", "function_calling", "streaming", "vision"}},
+		{"alibaba", "qwen2.5-coder-32b-instruct", []string{"chat", "function_calling", "streaming", "fill_in_middle"}},
+		{"nvidia", "nvidia/vila-vlm", []string{"chat", "streaming", "function_calling", "vision"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider+"/"+tt.id, func(t *testing.T) {
+			if got := Infer(tt.provider, tt.id).Capabilities(); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInferReasons(t *testing.T) {
+	got := Infer("groq", "llama-3.2-11b-vision-preview").Reasons()
+	want := []Reason{
+		{"chat", FromDefault, "every model of the provider"},
+		{"streaming", FromDefault, "every model of the provider"},
+		{"function_calling", FromDefault, "every model of the provider"},
+		{"vision", FromHeuristic, `ID contains "llama-3.2-11b"`},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSetKeepsFirstReason(t *testing.T) {
+	var s Set
+	s.Add("vision", FromAPI, "capabilities.vision is true")
+	s.Add("vision", FromHeuristic, `ID contains "vision"`)
+	if got := s.Reasons(); len(got) != 1 || got[0].Source != FromAPI {
+		t.Errorf("got %v, want the API reason only", got)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	reasons := []Reason{{"chat", FromAPI, `endpoints include "chat"`}}
+	got := Explain([]string{"chat", "vision"}, reasons)
+	if len(got) != 2 || got[0] != reasons[0] || got[1].Capability != "vision" || got[1].Source != FromAdapter {
+		t.Errorf("got %v, want the chat reason and vision unexplained", got)
+	}
+}
@@ -0,0 +1,156 @@
+package inference
+
+// common are the rules every provider's models are matched against, after
+// the provider's own.
+var common = []Rule{
+	{Capability: "vision", Match: []string{"vision", "-vl-", "-vl"}},
+	{Capability: "reasoning", Match: []string{"reasoner", "reasoning", "thinking"}},
+}
+
+// overrides are the heuristics of providers whose API doesn't say what
+// their models can do. Their rules run before the common ones, and
+// their defaults replace the common default of chat and streaming.
+var overrides = map[string]Heuristics{
+	"ai21": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+	},
+	"alibaba": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+		Rules: []Rule{
+			{Capability: "vision", Match: []string{"vl"}},
+			{Capability: "fill_in_middle", Match: []string{"coder", "code"}},
+		},
+	},
+	"anthropic": {
+		// Every current Claude model has extended thinking.
+		Defaults: []string{"chat", "function_calling", "vision", "streaming", "extended_thinking"},
+		Rules: []Rule{
+			{Capability: "adaptive_thinking", Match: []string{"opus-4-6", "sonnet-4-6"}},
+		},
+	},
+	"cerebras": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+	},
+	"deepinfra": {
+		Defaults: []string{"chat", "streaming", "function_calling"},
+	},
+	"deepseek": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+		Rules: []Rule{
+			{Capability: "fill_in_middle", Match: []string{"coder"}},
+		},
+	},
+	"fireworks": {
+		Defaults: []string{"chat", "streaming", "function_calling"},
+	},
+	"friendli": {
+		Defaults: []string{"chat", "streaming", "function_calling"},
+		Rules: []Rule{
+			{Capability: "vision", Match: []string{"vl"}},
+		},
+	},
+	"groq": {
+		// Most Groq chat models support function calling.
+		Defaults: []string{"chat", "streaming", "function_calling"},
+		Rules: []Rule{
+			// Llama 3.2's 11B and 90B models are its vision models.
+			{Capability: "vision", Match: []string{"llama-3.2-11b", "llama-3.2-90b"}},
+		},
+	},
+	"inception": {
+		Defaults: []string{"chat", "streaming"},
+		Rules: []Rule{
+			{Capability: "fill_in_middle", Match: []string{"coder"}},
+		},
+	},
+	"llama": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+		Rules: []Rule{
+			// Llama 4's Scout and Maverick are natively multimodal.
+			{Capability: "vision", Match: []string{"scout", "maverick"}},
+		},
+	},
+	"minimax": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+	},
+	"moonshotai": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+	},
+	"nebius": {
+		Defaults: []string{"chat", "streaming", "function_calling"},
+		Rules: []Rule{
+			{Capability: "vision", Match: []string{"vl"}},
+		},
+	},
+	"nova": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+	},
+	"novitaai": {
+		Defaults: []string{"chat", "streaming", "function_calling"},
+		Rules: []Rule{
+			{Capability: "vision", Match: []string{"vl"}},
+		},
+	},
+	"nvidia": {
+		Defaults: []string{"chat", "streaming", "function_calling"},
+		Rules: []Rule{
+			{Capability: "vision", Match: []string{"vlm"}},
+		},
+	},
+	"perplexity": {
+		Defaults: []string{"chat", "streaming"},
+		Rules: []Rule{
+			// Every Sonar model searches the web and cites its sources.
+			{Capability: "search", Match: []string{"sonar"}},
+		},
+	},
+	"siliconflow": {
+		Defaults: []string{"chat", "streaming", "function_calling"},
+		Rules: []Rule{
+			{Capability: "vision", Match: []string{"vl"}},
+		},
+	},
+	"stepfun": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+		Rules: []Rule{
+			// step-1v and its successors are the vision models.
+			{Capability: "vision", Match: []string{"1v"}},
+		},
+	},
+	"upstage": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+	},
+	"venice": {
+		Defaults: []string{"chat", "streaming", "function_calling"},
+		Rules: []Rule{
+			{Capability: "vision", Match: []string{"vl"}},
+		},
+	},
+	"xai": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+		Rules: []Rule{
+			// The Grok mini models think before answering.
+			{Capability: "reasoning", Match: []string{"mini"}},
+		},
+	},
+	"zhipuai": {
+		Defaults: []string{"chat", "function_calling", "streaming"},
+		Rules: []Rule{
+			// GLM-4V and its successors are the vision models.
+			{Capability: "vision", Match: []string{"4v"}},
+		},
+	},
+}
+
+// For returns the heuristics of provider: its overrides followed by the
+// common rules.
+func For(provider string) Heuristics {
+	h, ok := overrides[provider]
+	if !ok {
+		h.Defaults = []string{"chat", "streaming"}
+	}
+	return Heuristics{
+		Defaults: h.Defaults,
+		Rules:    append(append([]Rule(nil), h.Rules...), common...),
+	}
+}
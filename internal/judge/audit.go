@@ -0,0 +1,145 @@
+package judge
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AuditRecord is one judge evaluation as written to the audit log: what was
+// asked, of which model, and what came back. Cache hits are recorded too,
+// so every verdict that reached a PR can be traced.
+type AuditRecord struct {
+	Time          time.Time      `json:"time"`
+	Provider      string         `json:"provider"` // catalog provider of the changeset
+	Model         string         `json:"model"`    // judge model
+	PromptHash    string         `json:"prompt_hash"`
+	ChangeSetHash string         `json:"changeset_hash,omitempty"`
+	Cached        bool           `json:"cached,omitempty"`
+	Screened      bool           `json:"screened,omitempty"` // verdicts of the screening rules, without an LLM call
+	Prompt        string         `json:"prompt,omitempty"`   // user prompt, i.e. the rendered changeset
+	Response      string         `json:"response,omitempty"` // raw LLM output
+	Verdicts      []ModelVerdict `json:"verdicts,omitempty"`
+	InputTokens   int            `json:"input_tokens,omitempty"`
+	OutputTokens  int            `json:"output_tokens,omitempty"`
+	CostUSD       float64        `json:"cost_usd,omitempty"` // with the judge model's pricing known
+	LatencyMS     int64          `json:"latency_ms,omitempty"`
+	Error         string         `json:"error,omitempty"`
+}
+
+// ID is the short form of the changeset hash that identifies the run in
+// judge-log output and by judge replay.
+func (r *AuditRecord) ID() string {
+	if len(r.ChangeSetHash) > 12 {
+		return r.ChangeSetHash[:12]
+	}
+	return r.ChangeSetHash
+}
+
+// AuditLog appends judge evaluations to a JSON Lines file. The file is only
+// created on the first write.
+type AuditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewAuditLog returns an audit log writing to path.
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{path: path}
+}
+
+// Append writes one record.
+func (l *AuditLog) Append(rec *AuditRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encoding audit record: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// AuditFilter selects audit records. Zero fields match everything. Model
+// and Verdict match when a single verdict in the record has both.
+type AuditFilter struct {
+	Provider string
+	Model    string // catalog model name
+	Verdict  Verdict
+	Since    time.Time
+}
+
+// Match reports whether rec passes the filter.
+func (f AuditFilter) Match(rec *AuditRecord) bool {
+	if f.Provider != "" && rec.Provider != f.Provider {
+		return false
+	}
+	if !f.Since.IsZero() && rec.Time.Before(f.Since) {
+		return false
+	}
+	if f.Model == "" && f.Verdict == "" {
+		return true
+	}
+	for _, v := range rec.Verdicts {
+		if f.MatchVerdict(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchVerdict reports whether a verdict passes the Model and Verdict parts
+// of the filter.
+func (f AuditFilter) MatchVerdict(v ModelVerdict) bool {
+	return (f.Model == "" || v.ModelName == f.Model) && (f.Verdict == "" || v.Verdict == f.Verdict)
+}
+
+// ReadAuditLog returns the records in the log at path that pass filter,
+// oldest first. A missing log has no records.
+func ReadAuditLog(path string, filter AuditFilter) ([]AuditRecord, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []AuditRecord
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64<<10), 16<<20) // prompts of large changesets run long
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var rec AuditRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if filter.Match(&rec) {
+			records = append(records, rec)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return records, nil
+}
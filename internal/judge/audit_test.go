@@ -0,0 +1,103 @@
+package judge
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/cache"
+)
+
+func TestEvaluate_WritesAuditLog(t *testing.T) {
+	fc, err := cache.New(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "state", "judge-audit.jsonl")
+	client := &mockClient{response: withRejectionResponse()}
+	j := New(client, "test-model", false, WithCache(fc), WithAuditLog(NewAuditLog(path)))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := j.Evaluate(ctx, makeChangeSet()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client.err = errors.New("rate limited")
+	changed := makeChangeSet()
+	changed.New[0].Model.Limits.MaxTokens++
+	if _, err := j.Evaluate(ctx, changed); err == nil {
+		t.Fatal("expected error")
+	}
+
+	records, err := ReadAuditLog(path, AuditFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+
+	call, hit, failed := records[0], records[1], records[2]
+	if call.Provider != "openai" || call.Model != "test-model" || call.PromptHash == "" || call.ChangeSetHash == "" {
+		t.Errorf("call record missing identity: %+v", call)
+	}
+	if call.Cached || call.Prompt == "" || call.Response == "" || len(call.Verdicts) != 2 {
+		t.Errorf("call record = %+v", call)
+	}
+	if call.InputTokens != 1200 || call.OutputTokens != 300 {
+		t.Errorf("tokens = %d/%d, want 1200/300", call.InputTokens, call.OutputTokens)
+	}
+	if !hit.Cached || len(hit.Verdicts) != 2 || hit.ChangeSetHash != call.ChangeSetHash || hit.Prompt != "" {
+		t.Errorf("cache hit record = %+v", hit)
+	}
+	if failed.Error != "rate limited" || len(failed.Verdicts) != 0 || failed.ChangeSetHash == call.ChangeSetHash {
+		t.Errorf("failed record = %+v", failed)
+	}
+}
+
+func TestReadAuditLog_Filter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l := NewAuditLog(path)
+	old := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, rec := range []*AuditRecord{
+		{Time: old, Provider: "openai", Verdicts: []ModelVerdict{{ModelName: "gpt-5", Verdict: VerdictReject}}},
+		{Time: recent, Provider: "openai", Verdicts: []ModelVerdict{{ModelName: "gpt-5", Verdict: VerdictApprove}, {ModelName: "gpt-4o", Verdict: VerdictReject}}},
+		{Time: recent, Provider: "anthropic", Verdicts: []ModelVerdict{{ModelName: "claude-sonnet-4", Verdict: VerdictFlag}}},
+	} {
+		if err := l.Append(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter AuditFilter
+		want   int
+	}{
+		{"all", AuditFilter{}, 3},
+		{"provider", AuditFilter{Provider: "openai"}, 2},
+		{"since", AuditFilter{Since: recent}, 2},
+		{"verdict", AuditFilter{Verdict: VerdictReject}, 2},
+		{"model and verdict in the same verdict", AuditFilter{Model: "gpt-5", Verdict: VerdictReject}, 1},
+		{"unknown model", AuditFilter{Model: "gpt-3"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := ReadAuditLog(path, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != tt.want {
+				t.Errorf("got %d records, want %d", len(records), tt.want)
+			}
+		})
+	}
+
+	if records, err := ReadAuditLog(filepath.Join(t.TempDir(), "missing.jsonl"), AuditFilter{}); err != nil || records != nil {
+		t.Errorf("missing log = %v, %v; want no records", records, err)
+	}
+}
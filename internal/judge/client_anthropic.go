@@ -11,30 +11,53 @@ import (
 )
 
 // AnthropicClient implements LLMClient using the Anthropic Messages API.
+// By default the model must answer by calling a tool whose input schema is
+// the verdict schema, so the response needs no parsing out of prose.
 type AnthropicClient struct {
-	apiKey    string
-	baseURL   string
-	model     string
-	maxTokens int
-	client    *http.Client
+	apiKey     string
+	baseURL    string
+	model      string
+	maxTokens  int
+	client     *http.Client
+	structured bool
 }
 
 // NewAnthropicClient creates a client for the Anthropic Messages API.
 func NewAnthropicClient(apiKey, baseURL, model string, maxTokens int) *AnthropicClient {
 	return &AnthropicClient{
-		apiKey:    apiKey,
-		baseURL:   baseURL,
-		model:     model,
-		maxTokens: maxTokens,
-		client:    &http.Client{Timeout: 120 * time.Second},
+		apiKey:     apiKey,
+		baseURL:    baseURL,
+		model:      model,
+		maxTokens:  maxTokens,
+		client:     &http.Client{Timeout: 120 * time.Second},
+		structured: true,
 	}
 }
 
+// SetStructured turns the verdict tool off, for Anthropic-compatible APIs
+// without tool use. The verdicts are then parsed out of the text.
+func (c *AnthropicClient) SetStructured(on bool) {
+	c.structured = on
+}
+
 type anthropicRequest struct {
-	Model     string             `json:"model"`
-	MaxTokens int                `json:"max_tokens"`
-	System    string             `json:"system,omitempty"`
-	Messages  []anthropicMessage `json:"messages"`
+	Model      string               `json:"model"`
+	MaxTokens  int                  `json:"max_tokens"`
+	System     string               `json:"system,omitempty"`
+	Messages   []anthropicMessage   `json:"messages"`
+	Tools      []anthropicTool      `json:"tools,omitempty"`
+	ToolChoice *anthropicToolChoice `json:"tool_choice,omitempty"`
+}
+
+type anthropicTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"input_schema"`
+}
+
+type anthropicToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
 }
 
 type anthropicMessage struct {
@@ -44,9 +67,15 @@ type anthropicMessage struct {
 
 type anthropicResponse struct {
 	Content []struct {
-		Type string `json:"type"`
-		Text string `json:"text"`
+		Type  string          `json:"type"`
+		Text  string          `json:"text"`
+		Name  string          `json:"name"`  // of a tool_use block
+		Input json.RawMessage `json:"input"` // of a tool_use block
 	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
 	Error *struct {
 		Type    string `json:"type"`
 		Message string `json:"message"`
@@ -62,6 +91,14 @@ func (c *AnthropicClient) Complete(ctx context.Context, systemPrompt, userPrompt
 			{Role: "user", Content: userPrompt},
 		},
 	}
+	if c.structured {
+		reqBody.Tools = []anthropicTool{{
+			Name:        verdictsTool,
+			Description: "Submit the verdict for every model in the changeset.",
+			InputSchema: verdictsSchema,
+		}}
+		reqBody.ToolChoice = &anthropicToolChoice{Type: "tool", Name: verdictsTool}
+	}
 
 	bodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
@@ -105,12 +142,18 @@ func (c *AnthropicClient) Complete(ctx context.Context, systemPrompt, userPrompt
 		return nil, fmt.Errorf("empty response from anthropic")
 	}
 
-	var text string
+	out := &LLMResponse{
+		InputTokens:  anthropicResp.Usage.InputTokens,
+		OutputTokens: anthropicResp.Usage.OutputTokens,
+	}
 	for _, block := range anthropicResp.Content {
-		if block.Type == "text" {
-			text += block.Text
+		switch {
+		case block.Type == "tool_use" && block.Name == verdictsTool:
+			out.Content, out.Structured = string(block.Input), true
+			return out, nil
+		case block.Type == "text":
+			out.Content += block.Text
 		}
 	}
-
-	return &LLMResponse{Content: text}, nil
+	return out, nil
 }
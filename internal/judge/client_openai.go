@@ -11,30 +11,41 @@ import (
 )
 
 // OpenAIClient implements LLMClient using the OpenAI Chat Completions API.
+// By default it asks for structured outputs, a response conforming to the
+// verdict schema.
 type OpenAIClient struct {
-	apiKey    string
-	baseURL   string
-	model     string
-	maxTokens int
-	client    *http.Client
+	apiKey     string
+	baseURL    string
+	model      string
+	maxTokens  int
+	client     *http.Client
+	structured bool
 }
 
 // NewOpenAIClient creates a client for the OpenAI Chat Completions API.
 func NewOpenAIClient(apiKey, baseURL, model string, maxTokens int) *OpenAIClient {
 	return &OpenAIClient{
-		apiKey:    apiKey,
-		baseURL:   baseURL,
-		model:     model,
-		maxTokens: maxTokens,
-		client:    &http.Client{Timeout: 120 * time.Second},
+		apiKey:     apiKey,
+		baseURL:    baseURL,
+		model:      model,
+		maxTokens:  maxTokens,
+		client:     &http.Client{Timeout: 120 * time.Second},
+		structured: true,
 	}
 }
 
+// SetStructured turns structured outputs off, for OpenAI-compatible APIs
+// that only support JSON mode. The verdicts are then parsed out of the
+// text.
+func (c *OpenAIClient) SetStructured(on bool) {
+	c.structured = on
+}
+
 type openaiRequest struct {
-	Model          string           `json:"model"`
-	MaxTokens      int              `json:"max_tokens"`
-	Messages       []openaiMessage  `json:"messages"`
-	ResponseFormat *openaiRespFmt   `json:"response_format,omitempty"`
+	Model          string          `json:"model"`
+	MaxTokens      int             `json:"max_tokens"`
+	Messages       []openaiMessage `json:"messages"`
+	ResponseFormat *openaiRespFmt  `json:"response_format,omitempty"`
 }
 
 type openaiMessage struct {
@@ -43,15 +54,27 @@ type openaiMessage struct {
 }
 
 type openaiRespFmt struct {
-	Type string `json:"type"`
+	Type       string            `json:"type"`
+	JSONSchema *openaiJSONSchema `json:"json_schema,omitempty"`
+}
+
+type openaiJSONSchema struct {
+	Name   string          `json:"name"`
+	Strict bool            `json:"strict"`
+	Schema json.RawMessage `json:"schema"`
 }
 
 type openaiResponse struct {
 	Choices []struct {
 		Message struct {
 			Content string `json:"content"`
+			Refusal string `json:"refusal"`
 		} `json:"message"`
 	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
 	Error *struct {
 		Message string `json:"message"`
 		Type    string `json:"type"`
@@ -70,6 +93,12 @@ func (c *OpenAIClient) Complete(ctx context.Context, systemPrompt, userPrompt st
 		},
 		ResponseFormat: &openaiRespFmt{Type: "json_object"},
 	}
+	if c.structured {
+		reqBody.ResponseFormat = &openaiRespFmt{
+			Type:       "json_schema",
+			JSONSchema: &openaiJSONSchema{Name: verdictsTool, Strict: true, Schema: verdictsSchema},
+		}
+	}
 
 	bodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
@@ -112,5 +141,14 @@ func (c *OpenAIClient) Complete(ctx context.Context, systemPrompt, userPrompt st
 		return nil, fmt.Errorf("empty response from openai")
 	}
 
-	return &LLMResponse{Content: openaiResp.Choices[0].Message.Content}, nil
+	msg := openaiResp.Choices[0].Message
+	if msg.Refusal != "" {
+		return nil, fmt.Errorf("openai refused: %s", msg.Refusal)
+	}
+	return &LLMResponse{
+		Content:      msg.Content,
+		InputTokens:  openaiResp.Usage.PromptTokens,
+		OutputTokens: openaiResp.Usage.CompletionTokens,
+		Structured:   c.structured,
+	}, nil
 }
@@ -0,0 +1,125 @@
+package judge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientsStructuredOutput(t *testing.T) {
+	verdicts := `{"verdicts":[{"model_name":"gpt-5","verdict":"flag","confidence":0.6,"concerns":["odd price"],"reasoning":"check"}]}`
+	tests := []struct {
+		name       string
+		structured bool
+		newClient  func(url string, structured bool) LLMClient
+		// check inspects the request body; respond is the response body.
+		check   func(t *testing.T, req map[string]any)
+		respond string
+	}{
+		{
+			name: "anthropic tool use", structured: true,
+			newClient: func(url string, s bool) LLMClient {
+				c := NewAnthropicClient("key", url, "claude", 1024)
+				c.SetStructured(s)
+				return c
+			},
+			check: func(t *testing.T, req map[string]any) {
+				choice, _ := req["tool_choice"].(map[string]any)
+				if choice["name"] != verdictsTool || len(req["tools"].([]any)) != 1 {
+					t.Errorf("tools = %v, tool_choice = %v", req["tools"], req["tool_choice"])
+				}
+			},
+			respond: `{"content":[{"type":"text","text":"Here you go."},{"type":"tool_use","name":"submit_verdicts","input":` + verdicts + `}],"usage":{"input_tokens":10,"output_tokens":5}}`,
+		},
+		{
+			name: "anthropic text", structured: false,
+			newClient: func(url string, s bool) LLMClient {
+				c := NewAnthropicClient("key", url, "claude", 1024)
+				c.SetStructured(s)
+				return c
+			},
+			check: func(t *testing.T, req map[string]any) {
+				if req["tools"] != nil || req["tool_choice"] != nil {
+					t.Errorf("tools sent with structured output off: %v", req["tools"])
+				}
+			},
+			respond: `{"content":[{"type":"text","text":` + jsonString("```json\n"+verdicts+"\n```") + `}],"usage":{"input_tokens":10,"output_tokens":5}}`,
+		},
+		{
+			name: "openai json schema", structured: true,
+			newClient: func(url string, s bool) LLMClient {
+				c := NewOpenAIClient("key", url, "gpt", 1024)
+				c.SetStructured(s)
+				return c
+			},
+			check: func(t *testing.T, req map[string]any) {
+				format, _ := req["response_format"].(map[string]any)
+				schema, _ := format["json_schema"].(map[string]any)
+				if format["type"] != "json_schema" || schema["strict"] != true || schema["schema"] == nil {
+					t.Errorf("response_format = %v", req["response_format"])
+				}
+			},
+			respond: `{"choices":[{"message":{"content":` + jsonString(verdicts) + `}}],"usage":{"prompt_tokens":10,"completion_tokens":5}}`,
+		},
+		{
+			name: "openai json mode", structured: false,
+			newClient: func(url string, s bool) LLMClient {
+				c := NewOpenAIClient("key", url, "gpt", 1024)
+				c.SetStructured(s)
+				return c
+			},
+			check: func(t *testing.T, req map[string]any) {
+				format, _ := req["response_format"].(map[string]any)
+				if format["type"] != "json_object" {
+					t.Errorf("response_format = %v", req["response_format"])
+				}
+			},
+			respond: `{"choices":[{"message":{"content":` + jsonString(verdicts) + `}}],"usage":{"prompt_tokens":10,"completion_tokens":5}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Error(err)
+				}
+				tt.check(t, req)
+				_, _ = w.Write([]byte(tt.respond))
+			}))
+			defer srv.Close()
+
+			resp, err := tt.newClient(srv.URL, tt.structured).Complete(context.Background(), "system", "user")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Structured != tt.structured || resp.InputTokens != 10 || resp.OutputTokens != 5 {
+				t.Errorf("response = %+v", resp)
+			}
+			result, err := parseLLMResponse(resp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(result.Verdicts) != 1 || result.Verdicts[0].Verdict != VerdictFlag || result.Verdicts[0].Source != SourceLLM {
+				t.Errorf("verdicts = %+v", result.Verdicts)
+			}
+		})
+	}
+}
+
+func TestOpenAIClientRefusal(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":null,"refusal":"I can't help with that."}}]}`))
+	}))
+	defer srv.Close()
+	if _, err := NewOpenAIClient("key", srv.URL, "gpt", 1024).Complete(context.Background(), "system", "user"); err == nil {
+		t.Error("a refusal was not an error")
+	}
+}
+
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,109 @@
+package judge
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/everstacklabs/sentinel/internal/diff"
+)
+
+// Pricing is what the judge model's tokens cost, in USD per million.
+type Pricing struct {
+	InputPer1M  float64
+	OutputPer1M float64
+}
+
+// IsZero reports whether the pricing is unknown.
+func (p Pricing) IsZero() bool {
+	return p.InputPer1M == 0 && p.OutputPer1M == 0
+}
+
+// Cost is what the tokens cost in USD.
+func (p Pricing) Cost(inputTokens, outputTokens int) float64 {
+	return (float64(inputTokens)*p.InputPer1M + float64(outputTokens)*p.OutputPer1M) / 1e6
+}
+
+// Usage is the tokens a judge call used, from the provider's usage fields,
+// and what they cost. CostUSD is 0 when the judge model's pricing is
+// unknown.
+type Usage struct {
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// Budget caps what a run spends on judge calls across every changeset it
+// evaluates. It is checked before each call, so the call that crosses the
+// cap still completes. Safe for concurrent use.
+type Budget struct {
+	limit float64 // USD; 0 means no cap
+
+	mu    sync.Mutex
+	spent Usage
+}
+
+// NewBudget returns a budget of limitUSD. Zero only tracks spend.
+func NewBudget(limitUSD float64) *Budget {
+	return &Budget{limit: limitUSD}
+}
+
+// Limit returns the cap in USD, 0 for none.
+func (b *Budget) Limit() float64 { return b.limit }
+
+// Exhausted reports whether the run has spent its cap.
+func (b *Budget) Exhausted() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.limit > 0 && b.spent.CostUSD >= b.limit
+}
+
+// Spent returns the run's judge usage so far.
+func (b *Budget) Spent() Usage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.spent
+}
+
+func (b *Budget) add(u Usage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent.InputTokens += u.InputTokens
+	b.spent.OutputTokens += u.OutputTokens
+	b.spent.CostUSD += u.CostUSD
+}
+
+// WithPricing prices the judge model's tokens, so each result carries its
+// cost and a Budget can cap it.
+func WithPricing(p Pricing) Option {
+	return func(j *Judge) { j.pricing = p }
+}
+
+// WithBudget charges every LLM call to b. Once b is exhausted, changesets
+// are no longer sent to the LLM: their models are flagged for a person to
+// review instead.
+func WithBudget(b *Budget) Option {
+	return func(j *Judge) { j.budget = b }
+}
+
+// cappedResult flags every model the judge would have evaluated, for a
+// changeset left over once the spend cap is reached.
+func cappedResult(cs *diff.ChangeSet, limit float64) *Result {
+	concern := fmt.Sprintf("not evaluated: the judge spend cap of $%.2f for this run was reached", limit)
+	result := &Result{Capped: true}
+	flag := func(name string) {
+		result.Verdicts = append(result.Verdicts, ModelVerdict{
+			ModelName: name,
+			Verdict:   VerdictFlag,
+			Concerns:  []string{concern},
+			Reasoning: "Review this model by hand.",
+			Source:    SourceSpendCap,
+		})
+	}
+	for _, m := range cs.New {
+		flag(m.Name)
+	}
+	for _, u := range cs.Updated {
+		flag(u.Name)
+	}
+	return result
+}
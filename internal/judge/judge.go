@@ -2,11 +2,16 @@ package judge
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
+	"time"
 
+	"github.com/everstacklabs/sentinel/internal/cache"
 	"github.com/everstacklabs/sentinel/internal/diff"
 )
 
@@ -34,11 +39,15 @@ type ModelVerdict struct {
 	Confidence float64  `json:"confidence"`
 	Concerns   []string `json:"concerns"`
 	Reasoning  string   `json:"reasoning"`
+	Source     string   `json:"source,omitempty"` // SourceLLM, SourceRule or SourceSpendCap
+	Rule       string   `json:"rule,omitempty"`   // the screening rule, for SourceRule
 }
 
 // Result holds the complete judge evaluation.
 type Result struct {
 	Verdicts []ModelVerdict `json:"verdicts"`
+	Usage    *Usage         `json:"usage,omitempty"`  // of the LLM call; nil for cached verdicts
+	Capped   bool           `json:"capped,omitempty"` // not evaluated: the run's spend cap was reached
 }
 
 // HasRejections reports whether any model was rejected.
@@ -74,7 +83,12 @@ func (r *Result) RejectedNames() []string {
 
 // LLMResponse is the raw response from an LLM provider.
 type LLMResponse struct {
-	Content string
+	Content      string
+	InputTokens  int
+	OutputTokens int
+	// Structured means the provider held Content to the verdict schema,
+	// so it is decoded as is rather than searched for a JSON object.
+	Structured bool
 }
 
 // LLMClient abstracts LLM API calls for testability.
@@ -87,15 +101,56 @@ type Judge struct {
 	client   LLMClient
 	model    string
 	disabled bool
+	cache    cache.Cache
+	audit    *AuditLog
+	prompt   string // system prompt override
+	rubric   Rubric
+	pricing  Pricing
+	budget   *Budget
+	screen   bool
+}
+
+// Option configures a Judge.
+type Option func(*Judge)
+
+// WithCache reuses verdicts for identical changesets, so repeated dry runs
+// don't pay for the same evaluation twice. Freshness is the cache's TTL.
+func WithCache(c cache.Cache) Option {
+	return func(j *Judge) { j.cache = c }
+}
+
+// WithAuditLog records every evaluation, including cache hits and failed
+// calls, in an audit log.
+func WithAuditLog(l *AuditLog) Option {
+	return func(j *Judge) { j.audit = l }
+}
+
+// WithSystemPrompt replaces the built-in system prompt, e.g. to try a
+// revised prompt with judge replay before shipping it.
+func WithSystemPrompt(prompt string) Option {
+	return func(j *Judge) { j.prompt = prompt }
+}
+
+// WithScreening decides clear-cut models with deterministic rules before
+// the LLM is called, such as rejecting an embedding model with the chat
+// capability, and sends only the rest to the LLM. When the rules settle
+// every model, there is no LLM call at all. With a rubric, the rules only
+// reject, so every approval is the LLM's under the rubric.
+func WithScreening() Option {
+	return func(j *Judge) { j.screen = true }
 }
 
 // New creates a new Judge. If disabled is true, Evaluate returns nil.
-func New(client LLMClient, model string, disabled bool) *Judge {
-	return &Judge{
+func New(client LLMClient, model string, disabled bool, opts ...Option) *Judge {
+	j := &Judge{
 		client:   client,
 		model:    model,
 		disabled: disabled,
 	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
 }
 
 // Evaluate sends the changeset to the LLM for review.
@@ -109,22 +164,153 @@ func (j *Judge) Evaluate(ctx context.Context, cs *diff.ChangeSet) (*Result, erro
 		return nil, nil
 	}
 
-	systemPrompt := buildSystemPrompt()
+	if !j.screen {
+		return j.evaluate(ctx, cs)
+	}
+	screened, rest := screen(cs, j.rubric.prompt() == "")
+	if len(screened) > 0 {
+		slog.Info("judge screening rules decided models", "provider", cs.Provider, "screened", len(screened),
+			"left_for_llm", len(rest.New)+len(rest.Updated))
+		j.record(cs, j.systemPrompt(), &AuditRecord{Screened: true, Verdicts: screened})
+	}
+	if len(rest.New) == 0 && len(rest.Updated) == 0 && len(screened) > 0 {
+		return &Result{Verdicts: screened}, nil
+	}
+	result, err := j.evaluate(ctx, rest)
+	if err != nil || result == nil {
+		return result, err
+	}
+	result.Verdicts = append(screened, result.Verdicts...)
+	return result, nil
+}
+
+// evaluate sends the changeset to the LLM, or answers from the cache.
+func (j *Judge) evaluate(ctx context.Context, cs *diff.ChangeSet) (*Result, error) {
+	systemPrompt := j.systemPrompt()
 	userPrompt := buildUserPrompt(cs)
 
+	key := j.cacheKey(cs, systemPrompt)
+	if cached := j.cached(key); cached != nil {
+		slog.Info("judge verdicts reused from cache", "provider", cs.Provider, "verdicts", len(cached.Verdicts))
+		j.record(cs, systemPrompt, &AuditRecord{Cached: true, Verdicts: cached.Verdicts})
+		return cached, nil
+	}
+
+	if j.budget != nil && j.budget.Exhausted() {
+		result := cappedResult(cs, j.budget.Limit())
+		slog.Warn("judge spend cap reached, flagging models without evaluation",
+			"provider", cs.Provider, "cap_usd", j.budget.Limit(), "models", len(result.Verdicts))
+		j.record(cs, systemPrompt, &AuditRecord{Prompt: userPrompt, Verdicts: result.Verdicts, Error: "judge spend cap reached"})
+		return result, nil
+	}
+
+	start := time.Now()
 	resp, err := j.client.Complete(ctx, systemPrompt, userPrompt)
+	rec := &AuditRecord{Prompt: userPrompt, LatencyMS: time.Since(start).Milliseconds()}
 	if err != nil {
+		rec.Error = err.Error()
+		j.record(cs, systemPrompt, rec)
 		return nil, fmt.Errorf("LLM call failed: %w", err)
 	}
+	rec.Response = resp.Content
+	rec.InputTokens, rec.OutputTokens = resp.InputTokens, resp.OutputTokens
+	usage := Usage{
+		InputTokens:  resp.InputTokens,
+		OutputTokens: resp.OutputTokens,
+		CostUSD:      j.pricing.Cost(resp.InputTokens, resp.OutputTokens),
+	}
+	rec.CostUSD = usage.CostUSD
+	if j.budget != nil {
+		j.budget.add(usage)
+	}
 
-	result, err := parseResponse(resp.Content)
+	result, err := parseLLMResponse(resp)
 	if err != nil {
+		rec.Error = err.Error()
+		j.record(cs, systemPrompt, rec)
 		return nil, fmt.Errorf("parsing LLM response: %w", err)
 	}
+	rec.Verdicts = result.Verdicts
+	j.record(cs, systemPrompt, rec)
 
+	j.store(key, result)
+	result.Usage = &usage
 	return result, nil
 }
 
+// record fills in what identifies an evaluation and appends it to the audit
+// log. A failing log is worth a warning, not a failed run.
+func (j *Judge) record(cs *diff.ChangeSet, systemPrompt string, rec *AuditRecord) {
+	if j.audit == nil {
+		return
+	}
+	rec.Time = time.Now().UTC()
+	rec.Provider = cs.Provider
+	rec.Model = j.model
+	rec.PromptHash = promptHash(systemPrompt)
+	rec.ChangeSetHash, _ = cs.Hash()
+	if err := j.audit.Append(rec); err != nil {
+		slog.Warn("writing judge audit log failed", "error", err)
+	}
+}
+
+func (j *Judge) systemPrompt() string {
+	prompt := j.prompt
+	if prompt == "" {
+		prompt = buildSystemPrompt()
+	}
+	return prompt + j.rubric.prompt()
+}
+
+// promptHash is a short, stable identifier of a system prompt version.
+func promptHash(systemPrompt string) string {
+	sum := sha256.Sum256([]byte(systemPrompt))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cacheKey identifies an evaluation by changeset, judge model and system
+// prompt, so a prompt or model change never reuses old verdicts. It returns
+// "" when caching is off or the changeset can't be hashed.
+func (j *Judge) cacheKey(cs *diff.ChangeSet, systemPrompt string) string {
+	if j.cache == nil {
+		return ""
+	}
+	csHash, err := cs.Hash()
+	if err != nil {
+		slog.Warn("judge cache disabled for changeset", "error", err)
+		return ""
+	}
+	return "judge:" + j.model + ":" + promptHash(systemPrompt) + ":" + csHash
+}
+
+func (j *Judge) cached(key string) *Result {
+	if key == "" {
+		return nil
+	}
+	entry, fresh := j.cache.Get(key)
+	if !fresh {
+		return nil
+	}
+	var result Result
+	if err := json.Unmarshal(entry.Body, &result); err != nil {
+		return nil
+	}
+	return &result
+}
+
+func (j *Judge) store(key string, result *Result) {
+	if key == "" {
+		return
+	}
+	body, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	if err := j.cache.Set(key, &cache.Entry{Body: body, StatusCode: http.StatusOK}); err != nil {
+		slog.Warn("caching judge verdicts failed", "error", err)
+	}
+}
+
 // ApplyToChangeSet applies the judge result to the changeset.
 // Returns forceDraft=true when behavior is "draft" and there are rejections.
 // When behavior is "exclude", rejected models are removed from the changeset.
@@ -180,12 +366,24 @@ func ApplyToChangeSet(cs *diff.ChangeSet, result *Result, behavior OnRejectBehav
 }
 
 // parseResponse extracts the JSON verdict array from the LLM response text.
+// parseLLMResponse decodes the verdicts of a structured response as they
+// are, and searches free-form ones for the JSON object.
+func parseLLMResponse(resp *LLMResponse) (*Result, error) {
+	if resp.Structured {
+		return decodeResult(resp.Content)
+	}
+	return parseResponse(resp.Content)
+}
+
 func parseResponse(content string) (*Result, error) {
 	jsonStr, err := extractJSON(content)
 	if err != nil {
 		return nil, err
 	}
+	return decodeResult(jsonStr)
+}
 
+func decodeResult(jsonStr string) (*Result, error) {
 	var result Result
 	if err := json.Unmarshal([]byte(jsonStr), &result); err != nil {
 		return nil, fmt.Errorf("unmarshaling judge response: %w", err)
@@ -202,6 +400,7 @@ func parseResponse(content string) (*Result, error) {
 		if v.Confidence < 0 || v.Confidence > 1 {
 			result.Verdicts[i].Confidence = clamp(v.Confidence, 0, 1)
 		}
+		result.Verdicts[i].Source = SourceLLM
 	}
 
 	return &result, nil
@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/everstacklabs/sentinel/internal/cache"
 	"github.com/everstacklabs/sentinel/internal/catalog"
 	"github.com/everstacklabs/sentinel/internal/diff"
 )
@@ -15,13 +18,15 @@ import (
 type mockClient struct {
 	response string
 	err      error
+	calls    int
 }
 
 func (m *mockClient) Complete(_ context.Context, _, _ string) (*LLMResponse, error) {
+	m.calls++
 	if m.err != nil {
 		return nil, m.err
 	}
-	return &LLMResponse{Content: m.response}, nil
+	return &LLMResponse{Content: m.response, InputTokens: 1200, OutputTokens: 300}, nil
 }
 
 func makeChangeSet() *diff.ChangeSet {
@@ -139,6 +144,48 @@ func TestEvaluate_Disabled(t *testing.T) {
 	}
 }
 
+func TestEvaluate_CachesVerdictsByChangeSet(t *testing.T) {
+	fc, err := cache.New(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &mockClient{response: withRejectionResponse()}
+	j := New(client, "test-model", false, WithCache(fc))
+	ctx := context.Background()
+
+	first, err := j.Evaluate(ctx, makeChangeSet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := j.Evaluate(ctx, makeChangeSet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.calls != 1 {
+		t.Errorf("LLM called %d times, want 1 for an identical changeset", client.calls)
+	}
+	if !second.HasRejections() || len(second.Verdicts) != len(first.Verdicts) {
+		t.Errorf("cached result = %+v, want %+v", second, first)
+	}
+
+	changed := makeChangeSet()
+	changed.New[0].Model.Limits.MaxTokens++
+	if _, err := j.Evaluate(ctx, changed); err != nil {
+		t.Fatal(err)
+	}
+	if client.calls != 2 {
+		t.Errorf("LLM called %d times, want a fresh call for a different changeset", client.calls)
+	}
+
+	other := New(client, "other-model", false, WithCache(fc))
+	if _, err := other.Evaluate(ctx, makeChangeSet()); err != nil {
+		t.Fatal(err)
+	}
+	if client.calls != 3 {
+		t.Errorf("LLM called %d times, want a fresh call for a different judge model", client.calls)
+	}
+}
+
 func TestEvaluate_NoChanges(t *testing.T) {
 	client := &mockClient{response: "should not be called"}
 	j := New(client, "test-model", false)
@@ -428,3 +475,95 @@ func TestBuildUserPrompt_IncludesModels(t *testing.T) {
 		t.Error("expected Updated Models section")
 	}
 }
+
+func TestSystemPrompt_Rubric(t *testing.T) {
+	plain := New(&mockClient{}, "test-model", false)
+	empty := New(&mockClient{}, "test-model", false, WithRubric(Rubric{}))
+	if empty.systemPrompt() != plain.systemPrompt() {
+		t.Error("an empty rubric changed the system prompt")
+	}
+
+	rubric := Rubric{
+		Rules:    []string{"Reject any acme model without pricing."},
+		Examples: []Example{{Model: "acme-1 with no cost block", Verdict: VerdictReject, Concerns: []string{"no pricing"}, Reasoning: "acme always publishes prices"}},
+	}
+	for _, j := range []*Judge{
+		New(&mockClient{}, "test-model", false, WithRubric(rubric)),
+		New(&mockClient{}, "test-model", false, WithRubric(rubric), WithSystemPrompt("Custom prompt.")),
+	} {
+		prompt := j.systemPrompt()
+		for _, want := range []string{
+			"- Reject any acme model without pricing.",
+			"Model: acme-1 with no cost block\nVerdict: reject\nConcerns: no pricing\nReasoning: acme always publishes prices",
+		} {
+			if !strings.Contains(prompt, want) {
+				t.Errorf("system prompt lacks %q:\n%s", want, prompt)
+			}
+		}
+		if promptHash(prompt) == promptHash(plain.systemPrompt()) {
+			t.Error("the rubric didn't change the prompt hash")
+		}
+	}
+}
+
+func TestEvaluate_SpendCap(t *testing.T) {
+	fc, err := cache.New(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &mockClient{response: allApprovedResponse()}
+	budget := NewBudget(0.01)
+	// 1200 input and 300 output tokens a call: $0.0081.
+	j := New(client, "test-model", false, WithCache(fc), WithPricing(Pricing{InputPer1M: 3, OutputPer1M: 15}), WithBudget(budget))
+	ctx := context.Background()
+
+	first, err := j.Evaluate(ctx, makeChangeSet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Usage == nil || first.Usage.InputTokens != 1200 || first.Usage.OutputTokens != 300 || math.Abs(first.Usage.CostUSD-0.0081) > 1e-9 {
+		t.Errorf("usage = %+v, want 1200+300 tokens for $0.0081", first.Usage)
+	}
+
+	// Cached verdicts are free and still served once the cap is reached.
+	cached, err := j.Evaluate(ctx, makeChangeSet())
+	if err != nil || cached.Usage != nil || cached.Capped {
+		t.Errorf("cached = %+v, %v; want verdicts without usage", cached, err)
+	}
+
+	second := makeChangeSet()
+	second.New[0].Model.Limits.MaxTokens++
+	if _, err := j.Evaluate(ctx, second); err != nil {
+		t.Fatal(err)
+	}
+	if !budget.Exhausted() || budget.Spent().InputTokens != 2400 {
+		t.Fatalf("spent %+v, want the $0.01 cap exhausted", budget.Spent())
+	}
+
+	third := makeChangeSet()
+	third.New[0].Model.Limits.MaxTokens += 2
+	capped, err := j.Evaluate(ctx, third)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.calls != 2 {
+		t.Errorf("LLM called %d times, want 2 before the cap", client.calls)
+	}
+	if !capped.Capped || len(capped.Verdicts) != 2 || !capped.HasFlags() || capped.Usage != nil {
+		t.Errorf("capped result = %+v, want both models flagged", capped)
+	}
+	if section := RenderSection(capped); !strings.Contains(section, "spend cap") {
+		t.Errorf("section lacks the spend cap note:\n%s", section)
+	}
+}
+
+func TestRenderSection_Cost(t *testing.T) {
+	result := &Result{
+		Verdicts: []ModelVerdict{{ModelName: "gpt-5", Verdict: VerdictApprove, Confidence: 0.95}},
+		Usage:    &Usage{InputTokens: 1200, OutputTokens: 300, CostUSD: 0.0081},
+	}
+	section := RenderSection(result)
+	if !strings.Contains(section, "Judge cost: $0.0081 (1200 input, 300 output tokens)") {
+		t.Errorf("section lacks the judge cost:\n%s", section)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/everstacklabs/sentinel/internal/catalog"
 	"github.com/everstacklabs/sentinel/internal/diff"
 )
 
@@ -14,10 +15,11 @@ func buildSystemPrompt() string {
 For each model in the changeset, evaluate:
 
 1. **Capabilities**: Are the inferred capabilities reasonable for this model type? (e.g., an embedding model should NOT have "chat" or "function_calling")
-2. **Pricing**: Is the pricing plausible? Compare against known market rates. Flag suspiciously high or low prices.
+2. **Pricing**: Is the pricing plausible? Compare against known market rates. Flag suspiciously high or low prices. A reasoning_per_1k price applies to reasoning/thinking tokens and is usually equal to the output price; it only makes sense for models with the "reasoning" or "extended_thinking" capability. cache_write_per_1k and cache_read_per_1k price explicit prompt caching: writes usually cost somewhat more than input (Anthropic charges 1.25x), reads a fraction of it (Anthropic charges 0.1x). batch_input_per_1k and batch_output_per_1k are batch API prices, usually half the standard ones and never above them.
 3. **Limits**: Are the token limits reasonable? (e.g., max_completion_tokens should not exceed max_tokens, context windows should match known specs)
 4. **Status**: Is the status appropriate? (e.g., a brand-new model shouldn't be "deprecated")
 5. **Changes**: For updated models, are the field changes plausible? (e.g., a price dropping 90% is suspicious)
+6. **Provenance**: "sources" and a change's "source" say where a value came from: "api" (the provider's API), "docs" (its documentation or pricing pages), "llm" (extracted by an LLM) or "manual" (set by a person). A change's "previous_source" is where the value it replaces came from. Be more skeptical of docs and llm values, and flag changes that overwrite a manual value.
 
 Respond with a JSON object containing a "verdicts" array. Each verdict must have:
 - "model_name": the model identifier
@@ -56,11 +58,17 @@ func buildUserPrompt(cs *diff.ChangeSet) string {
 					MaxTokens:           m.Model.Limits.MaxTokens,
 					MaxCompletionTokens: m.Model.Limits.MaxCompletionTokens,
 				},
+				Sources: sourcesOf(m.Model.FieldSources()),
 			}
 			if m.Model.Cost != nil {
 				data.Cost = &costSummary{
-					InputPer1K:  m.Model.Cost.InputPer1K,
-					OutputPer1K: m.Model.Cost.OutputPer1K,
+					InputPer1K:       m.Model.Cost.InputPer1K,
+					OutputPer1K:      m.Model.Cost.OutputPer1K,
+					ReasoningPer1K:   m.Model.Cost.ReasoningPer1K,
+					CacheWritePer1K:  m.Model.Cost.CacheWritePer1K,
+					CacheReadPer1K:   m.Model.Cost.CacheReadPer1K,
+					BatchInputPer1K:  m.Model.Cost.BatchInputPer1K,
+					BatchOutputPer1K: m.Model.Cost.BatchOutputPer1K,
 				}
 			}
 			jsonBytes, _ := json.MarshalIndent(data, "", "  ")
@@ -74,11 +82,15 @@ func buildUserPrompt(cs *diff.ChangeSet) string {
 			data := updateSummary{
 				Name: u.Name,
 			}
+			sources := u.Model.FieldSources()
 			for _, c := range u.Changes {
+				top := diff.TopField(c.Field)
 				data.Changes = append(data.Changes, changeSummary{
-					Field:    c.Field,
-					OldValue: c.OldValue,
-					NewValue: c.NewValue,
+					Field:          c.Field,
+					OldValue:       c.OldValue,
+					NewValue:       c.NewValue,
+					Source:         sources[top].Source,
+					PreviousSource: u.Provenance[top].Source,
 				})
 			}
 			// Include full model state for context
@@ -98,8 +110,13 @@ func buildUserPrompt(cs *diff.ChangeSet) string {
 			}
 			if u.Model.Cost != nil {
 				data.CurrentState.Cost = &costSummary{
-					InputPer1K:  u.Model.Cost.InputPer1K,
-					OutputPer1K: u.Model.Cost.OutputPer1K,
+					InputPer1K:       u.Model.Cost.InputPer1K,
+					OutputPer1K:      u.Model.Cost.OutputPer1K,
+					ReasoningPer1K:   u.Model.Cost.ReasoningPer1K,
+					CacheWritePer1K:  u.Model.Cost.CacheWritePer1K,
+					CacheReadPer1K:   u.Model.Cost.CacheReadPer1K,
+					BatchInputPer1K:  u.Model.Cost.BatchInputPer1K,
+					BatchOutputPer1K: u.Model.Cost.BatchOutputPer1K,
 				}
 			}
 			jsonBytes, _ := json.MarshalIndent(data, "", "  ")
@@ -110,6 +127,18 @@ func buildUserPrompt(cs *diff.ChangeSet) string {
 	return b.String()
 }
 
+// sourcesOf flattens field provenance to the source of each field.
+func sourcesOf(fields map[string]catalog.FieldSource) map[string]string {
+	if len(fields) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(fields))
+	for f, fs := range fields {
+		out[f] = fs.Source
+	}
+	return out
+}
+
 type modelSummary struct {
 	Name         string          `json:"name"`
 	Family       string          `json:"family"`
@@ -118,6 +147,7 @@ type modelSummary struct {
 	Modalities   modalitySummary `json:"modalities"`
 	Limits       limitsSummary   `json:"limits"`
 	Cost         *costSummary    `json:"cost,omitempty"`
+	Sources      map[string]string `json:"sources,omitempty"`
 }
 
 type modalitySummary struct {
@@ -131,8 +161,13 @@ type limitsSummary struct {
 }
 
 type costSummary struct {
-	InputPer1K  float64 `json:"input_per_1k"`
-	OutputPer1K float64 `json:"output_per_1k"`
+	InputPer1K       float64 `json:"input_per_1k"`
+	OutputPer1K      float64 `json:"output_per_1k"`
+	ReasoningPer1K   float64 `json:"reasoning_per_1k,omitempty"`
+	CacheWritePer1K  float64 `json:"cache_write_per_1k,omitempty"`
+	CacheReadPer1K   float64 `json:"cache_read_per_1k,omitempty"`
+	BatchInputPer1K  float64 `json:"batch_input_per_1k,omitempty"`
+	BatchOutputPer1K float64 `json:"batch_output_per_1k,omitempty"`
 }
 
 type updateSummary struct {
@@ -145,4 +180,6 @@ type changeSummary struct {
 	Field    string      `json:"field"`
 	OldValue interface{} `json:"old_value"`
 	NewValue interface{} `json:"new_value"`
+	Source         string `json:"source,omitempty"`
+	PreviousSource string `json:"previous_source,omitempty"`
 }
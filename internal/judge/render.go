@@ -5,8 +5,32 @@ import (
 	"strings"
 )
 
+// RenderComment formats a single verdict as a PR review comment on the
+// model's file.
+func RenderComment(v ModelVerdict) string {
+	var b strings.Builder
+	label := "flagged"
+	if v.Verdict == VerdictReject {
+		label = "rejected"
+	}
+	fmt.Fprintf(&b, "**LLM judge: %s** `%s` (confidence %.0f%%)\n\n", label, v.ModelName, v.Confidence*100)
+	if len(v.Concerns) > 0 {
+		b.WriteString("Concerns:\n")
+		for _, c := range v.Concerns {
+			fmt.Fprintf(&b, "- %s\n", c)
+		}
+		b.WriteString("\n")
+	}
+	if v.Reasoning != "" {
+		b.WriteString(v.Reasoning)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 // RenderSection generates a markdown section for the PR body summarizing judge results.
-// Returns an empty string when all models are approved or result is nil.
+// Returns an empty string when result is nil, or when all models are
+// approved and the evaluation cost nothing, such as cached verdicts.
 func RenderSection(result *Result) string {
 	if result == nil {
 		return ""
@@ -22,7 +46,7 @@ func RenderSection(result *Result) string {
 		}
 	}
 
-	if len(flagged) == 0 && len(rejected) == 0 {
+	if len(flagged) == 0 && len(rejected) == 0 && (result.Usage == nil || result.Usage.CostUSD == 0) {
 		return ""
 	}
 
@@ -34,6 +58,14 @@ func RenderSection(result *Result) string {
 	fmt.Fprintf(&b, "**%d** approved, **%d** flagged, **%d** rejected\n\n",
 		approved, len(flagged), len(rejected))
 
+	switch {
+	case result.Capped:
+		b.WriteString("The judge spend cap for the run was reached before this changeset, so its models are flagged without evaluation.\n\n")
+	case result.Usage != nil && result.Usage.CostUSD > 0:
+		fmt.Fprintf(&b, "Judge cost: $%.4f (%d input, %d output tokens)\n\n",
+			result.Usage.CostUSD, result.Usage.InputTokens, result.Usage.OutputTokens)
+	}
+
 	if len(rejected) > 0 {
 		b.WriteString("<details>\n<summary>Rejected Models</summary>\n\n")
 		b.WriteString("| Model | Confidence | Concerns | Reasoning |\n")
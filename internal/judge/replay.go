@@ -0,0 +1,119 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Replay is a recorded evaluation judged again, possibly by another model
+// or with another system prompt.
+type Replay struct {
+	Original     *AuditRecord    `json:"original"`
+	Model        string          `json:"model"`
+	PromptHash   string          `json:"prompt_hash"`
+	Verdicts     []ModelVerdict  `json:"verdicts"`
+	Changes      []VerdictChange `json:"changes"`
+	InputTokens  int             `json:"input_tokens,omitempty"`
+	OutputTokens int             `json:"output_tokens,omitempty"`
+	LatencyMS    int64           `json:"latency_ms,omitempty"`
+}
+
+// VerdictChange is a model whose verdict differs between two evaluations.
+// An empty verdict means the evaluation had none for the model.
+type VerdictChange struct {
+	ModelName string  `json:"model_name"`
+	Before    Verdict `json:"before"`
+	After     Verdict `json:"after"`
+}
+
+// Replay sends the changeset of a recorded evaluation to this judge's model
+// and compares the verdicts with the recorded ones. It bypasses the cache
+// and doesn't write to the audit log, so experiments don't mix with
+// production verdicts.
+func (j *Judge) Replay(ctx context.Context, rec *AuditRecord) (*Replay, error) {
+	if rec.Prompt == "" {
+		return nil, fmt.Errorf("audit record has no recorded changeset prompt")
+	}
+	systemPrompt := j.systemPrompt()
+
+	start := time.Now()
+	resp, err := j.client.Complete(ctx, systemPrompt, rec.Prompt)
+	if err != nil {
+		return nil, fmt.Errorf("LLM call failed: %w", err)
+	}
+	latency := time.Since(start).Milliseconds()
+
+	result, err := parseLLMResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing LLM response: %w", err)
+	}
+	return &Replay{
+		Original:     rec,
+		Model:        j.model,
+		PromptHash:   promptHash(systemPrompt),
+		Verdicts:     result.Verdicts,
+		Changes:      CompareVerdicts(rec.Verdicts, result.Verdicts),
+		InputTokens:  resp.InputTokens,
+		OutputTokens: resp.OutputTokens,
+		LatencyMS:    latency,
+	}, nil
+}
+
+// CompareVerdicts lists the models whose verdict differs between before and
+// after, sorted by model name.
+func CompareVerdicts(before, after []ModelVerdict) []VerdictChange {
+	verdicts := make(map[string]*VerdictChange)
+	for _, v := range before {
+		verdicts[v.ModelName] = &VerdictChange{ModelName: v.ModelName, Before: v.Verdict}
+	}
+	for _, v := range after {
+		c, ok := verdicts[v.ModelName]
+		if !ok {
+			c = &VerdictChange{ModelName: v.ModelName}
+			verdicts[v.ModelName] = c
+		}
+		c.After = v.Verdict
+	}
+
+	var changes []VerdictChange
+	for _, c := range verdicts {
+		if c.Before != c.After {
+			changes = append(changes, *c)
+		}
+	}
+	sort.Slice(changes, func(i, k int) bool { return changes[i].ModelName < changes[k].ModelName })
+	return changes
+}
+
+// FindAuditRecord returns the most recent evaluation in the log at path
+// whose changeset hash starts with id and that reached the LLM, i.e. has a
+// recorded prompt and verdicts.
+func FindAuditRecord(path, id string) (*AuditRecord, error) {
+	if id == "" {
+		return nil, fmt.Errorf("run id is required")
+	}
+	records, err := ReadAuditLog(path, AuditFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	var found *AuditRecord
+	for i := len(records) - 1; i >= 0; i-- {
+		rec := &records[i]
+		if !strings.HasPrefix(rec.ChangeSetHash, id) || rec.Prompt == "" || rec.Error != "" {
+			continue
+		}
+		if found == nil {
+			found = rec
+		} else if found.ChangeSetHash != rec.ChangeSetHash {
+			return nil, fmt.Errorf("run id %q is ambiguous, give more of the hash", id)
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no judged run %q in %s", id, path)
+	}
+	return found, nil
+}
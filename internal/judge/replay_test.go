@@ -0,0 +1,77 @@
+package judge
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type promptRecorder struct {
+	mockClient
+	system, user string
+}
+
+func (p *promptRecorder) Complete(ctx context.Context, system, user string) (*LLMResponse, error) {
+	p.system, p.user = system, user
+	return p.mockClient.Complete(ctx, system, user)
+}
+
+func TestReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	recorded := New(&mockClient{response: withRejectionResponse()}, "old-model", false, WithAuditLog(NewAuditLog(path)))
+	if _, err := recorded.Evaluate(context.Background(), makeChangeSet()); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := ReadAuditLog(path, AuditFilter{})
+	if err != nil || len(records) != 1 {
+		t.Fatalf("records = %v, %v", records, err)
+	}
+	rec, err := FindAuditRecord(path, records[0].ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &promptRecorder{mockClient: mockClient{response: allApprovedResponse()}}
+	r, err := New(client, "new-model", false, WithSystemPrompt("be strict")).Replay(context.Background(), rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.system != "be strict" || client.user != rec.Prompt {
+		t.Errorf("replay sent system %q and a different changeset prompt", client.system)
+	}
+	if r.Model != "new-model" || r.PromptHash == rec.PromptHash {
+		t.Errorf("replay = model %s, prompt %s; want the new model and prompt", r.Model, r.PromptHash)
+	}
+	want := []VerdictChange{{ModelName: "gpt-5", Before: VerdictReject, After: VerdictApprove}}
+	if !reflect.DeepEqual(r.Changes, want) {
+		t.Errorf("changes = %+v, want %+v", r.Changes, want)
+	}
+
+	if _, err := FindAuditRecord(path, "ffffffff"); err == nil || !strings.Contains(err.Error(), "no judged run") {
+		t.Errorf("unknown run id: err = %v", err)
+	}
+}
+
+func TestCompareVerdicts(t *testing.T) {
+	before := []ModelVerdict{
+		{ModelName: "b", Verdict: VerdictApprove},
+		{ModelName: "a", Verdict: VerdictFlag},
+		{ModelName: "gone", Verdict: VerdictReject},
+	}
+	after := []ModelVerdict{
+		{ModelName: "a", Verdict: VerdictReject},
+		{ModelName: "b", Verdict: VerdictApprove},
+		{ModelName: "added", Verdict: VerdictFlag},
+	}
+	want := []VerdictChange{
+		{ModelName: "a", Before: VerdictFlag, After: VerdictReject},
+		{ModelName: "added", After: VerdictFlag},
+		{ModelName: "gone", Before: VerdictReject},
+	}
+	if got := CompareVerdicts(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareVerdicts = %+v, want %+v", got, want)
+	}
+}
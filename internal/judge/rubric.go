@@ -0,0 +1,59 @@
+package judge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rubric is an organization's additions to the system prompt: review rules
+// the judge applies on top of the built-in checks, and example verdicts
+// showing how.
+type Rubric struct {
+	Rules    []string
+	Examples []Example
+}
+
+// Example is a worked verdict for the judge to follow.
+type Example struct {
+	Model     string   `yaml:"model"` // the model data, as JSON or in prose
+	Verdict   Verdict  `yaml:"verdict"`
+	Concerns  []string `yaml:"concerns"`
+	Reasoning string   `yaml:"reasoning"`
+}
+
+// WithRubric appends the rubric's rules and examples to the system prompt,
+// the built-in one or a WithSystemPrompt replacement. Since the prompt hash
+// covers them, editing the rubric doesn't reuse cached verdicts.
+func WithRubric(r Rubric) Option {
+	return func(j *Judge) { j.rubric = r }
+}
+
+// prompt renders the rubric as a system prompt section, or "" when it's
+// empty.
+func (r Rubric) prompt() string {
+	if len(r.Rules) == 0 && len(r.Examples) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	if len(r.Rules) > 0 {
+		b.WriteString("\n\n## Organization rules\n\n")
+		b.WriteString("Apply these rules as well. Where they conflict with the guidance above, they win.\n")
+		for _, rule := range r.Rules {
+			fmt.Fprintf(&b, "\n- %s", strings.TrimSpace(rule))
+		}
+	}
+	if len(r.Examples) > 0 {
+		b.WriteString("\n\n## Example verdicts\n\n")
+		b.WriteString("These are verdicts this organization agrees with. Judge similar models the same way.")
+		for i, ex := range r.Examples {
+			fmt.Fprintf(&b, "\n\nExample %d:\nModel: %s\nVerdict: %s", i+1, strings.TrimSpace(ex.Model), ex.Verdict)
+			if len(ex.Concerns) > 0 {
+				fmt.Fprintf(&b, "\nConcerns: %s", strings.Join(ex.Concerns, "; "))
+			}
+			if ex.Reasoning != "" {
+				fmt.Fprintf(&b, "\nReasoning: %s", strings.TrimSpace(ex.Reasoning))
+			}
+		}
+	}
+	return b.String()
+}
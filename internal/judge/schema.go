@@ -0,0 +1,32 @@
+package judge
+
+import "encoding/json"
+
+// verdictsTool names the verdict schema: the Anthropic tool the judge must
+// call and the OpenAI json_schema response format.
+const verdictsTool = "submit_verdicts"
+
+// verdictsSchema is the JSON Schema of a judge response, in the strict
+// subset both providers accept: every property required, no extra ones.
+var verdictsSchema = json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "verdicts": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "model_name": {"type": "string"},
+          "verdict": {"type": "string", "enum": ["approve", "flag", "reject"]},
+          "confidence": {"type": "number"},
+          "concerns": {"type": "array", "items": {"type": "string"}},
+          "reasoning": {"type": "string"}
+        },
+        "required": ["model_name", "verdict", "confidence", "concerns", "reasoning"],
+        "additionalProperties": false
+      }
+    }
+  },
+  "required": ["verdicts"],
+  "additionalProperties": false
+}`)
@@ -0,0 +1,142 @@
+package judge
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+)
+
+// Where a verdict came from, in ModelVerdict.Source.
+const (
+	SourceLLM      = "llm"       // the judge model
+	SourceRule     = "rule"      // a screening rule, without an LLM call
+	SourceSpendCap = "spend_cap" // flagged unevaluated once the spend cap was reached
+)
+
+// screenRule decides a clear-cut case without the LLM. check returns why
+// the rule applies to the model, or "" when it doesn't. update is nil for
+// a new model.
+type screenRule struct {
+	name    string
+	verdict Verdict
+	check   func(m *catalog.Model, update *diff.ModelUpdate) string
+}
+
+// screenRules are tried in order; the first that applies decides. The
+// rejections are the cases the system prompt already calls clearly wrong.
+var screenRules = []screenRule{
+	{"embedding-with-chat", VerdictReject, func(m *catalog.Model, _ *diff.ModelUpdate) string {
+		embedding := m.ModelType == catalog.ModelTypeEmbedding || m.Embedding != nil || slices.Contains(m.Capabilities, "embedding")
+		if embedding && slices.Contains(m.Capabilities, "chat") {
+			return "embedding model has the chat capability"
+		}
+		return ""
+	}},
+	{"completion-exceeds-context", VerdictReject, func(m *catalog.Model, _ *diff.ModelUpdate) string {
+		if l := m.Limits; l.MaxTokens > 0 && l.MaxCompletionTokens > l.MaxTokens {
+			return fmt.Sprintf("max_completion_tokens %d exceeds max_tokens %d", l.MaxCompletionTokens, l.MaxTokens)
+		}
+		return ""
+	}},
+	{"negative-price", VerdictReject, func(m *catalog.Model, _ *diff.ModelUpdate) string {
+		c := m.Cost
+		if c == nil {
+			return ""
+		}
+		for _, p := range []struct {
+			field string
+			price float64
+		}{
+			{"input_per_1k", c.InputPer1K}, {"output_per_1k", c.OutputPer1K},
+			{"cached_input_per_1k", c.CachedInputPer1K}, {"cache_write_per_1k", c.CacheWritePer1K},
+			{"cache_read_per_1k", c.CacheReadPer1K}, {"batch_input_per_1k", c.BatchInputPer1K},
+			{"batch_output_per_1k", c.BatchOutputPer1K}, {"reasoning_per_1k", c.ReasoningPer1K},
+		} {
+			if p.price < 0 {
+				return fmt.Sprintf("negative price: cost.%s is %g", p.field, p.price)
+			}
+		}
+		return ""
+	}},
+	{"batch-above-standard", VerdictReject, func(m *catalog.Model, _ *diff.ModelUpdate) string {
+		c := m.Cost
+		switch {
+		case c == nil:
+		case c.BatchInputPer1K > c.InputPer1K && c.InputPer1K > 0:
+			return "batch input price is above the standard input price"
+		case c.BatchOutputPer1K > c.OutputPer1K && c.OutputPer1K > 0:
+			return "batch output price is above the standard output price"
+		}
+		return ""
+	}},
+	{"api-update", VerdictApprove, func(m *catalog.Model, u *diff.ModelUpdate) string {
+		if u == nil || len(u.Changes) == 0 {
+			return ""
+		}
+		sources := m.FieldSources()
+		for _, c := range u.Changes {
+			top := diff.TopField(c.Field)
+			// Prices and capabilities are where plausible-looking values
+			// go wrong, so they always get the LLM's review.
+			if top == "cost" || top == "capabilities" || sources[top].Source != "api" || u.Provenance[top].Source == "manual" {
+				return ""
+			}
+		}
+		return "every change comes from the provider's API and none touches pricing or capabilities"
+	}},
+}
+
+// screen decides the models screenRules settle and returns their verdicts,
+// with the changeset of the models left for the LLM. Without approve, the
+// approving rules are skipped: an organization's rubric may object to a
+// change the built-in rules find harmless, so only the LLM may approve.
+func screen(cs *diff.ChangeSet, approve bool) ([]ModelVerdict, *diff.ChangeSet) {
+	rest := *cs
+	rest.New, rest.Updated = nil, nil
+	var verdicts []ModelVerdict
+	for _, m := range cs.New {
+		if v, ok := screenModel(m.Name, m.Model, nil, approve); ok {
+			verdicts = append(verdicts, v)
+		} else {
+			rest.New = append(rest.New, m)
+		}
+	}
+	for _, u := range cs.Updated {
+		if v, ok := screenModel(u.Name, u.Model, &u, approve); ok {
+			verdicts = append(verdicts, v)
+		} else {
+			rest.Updated = append(rest.Updated, u)
+		}
+	}
+	return verdicts, &rest
+}
+
+func screenModel(name string, m *catalog.Model, update *diff.ModelUpdate, approve bool) (ModelVerdict, bool) {
+	if m == nil {
+		return ModelVerdict{}, false
+	}
+	for _, r := range screenRules {
+		if r.verdict == VerdictApprove && !approve {
+			continue
+		}
+		why := r.check(m, update)
+		if why == "" {
+			continue
+		}
+		v := ModelVerdict{
+			ModelName:  name,
+			Verdict:    r.verdict,
+			Confidence: 1,
+			Reasoning:  fmt.Sprintf("Decided by the %s screening rule without an LLM call: %s.", r.name, why),
+			Source:     SourceRule,
+			Rule:       r.name,
+		}
+		if r.verdict != VerdictApprove {
+			v.Concerns = []string{why}
+		}
+		return v, true
+	}
+	return ModelVerdict{}, false
+}
@@ -0,0 +1,146 @@
+package judge
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+)
+
+func TestScreenModel(t *testing.T) {
+	chat := func(mod func(m *catalog.Model)) *catalog.Model {
+		m := &catalog.Model{
+			Capabilities: []string{"chat"},
+			Limits:       catalog.Limits{MaxTokens: 128000, MaxCompletionTokens: 16384},
+			Cost:         &catalog.Cost{InputPer1K: 0.003, OutputPer1K: 0.015},
+		}
+		mod(m)
+		return m
+	}
+	apiSources := map[string]catalog.FieldSource{"limits": {Source: "api"}, "capabilities": {Source: "api"}}
+	update := func(field string, previous string) *diff.ModelUpdate {
+		return &diff.ModelUpdate{
+			Changes:    []catalog.FieldChange{{Field: field}},
+			Provenance: map[string]catalog.FieldSource{diff.TopField(field): {Source: previous}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		model  *catalog.Model
+		update *diff.ModelUpdate
+		want   string // the deciding rule; "" for the LLM
+	}{
+		{"plain chat model", chat(func(m *catalog.Model) {}), nil, ""},
+		{"embedding with chat", chat(func(m *catalog.Model) { m.Capabilities = []string{"embedding", "chat"} }), nil, "embedding-with-chat"},
+		{"embedding type with chat", chat(func(m *catalog.Model) { m.ModelType = catalog.ModelTypeEmbedding }), nil, "embedding-with-chat"},
+		{"completion over context", chat(func(m *catalog.Model) { m.Limits.MaxCompletionTokens = 200000 }), nil, "completion-exceeds-context"},
+		{"no context length", chat(func(m *catalog.Model) { m.Limits.MaxTokens = 0 }), nil, ""},
+		{"negative price", chat(func(m *catalog.Model) { m.Cost.OutputPer1K = -1 }), nil, "negative-price"},
+		{"batch above standard", chat(func(m *catalog.Model) { m.Cost.BatchInputPer1K = 0.004 }), nil, "batch-above-standard"},
+		{"api limits update", chat(func(m *catalog.Model) { m.XUpdater = &catalog.XUpdater{Fields: apiSources} }), update("limits.max_tokens", "api"), "api-update"},
+		{"api update over manual", chat(func(m *catalog.Model) { m.XUpdater = &catalog.XUpdater{Fields: apiSources} }), update("limits.max_tokens", "manual"), ""},
+		{"api capability update", chat(func(m *catalog.Model) { m.XUpdater = &catalog.XUpdater{Fields: apiSources} }), update("capabilities", "api"), ""},
+		{"update without provenance", chat(func(m *catalog.Model) {}), update("limits.max_tokens", ""), ""},
+		{"rejection beats approval", chat(func(m *catalog.Model) {
+			m.XUpdater = &catalog.XUpdater{Fields: apiSources}
+			m.Limits.MaxCompletionTokens = 200000
+		}), update("limits.max_tokens", "api"), "completion-exceeds-context"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := screenModel("m", tt.model, tt.update, true)
+			if got := v.Rule; got != tt.want || ok != (tt.want != "") {
+				t.Fatalf("rule = %q (screened %v), want %q", got, ok, tt.want)
+			}
+			if ok && (v.Source != SourceRule || (v.Verdict == VerdictReject) != (len(v.Concerns) > 0)) {
+				t.Errorf("verdict = %+v", v)
+			}
+		})
+	}
+}
+
+// promptClient records the prompts it is sent.
+type promptClient struct {
+	mockClient
+	prompts       []string
+	systemPrompts []string
+}
+
+func (c *promptClient) Complete(ctx context.Context, systemPrompt, userPrompt string) (*LLMResponse, error) {
+	c.prompts = append(c.prompts, userPrompt)
+	c.systemPrompts = append(c.systemPrompts, systemPrompt)
+	return c.mockClient.Complete(ctx, systemPrompt, userPrompt)
+}
+
+func TestEvaluate_Screening(t *testing.T) {
+	cs := makeChangeSet()
+	cs.New = append(cs.New, diff.ModelChange{Name: "text-embedding-4", Model: &catalog.Model{
+		Name:         "text-embedding-4",
+		Capabilities: []string{"embedding", "chat"},
+	}})
+	client := &promptClient{mockClient: mockClient{response: allApprovedResponse()}}
+	result, err := New(client, "test-model", false, WithScreening()).Evaluate(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(client.prompts) != 1 || strings.Contains(client.prompts[0], "text-embedding-4") || !strings.Contains(client.prompts[0], "gpt-5") {
+		t.Errorf("prompts = %q, want one without the screened model", client.prompts)
+	}
+	sources := make(map[string]string)
+	for _, v := range result.Verdicts {
+		sources[v.ModelName] = v.Source
+	}
+	if sources["text-embedding-4"] != SourceRule || sources["gpt-5"] != SourceLLM || sources["gpt-4o"] != SourceLLM {
+		t.Errorf("verdict sources = %v", sources)
+	}
+	if !result.HasRejections() || result.RejectedNames()[0] != "text-embedding-4" {
+		t.Errorf("rejected = %v, want text-embedding-4", result.RejectedNames())
+	}
+
+	// When the rules settle every model, the LLM isn't called.
+	cs.New, cs.Updated = cs.New[1:], nil
+	result, err = New(client, "test-model", false, WithScreening()).Evaluate(context.Background(), cs)
+	if err != nil || len(client.prompts) != 1 || len(result.Verdicts) != 1 || result.Usage != nil {
+		t.Errorf("result = %+v, %v after %d calls, want the rule verdict only", result, err, len(client.prompts))
+	}
+}
+
+// A rubric may object to what api-update approves, so with one the update
+// goes to the LLM, prompted with the rubric.
+func TestEvaluate_ScreeningWithRubric(t *testing.T) {
+	m := &catalog.Model{
+		Name:         "gpt-4o",
+		Capabilities: []string{"chat"},
+		Limits:       catalog.Limits{MaxTokens: 256000},
+		XUpdater:     &catalog.XUpdater{Fields: map[string]catalog.FieldSource{"limits": {Source: "api"}}},
+	}
+	cs := &diff.ChangeSet{Provider: "openai", Updated: []diff.ModelUpdate{{
+		Name:       "gpt-4o",
+		Model:      m,
+		Changes:    []catalog.FieldChange{{Field: "limits.max_tokens", OldValue: 128000, NewValue: 256000}},
+		Provenance: map[string]catalog.FieldSource{"limits": {Source: "api"}},
+	}}}
+
+	client := &promptClient{mockClient: mockClient{response: allApprovedResponse()}}
+	result, err := New(client, "test-model", false, WithScreening()).Evaluate(context.Background(), cs)
+	if err != nil || len(client.systemPrompts) != 0 || result.Verdicts[0].Rule != "api-update" {
+		t.Fatalf("without a rubric: result = %+v, %v after %d calls, want api-update to approve", result, err, len(client.systemPrompts))
+	}
+
+	rubric := Rubric{Rules: []string{"Flag any context window above 200K tokens."}}
+	result, err = New(client, "test-model", false, WithScreening(), WithRubric(rubric)).Evaluate(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(client.systemPrompts) != 1 || !strings.Contains(client.systemPrompts[0], rubric.Rules[0]) {
+		t.Errorf("system prompts = %q, want one call with the rubric", client.systemPrompts)
+	}
+	for _, v := range result.Verdicts {
+		if v.Source == SourceRule {
+			t.Errorf("verdict %+v came from a screening rule, want the LLM's", v)
+		}
+	}
+}
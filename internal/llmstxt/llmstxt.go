@@ -3,39 +3,28 @@ package llmstxt
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"regexp"
-	"time"
+
+	"github.com/everstacklabs/sentinel/internal/htmlutil"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
 )
 
-// Fetch performs an HTTP GET and returns the raw text body.
+// Fetch performs an HTTP GET through client and returns the raw text body.
 // Uses the same User-Agent as htmlutil.Fetch for consistency.
-func Fetch(ctx context.Context, url string) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; Sentinel/1.0; +https://github.com/everstacklabs/sentinel)")
-	req.Header.Set("Accept", "text/plain")
-
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+func Fetch(ctx context.Context, client *httpclient.Client, url string) (string, error) {
+	resp, err := client.Get(ctx, url, map[string]string{
+		"User-Agent": htmlutil.UserAgent,
+		"Accept":     "text/plain",
+	})
 	if err != nil {
 		return "", fmt.Errorf("fetching %s: %w", url, err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("fetching %s: status %d", url, resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("reading body from %s: %w", url, err)
-	}
-
-	return string(body), nil
+	return string(resp.Body), nil
 }
 
 // ExtractModelIDs extracts unique model IDs from raw text using the given regex patterns.
@@ -0,0 +1,133 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/pr/render"
+	"github.com/google/go-github/v60/github"
+)
+
+// aggregatePR collects a run's provider changesets on one branch, one
+// commit per provider, for a single PR opened at the end of the run.
+type aggregatePR struct {
+	git      *GitOps
+	branch   string
+	existing *github.PullRequest // open aggregate PR from an earlier run, if any
+	inputs   []*render.Input
+	draft    bool
+}
+
+// commitAggregate commits a provider's written changes to the run's shared
+// branch, creating the branch on first use. The branch of an aggregate PR
+// still open from an earlier run is reused.
+func (p *Pipeline) commitAggregate(ctx context.Context, in *render.Input, draft bool) error {
+	if p.aggregate == nil {
+		gitOps, err := p.openRepo()
+		if err != nil {
+			return err
+		}
+		a := &aggregatePR{git: gitOps, branch: fmt.Sprintf("sentinel/catalog-%s", time.Now().Format("20060102-150405"))}
+		if p.cfg.GitHub.UpdateOpenPRs {
+			existing, err := p.findOpenPR(ctx, p.githubClient(ctx), branchPattern("catalog"))
+			if err != nil {
+				slog.Warn("looking up open PRs failed, opening a new one", "error", err)
+			} else if existing != nil {
+				a.existing, a.branch = existing, existing.GetHead().GetRef()
+			}
+		}
+		if err := gitOps.CreateBranch(a.branch); err != nil {
+			return fmt.Errorf("creating branch: %w", err)
+		}
+		p.aggregate = a
+	}
+	a := p.aggregate
+
+	if err := a.git.AddAll(); err != nil {
+		return fmt.Errorf("staging changes: %w", err)
+	}
+	if err := a.git.Commit(commitMessage(fmt.Sprintf("chore(catalog): update %s models", in.ChangeSet.Provider), in)); err != nil {
+		return fmt.Errorf("committing: %w", err)
+	}
+	in.Branch = a.branch
+	a.inputs = append(a.inputs, in)
+	a.draft = a.draft || draft
+	return nil
+}
+
+// openAggregatePR pushes the shared branch and opens its PR, then records
+// the PR on the results of the providers it contains. It does nothing when
+// no provider was committed.
+func (p *Pipeline) openAggregatePR(ctx context.Context, results []SyncResult) {
+	a := p.aggregate
+	if a == nil {
+		return
+	}
+	p.aggregate = nil
+
+	inPR := make(map[string]bool, len(a.inputs))
+	for _, in := range a.inputs {
+		inPR[in.ChangeSet.Provider] = true
+	}
+	number, err := p.createAggregatePR(ctx, a)
+	for i := range results {
+		if !inPR[results[i].Provider] {
+			continue
+		}
+		if err != nil {
+			results[i].Error = fmt.Errorf("creating aggregate PR: %w", err)
+			continue
+		}
+		results[i].PRNumber = number
+		results[i].PRDraft = a.draft
+	}
+}
+
+func (p *Pipeline) createAggregatePR(ctx context.Context, a *aggregatePR) (int, error) {
+	if err := a.git.Push(ctx); err != nil {
+		return 0, fmt.Errorf("pushing: %w", err)
+	}
+
+	title := fmt.Sprintf("chore(catalog): update models for %d providers", len(a.inputs))
+	if len(a.inputs) == 1 {
+		title = fmt.Sprintf("chore(catalog): update %s models", a.inputs[0].ChangeSet.Provider)
+	}
+	body := render.AggregateBody(a.inputs, a.branch)
+
+	client := p.githubClient(ctx)
+	if a.existing != nil {
+		pr, err := p.updatePR(ctx, client, a.existing, title, body, a.draft)
+		if err != nil {
+			return 0, err
+		}
+		slog.Info("aggregate PR updated", "providers", len(a.inputs), "number", pr.GetNumber(), "url", pr.GetHTMLURL())
+		for _, in := range a.inputs {
+			p.postVerdictComments(ctx, client, a.git, pr, in)
+		}
+		return pr.GetNumber(), nil
+	}
+
+	pr, _, err := client.PullRequests.Create(ctx, p.cfg.GitHub.Owner, p.cfg.GitHub.Repo, &github.NewPullRequest{
+		Title: &title,
+		Body:  &body,
+		Head:  &a.branch,
+		Base:  &p.cfg.GitHub.BaseBranch,
+		Draft: &a.draft,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	slog.Info("aggregate PR created",
+		"providers", len(a.inputs),
+		"number", pr.GetNumber(),
+		"draft", a.draft,
+		"url", pr.GetHTMLURL())
+
+	for _, in := range a.inputs {
+		p.postVerdictComments(ctx, client, a.git, pr, in)
+	}
+	return pr.GetNumber(), nil
+}
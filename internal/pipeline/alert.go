@@ -0,0 +1,87 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/pr/render"
+	"github.com/google/go-github/v60/github"
+)
+
+// What a changeset that drops whole model families does (risk.family_removed).
+const (
+	FamilyRemovedBlock = "block" // skip the provider; a draft in relaxed mode
+	FamilyRemovedDraft = "draft" // open the PR as a draft
+	FamilyRemovedOff   = "off"   // treat the models as ordinary deprecation candidates
+)
+
+// alertLabel marks the issues sentinel opens for family removals.
+const alertLabel = "sentinel-alert"
+
+// alertRemovedFamilies raises the family removals in cs apart from the
+// catalog PR: an error per family in the log and, unless this is a dry run,
+// an issue labelled sentinel-alert when github.alert_issues is set. pr is
+// the catalog PR the changes went to, or 0. Failing to open the issue is
+// only logged.
+func (p *Pipeline) alertRemovedFamilies(ctx context.Context, cs *diff.ChangeSet, health *render.SourceHealth, pr int) {
+	for _, f := range cs.RemovedFamilies {
+		slog.Error("provider no longer lists a model family",
+			"provider", cs.Provider, "family", f.Family, "models", strings.Join(f.Models, ","),
+			"hint", "a provider retirement or an adapter regression; review before merging")
+	}
+	if p.cfg.DryRun || !p.cfg.GitHub.AlertIssues || p.cfg.GitHub.Token == "" {
+		return
+	}
+	title, body := render.FamilyRemovalIssue(cs, health, pr)
+	number, err := p.openAlertIssue(ctx, title, body)
+	if err != nil {
+		slog.Warn("opening family removal issue failed", "provider", cs.Provider, "error", err)
+		return
+	}
+	slog.Info("family removal issue opened", "provider", cs.Provider, "issue", number)
+}
+
+// openAlertIssue opens an alert issue, or comments on the open one with the
+// same title so repeated runs don't pile up duplicates. It returns the
+// issue number.
+func (p *Pipeline) openAlertIssue(ctx context.Context, title, body string) (int, error) {
+	client := p.githubClient(ctx)
+	owner, repo := p.cfg.GitHub.Owner, p.cfg.GitHub.Repo
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		Labels:      []string{alertLabel},
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+		if err != nil {
+			return 0, fmt.Errorf("listing alert issues: %w", err)
+		}
+		for _, issue := range issues {
+			if issue.IsPullRequest() || issue.GetTitle() != title {
+				continue
+			}
+			if _, _, err := client.Issues.CreateComment(ctx, owner, repo, issue.GetNumber(), &github.IssueComment{Body: &body}); err != nil {
+				return 0, fmt.Errorf("commenting on issue #%d: %w", issue.GetNumber(), err)
+			}
+			return issue.GetNumber(), nil
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	issue, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{
+		Title:  &title,
+		Body:   &body,
+		Labels: &[]string{alertLabel},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("creating issue: %w", err)
+	}
+	return issue.GetNumber(), nil
+}
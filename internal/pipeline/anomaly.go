@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+)
+
+// What a sync does when a provider's model count strays from its usual
+// count (health.on_anomaly).
+const (
+	OnAnomalyWarn  = "warn"  // note it on the changeset
+	OnAnomalyBlock = "block" // fail the provider
+)
+
+// minBaselineRuns is how many counted runs a provider needs in the history
+// before its model count is compared with them.
+const minBaselineRuns = 3
+
+// countBaseline is a provider's usual model count.
+type countBaseline struct {
+	Median int
+	Runs   int // the counted runs the median is over
+}
+
+// modelCountBaseline is the median model count of the provider's last runs
+// counted runs in records, which are oldest first. Runs that failed on
+// their count are included, so a lasting change becomes the new baseline
+// once it fills half the window. It reports false when there are fewer
+// than minBaselineRuns such runs.
+func modelCountBaseline(records []RunRecord, provider string, runs int) (countBaseline, bool) {
+	var counts []int
+	for i := len(records) - 1; i >= 0 && (runs <= 0 || len(counts) < runs); i-- {
+		if rec := records[i]; rec.Provider == provider && rec.Models > 0 {
+			counts = append(counts, rec.Models)
+		}
+	}
+	if len(counts) < minBaselineRuns {
+		return countBaseline{}, false
+	}
+	slices.Sort(counts)
+	median := counts[len(counts)/2]
+	if len(counts)%2 == 0 {
+		median = (counts[len(counts)/2-1] + median) / 2
+	}
+	return countBaseline{Median: median, Runs: len(counts)}, true
+}
+
+// modelCountAnomaly describes how discovered strays from the provider's
+// usual model count, or returns "" when it is within health.anomaly_deviation
+// or there is no baseline yet.
+func (p *Pipeline) modelCountAnomaly(provider string, discovered int) string {
+	h := p.cfg.Health
+	if !h.Enabled || h.AnomalyDeviation <= 0 || h.History == "" {
+		return ""
+	}
+	p.historyOnce.Do(func() {
+		var err error
+		if p.history, err = ReadRunHistory(h.History); err != nil {
+			slog.Warn("reading run history failed, skipping model count anomaly checks", "path", h.History, "error", err)
+		}
+	})
+	base, ok := modelCountBaseline(p.history, provider, h.AnomalyRuns)
+	if !ok {
+		return ""
+	}
+	deviation := float64(discovered-base.Median) / float64(base.Median)
+	if math.Abs(deviation) <= h.AnomalyDeviation {
+		return ""
+	}
+	direction := "fewer"
+	if deviation > 0 {
+		direction = "more"
+	}
+	return fmt.Sprintf("discovered %d models, %.0f%% %s than usual (median %d over the last %d runs)",
+		discovered, math.Abs(deviation)*100, direction, base.Median, base.Runs)
+}
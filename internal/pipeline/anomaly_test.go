@@ -0,0 +1,109 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/config"
+)
+
+func TestModelCountBaseline(t *testing.T) {
+	records := func(counts ...int) []RunRecord {
+		var recs []RunRecord
+		for _, n := range counts {
+			recs = append(recs, RunRecord{Provider: "openai", Status: RunOK, Models: n})
+			recs = append(recs, RunRecord{Provider: "mistral", Status: RunOK, Models: 1000})
+		}
+		return recs
+	}
+	tests := []struct {
+		name    string
+		records []RunRecord
+		runs    int
+		want    countBaseline
+		wantOK  bool
+	}{
+		{"too few runs", records(100, 100), 10, countBaseline{}, false},
+		{"odd", records(90, 120, 100), 10, countBaseline{Median: 100, Runs: 3}, true},
+		{"even", records(90, 120, 100, 110), 10, countBaseline{Median: 105, Runs: 4}, true},
+		{"last runs only", records(10, 10, 10, 100, 100, 100), 3, countBaseline{Median: 100, Runs: 3}, true},
+		{"uncounted runs skipped", records(100, 0, 100, 0, 100), 10, countBaseline{Median: 100, Runs: 3}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := modelCountBaseline(tt.records, "openai", tt.runs)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("got %+v, %v; want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestModelCountAnomaly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runs.jsonl")
+	New(&config.Config{Health: config.HealthConfig{History: path}}).recordRuns([]SyncResult{
+		{Provider: "anomaly-drop", Discovered: 10},
+		{Provider: "anomaly-drop", Discovered: 10},
+		{Provider: "anomaly-drop", Discovered: 11},
+	})
+	adapter.Register(&probedAdapter{healthAdapter{name: "anomaly-drop", models: 6, min: 1}})
+
+	tests := []struct {
+		name      string
+		deviation float64
+		onAnomaly string
+		wantErr   bool
+		wantWarn  bool
+	}{
+		{"within deviation", 0.5, OnAnomalyWarn, false, false},
+		{"warn", 0.3, OnAnomalyWarn, false, true},
+		{"block", 0.3, OnAnomalyBlock, true, false},
+		{"disabled", 0, OnAnomalyBlock, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Sources: []string{"api"},
+				Health: config.HealthConfig{Enabled: true, Threshold: 0.9, History: path,
+					AnomalyDeviation: tt.deviation, AnomalyRuns: 10, OnAnomaly: tt.onAnomaly},
+			}
+			p := New(cfg)
+			p.catalog = &catalog.Catalog{Providers: map[string]*catalog.ProviderCatalog{}}
+
+			const want = "discovered 6 models, 40% fewer than usual (median 10 over the last 3 runs)"
+			cs, _, err := p.discoverAndDiff(context.Background(), "anomaly-drop")
+			if tt.wantErr {
+				var he *SourceHealthError
+				if !errors.As(err, &he) || he.Reason != want || he.Discovered != 6 {
+					t.Fatalf("err = %v, want a source health error: %s", err, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			warned := false
+			for _, w := range cs.Warnings {
+				warned = warned || strings.Contains(w.Message, want)
+			}
+			if warned != tt.wantWarn {
+				t.Errorf("warned = %v, want %v: %+v", warned, tt.wantWarn, cs.Warnings)
+			}
+		})
+	}
+}
+
+func TestRunRecordModels(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	rec, _ := runRecord(SyncResult{Provider: "openai", Discovered: 42,
+		Error: &SourceHealthError{Provider: "openai", Reason: "too few", Discovered: 42}}, now)
+	if rec.Status != RunFailed || rec.Models != 42 {
+		t.Errorf("record = %+v, want a failed run counting 42 models", rec)
+	}
+}
@@ -0,0 +1,188 @@
+package pipeline
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+	"gopkg.in/yaml.v3"
+)
+
+// ChangeFilter narrows a changeset before it is validated and written, to
+// land only part of what discovery found. The zero value keeps everything.
+type ChangeFilter struct {
+	OnlyNew     bool // keep only new models
+	OnlyUpdates bool // keep only updates to existing models
+
+	// Fields keeps only the changes to these top-level model fields in
+	// updates, such as "cost" or "limits". New models are written whole.
+	Fields []string
+
+	// Models keeps only models whose name matches one of these patterns:
+	// globs with * and ?, or regular expressions between slashes.
+	Models []string
+}
+
+// WithChangeFilter narrows every provider's changeset with f during Sync.
+func WithChangeFilter(f ChangeFilter) Option {
+	return func(p *Pipeline) { p.changeFilter = f }
+}
+
+func (f ChangeFilter) isZero() bool {
+	return !f.OnlyNew && !f.OnlyUpdates && len(f.Fields) == 0 && len(f.Models) == 0
+}
+
+// validate checks the filter before any discovery runs.
+func (f ChangeFilter) validate() error {
+	if f.OnlyNew && f.OnlyUpdates {
+		return fmt.Errorf("only new models and only updates are mutually exclusive")
+	}
+	for _, field := range f.Fields {
+		if strings.Contains(field, ".") || !catalog.IsModelField(field) {
+			return fmt.Errorf("fields: %q is not a top-level model field", field)
+		}
+	}
+	if _, err := compilePatterns(f.Models); err != nil {
+		return fmt.Errorf("model patterns: %w", err)
+	}
+	return nil
+}
+
+// filterChangeSet applies p.changeFilter to cs. Existing models back the
+// field filter: an update keeping only some fields is written as the
+// catalog model with just those fields taken from discovery.
+func (p *Pipeline) filterChangeSet(cs *diff.ChangeSet) error {
+	f := p.changeFilter
+	if f.isZero() {
+		return nil
+	}
+	models, err := compilePatterns(f.Models)
+	if err != nil {
+		return err
+	}
+	keep := func(names ...string) bool {
+		if len(models) == 0 {
+			return true
+		}
+		for _, n := range names {
+			if matchAny(models, n) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var existing map[string]*catalog.Model
+	if pc, ok := p.catalog.Providers[cs.Provider]; ok {
+		existing = pc.Models
+	}
+
+	before := cs.TotalChanged()
+	var added []diff.ModelChange
+	if !f.OnlyUpdates {
+		for _, m := range cs.New {
+			if keep(m.Name) {
+				added = append(added, m)
+			}
+		}
+	}
+	var updated []diff.ModelUpdate
+	if !f.OnlyNew {
+		for _, u := range cs.Updated {
+			if !keep(u.Name) {
+				continue
+			}
+			if len(f.Fields) > 0 {
+				if u, err = narrowUpdate(u, existing[u.Name], f.Fields); err != nil {
+					return fmt.Errorf("%s: %w", u.Name, err)
+				}
+				if len(u.Changes) == 0 {
+					continue
+				}
+			}
+			updated = append(updated, u)
+		}
+	}
+	var renamed []diff.ModelRename
+	var deprecations []diff.ModelChange
+	if !f.OnlyNew && !f.OnlyUpdates {
+		for _, r := range cs.Renamed {
+			if keep(r.OldName, r.Name) {
+				renamed = append(renamed, r)
+			}
+		}
+		for _, m := range cs.DeprecationCandidates {
+			if keep(m.Name) {
+				deprecations = append(deprecations, m)
+			}
+		}
+	}
+	var possible []diff.RenamePair
+	for _, r := range cs.PossibleRenames {
+		if keep(r.OldName, r.NewName) {
+			possible = append(possible, r)
+		}
+	}
+
+	cs.New, cs.Updated, cs.Renamed = added, updated, renamed
+	cs.DeprecationCandidates, cs.PossibleRenames = deprecations, possible
+	slog.Info("changeset filtered", "provider", cs.Provider, "kept", cs.TotalChanged(), "of", before)
+	return nil
+}
+
+// narrowUpdate keeps only the changes of u to the given fields, and makes
+// its model the existing model with only those fields taken from discovery.
+func narrowUpdate(u diff.ModelUpdate, existing *catalog.Model, fields []string) (diff.ModelUpdate, error) {
+	var changes []catalog.FieldChange
+	for _, c := range u.Changes {
+		if slices.Contains(fields, diff.TopField(c.Field)) {
+			changes = append(changes, c)
+		}
+	}
+	if len(changes) == len(u.Changes) || existing == nil {
+		u.Changes = changes
+		return u, nil
+	}
+
+	base, err := toMap(existing)
+	if err != nil {
+		return u, err
+	}
+	discovered, err := toMap(u.Model)
+	if err != nil {
+		return u, err
+	}
+	// The provenance comes along for updateMetadata to record.
+	base["x_updater"] = discovered["x_updater"]
+	for _, c := range changes {
+		key := diff.TopField(c.Field)
+		if v, ok := discovered[key]; ok {
+			base[key] = v
+		} else {
+			delete(base, key)
+		}
+	}
+	data, err := yaml.Marshal(base)
+	if err != nil {
+		return u, err
+	}
+	var m catalog.Model
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return u, err
+	}
+	u.Model, u.Changes = &m, changes
+	return u, nil
+}
+
+// toMap converts a model to its YAML mapping.
+func toMap(m *catalog.Model) (map[string]any, error) {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]any)
+	return out, yaml.Unmarshal(data, &out)
+}
@@ -0,0 +1,106 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+)
+
+func TestFilterChangeSet(t *testing.T) {
+	existing := &catalog.Model{
+		Name: "gpt-4o", DisplayName: "GPT-4o", Family: "gpt-4o", Status: "stable",
+		Cost:         &catalog.Cost{InputPer1K: 0.005, OutputPer1K: 0.015},
+		Limits:       catalog.Limits{MaxTokens: 128000},
+		Capabilities: []string{"chat"},
+	}
+	changeSet := func() *diff.ChangeSet {
+		return &diff.ChangeSet{
+			Provider: "openai",
+			New: []diff.ModelChange{
+				{Name: "gpt-5", Model: &catalog.Model{Name: "gpt-5"}},
+				{Name: "o3", Model: &catalog.Model{Name: "o3"}},
+			},
+			Updated: []diff.ModelUpdate{{
+				Name: "gpt-4o",
+				Model: &catalog.Model{
+					Name: "gpt-4o", DisplayName: "GPT-4o", Family: "gpt-4o", Status: "stable",
+					Cost:         &catalog.Cost{InputPer1K: 0.0025, OutputPer1K: 0.01},
+					Limits:       catalog.Limits{MaxTokens: 256000},
+					Capabilities: []string{"chat", "vision"},
+				},
+				Changes: []catalog.FieldChange{
+					{Field: "cost.input_per_1k", OldValue: 0.005, NewValue: 0.0025},
+					{Field: "cost.output_per_1k", OldValue: 0.015, NewValue: 0.01},
+					{Field: "limits.max_tokens", OldValue: 128000, NewValue: 256000},
+					{Field: "capabilities", OldValue: []string{"chat"}, NewValue: []string{"chat", "vision"}},
+				},
+			}},
+			DeprecationCandidates: []diff.ModelChange{{Name: "gpt-3.5-turbo", Model: &catalog.Model{Name: "gpt-3.5-turbo"}}},
+		}
+	}
+
+	tests := []struct {
+		name                          string
+		filter                        ChangeFilter
+		wantNew, wantUpdated, wantDep int
+	}{
+		{"none", ChangeFilter{}, 2, 1, 1},
+		{"only new", ChangeFilter{OnlyNew: true}, 2, 0, 0},
+		{"only updates", ChangeFilter{OnlyUpdates: true}, 0, 1, 0},
+		{"glob", ChangeFilter{Models: []string{"gpt-*"}}, 1, 1, 1},
+		{"regexp", ChangeFilter{Models: []string{"/^o[0-9]/"}}, 1, 0, 0},
+		{"fields without a change", ChangeFilter{Fields: []string{"status"}}, 2, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(nil, WithChangeFilter(tt.filter))
+			p.catalog = &catalog.Catalog{Providers: map[string]*catalog.ProviderCatalog{
+				"openai": {Models: map[string]*catalog.Model{"gpt-4o": existing}},
+			}}
+			cs := changeSet()
+			if err := p.filterChangeSet(cs); err != nil {
+				t.Fatal(err)
+			}
+			if len(cs.New) != tt.wantNew || len(cs.Updated) != tt.wantUpdated || len(cs.DeprecationCandidates) != tt.wantDep {
+				t.Errorf("new %d, updated %d, deprecations %d; want %d, %d, %d",
+					len(cs.New), len(cs.Updated), len(cs.DeprecationCandidates), tt.wantNew, tt.wantUpdated, tt.wantDep)
+			}
+		})
+	}
+
+	// Only the cost changes land; the model keeps the catalog's limits and
+	// capabilities.
+	p := New(nil, WithChangeFilter(ChangeFilter{Fields: []string{"cost"}}))
+	p.catalog = &catalog.Catalog{Providers: map[string]*catalog.ProviderCatalog{
+		"openai": {Models: map[string]*catalog.Model{"gpt-4o": existing}},
+	}}
+	cs := changeSet()
+	if err := p.filterChangeSet(cs); err != nil {
+		t.Fatal(err)
+	}
+	u := cs.Updated[0]
+	if len(u.Changes) != 2 || u.Changes[0].Field != "cost.input_per_1k" {
+		t.Errorf("changes = %+v, want only the cost changes", u.Changes)
+	}
+	if u.Model.Cost.InputPer1K != 0.0025 || u.Model.Limits.MaxTokens != 128000 || len(u.Model.Capabilities) != 1 {
+		t.Errorf("model = %+v, want the new cost with the catalog's limits and capabilities", u.Model)
+	}
+}
+
+func TestChangeFilterValidate(t *testing.T) {
+	if err := (ChangeFilter{OnlyNew: true, OnlyUpdates: true}).validate(); err == nil {
+		t.Error("expected error for only new and only updates together")
+	}
+	if err := (ChangeFilter{Models: []string{"/[/"}}).validate(); err == nil {
+		t.Error("expected error for an invalid model regexp")
+	}
+	for _, field := range []string{"cots", "limits.max_tokens", ""} {
+		if err := (ChangeFilter{Fields: []string{field}}).validate(); err == nil {
+			t.Errorf("expected error for field %q", field)
+		}
+	}
+	if err := (ChangeFilter{Models: []string{"gpt-*"}, Fields: []string{"cost"}}).validate(); err != nil {
+		t.Errorf("valid filter: %v", err)
+	}
+}
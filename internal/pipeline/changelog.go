@@ -0,0 +1,66 @@
+package pipeline
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/fsutil"
+)
+
+// changelogHeader starts a CHANGELOG.md that sentinel creates.
+const changelogHeader = "# Changelog\n\nModel changes in each catalog release, newest first.\n\n"
+
+// updateChangelog adds an entry for the version a changeset was released
+// as to the catalog's changelog file, when one is configured.
+func (p *Pipeline) updateChangelog(cs *diff.ChangeSet, version string, journal *catalog.Journal) error {
+	if p.cfg.Changelog == "" {
+		return nil
+	}
+	path := p.cfg.Changelog
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.cfg.CatalogPath, path)
+	}
+	entry := diff.RenderChangelogEntry(version, time.Now().UTC(), []diff.ChangeSet{*cs})
+	if entry == "" {
+		return nil
+	}
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := journal.Track(path); err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(path, prependChangelogEntry(existing, entry), 0o644, p.cfg.YAML.Fsync)
+}
+
+// prependChangelogEntry inserts entry above the newest release in a
+// changelog, below any title and introduction. An empty changelog gets
+// sentinel's header.
+func prependChangelogEntry(changelog []byte, entry string) []byte {
+	if len(bytes.TrimSpace(changelog)) == 0 {
+		return []byte(changelogHeader + entry)
+	}
+	at := len(changelog)
+	if bytes.HasPrefix(changelog, []byte("## ")) {
+		at = 0
+	} else if i := bytes.Index(changelog, []byte("\n## ")); i >= 0 {
+		at = i + 1
+	}
+
+	var out bytes.Buffer
+	if at == len(changelog) {
+		out.Write(bytes.TrimRight(changelog, "\n"))
+		out.WriteString("\n\n")
+	} else {
+		out.Write(changelog[:at])
+	}
+	out.WriteString(entry)
+	out.Write(changelog[at:])
+	return out.Bytes()
+}
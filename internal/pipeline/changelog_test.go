@@ -0,0 +1,26 @@
+package pipeline
+
+import "testing"
+
+func TestPrependChangelogEntry(t *testing.T) {
+	entry := "## 1.1.0 (2026-03-01)\n\n### openai\n\n#### Added\n\n- `gpt-5`\n\n"
+	tests := []struct {
+		name, changelog, want string
+	}{
+		{"new file", "", changelogHeader + entry},
+		{
+			"above the newest release",
+			"# Changelog\n\nIntro.\n\n## 1.0.0 (2026-02-01)\n\n- first\n",
+			"# Changelog\n\nIntro.\n\n" + entry + "## 1.0.0 (2026-02-01)\n\n- first\n",
+		},
+		{"releases only", "## 1.0.0\n", entry + "## 1.0.0\n"},
+		{"no releases yet", "# Changes\nIntro.", "# Changes\nIntro.\n\n" + entry},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(prependChangelogEntry([]byte(tt.changelog), entry)); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/dbsync"
+)
+
+// syncDatabase mirrors a provider's models into the configured database.
+// cat must be loaded from disk after the write so the rows match the merged
+// files exactly.
+func (p *Pipeline) syncDatabase(ctx context.Context, providerName string, cat *catalog.Catalog) error {
+	w, err := p.databaseWriter(ctx)
+	if err != nil {
+		return err
+	}
+
+	models := make(map[string]*catalog.Model)
+	if pc, ok := cat.Providers[providerName]; ok {
+		models = pc.Models
+	}
+
+	res, err := w.SyncProvider(ctx, providerName, cat.Version, models)
+	if err != nil {
+		return err
+	}
+	slog.Info("database synced",
+		"provider", providerName,
+		"upserted", res.Upserted,
+		"removed", res.Removed)
+	return nil
+}
+
+// databaseWriter opens the database on first use and applies migrations
+// when auto_migrate is set.
+func (p *Pipeline) databaseWriter(ctx context.Context) (*dbsync.Writer, error) {
+	if p.db != nil {
+		return p.db, nil
+	}
+	w, err := dbsync.Open(ctx, p.cfg.Database.Driver, p.cfg.Database.DSN)
+	if err != nil {
+		return nil, err
+	}
+	if p.cfg.Database.AutoMigrate {
+		if _, err := w.Migrate(ctx); err != nil {
+			_ = w.Close()
+			return nil, fmt.Errorf("migrating database: %w", err)
+		}
+	}
+	p.db = w
+	return w, nil
+}
+
+func (p *Pipeline) closeDatabase() {
+	if p.db == nil {
+		return
+	}
+	if err := p.db.Close(); err != nil {
+		slog.Warn("closing database", "error", err)
+	}
+	p.db = nil
+}
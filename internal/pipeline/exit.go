@@ -0,0 +1,61 @@
+package pipeline
+
+import (
+	"errors"
+	"slices"
+)
+
+// Provider failures fail_on can make a sync exit non-zero for.
+const (
+	FailOnError      = "error"      // a provider failed for any other reason
+	FailOnHealth     = "health"     // a source health check failed
+	FailOnValidation = "validation" // discovered models failed validation
+)
+
+// ExitCode is the exit code for a sync's results. Failures of the kinds in
+// failOn come first: ExitSourceHealth, then ExitValidation, then
+// ExitFailed. Otherwise a changeset blocked by the risk policy gives
+// ExitPolicyBlock. Providers a fail_fast run didn't start don't count; the
+// failure that stopped it does.
+func ExitCode(results []SyncResult, failOn []string) int {
+	kinds := make(map[string]bool)
+	blocked := false
+	for _, r := range results {
+		if r.Error != nil {
+			kinds[failureKind(r.Error)] = true
+		}
+		blocked = blocked || r.Blocked
+	}
+	for _, c := range []struct {
+		kind string
+		code int
+	}{
+		{FailOnHealth, ExitSourceHealth},
+		{FailOnValidation, ExitValidation},
+		{FailOnError, ExitFailed},
+	} {
+		if kinds[c.kind] && slices.Contains(failOn, c.kind) {
+			return c.code
+		}
+	}
+	if blocked {
+		return ExitPolicyBlock
+	}
+	return ExitSuccess
+}
+
+// failureKind sorts a provider's error into a fail_on kind, or "" for a
+// provider fail_fast skipped.
+func failureKind(err error) string {
+	var he *SourceHealthError
+	var ve *ValidationError
+	switch {
+	case errors.Is(err, ErrFailFast):
+		return ""
+	case errors.As(err, &he):
+		return FailOnHealth
+	case errors.As(err, &ve):
+		return FailOnValidation
+	}
+	return FailOnError
+}
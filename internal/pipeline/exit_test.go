@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/validate"
+)
+
+func TestExitCode(t *testing.T) {
+	var (
+		ok         = SyncResult{Provider: "openai"}
+		blocked    = SyncResult{Provider: "groq", Skipped: true, Blocked: true}
+		failed     = SyncResult{Provider: "xai", Error: errors.New("discovering models: 500")}
+		health     = SyncResult{Provider: "mistral", Error: fmt.Errorf("wrapped: %w", &SourceHealthError{Provider: "mistral", Reason: "probe failed"})}
+		validation = SyncResult{Provider: "cohere", Error: &ValidationError{Provider: "cohere", Result: &validate.Result{}}}
+		stopped    = stoppedEarly("venice")
+		all        = []string{FailOnError, FailOnHealth, FailOnValidation}
+	)
+	tests := []struct {
+		name    string
+		results []SyncResult
+		failOn  []string
+		want    int
+	}{
+		{"all synced", []SyncResult{ok}, all, ExitSuccess},
+		{"failures ignored", []SyncResult{ok, failed, health}, nil, ExitSuccess},
+		{"blocked", []SyncResult{ok, blocked}, nil, ExitPolicyBlock},
+		{"error", []SyncResult{ok, failed}, all, ExitFailed},
+		{"failure before block", []SyncResult{blocked, failed}, all, ExitFailed},
+		{"health first", []SyncResult{failed, validation, health}, all, ExitSourceHealth},
+		{"validation before error", []SyncResult{failed, validation}, all, ExitValidation},
+		{"kind not listed", []SyncResult{ok, validation}, []string{FailOnHealth}, ExitSuccess},
+		{"health is not an error", []SyncResult{health}, []string{FailOnError}, ExitSuccess},
+		{"fail-fast skips don't count", []SyncResult{validation, stopped}, []string{FailOnValidation, FailOnError}, ExitValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.results, tt.failOn); got != tt.want {
+				t.Errorf("ExitCode = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncFailFast(t *testing.T) {
+	adapter.Register(&probedAdapter{healthAdapter{name: "failfast-down", models: 3, probeErr: errors.New("connection refused")}})
+	adapter.Register(&healthAdapter{name: "failfast-up", models: 3})
+
+	for _, failFast := range []bool{false, true} {
+		t.Run(fmt.Sprintf("fail_fast=%v", failFast), func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.Mkdir(filepath.Join(dir, "providers"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "version.txt"), []byte("1.0.0\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			cfg := &config.Config{
+				CatalogPath: dir,
+				Providers:   []string{"failfast-down", "failfast-up"},
+				Sources:     []string{"api"},
+				DryRun:      true,
+				FailFast:    failFast,
+				Health:      config.HealthConfig{Enabled: true, Threshold: 0.9},
+			}
+			results, err := New(cfg).Sync(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != 2 {
+				t.Fatalf("got %d results, want 2", len(results))
+			}
+			var he *SourceHealthError
+			if !errors.As(results[0].Error, &he) {
+				t.Errorf("failfast-down: error %v, want a source health error", results[0].Error)
+			}
+			if stopped := errors.Is(results[1].Error, ErrFailFast); stopped != failFast {
+				t.Errorf("failfast-up: error %v, stopped = %v, want %v", results[1].Error, stopped, failFast)
+			}
+		})
+	}
+}
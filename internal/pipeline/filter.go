@@ -0,0 +1,145 @@
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/config"
+)
+
+// modelFilter is a compiled config.ModelFilterConfig together with the
+// model_types setting.
+type modelFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+	types   []string // empty allows every type
+}
+
+// newModelFilter compiles a provider's filter. It returns nil when the
+// provider has no patterns and every type is kept, and a nil filter allows
+// every model.
+func newModelFilter(fc config.ModelFilterConfig, types []string) (*modelFilter, error) {
+	if len(fc.IncludePatterns) == 0 && len(fc.ExcludePatterns) == 0 && len(types) == 0 {
+		return nil, nil
+	}
+	for _, t := range types {
+		if !catalog.IsValidModelType(t) {
+			return nil, fmt.Errorf("model_types: unknown type %q, expected one of: %s", t, strings.Join(catalog.ModelTypes(), ", "))
+		}
+	}
+	include, err := compilePatterns(fc.IncludePatterns)
+	if err != nil {
+		return nil, fmt.Errorf("include_patterns: %w", err)
+	}
+	exclude, err := compilePatterns(fc.ExcludePatterns)
+	if err != nil {
+		return nil, fmt.Errorf("exclude_patterns: %w", err)
+	}
+	return &modelFilter{include: include, exclude: exclude, types: types}, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	out := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		expr := p
+		if len(p) >= 2 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+			expr = p[1 : len(p)-1]
+		} else {
+			expr = "^" + strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(p)) + "$"
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", p, err)
+		}
+		out = append(out, re)
+	}
+	return out, nil
+}
+
+// Allows reports whether a model name passes the filter.
+func (f *modelFilter) Allows(name string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.include) > 0 && !matchAny(f.include, name) {
+		return false
+	}
+	return !matchAny(f.exclude, name)
+}
+
+// AllowsType reports whether models of type t pass the filter.
+func (f *modelFilter) AllowsType(t string) bool {
+	return f == nil || len(f.types) == 0 || slices.Contains(f.types, t)
+}
+
+// discoveredType is the type of a discovered model. A model found only in
+// docs has no capabilities to infer a type from; it is typed when the API
+// lists it.
+func discoveredType(m *adapter.DiscoveredModel) (string, bool) {
+	if m.ModelType != "" {
+		return m.ModelType, true
+	}
+	if len(m.Capabilities) == 0 {
+		return "", false
+	}
+	return catalog.InferModelType(m.Capabilities, catalog.Modalities(m.Modalities)), true
+}
+
+func matchAny(res []*regexp.Regexp, name string) bool {
+	for _, re := range res {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterDiscovered drops discovered models the filter doesn't allow.
+func (f *modelFilter) filterDiscovered(models []adapter.DiscoveredModel) []adapter.DiscoveredModel {
+	if f == nil {
+		return models
+	}
+	kept := models[:0]
+	for _, m := range models {
+		if !f.Allows(m.Name) {
+			continue
+		}
+		if t, ok := discoveredType(&m); ok && !f.AllowsType(t) {
+			continue
+		}
+		kept = append(kept, m)
+	}
+	return kept
+}
+
+// withoutFineTuned drops the fine-tuned models from existing, for adapters
+// that don't list them.
+func withoutFineTuned(models map[string]*catalog.Model) map[string]*catalog.Model {
+	kept := make(map[string]*catalog.Model, len(models))
+	for name, m := range models {
+		if !catalog.IsFineTuned(name) {
+			kept[name] = m
+		}
+	}
+	return kept
+}
+
+// filterExisting returns the catalog models the filter allows, so models
+// outside the filter are left alone instead of becoming deprecation
+// candidates.
+func (f *modelFilter) filterExisting(models map[string]*catalog.Model) map[string]*catalog.Model {
+	if f == nil {
+		return models
+	}
+	kept := make(map[string]*catalog.Model, len(models))
+	for name, m := range models {
+		if f.Allows(name) && f.AllowsType(m.Type()) {
+			kept[name] = m
+		}
+	}
+	return kept
+}
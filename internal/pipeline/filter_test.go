@@ -0,0 +1,87 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/config"
+)
+
+func TestModelFilter(t *testing.T) {
+	f, err := newModelFilter(config.ModelFilterConfig{
+		IncludePatterns: []string{"gpt-*", "meta-llama/*"},
+		ExcludePatterns: []string{"*-preview", `/-\d{4}-\d{2}-\d{2}$/`},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"gpt-4o", true},
+		{"gpt-4o-preview", false},
+		{"gpt-4o-2024-08-06", false},
+		{"meta-llama/Llama-3.3-70B", true},
+		{"o3-mini", false},
+		{"xgpt-4o", false},
+	}
+	for _, tt := range tests {
+		if got := f.Allows(tt.name); got != tt.want {
+			t.Errorf("Allows(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestModelFilterExcludeOnly(t *testing.T) {
+	f, err := newModelFilter(config.ModelFilterConfig{ExcludePatterns: []string{"*embed*"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.Allows("mistral-large") || f.Allows("mistral-embed") {
+		t.Error("exclude-only filter should keep everything but embeddings")
+	}
+
+	if f, err := newModelFilter(config.ModelFilterConfig{}, nil); err != nil || f != nil || !f.Allows("anything") {
+		t.Errorf("empty config: filter %v, err %v; want nil filter allowing all", f, err)
+	}
+	if _, err := newModelFilter(config.ModelFilterConfig{IncludePatterns: []string{"/(/"}}, nil); err == nil {
+		t.Error("expected error for invalid regular expression")
+	}
+}
+
+func TestModelFilterTypes(t *testing.T) {
+	f, err := newModelFilter(config.ModelFilterConfig{}, []string{"chat", "embedding"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	discovered := []adapter.DiscoveredModel{
+		{Name: "gpt-4o", Capabilities: []string{"chat"}},
+		{Name: "text-embedding-3-small", Capabilities: []string{"embeddings"}},
+		{Name: "whisper-1", ModelType: "speech_to_text", Capabilities: []string{"speech_to_text"}},
+		{Name: "docs-only"},
+	}
+	var kept []string
+	for _, m := range f.filterDiscovered(discovered) {
+		kept = append(kept, m.Name)
+	}
+	if strings.Join(kept, ",") != "gpt-4o,text-embedding-3-small,docs-only" {
+		t.Errorf("kept %v", kept)
+	}
+
+	existing := map[string]*catalog.Model{
+		"gpt-4o":    {Name: "gpt-4o", Capabilities: []string{"chat"}},
+		"dall-e-3":  {Name: "dall-e-3", ModelType: "image_generation"},
+		"whisper-1": {Name: "whisper-1", Capabilities: []string{"speech_to_text"}},
+	}
+	if got := f.filterExisting(existing); len(got) != 1 || got["gpt-4o"] == nil {
+		t.Errorf("filterExisting kept %v, want only gpt-4o", got)
+	}
+
+	if _, err := newModelFilter(config.ModelFilterConfig{}, []string{"audio"}); err == nil {
+		t.Error("expected error for an unknown model type")
+	}
+}
@@ -1,7 +1,15 @@
 package pipeline
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -16,11 +24,24 @@ type GitOps struct {
 	repo     *git.Repository
 	worktree *git.Worktree
 	token    string
+
+	name, email string     // commit author; sentinel's own identity when empty
+	signer      git.Signer // signs commits when set
+}
+
+// SetAuthor sets the name and email commits are made with.
+func (g *GitOps) SetAuthor(name, email string) {
+	g.name, g.email = name, email
 }
 
-// OpenRepo opens a git repository at the given path.
+// SetSigner signs every commit with s; nil disables signing.
+func (g *GitOps) SetSigner(s git.Signer) {
+	g.signer = s
+}
+
+// OpenRepo opens the git repository containing the given path.
 func OpenRepo(path, token string) (*GitOps, error) {
-	repo, err := git.PlainOpen(path)
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
 	if err != nil {
 		return nil, fmt.Errorf("opening repo: %w", err)
 	}
@@ -33,6 +54,46 @@ func OpenRepo(path, token string) (*GitOps, error) {
 	return &GitOps{repo: repo, worktree: wt, token: token}, nil
 }
 
+// CloneRepo clones the repository at url into path, checked out at branch,
+// authenticating with token when it is set. path must not exist or be an
+// empty directory.
+func CloneRepo(ctx context.Context, url, path, branch, token string) (*GitOps, error) {
+	opts := &git.CloneOptions{
+		URL:          url,
+		SingleBranch: true,
+	}
+	if branch != "" {
+		opts.ReferenceName = plumbing.NewBranchReferenceName(branch)
+	}
+	if token != "" {
+		opts.Auth = &githttp.BasicAuth{Username: "x-access-token", Password: token}
+	}
+	repo, err := git.PlainCloneContext(ctx, path, false, opts)
+	if err != nil {
+		return nil, fmt.Errorf("cloning %s: %w", url, err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("getting worktree: %w", err)
+	}
+
+	return &GitOps{repo: repo, worktree: wt, token: token}, nil
+}
+
+// needsClone reports whether path has no catalog checkout yet: it doesn't
+// exist or is an empty directory, like a fresh volume mount.
+func needsClone(path string) (bool, error) {
+	entries, err := os.ReadDir(path)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(entries) == 0, nil
+}
+
 // CreateBranch creates and checks out a new branch.
 func (g *GitOps) CreateBranch(name string) error {
 	headRef, err := g.repo.Head()
@@ -47,37 +108,203 @@ func (g *GitOps) CreateBranch(name string) error {
 		return fmt.Errorf("creating branch ref: %w", err)
 	}
 
+	// Keep the catalog changes, as git checkout -b does; go-git refuses to
+	// switch branches over them otherwise.
 	return g.worktree.Checkout(&git.CheckoutOptions{
 		Branch: branchRef,
+		Keep:   true,
 	})
 }
 
+// Head returns the current branch, or commit when HEAD is detached, to
+// return to with Restore.
+func (g *GitOps) Head() (*plumbing.Reference, error) {
+	return g.repo.Head()
+}
+
+// Restore checks out ref, as returned by Head, discarding changes to
+// tracked files in the worktree and the index.
+func (g *GitOps) Restore(ref *plumbing.Reference) error {
+	opts := &git.CheckoutOptions{Force: true}
+	if ref.Name().IsBranch() {
+		opts.Branch = ref.Name()
+	} else {
+		opts.Hash = ref.Hash()
+	}
+	return g.worktree.Checkout(opts)
+}
+
 // AddAll stages all changes.
 func (g *GitOps) AddAll() error {
 	_, err := g.worktree.Add(".")
 	return err
 }
 
-// Commit creates a commit with the given message.
+// Commit creates a commit with the given message, signed when a signer
+// is set.
 func (g *GitOps) Commit(message string) error {
+	name, email := g.name, g.email
+	if name == "" {
+		name = "sentinel"
+	}
+	if email == "" {
+		email = "sentinel@everstack.dev"
+	}
 	_, err := g.worktree.Commit(message, &git.CommitOptions{
 		Author: &object.Signature{
-			Name:  "sentinel",
-			Email: "sentinel@everstack.dev",
+			Name:  name,
+			Email: email,
 			When:  time.Now(),
 		},
+		Signer: g.signer,
 	})
 	return err
 }
 
-// Push pushes the current branch to origin.
-func (g *GitOps) Push() error {
-	return g.repo.Push(&git.PushOptions{
-		RemoteName: "origin",
-		RefSpecs:   []gitconfig.RefSpec{gitconfig.RefSpec("+refs/heads/*:refs/heads/*")},
-		Auth: &githttp.BasicAuth{
-			Username: "x-access-token",
-			Password: g.token,
-		},
-	})
+// Push pushes the current branch to origin. Pushes turned away by rate
+// limiting or a temporarily unavailable server are retried with backoff,
+// until ctx is done.
+func (g *GitOps) Push(ctx context.Context) error {
+	for attempt := 0; ; attempt++ {
+		err := g.repo.PushContext(ctx, &git.PushOptions{
+			RemoteName: "origin",
+			RefSpecs:   []gitconfig.RefSpec{gitconfig.RefSpec("+refs/heads/*:refs/heads/*")},
+			Auth: &githttp.BasicAuth{
+				Username: "x-access-token",
+				Password: g.token,
+			},
+		})
+		if err == nil || errors.Is(err, git.NoErrAlreadyUpToDate) || attempt >= pushRetries || !retryablePushError(err) {
+			return err
+		}
+		wait := pushBackoff << attempt
+		slog.Warn("push rejected, backing off", "error", err, "wait", wait, "attempt", attempt+1, "max_retries", pushRetries)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (waiting to retry after: %v)", context.Cause(ctx), err)
+		case <-time.After(wait):
+		}
+	}
+}
+
+const (
+	pushRetries = 3
+	pushBackoff = 30 * time.Second
+)
+
+// pushRetryRe matches the push failures worth retrying: rate limiting and
+// server errors, as reported by go-git's HTTP transport.
+var pushRetryRe = regexp.MustCompile(`(?i)status code: (429|5\d\d)|rate limit|abuse|temporarily unavailable`)
+
+func retryablePushError(err error) bool {
+	return pushRetryRe.MatchString(err.Error())
+}
+
+// RepoPath returns path (absolute or relative to the working directory) as
+// a slash-separated path relative to the repository root, as GitHub expects.
+func (g *GitOps) RepoPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(g.worktree.Filesystem.Root(), abs)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the repository", path)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// ChangedFiles returns the files under dir that differ between the merge
+// base of base and HEAD, plus uncommitted changes in the worktree. Paths are
+// relative to dir. Deleted files are included; callers decide what to skip.
+func (g *GitOps) ChangedFiles(base, dir string) ([]string, error) {
+	baseHash, err := g.repo.ResolveRevision(plumbing.Revision(base))
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", base, err)
+	}
+	headRef, err := g.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("getting HEAD: %w", err)
+	}
+
+	baseCommit, err := g.repo.CommitObject(*baseHash)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", base, err)
+	}
+	headCommit, err := g.repo.CommitObject(headRef.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("loading HEAD: %w", err)
+	}
+
+	bases, err := headCommit.MergeBase(baseCommit)
+	if err != nil {
+		return nil, fmt.Errorf("finding merge base with %s: %w", base, err)
+	}
+	if len(bases) == 0 {
+		return nil, fmt.Errorf("no common ancestor between HEAD and %s (shallow clone?)", base)
+	}
+
+	fromTree, err := bases[0].Tree()
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, fmt.Errorf("diffing against %s: %w", base, err)
+	}
+
+	changed := make(map[string]bool)
+	for _, c := range changes {
+		if c.To.Name != "" {
+			changed[c.To.Name] = true
+		}
+		if c.From.Name != "" {
+			changed[c.From.Name] = true
+		}
+	}
+
+	status, err := g.worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("reading worktree status: %w", err)
+	}
+	for path, st := range status {
+		if st.Staging != git.Unmodified || st.Worktree != git.Unmodified {
+			changed[path] = true
+		}
+	}
+
+	return filterToDir(changed, g.worktree.Filesystem.Root(), dir)
+}
+
+// filterToDir keeps repo-relative paths that fall under dir and rewrites
+// them relative to it.
+func filterToDir(paths map[string]bool, root, dir string) ([]string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := filepath.Rel(root, absDir)
+	if err != nil {
+		return nil, err
+	}
+	prefix = filepath.ToSlash(prefix)
+
+	var out []string
+	for p := range paths {
+		switch {
+		case prefix == ".":
+			out = append(out, p)
+		case strings.HasPrefix(p, prefix+"/"):
+			out = append(out, strings.TrimPrefix(p, prefix+"/"))
+		}
+	}
+	sort.Strings(out)
+	return out, nil
 }
@@ -0,0 +1,249 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/pr/render"
+)
+
+func TestChangedFiles(t *testing.T) {
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit := func(msg string) plumbing.Hash {
+		t.Helper()
+		if _, err := wt.Add("."); err != nil {
+			t.Fatal(err)
+		}
+		h, err := wt.Commit(msg, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	write("catalog/providers/openai/models/gpt-4o.yaml", "name: gpt-4o\n")
+	write("catalog/providers/openai/models/gpt-3.5.yaml", "name: gpt-3.5\n")
+	write("README.md", "readme\n")
+	base := commit("initial")
+	if err := repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/main", base)); err != nil {
+		t.Fatal(err)
+	}
+
+	write("catalog/providers/openai/models/gpt-4o.yaml", "name: gpt-4o\nstatus: stable\n")
+	write("README.md", "changed\n")
+	if err := os.Remove(filepath.Join(root, "catalog/providers/openai/models/gpt-3.5.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	commit("update")
+	write("catalog/providers/openai/models/gpt-5.yaml", "name: gpt-5\n") // uncommitted
+
+	g, err := OpenRepo(filepath.Join(root, "catalog"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := g.ChangedFiles("main", filepath.Join(root, "catalog"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"providers/openai/models/gpt-3.5.yaml",
+		"providers/openai/models/gpt-4o.yaml",
+		"providers/openai/models/gpt-5.yaml",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := g.ChangedFiles("no-such-branch", root); err == nil {
+		t.Error("expected error for unknown base revision")
+	}
+}
+
+func TestRepoPath(t *testing.T) {
+	root := t.TempDir()
+	if _, err := git.PlainInit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	g, err := OpenRepo(filepath.Join(root), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := g.RepoPath(filepath.Join(root, "catalog", "providers", "openai", "models", "gpt-4o.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "catalog/providers/openai/models/gpt-4o.yaml" {
+		t.Errorf("RepoPath = %q", got)
+	}
+	if _, err := g.RepoPath(filepath.Dir(root)); err == nil {
+		t.Error("expected error for a path outside the repository")
+	}
+}
+
+func TestRetryablePushError(t *testing.T) {
+	tests := []struct {
+		err  string
+		want bool
+	}{
+		{`unexpected client error: unexpected requesting "https://github.com/acme/catalog/git-receive-pack" status code: 429`, true},
+		{`unexpected requesting "https://ghe.example.com/acme/catalog/info/refs" status code: 503`, true},
+		{"remote: You have exceeded a secondary rate limit", true},
+		{"authentication required", false},
+		{"non-fast-forward update: refs/heads/main", false},
+	}
+	for _, tt := range tests {
+		if got := retryablePushError(errors.New(tt.err)); got != tt.want {
+			t.Errorf("retryablePushError(%q) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestPushStopsBackingOffWhenCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{srv.URL + "/acme/catalog.git"}}); err != nil {
+		t.Fatal(err)
+	}
+	g, err := OpenRepo(root, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 100*time.Millisecond, ErrDeadline)
+	defer cancel()
+	began := time.Now()
+	err = g.Push(ctx)
+	if !errors.Is(err, ErrDeadline) {
+		t.Errorf("Push = %v, want ErrDeadline", err)
+	}
+	if elapsed := time.Since(began); elapsed > 5*time.Second {
+		t.Errorf("Push returned after %s, want it to stop backing off at the deadline", elapsed)
+	}
+}
+
+func TestEnsureCatalogClones(t *testing.T) {
+	src := t.TempDir()
+	repo, err := git.PlainInit(src, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "version.txt"), []byte("1.0.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wt.Add("."); err != nil {
+		t.Fatal(err)
+	}
+	h, err := wt.Commit("initial", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/main", h)); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(t.TempDir(), "catalog")
+	cfg := &config.Config{CatalogPath: dst}
+	cfg.GitHub = config.GitHubConfig{AutoClone: true, CloneURL: src, BaseBranch: "main"}
+	p := New(cfg)
+	if err := p.ensureCatalog(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "version.txt")); err != nil {
+		t.Fatalf("catalog not cloned: %v", err)
+	}
+	cloned, err := git.PlainOpen(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head, err := cloned.Head(); err != nil || head.Name().Short() != "main" {
+		t.Errorf("checked out %v (%v), want main", head, err)
+	}
+
+	// An existing checkout is left alone.
+	if err := os.WriteFile(filepath.Join(dst, "version.txt"), []byte("2.0.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.ensureCatalog(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, "version.txt")); string(data) != "2.0.0\n" {
+		t.Errorf("existing checkout touched: version.txt = %q", data)
+	}
+
+	// Without a repository to clone from, auto_clone is an error.
+	cfg.CatalogPath, cfg.GitHub.CloneURL = t.TempDir(), ""
+	if err := p.ensureCatalog(context.Background()); err == nil {
+		t.Error("expected an error without clone_url or owner/repo")
+	}
+}
+
+func TestCommitMessage(t *testing.T) {
+	in := &render.Input{
+		ChangeSet: &diff.ChangeSet{
+			Provider: "openai",
+			New:      []diff.ModelChange{{Name: "gpt-5"}, {Name: "gpt-5-mini"}},
+			Updated:  []diff.ModelUpdate{{Name: "gpt-4o"}},
+		},
+		FromVersion: "1.4.2",
+		ToVersion:   "1.5.0",
+	}
+	want := "chore(catalog): update openai models\n\nModels: 2 new, 1 updated.\nCatalog version 1.4.2 -> 1.5.0.\n"
+	if got := commitMessage("chore(catalog): update openai models", in); got != want {
+		t.Errorf("commitMessage = %q, want %q", got, want)
+	}
+	empty := &render.Input{ChangeSet: &diff.ChangeSet{Provider: "openai"}}
+	if got := commitMessage("subject", empty); got != "subject" {
+		t.Errorf("commitMessage without changes = %q", got)
+	}
+}
@@ -4,25 +4,67 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v60/github"
+	"github.com/everstacklabs/sentinel/internal/config"
 	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
 	"github.com/everstacklabs/sentinel/internal/judge"
+	"github.com/everstacklabs/sentinel/internal/pr/render"
 	"golang.org/x/oauth2"
 )
 
-// createPR creates a GitHub PR for catalog changes.
-func (p *Pipeline) createPR(ctx context.Context, provider string, cs *diff.ChangeSet, draft bool, judgeResult *judge.Result) (int, error) {
+// createPR creates a GitHub PR for catalog changes, targeting base.
+func (p *Pipeline) createPR(ctx context.Context, provider string, in *render.Input, draft bool, base string) (_ int, err error) {
 	branchName := fmt.Sprintf("sentinel/%s-%s", provider, time.Now().Format("20060102-150405"))
 	commitMsg := fmt.Sprintf("chore(catalog): update %s models", provider)
+	title := commitMsg
+	if s := in.Split; s != nil {
+		branchName += fmt.Sprintf("-part%d", s.Part)
+		commitMsg += fmt.Sprintf(" (part %d of %d)", s.Part, s.Total)
+		title = commitMsg
+	}
+
+	// Reuse the branch of a PR still open from an earlier run, so it is
+	// updated in place rather than duplicated. Split chains are always new.
+	client := p.githubClient(ctx)
+	var existing *github.PullRequest
+	if in.Split == nil && p.cfg.GitHub.UpdateOpenPRs {
+		var err error
+		existing, err = p.findOpenPR(ctx, client, branchPattern(provider))
+		if err != nil {
+			slog.Warn("looking up open PRs failed, opening a new one", "provider", provider, "error", err)
+		} else if existing != nil {
+			branchName = existing.GetHead().GetRef()
+		}
+	}
 
 	// Git operations
-	gitOps, err := OpenRepo(p.cfg.CatalogPath, p.cfg.GitHub.Token)
+	gitOps, err := p.openRepo()
 	if err != nil {
 		return 0, err
 	}
 
+	// On failure, go back to where the run was, so the next provider's
+	// branch doesn't start from this one's commit.
+	start, err := gitOps.Head()
+	if err != nil {
+		return 0, fmt.Errorf("getting HEAD: %w", err)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if rerr := gitOps.Restore(start); rerr != nil {
+			slog.Error("restoring the branch after a failed PR", "provider", provider, "branch", start.Name(), "error", rerr)
+		}
+	}()
+
 	if err := gitOps.CreateBranch(branchName); err != nil {
 		return 0, fmt.Errorf("creating branch: %w", err)
 	}
@@ -31,30 +73,33 @@ func (p *Pipeline) createPR(ctx context.Context, provider string, cs *diff.Chang
 		return 0, fmt.Errorf("staging changes: %w", err)
 	}
 
-	if err := gitOps.Commit(commitMsg); err != nil {
+	if err := gitOps.Commit(commitMessage(commitMsg, in)); err != nil {
 		return 0, fmt.Errorf("committing: %w", err)
 	}
 
-	if err := gitOps.Push(); err != nil {
+	if err := gitOps.Push(ctx); err != nil {
 		return 0, fmt.Errorf("pushing: %w", err)
 	}
 
-	// Create PR
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: p.cfg.GitHub.Token})
-	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
+	in.Branch = branchName
+	body := render.Body(in)
 
-	title := fmt.Sprintf("chore(catalog): update %s models", provider)
-	body := diff.RenderPRBody(cs)
-	if section := judge.RenderSection(judgeResult); section != "" {
-		body += "\n" + section
+	if existing != nil {
+		pr, err := p.updatePR(ctx, client, existing, title, body, draft)
+		if err != nil {
+			return 0, err
+		}
+		slog.Info("PR updated", "provider", provider, "number", pr.GetNumber(), "url", pr.GetHTMLURL())
+		p.postVerdictComments(ctx, client, gitOps, pr, in)
+		return pr.GetNumber(), nil
 	}
 
+	// Create PR
 	pr, _, err := client.PullRequests.Create(ctx, p.cfg.GitHub.Owner, p.cfg.GitHub.Repo, &github.NewPullRequest{
 		Title: &title,
 		Body:  &body,
 		Head:  &branchName,
-		Base:  &p.cfg.GitHub.BaseBranch,
+		Base:  &base,
 		Draft: &draft,
 	})
 	if err != nil {
@@ -67,5 +112,256 @@ func (p *Pipeline) createPR(ctx context.Context, provider string, cs *diff.Chang
 		"draft", draft,
 		"url", pr.GetHTMLURL())
 
+	p.postVerdictComments(ctx, client, gitOps, pr, in)
+
 	return pr.GetNumber(), nil
 }
+
+// openRepo opens the catalog repository for committing, with the
+// configured author and signing key.
+func (p *Pipeline) openRepo() (*GitOps, error) {
+	gitOps, err := OpenRepo(p.cfg.CatalogPath, p.cfg.GitHub.Token)
+	if err != nil {
+		return nil, err
+	}
+	gitOps.SetAuthor(p.cfg.GitHub.CommitName, p.cfg.GitHub.CommitEmail)
+	gitOps.SetSigner(p.signer)
+	return gitOps, nil
+}
+
+// commitMessage adds a body summarizing what was written to a conventional
+// commit subject.
+func commitMessage(subject string, in *render.Input) string {
+	cs := in.ChangeSet
+	var counts []string
+	for _, c := range []struct {
+		n    int
+		what string
+	}{
+		{len(cs.New), "new"},
+		{len(cs.Updated), "updated"},
+		{len(cs.Renamed), "renamed"},
+		{len(cs.DeprecationCandidates), "deprecation candidates"},
+	} {
+		if c.n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", c.n, c.what))
+		}
+	}
+	var body []string
+	if len(counts) > 0 {
+		body = append(body, "Models: "+strings.Join(counts, ", ")+".")
+	}
+	if in.FromVersion != "" && in.ToVersion != "" && in.FromVersion != in.ToVersion {
+		body = append(body, fmt.Sprintf("Catalog version %s -> %s.", in.FromVersion, in.ToVersion))
+	}
+	if len(body) == 0 {
+		return subject
+	}
+	return subject + "\n\n" + strings.Join(body, "\n") + "\n"
+}
+
+// postVerdictComments comments on the file of each model the judge flagged
+// or rejected. A PR updated in place keeps its comments, so one already on
+// the same file with the same text is not posted again. Comments are a
+// convenience; the PR body already has the verdicts, so failures are only
+// logged.
+func (p *Pipeline) postVerdictComments(ctx context.Context, client *github.Client, gitOps *GitOps, pr *github.PullRequest, in *render.Input) {
+	if !p.cfg.Judge.ReviewComments || in.Judge == nil {
+		return
+	}
+	provider := in.ChangeSet.Provider
+	pathFor := func(name string) (string, error) {
+		return gitOps.RepoPath(filepath.Join(p.cfg.CatalogPath, "providers", provider, "models", name+p.format().Ext()))
+	}
+	comments, err := verdictComments(in.ChangeSet, in.Judge, pathFor)
+	if err != nil {
+		slog.Warn("skipping judge review comments", "provider", provider, "error", err)
+		return
+	}
+	if len(comments) == 0 {
+		return
+	}
+	posted, err := p.reviewComments(ctx, client, pr.GetNumber())
+	if err != nil {
+		slog.Warn("skipping judge review comments", "provider", provider, "error", err)
+		return
+	}
+	for _, c := range comments {
+		if posted[commentKey(c)] {
+			continue
+		}
+		c.CommitID = github.String(pr.GetHead().GetSHA())
+		if _, _, err := client.PullRequests.CreateComment(ctx, p.cfg.GitHub.Owner, p.cfg.GitHub.Repo, pr.GetNumber(), c); err != nil {
+			slog.Warn("posting judge review comment failed", "provider", provider, "path", c.GetPath(), "error", err)
+		}
+	}
+}
+
+// reviewComments returns the keys of the review comments already on PR
+// number.
+func (p *Pipeline) reviewComments(ctx context.Context, client *github.Client, number int) (map[string]bool, error) {
+	posted := make(map[string]bool)
+	opts := &github.PullRequestListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := client.PullRequests.ListComments(ctx, p.cfg.GitHub.Owner, p.cfg.GitHub.Repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("listing review comments on #%d: %w", number, err)
+		}
+		for _, c := range comments {
+			posted[commentKey(c)] = true
+		}
+		if resp.NextPage == 0 {
+			return posted, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// commentKey identifies a review comment by the file it is on and its text.
+func commentKey(c *github.PullRequestComment) string {
+	return c.GetPath() + "\x00" + c.GetBody()
+}
+
+// branchPattern matches the branches sentinel opens PRs from for name, a
+// provider or "catalog" for aggregated runs. Parts of split chains don't
+// match.
+func branchPattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(`^sentinel/` + regexp.QuoteMeta(name) + `-\d{8}-\d{6}$`)
+}
+
+// findOpenPR returns the newest open PR into the base branch whose head
+// branch matches pattern, or nil if there is none.
+func (p *Pipeline) findOpenPR(ctx context.Context, client *github.Client, pattern *regexp.Regexp) (*github.PullRequest, error) {
+	opts := &github.PullRequestListOptions{
+		State:       "open",
+		Base:        p.cfg.GitHub.BaseBranch,
+		Sort:        "created",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		prs, resp, err := client.PullRequests.List(ctx, p.cfg.GitHub.Owner, p.cfg.GitHub.Repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		if pr := matchOpenPR(prs, pattern, p.cfg.GitHub.Owner+"/"+p.cfg.GitHub.Repo); pr != nil {
+			return pr, nil
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// matchOpenPR returns the first PR whose head branch matches pattern and
+// lives in repo itself; PRs from forks are never reused.
+func matchOpenPR(prs []*github.PullRequest, pattern *regexp.Regexp, repo string) *github.PullRequest {
+	for _, pr := range prs {
+		head := pr.GetHead()
+		if pattern.MatchString(head.GetRef()) && strings.EqualFold(head.GetRepo().GetFullName(), repo) {
+			return pr
+		}
+	}
+	return nil
+}
+
+// updatePR replaces the title and body of a PR whose branch was just
+// force-pushed, returning the PR with its new head. The REST API can't turn
+// a ready PR back into a draft, so a draft-worthy update to a ready PR is
+// only logged.
+func (p *Pipeline) updatePR(ctx context.Context, client *github.Client, pr *github.PullRequest, title, body string, draft bool) (*github.PullRequest, error) {
+	if draft && !pr.GetDraft() {
+		slog.Warn("updated PR should be a draft but is ready for review", "number", pr.GetNumber())
+	}
+	updated, _, err := client.PullRequests.Edit(ctx, p.cfg.GitHub.Owner, p.cfg.GitHub.Repo, pr.GetNumber(), &github.PullRequest{
+		Title: &title,
+		Body:  &body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("updating PR #%d: %w", pr.GetNumber(), err)
+	}
+	return updated, nil
+}
+
+// githubClient returns an API client authenticated with the configured
+// token. It is shared by the whole run, so write pacing and rate limit
+// backoff apply across providers.
+func (p *Pipeline) githubClient(ctx context.Context) *github.Client {
+	if p.github == nil {
+		base := &http.Client{Transport: httpclient.NewGitHubTransport(nil, p.ghLimits)}
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: p.cfg.GitHub.Token})
+		p.github = github.NewClient(oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, base), ts))
+	}
+	return p.github
+}
+
+// githubLimits parses the GitHub rate limit settings.
+func githubLimits(cfg config.GitHubConfig) (httpclient.GitHubLimits, error) {
+	limits := httpclient.GitHubLimits{MaxRetries: cfg.RateLimitRetries}
+	for _, d := range []struct {
+		key, value string
+		dst        *time.Duration
+	}{
+		{"github.write_interval", cfg.WriteInterval, &limits.WriteInterval},
+		{"github.max_rate_limit_wait", cfg.MaxRateLimitWait, &limits.MaxWait},
+	} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil {
+			return limits, fmt.Errorf("invalid %s %q: %w", d.key, d.value, err)
+		}
+		*d.dst = v
+	}
+	return limits, nil
+}
+
+// linkSplitPRs rewrites the bodies of a chain of split PRs once all of them
+// exist, so each one links to every part. Failures only cost the links.
+func (p *Pipeline) linkSplitPRs(ctx context.Context, inputs []*render.Input, numbers []int) {
+	client := p.githubClient(ctx)
+	for i, in := range inputs {
+		in.Split.PRs = numbers
+		body := render.Body(in)
+		if _, _, err := client.PullRequests.Edit(ctx, p.cfg.GitHub.Owner, p.cfg.GitHub.Repo, numbers[i], &github.PullRequest{Body: &body}); err != nil {
+			slog.Warn("linking split PR failed", "pr", numbers[i], "error", err)
+		}
+	}
+}
+
+// verdictComments builds one file-level review comment per flagged or
+// rejected model, anchored to that model's YAML file so reviewers can resolve
+// each concern on its own. Verdicts for models whose files aren't part of the
+// PR (e.g. excluded or deprecation candidates) are skipped: GitHub only
+// accepts comments on changed files.
+func verdictComments(cs *diff.ChangeSet, result *judge.Result, pathFor func(name string) (string, error)) ([]*github.PullRequestComment, error) {
+	written := make(map[string]bool, cs.TotalChanged())
+	for _, m := range cs.New {
+		written[m.Name] = true
+	}
+	for _, u := range cs.Updated {
+		written[u.Name] = true
+	}
+	for _, r := range cs.Renamed {
+		written[r.Name] = true
+	}
+
+	var comments []*github.PullRequestComment
+	for _, v := range result.Verdicts {
+		if v.Verdict == judge.VerdictApprove || !written[v.ModelName] {
+			continue
+		}
+		path, err := pathFor(v.ModelName)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, &github.PullRequestComment{
+			Path:        github.String(path),
+			Body:        github.String(judge.RenderComment(v)),
+			SubjectType: github.String("file"),
+		})
+	}
+	return comments, nil
+}
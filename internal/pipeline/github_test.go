@@ -0,0 +1,150 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/judge"
+	"github.com/everstacklabs/sentinel/internal/pr/render"
+	"github.com/google/go-github/v60/github"
+)
+
+func TestVerdictComments(t *testing.T) {
+	cs := &diff.ChangeSet{
+		Provider:              "openai",
+		New:                   []diff.ModelChange{{Name: "gpt-5"}, {Name: "gpt-5-mini"}},
+		Updated:               []diff.ModelUpdate{{Name: "gpt-4o"}},
+		DeprecationCandidates: []diff.ModelChange{{Name: "gpt-3.5-turbo"}},
+	}
+	result := &judge.Result{Verdicts: []judge.ModelVerdict{
+		{ModelName: "gpt-5", Verdict: judge.VerdictApprove},
+		{ModelName: "gpt-5-mini", Verdict: judge.VerdictFlag, Confidence: 0.7, Concerns: []string{"price drop of 90%"}, Reasoning: "Check the pricing page."},
+		{ModelName: "gpt-4o", Verdict: judge.VerdictReject, Confidence: 0.9},
+		{ModelName: "gpt-3.5-turbo", Verdict: judge.VerdictFlag},
+	}}
+	pathFor := func(name string) (string, error) {
+		return "catalog/providers/openai/models/" + name + ".yaml", nil
+	}
+
+	comments, err := verdictComments(cs, result, pathFor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("expected comments for the flagged and rejected written models, got %d", len(comments))
+	}
+
+	flag := comments[0]
+	if flag.GetPath() != "catalog/providers/openai/models/gpt-5-mini.yaml" || flag.GetSubjectType() != "file" {
+		t.Errorf("comment anchored to %q (%s)", flag.GetPath(), flag.GetSubjectType())
+	}
+	for _, want := range []string{"flagged", "70%", "- price drop of 90%", "Check the pricing page."} {
+		if !strings.Contains(flag.GetBody(), want) {
+			t.Errorf("comment body missing %q:\n%s", want, flag.GetBody())
+		}
+	}
+	if !strings.Contains(comments[1].GetBody(), "rejected") {
+		t.Errorf("expected rejection comment, got:\n%s", comments[1].GetBody())
+	}
+}
+
+func TestPostVerdictCommentsOnUpdate(t *testing.T) {
+	var posted []*github.PullRequestComment
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/catalog/pulls/7/comments" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPost {
+			var c github.PullRequestComment
+			if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+				t.Error(err)
+			}
+			posted = append(posted, &c)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(c)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(posted)
+	}))
+	defer srv.Close()
+
+	root := t.TempDir()
+	if _, err := git.PlainInit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	gitOps, err := OpenRepo(root, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	p := New(&config.Config{
+		CatalogPath: filepath.Join(root, "catalog"),
+		GitHub:      config.GitHubConfig{Owner: "acme", Repo: "catalog"},
+		Judge:       config.JudgeConfig{ReviewComments: true},
+	})
+	pr := &github.PullRequest{Number: github.Int(7)}
+	in := &render.Input{
+		ChangeSet: &diff.ChangeSet{Provider: "openai", New: []diff.ModelChange{{Name: "gpt-5"}, {Name: "gpt-5-mini"}}},
+		Judge: &judge.Result{Verdicts: []judge.ModelVerdict{
+			{ModelName: "gpt-5", Verdict: judge.VerdictFlag, Concerns: []string{"no pricing"}},
+		}},
+	}
+
+	// The PR is opened, then updated in place by the next run.
+	p.postVerdictComments(context.Background(), client, gitOps, pr, in)
+	p.postVerdictComments(context.Background(), client, gitOps, pr, in)
+	if len(posted) != 1 {
+		t.Fatalf("posted %d comments, want the flag once", len(posted))
+	}
+
+	// A new verdict on the update is still posted.
+	in.Judge.Verdicts = append(in.Judge.Verdicts, judge.ModelVerdict{ModelName: "gpt-5-mini", Verdict: judge.VerdictReject})
+	p.postVerdictComments(context.Background(), client, gitOps, pr, in)
+	if len(posted) != 2 || posted[1].GetPath() != "catalog/providers/openai/models/gpt-5-mini.yaml" {
+		t.Errorf("posted = %v, want the rejection added", posted)
+	}
+}
+
+func TestMatchOpenPR(t *testing.T) {
+	pr := func(number int, ref, repo string) *github.PullRequest {
+		return &github.PullRequest{
+			Number: github.Int(number),
+			Head:   &github.PullRequestBranch{Ref: github.String(ref), Repo: &github.Repository{FullName: github.String(repo)}},
+		}
+	}
+	prs := []*github.PullRequest{
+		pr(9, "sentinel/openai-20260301-060000", "someone/model-catalog"),
+		pr(8, "sentinel/openai-compat-20260301-060000", "acme/model-catalog"),
+		pr(7, "sentinel/openai-20260301-060000-part2", "acme/model-catalog"),
+		pr(6, "sentinel/openai-20260228-060000", "acme/model-catalog"),
+		pr(5, "sentinel/openai-20260227-060000", "acme/model-catalog"),
+	}
+
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"openai", 6},
+		{"openai-compat", 8},
+		{"groq", 0},
+	}
+	for _, tt := range tests {
+		got := matchOpenPR(prs, branchPattern(tt.name), "Acme/model-catalog")
+		if got.GetNumber() != tt.want {
+			t.Errorf("matchOpenPR(%s) = #%d, want #%d", tt.name, got.GetNumber(), tt.want)
+		}
+	}
+}
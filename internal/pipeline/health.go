@@ -0,0 +1,110 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+)
+
+// Provider health statuses reported by Health.
+const (
+	HealthOK       = "ok"
+	HealthLow      = "low"       // fewer models than the count threshold
+	HealthDown     = "down"      // liveness probe or discovery failed
+	HealthNoAccess = "no access" // the account lacks entitlement (HTTP 402/403)
+	HealthNoProbe  = "no probe"  // the adapter has no health check
+)
+
+// ProviderHealth is the state of one provider's sources.
+type ProviderHealth struct {
+	Provider    string `json:"provider"`
+	Status      string `json:"status"`
+	LatencyMS   int64  `json:"latency_ms"`             // of the liveness probe
+	Models      int    `json:"models,omitempty"`       // discovered; 0 when not counted
+	MinExpected int    `json:"min_expected,omitempty"` // the adapter's MinExpectedModels
+	Threshold   int    `json:"threshold,omitempty"`    // MinExpected scaled by health.threshold
+	Error       string `json:"error,omitempty"`
+	Incident    string `json:"incident,omitempty"` // declared on the status page, with health.status_pages
+}
+
+// Healthy reports whether the provider can be synced as things stand.
+func (h *ProviderHealth) Healthy() bool {
+	return h.Status == HealthOK || h.Status == HealthNoProbe
+}
+
+// Health probes every configured provider concurrently, without touching
+// the catalog. With countModels set, providers that pass the probe also
+// run discovery so the model count can be checked against the threshold.
+// Results are in the order of the configured providers.
+func (p *Pipeline) Health(ctx context.Context, countModels bool) []ProviderHealth {
+	results := make([]ProviderHealth, len(p.cfg.Providers))
+	var wg sync.WaitGroup
+	for i, name := range p.cfg.Providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = p.providerHealth(ctx, name, countModels)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func (p *Pipeline) providerHealth(ctx context.Context, name string, countModels bool) ProviderHealth {
+	h := ProviderHealth{Provider: name}
+	a, err := adapter.Get(name)
+	if err != nil {
+		h.Status, h.Error = HealthDown, err.Error()
+		return h
+	}
+	if incident := p.providerIncident(ctx, a); incident != nil {
+		h.Incident = incident.String()
+	}
+
+	fail := func(err error) ProviderHealth {
+		h.Status, h.Error = HealthDown, err.Error()
+		if ee, ok := asEntitlementError(name, err); ok {
+			h.Status, h.Error = HealthNoAccess, ee.Error()
+		}
+		return h
+	}
+
+	hc, ok := a.(adapter.HealthChecker)
+	if ok {
+		h.MinExpected = hc.MinExpectedModels()
+		h.Threshold = int(float64(h.MinExpected) * p.cfg.Health.Threshold)
+		start := time.Now()
+		err := hc.HealthCheck(ctx)
+		h.LatencyMS = time.Since(start).Milliseconds()
+		if err != nil {
+			return fail(err)
+		}
+	}
+	h.Status = HealthOK
+	if !ok {
+		h.Status = HealthNoProbe
+	}
+	if !countModels {
+		return h
+	}
+
+	sources := make([]adapter.SourceType, 0, len(p.cfg.Sources))
+	for _, s := range p.cfg.Sources {
+		sources = append(sources, adapter.SourceType(s))
+	}
+	discovered, err := p.discover(ctx, a, adapter.DiscoverOptions{
+		Sources:  sources,
+		NoCache:  p.cfg.NoCache,
+		CacheDir: p.cfg.CacheDir,
+	})
+	if err != nil {
+		return fail(err)
+	}
+	h.Models = len(deduplicateDiscovered(discovered))
+	if h.Threshold > 0 && h.Models < h.Threshold {
+		h.Status = HealthLow
+	}
+	return h
+}
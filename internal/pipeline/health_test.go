@@ -0,0 +1,90 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+)
+
+// healthAdapter discovers a fixed number of models. probedAdapter adds an
+// adapter.HealthChecker whose probe returns probeErr.
+type healthAdapter struct {
+	name     string
+	models   int
+	min      int
+	probeErr error
+}
+
+func (a *healthAdapter) Name() string { return a.name }
+
+func (a *healthAdapter) SupportedSources() []adapter.SourceType {
+	return []adapter.SourceType{adapter.SourceAPI}
+}
+
+func (a *healthAdapter) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
+	var models []adapter.DiscoveredModel
+	for i := 0; i < a.models; i++ {
+		models = append(models, adapter.DiscoveredModel{Name: fmt.Sprintf("model-%d", i)})
+	}
+	return models, nil
+}
+
+type probedAdapter struct{ healthAdapter }
+
+func (a *probedAdapter) HealthCheck(ctx context.Context) error { return a.probeErr }
+func (a *probedAdapter) MinExpectedModels() int                { return a.min }
+
+func TestHealth(t *testing.T) {
+	adapter.Register(&probedAdapter{healthAdapter{name: "health-ok", models: 10, min: 10}})
+	adapter.Register(&probedAdapter{healthAdapter{name: "health-low", models: 3, min: 10}})
+	adapter.Register(&probedAdapter{healthAdapter{name: "health-down", models: 10, min: 10, probeErr: errors.New("connection refused")}})
+	adapter.Register(&probedAdapter{healthAdapter{name: "health-denied", models: 10, min: 10,
+		probeErr: &httpclient.StatusError{StatusCode: 403, Body: []byte("forbidden")}}})
+	adapter.Register(&healthAdapter{name: "health-noprobe", models: 2})
+
+	cfg := &config.Config{
+		Providers: []string{"health-ok", "health-low", "health-down", "health-denied", "health-noprobe", "health-missing"},
+		Sources:   []string{"api"},
+		Health:    config.HealthConfig{Enabled: true, Threshold: 0.5},
+	}
+	results := New(cfg).Health(context.Background(), true)
+
+	want := []struct {
+		status    string
+		models    int
+		threshold int
+		healthy   bool
+	}{
+		{HealthOK, 10, 5, true},
+		{HealthLow, 3, 5, false},
+		{HealthDown, 0, 5, false},
+		{HealthNoAccess, 0, 5, false},
+		{HealthNoProbe, 2, 0, true},
+		{HealthDown, 0, 0, false},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		h := results[i]
+		if h.Provider != cfg.Providers[i] {
+			t.Errorf("result %d is %s, want %s", i, h.Provider, cfg.Providers[i])
+		}
+		if h.Status != w.status || h.Models != w.models || h.Threshold != w.threshold || h.Healthy() != w.healthy {
+			t.Errorf("%s: got status=%q models=%d threshold=%d healthy=%v, want %q %d %d %v",
+				h.Provider, h.Status, h.Models, h.Threshold, h.Healthy(), w.status, w.models, w.threshold, w.healthy)
+		}
+	}
+
+	// Probe only: no discovery, so a low count goes unnoticed.
+	cfg.Providers = []string{"health-low"}
+	results = New(cfg).Health(context.Background(), false)
+	if results[0].Status != HealthOK || results[0].Models != 0 {
+		t.Errorf("probe only: got status=%q models=%d", results[0].Status, results[0].Models)
+	}
+}
@@ -0,0 +1,157 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// LoadCatalogVersion loads the catalog at catalogPath as it was at a
+// published version, from the history of the git repository containing it.
+func LoadCatalogVersion(catalogPath, version string) (*catalog.Catalog, error) {
+	return loadCatalogCommit(catalogPath, version, func(g *GitOps, dir string) (*object.Commit, error) {
+		return g.versionCommit(dir, version)
+	})
+}
+
+// LoadCatalogRef loads the catalog at catalogPath as it was at a git
+// revision: a branch, tag, commit or expression such as HEAD~3. A ref that
+// is none of these is looked up as a published version.
+func LoadCatalogRef(catalogPath, ref string) (*catalog.Catalog, error) {
+	return loadCatalogCommit(catalogPath, ref, func(g *GitOps, dir string) (*object.Commit, error) {
+		if hash, err := g.repo.ResolveRevision(plumbing.Revision(ref)); err == nil {
+			return g.repo.CommitObject(*hash)
+		}
+		return g.versionCommit(dir, ref)
+	})
+}
+
+// loadCatalogCommit loads the catalog at catalogPath from the commit find
+// picks in the history of the git repository containing it.
+func loadCatalogCommit(catalogPath, name string, find func(g *GitOps, dir string) (*object.Commit, error)) (*catalog.Catalog, error) {
+	g, err := OpenRepo(catalogPath, "")
+	if err != nil {
+		return nil, err
+	}
+	dir, err := g.RepoPath(catalogPath)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := find(g, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "sentinel-catalog-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	if err := exportTree(commit, dir, tmp); err != nil {
+		return nil, fmt.Errorf("reading catalog at %s: %w", commit.Hash.String()[:12], err)
+	}
+
+	cat, err := catalog.Load(tmp)
+	if err != nil {
+		return nil, fmt.Errorf("loading catalog at %s: %w", name, err)
+	}
+	cat.BasePath = catalogPath
+	return cat, nil
+}
+
+// versionCommit finds the commit of a catalog version: a tag named after it
+// (with or without a "v" prefix), or else the newest commit reachable from
+// HEAD whose version.txt under dir holds it.
+func (g *GitOps) versionCommit(dir, version string) (*object.Commit, error) {
+	bare := strings.TrimPrefix(version, "v")
+	for _, tag := range []string{version, "v" + bare, bare} {
+		ref, err := g.repo.Tag(tag)
+		if err != nil {
+			continue
+		}
+		if t, err := g.repo.TagObject(ref.Hash()); err == nil {
+			return t.Commit()
+		}
+		return g.repo.CommitObject(ref.Hash())
+	}
+
+	head, err := g.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("getting HEAD: %w", err)
+	}
+	log, err := g.repo.Log(&git.LogOptions{From: head.Hash(), Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, err
+	}
+	versionFile := path.Join(dir, "version.txt")
+	var found *object.Commit
+	err = log.ForEach(func(c *object.Commit) error {
+		f, err := c.File(versionFile)
+		if err != nil {
+			return nil
+		}
+		content, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		if strings.TrimPrefix(strings.TrimSpace(content), "v") == bare {
+			found = c
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, storer.ErrStop) {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("catalog version %s not found in git history (no tag and no commit with that version.txt)", version)
+	}
+	return found, nil
+}
+
+// exportTree writes version.txt and providers/ under dir in commit's tree
+// to dest, laid out as a catalog.
+func exportTree(commit *object.Commit, dir, dest string) error {
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	prefix := ""
+	if dir != "." && dir != "" {
+		prefix = dir + "/"
+	}
+	return tree.Files().ForEach(func(f *object.File) error {
+		rel, ok := strings.CutPrefix(f.Name, prefix)
+		if !ok || (rel != "version.txt" && !strings.HasPrefix(rel, "providers/")) {
+			return nil
+		}
+		target := filepath.Join(dest, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		r, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		out, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, r); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
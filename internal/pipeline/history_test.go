@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestLoadCatalogVersion(t *testing.T) {
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := func(version string, models ...string) {
+		t.Helper()
+		writeFile(t, filepath.Join(root, "catalog", "version.txt"), version+"\n")
+		writeFile(t, filepath.Join(root, "catalog", "providers", "openai", "provider.yaml"), "name: openai\n")
+		for _, m := range models {
+			writeFile(t, filepath.Join(root, "catalog", "providers", "openai", "models", m+".yaml"), "name: "+m+"\n")
+		}
+		if _, err := wt.Add("."); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := wt.Commit("release "+version, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	commit("1.0.0", "gpt-4o")
+	commit("1.1.0", "gpt-5")
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateTag("v1.1.0", head.Hash(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(root, "catalog", "providers", "openai", "models", "gpt-4o.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	commit("1.2.0")
+
+	catalogPath := filepath.Join(root, "catalog")
+	tests := []struct {
+		version string
+		want    int // openai models
+	}{
+		{"v1.0.0", 1}, // from version.txt
+		{"1.1.0", 2},  // from the v1.1.0 tag
+		{"1.2.0", 1},
+	}
+	for _, tt := range tests {
+		cat, err := LoadCatalogVersion(catalogPath, tt.version)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.version, err)
+		}
+		if got := len(cat.Providers["openai"].Models); got != tt.want {
+			t.Errorf("%s: got %d openai models, want %d", tt.version, got, tt.want)
+		}
+	}
+
+	if _, err := LoadCatalogVersion(catalogPath, "9.9.9"); err == nil {
+		t.Error("expected error for a version not in history")
+	}
+
+	for _, tt := range []struct {
+		ref  string
+		want int
+	}{
+		{"HEAD~2", 1},
+		{"HEAD~1", 2},
+		{head.Hash().String(), 2},
+		{"v1.1.0", 2},
+		{"1.0.0", 1}, // not a revision: from version.txt
+	} {
+		cat, err := LoadCatalogRef(catalogPath, tt.ref)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.ref, err)
+		}
+		if got := len(cat.Providers["openai"].Models); got != tt.want {
+			t.Errorf("%s: got %d openai models, want %d", tt.ref, got, tt.want)
+		}
+	}
+	if _, err := LoadCatalogRef(catalogPath, "no-such-branch"); err == nil {
+		t.Error("expected error for an unknown ref")
+	}
+}
@@ -2,43 +2,155 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"math"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/cache"
 	"github.com/everstacklabs/sentinel/internal/catalog"
 	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/dbsync"
 	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/fsutil"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
 	"github.com/everstacklabs/sentinel/internal/judge"
+	"github.com/everstacklabs/sentinel/internal/pr/render"
 	"github.com/everstacklabs/sentinel/internal/validate"
+	"github.com/go-git/go-git/v5"
+	"github.com/google/go-github/v60/github"
 )
 
 // ExitCode constants for CLI.
 const (
 	ExitSuccess      = 0
+	ExitFailed       = 1 // A provider failed (sync with fail_on: [error])
 	ExitChanges      = 2 // Changes detected (diff mode)
 	ExitPolicyBlock  = 3 // Blocked by risk policy
 	ExitSourceHealth = 4 // Source health failure
+	ExitValidation   = 5 // Discovered models failed validation
 )
 
+// ErrDeadline is the cancellation cause of a run that hit its --deadline.
+var ErrDeadline = errors.New("run deadline exceeded")
+
+// notStarted is the result of a provider the run had no time left for.
+func notStarted(ctx context.Context, provider string) (SyncResult, bool) {
+	if ctx.Err() == nil {
+		return SyncResult{}, false
+	}
+	return SyncResult{Provider: provider, Error: fmt.Errorf("not started: %w", context.Cause(ctx))}, true
+}
+
+// ErrFailFast is why a fail_fast run didn't start the providers after the
+// first one that failed.
+var ErrFailFast = errors.New("an earlier provider failed (fail_fast)")
+
+// stoppedEarly is the result of a provider a fail_fast run skipped.
+func stoppedEarly(provider string) SyncResult {
+	return SyncResult{Provider: provider, Error: fmt.Errorf("not started: %w", ErrFailFast)}
+}
+
 // Pipeline orchestrates the full sync workflow.
 type Pipeline struct {
-	cfg     *config.Config
-	catalog *catalog.Catalog
+	cfg        *config.Config
+	catalog    *catalog.Catalog
+	db         *dbsync.Writer
+	judgeCache cache.Cache
+	rules      *validate.Ruleset
+	risk       RiskPolicy
+	aggregate  *aggregatePR // the run's shared PR branch with github.aggregate_prs
+	github     *github.Client
+	ghLimits   httpclient.GitHubLimits
+	signer     git.Signer // signs catalog commits, from github.signing
+
+	confirmRename RenameConfirmFunc  // asks about possible renames with diff.renames "confirm"
+	baseline      *catalog.Catalog   // what Diff compares against instead of the catalog on disk
+	changeFilter  ChangeFilter       // narrows changesets during Sync
+	statusClient  *httpclient.Client // fetches provider status pages
+
+	historyOnce sync.Once
+	history     []RunRecord // the run history, read for model count baselines
+
+	judgeSpendOnce sync.Once
+	judgeSpend     *judge.Budget // the run's judge spend, capped by judge.max_spend_usd
+}
+
+// Option configures a Pipeline.
+type Option func(*Pipeline)
+
+// WithJudgeCache reuses judge verdicts for changesets seen before.
+func WithJudgeCache(c cache.Cache) Option {
+	return func(p *Pipeline) { p.judgeCache = c }
+}
+
+// WithBaseline makes Diff compare discovery against cat, a past state of
+// the catalog, instead of the catalog on disk.
+func WithBaseline(cat *catalog.Catalog) Option {
+	return func(p *Pipeline) { p.baseline = cat }
 }
 
 // New creates a new Pipeline.
-func New(cfg *config.Config) *Pipeline {
-	return &Pipeline{cfg: cfg}
+func New(cfg *config.Config, opts ...Option) *Pipeline {
+	p := &Pipeline{
+		cfg:          cfg,
+		statusClient: httpclient.New(httpclient.WithNoCache(), httpclient.WithMaxRetries(1)),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// ensureCatalog clones the catalog repository to catalog_path when
+// github.auto_clone is set and there is no checkout there yet, so a run can
+// start from an empty container.
+func (p *Pipeline) ensureCatalog(ctx context.Context) error {
+	gh := p.cfg.GitHub
+	if !gh.AutoClone {
+		return nil
+	}
+	missing, err := needsClone(p.cfg.CatalogPath)
+	if err != nil || !missing {
+		return err
+	}
+	url := gh.RepoURL()
+	if url == "" {
+		return fmt.Errorf("github.auto_clone needs github.clone_url, or github.owner and github.repo")
+	}
+	slog.Info("cloning catalog", "url", url, "branch", gh.BaseBranch, "path", p.cfg.CatalogPath)
+	_, err = CloneRepo(ctx, url, p.cfg.CatalogPath, gh.BaseBranch, gh.Token)
+	return err
 }
 
 // LoadCatalog loads the existing catalog from disk.
 func (p *Pipeline) LoadCatalog() error {
-	cat, err := catalog.Load(p.cfg.CatalogPath)
+	return p.loadCatalog()
+}
+
+// loadRunCatalog loads only the providers a run touches: the configured
+// ones and, with the judge enabled, the judge's, whose entry prices its
+// calls. Nothing a run writes covers every provider; the manifest is
+// regenerated from disk.
+func (p *Pipeline) loadRunCatalog() error {
+	providers := slices.Clone(p.cfg.Providers)
+	if p.cfg.Judge.Enabled {
+		providers = append(providers, p.cfg.Judge.Provider)
+	}
+	return p.loadCatalog(catalog.WithProviders(providers...))
+}
+
+func (p *Pipeline) loadCatalog(opts ...catalog.LoadOption) error {
+	cat, err := catalog.Load(p.cfg.CatalogPath, opts...)
 	if err != nil {
 		return fmt.Errorf("loading catalog: %w", err)
 	}
@@ -49,44 +161,203 @@ func (p *Pipeline) LoadCatalog() error {
 	return nil
 }
 
+// Catalog returns the catalog loaded by LoadCatalog, Diff or Sync, or nil
+// before one is loaded.
+func (p *Pipeline) Catalog() *catalog.Catalog {
+	return p.catalog
+}
+
 // SyncResult holds the outcome of a sync for one provider.
 type SyncResult struct {
 	Provider    string
+	Catalog     string // the catalogs entry synced, when catalogs are configured
 	ChangeSet   *diff.ChangeSet
 	JudgeResult *judge.Result
 	PRNumber    int
+	PRNumbers   []int // every PR opened, in merge order, when the changeset was split
 	PRDraft     bool
 	Skipped     bool
 	SkipReason  string
+	Blocked     bool              // skipped because a risk gate blocked the changeset
+	Entitlement *EntitlementError // skipped because the account can't use the provider
+	Incident    *Incident         // skipped because the provider's status page declares an incident
+	TimedOut    bool              // failed because discovery ran past discovery_timeout
 	Error       error
+	Duration    time.Duration // wall time spent on the provider
+	Discovered  int           // models discovery returned, before filters; 0 when it didn't run
 }
 
 // Sync runs the full pipeline for the configured providers.
 func (p *Pipeline) Sync(ctx context.Context) ([]SyncResult, error) {
-	if err := p.LoadCatalog(); err != nil {
+	if err := p.ensureCatalog(ctx); err != nil {
+		return nil, err
+	}
+	if err := p.prepareWrite(); err != nil {
+		return nil, err
+	}
+	if err := p.loadRunCatalog(); err != nil {
 		return nil, err
 	}
+	defer p.closeDatabase()
 
 	var results []SyncResult
+	started := time.Now()
+	failed := false
 
 	for _, providerName := range p.cfg.Providers {
+		if result, ok := notStarted(ctx, providerName); ok {
+			results = append(results, result)
+			continue
+		}
+		if failed && p.cfg.FailFast {
+			results = append(results, stoppedEarly(providerName))
+			continue
+		}
+		begin := time.Now()
 		result := p.syncProvider(ctx, providerName)
+		result.Duration = time.Since(begin)
 		results = append(results, result)
+		failed = failed || result.Error != nil
 	}
+	p.openAggregatePR(ctx, results)
+	p.recordRuns(results)
+	writeReport(p.cfg, results, started)
 
 	return results, nil
 }
 
+// SyncCatalogs runs Sync for each configured catalog in turn, with that
+// catalog's path, providers and GitHub settings, and writes one run report
+// covering them all. Without catalogs it is New(cfg, opts...).Sync.
+func SyncCatalogs(ctx context.Context, cfg *config.Config, opts ...Option) ([]SyncResult, error) {
+	if len(cfg.Catalogs) == 0 {
+		return New(cfg, opts...).Sync(ctx)
+	}
+
+	var results []SyncResult
+	started := time.Now()
+
+	failed := false
+	for _, cc := range cfg.ForCatalogs() {
+		if failed && cfg.FailFast {
+			for _, provider := range cc.Providers {
+				r := stoppedEarly(provider)
+				r.Catalog = cc.CatalogName
+				results = append(results, r)
+			}
+			continue
+		}
+		cc.ReportPath = ""
+		slog.Info("syncing catalog", "catalog", cc.CatalogName, "path", cc.CatalogPath, "providers", len(cc.Providers))
+		catResults, err := New(cc, opts...).Sync(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("catalog %s: %w", cc.CatalogName, err)
+		}
+		for i := range catResults {
+			catResults[i].Catalog = cc.CatalogName
+			failed = failed || catResults[i].Error != nil
+		}
+		results = append(results, catResults...)
+	}
+	writeReport(cfg, results, started)
+
+	return results, nil
+}
+
+// format is the format of the catalog's model files. prepareWrite rejects
+// an unknown one; until then it falls back to YAML.
+func (p *Pipeline) format() catalog.Format {
+	f, err := p.cfg.CatalogFormat()
+	if err != nil {
+		f, _ = catalog.FormatByName(catalog.FormatYAML)
+	}
+	return f
+}
+
+// prepareWrite checks the settings that only matter when changes are
+// written, before any discovery runs.
+func (p *Pipeline) prepareWrite() error {
+	if err := p.cfg.YAML.Style().Validate(); err != nil {
+		return err
+	}
+	if _, err := p.cfg.CatalogFormat(); err != nil {
+		return err
+	}
+	rules, err := p.cfg.Validation.Ruleset(p.cfg.CatalogPath)
+	if err != nil {
+		return err
+	}
+	p.rules = rules
+	if p.ghLimits, err = githubLimits(p.cfg.GitHub); err != nil {
+		return err
+	}
+	sign := p.cfg.GitHub.Signing
+	if p.signer, err = LoadSigner(sign.Format, sign.Key, sign.Passphrase); err != nil {
+		return err
+	}
+	for _, kind := range p.cfg.Versioning.MajorOn {
+		if !slices.Contains(diff.BreakingKinds(), kind) {
+			return fmt.Errorf("unknown versioning.major_on %q (want one of %s)", kind, strings.Join(diff.BreakingKinds(), ", "))
+		}
+	}
+	if err := p.changeFilter.validate(); err != nil {
+		return err
+	}
+	switch p.cfg.Diff.Renames {
+	case "", RenamesReport, RenamesAuto, RenamesConfirm:
+	default:
+		return fmt.Errorf("unknown diff.renames %q (want %q, %q or %q)", p.cfg.Diff.Renames, RenamesReport, RenamesAuto, RenamesConfirm)
+	}
+	switch p.cfg.Health.OnIncident {
+	case "", OnIncidentAnnotate, OnIncidentSkip:
+	default:
+		return fmt.Errorf("unknown health.on_incident %q (want %q or %q)", p.cfg.Health.OnIncident, OnIncidentAnnotate, OnIncidentSkip)
+	}
+	switch p.cfg.Health.OnAnomaly {
+	case "", OnAnomalyWarn, OnAnomalyBlock:
+	default:
+		return fmt.Errorf("unknown health.on_anomaly %q (want %q or %q)", p.cfg.Health.OnAnomaly, OnAnomalyWarn, OnAnomalyBlock)
+	}
+	switch p.cfg.GitHub.SplitBy {
+	case "", SplitByFamily, SplitByChunk:
+	default:
+		return fmt.Errorf("unknown github.split_by %q (want %q or %q)", p.cfg.GitHub.SplitBy, SplitByFamily, SplitByChunk)
+	}
+	p.risk, err = NewRiskPolicy(p.cfg)
+	return err
+}
+
 // Diff runs discovery and diff without writing changes.
 func (p *Pipeline) Diff(ctx context.Context) ([]diff.ChangeSet, error) {
-	if err := p.LoadCatalog(); err != nil {
-		return nil, err
+	if p.baseline != nil {
+		p.catalog = p.baseline
+		slog.Info("diffing against baseline", "version", p.baseline.Version, "providers", len(p.baseline.Providers))
+	} else {
+		if err := p.ensureCatalog(ctx); err != nil {
+			return nil, err
+		}
+		if err := p.loadRunCatalog(); err != nil {
+			return nil, err
+		}
 	}
 
 	var changesets []diff.ChangeSet
 
 	for _, providerName := range p.cfg.Providers {
-		cs, err := p.discoverAndDiff(ctx, providerName)
+		if ctx.Err() != nil {
+			slog.Error("diff not started", "provider", providerName, "error", context.Cause(ctx))
+			continue
+		}
+		cs, _, err := p.discoverAndDiff(ctx, providerName)
+		if ee, ok := asEntitlementError(providerName, err); ok {
+			slog.Warn("provider skipped", "provider", providerName, "reason", ee.Error(), "hint", ee.Hint())
+			continue
+		}
+		var ie *IncidentError
+		if errors.As(err, &ie) {
+			slog.Warn("provider skipped", "provider", providerName, "reason", ie.Error())
+			continue
+		}
 		if err != nil {
 			slog.Error("diff failed", "provider", providerName, "error", err)
 			continue
@@ -101,12 +372,45 @@ func (p *Pipeline) syncProvider(ctx context.Context, providerName string) SyncRe
 	result := SyncResult{Provider: providerName}
 
 	// 1. Discover + diff
-	cs, err := p.discoverAndDiff(ctx, providerName)
+	cs, health, err := p.discoverAndDiff(ctx, providerName)
+	if ee, ok := asEntitlementError(providerName, err); ok {
+		result.Skipped = true
+		result.Entitlement = ee
+		result.SkipReason = ee.Error()
+		return result
+	}
+	var ie *IncidentError
+	if errors.As(err, &ie) {
+		result.Skipped = true
+		result.Incident = ie.Incident
+		result.SkipReason = ie.Error()
+		return result
+	}
 	if err != nil {
+		var he *SourceHealthError
+		if errors.As(err, &he) {
+			result.Discovered = he.Discovered
+		}
+		var te *DiscoveryTimeoutError
+		result.TimedOut = errors.As(err, &te)
 		result.Error = err
 		return result
 	}
-	result.ChangeSet = cs
+	result.Discovered = health.Discovered
+	if err := p.filterChangeSet(cs); err != nil {
+		result.Error = fmt.Errorf("filtering changeset: %w", err)
+		return result
+	}
+	result = p.applyChangeSet(ctx, cs, health, true)
+	result.Discovered = health.Discovered
+	return result
+}
+
+// applyChangeSet runs everything after discovery: risk gates, validation,
+// the judge (when runJudge is set), then writing, versioning and the PR.
+func (p *Pipeline) applyChangeSet(ctx context.Context, cs *diff.ChangeSet, health *render.SourceHealth, runJudge bool) SyncResult {
+	providerName := cs.Provider
+	result := SyncResult{Provider: providerName, ChangeSet: cs}
 
 	if !cs.HasChanges() {
 		slog.Info("no changes detected", "provider", providerName)
@@ -115,25 +419,46 @@ func (p *Pipeline) syncProvider(ctx context.Context, providerName string) SyncRe
 		return result
 	}
 
+	// With risk.family_removed off the models are ordinary deprecation
+	// candidates: no alert and no caution block in the PR.
+	if p.risk.FamilyRemoved == FamilyRemovedOff {
+		cs.RemovedFamilies = nil
+	}
+
+	// Family removals are raised on their own channel whatever becomes of
+	// the changeset.
+	if len(cs.RemovedFamilies) > 0 {
+		defer func() { p.alertRemovedFamilies(ctx, cs, health, result.PRNumber) }()
+	}
+
 	// 2. Risk assessment
-	draft, blocked, reason := assessRisk(cs)
+	draft, blocked, reason := assessRisk(cs, p.risk)
 	if blocked {
 		result.Skipped = true
+		result.Blocked = true
 		result.SkipReason = reason
 		slog.Warn("sync blocked by policy", "provider", providerName, "reason", reason)
 		return result
 	}
 	result.PRDraft = draft
+	if draft {
+		slog.Info("changeset will open as a draft PR", "provider", providerName, "reason", reason)
+	}
 
 	// 3. Validate new/updated models
 	valResult := p.validateChanges(cs)
 	if valResult.HasErrors() {
-		result.Error = fmt.Errorf("validation failed:\n%s", validate.FormatResult(valResult))
+		result.Error = &ValidationError{Provider: providerName, Result: valResult}
 		return result
 	}
 
-	// 4. LLM Judge (non-fatal)
-	judgeResult, err := p.runJudge(ctx, cs)
+	// 4. LLM Judge (non-fatal). Saved changesets skip it: they were reviewed
+	// already, and the judge excluding models would change what gets applied.
+	var judgeResult *judge.Result
+	var err error
+	if runJudge {
+		judgeResult, err = p.runJudge(ctx, cs)
+	}
 	if err != nil {
 		slog.Warn("judge evaluation failed, continuing", "provider", providerName, "error", err)
 	} else if judgeResult != nil {
@@ -155,58 +480,231 @@ func (p *Pipeline) syncProvider(ctx context.Context, providerName string) SyncRe
 		return result
 	}
 
+	// With aggregate_prs, the provider becomes one commit on the run's
+	// shared branch; Sync opens a single PR once every provider is done.
+	if p.cfg.GitHub.AggregatePRs && p.cfg.GitHub.Token != "" {
+		in, journal, err := p.writeChangeSet(ctx, cs)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+		in.Judge = result.JudgeResult
+		in.Health = health
+		if err := p.commitAggregate(ctx, in, result.PRDraft); err != nil {
+			p.rollBack(providerName, journal)
+			result.Error = fmt.Errorf("committing to aggregate branch: %w", err)
+		}
+		return result
+	}
+
+	// 4–9. Write, version, mirror and open the PR, once per part when the
+	// changeset is too large for a single PR.
+	parts := splitChangeSet(cs, p.cfg.GitHub.MaxPRModels, p.cfg.GitHub.SplitBy)
+	if len(parts) > 1 {
+		slog.Info("splitting changeset into multiple PRs", "provider", providerName,
+			"models", cs.TotalChanged(), "parts", len(parts), "by", p.cfg.GitHub.SplitBy)
+	}
+	var opened []*render.Input
+	for i, part := range parts {
+		in, journal, err := p.writeChangeSet(ctx, part)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+		in.Judge = result.JudgeResult
+		if i == 0 {
+			in.Health = health
+		}
+		if len(parts) > 1 {
+			in.Judge = judgeFor(result.JudgeResult, part)
+			in.Split = &render.Split{Part: i + 1, Total: len(parts), PRs: append([]int(nil), result.PRNumbers...)}
+		}
+
+		// Git + PR (if GitHub is configured)
+		if p.cfg.GitHub.Token == "" {
+			continue
+		}
+		base := p.cfg.GitHub.BaseBranch
+		if i > 0 {
+			base = opened[i-1].Branch
+		}
+		prNum, err := p.createPR(ctx, providerName, in, result.PRDraft, base)
+		if err != nil {
+			// Left in the worktree, the files would be committed into the
+			// next provider's PR.
+			p.rollBack(providerName, journal)
+			result.Error = fmt.Errorf("creating PR: %w", err)
+			return result
+		}
+		if result.PRNumber == 0 {
+			result.PRNumber = prNum
+		}
+		result.PRNumbers = append(result.PRNumbers, prNum)
+		opened = append(opened, in)
+	}
+
+	if len(opened) > 1 {
+		p.linkSplitPRs(ctx, opened, result.PRNumbers)
+	}
+
+	return result
+}
+
+// writeChangeSet writes a changeset's models to the catalog, then updates
+// their metadata, bumps the version, regenerates the manifest and mirrors
+// the result into the database. It returns the PR body input for what was
+// written, and the journal of every file it touched for rolling back should
+// committing them fail. If any step of the write fails, they are restored
+// already.
+func (p *Pipeline) writeChangeSet(ctx context.Context, cs *diff.ChangeSet) (*render.Input, *catalog.Journal, error) {
+	journal := catalog.NewJournal()
+	in, err := p.writeJournaled(ctx, cs, journal)
+	if err != nil {
+		p.rollBack(cs.Provider, journal)
+		return nil, nil, err
+	}
+	return in, journal, nil
+}
+
+// rollBack restores the files a failed write or PR touched.
+func (p *Pipeline) rollBack(provider string, journal *catalog.Journal) {
+	files := len(journal.Files())
+	if err := journal.Rollback(); err != nil {
+		slog.Error("restoring catalog after a failed sync", "provider", provider, "error", err)
+		return
+	}
+	slog.Warn("catalog changes rolled back", "provider", provider, "files", files)
+}
+
+func (p *Pipeline) writeJournaled(ctx context.Context, cs *diff.ChangeSet, journal *catalog.Journal) (*render.Input, error) {
+	providerName := cs.Provider
+
 	// 4. Write changes
-	writer := catalog.NewWriter(p.cfg.CatalogPath)
+	writer := catalog.NewWriter(p.cfg.CatalogPath,
+		catalog.WithStyle(p.cfg.YAML.Style()),
+		catalog.WithFormat(p.format()),
+		catalog.WithFsync(p.cfg.YAML.Fsync),
+		catalog.WithJournal(journal))
+	if err := p.writeProvider(writer, providerName); err != nil {
+		return nil, fmt.Errorf("writing provider.yaml: %w", err)
+	}
 	for _, m := range cs.New {
 		if _, err := writer.WriteModel(providerName, m.Model); err != nil {
-			result.Error = fmt.Errorf("writing new model %s: %w", m.Name, err)
-			return result
+			return nil, fmt.Errorf("writing new model %s: %w", m.Name, err)
 		}
 	}
 	for _, u := range cs.Updated {
 		if _, err := writer.WriteModel(providerName, u.Model); err != nil {
-			result.Error = fmt.Errorf("writing updated model %s: %w", u.Name, err)
-			return result
+			return nil, fmt.Errorf("writing updated model %s: %w", u.Name, err)
+		}
+	}
+	for _, r := range cs.Renamed {
+		if _, err := writer.RenameModel(providerName, r.OldName, r.Model); err != nil {
+			return nil, fmt.Errorf("renaming model %s to %s: %w", r.OldName, r.Name, err)
 		}
 	}
 
 	// 5. Update x_updater metadata
-	p.updateMetadata(providerName, cs)
+	if err := p.updateMetadata(writer, providerName, cs); err != nil {
+		return nil, fmt.Errorf("updating metadata: %w", err)
+	}
 
 	// 6. Bump version
-	if err := p.bumpVersion(cs); err != nil {
-		result.Error = fmt.Errorf("bumping version: %w", err)
-		return result
+	fromVersion, toVersion, err := p.bumpVersion(cs, journal)
+	if err != nil {
+		return nil, fmt.Errorf("bumping version: %w", err)
+	}
+	if err := p.updateChangelog(cs, toVersion, journal); err != nil {
+		return nil, fmt.Errorf("updating changelog: %w", err)
 	}
 
 	// 7. Regenerate manifest
+	if err := journal.Track(filepath.Join(p.cfg.CatalogPath, "manifest.yaml")); err != nil {
+		return nil, err
+	}
 	if err := catalog.GenerateManifest(p.cfg.CatalogPath); err != nil {
-		result.Error = fmt.Errorf("generating manifest: %w", err)
-		return result
+		return nil, fmt.Errorf("generating manifest: %w", err)
 	}
 
-	// 9. Git + PR (if GitHub is configured)
-	if p.cfg.GitHub.Token != "" {
-		prNum, err := p.createPR(ctx, providerName, cs, result.PRDraft, result.JudgeResult)
-		if err != nil {
-			result.Error = fmt.Errorf("creating PR: %w", err)
-			return result
+	// Reload the merged catalog: it backs the database mirror and the PR's
+	// capability impact section.
+	merged, err := catalog.Load(p.cfg.CatalogPath)
+	if err != nil {
+		return nil, fmt.Errorf("reloading catalog: %w", err)
+	}
+
+	// 8. Regenerate indexes from the same catalog as the manifest
+	if p.cfg.Indexes != "" {
+		if err := writer.WriteIndexes(merged, p.cfg.Indexes); err != nil {
+			return nil, fmt.Errorf("generating indexes: %w", err)
 		}
-		result.PRNumber = prNum
 	}
 
-	return result
+	// 9. Mirror into the database (dual-write)
+	if p.cfg.Database.Enabled {
+		if err := p.syncDatabase(ctx, providerName, merged); err != nil {
+			return nil, fmt.Errorf("syncing database: %w", err)
+		}
+	}
+
+	return &render.Input{
+		ChangeSet:    cs,
+		FromVersion:  fromVersion,
+		ToVersion:    toVersion,
+		Capabilities: catalog.BuildCapabilityIndex(merged),
+		ModelExt:     p.format().Ext(),
+	}, nil
 }
 
-func (p *Pipeline) discoverAndDiff(ctx context.Context, providerName string) (*diff.ChangeSet, error) {
+// writeProvider creates the provider's provider.yaml when it is missing and
+// fills in fields it leaves empty from the adapter's defaults. Fields set in
+// the catalog are never overwritten.
+func (p *Pipeline) writeProvider(w *catalog.SmartMergeWriter, providerName string) error {
 	a, err := adapter.Get(providerName)
 	if err != nil {
-		return nil, err
+		return nil // no adapter to take defaults from
+	}
+	defaults := &catalog.Provider{
+		Name:                   providerName,
+		DisplayName:            providerName,
+		ProviderType:           "llm",
+		SupportsModelDiscovery: slices.Contains(a.SupportedSources(), adapter.SourceAPI),
+	}
+	if d, ok := a.(adapter.Describer); ok {
+		info := d.ProviderInfo()
+		if info.DisplayName != "" {
+			defaults.DisplayName = info.DisplayName
+		}
+		defaults.BaseURL, defaults.AuthType, defaults.StatusPage = info.BaseURL, info.AuthType, info.StatusPage
+	}
+	written, err := w.WriteProvider(defaults)
+	if written {
+		slog.Info("provider.yaml updated", "provider", providerName)
+	}
+	return err
+}
+
+// discoverAndDiff runs discovery for a provider and diffs the result against
+// the loaded catalog. The returned SourceHealth summarizes the discovery run.
+func (p *Pipeline) discoverAndDiff(ctx context.Context, providerName string) (*diff.ChangeSet, *render.SourceHealth, error) {
+	a, err := adapter.Get(providerName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A declared incident explains a short or failing discovery, so the
+	// status page comes first.
+	incident := p.providerIncident(ctx, a)
+	if incident != nil {
+		if p.cfg.Health.OnIncident == OnIncidentSkip {
+			return nil, nil, &IncidentError{Provider: providerName, Incident: incident}
+		}
+		slog.Warn("provider reports an incident, syncing anyway", "provider", providerName, "incident", incident.String())
 	}
 
 	// Pre-discovery health check.
 	if err := p.checkSourceHealth(ctx, a, providerName); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	sources := make([]adapter.SourceType, 0, len(p.cfg.Sources))
@@ -214,92 +712,225 @@ func (p *Pipeline) discoverAndDiff(ctx context.Context, providerName string) (*d
 		sources = append(sources, adapter.SourceType(s))
 	}
 
-	discovered, err := a.Discover(ctx, adapter.DiscoverOptions{
+	filter, err := newModelFilter(p.cfg.Filters[providerName], p.cfg.ModelTypes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("filters for %s: %w", providerName, err)
+	}
+
+	ctx, warnings := adapter.WithWarnings(ctx)
+	discovered, err := p.discover(ctx, a, adapter.DiscoverOptions{
 		Sources:  sources,
 		NoCache:  p.cfg.NoCache,
 		CacheDir: p.cfg.CacheDir,
 	})
+	var te *DiscoveryTimeoutError
+	if errors.As(err, &te) {
+		return nil, nil, te
+	}
 	if err != nil {
-		return nil, fmt.Errorf("discovering models: %w", err)
+		return nil, nil, fmt.Errorf("discovering models: %w", err)
 	}
 
 	discovered = deduplicateDiscovered(discovered)
 	slog.Info("discovery complete", "provider", providerName, "models", len(discovered))
 
-	// Post-discovery model count threshold check.
+	// Post-discovery model count threshold check. During an incident a
+	// short count is expected and only noted.
 	if err := p.checkModelCountThreshold(a, discovered, providerName); err != nil {
-		return nil, err
+		if incident == nil {
+			return nil, nil, err
+		}
+		adapter.Warn(ctx, "", fmt.Sprintf("%s, during a %s", err.(*SourceHealthError).Reason, incident))
+	}
+	// And against the provider's usual count, from the run history.
+	if anomaly := p.modelCountAnomaly(providerName, len(discovered)); anomaly != "" {
+		if p.cfg.Health.OnAnomaly == OnAnomalyBlock && incident == nil {
+			return nil, nil, &SourceHealthError{Provider: providerName, Reason: anomaly, Discovered: len(discovered)}
+		}
+		adapter.Warn(ctx, "", anomaly)
+	}
+
+	health := &render.SourceHealth{
+		Sources:    p.cfg.Sources,
+		Discovered: len(discovered),
+	}
+	if hc, ok := a.(adapter.HealthChecker); ok && p.cfg.Health.Enabled {
+		health.Checked = true
+		health.MinExpected = hc.MinExpectedModels()
+		health.Threshold = p.cfg.Health.Threshold
+	}
+	if incident != nil {
+		health.Incident = incident.String()
+	}
+
+	// Filters apply after the health checks, which judge the source as a
+	// whole rather than the subset the catalog keeps.
+	if filter != nil {
+		discovered = filter.filterDiscovered(discovered)
+		slog.Info("models filtered", "provider", providerName, "kept", len(discovered), "of", health.Discovered)
 	}
 
 	// Get existing models for this provider
 	existing := make(map[string]*catalog.Model)
 	if pc, ok := p.catalog.Providers[providerName]; ok {
-		existing = pc.Models
+		existing = filter.filterExisting(pc.Models)
+	}
+	if fl, ok := a.(adapter.FineTunedLister); !ok || !fl.ListsFineTuned() {
+		existing = withoutFineTuned(existing)
+	}
+
+	for _, m := range discovered {
+		if m.Limits.MaxTokens == 0 && catalog.TokenLimited(m.ModelType) {
+			adapter.Warn(ctx, m.Name, "no context length reported")
+		}
 	}
 
-	opts := diff.DiffOptions{
+	cs := diff.Compute(providerName, discovered, existing, p.DiffOptions())
+	cs.Warnings = warnings()
+	p.applyRenames(cs)
+	return cs, health, nil
+}
+
+// DiffOptions are the diff settings changesets are computed with.
+func (p *Pipeline) DiffOptions() diff.DiffOptions {
+	return diff.DiffOptions{
 		TrackDisplayName: p.cfg.Diff.TrackDisplayName,
+		ManageReadiness:  p.cfg.Readiness.Enabled,
 	}
-	cs := diff.Compute(providerName, discovered, existing, opts)
-	return cs, nil
 }
 
 func (p *Pipeline) validateChanges(cs *diff.ChangeSet) *validate.Result {
 	result := &validate.Result{}
 
 	for _, m := range cs.New {
-		filename := m.Name + ".yaml"
-		r := validate.ValidateModel(m.Model, filename)
+		filename := m.Name + p.format().Ext()
+		r := p.rules.ValidateModel(cs.Provider, m.Model, filename)
 		result.Issues = append(result.Issues, r.Issues...)
 	}
 	for _, u := range cs.Updated {
-		filename := u.Name + ".yaml"
-		r := validate.ValidateModel(u.Model, filename)
+		filename := u.Name + p.format().Ext()
+		r := p.rules.ValidateModel(cs.Provider, u.Model, filename)
+		result.Issues = append(result.Issues, r.Issues...)
+	}
+	for _, rn := range cs.Renamed {
+		r := p.rules.ValidateModel(cs.Provider, rn.Model, rn.Name+p.format().Ext())
 		result.Issues = append(result.Issues, r.Issues...)
 	}
 
 	return result
 }
 
-func (p *Pipeline) updateMetadata(provider string, cs *diff.ChangeSet) {
+// updateMetadata records in the x_updater block of each written model when
+// it was verified and where the fields written came from. Fields the
+// changeset didn't touch keep the provenance the catalog had for them.
+func (p *Pipeline) updateMetadata(writer *catalog.SmartMergeWriter, provider string, cs *diff.ChangeSet) error {
 	now := time.Now().UTC().Format(time.RFC3339)
-	writer := catalog.NewWriter(p.cfg.CatalogPath)
 
-	allModels := make([]*catalog.Model, 0)
+	var existing map[string]*catalog.Model
+	if p.catalog != nil {
+		if pc, ok := p.catalog.Providers[provider]; ok {
+			existing = pc.Models
+		}
+	}
+	type written struct {
+		model   *catalog.Model
+		prev    *catalog.Model // the catalog model it replaces, if any
+		changed []string       // top-level fields written
+	}
+	var all []written
 	for _, m := range cs.New {
-		allModels = append(allModels, m.Model)
+		all = append(all, written{m.Model, nil, m.Model.PopulatedFields()})
 	}
 	for _, u := range cs.Updated {
-		allModels = append(allModels, u.Model)
+		var changed []string
+		for _, c := range u.Changes {
+			changed = append(changed, diff.TopField(c.Field))
+		}
+		all = append(all, written{u.Model, existing[u.Name], changed})
+	}
+	for _, r := range cs.Renamed {
+		all = append(all, written{r.Model, existing[r.OldName], r.Model.PopulatedFields()})
 	}
 
-	for _, m := range allModels {
-		m.XUpdater = &catalog.XUpdater{
-			LastVerifiedAt: now,
-			Sources:        p.cfg.Sources,
+	for _, w := range all {
+		fields := make(map[string]catalog.FieldSource)
+		if w.prev != nil {
+			maps.Copy(fields, w.prev.FieldSources())
+		}
+		sources := w.model.FieldSources()
+		for _, f := range w.changed {
+			if fs, ok := sources[f]; ok {
+				fields[f] = catalog.FieldSource{Source: fs.Source, UpdatedAt: now}
+			}
+		}
+		u := &catalog.XUpdater{LastVerifiedAt: now, Sources: p.cfg.Sources}
+		if len(fields) > 0 {
+			u.Fields = fields
+		}
+		w.model.XUpdater = u
+		if err := writer.SetUpdater(provider, w.model.Name, u); err != nil {
+			return fmt.Errorf("%s: %w", w.model.Name, err)
 		}
-		_, _ = writer.WriteModel(provider, m)
 	}
+	return nil
 }
 
-func (p *Pipeline) bumpVersion(cs *diff.ChangeSet) error {
+// bumpVersion writes the next catalog version to version.txt and returns
+// the previous and new versions.
+func (p *Pipeline) bumpVersion(cs *diff.ChangeSet, journal *catalog.Journal) (string, string, error) {
 	versionPath := filepath.Join(p.cfg.CatalogPath, "version.txt")
 	data, err := os.ReadFile(versionPath)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	version := strings.TrimSpace(string(data))
-	newVersion, err := bumpSemver(version, len(cs.New) > 0)
+	newVersion, err := bumpSemver(version, p.versionBump(cs))
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
-	return os.WriteFile(versionPath, []byte(newVersion+"\n"), 0o644)
+	if err := journal.Track(versionPath); err != nil {
+		return "", "", err
+	}
+	if err := fsutil.WriteFileAtomic(versionPath, []byte(newVersion+"\n"), 0o644, p.cfg.YAML.Fsync); err != nil {
+		return "", "", err
+	}
+	return version, newVersion, nil
 }
 
-// bumpSemver increments MINOR for new models, PATCH for updates only.
-func bumpSemver(version string, hasNew bool) (string, error) {
+// Semver components a release bumps.
+type bumpLevel int
+
+const (
+	bumpPatch bumpLevel = iota
+	bumpMinor
+	bumpMajor
+)
+
+// versionBump decides how far a changeset moves the catalog version:
+// MAJOR for a breaking change the versioning policy lists, MINOR for new
+// or renamed models and PATCH for updates only.
+func (p *Pipeline) versionBump(cs *diff.ChangeSet) bumpLevel {
+	var breaking []string
+	for _, b := range diff.Breaking(cs) {
+		if slices.Contains(p.cfg.Versioning.MajorOn, b.Kind) {
+			breaking = append(breaking, b.String())
+		}
+	}
+	switch {
+	case len(breaking) > 0:
+		slog.Warn("breaking changes, bumping major version", "provider", cs.Provider, "changes", breaking)
+		return bumpMajor
+	case len(cs.New) > 0 || len(cs.Renamed) > 0:
+		return bumpMinor
+	}
+	return bumpPatch
+}
+
+// bumpSemver increments the given component of version, resetting the
+// ones below it.
+func bumpSemver(version string, level bumpLevel) (string, error) {
 	parts := strings.Split(version, ".")
 	if len(parts) != 3 {
 		return "", fmt.Errorf("invalid semver: %s", version)
@@ -310,16 +941,27 @@ func bumpSemver(version string, hasNew bool) (string, error) {
 	_, _ = fmt.Sscanf(parts[1], "%d", &minor)
 	_, _ = fmt.Sscanf(parts[2], "%d", &patch)
 
-	if hasNew {
+	switch level {
+	case bumpMajor:
+		major++
+		minor, patch = 0, 0
+	case bumpMinor:
 		minor++
 		patch = 0
-	} else {
+	default:
 		patch++
 	}
 
 	return fmt.Sprintf("%d.%d.%d", major, minor, patch), nil
 }
 
+// Judge evaluates a changeset with the configured judge without acting on
+// the verdicts, for a person to weigh. Returns (nil, nil) when the judge
+// is disabled.
+func (p *Pipeline) Judge(ctx context.Context, cs *diff.ChangeSet) (*judge.Result, error) {
+	return p.runJudge(ctx, cs)
+}
+
 // runJudge creates an LLM client and evaluates the changeset.
 // Returns (nil, nil) when the judge is disabled.
 func (p *Pipeline) runJudge(ctx context.Context, cs *diff.ChangeSet) (*judge.Result, error) {
@@ -327,41 +969,108 @@ func (p *Pipeline) runJudge(ctx context.Context, cs *diff.ChangeSet) (*judge.Res
 		return nil, nil
 	}
 
+	client, err := NewJudgeClient(p.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rubric, err := p.cfg.Judge.Rubric()
+	if err != nil {
+		return nil, err
+	}
+	opts := []judge.Option{
+		judge.WithRubric(rubric),
+		judge.WithPricing(p.judgePricing()),
+		judge.WithBudget(p.judgeBudget()),
+	}
+	if p.cfg.Judge.Screening {
+		opts = append(opts, judge.WithScreening())
+	}
+	if p.judgeCache != nil {
+		opts = append(opts, judge.WithCache(p.judgeCache))
+	}
+	if p.cfg.Judge.AuditLog != "" {
+		opts = append(opts, judge.WithAuditLog(judge.NewAuditLog(p.cfg.Judge.AuditLog)))
+	}
+	j := judge.New(client, p.cfg.Judge.Model, false, opts...)
+	return j.Evaluate(ctx, cs)
+}
+
+// judgeBudget is the spend of every judge call in the run.
+func (p *Pipeline) judgeBudget() *judge.Budget {
+	p.judgeSpendOnce.Do(func() { p.judgeSpend = judge.NewBudget(p.cfg.Judge.MaxSpendUSD) })
+	return p.judgeSpend
+}
+
+// judgePricing is the judge model's token pricing from judge.input_price_per_1m
+// and judge.output_price_per_1m, or else from its entry in the catalog. It
+// is zero when neither knows it, which leaves the spend cap unenforceable.
+func (p *Pipeline) judgePricing() judge.Pricing {
+	jc := p.cfg.Judge
+	if jc.InputPricePer1M != 0 || jc.OutputPricePer1M != 0 {
+		return judge.Pricing{InputPer1M: jc.InputPricePer1M, OutputPer1M: jc.OutputPricePer1M}
+	}
+	var cost *catalog.Cost
+	if p.catalog != nil {
+		if pc := p.catalog.Providers[jc.Provider]; pc != nil {
+			if m := pc.Models[jc.Model]; m != nil {
+				cost = m.Cost.Normalize()
+			}
+		}
+	}
+	if cost == nil || cost.CurrencyOrDefault() != catalog.DefaultCurrency {
+		if jc.MaxSpendUSD > 0 {
+			slog.Warn("judge model pricing unknown, judge.max_spend_usd can't be enforced; set judge.input_price_per_1m and judge.output_price_per_1m",
+				"provider", jc.Provider, "model", jc.Model)
+		}
+		return judge.Pricing{}
+	}
+	return judge.Pricing{InputPer1M: cost.InputPer1K * 1000, OutputPer1M: cost.OutputPer1K * 1000}
+}
+
+// NewJudgeClient returns the LLM client for the configured judge provider
+// and model.
+func NewJudgeClient(cfg *config.Config) (judge.LLMClient, error) {
 	var client judge.LLMClient
 
-	switch p.cfg.Judge.Provider {
+	access, _ := cfg.Access(cfg.Judge.Provider)
+	switch cfg.Judge.Provider {
 	case "anthropic":
-		apiKey := p.cfg.Anthropic.APIKey
+		apiKey := access.APIKey
 		if apiKey == "" {
 			return nil, fmt.Errorf("anthropic API key required when judge.provider=anthropic")
 		}
-		client = judge.NewAnthropicClient(
+		c := judge.NewAnthropicClient(
 			apiKey,
-			p.cfg.Anthropic.BaseURL,
-			p.cfg.Judge.Model,
-			p.cfg.Judge.MaxTokens,
+			access.BaseURL,
+			cfg.Judge.Model,
+			cfg.Judge.MaxTokens,
 		)
+		c.SetStructured(cfg.Judge.StructuredOutput)
+		client = c
 	case "openai":
-		apiKey := p.cfg.OpenAI.APIKey
+		apiKey := access.APIKey
 		if apiKey == "" {
 			return nil, fmt.Errorf("openai API key required when judge.provider=openai")
 		}
-		client = judge.NewOpenAIClient(
+		c := judge.NewOpenAIClient(
 			apiKey,
-			p.cfg.OpenAI.BaseURL,
-			p.cfg.Judge.Model,
-			p.cfg.Judge.MaxTokens,
+			access.BaseURL,
+			cfg.Judge.Model,
+			cfg.Judge.MaxTokens,
 		)
+		c.SetStructured(cfg.Judge.StructuredOutput)
+		client = c
 	default:
-		return nil, fmt.Errorf("unsupported judge provider: %s", p.cfg.Judge.Provider)
+		return nil, fmt.Errorf("unsupported judge provider: %s", cfg.Judge.Provider)
 	}
-
-	j := judge.New(client, p.cfg.Judge.Model, false)
-	return j.Evaluate(ctx, cs)
+	return client, nil
 }
 
 // deduplicateDiscovered merges models discovered from multiple sources.
-// API entries take priority; docs-sourced cost data fills gaps for API models missing cost.
+// API entries take priority; docs data fills gaps for API models, such as
+// missing cost. The batch discount and batch prices of the merged models
+// are then completed from each other.
 func deduplicateDiscovered(models []adapter.DiscoveredModel) []adapter.DiscoveredModel {
 	byName := make(map[string]*adapter.DiscoveredModel, len(models))
 	var order []string
@@ -377,38 +1086,188 @@ func deduplicateDiscovered(models []adapter.DiscoveredModel) []adapter.Discovere
 
 		// API source takes priority over docs.
 		if existing.DiscoveredBy == adapter.SourceAPI && m.DiscoveredBy != adapter.SourceAPI {
-			// Fill in cost data from docs if API model has none.
-			if existing.Cost == nil && m.Cost != nil {
-				existing.Cost = m.Cost
-			}
+			fillFromDocs(existing, m)
 		} else if m.DiscoveredBy == adapter.SourceAPI && existing.DiscoveredBy != adapter.SourceAPI {
-			// Replace docs entry with API entry, preserving docs cost if needed.
-			docsCost := existing.Cost
+			// Replace docs entry with API entry, keeping what only the docs know.
 			byName[m.Name] = m
-			if m.Cost == nil && docsCost != nil {
-				m.Cost = docsCost
-			}
+			fillFromDocs(m, existing)
 		}
 		// If both are from the same source, keep the first one.
 	}
 
 	result := make([]adapter.DiscoveredModel, 0, len(byName))
 	for _, name := range order {
-		result = append(result, *byName[name])
+		m := *byName[name]
+		completeBatch(&m)
+		result = append(result, m)
 	}
 	return result
 }
 
+// completeBatch fills in whichever of the batch discount and the batch
+// prices m lacks from the other: pricing pages list batch prices, while
+// APIs and overview pages state a discount.
+func completeBatch(m *adapter.DiscoveredModel) {
+	c := m.Cost
+	if c == nil || c.InputPer1K <= 0 {
+		return
+	}
+	switch {
+	case c.BatchInputPer1K > 0 && (m.Batch == nil || m.Batch.Discount == 0):
+		discount := math.Round((1-c.BatchInputPer1K/c.InputPer1K)*1e4) / 1e4
+		if discount <= 0 || discount >= 1 {
+			return
+		}
+		m.Batch = &adapter.Batch{Supported: true, Discount: discount}
+		source := m.DiscoveredBy
+		if s, ok := m.FieldSources["cost"]; ok {
+			source = s
+		}
+		m.SetFieldSource("batch", source)
+	case m.Batch != nil && m.Batch.Supported && m.Batch.Discount > 0 && c.BatchInputPer1K == 0 && c.BatchOutputPer1K == 0:
+		cost := *c
+		cost.BatchInputPer1K = c.InputPer1K * (1 - m.Batch.Discount)
+		cost.BatchOutputPer1K = c.OutputPer1K * (1 - m.Batch.Discount)
+		m.Cost = &cost
+	}
+}
+
+// fillFromDocs copies what an API model lacks from the docs entry for it.
+// The copied fields keep the docs as their source.
+func fillFromDocs(api, docs *adapter.DiscoveredModel) {
+	source := func(field string) adapter.SourceType {
+		if s, ok := docs.FieldSources[field]; ok {
+			return s
+		}
+		return docs.DiscoveredBy
+	}
+	if api.Cost == nil && docs.Cost != nil {
+		api.Cost = docs.Cost
+		api.SetFieldSource("cost", source("cost"))
+	}
+	// Some APIs list model IDs only; the docs know their limits.
+	if api.Limits.MaxTokens == 0 && docs.Limits.MaxTokens > 0 {
+		api.Limits.MaxTokens = docs.Limits.MaxTokens
+		if api.Limits.MaxCompletionTokens == 0 {
+			api.Limits.MaxCompletionTokens = docs.Limits.MaxCompletionTokens
+		}
+		api.SetFieldSource("limits", source("limits"))
+	}
+	switch {
+	case api.Batch == nil && docs.Batch != nil:
+		api.Batch = docs.Batch
+		api.SetFieldSource("batch", source("batch"))
+	case api.Batch != nil && api.Batch.Discount == 0 && docs.Batch != nil:
+		b := *api.Batch
+		b.Discount = docs.Batch.Discount
+		api.Batch = &b
+		api.SetFieldSource("batch", source("batch"))
+	}
+	switch {
+	case api.FineTuning == nil && docs.FineTuning != nil:
+		api.FineTuning = docs.FineTuning
+		api.SetFieldSource("fine_tuning", source("fine_tuning"))
+	case api.FineTuning != nil && docs.FineTuning != nil && api.FineTuning.TrainingPer1K == 0 && api.FineTuning.InputPer1K == 0:
+		// The API knows a model is fine-tunable, the docs what it costs.
+		ft := *docs.FineTuning
+		ft.Supported = api.FineTuning.Supported || ft.Supported
+		api.FineTuning = &ft
+		api.SetFieldSource("fine_tuning", source("fine_tuning"))
+	}
+	if api.Embedding == nil && docs.Embedding != nil {
+		api.Embedding = docs.Embedding
+		api.SetFieldSource("embedding", source("embedding"))
+	}
+	if api.RateLimits == nil && docs.RateLimits != nil {
+		api.RateLimits = docs.RateLimits
+		api.SetFieldSource("rate_limits", source("rate_limits"))
+	}
+	if api.KnowledgeCutoff == "" && docs.KnowledgeCutoff != "" {
+		api.KnowledgeCutoff = docs.KnowledgeCutoff
+		api.SetFieldSource("knowledge_cutoff", source("knowledge_cutoff"))
+	}
+	if api.ReleasedAt == "" && docs.ReleasedAt != "" {
+		api.ReleasedAt = docs.ReleasedAt
+		api.SetFieldSource("released_at", source("released_at"))
+	}
+	api.XProvider = mergeXProvider(api.XProvider, docs.XProvider)
+}
+
+// mergeXProvider combines x_provider metadata from two sources. Keys from
+// primary win; secondary only fills in keys primary doesn't have.
+func mergeXProvider(primary, secondary map[string]any) map[string]any {
+	if len(secondary) == 0 {
+		return primary
+	}
+	merged := make(map[string]any, len(primary)+len(secondary))
+	for k, v := range secondary {
+		merged[k] = v
+	}
+	for k, v := range primary {
+		merged[k] = v
+	}
+	return merged
+}
+
 // SourceHealthError indicates a source health check failure (exit code 4).
 type SourceHealthError struct {
-	Provider string
-	Reason   string
+	Provider   string
+	Reason     string
+	Discovered int // models discovered, when the model count failed
 }
 
 func (e *SourceHealthError) Error() string {
 	return fmt.Sprintf("source health check failed for %s: %s", e.Provider, e.Reason)
 }
 
+// ValidationError means models a changeset adds or updates broke the
+// catalog's validation rules, so nothing was written for the provider.
+type ValidationError struct {
+	Provider string
+	Result   *validate.Result
+}
+
+func (e *ValidationError) Error() string {
+	return "validation failed:\n" + validate.FormatResult(e.Result)
+}
+
+// EntitlementError means a provider turned sentinel away because the account
+// lacks the tier, credits or permission for it (HTTP 402 or 403). It is
+// reported as a skip rather than a failure: retrying won't help until the
+// account changes.
+type EntitlementError struct {
+	Provider   string
+	StatusCode int
+	Message    string // the provider's response body, shortened
+}
+
+func (e *EntitlementError) Error() string {
+	return fmt.Sprintf("insufficient entitlement for %s (HTTP %d): %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// Hint suggests the configuration that stops sentinel from trying.
+func (e *EntitlementError) Hint() string {
+	return fmt.Sprintf("remove %q from providers in the config (or SENTINEL_PROVIDERS) to stop syncing it", e.Provider)
+}
+
+// asEntitlementError reports whether err carries a 402 or 403 response and
+// converts it to an EntitlementError.
+func asEntitlementError(provider string, err error) (*EntitlementError, bool) {
+	var ee *EntitlementError
+	if errors.As(err, &ee) {
+		return ee, true
+	}
+	var se *httpclient.StatusError
+	if !errors.As(err, &se) || (se.StatusCode != http.StatusPaymentRequired && se.StatusCode != http.StatusForbidden) {
+		return nil, false
+	}
+	msg := strings.Join(strings.Fields(string(se.Body)), " ")
+	if len(msg) > 200 {
+		msg = msg[:200] + "…"
+	}
+	return &EntitlementError{Provider: provider, StatusCode: se.StatusCode, Message: msg}, true
+}
+
 // checkSourceHealth performs a pre-discovery liveness probe.
 func (p *Pipeline) checkSourceHealth(ctx context.Context, a adapter.Adapter, providerName string) error {
 	hc, ok := a.(adapter.HealthChecker)
@@ -417,6 +1276,9 @@ func (p *Pipeline) checkSourceHealth(ctx context.Context, a adapter.Adapter, pro
 	}
 	slog.Info("running health check", "provider", providerName)
 	if err := hc.HealthCheck(ctx); err != nil {
+		if ee, ok := asEntitlementError(providerName, err); ok {
+			return ee
+		}
 		return &SourceHealthError{Provider: providerName, Reason: fmt.Sprintf("liveness probe failed: %v", err)}
 	}
 	slog.Info("health check passed", "provider", providerName)
@@ -437,39 +1299,131 @@ func (p *Pipeline) checkModelCountThreshold(a adapter.Adapter, discovered []adap
 	requiredMin := int(float64(min) * threshold)
 	if len(discovered) < requiredMin {
 		return &SourceHealthError{
-			Provider: providerName,
-			Reason:   fmt.Sprintf("discovered %d models, below threshold %d (min=%d × %.0f%%)", len(discovered), requiredMin, min, threshold*100),
+			Provider:   providerName,
+			Reason:     fmt.Sprintf("discovered %d models, below threshold %d (min=%d × %.0f%%)", len(discovered), requiredMin, min, threshold*100),
+			Discovered: len(discovered),
 		}
 	}
 	return nil
 }
 
-// assessRisk evaluates the changeset against risk gates.
-// Returns: (draft, blocked, reason)
-func assessRisk(cs *diff.ChangeSet) (bool, bool, string) {
-	// Changed models > 25 → draft PR
-	draft := cs.TotalChanged() > 25
+// Risk modes. Relaxed (alias: permissive) turns block gates into draft gates.
+const (
+	RiskModeStrict     = "strict"
+	RiskModeRelaxed    = "relaxed"
+	RiskModePermissive = "permissive"
+)
 
-	// Deprecation candidates > 3 → draft PR
-	if len(cs.DeprecationCandidates) > 3 {
-		draft = true
+// RiskGate holds the thresholds above which a measure makes the PR a draft
+// or blocks it. Zero disables a threshold.
+type RiskGate struct {
+	Draft float64
+	Block float64
+}
+
+// RiskPolicy is the set of gates assessRisk applies.
+type RiskPolicy struct {
+	Changes      RiskGate // new + updated models
+	Deprecations RiskGate // deprecation candidates
+	PriceDelta   RiskGate // largest relative token price change, e.g. 0.35 for 35%
+	Relaxed      bool     // never block; block gates only force a draft
+
+	// FamilyRemoved is what removed model families do: FamilyRemovedBlock,
+	// FamilyRemovedDraft, or FamilyRemovedOff (also the zero value).
+	FamilyRemoved string
+}
+
+// NewRiskPolicy builds the policy from the risk config section and risk_mode.
+func NewRiskPolicy(cfg *config.Config) (RiskPolicy, error) {
+	p := RiskPolicy{
+		Changes:      RiskGate{Draft: cfg.Risk.Changes.Draft, Block: cfg.Risk.Changes.Block},
+		Deprecations: RiskGate{Draft: cfg.Risk.Deprecations.Draft, Block: cfg.Risk.Deprecations.Block},
+		PriceDelta:   RiskGate{Draft: cfg.Risk.PriceDelta.Draft, Block: cfg.Risk.PriceDelta.Block},
+
+		FamilyRemoved: cfg.Risk.FamilyRemoved,
+	}
+	switch p.FamilyRemoved {
+	case "":
+		p.FamilyRemoved = FamilyRemovedBlock
+	case FamilyRemovedBlock, FamilyRemovedDraft, FamilyRemovedOff:
+	default:
+		return p, fmt.Errorf("unknown risk.family_removed %q (want %s, %s or %s)",
+			cfg.Risk.FamilyRemoved, FamilyRemovedBlock, FamilyRemovedDraft, FamilyRemovedOff)
+	}
+	switch cfg.RiskMode {
+	case RiskModeStrict, "":
+	case RiskModeRelaxed, RiskModePermissive:
+		p.Relaxed = true
+	default:
+		return p, fmt.Errorf("unknown risk_mode %q (want %s or %s)", cfg.RiskMode, RiskModeStrict, RiskModeRelaxed)
+	}
+	return p, nil
+}
+
+// check applies a gate to value. It reports whether the draft and block
+// thresholds are exceeded, with a reason for the stricter outcome.
+func (g RiskGate) check(what string, value float64, format string) (draft, block bool, reason string) {
+	if g.Block > 0 && value > g.Block {
+		return true, true, fmt.Sprintf("%s "+format+" exceeds block threshold "+format, what, value, g.Block)
+	}
+	if g.Draft > 0 && value > g.Draft {
+		return true, false, fmt.Sprintf("%s "+format+" exceeds draft threshold "+format, what, value, g.Draft)
 	}
+	return false, false, ""
+}
 
-	// Check for large price deltas
+// assessRisk evaluates the changeset against risk gates.
+// Returns: (draft, blocked, reason)
+func assessRisk(cs *diff.ChangeSet, policy RiskPolicy) (bool, bool, string) {
+	// Largest relative change of any token price.
+	var maxDelta float64
 	for _, u := range cs.Updated {
 		for _, c := range u.Changes {
 			if c.Field == "cost.input_per_1k" || c.Field == "cost.output_per_1k" {
 				oldVal, okOld := c.OldValue.(float64)
 				newVal, okNew := c.NewValue.(float64)
 				if okOld && okNew && oldVal > 0 {
-					delta := (newVal - oldVal) / oldVal
-					if delta > 0.35 || delta < -0.35 || newVal > oldVal*2 {
-						draft = true
-					}
+					maxDelta = max(maxDelta, math.Abs((newVal-oldVal)/oldVal))
 				}
 			}
 		}
 	}
 
-	return draft, false, ""
+	var draft, blocked bool
+	var reasons []string
+	if n := len(cs.RemovedFamilies); n > 0 && policy.FamilyRemoved != "" && policy.FamilyRemoved != FamilyRemovedOff {
+		reason := fmt.Sprintf("%d model families no longer listed", n)
+		draft = true
+		switch {
+		case policy.FamilyRemoved != FamilyRemovedBlock:
+		case policy.Relaxed:
+			reason += " (relaxed: draft instead)"
+		default:
+			blocked = true
+		}
+		reasons = append(reasons, reason)
+	}
+	for _, gate := range []struct {
+		gate   RiskGate
+		what   string
+		value  float64
+		format string
+	}{
+		{policy.Changes, "changed models", float64(cs.TotalChanged()), "%.0f"},
+		{policy.Deprecations, "deprecation candidates", float64(len(cs.DeprecationCandidates)), "%.0f"},
+		{policy.PriceDelta, "price change", maxDelta, "%.2f"},
+	} {
+		d, b, reason := gate.gate.check(gate.what, gate.value, gate.format)
+		if b && policy.Relaxed {
+			b = false
+			reason += " (relaxed: draft instead)"
+		}
+		draft = draft || d
+		blocked = blocked || b
+		if reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	return draft, blocked, strings.Join(reasons, "; ")
 }
@@ -1,12 +1,34 @@
 package pipeline
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
 	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/config"
 	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+	"github.com/everstacklabs/sentinel/internal/judge"
 )
 
+// defaultRisk mirrors the config defaults: draft gates only.
+var defaultRisk = RiskPolicy{
+	Changes:      RiskGate{Draft: 25},
+	Deprecations: RiskGate{Draft: 3},
+	PriceDelta:   RiskGate{Draft: 0.35},
+}
+
 func TestAssessRisk_LargeChangeset(t *testing.T) {
 	cs := &diff.ChangeSet{}
 	// 26 new models → draft
@@ -14,12 +36,12 @@ func TestAssessRisk_LargeChangeset(t *testing.T) {
 		cs.New = append(cs.New, diff.ModelChange{Name: "model"})
 	}
 
-	draft, blocked, _ := assessRisk(cs)
+	draft, blocked, _ := assessRisk(cs, defaultRisk)
 	if !draft {
 		t.Error("expected draft for >25 changes")
 	}
 	if blocked {
-		t.Error("should never block")
+		t.Error("default policy should not block")
 	}
 }
 
@@ -30,12 +52,12 @@ func TestAssessRisk_ManyDeprecations(t *testing.T) {
 		},
 	}
 
-	draft, blocked, _ := assessRisk(cs)
+	draft, blocked, _ := assessRisk(cs, defaultRisk)
 	if !draft {
 		t.Error("expected draft for >3 deprecation candidates")
 	}
 	if blocked {
-		t.Error("should never block")
+		t.Error("default policy should not block")
 	}
 }
 
@@ -45,12 +67,12 @@ func TestAssessRisk_NormalChangeset(t *testing.T) {
 		Updated: []diff.ModelUpdate{{Name: "b"}},
 	}
 
-	draft, blocked, _ := assessRisk(cs)
+	draft, blocked, _ := assessRisk(cs, defaultRisk)
 	if draft {
 		t.Error("expected non-draft for small changeset")
 	}
 	if blocked {
-		t.Error("should never block")
+		t.Error("default policy should not block")
 	}
 }
 
@@ -66,7 +88,7 @@ func TestAssessRisk_LargePriceDelta(t *testing.T) {
 		},
 	}
 
-	draft, _, _ := assessRisk(cs)
+	draft, _, _ := assessRisk(cs, defaultRisk)
 	if !draft {
 		t.Error("expected draft for >35% price increase (100% increase)")
 	}
@@ -84,14 +106,151 @@ func TestAssessRisk_SmallPriceDelta(t *testing.T) {
 		},
 	}
 
-	draft, _, _ := assessRisk(cs)
+	draft, _, _ := assessRisk(cs, defaultRisk)
 	if draft {
 		t.Error("20% price increase should not trigger draft")
 	}
 }
 
+func TestAssessRisk_BlockGates(t *testing.T) {
+	priceCut := &diff.ChangeSet{
+		Updated: []diff.ModelUpdate{{
+			Name: "gpt-4o",
+			Changes: []catalog.FieldChange{
+				{Field: "cost.output_per_1k", OldValue: float64(0.01), NewValue: float64(0.001)},
+			},
+		}},
+	}
+	policy := defaultRisk
+	policy.PriceDelta.Block = 0.8
+
+	tests := []struct {
+		name        string
+		relaxed     bool
+		wantDraft   bool
+		wantBlocked bool
+	}{
+		{"strict blocks", false, true, true},
+		{"relaxed drafts", true, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy.Relaxed = tt.relaxed
+			draft, blocked, reason := assessRisk(priceCut, policy)
+			if draft != tt.wantDraft || blocked != tt.wantBlocked {
+				t.Errorf("draft=%v blocked=%v, want %v/%v", draft, blocked, tt.wantDraft, tt.wantBlocked)
+			}
+			if !strings.Contains(reason, "block threshold") {
+				t.Errorf("reason = %q, want block threshold mentioned", reason)
+			}
+		})
+	}
+}
+
+func TestAssessRisk_RemovedFamilies(t *testing.T) {
+	cs := &diff.ChangeSet{
+		DeprecationCandidates: []diff.ModelChange{{Name: "a"}, {Name: "b"}},
+		RemovedFamilies:       []diff.FamilyRemoval{{Family: "f", Models: []string{"a", "b"}}},
+	}
+
+	tests := []struct {
+		mode        string
+		relaxed     bool
+		wantDraft   bool
+		wantBlocked bool
+	}{
+		{FamilyRemovedBlock, false, true, true},
+		{FamilyRemovedBlock, true, true, false},
+		{FamilyRemovedDraft, false, true, false},
+		{FamilyRemovedOff, false, false, false},
+	}
+	for _, tt := range tests {
+		policy := defaultRisk
+		policy.FamilyRemoved, policy.Relaxed = tt.mode, tt.relaxed
+		draft, blocked, reason := assessRisk(cs, policy)
+		if draft != tt.wantDraft || blocked != tt.wantBlocked {
+			t.Errorf("%s (relaxed=%v): draft=%v blocked=%v, want %v/%v", tt.mode, tt.relaxed, draft, blocked, tt.wantDraft, tt.wantBlocked)
+		}
+		if tt.wantDraft && !strings.Contains(reason, "model families no longer listed") {
+			t.Errorf("%s: reason = %q", tt.mode, reason)
+		}
+	}
+}
+
+func TestApply_FamilyRemovedOff(t *testing.T) {
+	tests := []struct {
+		mode         string
+		wantFamilies bool
+	}{
+		{FamilyRemovedDraft, true},
+		{FamilyRemovedOff, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			root := t.TempDir()
+			writeFile(t, filepath.Join(root, "version.txt"), "1.0.0\n")
+			writeFile(t, filepath.Join(root, "providers", "openai", "provider.yaml"), "name: openai\n")
+
+			cfg := &config.Config{CatalogPath: root, Sources: []string{"api"}, DryRun: true}
+			cfg.Risk.FamilyRemoved = tt.mode
+			p := New(cfg)
+			if err := p.LoadCatalog(); err != nil {
+				t.Fatal(err)
+			}
+			snap := p.Snapshot([]diff.ChangeSet{{
+				Provider: "openai",
+				New: []diff.ModelChange{{Name: "gpt-5", Model: &catalog.Model{
+					Name: "gpt-5", DisplayName: "GPT-5", Family: "gpt-5", Status: "stable",
+					Limits:       catalog.Limits{MaxTokens: 400000},
+					Capabilities: []string{"chat"},
+					Modalities:   catalog.Modalities{Input: []string{"text"}, Output: []string{"text"}},
+				}}},
+				RemovedFamilies: []diff.FamilyRemoval{{Family: "o1", Models: []string{"o1"}}},
+			}})
+			results, err := New(cfg).Apply(context.Background(), snap)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := results[0]
+			if r.Error != nil || r.Blocked {
+				t.Fatalf("result = %+v", r)
+			}
+			// The PR body and the alert issue both come from RemovedFamilies.
+			if got := len(r.ChangeSet.RemovedFamilies) > 0; got != tt.wantFamilies {
+				t.Errorf("removed families kept = %v, want %v", got, tt.wantFamilies)
+			}
+			if r.PRDraft != tt.wantFamilies {
+				t.Errorf("draft = %v, want %v", r.PRDraft, tt.wantFamilies)
+			}
+		})
+	}
+}
+
+func TestNewRiskPolicy(t *testing.T) {
+	cfg := &config.Config{RiskMode: "permissive"}
+	cfg.Risk.Changes = config.RiskGateConfig{Draft: 10, Block: 100}
+	p, err := NewRiskPolicy(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Relaxed || p.Changes != (RiskGate{Draft: 10, Block: 100}) || p.FamilyRemoved != FamilyRemovedBlock {
+		t.Errorf("policy = %+v", p)
+	}
+
+	cfg.Risk.FamilyRemoved = "warn"
+	if _, err := NewRiskPolicy(cfg); err == nil {
+		t.Error("expected error for unknown risk.family_removed")
+	}
+	cfg.Risk.FamilyRemoved = ""
+
+	cfg.RiskMode = "lenient"
+	if _, err := NewRiskPolicy(cfg); err == nil {
+		t.Error("expected error for unknown risk_mode")
+	}
+}
+
 func TestBumpSemver_NewModels(t *testing.T) {
-	v, err := bumpSemver("2.1.3", true)
+	v, err := bumpSemver("2.1.3", bumpMinor)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -101,7 +260,7 @@ func TestBumpSemver_NewModels(t *testing.T) {
 }
 
 func TestBumpSemver_UpdatesOnly(t *testing.T) {
-	v, err := bumpSemver("2.1.3", false)
+	v, err := bumpSemver("2.1.3", bumpPatch)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -111,14 +270,14 @@ func TestBumpSemver_UpdatesOnly(t *testing.T) {
 }
 
 func TestBumpSemver_InvalidVersion(t *testing.T) {
-	_, err := bumpSemver("invalid", true)
+	_, err := bumpSemver("invalid", bumpMinor)
 	if err == nil {
 		t.Error("expected error for invalid semver")
 	}
 }
 
 func TestBumpSemver_ZeroVersion(t *testing.T) {
-	v, err := bumpSemver("0.0.0", true)
+	v, err := bumpSemver("0.0.0", bumpMinor)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -126,3 +285,411 @@ func TestBumpSemver_ZeroVersion(t *testing.T) {
 		t.Errorf("expected 0.1.0, got %s", v)
 	}
 }
+
+func TestBumpSemver_Major(t *testing.T) {
+	v, err := bumpSemver("2.1.3", bumpMajor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "3.0.0" {
+		t.Errorf("expected 3.0.0, got %s", v)
+	}
+}
+
+func TestVersionBump(t *testing.T) {
+	limitCut := diff.ModelUpdate{Name: "gpt-4o", Changes: []catalog.FieldChange{{Field: "limits.max_tokens", OldValue: 128000, NewValue: 64000}}}
+	limitRaise := diff.ModelUpdate{Name: "gpt-4o", Changes: []catalog.FieldChange{{Field: "limits.max_tokens", OldValue: 128000, NewValue: 256000}}}
+	capLoss := diff.ModelUpdate{Name: "o1", Changes: []catalog.FieldChange{{Field: "capabilities", OldValue: []string{"chat", "tools"}, NewValue: []string{"chat"}}}}
+	deprecated := diff.ModelUpdate{Name: "gpt-4", Changes: []catalog.FieldChange{{Field: "status", OldValue: "stable", NewValue: "deprecated"}}}
+	newModel := []diff.ModelChange{{Name: "gpt-5"}}
+	all := diff.BreakingKinds()
+
+	tests := []struct {
+		name    string
+		cs      diff.ChangeSet
+		majorOn []string
+		want    bumpLevel
+	}{
+		{"updates only", diff.ChangeSet{Updated: []diff.ModelUpdate{limitRaise}}, all, bumpPatch},
+		{"new model", diff.ChangeSet{New: newModel, Updated: []diff.ModelUpdate{limitRaise}}, all, bumpMinor},
+		{"limit reduction", diff.ChangeSet{New: newModel, Updated: []diff.ModelUpdate{limitCut}}, all, bumpMajor},
+		{"capability removal", diff.ChangeSet{Updated: []diff.ModelUpdate{capLoss}}, all, bumpMajor},
+		{"deprecation", diff.ChangeSet{Updated: []diff.ModelUpdate{deprecated}}, all, bumpMajor},
+		{"kind not in policy", diff.ChangeSet{Updated: []diff.ModelUpdate{limitCut}}, []string{diff.BreakingModelRemoval}, bumpPatch},
+		{"major bumps off", diff.ChangeSet{New: newModel, Updated: []diff.ModelUpdate{capLoss}}, nil, bumpMinor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(&config.Config{Versioning: config.VersioningConfig{MajorOn: tt.majorOn}})
+			if got := p.versionBump(&tt.cs); got != tt.want {
+				t.Errorf("versionBump = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsEntitlementError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int // status code; 0 when not an entitlement error
+	}{
+		{"payment required", fmt.Errorf("discovering models: %w", &httpclient.StatusError{StatusCode: 402, Body: []byte(`{"error":"insufficient credits"}`)}), 402},
+		{"forbidden", fmt.Errorf("anthropic API discovery: %w", &httpclient.StatusError{StatusCode: 403, Body: []byte("tier required")}), 403},
+		{"unauthorized", &httpclient.StatusError{StatusCode: 401}, 0},
+		{"server error", errors.New("max retries exceeded: retryable HTTP 503"), 0},
+		{"nil", nil, 0},
+	}
+	for _, tt := range tests {
+		ee, ok := asEntitlementError("groq", tt.err)
+		if ok != (tt.want != 0) {
+			t.Errorf("%s: ok = %v", tt.name, ok)
+			continue
+		}
+		if ok && (ee.StatusCode != tt.want || ee.Provider != "groq") {
+			t.Errorf("%s: got %+v", tt.name, ee)
+		}
+	}
+}
+
+func TestDeduplicateDiscovered(t *testing.T) {
+	models := []adapter.DiscoveredModel{
+		{Name: "gpt-4o", Batch: &adapter.Batch{Supported: true}, DiscoveredBy: adapter.SourceAPI},
+		{
+			Name:         "gpt-4o",
+			Cost:         &adapter.Cost{InputPer1K: 0.0025, OutputPer1K: 0.01},
+			Batch:        &adapter.Batch{Supported: true, Discount: 0.5},
+			DiscoveredBy: adapter.SourceDocs,
+		},
+		{Name: "o3", Batch: &adapter.Batch{Supported: true, Discount: 0.5}, DiscoveredBy: adapter.SourceDocs},
+		{Name: "o3", ReleasedAt: "2025-04-16", DiscoveredBy: adapter.SourceAPI},
+		{Name: "sonar-pro", DiscoveredBy: adapter.SourceAPI},
+		{Name: "sonar-pro", Limits: adapter.Limits{MaxTokens: 200000, MaxCompletionTokens: 8000}, DiscoveredBy: adapter.SourceDocs},
+	}
+
+	apiBatch := models[0].Batch
+	got := deduplicateDiscovered(models)
+	if len(got) != 3 {
+		t.Fatalf("got %d models, want 3", len(got))
+	}
+	gpt := got[0]
+	if gpt.Cost == nil || gpt.Batch == nil || gpt.Batch.Discount != 0.5 {
+		t.Errorf("docs cost and batch discount should fill the API model: %+v %+v", gpt.Cost, gpt.Batch)
+	}
+	if apiBatch.Discount != 0 {
+		t.Error("filling the discount should not modify the API's batch block in place")
+	}
+	if gpt.FieldSources["cost"] != adapter.SourceDocs || gpt.FieldSources["batch"] != adapter.SourceDocs {
+		t.Errorf("field sources = %v, want cost and batch from the docs", gpt.FieldSources)
+	}
+	o3 := got[1]
+	if o3.DiscoveredBy != adapter.SourceAPI || o3.ReleasedAt != "2025-04-16" || o3.Batch == nil {
+		t.Errorf("API entry should replace the docs entry and keep its batch block: %+v", o3)
+	}
+	if _, ok := o3.FieldSources["released_at"]; ok || o3.FieldSources["batch"] != adapter.SourceDocs {
+		t.Errorf("field sources = %v, want only batch from the docs", o3.FieldSources)
+	}
+	sonar := got[2]
+	if sonar.Limits.MaxTokens != 200000 || sonar.Limits.MaxCompletionTokens != 8000 || sonar.FieldSources["limits"] != adapter.SourceDocs {
+		t.Errorf("docs limits should fill an API model listed without them: %+v %v", sonar.Limits, sonar.FieldSources)
+	}
+}
+
+func TestDeduplicateDiscovered_CompletesBatch(t *testing.T) {
+	models := []adapter.DiscoveredModel{
+		// The Anthropic pricing page lists batch prices only.
+		{
+			Name:         "claude-opus-4-6",
+			Cost:         &adapter.Cost{InputPer1K: 5, OutputPer1K: 25, BatchInputPer1K: 2.5, BatchOutputPer1K: 12.5, Unit: "per_1m"},
+			DiscoveredBy: adapter.SourceDocs,
+		},
+		// Groq's API states the discount only.
+		{
+			Name:         "llama-3.3-70b-versatile",
+			Cost:         &adapter.Cost{InputPer1K: 0.59, OutputPer1K: 0.79, Unit: "per_1m"},
+			Batch:        &adapter.Batch{Supported: true, Discount: 0.5},
+			DiscoveredBy: adapter.SourceAPI,
+		},
+		{Name: "free", Cost: &adapter.Cost{}, DiscoveredBy: adapter.SourceAPI},
+	}
+	got := deduplicateDiscovered(models)
+
+	if b := got[0].Batch; b == nil || !b.Supported || b.Discount != 0.5 {
+		t.Errorf("batch = %+v, want the discount derived from the batch prices", b)
+	}
+	if c := got[1].Cost; c.BatchInputPer1K != 0.295 || c.BatchOutputPer1K != 0.395 {
+		t.Errorf("batch prices = %v, %v; want them derived from the discount", c.BatchInputPer1K, c.BatchOutputPer1K)
+	}
+	if models[1].Cost.BatchInputPer1K != 0 {
+		t.Error("deriving the batch prices should not modify the discovered cost in place")
+	}
+	if got[2].Batch != nil || got[2].Cost.BatchInputPer1K != 0 {
+		t.Errorf("a model without prices should be left alone: %+v %+v", got[2].Batch, got[2].Cost)
+	}
+}
+
+func TestWriteChangeSet_Provenance(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "version.txt"), "1.0.0\n")
+	writeFile(t, filepath.Join(root, "providers", "openai", "provider.yaml"), "name: openai\n")
+	modelsDir := filepath.Join(root, "providers", "openai", "models")
+	writeFile(t, filepath.Join(modelsDir, "gpt-4o.yaml"), `name: gpt-4o
+display_name: GPT-4o
+family: gpt-4o
+status: stable
+cost:
+  input_per_1k: 0.005
+  output_per_1k: 0.015
+limits:
+  max_tokens: 128000
+x_updater:
+  last_verified_at: "2025-01-01T00:00:00Z"
+  sources: [docs]
+  fields:
+    cost: {source: docs, updated_at: "2025-01-01T00:00:00Z"}
+    limits: {source: manual}
+`)
+
+	p := New(&config.Config{CatalogPath: root, Sources: []string{"api"}})
+	if err := p.LoadCatalog(); err != nil {
+		t.Fatal(err)
+	}
+	api := &catalog.XUpdater{Fields: map[string]catalog.FieldSource{"cost": {Source: "api"}, "limits": {Source: "api"}}}
+	cs := &diff.ChangeSet{
+		Provider: "openai",
+		New: []diff.ModelChange{{Name: "gpt-5", Model: &catalog.Model{
+			Name: "gpt-5", DisplayName: "GPT-5", Family: "gpt-5", Status: "stable",
+			XUpdater: &catalog.XUpdater{Fields: map[string]catalog.FieldSource{"display_name": {Source: "api"}, "family": {Source: "docs"}}},
+		}}},
+		Updated: []diff.ModelUpdate{{
+			Name: "gpt-4o",
+			Model: &catalog.Model{
+				Name: "gpt-4o", DisplayName: "GPT-4o", Family: "gpt-4o", Status: "stable",
+				Cost:   &catalog.Cost{InputPer1K: 0.0025, OutputPer1K: 0.01},
+				Limits: catalog.Limits{MaxTokens: 128000}, XUpdater: api,
+			},
+			Changes: []catalog.FieldChange{{Field: "cost.input_per_1k", OldValue: 0.005, NewValue: 0.0025}},
+		}},
+	}
+	if _, _, err := p.writeChangeSet(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	cat, err := catalog.Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	models := cat.Providers["openai"].Models
+	updated := models["gpt-4o"].FieldSources()
+	if updated["cost"].Source != "api" || updated["cost"].UpdatedAt == "2025-01-01T00:00:00Z" {
+		t.Errorf("cost provenance = %+v, want api, now", updated["cost"])
+	}
+	if updated["limits"].Source != catalog.SourceManual {
+		t.Errorf("limits provenance = %+v, want the manual entry kept", updated["limits"])
+	}
+	added := models["gpt-5"]
+	if added.XUpdater == nil || added.XUpdater.LastVerifiedAt == "" || added.FieldSources()["family"].Source != "docs" {
+		t.Errorf("new model x_updater = %+v, want family from the docs", added.XUpdater)
+	}
+}
+
+// describedAdapter supplies provider.yaml defaults.
+type describedAdapter struct{ healthAdapter }
+
+func (a *describedAdapter) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{DisplayName: "Acme AI", BaseURL: "https://api.acme.test/v1", AuthType: catalog.AuthBearer}
+}
+
+func TestWriteChangeSet_ProviderFile(t *testing.T) {
+	adapter.Register(&describedAdapter{healthAdapter{name: "acme"}})
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "version.txt"), "1.0.0\n")
+
+	p := New(&config.Config{CatalogPath: root, Sources: []string{"api"}})
+	cs := &diff.ChangeSet{
+		Provider: "acme",
+		New: []diff.ModelChange{{Name: "acme-1", Model: &catalog.Model{
+			Name: "acme-1", DisplayName: "Acme 1", Family: "acme", Status: "stable",
+		}}},
+	}
+	if _, _, err := p.writeChangeSet(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	cat, err := catalog.Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := catalog.Provider{
+		Name: "acme", DisplayName: "Acme AI", ProviderType: "llm", SupportsModelDiscovery: true,
+		BaseURL: "https://api.acme.test/v1", AuthType: catalog.AuthBearer,
+	}
+	if got := cat.Providers["acme"].Provider; got != want {
+		t.Errorf("provider = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteChangeSet_RollsBackOnFailure(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "version.txt"), "not-a-version\n")
+	modelPath := filepath.Join(root, "providers", "openai", "models", "gpt-4o.yaml")
+	original := "name: gpt-4o\ndisplay_name: GPT-4o\nfamily: gpt-4o\nstatus: stable\nlimits:\n  max_tokens: 128000\n"
+	writeFile(t, modelPath, original)
+
+	p := New(&config.Config{CatalogPath: root, Sources: []string{"api"}})
+	cs := &diff.ChangeSet{
+		Provider: "openai",
+		New: []diff.ModelChange{{Name: "gpt-5", Model: &catalog.Model{
+			Name: "gpt-5", DisplayName: "GPT-5", Family: "gpt-5", Status: "stable",
+		}}},
+		Updated: []diff.ModelUpdate{{Name: "gpt-4o", Model: &catalog.Model{
+			Name: "gpt-4o", DisplayName: "GPT-4o", Family: "gpt-4o", Status: "stable",
+			Limits: catalog.Limits{MaxTokens: 256000},
+		}}},
+	}
+
+	// The version bump fails after both models were written.
+	if _, _, err := p.writeChangeSet(context.Background(), cs); err == nil {
+		t.Fatal("expected an error for an invalid version.txt")
+	}
+	got, err := os.ReadFile(modelPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != original {
+		t.Errorf("updated model not restored:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(root, "providers", "openai", "models", "gpt-5.yaml")); !os.IsNotExist(err) {
+		t.Errorf("new model not removed: %v", err)
+	}
+}
+
+func TestApply_RollsBackOnPRFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		git     bool
+		wantErr string
+	}{
+		// Fails before anything is staged: only the journal restores.
+		{"not a repository", false, "repository does not exist"},
+		// Fails after the changes were committed to a new branch.
+		{"push fails", true, "pushing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFile(t, filepath.Join(root, "version.txt"), "1.0.0\n")
+			writeFile(t, filepath.Join(root, "providers", "openai", "provider.yaml"), "name: openai\n")
+			var repo *git.Repository
+			if tt.git {
+				// No origin, so the push fails.
+				var err error
+				if repo, err = git.PlainInit(root, false); err != nil {
+					t.Fatal(err)
+				}
+				wt, err := repo.Worktree()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := wt.Add("."); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := wt.Commit("initial", &git.CommitOptions{
+					Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg := &config.Config{CatalogPath: root, Sources: []string{"api"}, GitHub: config.GitHubConfig{Token: "token", BaseBranch: "main"}}
+			p := New(cfg)
+			if err := p.LoadCatalog(); err != nil {
+				t.Fatal(err)
+			}
+			snap := p.Snapshot([]diff.ChangeSet{{Provider: "openai", New: []diff.ModelChange{{Name: "gpt-5", Model: &catalog.Model{
+				Name: "gpt-5", DisplayName: "GPT-5", Family: "gpt-5", Status: "stable",
+				Limits:       catalog.Limits{MaxTokens: 400000},
+				Capabilities: []string{"chat"},
+				Modalities:   catalog.Modalities{Input: []string{"text"}, Output: []string{"text"}},
+			}}}}})
+			var start string
+			if repo != nil {
+				head, err := repo.Head()
+				if err != nil {
+					t.Fatal(err)
+				}
+				start = head.String()
+			}
+
+			results, err := New(cfg).Apply(context.Background(), snap)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != 1 || results[0].Error == nil || !strings.Contains(results[0].Error.Error(), tt.wantErr) {
+				t.Fatalf("results = %+v, want an error containing %q", results, tt.wantErr)
+			}
+
+			if _, err := os.Stat(filepath.Join(root, "providers", "openai", "models", "gpt-5.yaml")); !os.IsNotExist(err) {
+				t.Errorf("new model not removed: %v", err)
+			}
+			for _, name := range []string{"manifest.yaml", "CHANGELOG.md"} {
+				if _, err := os.Stat(filepath.Join(root, name)); !os.IsNotExist(err) {
+					t.Errorf("%s not removed: %v", name, err)
+				}
+			}
+			if got, err := os.ReadFile(filepath.Join(root, "version.txt")); err != nil || string(got) != "1.0.0\n" {
+				t.Errorf("version.txt = %q, %v; want it restored", got, err)
+			}
+			if repo == nil {
+				return
+			}
+			head, err := repo.Head()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if head.String() != start {
+				t.Errorf("HEAD = %s, want %s", head, start)
+			}
+			wt, err := repo.Worktree()
+			if err != nil {
+				t.Fatal(err)
+			}
+			status, err := wt.Status()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !status.IsClean() {
+				t.Errorf("worktree not restored:\n%s", status)
+			}
+		})
+	}
+}
+
+func TestJudgePricing(t *testing.T) {
+	cat := &catalog.Catalog{Providers: map[string]*catalog.ProviderCatalog{
+		"anthropic": {Models: map[string]*catalog.Model{
+			"claude-sonnet-4": {Cost: &catalog.Cost{InputPer1K: 3, OutputPer1K: 15, Unit: catalog.PriceUnitPer1M}},
+			"claude-eur":      {Cost: &catalog.Cost{InputPer1K: 0.003, OutputPer1K: 0.015, Currency: "EUR"}},
+		}},
+	}}
+	tests := []struct {
+		name  string
+		judge config.JudgeConfig
+		want  judge.Pricing
+	}{
+		{"from the catalog", config.JudgeConfig{Provider: "anthropic", Model: "claude-sonnet-4"}, judge.Pricing{InputPer1M: 3, OutputPer1M: 15}},
+		{"configured", config.JudgeConfig{Provider: "anthropic", Model: "claude-sonnet-4", InputPricePer1M: 1, OutputPricePer1M: 2}, judge.Pricing{InputPer1M: 1, OutputPer1M: 2}},
+		{"not in the catalog", config.JudgeConfig{Provider: "openai", Model: "gpt-5"}, judge.Pricing{}},
+		{"not in USD", config.JudgeConfig{Provider: "anthropic", Model: "claude-eur"}, judge.Pricing{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(&config.Config{Judge: tt.judge})
+			p.catalog = cat
+			got := p.judgePricing()
+			if math.Abs(got.InputPer1M-tt.want.InputPer1M) > 1e-9 || math.Abs(got.OutputPer1M-tt.want.OutputPer1M) > 1e-9 {
+				t.Errorf("pricing = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package pipeline
+
+import (
+	"log/slog"
+
+	"github.com/everstacklabs/sentinel/internal/diff"
+)
+
+// Rename modes, the diff.renames setting.
+const (
+	RenamesReport  = "report"  // list possible renames in the PR only
+	RenamesAuto    = "auto"    // apply every unambiguous possible rename
+	RenamesConfirm = "confirm" // ask WithRenameConfirm's function about each
+)
+
+// RenameConfirmFunc decides whether a provider's possible rename is real.
+type RenameConfirmFunc func(provider string, r diff.RenamePair) bool
+
+// WithRenameConfirm asks confirm about each possible rename when
+// diff.renames is "confirm". Without it, confirm mode only reports them.
+func WithRenameConfirm(confirm RenameConfirmFunc) Option {
+	return func(p *Pipeline) { p.confirmRename = confirm }
+}
+
+// applyRenames turns the possible renames of cs that diff.renames confirms
+// into renames, so the new model takes over the old one's file instead of
+// being added next to it.
+func (p *Pipeline) applyRenames(cs *diff.ChangeSet) {
+	switch {
+	case p.cfg.Diff.Renames == RenamesAuto:
+		cs.ApplyRenames(func(diff.RenamePair) bool { return true })
+	case p.cfg.Diff.Renames == RenamesConfirm && p.confirmRename != nil:
+		cs.ApplyRenames(func(r diff.RenamePair) bool { return p.confirmRename(cs.Provider, r) })
+	}
+	for _, r := range cs.Renamed {
+		slog.Info("rename confirmed", "provider", cs.Provider, "from", r.OldName, "to", r.Name)
+	}
+}
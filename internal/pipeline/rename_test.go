@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/diff"
+)
+
+func TestApplyRenamesModes(t *testing.T) {
+	changeSet := func() *diff.ChangeSet {
+		return &diff.ChangeSet{
+			Provider:        "openai",
+			New:             []diff.ModelChange{{Name: "gpt-4o-v2", Model: &catalog.Model{Name: "gpt-4o-v2"}}},
+			PossibleRenames: []diff.RenamePair{{OldName: "gpt-4o-v1", NewName: "gpt-4o-v2"}},
+		}
+	}
+	yes := func(string, diff.RenamePair) bool { return true }
+
+	tests := []struct {
+		mode    string
+		confirm RenameConfirmFunc
+		renamed int
+	}{
+		{RenamesReport, yes, 0},
+		{RenamesAuto, nil, 1},
+		{RenamesConfirm, yes, 1},
+		{RenamesConfirm, func(string, diff.RenamePair) bool { return false }, 0},
+		{RenamesConfirm, nil, 0}, // no terminal to ask on
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{Diff: config.DiffConfig{Renames: tt.mode}}
+		p := New(cfg, WithRenameConfirm(tt.confirm))
+		cs := changeSet()
+		p.applyRenames(cs)
+		if len(cs.Renamed) != tt.renamed || len(cs.New)+len(cs.Renamed) != 1 {
+			t.Errorf("%s: renamed %d, new %d, want %d renamed", tt.mode, len(cs.Renamed), len(cs.New), tt.renamed)
+		}
+	}
+}
+
+func TestWriteChangeSet_Renamed(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "version.txt"), "1.4.2\n")
+	writeFile(t, filepath.Join(root, "providers", "openai", "provider.yaml"), "name: openai\n")
+	modelsDir := filepath.Join(root, "providers", "openai", "models")
+	writeFile(t, filepath.Join(modelsDir, "gpt-4o-v1.yaml"),
+		"name: gpt-4o-v1\ndisplay_name: GPT-4o V1\nfamily: gpt-4o\nstatus: stable\nlimits:\n  max_tokens: 128000\nnotes: keep\n")
+
+	p := New(&config.Config{CatalogPath: root, Sources: []string{"api"}})
+	cs := &diff.ChangeSet{
+		Provider: "openai",
+		Renamed: []diff.ModelRename{{OldName: "gpt-4o-v1", Name: "gpt-4o-v2", Model: &catalog.Model{
+			Name: "gpt-4o-v2", DisplayName: "GPT-4o V2", Family: "gpt-4o", Status: "stable",
+			Limits: catalog.Limits{MaxTokens: 128000},
+		}}},
+	}
+	in, _, err := p.writeChangeSet(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in.ToVersion != "1.5.0" {
+		t.Errorf("version = %s, want a minor bump to 1.5.0", in.ToVersion)
+	}
+
+	cat, err := catalog.Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	models := cat.Providers["openai"].Models
+	if m := models["gpt-4o-v2"]; m == nil || len(m.Aliases) != 1 || m.Aliases[0] != "gpt-4o-v1" {
+		t.Errorf("renamed model = %+v, want alias gpt-4o-v1", m)
+	}
+	if m := models["gpt-4o-v1"]; m == nil || m.Status != "deprecated" {
+		t.Errorf("old model = %+v, want it kept as deprecated", m)
+	}
+	data, err := os.ReadFile(filepath.Join(modelsDir, "gpt-4o-v2.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "notes: keep") {
+		t.Errorf("manual fields not carried over:\n%s", data)
+	}
+}
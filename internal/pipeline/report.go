@@ -0,0 +1,169 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/fsutil"
+	"github.com/everstacklabs/sentinel/internal/judge"
+)
+
+// Provider outcomes in a run report.
+const (
+	OutcomePR        = "pr"         // a PR was opened or updated
+	OutcomeWritten   = "written"    // changes were written, without a PR
+	OutcomeDryRun    = "dry run"    // changes found, nothing written
+	OutcomeNoChanges = "no changes" // the catalog is up to date
+	OutcomeSkipped   = "skipped"    // e.g. every model rejected by the judge
+	OutcomeBlocked   = "blocked"    // a risk gate blocked the changeset
+	OutcomeNoAccess  = "no access"  // the account lacks entitlement
+	OutcomeTimedOut  = "timed out"  // discovery ran past discovery_timeout
+	OutcomeFailed    = "failed"
+)
+
+// RunReport is the machine-readable summary of a sync, written to
+// report_path for CI to keep as an artifact and for trend analysis.
+type RunReport struct {
+	Started   time.Time        `json:"started"`
+	Finished  time.Time        `json:"finished"`
+	DryRun    bool             `json:"dry_run"`
+	ExitCode  int              `json:"exit_code"` // what sync exits with, under fail_on
+	Failed    int              `json:"failed"`    // providers that failed
+	Models    int              `json:"models"`    // models discovered, over every provider
+	JudgeCost float64          `json:"judge_cost_usd"`
+	Providers []ProviderReport `json:"providers"`
+}
+
+// ProviderReport is one provider's part of a run report.
+type ProviderReport struct {
+	Provider   string `json:"provider"`
+	Catalog    string `json:"catalog,omitempty"`
+	Outcome    string `json:"outcome"`
+	DurationMS int64  `json:"duration_ms"`
+	Discovered int    `json:"discovered"`       // models discovery returned, before filters
+	Reason     string `json:"reason,omitempty"` // why it was skipped or blocked
+	Error      string `json:"error,omitempty"`
+
+	New             int      `json:"new"`
+	Updated         int      `json:"updated"`
+	Unchanged       int      `json:"unchanged"`
+	Deprecations    int      `json:"deprecation_candidates"`
+	Renamed         int      `json:"renamed"`
+	Renames         int      `json:"possible_renames"`
+	Warnings        int      `json:"warnings"`
+	RemovedFamilies []string `json:"removed_families,omitempty"`
+
+	PRs   []int         `json:"prs,omitempty"`
+	Draft bool          `json:"draft,omitempty"`
+	Judge *judge.Result `json:"judge,omitempty"`
+}
+
+// BuildRunReport summarizes the results of a sync that ran from started to
+// finished.
+func BuildRunReport(results []SyncResult, started, finished time.Time, dryRun bool) *RunReport {
+	report := &RunReport{
+		Started:   started.UTC(),
+		Finished:  finished.UTC(),
+		DryRun:    dryRun,
+		JudgeCost: JudgeSpend(results).CostUSD,
+		Providers: make([]ProviderReport, 0, len(results)),
+	}
+	for _, r := range results {
+		pr := ProviderReport{
+			Provider:   r.Provider,
+			Catalog:    r.Catalog,
+			Outcome:    outcome(r, dryRun),
+			DurationMS: r.Duration.Milliseconds(),
+			Discovered: r.Discovered,
+			Draft:      r.PRDraft && r.PRNumber != 0,
+			Judge:      r.JudgeResult,
+		}
+		report.Models += r.Discovered
+		switch {
+		case r.Error != nil:
+			report.Failed++
+			pr.Error = r.Error.Error()
+		case r.Skipped:
+			pr.Reason = r.SkipReason
+		}
+		if cs := r.ChangeSet; cs != nil {
+			pr.New, pr.Updated, pr.Renamed, pr.Unchanged = len(cs.New), len(cs.Updated), len(cs.Renamed), cs.Unchanged
+			pr.Deprecations, pr.Renames, pr.Warnings = len(cs.DeprecationCandidates), len(cs.PossibleRenames), len(cs.Warnings)
+			for _, f := range cs.RemovedFamilies {
+				pr.RemovedFamilies = append(pr.RemovedFamilies, f.Family)
+			}
+		}
+		switch {
+		case len(r.PRNumbers) > 0:
+			pr.PRs = r.PRNumbers
+		case r.PRNumber != 0:
+			pr.PRs = []int{r.PRNumber}
+		}
+		report.Providers = append(report.Providers, pr)
+	}
+	return report
+}
+
+// JudgeSpend adds up the judge usage of every provider's evaluation.
+func JudgeSpend(results []SyncResult) judge.Usage {
+	var total judge.Usage
+	for _, r := range results {
+		if r.JudgeResult == nil || r.JudgeResult.Usage == nil {
+			continue
+		}
+		total.InputTokens += r.JudgeResult.Usage.InputTokens
+		total.OutputTokens += r.JudgeResult.Usage.OutputTokens
+		total.CostUSD += r.JudgeResult.Usage.CostUSD
+	}
+	return total
+}
+
+func outcome(r SyncResult, dryRun bool) string {
+	switch {
+	case r.TimedOut:
+		return OutcomeTimedOut
+	case r.Error != nil:
+		return OutcomeFailed
+	case r.Entitlement != nil:
+		return OutcomeNoAccess
+	case r.Blocked:
+		return OutcomeBlocked
+	case r.Skipped && r.SkipReason == "no changes":
+		return OutcomeNoChanges
+	case r.Skipped:
+		return OutcomeSkipped
+	case r.PRNumber != 0:
+		return OutcomePR
+	case dryRun:
+		return OutcomeDryRun
+	default:
+		return OutcomeWritten
+	}
+}
+
+// writeReport writes the run report to report_path. Like the run history,
+// a report that can't be written is only logged: the sync itself is done.
+func writeReport(cfg *config.Config, results []SyncResult, started time.Time) {
+	path := cfg.ReportPath
+	if path == "" {
+		return
+	}
+	report := BuildRunReport(results, started, time.Now(), cfg.DryRun)
+	report.ExitCode = ExitCode(results, cfg.FailOn)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0o755)
+	}
+	if err == nil {
+		err = fsutil.WriteFileAtomic(path, append(data, '\n'), 0o644, false)
+	}
+	if err != nil {
+		slog.Warn("writing run report failed", "path", path, "error", err)
+		return
+	}
+	slog.Info("run report written", "path", path)
+}
@@ -0,0 +1,80 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/judge"
+)
+
+func TestBuildRunReport(t *testing.T) {
+	changes := &diff.ChangeSet{
+		Provider:              "openai",
+		New:                   []diff.ModelChange{{Name: "gpt-5"}},
+		Updated:               []diff.ModelUpdate{{Name: "gpt-4o"}},
+		DeprecationCandidates: []diff.ModelChange{{Name: "o1"}, {Name: "o1-pro"}},
+		RemovedFamilies:       []diff.FamilyRemoval{{Family: "o1", Models: []string{"o1", "o1-pro"}}},
+		Unchanged:             7,
+	}
+	verdicts := &judge.Result{
+		Verdicts: []judge.ModelVerdict{{ModelName: "gpt-5", Verdict: judge.VerdictApprove}},
+		Usage:    &judge.Usage{InputTokens: 1000, OutputTokens: 200, CostUSD: 0.006},
+	}
+	results := []SyncResult{
+		{Provider: "openai", ChangeSet: changes, JudgeResult: verdicts, PRNumber: 12, PRNumbers: []int{12, 13}, PRDraft: true, Duration: 1500 * time.Millisecond, Discovered: 120},
+		{Provider: "mistral", ChangeSet: &diff.ChangeSet{Provider: "mistral", Unchanged: 4}, Skipped: true, SkipReason: "no changes", Discovered: 70},
+		{Provider: "groq", ChangeSet: changes, Skipped: true, Blocked: true, SkipReason: "1 model families no longer listed"},
+		{Provider: "cohere", Skipped: true, Entitlement: &EntitlementError{Provider: "cohere", StatusCode: 403}},
+		{Provider: "xai", Error: errors.New("discovering models: 500")},
+		{Provider: "venice", Catalog: "internal", ChangeSet: changes, JudgeResult: &judge.Result{Usage: &judge.Usage{CostUSD: 0.004}}},
+	}
+	start := time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC)
+	report := BuildRunReport(results, start, start.Add(time.Minute), false)
+
+	want := []string{OutcomePR, OutcomeNoChanges, OutcomeBlocked, OutcomeNoAccess, OutcomeFailed, OutcomeWritten}
+	for i, p := range report.Providers {
+		if p.Outcome != want[i] {
+			t.Errorf("%s: outcome %q, want %q", p.Provider, p.Outcome, want[i])
+		}
+	}
+	openai := report.Providers[0]
+	if openai.DurationMS != 1500 || openai.New != 1 || openai.Updated != 1 || openai.Deprecations != 2 || openai.Unchanged != 7 ||
+		len(openai.PRs) != 2 || !openai.Draft || openai.Judge != verdicts || len(openai.RemovedFamilies) != 1 {
+		t.Errorf("openai = %+v", openai)
+	}
+	if report.Providers[5].Catalog != "internal" || report.Providers[0].Catalog != "" {
+		t.Errorf("catalogs = %q, %q", report.Providers[5].Catalog, report.Providers[0].Catalog)
+	}
+	if report.Providers[2].Reason == "" || report.Providers[4].Error == "" {
+		t.Errorf("reason or error missing: %+v", report.Providers)
+	}
+	if got := BuildRunReport(results[5:], start, start, true).Providers[0].Outcome; got != OutcomeDryRun {
+		t.Errorf("dry run outcome = %q", got)
+	}
+
+	// writeReport creates missing directories.
+	path := filepath.Join(t.TempDir(), "reports", "run.json")
+	writeReport(&config.Config{ReportPath: path, FailOn: []string{FailOnError}}, results, start)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded RunReport
+	if err := json.Unmarshal(data, &decoded); err != nil || len(decoded.Providers) != len(results) {
+		t.Errorf("report file: %v, %d providers", err, len(decoded.Providers))
+	}
+	if decoded.ExitCode != ExitFailed || decoded.Failed != 1 || decoded.Models != 190 || decoded.Providers[0].Discovered != 120 {
+		t.Errorf("report file: exit code %d, %d failed, %d models, openai discovered %d",
+			decoded.ExitCode, decoded.Failed, decoded.Models, decoded.Providers[0].Discovered)
+	}
+	if math.Abs(decoded.JudgeCost-0.01) > 1e-9 || decoded.Providers[0].Judge.Usage.InputTokens != 1000 {
+		t.Errorf("report file: judge cost %v, openai judge %+v", decoded.JudgeCost, decoded.Providers[0].Judge)
+	}
+}
@@ -0,0 +1,175 @@
+package pipeline
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5"
+	"golang.org/x/crypto/ssh"
+)
+
+// Commit signing formats.
+const (
+	SigningGPG = "gpg"
+	SigningSSH = "ssh"
+)
+
+// LoadSigner returns the signer for sentinel's commits. key is the path of
+// a private key file or, when it starts with "-----BEGIN", the key itself,
+// as CI secrets usually hold it: an armored OpenPGP key for gpg, an OpenSSH
+// private key for ssh. An empty format disables signing.
+func LoadSigner(format, key, passphrase string) (git.Signer, error) {
+	if format == "" {
+		return nil, nil
+	}
+	if key == "" {
+		return nil, fmt.Errorf("commit signing: %s key is not set", format)
+	}
+	data := []byte(key)
+	if !strings.HasPrefix(strings.TrimSpace(key), "-----BEGIN") {
+		var err error
+		if data, err = os.ReadFile(key); err != nil {
+			return nil, fmt.Errorf("commit signing: reading key: %w", err)
+		}
+	}
+
+	switch format {
+	case SigningGPG:
+		return newGPGSigner(data, passphrase)
+	case SigningSSH:
+		return newSSHSigner(data, passphrase)
+	default:
+		return nil, fmt.Errorf("commit signing: unknown format %q (want %q or %q)", format, SigningGPG, SigningSSH)
+	}
+}
+
+// gpgSigner makes the armored detached OpenPGP signatures git stores in a
+// commit's gpgsig header.
+type gpgSigner struct {
+	entity *openpgp.Entity
+}
+
+func newGPGSigner(data []byte, passphrase string) (*gpgSigner, error) {
+	keys, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("commit signing: reading OpenPGP key: %w", err)
+	}
+	if len(keys) == 0 || keys[0].PrivateKey == nil {
+		return nil, fmt.Errorf("commit signing: no OpenPGP private key found")
+	}
+	entity := keys[0]
+	if entity.PrivateKey.Encrypted {
+		if passphrase == "" {
+			return nil, fmt.Errorf("commit signing: OpenPGP key is encrypted and no passphrase is set")
+		}
+		if err := entity.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+			return nil, fmt.Errorf("commit signing: decrypting OpenPGP key: %w", err)
+		}
+	}
+	return &gpgSigner{entity: entity}, nil
+}
+
+func (s *gpgSigner) Sign(message io.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&buf, s.entity, message, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sshSigner makes SSH signatures in the SSHSIG format git uses with
+// gpg.format=ssh.
+type sshSigner struct {
+	signer ssh.Signer
+}
+
+// sshsigNamespace is the namespace git signs commits in.
+const sshsigNamespace = "git"
+
+func newSSHSigner(data []byte, passphrase string) (*sshSigner, error) {
+	var (
+		signer ssh.Signer
+		err    error
+	)
+	if passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(data, []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("commit signing: reading SSH key: %w", err)
+	}
+	return &sshSigner{signer: signer}, nil
+}
+
+// sshsigSignedData is what an SSHSIG signature covers, after the magic
+// preamble.
+type sshsigSignedData struct {
+	Namespace string
+	Reserved  string
+	HashAlg   string
+	Hash      []byte
+}
+
+// sshsigBlob is an SSHSIG signature, after the magic preamble.
+type sshsigBlob struct {
+	Version   uint32
+	PublicKey []byte
+	Namespace string
+	Reserved  string
+	HashAlg   string
+	Signature []byte
+}
+
+const sshsigMagic = "SSHSIG"
+
+func (s *sshSigner) Sign(message io.Reader) ([]byte, error) {
+	h := sha512.New()
+	if _, err := io.Copy(h, message); err != nil {
+		return nil, err
+	}
+	signed := append([]byte(sshsigMagic), ssh.Marshal(sshsigSignedData{
+		Namespace: sshsigNamespace,
+		HashAlg:   "sha512",
+		Hash:      h.Sum(nil),
+	})...)
+
+	// RSA keys sign with SHA-512; ssh-rsa (SHA-1) signatures are rejected.
+	var (
+		sig *ssh.Signature
+		err error
+	)
+	if as, ok := s.signer.(ssh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		sig, err = as.SignWithAlgorithm(rand.Reader, signed, ssh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = s.signer.Sign(rand.Reader, signed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blob := append([]byte(sshsigMagic), ssh.Marshal(sshsigBlob{
+		Version:   1,
+		PublicKey: s.signer.PublicKey().Marshal(),
+		Namespace: sshsigNamespace,
+		HashAlg:   "sha512",
+		Signature: ssh.Marshal(sig),
+	})...)
+
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	var buf bytes.Buffer
+	buf.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > 70 {
+		buf.WriteString(encoded[:70] + "\n")
+		encoded = encoded[70:]
+	}
+	buf.WriteString(encoded + "\n-----END SSH SIGNATURE-----\n")
+	return buf.Bytes(), nil
+}
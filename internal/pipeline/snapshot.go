@@ -0,0 +1,141 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/cache"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+)
+
+// SnapshotVersion is the format version written by WriteSnapshot.
+const SnapshotVersion = 1
+
+// Snapshot is a frozen set of changesets, saved by `diff --save` so it can
+// be reviewed offline and later applied exactly as reviewed.
+type Snapshot struct {
+	Version        int              `json:"version"`
+	CreatedAt      time.Time        `json:"created_at"`
+	CatalogVersion string           `json:"catalog_version"` // catalog the diff ran against
+	ChangeSets     []diff.ChangeSet `json:"changesets"`
+}
+
+// Snapshot freezes the changesets that have changes, recording the version
+// of the loaded catalog.
+func (p *Pipeline) Snapshot(changesets []diff.ChangeSet) *Snapshot {
+	snap := &Snapshot{Version: SnapshotVersion, CreatedAt: time.Now().UTC()}
+	if p.catalog != nil {
+		snap.CatalogVersion = p.catalog.Version
+	}
+	for _, cs := range changesets {
+		if cs.HasChanges() {
+			snap.ChangeSets = append(snap.ChangeSets, cs)
+		}
+	}
+	return snap
+}
+
+// WriteSnapshot saves a snapshot as indented JSON.
+func WriteSnapshot(path string, snap *Snapshot) error {
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding changeset snapshot: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ReadSnapshot loads a snapshot written by WriteSnapshot.
+func ReadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseSnapshot(data, path)
+}
+
+// CacheSnapshotPrefix marks a snapshot location as a name in the cache
+// backend rather than a file, as in "cache:weekly-sync". On a shared
+// backend a changeset saved by one CI runner can be applied by another.
+const CacheSnapshotPrefix = "cache:"
+
+// snapshotCacheKey keeps snapshot names apart from cached URLs.
+func snapshotCacheKey(name string) string {
+	return "sentinel:snapshot:" + name
+}
+
+// SaveSnapshot stores a snapshot in a cache backend under name.
+func SaveSnapshot(c cache.Cache, name string, snap *Snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("encoding changeset snapshot: %w", err)
+	}
+	return c.Set(snapshotCacheKey(name), &cache.Entry{Body: data, StatusCode: 200})
+}
+
+// LoadSnapshot loads a snapshot stored with SaveSnapshot. Snapshots don't
+// go stale with the cache TTL; they last as long as the backend keeps them.
+func LoadSnapshot(c cache.Cache, name string) (*Snapshot, error) {
+	entry, _ := c.Get(snapshotCacheKey(name))
+	if entry == nil {
+		return nil, fmt.Errorf("changeset snapshot %q not found in the cache", name)
+	}
+	return parseSnapshot(entry.Body, CacheSnapshotPrefix+name)
+}
+
+func parseSnapshot(data []byte, path string) (*Snapshot, error) {
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parsing changeset snapshot %s: %w", path, err)
+	}
+	if snap.Version != SnapshotVersion {
+		return nil, fmt.Errorf("changeset snapshot %s has version %d, want %d", path, snap.Version, SnapshotVersion)
+	}
+	return &snap, nil
+}
+
+// Apply writes a saved snapshot without running discovery. Each changeset
+// is first reconciled against the current catalog; a provider whose models
+// changed since the snapshot was taken fails instead of being applied.
+// Everything after discovery runs as in Sync, except the judge.
+func (p *Pipeline) Apply(ctx context.Context, snap *Snapshot) ([]SyncResult, error) {
+	if err := p.ensureCatalog(ctx); err != nil {
+		return nil, err
+	}
+	if err := p.prepareWrite(); err != nil {
+		return nil, err
+	}
+	if err := p.LoadCatalog(); err != nil {
+		return nil, err
+	}
+	defer p.closeDatabase()
+
+	if snap.CatalogVersion != p.catalog.Version {
+		slog.Warn("catalog version changed since the changeset was saved",
+			"saved", snap.CatalogVersion, "current", p.catalog.Version)
+	}
+
+	var results []SyncResult
+	for i := range snap.ChangeSets {
+		cs := &snap.ChangeSets[i]
+		if result, ok := notStarted(ctx, cs.Provider); ok {
+			results = append(results, result)
+			continue
+		}
+		existing := make(map[string]*catalog.Model)
+		if pc, ok := p.catalog.Providers[cs.Provider]; ok {
+			existing = pc.Models
+		}
+		if err := diff.Reconcile(cs, existing, p.DiffOptions()); err != nil {
+			results = append(results, SyncResult{Provider: cs.Provider, ChangeSet: cs, Error: err})
+			continue
+		}
+		results = append(results, p.applyChangeSet(ctx, cs, nil, false))
+	}
+	p.openAggregatePR(ctx, results)
+	return results, nil
+}
@@ -0,0 +1,127 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/cache"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/diff"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplySavedSnapshot(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "version.txt"), "1.0.0\n")
+	writeFile(t, filepath.Join(root, "providers", "openai", "provider.yaml"), "name: openai\n")
+
+	cfg := &config.Config{CatalogPath: root, Sources: []string{"api"}}
+	p := New(cfg)
+	if err := p.LoadCatalog(); err != nil {
+		t.Fatal(err)
+	}
+	gpt5 := &catalog.Model{
+		Name: "gpt-5", DisplayName: "GPT-5", Family: "gpt-5", Status: "stable",
+		Limits:       catalog.Limits{MaxTokens: 400000},
+		Capabilities: []string{"chat"},
+		Modalities:   catalog.Modalities{Input: []string{"text"}, Output: []string{"text"}},
+	}
+	snap := p.Snapshot([]diff.ChangeSet{
+		{Provider: "openai", New: []diff.ModelChange{{Name: "gpt-5", Model: gpt5}}},
+		{Provider: "groq"}, // no changes: not saved
+	})
+
+	path := filepath.Join(t.TempDir(), "changeset.json")
+	if err := WriteSnapshot(path, snap); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.ChangeSets) != 1 || loaded.CatalogVersion != "1.0.0" {
+		t.Fatalf("snapshot = %+v", loaded)
+	}
+
+	// A run past its deadline reports the changeset as not started.
+	expired, cancel := context.WithCancelCause(context.Background())
+	cancel(ErrDeadline)
+	results, err := New(cfg).Apply(expired, loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || !errors.Is(results[0].Error, ErrDeadline) {
+		t.Fatalf("results past the deadline = %+v", results)
+	}
+
+	results, err = New(cfg).Apply(context.Background(), loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Error != nil {
+		t.Fatalf("results = %+v", results)
+	}
+	cat, err := catalog.Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cat.Providers["openai"].Models["gpt-5"]; !ok || cat.Version != "1.1.0" {
+		t.Errorf("after apply: version %s, models %v", cat.Version, cat.Providers["openai"].Models)
+	}
+
+	// Applying the same snapshot again must fail: gpt-5 now exists.
+	again, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err = New(cfg).Apply(context.Background(), again)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Error == nil || !strings.Contains(results[0].Error.Error(), "already in the catalog") {
+		t.Errorf("second apply error = %v, want drift", results[0].Error)
+	}
+}
+
+func TestSnapshotInCache(t *testing.T) {
+	c, err := cache.New(t.TempDir(), time.Nanosecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snap := &Snapshot{
+		Version:        SnapshotVersion,
+		CatalogVersion: "1.0.0",
+		ChangeSets:     []diff.ChangeSet{{Provider: "openai", New: []diff.ModelChange{{Name: "gpt-5"}}}},
+	}
+	if err := SaveSnapshot(c, "nightly", snap); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+
+	got, err := LoadSnapshot(c, "nightly")
+	if err != nil {
+		t.Fatalf("a snapshot past the cache TTL should still load: %v", err)
+	}
+	if got.CatalogVersion != "1.0.0" || len(got.ChangeSets) != 1 || got.ChangeSets[0].New[0].Name != "gpt-5" {
+		t.Errorf("loaded snapshot = %+v", got)
+	}
+
+	if _, err := LoadSnapshot(c, "weekly"); err == nil {
+		t.Error("expected an error for a missing snapshot")
+	}
+}
@@ -0,0 +1,120 @@
+package pipeline
+
+import (
+	"sort"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/judge"
+)
+
+// Ways to split an oversized changeset.
+const (
+	SplitByFamily = "family" // keep a family's models in one PR where it fits
+	SplitByChunk  = "chunk"  // fixed-size chunks in changeset order
+)
+
+// splitChangeSet breaks a changeset with more than max new and updated
+// models into parts of at most max each, to be opened as a chain of PRs.
+// Deprecation candidates and renames go to the last part, which is
+// reviewed once everything else is in. A max of 0 or less disables
+// splitting.
+func splitChangeSet(cs *diff.ChangeSet, max int, by string) []*diff.ChangeSet {
+	if max <= 0 || cs.TotalChanged() <= max {
+		return []*diff.ChangeSet{cs}
+	}
+
+	type item struct {
+		family string
+		isNew  bool
+		change diff.ModelChange
+		update diff.ModelUpdate
+	}
+	items := make([]item, 0, cs.TotalChanged())
+	for _, m := range cs.New {
+		items = append(items, item{family: familyOf(m.Model, m.Name), isNew: true, change: m})
+	}
+	for _, u := range cs.Updated {
+		items = append(items, item{family: familyOf(u.Model, u.Name), update: u})
+	}
+
+	// Group into units that should stay together, then pack units into parts.
+	var units [][]item
+	if by != SplitByChunk {
+		byFamily := make(map[string][]item)
+		var families []string
+		for _, it := range items {
+			if _, ok := byFamily[it.family]; !ok {
+				families = append(families, it.family)
+			}
+			byFamily[it.family] = append(byFamily[it.family], it)
+		}
+		sort.Strings(families)
+		for _, f := range families {
+			group := byFamily[f]
+			for len(group) > max {
+				units = append(units, group[:max])
+				group = group[max:]
+			}
+			units = append(units, group)
+		}
+	} else {
+		for i := 0; i < len(items); i += max {
+			units = append(units, items[i:min(i+max, len(items))])
+		}
+	}
+
+	var parts []*diff.ChangeSet
+	cur := &diff.ChangeSet{Provider: cs.Provider, Unchanged: cs.Unchanged, Warnings: cs.Warnings}
+	for _, unit := range units {
+		if cur.TotalChanged() > 0 && cur.TotalChanged()+len(unit) > max {
+			parts = append(parts, cur)
+			cur = &diff.ChangeSet{Provider: cs.Provider, Unchanged: cs.Unchanged}
+		}
+		for _, it := range unit {
+			if it.isNew {
+				cur.New = append(cur.New, it.change)
+			} else {
+				cur.Updated = append(cur.Updated, it.update)
+			}
+		}
+	}
+	cur.DeprecationCandidates = cs.DeprecationCandidates
+	cur.Renamed = cs.Renamed
+	cur.PossibleRenames = cs.PossibleRenames
+	cur.RemovedFamilies = cs.RemovedFamilies
+	return append(parts, cur)
+}
+
+// familyOf groups models without a family on their own.
+func familyOf(m *catalog.Model, name string) string {
+	if m != nil && m.Family != "" {
+		return m.Family
+	}
+	return name
+}
+
+// judgeFor narrows a judge result to the models in one part of a split
+// changeset, so each PR shows only the verdicts it can act on.
+func judgeFor(result *judge.Result, cs *diff.ChangeSet) *judge.Result {
+	if result == nil {
+		return nil
+	}
+	inPart := make(map[string]bool)
+	for _, m := range cs.New {
+		inPart[m.Name] = true
+	}
+	for _, u := range cs.Updated {
+		inPart[u.Name] = true
+	}
+	for _, m := range cs.DeprecationCandidates {
+		inPart[m.Name] = true
+	}
+	out := &judge.Result{Capped: result.Capped}
+	for _, v := range result.Verdicts {
+		if inPart[v.ModelName] {
+			out.Verdicts = append(out.Verdicts, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+)
+
+func splitFixture() *diff.ChangeSet {
+	cs := &diff.ChangeSet{Provider: "openai"}
+	for _, fam := range []struct {
+		family string
+		n      int
+	}{{"gpt-4o", 3}, {"o3", 2}, {"gpt-4.1", 3}} {
+		for i := 0; i < fam.n; i++ {
+			name := fmt.Sprintf("%s-%d", fam.family, i)
+			cs.New = append(cs.New, diff.ModelChange{Name: name, Model: &catalog.Model{Name: name, Family: fam.family}})
+		}
+	}
+	cs.DeprecationCandidates = []diff.ModelChange{{Name: "gpt-3.5-turbo"}}
+	return cs
+}
+
+func TestSplitChangeSet_NoSplitUnderMax(t *testing.T) {
+	cs := splitFixture()
+	for _, max := range []int{0, 8, 100} {
+		if parts := splitChangeSet(cs, max, SplitByFamily); len(parts) != 1 || parts[0] != cs {
+			t.Errorf("max %d: got %d parts, want the changeset unchanged", max, len(parts))
+		}
+	}
+}
+
+func TestSplitChangeSet_ByFamily(t *testing.T) {
+	parts := splitChangeSet(splitFixture(), 5, SplitByFamily)
+	if len(parts) != 2 {
+		t.Fatalf("got %d parts, want 2", len(parts))
+	}
+
+	seen := make(map[string]int)
+	for i, part := range parts {
+		if n := part.TotalChanged(); n > 5 {
+			t.Errorf("part %d has %d models, max is 5", i+1, n)
+		}
+		for _, m := range part.New {
+			if prev, ok := seen[m.Model.Family]; ok && prev != i {
+				t.Errorf("family %s is split across parts %d and %d", m.Model.Family, prev+1, i+1)
+			}
+			seen[m.Model.Family] = i
+		}
+	}
+	if len(parts[0].DeprecationCandidates) != 0 || len(parts[1].DeprecationCandidates) != 1 {
+		t.Error("deprecation candidates should be in the last part only")
+	}
+}
+
+func TestSplitChangeSet_ByChunk(t *testing.T) {
+	parts := splitChangeSet(splitFixture(), 3, SplitByChunk)
+	want := []int{3, 3, 2}
+	if len(parts) != len(want) {
+		t.Fatalf("got %d parts, want %d", len(parts), len(want))
+	}
+	for i, part := range parts {
+		if n := part.TotalChanged(); n != want[i] {
+			t.Errorf("part %d has %d models, want %d", i+1, n, want[i])
+		}
+	}
+}
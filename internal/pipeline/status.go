@@ -0,0 +1,227 @@
+package pipeline
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Run outcomes recorded in the run history.
+const (
+	RunOK       = "ok"        // discovery succeeded, whatever happened to the changeset
+	RunFailed   = "failed"    // the health checks or discovery failed
+	RunNoAccess = "no access" // the account lacks entitlement (HTTP 402/403)
+)
+
+// RunRecord is one provider's outcome of one sync, as appended to the run
+// history (health.history). It records how the provider's sources did, not
+// whether a PR was opened.
+type RunRecord struct {
+	Time     time.Time `json:"time"`
+	Provider string    `json:"provider"`
+	Status   string    `json:"status"`
+	Models   int       `json:"models,omitempty"` // discovered; 0 when discovery didn't finish
+	Error    string    `json:"error,omitempty"`
+}
+
+// runRecord derives the history record of a sync result. Providers the run
+// never started, cut short or skipped for an incident have none: that says nothing about the source.
+func runRecord(r SyncResult, now time.Time) (RunRecord, bool) {
+	rec := RunRecord{Time: now.UTC(), Provider: r.Provider, Status: RunOK, Models: r.Discovered}
+	switch {
+	case r.Entitlement != nil:
+		rec.Status, rec.Error = RunNoAccess, r.Entitlement.Error()
+	case r.Incident != nil:
+		// Skipped for a declared incident: the sources weren't tried.
+		return rec, false
+	case r.ChangeSet == nil && r.Error != nil:
+		if errors.Is(r.Error, ErrDeadline) || errors.Is(r.Error, ErrFailFast) || errors.Is(r.Error, context.Canceled) || errors.Is(r.Error, context.DeadlineExceeded) {
+			return rec, false
+		}
+		rec.Status, rec.Error = RunFailed, r.Error.Error()
+	}
+	return rec, true
+}
+
+// recordRuns appends the results of a sync to the run history. A history
+// that can't be written only costs the status page a data point.
+func (p *Pipeline) recordRuns(results []SyncResult) {
+	path := p.cfg.Health.History
+	if path == "" {
+		return
+	}
+	var b strings.Builder
+	now := time.Now()
+	for _, r := range results {
+		rec, ok := runRecord(r, now)
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(rec)
+		if err != nil {
+			continue
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	if err := appendFile(path, b.String()); err != nil {
+		slog.Warn("recording run history failed", "path", path, "error", err)
+	}
+}
+
+func appendFile(path, data string) error {
+	if data == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadRunHistory returns the records in the run history at path, oldest
+// first. A missing history has no records.
+func ReadRunHistory(path string) ([]RunRecord, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []RunRecord
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var rec RunRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// StatusPage summarizes each provider's recent runs for a status page. It
+// leaves out error messages, which can name accounts or endpoints; those
+// stay in the run history.
+type StatusPage struct {
+	Generated time.Time        `json:"generated"`
+	Runs      int              `json:"runs"` // the window: at most this many runs per provider
+	Providers []ProviderStatus `json:"providers"`
+}
+
+// ProviderStatus is one provider's row on the status page.
+type ProviderStatus struct {
+	Provider    string     `json:"provider"`
+	Runs        int        `json:"runs"`
+	Successes   int        `json:"successes"`
+	SuccessRate float64    `json:"success_rate"`
+	LastStatus  string     `json:"last_status"`
+	LastRun     time.Time  `json:"last_run"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	// Quarantined is set when the provider failed its last quarantine_after
+	// runs in a row.
+	Quarantined bool `json:"quarantined"`
+}
+
+// BuildStatusPage summarizes the last runs records of every provider in
+// records, sorted by provider. A provider is quarantined once its last
+// quarantineAfter runs all failed; zero disables quarantine. Last success
+// looks at the whole history, not just the window.
+func BuildStatusPage(records []RunRecord, runs, quarantineAfter int, now time.Time) *StatusPage {
+	byProvider := make(map[string][]RunRecord)
+	for _, rec := range records {
+		byProvider[rec.Provider] = append(byProvider[rec.Provider], rec)
+	}
+
+	page := &StatusPage{Generated: now.UTC(), Runs: runs, Providers: []ProviderStatus{}}
+	for provider, all := range byProvider {
+		sort.SliceStable(all, func(i, k int) bool { return all[i].Time.Before(all[k].Time) })
+		window := all
+		if runs > 0 && len(window) > runs {
+			window = window[len(window)-runs:]
+		}
+
+		last := all[len(all)-1]
+		s := ProviderStatus{
+			Provider:   provider,
+			Runs:       len(window),
+			LastStatus: last.Status,
+			LastRun:    last.Time,
+		}
+		for _, rec := range window {
+			if rec.Status == RunOK {
+				s.Successes++
+			}
+		}
+		s.SuccessRate = float64(s.Successes) / float64(s.Runs)
+
+		failing := 0
+		for i := len(all) - 1; i >= 0; i-- {
+			if all[i].Status == RunOK {
+				t := all[i].Time
+				s.LastSuccess = &t
+				break
+			}
+			failing++
+		}
+		s.Quarantined = quarantineAfter > 0 && failing >= quarantineAfter
+		page.Providers = append(page.Providers, s)
+	}
+	sort.Slice(page.Providers, func(i, k int) bool { return page.Providers[i].Provider < page.Providers[k].Provider })
+	return page
+}
+
+// Markdown renders the status page as a Markdown table.
+func (s *StatusPage) Markdown() string {
+	var b strings.Builder
+	b.WriteString("## Provider status\n\n")
+	fmt.Fprintf(&b, "Last %d runs per provider, generated %s.\n\n", s.Runs, s.Generated.Format(time.RFC3339))
+	if len(s.Providers) == 0 {
+		b.WriteString("No runs recorded.\n")
+		return b.String()
+	}
+	b.WriteString("| Provider | Status | Success rate | Last success | Last run |\n")
+	b.WriteString("|---|---|---:|---|---|\n")
+	for _, p := range s.Providers {
+		status := ":white_check_mark: " + p.LastStatus
+		switch {
+		case p.Quarantined:
+			status = ":no_entry: quarantined"
+		case p.LastStatus != RunOK:
+			status = ":warning: " + p.LastStatus
+		}
+		lastSuccess := "never"
+		if p.LastSuccess != nil {
+			lastSuccess = p.LastSuccess.Format(time.RFC3339)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %.0f%% (%d/%d) | %s | %s |\n", p.Provider, status,
+			p.SuccessRate*100, p.Successes, p.Runs, lastSuccess, p.LastRun.Format(time.RFC3339))
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/diff"
+)
+
+func TestRecordRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "runs.jsonl")
+	p := New(&config.Config{Health: config.HealthConfig{History: path}})
+
+	p.recordRuns([]SyncResult{
+		{Provider: "openai", ChangeSet: &diff.ChangeSet{Provider: "openai"}, Error: errors.New("push failed")},
+		{Provider: "mistral", Error: errors.New("discovering models: 500")},
+		{Provider: "cohere", Skipped: true, Entitlement: &EntitlementError{Provider: "cohere", StatusCode: 403}},
+		{Provider: "groq", Error: fmt.Errorf("not started: %w", ErrDeadline)},
+		{Provider: "xai", Error: fmt.Errorf("discovering models: %w", context.Canceled)},
+		stoppedEarly("venice"),
+	})
+
+	records, err := ReadRunHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, rec := range records {
+		got = append(got, rec.Provider+"="+rec.Status)
+	}
+	want := "openai=ok mistral=failed cohere=no access"
+	if strings.Join(got, " ") != want {
+		t.Errorf("recorded %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+func TestBuildStatusPage(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	var records []RunRecord
+	add := func(provider string, statuses ...string) {
+		for i, s := range statuses {
+			records = append(records, RunRecord{Time: start.Add(time.Duration(i) * time.Hour), Provider: provider, Status: s})
+		}
+	}
+	add("openai", RunOK, RunFailed, RunOK, RunOK)
+	add("mistral", RunOK, RunFailed, RunFailed, RunFailed)
+	add("cohere", RunNoAccess, RunNoAccess)
+
+	page := BuildStatusPage(records, 3, 3, start.Add(24*time.Hour))
+
+	tests := []struct {
+		provider    string
+		runs        int
+		successes   int
+		lastSuccess time.Duration // hours after start; -1 for never
+		quarantined bool
+	}{
+		{"cohere", 2, 0, -1, false},
+		{"mistral", 3, 0, 0, true},
+		{"openai", 3, 2, 3 * time.Hour, false},
+	}
+	if len(page.Providers) != len(tests) {
+		t.Fatalf("got %d providers, want %d", len(page.Providers), len(tests))
+	}
+	for i, tt := range tests {
+		s := page.Providers[i]
+		if s.Provider != tt.provider {
+			t.Fatalf("provider %d is %s, want %s", i, s.Provider, tt.provider)
+		}
+		if s.Runs != tt.runs || s.Successes != tt.successes || s.Quarantined != tt.quarantined {
+			t.Errorf("%s: got runs=%d successes=%d quarantined=%v, want %d %d %v",
+				s.Provider, s.Runs, s.Successes, s.Quarantined, tt.runs, tt.successes, tt.quarantined)
+		}
+		switch {
+		case tt.lastSuccess < 0 && s.LastSuccess != nil:
+			t.Errorf("%s: last success %v, want never", s.Provider, s.LastSuccess)
+		case tt.lastSuccess >= 0 && (s.LastSuccess == nil || !s.LastSuccess.Equal(start.Add(tt.lastSuccess))):
+			t.Errorf("%s: last success %v, want %v", s.Provider, s.LastSuccess, start.Add(tt.lastSuccess))
+		}
+	}
+
+	md := page.Markdown()
+	for _, want := range []string{"| mistral | :no_entry: quarantined | 0% (0/3) |", "| openai | :white_check_mark: ok | 67% (2/3) |", "| never |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
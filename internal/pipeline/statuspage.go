@@ -0,0 +1,80 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+)
+
+// What a sync does when a provider's status page declares an incident
+// (health.on_incident).
+const (
+	OnIncidentAnnotate = "annotate" // sync anyway; a short model count doesn't fail the run
+	OnIncidentSkip     = "skip"     // leave the provider for the next run
+)
+
+// Incident is a disruption a provider has declared on its status page.
+type Incident struct {
+	Indicator   string `json:"indicator"` // minor, major or critical
+	Description string `json:"description"`
+	Page        string `json:"page"`
+}
+
+func (i *Incident) String() string {
+	return fmt.Sprintf("%s incident: %s (%s)", i.Indicator, i.Description, i.Page)
+}
+
+// IncidentError means the provider was skipped because its status page
+// declares an incident and health.on_incident is "skip".
+type IncidentError struct {
+	Provider string
+	Incident *Incident
+}
+
+func (e *IncidentError) Error() string {
+	return fmt.Sprintf("%s reports a %s", e.Provider, e.Incident)
+}
+
+// WithStatusPageClient fetches status pages through c instead of the
+// pipeline's own uncached client.
+func WithStatusPageClient(c *httpclient.Client) Option {
+	return func(p *Pipeline) { p.statusClient = c }
+}
+
+// statusSummary is the part of a statuspage.io /api/v2/status.json
+// response sentinel reads.
+type statusSummary struct {
+	Status struct {
+		Indicator   string `json:"indicator"`
+		Description string `json:"description"`
+	} `json:"status"`
+}
+
+// providerIncident reports the incident the provider's status page
+// declares, or nil when there is none, status pages are off or the adapter
+// names no page. A page that can't be read is only logged: it says nothing
+// about the provider's API.
+func (p *Pipeline) providerIncident(ctx context.Context, a adapter.Adapter) *Incident {
+	if !p.cfg.Health.StatusPages {
+		return nil
+	}
+	d, ok := a.(adapter.Describer)
+	if !ok || d.ProviderInfo().StatusPage == "" {
+		return nil
+	}
+	page := d.ProviderInfo().StatusPage
+	summary, err := httpclient.GetJSON[statusSummary](ctx, p.statusClient, strings.TrimSuffix(page, "/")+"/api/v2/status.json", nil)
+	if err != nil {
+		slog.Warn("reading status page failed", "provider", a.Name(), "page", page, "error", err)
+		return nil
+	}
+	switch summary.Status.Indicator {
+	case "", "none":
+		return nil
+	}
+	return &Incident{Indicator: summary.Status.Indicator, Description: summary.Status.Description, Page: page}
+}
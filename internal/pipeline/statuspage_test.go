@@ -0,0 +1,96 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/config"
+	"github.com/everstacklabs/sentinel/internal/httpclient"
+)
+
+// statusPageAdapter is a probedAdapter naming a status page.
+type statusPageAdapter struct {
+	probedAdapter
+	page string
+}
+
+func (a *statusPageAdapter) ProviderInfo() adapter.ProviderInfo {
+	return adapter.ProviderInfo{StatusPage: a.page}
+}
+
+func TestStatusPageIncidents(t *testing.T) {
+	indicator := map[string]string{"/calm": "none", "/outage": "major"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := strings.TrimSuffix(r.URL.Path, "/api/v2/status.json")
+		ind, ok := indicator[page]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"status":{"indicator":%q,"description":"Partial outage"}}`, ind)
+	}))
+	defer srv.Close()
+
+	// Each discovers 3 models against a threshold of 5.
+	adapter.Register(&statusPageAdapter{probedAdapter{healthAdapter{name: "incident-calm", models: 3, min: 10}}, srv.URL + "/calm"})
+	adapter.Register(&statusPageAdapter{probedAdapter{healthAdapter{name: "incident-outage", models: 3, min: 10}}, srv.URL + "/outage/"})
+	adapter.Register(&statusPageAdapter{probedAdapter{healthAdapter{name: "incident-nopage", models: 3, min: 10}}, srv.URL + "/missing"})
+
+	tests := []struct {
+		name         string
+		provider     string
+		statusPages  bool
+		onIncident   string
+		wantErr      string // "" for a changeset
+		wantIncident bool
+	}{
+		{"no incident fails the threshold", "incident-calm", true, OnIncidentAnnotate, "below threshold", false},
+		{"status pages off", "incident-outage", false, OnIncidentAnnotate, "below threshold", false},
+		{"unreadable page is ignored", "incident-nopage", true, OnIncidentAnnotate, "below threshold", false},
+		{"annotate waives the threshold", "incident-outage", true, OnIncidentAnnotate, "", true},
+		{"skip", "incident-outage", true, OnIncidentSkip, "reports a major incident", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Sources: []string{"api"},
+				Health:  config.HealthConfig{Enabled: true, Threshold: 0.5, StatusPages: tt.statusPages, OnIncident: tt.onIncident},
+			}
+			client := httpclient.New(httpclient.WithRateLimit(1000), httpclient.WithNoCache(), httpclient.WithMaxRetries(0))
+			p := New(cfg, WithStatusPageClient(client))
+			p.catalog = &catalog.Catalog{Providers: map[string]*catalog.ProviderCatalog{}}
+
+			cs, health, err := p.discoverAndDiff(context.Background(), tt.provider)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				var ie *IncidentError
+				if errors.As(err, &ie) != tt.wantIncident {
+					t.Errorf("IncidentError = %v, want %v", errors.As(err, &ie), tt.wantIncident)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(health.Incident, "major incident: Partial outage") {
+				t.Errorf("health.Incident = %q, want the outage", health.Incident)
+			}
+			waived := false
+			for _, w := range cs.Warnings {
+				waived = waived || strings.Contains(w.Message, "below threshold")
+			}
+			if len(cs.New) != 3 || !waived {
+				t.Errorf("new = %d, warnings = %+v; want 3 models and the waived threshold", len(cs.New), cs.Warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+)
+
+// DiscoveryTimeoutError means a provider's discovery ran past its
+// discovery_timeout. The run goes on with the other providers.
+type DiscoveryTimeoutError struct {
+	Provider string
+	Timeout  time.Duration
+}
+
+func (e *DiscoveryTimeoutError) Error() string {
+	return fmt.Sprintf("discovery for %s timed out after %s", e.Provider, e.Timeout)
+}
+
+// errDiscoveryTimeout is the cancellation cause of a discovery that ran
+// past its timeout, telling it apart from the run's own deadline.
+var errDiscoveryTimeout = errors.New("discovery timeout")
+
+// discover runs a's discovery within the provider's discovery_timeout.
+func (p *Pipeline) discover(ctx context.Context, a adapter.Adapter, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
+	timeout := p.cfg.DiscoveryTimeoutFor(a.Name())
+	if timeout == 0 {
+		return a.Discover(ctx, opts)
+	}
+	dctx, cancel := context.WithTimeoutCause(ctx, timeout, errDiscoveryTimeout)
+	defer cancel()
+	models, err := a.Discover(dctx, opts)
+	if err != nil && ctx.Err() == nil && errors.Is(context.Cause(dctx), errDiscoveryTimeout) {
+		return nil, &DiscoveryTimeoutError{Provider: a.Name(), Timeout: timeout}
+	}
+	return models, err
+}
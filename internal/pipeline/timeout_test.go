@@ -0,0 +1,49 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/config"
+)
+
+// slowAdapter discovers nothing until its context is done.
+type slowAdapter struct{ healthAdapter }
+
+func (a *slowAdapter) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestDiscoveryTimeout(t *testing.T) {
+	adapter.Register(&slowAdapter{healthAdapter{name: "slow"}})
+
+	cfg := &config.Config{
+		Sources:           []string{"api"},
+		DiscoveryTimeout:  "1h",
+		DiscoveryTimeouts: map[string]string{"slow": "10ms"},
+	}
+	p := New(cfg)
+	p.catalog = &catalog.Catalog{Providers: map[string]*catalog.ProviderCatalog{}}
+
+	result := p.syncProvider(context.Background(), "slow")
+	var te *DiscoveryTimeoutError
+	if !errors.As(result.Error, &te) || te.Timeout != 10*time.Millisecond || !result.TimedOut {
+		t.Fatalf("result = %+v, want a discovery timeout after 10ms", result)
+	}
+	if got := outcome(result, false); got != OutcomeTimedOut {
+		t.Errorf("outcome = %q, want %q", got, OutcomeTimedOut)
+	}
+
+	// The run's own deadline is not the provider's timeout.
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(ErrDeadline)
+	result = p.syncProvider(ctx, "slow")
+	if result.TimedOut || !errors.Is(result.Error, context.Canceled) {
+		t.Errorf("cancelled run: result = %+v, want a cancellation", result)
+	}
+}
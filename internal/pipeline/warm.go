@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+)
+
+// CacheWarmth is the outcome of warming one provider's responses.
+type CacheWarmth struct {
+	Provider   string `json:"provider"`
+	Models     int    `json:"models"`
+	DurationMS int64  `json:"duration_ms"`
+	Skipped    bool   `json:"skipped,omitempty"` // the adapter has no API source
+	Error      string `json:"error,omitempty"`
+}
+
+// WarmCache fetches every configured provider's models endpoint
+// concurrently through the response cache, so a sync that follows answers
+// from the cache or revalidates with ETags instead of refetching in full.
+// Only API sources are fetched; docs pages and LLM extraction are left to
+// the sync. Results are in the order of the configured providers.
+func (p *Pipeline) WarmCache(ctx context.Context) []CacheWarmth {
+	results := make([]CacheWarmth, len(p.cfg.Providers))
+	var wg sync.WaitGroup
+	for i, name := range p.cfg.Providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = p.warmProvider(ctx, name)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func (p *Pipeline) warmProvider(ctx context.Context, name string) CacheWarmth {
+	w := CacheWarmth{Provider: name}
+	a, err := adapter.Get(name)
+	if err != nil {
+		w.Error = err.Error()
+		return w
+	}
+	if !slices.Contains(a.SupportedSources(), adapter.SourceAPI) {
+		w.Skipped = true
+		return w
+	}
+
+	start := time.Now()
+	models, err := p.discover(ctx, a, adapter.DiscoverOptions{
+		Sources:  []adapter.SourceType{adapter.SourceAPI},
+		CacheDir: p.cfg.CacheDir,
+	})
+	w.DurationMS = time.Since(start).Milliseconds()
+	if err != nil {
+		w.Error = err.Error()
+		return w
+	}
+	w.Models = len(models)
+	return w
+}
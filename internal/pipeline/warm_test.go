@@ -0,0 +1,76 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/config"
+)
+
+// warmAdapter records the sources it was asked to discover from.
+type warmAdapter struct {
+	healthAdapter
+	sources []adapter.SourceType
+	err     error
+
+	mu    sync.Mutex
+	asked []adapter.SourceType
+}
+
+func (a *warmAdapter) SupportedSources() []adapter.SourceType { return a.sources }
+
+func (a *warmAdapter) Discover(ctx context.Context, opts adapter.DiscoverOptions) ([]adapter.DiscoveredModel, error) {
+	a.mu.Lock()
+	a.asked = opts.Sources
+	a.mu.Unlock()
+	if a.err != nil {
+		return nil, a.err
+	}
+	return a.healthAdapter.Discover(ctx, opts)
+}
+
+func TestWarmCache(t *testing.T) {
+	both := []adapter.SourceType{adapter.SourceAPI, adapter.SourceDocs}
+	api := &warmAdapter{healthAdapter: healthAdapter{name: "warm-api", models: 4}, sources: both}
+	docs := &warmAdapter{healthAdapter: healthAdapter{name: "warm-docs", models: 4}, sources: []adapter.SourceType{adapter.SourceDocs}}
+	failing := &warmAdapter{healthAdapter: healthAdapter{name: "warm-fail"}, sources: both, err: errors.New("status 500")}
+	for _, a := range []adapter.Adapter{api, docs, failing} {
+		adapter.Register(a)
+	}
+
+	cfg := &config.Config{
+		Providers: []string{"warm-api", "warm-docs", "warm-fail", "warm-missing"},
+		Sources:   []string{"api", "docs"},
+	}
+	results := New(cfg).WarmCache(context.Background())
+
+	want := []struct {
+		models  int
+		skipped bool
+		failed  bool
+	}{
+		{4, false, false},
+		{0, true, false},
+		{0, false, true},
+		{0, false, true},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Provider != cfg.Providers[i] || r.Models != w.models || r.Skipped != w.skipped || (r.Error != "") != w.failed {
+			t.Errorf("result %d = %+v, want %s with models=%d skipped=%v failed=%v", i, r, cfg.Providers[i], w.models, w.skipped, w.failed)
+		}
+	}
+	if !slices.Equal(api.asked, []adapter.SourceType{adapter.SourceAPI}) {
+		t.Errorf("warm-api discovered from %v, want only the API", api.asked)
+	}
+	if docs.asked != nil {
+		t.Errorf("warm-docs discovered from %v, want it skipped", docs.asked)
+	}
+}
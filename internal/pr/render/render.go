@@ -0,0 +1,557 @@
+// Package render builds the Markdown body for catalog update pull requests.
+package render
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/judge"
+)
+
+// Input holds everything that goes into a PR body.
+type Input struct {
+	ChangeSet *diff.ChangeSet
+	Judge     *judge.Result // nil when the judge is disabled or failed
+	Health    *SourceHealth // nil when no discovery summary is available
+
+	// Capabilities indexes the catalog as it will be after merge. When set,
+	// capability changes get routing-impact context.
+	Capabilities catalog.CapabilityIndex
+	Branch       string
+	FromVersion  string
+	ToVersion    string
+	ModelExt     string // extension of the catalog's model files; .yaml when empty
+
+	// Split is set when the PR is one of a chain opened for a changeset
+	// too large to review at once.
+	Split *Split
+}
+
+// Split places a PR in a chain. Each part builds on the previous one's
+// branch, so they must be merged in order.
+type Split struct {
+	Part  int // 1-based
+	Total int
+	PRs   []int // PR number of each part; 0 where not opened yet
+}
+
+// SourceHealth summarizes the discovery run that produced the changeset.
+type SourceHealth struct {
+	Sources     []string
+	Discovered  int
+	Checked     bool    // false when health checks are disabled or unsupported by the adapter
+	MinExpected int     // adapter's declared minimum model count; 0 when none
+	Threshold   float64 // fraction of MinExpected required to pass
+	Incident    string  // the incident the provider's status page declared, if any
+}
+
+// Body renders the full PR body.
+func Body(in *Input) string {
+	cs := in.ChangeSet
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## Model Catalog Update: %s\n\n", cs.Provider)
+	fmt.Fprintf(&b, "**Summary**: %d new, %d updated, %d unchanged, %d deprecation candidates\n\n",
+		len(cs.New), len(cs.Updated), cs.Unchanged, len(cs.DeprecationCandidates))
+	if in.FromVersion != "" && in.ToVersion != "" {
+		fmt.Fprintf(&b, "**Catalog version**: `%s` → `%s`\n\n", in.FromVersion, in.ToVersion)
+	}
+	writeSplit(&b, in.Split)
+
+	writeChangeSections(&b, in)
+	writeRollback(&b, in.Branch, in.ModelExt, cs)
+
+	b.WriteString("---\n")
+	b.WriteString("*Generated by sentinel*\n")
+
+	return b.String()
+}
+
+// AggregateBody renders the body of a PR that batches several providers'
+// changesets, committed one provider per commit on a shared branch.
+func AggregateBody(inputs []*Input, branch string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## Model Catalog Update: %d providers\n\n", len(inputs))
+	if len(inputs) > 0 && inputs[0].FromVersion != "" && inputs[len(inputs)-1].ToVersion != "" {
+		fmt.Fprintf(&b, "**Catalog version**: `%s` → `%s`\n\n", inputs[0].FromVersion, inputs[len(inputs)-1].ToVersion)
+	}
+
+	b.WriteString("| Provider | New | Updated | Unchanged | Deprecation Candidates |\n")
+	b.WriteString("|----------|-----|---------|-----------|------------------------|\n")
+	sets := make([]*diff.ChangeSet, 0, len(inputs))
+	ext := ""
+	for _, in := range inputs {
+		ext = in.ModelExt // one catalog, so one format
+		cs := in.ChangeSet
+		fmt.Fprintf(&b, "| [%s](#%s) | %d | %d | %d | %d |\n", cs.Provider, cs.Provider,
+			len(cs.New), len(cs.Updated), cs.Unchanged, len(cs.DeprecationCandidates))
+		sets = append(sets, cs)
+	}
+	b.WriteString("\nEach provider is a separate commit. To leave one out, revert its " +
+		"`chore(catalog): update <provider> models` commit on this branch.\n\n")
+
+	for _, in := range inputs {
+		fmt.Fprintf(&b, "## %s\n\n", in.ChangeSet.Provider)
+		writeChangeSections(&b, in)
+	}
+	writeRollback(&b, branch, ext, sets...)
+
+	b.WriteString("---\n")
+	b.WriteString("*Generated by sentinel*\n")
+
+	return b.String()
+}
+
+// writeChangeSections renders the per-changeset sections, from new models
+// through source health.
+func writeChangeSections(b *strings.Builder, in *Input) {
+	cs := in.ChangeSet
+	writeRemovedFamilies(b, cs.RemovedFamilies)
+	writeNewModels(b, cs.New)
+	writeUpdatedModels(b, cs.Updated)
+	writeRenamed(b, cs.Renamed)
+	writeCapabilityImpact(b, cs, in.Capabilities)
+	writeDeprecations(b, cs.DeprecationCandidates)
+	writeRenames(b, cs.PossibleRenames)
+	writeWarnings(b, cs.Warnings)
+
+	if section := judge.RenderSection(in.Judge); section != "" {
+		b.WriteString(section)
+	}
+
+	writeHealth(b, in.Health)
+}
+
+func writeSplit(b *strings.Builder, s *Split) {
+	if s == nil {
+		return
+	}
+	steps := make([]string, s.Total)
+	for i := range steps {
+		switch {
+		case i < len(s.PRs) && s.PRs[i] != 0:
+			steps[i] = fmt.Sprintf("#%d", s.PRs[i])
+		default:
+			steps[i] = fmt.Sprintf("part %d", i+1)
+		}
+		if i+1 == s.Part {
+			steps[i] = "**" + steps[i] + " (this PR)**"
+		}
+	}
+	fmt.Fprintf(b, "> **Part %d of %d.** This update was split to keep reviews manageable. "+
+		"Each part builds on the previous one; merge in order: %s\n\n", s.Part, s.Total, strings.Join(steps, " → "))
+}
+
+func writeNewModels(b *strings.Builder, models []diff.ModelChange) {
+	if len(models) == 0 {
+		return
+	}
+	b.WriteString("### New Models\n\n")
+	b.WriteString("| Model | Family | Status | Context Window | Capabilities | Input / 1K | Output / 1K |\n")
+	b.WriteString("|-------|--------|--------|----------------|--------------|------------|-------------|\n")
+	for _, m := range models {
+		in, out := formatCost(m.Model.Cost)
+		fmt.Fprintf(b, "| `%s` | %s | %s | %s | %s | %s | %s |\n",
+			m.Name,
+			cell(m.Model.Family),
+			cell(m.Model.Status),
+			formatInt(m.Model.Limits.MaxTokens),
+			cell(strings.Join(m.Model.Capabilities, ", ")),
+			in, out)
+	}
+	b.WriteString("\n")
+}
+
+func writeUpdatedModels(b *strings.Builder, updates []diff.ModelUpdate) {
+	if len(updates) == 0 {
+		return
+	}
+	b.WriteString("### Updated Models\n\n")
+	for _, u := range updates {
+		fmt.Fprintf(b, "#### `%s`\n\n", u.Name)
+		b.WriteString("| Field | Old | New | Change |\n")
+		b.WriteString("|-------|-----|-----|--------|\n")
+		for _, c := range u.Changes {
+			fmt.Fprintf(b, "| `%s` | %s | %s | %s |\n",
+				c.Field, formatValue(c.OldValue), formatValue(c.NewValue), formatDelta(c.OldValue, c.NewValue))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// maxImpactNames caps how many matching models are listed per capability.
+const maxImpactNames = 5
+
+// writeCapabilityImpact lists, for every capability gained or lost by an
+// updated model, which other models from the provider match that capability.
+func writeCapabilityImpact(b *strings.Builder, cs *diff.ChangeSet, idx catalog.CapabilityIndex) {
+	if idx == nil {
+		return
+	}
+
+	var lines []string
+	for _, u := range cs.Updated {
+		for _, c := range u.Changes {
+			if c.Field != "capabilities" {
+				continue
+			}
+			oldCaps, _ := c.OldValue.([]string)
+			newCaps, _ := c.NewValue.([]string)
+			for _, capability := range subtract(newCaps, oldCaps) {
+				others := without(idx.Models(cs.Provider, capability), u.Name)
+				lines = append(lines, fmt.Sprintf("- `%s` gains `%s`: now matches a `%s` filter alongside %s",
+					u.Name, capability, capability, describeOthers(others, cs.Provider)))
+			}
+			for _, capability := range subtract(oldCaps, newCaps) {
+				others := without(idx.Models(cs.Provider, capability), u.Name)
+				if len(others) == 0 {
+					lines = append(lines, fmt.Sprintf("- `%s` loses `%s`: **no other `%s` models remain from %s**",
+						u.Name, capability, capability, cs.Provider))
+					continue
+				}
+				lines = append(lines, fmt.Sprintf("- `%s` loses `%s`: %s remain",
+					u.Name, capability, describeOthers(others, cs.Provider)))
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	b.WriteString("### Capability Impact\n\n")
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n\n")
+}
+
+// describeOthers renders e.g. "3 other openai models (`a`, `b`, `c`)".
+func describeOthers(names []string, provider string) string {
+	if len(names) == 0 {
+		return fmt.Sprintf("no other %s models", provider)
+	}
+	noun := "models"
+	if len(names) == 1 {
+		noun = "model"
+	}
+	shown := names
+	if len(shown) > maxImpactNames {
+		shown = shown[:maxImpactNames]
+	}
+	quoted := make([]string, len(shown))
+	for i, n := range shown {
+		quoted[i] = "`" + n + "`"
+	}
+	list := strings.Join(quoted, ", ")
+	if extra := len(names) - len(shown); extra > 0 {
+		list += fmt.Sprintf(", and %d more", extra)
+	}
+	return fmt.Sprintf("%d other %s %s (%s)", len(names), provider, noun, list)
+}
+
+// subtract returns the values in a that are not in b.
+func subtract(a, b []string) []string {
+	var out []string
+	for _, v := range a {
+		if !contains(b, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func without(names []string, exclude string) []string {
+	out := make([]string, 0, len(names))
+	for _, n := range names {
+		if n != exclude {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func writeRemovedFamilies(b *strings.Builder, families []diff.FamilyRemoval) {
+	if len(families) == 0 {
+		return
+	}
+	b.WriteString("> [!CAUTION]\n")
+	b.WriteString("> **Model families removed.** The provider still lists other models but not a single one of ")
+	b.WriteString("these families. This is either a major retirement by the provider ")
+	b.WriteString("or an adapter that stopped parsing part of its source; confirm which before merging.\n>\n")
+	for _, f := range families {
+		fmt.Fprintf(b, "> - **%s**: %s\n", f.Family, "`"+strings.Join(f.Models, "`, `")+"`")
+	}
+	b.WriteString("\n")
+}
+
+// FamilyRemovalIssue renders the title and body of the alert issue opened
+// when a provider drops whole model families. pr is the catalog PR to hold
+// back, or 0 when there is none of its own.
+func FamilyRemovalIssue(cs *diff.ChangeSet, health *SourceHealth, pr int) (string, string) {
+	families := make([]string, len(cs.RemovedFamilies))
+	for i, f := range cs.RemovedFamilies {
+		families[i] = f.Family
+	}
+	title := fmt.Sprintf("sentinel: %s no longer lists the %s model families", cs.Provider, strings.Join(families, ", "))
+	if len(families) == 1 {
+		title = fmt.Sprintf("sentinel: %s no longer lists the %s model family", cs.Provider, families[0])
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Model families removed: %s\n\n", cs.Provider)
+	writeRemovedFamilies(&b, cs.RemovedFamilies)
+	if pr != 0 {
+		fmt.Fprintf(&b, "The catalog changes are in #%d; hold it until this is resolved.\n\n", pr)
+	}
+	writeHealth(&b, health)
+	b.WriteString("---\n")
+	b.WriteString("*Generated by sentinel*\n")
+	return title, b.String()
+}
+
+func writeDeprecations(b *strings.Builder, models []diff.ModelChange) {
+	if len(models) == 0 {
+		return
+	}
+	b.WriteString("### Deprecation Candidates\n\n")
+	b.WriteString("These models exist in the catalog but were not found by the provider API. ")
+	b.WriteString("They may have been renamed, deprecated, or temporarily unavailable. ")
+	b.WriteString("Their files are not modified by this PR.\n\n")
+	b.WriteString("| Model | Family | Status |\n")
+	b.WriteString("|-------|--------|--------|\n")
+	for _, m := range models {
+		fmt.Fprintf(b, "| `%s` | %s | %s |\n", m.Name, cell(m.Model.Family), cell(m.Model.Status))
+	}
+	b.WriteString("\n")
+}
+
+func writeRenamed(b *strings.Builder, renames []diff.ModelRename) {
+	if len(renames) == 0 {
+		return
+	}
+	b.WriteString("### Renamed Models\n\n")
+	b.WriteString("Each file moves to the new name with the old one kept as an alias. ")
+	b.WriteString("The old file stays, marked deprecated.\n\n")
+	b.WriteString("| Old Name | New Name |\n")
+	b.WriteString("|----------|----------|\n")
+	for _, r := range renames {
+		fmt.Fprintf(b, "| `%s` | `%s` |\n", r.OldName, r.Name)
+	}
+	b.WriteString("\n")
+}
+
+func writeRenames(b *strings.Builder, renames []diff.RenamePair) {
+	if len(renames) == 0 {
+		return
+	}
+	b.WriteString("### Possible Renames\n\n")
+	b.WriteString("| Old Name | New Name | Confidence | Reason |\n")
+	b.WriteString("|----------|----------|------------|--------|\n")
+	for _, r := range renames {
+		fmt.Fprintf(b, "| `%s` | `%s` | %.0f%% | %s |\n", r.OldName, r.NewName, r.Score*100, cell(r.Reason))
+	}
+	b.WriteString("\n")
+}
+
+func writeWarnings(b *strings.Builder, warnings []adapter.Warning) {
+	if len(warnings) == 0 {
+		return
+	}
+	b.WriteString("### Discovery Warnings\n\n")
+	b.WriteString("Reported during discovery; the affected data may be incomplete.\n\n")
+	for _, w := range warnings {
+		if w.Model != "" {
+			fmt.Fprintf(b, "- `%s`: %s\n", w.Model, w.Message)
+		} else {
+			fmt.Fprintf(b, "- %s\n", w.Message)
+		}
+	}
+	b.WriteString("\n")
+}
+
+func writeHealth(b *strings.Builder, h *SourceHealth) {
+	if h == nil {
+		return
+	}
+	b.WriteString("### Source Health\n\n")
+	b.WriteString("| Check | Result |\n")
+	b.WriteString("|-------|--------|\n")
+	fmt.Fprintf(b, "| Sources | %s |\n", cell(strings.Join(h.Sources, ", ")))
+	fmt.Fprintf(b, "| Models discovered | %d |\n", h.Discovered)
+	if h.Incident != "" {
+		fmt.Fprintf(b, "| Status page | %s |\n", cell(h.Incident))
+	}
+	if !h.Checked {
+		b.WriteString("| Liveness probe | skipped |\n")
+		b.WriteString("| Model count threshold | skipped |\n")
+		b.WriteString("\n")
+		return
+	}
+	b.WriteString("| Liveness probe | passed |\n")
+	if h.MinExpected > 0 {
+		required := int(float64(h.MinExpected) * h.Threshold)
+		if h.Discovered < required {
+			// Only let through during a declared incident.
+			fmt.Fprintf(b, "| Model count threshold | waived during the incident (%d < %d, min %d × %.0f%%) |\n",
+				h.Discovered, required, h.MinExpected, h.Threshold*100)
+		} else {
+			fmt.Fprintf(b, "| Model count threshold | passed (%d ≥ %d, min %d × %.0f%%) |\n",
+				h.Discovered, required, h.MinExpected, h.Threshold*100)
+		}
+	} else {
+		b.WriteString("| Model count threshold | n/a |\n")
+	}
+	b.WriteString("\n")
+}
+
+func writeRollback(b *strings.Builder, branch, ext string, sets ...*diff.ChangeSet) {
+	changed := false
+	for _, cs := range sets {
+		changed = changed || len(cs.New) > 0 || len(cs.Updated) > 0 || len(cs.Renamed) > 0
+	}
+	if !changed {
+		return
+	}
+
+	b.WriteString("### Rollback\n\n")
+	if branch != "" {
+		fmt.Fprintf(b, "**Before merge**: close this PR and delete the `%s` branch. Nothing is applied until merge.\n\n", branch)
+	} else {
+		b.WriteString("**Before merge**: close this PR. Nothing is applied until merge.\n\n")
+	}
+
+	b.WriteString("**After merge**: revert the merge commit. This restores the model files, `version.txt` and `manifest.yaml`:\n\n")
+	b.WriteString("```bash\ngit revert -m 1 <merge-commit-sha>\n```\n\n")
+
+	b.WriteString("To roll back individual models instead:\n\n")
+	b.WriteString("```bash\n")
+	for _, cs := range sets {
+		for _, m := range cs.New {
+			fmt.Fprintf(b, "git rm %s\n", modelPath(cs.Provider, m.Name, ext))
+		}
+		for _, u := range cs.Updated {
+			fmt.Fprintf(b, "git checkout <merge-commit-sha>^1 -- %s\n", modelPath(cs.Provider, u.Name, ext))
+		}
+		for _, r := range cs.Renamed {
+			fmt.Fprintf(b, "git rm %s\n", modelPath(cs.Provider, r.Name, ext))
+			fmt.Fprintf(b, "git checkout <merge-commit-sha>^1 -- %s\n", modelPath(cs.Provider, r.OldName, ext))
+		}
+	}
+	b.WriteString("```\n\n")
+}
+
+func modelPath(provider, name, ext string) string {
+	if ext == "" {
+		ext = ".yaml"
+	}
+	return fmt.Sprintf("providers/%s/models/%s%s", provider, name, ext)
+}
+
+// formatCost returns input/output per-1K prices, normalized from the
+// model's unit, or "—" when the model has no cost.
+func formatCost(c *catalog.Cost) (string, string) {
+	if c == nil {
+		return "—", "—"
+	}
+	n := c.Normalize()
+	return formatPrice(n.InputPer1K, n.CurrencyOrDefault()), formatPrice(n.OutputPer1K, n.CurrencyOrDefault())
+}
+
+func formatPrice(v float64, currency string) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if currency == catalog.DefaultCurrency {
+		return "$" + s
+	}
+	return s + " " + currency
+}
+
+func formatInt(v int) string {
+	if v == 0 {
+		return "—"
+	}
+	return strconv.Itoa(v)
+}
+
+// formatValue renders a FieldChange value for a table cell.
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "—"
+	case string:
+		if val == "" {
+			return "—"
+		}
+		return cell(val)
+	case []string:
+		if len(val) == 0 {
+			return "—"
+		}
+		return cell(strings.Join(val, ", "))
+	case *catalog.Cost:
+		if val == nil {
+			return "—"
+		}
+		in, out := formatCost(val)
+		return fmt.Sprintf("in %s / out %s", in, out)
+	case catalog.XProvider:
+		if len(val) == 0 {
+			return "—"
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = fmt.Sprintf("%s: %v", k, val[k])
+		}
+		return cell(strings.Join(parts, ", "))
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return cell(fmt.Sprintf("%v", val))
+	}
+}
+
+// formatDelta returns the relative change between two numeric values,
+// or "—" for non-numeric or zero-based changes.
+func formatDelta(oldV, newV any) string {
+	o, okOld := toFloat(oldV)
+	n, okNew := toFloat(newV)
+	if !okOld || !okNew || o == 0 {
+		return "—"
+	}
+	pct := (n - o) / o * 100
+	return fmt.Sprintf("%+.1f%%", pct)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case int:
+		return float64(val), true
+	default:
+		return 0, false
+	}
+}
+
+// cell escapes characters that would break a Markdown table row.
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,254 @@
+package render
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/adapter"
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/judge"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+func TestBodyGolden(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *Input
+	}{
+		{
+			name: "full",
+			input: &Input{
+				ChangeSet: &diff.ChangeSet{
+					Provider: "openai",
+					New: []diff.ModelChange{
+						{Name: "gpt-5", Model: &catalog.Model{
+							Name: "gpt-5", Family: "gpt-5", Status: "stable",
+							Cost:         &catalog.Cost{InputPer1K: 1.25, OutputPer1K: 10, Unit: catalog.PriceUnitPer1M},
+							Limits:       catalog.Limits{MaxTokens: 400000},
+							Capabilities: []string{"chat", "vision", "function_calling"},
+						}},
+						{Name: "gpt-image-1", Model: &catalog.Model{
+							Name: "gpt-image-1", Family: "gpt-image", Status: "stable",
+							Capabilities: []string{"image_generation"},
+						}},
+					},
+					Updated: []diff.ModelUpdate{
+						{Name: "gpt-4o", Model: &catalog.Model{Name: "gpt-4o"}, Changes: []catalog.FieldChange{
+							{Field: "cost.input_per_1k", OldValue: 0.005, NewValue: 0.0025},
+							{Field: "limits.max_tokens", OldValue: 128000, NewValue: 256000},
+							{Field: "capabilities", OldValue: []string{"chat"}, NewValue: []string{"chat", "vision"}},
+							{Field: "status", OldValue: "preview", NewValue: "stable"},
+						}},
+						{Name: "gpt-4o-mini", Model: &catalog.Model{Name: "gpt-4o-mini"}, Changes: []catalog.FieldChange{
+							{Field: "cost", OldValue: nil, NewValue: &catalog.Cost{InputPer1K: 0.00015, OutputPer1K: 0.0006}},
+						}},
+					},
+					DeprecationCandidates: []diff.ModelChange{
+						{Name: "gpt-3.5-turbo", Model: &catalog.Model{Name: "gpt-3.5-turbo", Family: "gpt-3.5", Status: "legacy"}},
+					},
+					PossibleRenames: []diff.RenamePair{
+						{OldName: "gpt-3.5-turbo", NewName: "gpt-5", Score: 0.72, Reason: "same family | similar limits"},
+					},
+					Unchanged: 12,
+				},
+				Judge: &judge.Result{Verdicts: []judge.ModelVerdict{
+					{ModelName: "gpt-5", Verdict: judge.VerdictApprove, Confidence: 0.9},
+					{ModelName: "gpt-4o", Verdict: judge.VerdictFlag, Confidence: 0.6,
+						Concerns: []string{"context window doubled"}, Reasoning: "Unusual jump."},
+				}},
+				Health: &SourceHealth{
+					Sources:     []string{"api", "docs"},
+					Discovered:  42,
+					Checked:     true,
+					MinExpected: 40,
+					Threshold:   0.9,
+				},
+				Capabilities: catalog.CapabilityIndex{
+					"openai": {
+						"vision": {"gpt-4.1", "gpt-4o", "gpt-5", "o3"},
+						"chat":   {"gpt-4.1", "gpt-4o", "gpt-4o-mini", "gpt-5", "o3"},
+					},
+				},
+				Branch:      "sentinel/openai-20260218-060000",
+				FromVersion: "1.4.2",
+				ToVersion:   "1.5.0",
+			},
+		},
+		{
+			name: "updates_only",
+			input: &Input{
+				ChangeSet: &diff.ChangeSet{
+					Provider: "mistral",
+					Updated: []diff.ModelUpdate{
+						{Name: "mistral-large", Model: &catalog.Model{Name: "mistral-large"}, Changes: []catalog.FieldChange{
+							{Field: "cost.currency", OldValue: "USD", NewValue: "EUR"},
+							{Field: "display_name", OldValue: "", NewValue: "Mistral Large"},
+						}},
+					},
+					Unchanged: 3,
+					Warnings: []adapter.Warning{
+						{Message: "mistral docs discovery failed, continuing (error: status 503)"},
+						{Model: "codestral-mamba", Message: "no context length reported"},
+					},
+				},
+				Health: &SourceHealth{Sources: []string{"api"}, Discovered: 4},
+			},
+		},
+		{
+			name: "capability_loss",
+			input: &Input{
+				ChangeSet: &diff.ChangeSet{
+					Provider: "groq",
+					Updated: []diff.ModelUpdate{
+						{Name: "llama-vision", Model: &catalog.Model{Name: "llama-vision"}, Changes: []catalog.FieldChange{
+							{Field: "capabilities", OldValue: []string{"chat", "vision"}, NewValue: []string{"chat"}},
+						}},
+						{Name: "llama-70b", Model: &catalog.Model{Name: "llama-70b"}, Changes: []catalog.FieldChange{
+							{Field: "capabilities", OldValue: []string{"chat", "function_calling"}, NewValue: []string{"chat"}},
+						}},
+					},
+				},
+				Capabilities: catalog.CapabilityIndex{
+					"groq": {
+						"chat":             {"a", "b", "c", "d", "e", "f", "llama-70b", "llama-vision"},
+						"function_calling": {"a", "b", "c", "d", "e", "f", "g"},
+					},
+				},
+			},
+		},
+		{
+			name: "deprecations_only",
+			input: &Input{
+				ChangeSet: &diff.ChangeSet{
+					Provider: "cohere",
+					DeprecationCandidates: []diff.ModelChange{
+						{Name: "command", Model: &catalog.Model{Name: "command", Family: "command", Status: "stable"}},
+					},
+				},
+			},
+		},
+		{
+			name: "family_removed",
+			input: &Input{
+				ChangeSet: &diff.ChangeSet{
+					Provider: "anthropic",
+					DeprecationCandidates: []diff.ModelChange{
+						{Name: "claude-3-opus", Model: &catalog.Model{Name: "claude-3-opus", Family: "claude-3", Status: "stable"}},
+						{Name: "claude-3-sonnet", Model: &catalog.Model{Name: "claude-3-sonnet", Family: "claude-3", Status: "stable"}},
+					},
+					RemovedFamilies: []diff.FamilyRemoval{
+						{Family: "claude-3", Models: []string{"claude-3-opus", "claude-3-sonnet"}},
+					},
+					Unchanged: 6,
+				},
+				Health: &SourceHealth{Sources: []string{"api"}, Discovered: 6, Checked: true, MinExpected: 5, Threshold: 0.8},
+			},
+		},
+		{
+			name: "incident",
+			input: &Input{
+				ChangeSet: &diff.ChangeSet{
+					Provider: "openai",
+					Updated: []diff.ModelUpdate{
+						{Name: "gpt-4o", Model: &catalog.Model{Name: "gpt-4o"}, Changes: []catalog.FieldChange{
+							{Field: "cost.input_per_1k", OldValue: 0.005, NewValue: 0.0025},
+						}},
+					},
+					Unchanged: 20,
+				},
+				Health: &SourceHealth{Sources: []string{"api"}, Discovered: 21, Checked: true, MinExpected: 40, Threshold: 0.9,
+					Incident: "major incident: Partial outage (https://status.openai.com)"},
+			},
+		},
+		{
+			name: "split",
+			input: &Input{
+				ChangeSet: &diff.ChangeSet{
+					Provider: "openrouter",
+					New: []diff.ModelChange{
+						{Name: "qwen-3-coder", Model: &catalog.Model{Name: "qwen-3-coder", Family: "qwen-3", Status: "stable"}},
+					},
+				},
+				Split: &Split{Part: 2, Total: 3, PRs: []int{101, 102}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkGolden(t, tt.name, Body(tt.input))
+		})
+	}
+}
+
+func TestAggregateBodyGolden(t *testing.T) {
+	inputs := []*Input{
+		{
+			ChangeSet: &diff.ChangeSet{
+				Provider: "groq",
+				New: []diff.ModelChange{
+					{Name: "llama-4-scout", Model: &catalog.Model{Name: "llama-4-scout", Family: "llama-4", Status: "stable"}},
+				},
+				Unchanged: 12,
+			},
+			FromVersion: "1.4.2",
+			ToVersion:   "1.5.0",
+		},
+		{
+			ChangeSet: &diff.ChangeSet{
+				Provider: "mistral",
+				Updated: []diff.ModelUpdate{
+					{Name: "mistral-large", Model: &catalog.Model{Name: "mistral-large"}, Changes: []catalog.FieldChange{
+						{Field: "display_name", OldValue: "", NewValue: "Mistral Large"},
+					}},
+				},
+				Unchanged: 3,
+			},
+			Health:      &SourceHealth{Sources: []string{"api"}, Discovered: 4},
+			FromVersion: "1.5.0",
+			ToVersion:   "1.5.1",
+		},
+	}
+	checkGolden(t, "aggregate", AggregateBody(inputs, "sentinel/catalog-20260218-060000"))
+}
+
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	golden := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("body mismatch for %s (run with -update to accept)\n--- got ---\n%s\n--- want ---\n%s", golden, got, want)
+	}
+}
+
+func TestFormatDelta(t *testing.T) {
+	tests := []struct {
+		old, new any
+		want     string
+	}{
+		{0.005, 0.0025, "-50.0%"},
+		{128000, 256000, "+100.0%"},
+		{0.0, 1.0, "—"},
+		{"a", "b", "—"},
+		{nil, 1.0, "—"},
+	}
+	for _, tt := range tests {
+		if got := formatDelta(tt.old, tt.new); got != tt.want {
+			t.Errorf("formatDelta(%v, %v) = %q, want %q", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package review
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"gopkg.in/yaml.v3"
+)
+
+// EditInEditor is an EditFunc that opens the model as YAML in $VISUAL or
+// $EDITOR, vi when neither is set, and reads it back once the editor exits.
+func EditInEditor(m *catalog.Model) (*catalog.Model, error) {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp("", "sentinel-review-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], f.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running %s: %w", args[0], err)
+	}
+
+	data, err = os.ReadFile(f.Name())
+	if err != nil {
+		return nil, err
+	}
+	return parseModel(data)
+}
+
+// parseModel decodes an edited model, rejecting unknown fields so a typo
+// isn't silently dropped.
+func parseModel(data []byte) (*catalog.Model, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var m catalog.Model
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("parsing the edited model: %w", err)
+	}
+	return &m, nil
+}
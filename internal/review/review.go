@@ -0,0 +1,464 @@
+// Package review walks an operator through proposed changesets in the
+// terminal, one model at a time, and keeps only the changes they accept.
+package review
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/judge"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrCancelled is returned by Run when the operator leaves the review
+// without writing anything.
+var ErrCancelled = errors.New("review cancelled, nothing written")
+
+// Item kinds.
+const (
+	kindNew    = "new"
+	kindUpdate = "update"
+	kindRename = "rename"
+)
+
+type decision int
+
+const (
+	undecided decision = iota
+	accepted
+	rejected
+)
+
+func (d decision) String() string {
+	switch d {
+	case accepted:
+		return "accepted"
+	case rejected:
+		return "rejected"
+	}
+	return ""
+}
+
+// EditFunc lets the operator change a proposed model, returning the edited
+// copy. The model's name can't change.
+type EditFunc func(m *catalog.Model) (*catalog.Model, error)
+
+// Session is one interactive review. Commands are read a line at a time,
+// so a review can also be scripted by piping them in.
+type Session struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	edit     EditFunc
+	verdicts map[string]*judge.Result
+	existing *catalog.Catalog
+	opts     diff.DiffOptions
+}
+
+// Option configures a Session.
+type Option func(*Session)
+
+// WithEditor enables the edit command.
+func WithEditor(edit EditFunc) Option {
+	return func(s *Session) { s.edit = edit }
+}
+
+// WithVerdicts shows the judge's verdicts, by provider, next to each model.
+// They are advisory: the operator decides.
+func WithVerdicts(verdicts map[string]*judge.Result) Option {
+	return func(s *Session) { s.verdicts = verdicts }
+}
+
+// WithCatalog sets the catalog the changesets were computed against, so an
+// edited update's field changes can be recomputed with opts.
+func WithCatalog(cat *catalog.Catalog, opts diff.DiffOptions) Option {
+	return func(s *Session) { s.existing, s.opts = cat, opts }
+}
+
+// New returns a session reading commands from in and writing to out.
+func New(in io.Reader, out io.Writer, opts ...Option) *Session {
+	s := &Session{in: bufio.NewReader(in), out: out}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// item is one proposed model change under review.
+type item struct {
+	cs       int // index into the reviewed changesets
+	kind     string
+	idx      int // index into the changeset's New, Updated or Renamed
+	decision decision
+}
+
+const help = `  a  accept            r  reject
+  e  edit the model     s  skip for now
+  b  back               A  accept the rest
+  R  reject the rest    q  finish reviewing
+Skipped models are not written.`
+
+// Run reviews changesets and returns them with only the accepted models,
+// once the operator confirms the write. Deprecation candidates and possible
+// renames are shown for information and kept as they are: they don't write
+// model files. Run returns ErrCancelled when the operator writes nothing.
+func (s *Session) Run(changesets []diff.ChangeSet) ([]diff.ChangeSet, error) {
+	reviewed := make([]diff.ChangeSet, len(changesets))
+	var items []*item
+	for i, cs := range changesets {
+		cs.New, cs.Updated, cs.Renamed = slices.Clone(cs.New), slices.Clone(cs.Updated), slices.Clone(cs.Renamed)
+		reviewed[i] = cs
+		for j := range cs.New {
+			items = append(items, &item{cs: i, kind: kindNew, idx: j})
+		}
+		for j := range cs.Updated {
+			items = append(items, &item{cs: i, kind: kindUpdate, idx: j})
+		}
+		for j := range cs.Renamed {
+			items = append(items, &item{cs: i, kind: kindRename, idx: j})
+		}
+	}
+	if len(items) == 0 {
+		fmt.Fprintln(s.out, "No model changes to review.")
+		return nil, ErrCancelled
+	}
+	s.printInfo(reviewed)
+	fmt.Fprintf(s.out, "%d proposed changes. Type ? for help.\n", len(items))
+
+	for i := 0; i < len(items); {
+		it := items[i]
+		s.show(reviewed, it, i+1, len(items))
+		cmd, err := s.readCommand("> ")
+		if err != nil {
+			return nil, err
+		}
+		switch cmd {
+		case "a":
+			it.decision = accepted
+			i++
+		case "r":
+			it.decision = rejected
+			i++
+		case "s", "":
+			i++
+		case "b":
+			i = max(i-1, 0)
+		case "e":
+			if s.editItem(reviewed, it) {
+				i++
+			}
+		case "A", "R":
+			d := accepted
+			if cmd == "R" {
+				d = rejected
+			}
+			for _, rest := range items[i:] {
+				if rest.decision == undecided {
+					rest.decision = d
+				}
+			}
+			i = len(items)
+		case "q":
+			i = len(items)
+		case "?":
+			fmt.Fprintln(s.out, help)
+		default:
+			fmt.Fprintf(s.out, "Unknown command %q. Type ? for help.\n", cmd)
+		}
+	}
+
+	var nAccepted, nRejected int
+	for _, it := range items {
+		switch it.decision {
+		case accepted:
+			nAccepted++
+		case rejected:
+			nRejected++
+		}
+	}
+	fmt.Fprintf(s.out, "\n%d accepted, %d rejected, %d skipped.\n", nAccepted, nRejected, len(items)-nAccepted-nRejected)
+	if nAccepted == 0 {
+		return nil, ErrCancelled
+	}
+	answer, err := s.readCommand(fmt.Sprintf("Write the %d accepted changes? [y/N] ", nAccepted))
+	if err != nil {
+		return nil, err
+	}
+	if answer != "y" && answer != "yes" {
+		return nil, ErrCancelled
+	}
+	return keepAccepted(reviewed, items), nil
+}
+
+// readCommand prompts and reads one trimmed line. Input ending before the
+// review does cancels it.
+func (s *Session) readCommand(prompt string) (string, error) {
+	fmt.Fprint(s.out, prompt)
+	line, err := s.in.ReadString('\n')
+	if err != nil && line == "" {
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(s.out)
+			return "", ErrCancelled
+		}
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// editItem runs the editor on an item's model and accepts the result. An
+// edit that fails leaves the item as it was, to try again.
+func (s *Session) editItem(reviewed []diff.ChangeSet, it *item) bool {
+	if s.edit == nil {
+		fmt.Fprintln(s.out, "Editing is not available.")
+		return false
+	}
+	cs := &reviewed[it.cs]
+	name, m := itemModel(cs, it)
+	edited, err := s.edit(m)
+	if err != nil {
+		fmt.Fprintf(s.out, "Edit failed: %v\n", err)
+		return false
+	}
+	if edited.Name != name {
+		fmt.Fprintf(s.out, "Edit discarded: the name must stay %s.\n", name)
+		return false
+	}
+	if err := markManual(m, edited); err != nil {
+		fmt.Fprintf(s.out, "Edit failed: %v\n", err)
+		return false
+	}
+
+	switch it.kind {
+	case kindNew:
+		cs.New[it.idx].Model = edited
+	case kindRename:
+		cs.Renamed[it.idx].Model = edited
+	case kindUpdate:
+		// The changes are recomputed so the update still reconciles
+		// against the catalog when it is applied.
+		cur := s.existingModel(cs.Provider, name)
+		if cur == nil {
+			fmt.Fprintf(s.out, "Edit discarded: %s is not in the catalog.\n", name)
+			return false
+		}
+		changes := diff.ModelChanges(cur, edited, s.opts)
+		if len(changes) == 0 {
+			fmt.Fprintln(s.out, "The edit leaves nothing to change; rejected.")
+			it.decision = rejected
+			return true
+		}
+		cs.Updated[it.idx].Model, cs.Updated[it.idx].Changes = edited, changes
+	}
+	it.decision = accepted
+	fmt.Fprintln(s.out, "Edited and accepted.")
+	return true
+}
+
+// markManual records the fields the operator changed in after as set by
+// hand, keeping the provenance before had for the others.
+func markManual(before, after *catalog.Model) error {
+	changed, err := editedFields(before, after)
+	if err != nil {
+		return err
+	}
+	u := catalog.XUpdater{}
+	if before.XUpdater != nil {
+		u = *before.XUpdater
+	}
+	u.Fields = maps.Clone(u.Fields)
+	for _, f := range changed {
+		if u.Fields == nil {
+			u.Fields = make(map[string]catalog.FieldSource)
+		}
+		u.Fields[f] = catalog.FieldSource{Source: catalog.SourceManual}
+	}
+	after.XUpdater = &u
+	return nil
+}
+
+// editedFields lists the top-level fields whose values differ between two
+// versions of a model.
+func editedFields(before, after *catalog.Model) ([]string, error) {
+	a, err := fieldMap(before)
+	if err != nil {
+		return nil, err
+	}
+	b, err := fieldMap(after)
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for k, v := range b {
+		if !reflect.DeepEqual(a[k], v) {
+			changed = append(changed, k)
+		}
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// fieldMap is the YAML mapping of a model without its name and metadata.
+func fieldMap(m *catalog.Model) (map[string]any, error) {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]any)
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	delete(out, "name")
+	delete(out, "x_updater")
+	return out, nil
+}
+
+func (s *Session) existingModel(provider, name string) *catalog.Model {
+	if s.existing == nil {
+		return nil
+	}
+	if pc, ok := s.existing.Providers[provider]; ok {
+		return pc.Models[name]
+	}
+	return nil
+}
+
+func itemModel(cs *diff.ChangeSet, it *item) (string, *catalog.Model) {
+	switch it.kind {
+	case kindNew:
+		return cs.New[it.idx].Name, cs.New[it.idx].Model
+	case kindUpdate:
+		return cs.Updated[it.idx].Name, cs.Updated[it.idx].Model
+	default:
+		return cs.Renamed[it.idx].Name, cs.Renamed[it.idx].Model
+	}
+}
+
+// keepAccepted narrows each changeset to its accepted models.
+func keepAccepted(reviewed []diff.ChangeSet, items []*item) []diff.ChangeSet {
+	out := make([]diff.ChangeSet, len(reviewed))
+	for i, cs := range reviewed {
+		cs.New, cs.Updated, cs.Renamed = nil, nil, nil
+		out[i] = cs
+	}
+	for _, it := range items {
+		if it.decision != accepted {
+			continue
+		}
+		src, dst := &reviewed[it.cs], &out[it.cs]
+		switch it.kind {
+		case kindNew:
+			dst.New = append(dst.New, src.New[it.idx])
+		case kindUpdate:
+			dst.Updated = append(dst.Updated, src.Updated[it.idx])
+		case kindRename:
+			dst.Renamed = append(dst.Renamed, src.Renamed[it.idx])
+		}
+	}
+	return out
+}
+
+// printInfo lists what is shown but not reviewed model by model.
+func (s *Session) printInfo(reviewed []diff.ChangeSet) {
+	for _, cs := range reviewed {
+		for _, m := range cs.DeprecationCandidates {
+			fmt.Fprintf(s.out, "%s: %s is no longer discovered (deprecation candidate)\n", cs.Provider, m.Name)
+		}
+		for _, r := range cs.PossibleRenames {
+			fmt.Fprintf(s.out, "%s: %s may have been renamed to %s (%.0f%% confidence)\n", cs.Provider, r.OldName, r.NewName, r.Score*100)
+		}
+		for _, w := range cs.Warnings {
+			fmt.Fprintf(s.out, "%s: warning: %s\n", cs.Provider, w)
+		}
+	}
+}
+
+// show prints the item at position n of total.
+func (s *Session) show(reviewed []diff.ChangeSet, it *item, n, total int) {
+	cs := &reviewed[it.cs]
+	name, m := itemModel(cs, it)
+	fmt.Fprintf(s.out, "\n[%d/%d] %s · %s · %s", n, total, cs.Provider, it.kind, name)
+	if it.decision != undecided {
+		fmt.Fprintf(s.out, " (%s)", it.decision)
+	}
+	fmt.Fprintln(s.out)
+
+	switch it.kind {
+	case kindUpdate:
+		changes := cs.Updated[it.idx].Changes
+		width := 0
+		for _, c := range changes {
+			width = max(width, len(c.Field))
+		}
+		for _, c := range changes {
+			fmt.Fprintf(s.out, "  %-*s  %v → %v\n", width, c.Field, diff.FormatValue(c.OldValue), diff.FormatValue(c.NewValue))
+		}
+	case kindRename:
+		fmt.Fprintf(s.out, "  renamed from %s\n", cs.Renamed[it.idx].OldName)
+		s.showModel(m)
+	default:
+		s.showModel(m)
+	}
+	s.showVerdict(cs.Provider, name)
+}
+
+// showModel prints the fields a reviewer checks on a new model.
+func (s *Session) showModel(m *catalog.Model) {
+	fields := []struct {
+		name  string
+		value any
+	}{
+		{"display_name", m.DisplayName},
+		{"family", m.Family},
+		{"status", m.Status},
+		{"model_type", m.ModelType},
+		{"cost", m.Cost},
+		{"max_tokens", m.Limits.MaxTokens},
+		{"max_completion", m.Limits.MaxCompletionTokens},
+		{"capabilities", m.Capabilities},
+		{"input", m.Modalities.Input},
+		{"output", m.Modalities.Output},
+	}
+	for _, f := range fields {
+		v := diff.FormatValue(f.value)
+		if v == "none" || v == 0 {
+			continue
+		}
+		fmt.Fprintf(s.out, "  %-14s  %v\n", f.name, v)
+	}
+}
+
+// showVerdict prints the judge's verdict on a model, when there is one.
+func (s *Session) showVerdict(provider, name string) {
+	result := s.verdicts[provider]
+	if result == nil {
+		return
+	}
+	for _, v := range result.Verdicts {
+		if v.ModelName != name {
+			continue
+		}
+		fmt.Fprintf(s.out, "  judge (advisory): %s, %.0f%% confidence", v.Verdict, v.Confidence*100)
+		if v.Reasoning != "" {
+			fmt.Fprintf(s.out, ": %s", v.Reasoning)
+		}
+		fmt.Fprintln(s.out)
+		for _, c := range v.Concerns {
+			fmt.Fprintf(s.out, "    - %s\n", c)
+		}
+		return
+	}
+}
@@ -0,0 +1,126 @@
+package review
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/diff"
+	"github.com/everstacklabs/sentinel/internal/judge"
+)
+
+func testChangeSets() []diff.ChangeSet {
+	return []diff.ChangeSet{{
+		Provider: "openai",
+		New: []diff.ModelChange{
+			{Name: "gpt-5", Model: &catalog.Model{Name: "gpt-5", Family: "gpt-5", Limits: catalog.Limits{MaxTokens: 400000}}},
+			{Name: "o3", Model: &catalog.Model{Name: "o3", Family: "o3"}},
+		},
+		Updated: []diff.ModelUpdate{{
+			Name:    "gpt-4o",
+			Model:   &catalog.Model{Name: "gpt-4o", Cost: &catalog.Cost{InputPer1K: 0.0025, OutputPer1K: 0.01}},
+			Changes: []catalog.FieldChange{{Field: "cost.input_per_1k", OldValue: 0.005, NewValue: 0.0025}},
+		}},
+		DeprecationCandidates: []diff.ModelChange{{Name: "gpt-3.5-turbo"}},
+	}}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                string
+		wantNew, wantUpdated int
+		wantErr              error
+	}{
+		{"accept all", "a\na\na\ny\n", 2, 1, nil},
+		{"reject one", "a\nr\na\ny\n", 1, 1, nil},
+		{"skipped is not written", "s\na\na\ny\n", 1, 1, nil},
+		{"back changes a decision", "r\nb\na\nr\nr\ny\n", 1, 0, nil},
+		{"accept the rest", "r\nA\ny\n", 1, 1, nil},
+		{"reject the rest", "a\nR\ny\n", 1, 0, nil},
+		{"finish early", "a\nq\ny\n", 1, 0, nil},
+		{"declined write", "A\nn\n", 0, 0, ErrCancelled},
+		{"nothing accepted", "R\n", 0, 0, ErrCancelled},
+		{"input ends", "a\n", 0, 0, ErrCancelled},
+		{"unknown command", "x\n?\nA\nyes\n", 2, 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := New(strings.NewReader(tt.input), &out).Run(testChangeSets())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v\n%s", err, tt.wantErr, out.String())
+			}
+			if err != nil {
+				return
+			}
+			cs := got[0]
+			if len(cs.New) != tt.wantNew || len(cs.Updated) != tt.wantUpdated {
+				t.Errorf("new %d, updated %d; want %d, %d", len(cs.New), len(cs.Updated), tt.wantNew, tt.wantUpdated)
+			}
+			if len(cs.DeprecationCandidates) != 1 {
+				t.Errorf("deprecation candidates = %d, want them kept", len(cs.DeprecationCandidates))
+			}
+		})
+	}
+}
+
+func TestRunShowsVerdicts(t *testing.T) {
+	verdicts := map[string]*judge.Result{"openai": {Verdicts: []judge.ModelVerdict{
+		{ModelName: "gpt-4o", Verdict: judge.VerdictFlag, Confidence: 0.8, Reasoning: "price halved", Concerns: []string{"no announcement"}},
+	}}}
+	var out bytes.Buffer
+	if _, err := New(strings.NewReader("s\ns\na\ny\n"), &out, WithVerdicts(verdicts)).Run(testChangeSets()); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"[1/3] openai · new · gpt-5",
+		"[3/3] openai · update · gpt-4o",
+		"judge (advisory): flag, 80% confidence: price halved",
+		"- no announcement",
+		"gpt-3.5-turbo is no longer discovered",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunEdit(t *testing.T) {
+	cat := &catalog.Catalog{Providers: map[string]*catalog.ProviderCatalog{"openai": {Models: map[string]*catalog.Model{
+		"gpt-4o": {Name: "gpt-4o", Cost: &catalog.Cost{InputPer1K: 0.005, OutputPer1K: 0.01}},
+	}}}}
+	edit := func(m *catalog.Model) (*catalog.Model, error) {
+		edited := *m
+		switch m.Name {
+		case "gpt-5":
+			edited.DisplayName = "GPT-5"
+		case "o3":
+			edited.Name = "o3-renamed"
+		case "gpt-4o":
+			edited.Cost = &catalog.Cost{InputPer1K: 0.003, OutputPer1K: 0.01}
+		}
+		return &edited, nil
+	}
+
+	var out bytes.Buffer
+	got, err := New(strings.NewReader("e\ne\nr\ne\ny\n"), &out, WithEditor(edit), WithCatalog(cat, diff.DiffOptions{})).Run(testChangeSets())
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+	cs := got[0]
+	if len(cs.New) != 1 || cs.New[0].Model.DisplayName != "GPT-5" {
+		t.Errorf("new = %+v, want the edited gpt-5 only", cs.New)
+	}
+	if !strings.Contains(out.String(), "the name must stay o3") {
+		t.Errorf("renaming edit not discarded:\n%s", out.String())
+	}
+	if len(cs.Updated) != 1 || len(cs.Updated[0].Changes) != 1 || cs.Updated[0].Changes[0].NewValue != 0.003 {
+		t.Errorf("updated = %+v, want the changes recomputed from the edit", cs.Updated)
+	}
+	if got := cs.Updated[0].Model.FieldSources(); len(got) != 1 || got["cost"].Source != catalog.SourceManual {
+		t.Errorf("sources = %v, want the edited cost marked manual", got)
+	}
+}
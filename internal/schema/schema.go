@@ -0,0 +1,84 @@
+// Package schema generates a JSON Schema for catalog model files from the
+// catalog.Model type and validates raw YAML against it. Unlike loading into
+// the struct, which ignores unknown keys and coerces some types, schema
+// validation reports misspelled fields and values of the wrong type.
+package schema
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+// Draft is the JSON Schema dialect Generate emits.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// ExtensionPrefix marks top-level keys that are allowed in addition to the
+// schema's own, so catalogs can keep custom fields under schema validation.
+const ExtensionPrefix = "x_"
+
+// Schema is the subset of JSON Schema that Generate emits and Validate
+// understands.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+}
+
+// Generate returns the schema for model YAML files. Fields the writer always
+// emits (no omitempty) are required; nested objects reject unknown keys, and
+// at the top level only keys starting with ExtensionPrefix may be added.
+func Generate() *Schema {
+	s := fromType(reflect.TypeOf(catalog.Model{}))
+	s.Schema = Draft
+	s.Title = "Sentinel catalog model"
+	s.Description = "A model file under providers/<provider>/models/ in a sentinel catalog."
+	s.PatternProperties = map[string]*Schema{"^" + ExtensionPrefix: {}}
+	s.Properties["readiness"].Enum = catalog.ReadinessLevels()
+	return s
+}
+
+func fromType(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice:
+		return &Schema{Type: "array", Items: fromType(t.Elem())}
+	case reflect.Map:
+		// Opaque blocks such as x_provider.
+		return &Schema{Type: "object"}
+	case reflect.Struct:
+		closed := false
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema), AdditionalProperties: &closed}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" || !f.IsExported() {
+				continue
+			}
+			s.Properties[name] = fromType(f.Type)
+			if !strings.Contains(opts, "omitempty") {
+				s.Required = append(s.Required, name)
+			}
+		}
+		return s
+	}
+	return &Schema{}
+}
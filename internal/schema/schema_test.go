@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const validModel = `name: gpt-4o
+display_name: GPT-4o
+family: gpt-4o
+status: stable
+readiness: approved
+cost:
+    input_per_1k: 0.0025
+    output_per_1k: 0.01
+limits:
+    max_tokens: 128000
+    max_completion_tokens: 16384
+capabilities:
+    - chat
+modalities:
+    input: [text, image]
+    output: [text]
+x_provider:
+    tier: 1
+x_internal_notes: keep
+`
+
+func TestGenerateRequiresNonOmitemptyFields(t *testing.T) {
+	s := Generate()
+	want := "name,display_name,family,status,limits,capabilities,modalities"
+	if got := strings.Join(s.Required, ","); got != want {
+		t.Errorf("required = %s, want %s", got, want)
+	}
+	if s.Properties["cost"].Type != "object" || s.Properties["limits"].Properties["max_tokens"].Type != "integer" {
+		t.Errorf("unexpected nested types: %+v", s.Properties["limits"])
+	}
+	if req := s.Properties["embedding"].Required; len(req) != 0 {
+		t.Errorf("embedding required = %v, want none", req)
+	}
+	if _, err := json.Marshal(s); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateAcceptsValidModel(t *testing.T) {
+	v, err := Generate().Validate([]byte(validModel))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v) != 0 {
+		t.Errorf("unexpected violations: %v", v)
+	}
+}
+
+func TestValidateReportsViolations(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace [2]string
+		path    string
+		message string
+		line    int
+	}{
+		{"misspelled field", [2]string{"capabilities:", "capabilites:"}, "capabilites", `did you mean "capabilities"`, 12},
+		{"unknown nested field", [2]string{"    output_per_1k", "    ouput_per_1k"}, "cost.ouput_per_1k", "unknown field", 8},
+		{"string for integer", [2]string{"max_tokens: 128000", "max_tokens: 128k"}, "limits.max_tokens", "expected integer, got string", 10},
+		{"scalar for list", [2]string{"    - chat", "    chat"}, "capabilities", "expected array", 13},
+		{"wrong list element", [2]string{"input: [text, image]", "input: [text, 3]"}, "modalities.input[1]", "expected string, got integer", 15},
+		{"missing required", [2]string{"status: stable\n", ""}, "", `missing required field "status"`, 1},
+		{"unknown readiness", [2]string{"readiness: approved", "readiness: done"}, "readiness", "not one of", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := strings.Replace(validModel, tt.replace[0], tt.replace[1], 1)
+			v, err := Generate().Validate([]byte(doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, got := range v {
+				if got.Path == tt.path && got.Line == tt.line && strings.Contains(got.Message, tt.message) {
+					return
+				}
+			}
+			t.Errorf("got %v, want %s at line %d containing %q", v, tt.path, tt.line, tt.message)
+		})
+	}
+}
+
+func TestValidateRejectsNonYAML(t *testing.T) {
+	if _, err := Generate().Validate([]byte("name: [unclosed")); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,166 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/everstacklabs/sentinel/internal/textutil"
+)
+
+// Violation is a place where a document doesn't match the schema.
+type Violation struct {
+	Path    string // dotted, with [i] for list elements; empty for the document
+	Line    int
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("line %d: %s: %s", v.Line, v.Path, v.Message)
+}
+
+// Validate checks a YAML document against s. The returned error is for
+// input that isn't YAML at all; schema mismatches are Violations.
+func (s *Schema) Validate(data []byte) ([]Violation, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return []Violation{{Line: 1, Message: "empty document"}}, nil
+	}
+	var out []Violation
+	s.check(doc.Content[0], "", &out)
+	return out, nil
+}
+
+func (s *Schema) check(n *yaml.Node, path string, out *[]Violation) {
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	fail := func(format string, args ...any) {
+		*out = append(*out, Violation{Path: path, Line: n.Line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Type != "" {
+		if got := nodeType(n); !typeMatches(s.Type, got) {
+			fail("expected %s, got %s", s.Type, got)
+			return
+		}
+	}
+	if len(s.Enum) > 0 && !contains(s.Enum, n.Value) {
+		fail("%q is not one of: %s", n.Value, strings.Join(s.Enum, ", "))
+	}
+
+	switch n.Kind {
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range n.Content {
+				s.Items.check(item, fmt.Sprintf("%s[%d]", path, i), out)
+			}
+		}
+	case yaml.MappingNode:
+		s.checkMapping(n, path, out, fail)
+	}
+}
+
+func (s *Schema) checkMapping(n *yaml.Node, path string, out *[]Violation, fail func(string, ...any)) {
+	present := make(map[string]bool, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, val := n.Content[i], n.Content[i+1]
+		present[key.Value] = true
+		child := join(path, key.Value)
+
+		if prop, ok := s.Properties[key.Value]; ok {
+			prop.check(val, child, out)
+			continue
+		}
+		if pp := s.matchPattern(key.Value); pp != nil {
+			pp.check(val, child, out)
+			continue
+		}
+		if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+			msg := "unknown field"
+			if suggestion := s.closest(key.Value); suggestion != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", suggestion)
+			}
+			*out = append(*out, Violation{Path: child, Line: key.Line, Message: msg})
+		}
+	}
+	for _, req := range s.Required {
+		if !present[req] {
+			fail("missing required field %q", req)
+		}
+	}
+}
+
+func (s *Schema) matchPattern(key string) *Schema {
+	patterns := make([]string, 0, len(s.PatternProperties))
+	for p := range s.PatternProperties {
+		patterns = append(patterns, p)
+	}
+	sort.Strings(patterns)
+	for _, p := range patterns {
+		if re, err := regexp.Compile(p); err == nil && re.MatchString(key) {
+			return s.PatternProperties[p]
+		}
+	}
+	return nil
+}
+
+// closest returns a known property within two edits of key, to point out typos.
+func (s *Schema) closest(key string) string {
+	best, bestDist := "", 3
+	for name := range s.Properties {
+		if d := textutil.EditDistance(key, name); d < bestDist || (d == bestDist && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+// nodeType returns the JSON Schema type name of a YAML node.
+func nodeType(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.ShortTag() {
+	case "!!str":
+		return "string"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return n.ShortTag()
+}
+
+func typeMatches(want, got string) bool {
+	return want == got || (want == "number" && got == "integer")
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+// Package serve exposes a catalog over a small read-only HTTP API, so
+// gateways can poll for updates instead of cloning the catalog repository.
+// Responses carry the catalog's content hash as an ETag; a poll with a
+// matching If-None-Match costs a 304 and no body.
+package serve
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/export"
+)
+
+// Server serves the catalog at a path on disk. The catalog is reloaded when
+// its content hash changes, so a sync or git pull is picked up on the next
+// request.
+type Server struct {
+	catalogPath string
+
+	mu   sync.Mutex
+	hash string
+	cat  *catalog.Catalog
+}
+
+// New creates a server for the catalog at catalogPath.
+func New(catalogPath string) *Server {
+	return &Server{catalogPath: catalogPath}
+}
+
+// Handler returns the HTTP API:
+//
+//	GET /v1/catalog        the JSON export; ?provider=, ?capability= and ?status= filter it
+//	GET /v1/catalog/hash   version and content hash only
+//	GET /healthz           liveness
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/catalog", s.handleCatalog)
+	mux.HandleFunc("GET /v1/catalog/hash", s.handleHash)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+// SetCatalogPath switches the server to the catalog at path. The next
+// request loads it.
+func (s *Server) SetCatalogPath(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.catalogPath = path
+	s.cat, s.hash = nil, ""
+}
+
+// current returns the catalog and its hash, reloading it if the files changed.
+func (s *Server) current() (*catalog.Catalog, string, error) {
+	s.mu.Lock()
+	catalogPath := s.catalogPath
+	s.mu.Unlock()
+
+	hash, err := catalog.ContentHash(catalogPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hash != s.hash || s.cat == nil {
+		cat, err := catalog.Load(catalogPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("loading catalog: %w", err)
+		}
+		if s.cat != nil {
+			slog.Info("catalog changed, reloaded", "version", cat.Version, "hash", hash)
+		}
+		s.cat, s.hash = cat, hash
+	}
+	return s.cat, s.hash, nil
+}
+
+func (s *Server) handleCatalog(w http.ResponseWriter, r *http.Request) {
+	cat, hash, err := s.current()
+	if err != nil {
+		serverError(w, err)
+		return
+	}
+	q := r.URL.Query()
+	f := export.Filter{
+		Providers:    splitParam(q["provider"]),
+		Capabilities: splitParam(q["capability"]),
+		Statuses:     splitParam(q["status"]),
+		Types:        splitParam(q["type"]),
+	}
+	// Filters change the body, so they are part of the entity tag.
+	etag := entityTag(hash, r.URL.RawQuery)
+	if notModified(w, r, etag) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	meta := export.Meta{Version: cat.Version, CatalogHash: hash}
+	if err := export.Write(w, export.FormatJSON, meta, export.Records(cat, f)); err != nil {
+		slog.Warn("writing catalog response", "error", err)
+	}
+}
+
+func (s *Server) handleHash(w http.ResponseWriter, r *http.Request) {
+	cat, hash, err := s.current()
+	if err != nil {
+		serverError(w, err)
+		return
+	}
+	if notModified(w, r, entityTag(hash, "")) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"version": cat.Version, "catalog_hash": hash})
+}
+
+// entityTag derives a strong ETag from the content hash and the query.
+func entityTag(hash, query string) string {
+	if query == "" {
+		return `"` + hash + `"`
+	}
+	return `"` + hash[:32] + "-" + shortHash(query) + `"`
+}
+
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
+
+// notModified sets the ETag and answers 304 when the client already has it.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// splitParam accepts both repeated and comma-separated query values.
+func splitParam(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+func serverError(w http.ResponseWriter, err error) {
+	slog.Error("serving catalog", "error", err)
+	http.Error(w, "catalog unavailable", http.StatusInternalServerError)
+}
@@ -0,0 +1,79 @@
+package serve
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/everstacklabs/sentinel/internal/export"
+)
+
+func writeCatalog(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func get(t *testing.T, h http.Handler, target, ifNoneMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCatalogETag(t *testing.T) {
+	root := t.TempDir()
+	writeCatalog(t, root, map[string]string{
+		"version.txt":                             "1.0.0\n",
+		"providers/openai/provider.yaml":          "name: openai\n",
+		"providers/openai/models/gpt-5.yaml":      "name: gpt-5\nstatus: stable\n",
+		"providers/mistral/provider.yaml":         "name: mistral\n",
+		"providers/mistral/models/codestral.yaml": "name: codestral\nstatus: stable\n",
+	})
+	h := New(root).Handler()
+
+	first := get(t, h, "/v1/catalog", "")
+	if first.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", first.Code, first.Body)
+	}
+	etag := first.Header().Get("ETag")
+	var doc export.Document
+	if err := json.Unmarshal(first.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Count != 2 || etag != `"`+doc.CatalogHash+`"` {
+		t.Errorf("count %d, etag %s, catalog_hash %s", doc.Count, etag, doc.CatalogHash)
+	}
+
+	if rec := get(t, h, "/v1/catalog", etag); rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("unchanged poll: status %d, %d bytes; want 304 with no body", rec.Code, rec.Body.Len())
+	}
+	if rec := get(t, h, "/v1/catalog?provider=openai", etag); rec.Code != http.StatusOK {
+		t.Errorf("filtered request with unfiltered ETag: status %d, want 200", rec.Code)
+	}
+
+	writeCatalog(t, root, map[string]string{"providers/openai/models/gpt-5.yaml": "name: gpt-5\nstatus: deprecated\n"})
+	changed := get(t, h, "/v1/catalog", etag)
+	if changed.Code != http.StatusOK || changed.Header().Get("ETag") == etag {
+		t.Errorf("after edit: status %d, etag %s; want 200 with a new ETag", changed.Code, changed.Header().Get("ETag"))
+	}
+
+	hash := get(t, h, "/v1/catalog/hash", "")
+	if hash.Code != http.StatusOK || hash.Header().Get("ETag") != changed.Header().Get("ETag") {
+		t.Errorf("hash endpoint: status %d, etag %s", hash.Code, hash.Header().Get("ETag"))
+	}
+}
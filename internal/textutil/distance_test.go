@@ -0,0 +1,24 @@
+package textutil
+
+import "testing"
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"owner", "owner", 0},
+		{"", "abc", 3},
+		{"ownr", "owner", 1},
+		{"togeterai", "togetherai", 1},
+		{"kitten", "sitting", 3},
+		{"gpt-4o-v1", "gpt-4o-v2", 1},
+		{"héllo", "hello", 1}, // runes, not bytes
+	}
+	for _, tt := range tests {
+		if got := EditDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("EditDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
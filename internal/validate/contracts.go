@@ -0,0 +1,146 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+// ContractsFile is where a catalog declares its downstream contracts,
+// relative to the catalog root.
+const ContractsFile = "contracts.yaml"
+
+// Contract is a promise the catalog makes to a downstream consumer: every
+// model it matches must have the required fields, and the listed fields
+// may only take the allowed values. Fields are dotted YAML paths, as in
+// Rules.
+type Contract struct {
+	Name        string              `yaml:"name"`
+	Consumer    string              `yaml:"consumer,omitempty"` // who breaks, e.g. a team or service
+	Description string              `yaml:"description,omitempty"`
+	Match       ContractMatch       `yaml:"match,omitempty"`
+	Required    []string            `yaml:"required,omitempty"`
+	Allowed     map[string][]string `yaml:"allowed,omitempty"`
+	Severity    string              `yaml:"severity,omitempty"` // "error" (default) or "warning"
+
+	severity Severity
+}
+
+// ContractMatch selects the models a contract applies to. Empty lists
+// match everything; a model must have all listed capabilities.
+type ContractMatch struct {
+	Providers    []string `yaml:"providers,omitempty"`
+	Capabilities []string `yaml:"capabilities,omitempty"`
+	Status       []string `yaml:"status,omitempty"`
+}
+
+// LoadContracts reads a contracts file. A missing file declares no
+// contracts unless mustExist is set.
+func LoadContracts(path string, mustExist bool) ([]Contract, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !mustExist {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading contracts: %w", err)
+	}
+	var file struct {
+		Contracts []Contract `yaml:"contracts"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for i := range file.Contracts {
+		if err := file.Contracts[i].compile(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return file.Contracts, nil
+}
+
+func (c *Contract) compile() error {
+	if c.Name == "" {
+		return fmt.Errorf("contract without a name")
+	}
+	if len(c.Required) == 0 && len(c.Allowed) == 0 {
+		return fmt.Errorf("contract %q checks nothing", c.Name)
+	}
+	switch c.Severity {
+	case "", "error":
+		c.severity = SeverityError
+	case "warning", "warn":
+		c.severity = SeverityWarning
+	default:
+		return fmt.Errorf("contract %q: unknown severity %q", c.Name, c.Severity)
+	}
+	return nil
+}
+
+func (c *Contract) matches(provider string, m *catalog.Model) bool {
+	if len(c.Match.Providers) > 0 && !slices.Contains(c.Match.Providers, provider) {
+		return false
+	}
+	if len(c.Match.Status) > 0 && !slices.Contains(c.Match.Status, m.Status) {
+		return false
+	}
+	for _, capability := range c.Match.Capabilities {
+		if !slices.Contains(m.Capabilities, capability) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkContracts reports every way m breaks a contract that applies to it.
+func checkContracts(contracts []Contract, provider string, m *catalog.Model, filename string, res *Result) {
+	var fields map[string]any
+	for i := range contracts {
+		c := &contracts[i]
+		if !c.matches(provider, m) {
+			continue
+		}
+		if fields == nil {
+			var err error
+			if fields, err = modelFields(m); err != nil {
+				res.Issues = append(res.Issues, Issue{SeverityError, filename, "", fmt.Sprintf("encoding model: %v", err)})
+				return
+			}
+		}
+
+		for _, f := range c.Required {
+			if len(lookupField(fields, f)) == 0 {
+				res.Issues = append(res.Issues, Issue{c.severity, filename, f, c.breach("required field is empty")})
+			}
+		}
+		for _, f := range sortedKeys(c.Allowed) {
+			for _, v := range lookupField(fields, f) {
+				if !slices.Contains(c.Allowed[f], v) {
+					res.Issues = append(res.Issues, Issue{c.severity, filename, f, c.breach(fmt.Sprintf("value %q is not allowed", v))})
+				}
+			}
+		}
+	}
+}
+
+// breach words an issue so the author knows whose contract they broke.
+func (c *Contract) breach(msg string) string {
+	if c.Consumer != "" {
+		return fmt.Sprintf("%s (breaks contract %q of %s)", msg, c.Name, c.Consumer)
+	}
+	return fmt.Sprintf("%s (breaks contract %q)", msg, c.Name)
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,286 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/everstacklabs/sentinel/internal/catalog"
+)
+
+// Rules holds the thresholds ValidateModel checks against, plus any extra
+// required fields and pattern checks. Fields are dotted YAML paths as they
+// appear in model files, e.g. "family" or "cost.cached_input_per_1k".
+type Rules struct {
+	MaxPricePer1K         float64 // USD, after normalizing to per-1K
+	MinMaxTokens          int
+	MinEmbeddingMaxTokens int
+	MaxMaxTokens          int
+	Required              []string
+	Patterns              []Pattern
+}
+
+// Pattern requires a field to match a regular expression. For list fields
+// every element must match. Empty fields are left to Required.
+type Pattern struct {
+	Field    string
+	Regex    string
+	Message  string // reported instead of the default when set
+	Severity Severity
+
+	re *regexp.Regexp
+}
+
+// DefaultRules returns the built-in thresholds.
+func DefaultRules() Rules {
+	return Rules{
+		MaxPricePer1K:         0.10,
+		MinMaxTokens:          1024,
+		MinEmbeddingMaxTokens: 64,
+		MaxMaxTokens:          2_000_000,
+	}
+}
+
+func (r *Rules) compile() error {
+	if r.MaxPricePer1K <= 0 {
+		return fmt.Errorf("max_price_per_1k must be positive, got %g", r.MaxPricePer1K)
+	}
+	if r.MinMaxTokens < 0 || r.MinEmbeddingMaxTokens < 0 {
+		return fmt.Errorf("minimum max_tokens must not be negative")
+	}
+	if r.MaxMaxTokens < r.MinMaxTokens || r.MaxMaxTokens < r.MinEmbeddingMaxTokens {
+		return fmt.Errorf("max_max_tokens %d is below the minimum", r.MaxMaxTokens)
+	}
+	for _, f := range r.Required {
+		if f == "" {
+			return fmt.Errorf("empty required field")
+		}
+	}
+	for i := range r.Patterns {
+		p := &r.Patterns[i]
+		if p.Field == "" {
+			return fmt.Errorf("pattern %q has no field", p.Regex)
+		}
+		re, err := regexp.Compile(p.Regex)
+		if err != nil {
+			return fmt.Errorf("pattern for %s: %w", p.Field, err)
+		}
+		p.re = re
+	}
+	return nil
+}
+
+// Ruleset maps providers to the Rules their models are validated with.
+// Providers without an entry use Default. Contracts apply on top, to every
+// model they match.
+type Ruleset struct {
+	Default   Rules
+	Providers map[string]Rules
+	Contracts []Contract
+}
+
+// NewRuleset checks the thresholds and compiles the patterns of every Rules.
+func NewRuleset(def Rules, providers map[string]Rules) (*Ruleset, error) {
+	if err := def.compile(); err != nil {
+		return nil, fmt.Errorf("validation rules: %w", err)
+	}
+	rs := &Ruleset{Default: def, Providers: make(map[string]Rules, len(providers))}
+	for name, r := range providers {
+		if err := r.compile(); err != nil {
+			return nil, fmt.Errorf("validation rules for %s: %w", name, err)
+		}
+		rs.Providers[name] = r
+	}
+	return rs, nil
+}
+
+var defaultRuleset = &Ruleset{Default: DefaultRules()}
+
+// For returns the rules for a provider. A nil Ruleset yields the defaults.
+func (rs *Ruleset) For(provider string) *Rules {
+	if rs == nil {
+		rs = defaultRuleset
+	}
+	if r, ok := rs.Providers[provider]; ok {
+		return &r
+	}
+	return &rs.Default
+}
+
+// ValidateModel checks a model of the given provider.
+func (rs *Ruleset) ValidateModel(provider string, m *catalog.Model, filename string) *Result {
+	res := rs.For(provider).validate(m, filename)
+	if rs != nil {
+		checkContracts(rs.Contracts, provider, m, filename, res)
+	}
+	return res
+}
+
+// ValidateCatalog validates all models in a catalog.
+func (rs *Ruleset) ValidateCatalog(cat *catalog.Catalog) *Result {
+	r := &Result{}
+	for providerName, pc := range cat.Providers {
+		r.Issues = append(r.Issues, ValidateProvider(providerName, &pc.Provider).Issues...)
+		for modelName, model := range pc.Models {
+			filename := filepath.FromSlash(cat.ModelPath(providerName, modelName))
+			r.Issues = append(r.Issues, rs.ValidateModel(providerName, model, filename).Issues...)
+		}
+		checkCaseCollisions(providerName, pc, r)
+	}
+	return r
+}
+
+// checkCaseCollisions reports models whose names differ only in case. Their
+// files can't both exist on Windows or macOS, so a checkout there silently
+// loses one of them.
+func checkCaseCollisions(provider string, pc *catalog.ProviderCatalog, r *Result) {
+	names := make([]string, 0, len(pc.Models))
+	for name := range pc.Models {
+		names = append(names, name)
+	}
+	for _, group := range catalog.CaseCollisions(names) {
+		for _, name := range group {
+			others := slices.DeleteFunc(slices.Clone(group), func(n string) bool { return n == name })
+			file := filepath.Join("providers", provider, "models", name+".yaml")
+			if rel, ok := pc.Files[name]; ok {
+				file = filepath.FromSlash(rel)
+			}
+			r.Issues = append(r.Issues, Issue{SeverityError, file, "name",
+				fmt.Sprintf("differs only in case from %s; the files collide on case-insensitive filesystems", strings.Join(others, ", "))})
+		}
+	}
+}
+
+// ValidateFiles validates the given model files, relative to basePath.
+// Unlike ValidateCatalog it checks the on-disk filename, not one derived
+// from the model name.
+func (rs *Ruleset) ValidateFiles(basePath string, files []string) (*Result, error) {
+	r := &Result{}
+	providers := make(map[string]bool)
+	for _, f := range files {
+		m, err := catalog.LoadModelFile(filepath.Join(basePath, f))
+		if err != nil {
+			return nil, err
+		}
+		provider := ""
+		if parts := strings.Split(filepath.ToSlash(f), "/"); len(parts) > 1 && parts[0] == "providers" {
+			provider = parts[1]
+		}
+		r.Issues = append(r.Issues, rs.ValidateModel(provider, m, f).Issues...)
+		if provider != "" && !providers[provider] {
+			providers[provider] = true
+			if err := validateProviderFile(basePath, provider, r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r, nil
+}
+
+// validateProviderFile checks the provider.yaml next to changed models, so
+// --changed catches a broken provider.yaml too.
+func validateProviderFile(basePath, provider string, r *Result) error {
+	data, err := os.ReadFile(filepath.Join(basePath, "providers", provider, "provider.yaml"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil // catalog.Load reports it on a full run
+	} else if err != nil {
+		return err
+	}
+	var p catalog.Provider
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		r.Issues = append(r.Issues, Issue{SeverityError, filepath.Join("providers", provider, "provider.yaml"), "", fmt.Sprintf("invalid YAML: %v", err)})
+		return nil
+	}
+	r.Issues = append(r.Issues, ValidateProvider(provider, &p).Issues...)
+	return nil
+}
+
+// checkCustom applies the configured required fields and patterns.
+func (r *Rules) checkCustom(m *catalog.Model, filename string, res *Result) {
+	if len(r.Required) == 0 && len(r.Patterns) == 0 {
+		return
+	}
+	fields, err := modelFields(m)
+	if err != nil {
+		res.Issues = append(res.Issues, Issue{SeverityError, filename, "", fmt.Sprintf("encoding model: %v", err)})
+		return
+	}
+
+	for _, f := range r.Required {
+		if len(lookupField(fields, f)) == 0 {
+			res.Issues = append(res.Issues, Issue{SeverityError, filename, f, "required field is empty"})
+		}
+	}
+	for _, p := range r.Patterns {
+		re := p.re
+		if re == nil {
+			if re, err = regexp.Compile(p.Regex); err != nil {
+				res.Issues = append(res.Issues, Issue{SeverityError, filename, p.Field, fmt.Sprintf("invalid pattern: %v", err)})
+				continue
+			}
+		}
+		for _, v := range lookupField(fields, p.Field) {
+			if re.MatchString(v) {
+				continue
+			}
+			msg := p.Message
+			if msg == "" {
+				msg = fmt.Sprintf("value %q does not match %s", v, p.Regex)
+			}
+			res.Issues = append(res.Issues, Issue{p.Severity, m.Name, p.Field, msg})
+		}
+	}
+}
+
+// modelFields returns the model as it would be written to YAML, so rules
+// refer to fields by their file names.
+func modelFields(m *catalog.Model) (map[string]any, error) {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// lookupField resolves a dotted path to its non-empty scalar values.
+func lookupField(fields map[string]any, path string) []string {
+	var cur any = fields
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = obj[key]
+	}
+
+	var out []string
+	add := func(v any) {
+		if s := fmt.Sprint(v); v != nil && s != "" && s != "0" && s != "false" {
+			out = append(out, s)
+		}
+	}
+	switch v := cur.(type) {
+	case []any:
+		for _, e := range v {
+			add(e)
+		}
+	case map[string]any:
+		if len(v) > 0 {
+			out = append(out, fmt.Sprint(v))
+		}
+	default:
+		add(v)
+	}
+	return out
+}
@@ -1,11 +1,19 @@
 package validate
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"math"
+	"net/url"
+	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/everstacklabs/sentinel/internal/catalog"
+	"github.com/everstacklabs/sentinel/internal/schema"
 )
 
 // Severity classifies validation issues.
@@ -16,6 +24,10 @@ const (
 	SeverityWarning                 // Included in PR body but doesn't block
 )
 
+// maxEmbeddingDimensions bounds embedding.dimensions; the largest published
+// embedding models are well below it.
+const maxEmbeddingDimensions = 65536
+
 // Issue represents a single validation problem.
 type Issue struct {
 	Severity Severity
@@ -83,6 +95,18 @@ var knownCapabilities = map[string]bool{
 	"reasoning":         true,
 	"coding":            true,
 	"rerank":            true,
+	// Image and speech models carry their model type as their capability.
+	"image_generation": true,
+	"speech_to_text":   true,
+	"text_to_speech":   true,
+	// Accepts a reasoning effort setting (e.g. OpenAI's reasoning_effort).
+	"supports_reasoning_effort": true,
+}
+
+// reasons reports whether a model produces reasoning tokens that can be
+// billed separately.
+func reasons(capabilities []string) bool {
+	return slices.Contains(capabilities, "reasoning") || slices.Contains(capabilities, "extended_thinking")
 }
 
 // Known modality values.
@@ -92,10 +116,43 @@ var knownModalities = map[string]bool{
 	"audio":     true,
 	"video":     true,
 	"embedding": true,
+	"score":     true, // rerank relevance scores
 }
 
-// ValidateModel checks a single model for schema compliance.
+// typeModalities are the modalities a model of each media type must have:
+// its input and its output.
+var typeModalities = map[string][2]string{
+	catalog.ModelTypeImageGeneration: {"", "image"},
+	catalog.ModelTypeSpeechToText:    {"audio", "text"},
+	catalog.ModelTypeTextToSpeech:    {"", "audio"},
+}
+
+// checkModelTypeModalities warns when a media model's modalities don't
+// match its type, such as a speech-to-text model without audio input.
+func checkModelTypeModalities(m *catalog.Model, modelType string) []Issue {
+	want, ok := typeModalities[modelType]
+	if !ok {
+		return nil
+	}
+	var issues []Issue
+	if want[0] != "" && !slices.Contains(m.Modalities.Input, want[0]) {
+		issues = append(issues, Issue{SeverityWarning, m.Name, "modalities.input",
+			fmt.Sprintf("%s model without %s input", modelType, want[0])})
+	}
+	if !slices.Contains(m.Modalities.Output, want[1]) {
+		issues = append(issues, Issue{SeverityWarning, m.Name, "modalities.output",
+			fmt.Sprintf("%s model without %s output", modelType, want[1])})
+	}
+	return issues
+}
+
+// ValidateModel checks a single model for schema compliance using the
+// default rules.
 func ValidateModel(m *catalog.Model, filename string) *Result {
+	return defaultRuleset.Default.validate(m, filename)
+}
+
+func (rules *Rules) validate(m *catalog.Model, filename string) *Result {
 	r := &Result{}
 
 	// Required fields
@@ -108,7 +165,8 @@ func ValidateModel(m *catalog.Model, filename string) *Result {
 	if m.Status == "" {
 		r.Issues = append(r.Issues, Issue{SeverityError, filename, "status", "required field is empty"})
 	}
-	if m.Limits.MaxTokens == 0 {
+	modelType := m.Type()
+	if m.Limits.MaxTokens == 0 && catalog.TokenLimited(modelType) {
 		r.Issues = append(r.Issues, Issue{SeverityError, filename, "limits.max_tokens", "required field is zero"})
 	}
 	if len(m.Capabilities) == 0 {
@@ -130,7 +188,11 @@ func ValidateModel(m *catalog.Model, filename string) *Result {
 		if idx := strings.LastIndex(nameForFile, "/"); idx >= 0 {
 			nameForFile = nameForFile[idx+1:]
 		}
-		expectedFilename := nameForFile + ".yaml"
+		ext := ".yaml"
+		if catalog.IsModelFile(actualFilename) {
+			ext = filepath.Ext(actualFilename) // .json in a JSON catalog
+		}
+		expectedFilename := nameForFile + ext
 		if actualFilename != expectedFilename {
 			r.Issues = append(r.Issues, Issue{SeverityError, filename, "name",
 				fmt.Sprintf("filename %q does not match name field %q", actualFilename, m.Name)})
@@ -144,8 +206,26 @@ func ValidateModel(m *catalog.Model, filename string) *Result {
 			fmt.Sprintf("unknown status %q, expected one of: stable, beta, deprecated", m.Status)})
 	}
 
+	if m.Readiness != "" && !catalog.IsValidReadiness(m.Readiness) {
+		r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, "readiness",
+			fmt.Sprintf("unknown readiness %q, expected one of: %s", m.Readiness, strings.Join(catalog.ReadinessLevels(), ", "))})
+	}
+
+	for _, field := range m.Locked {
+		if !catalog.IsModelField(field) {
+			r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, "x_locked",
+				fmt.Sprintf("unknown field %q, nothing is locked", field)})
+		}
+	}
+
+	if m.ModelType != "" && !catalog.IsValidModelType(m.ModelType) {
+		r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "model_type",
+			fmt.Sprintf("unknown model type %q, expected one of: %s", m.ModelType, strings.Join(catalog.ModelTypes(), ", "))})
+	}
+	r.Issues = append(r.Issues, checkModelTypeModalities(m, modelType)...)
+
 	// Check if model is embedding type (used in multiple checks below)
-	isEmbedding := false
+	isEmbedding := modelType == catalog.ModelTypeEmbedding
 	for _, cap := range m.Capabilities {
 		if cap == "embeddings" {
 			isEmbedding = true
@@ -153,38 +233,183 @@ func ValidateModel(m *catalog.Model, filename string) *Result {
 		}
 	}
 
-	// Pricing sanity
+	// Pricing sanity — ranges apply to normalized per-1K prices. The USD
+	// range is meaningless for other currencies, so only negativity is checked there.
 	if m.Cost != nil {
-		if m.Cost.InputPer1K < 0 || m.Cost.InputPer1K > 0.10 {
+		cost := m.Cost.Normalize()
+		maxPrice := rules.MaxPricePer1K
+		if cost.CurrencyOrDefault() != catalog.DefaultCurrency {
+			maxPrice = math.Inf(1)
+		}
+		if cost.InputPer1K < 0 || cost.InputPer1K > maxPrice {
 			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "cost.input_per_1k",
-				fmt.Sprintf("value %.6f outside expected range [0, 0.10]", m.Cost.InputPer1K)})
+				fmt.Sprintf("value %.6f outside expected range [0, %.2f]", cost.InputPer1K, maxPrice)})
 		}
-		if m.Cost.OutputPer1K < 0 || m.Cost.OutputPer1K > 0.10 {
+		if cost.OutputPer1K < 0 || cost.OutputPer1K > maxPrice {
 			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "cost.output_per_1k",
-				fmt.Sprintf("value %.6f outside expected range [0, 0.10]", m.Cost.OutputPer1K)})
+				fmt.Sprintf("value %.6f outside expected range [0, %.2f]", cost.OutputPer1K, maxPrice)})
+		}
+		if cost.CachedInputPer1K < 0 || (cost.CachedInputPer1K > 0 && cost.CachedInputPer1K > cost.InputPer1K) {
+			r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, "cost.cached_input_per_1k",
+				fmt.Sprintf("value %.6f is negative or exceeds input price %.6f", cost.CachedInputPer1K, cost.InputPer1K)})
 		}
-		if !isEmbedding && m.Cost.OutputPer1K == 0 {
+		if cost.CacheWritePer1K < 0 || cost.CacheWritePer1K > maxPrice {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "cost.cache_write_per_1k",
+				fmt.Sprintf("value %.6f outside expected range [0, %.2f]", cost.CacheWritePer1K, maxPrice)})
+		}
+		if cost.CacheReadPer1K < 0 || (cost.CacheReadPer1K > 0 && cost.CacheReadPer1K > cost.InputPer1K) {
+			r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, "cost.cache_read_per_1k",
+				fmt.Sprintf("value %.6f is negative or exceeds input price %.6f", cost.CacheReadPer1K, cost.InputPer1K)})
+		}
+		for _, bp := range []struct {
+			field           string
+			batch, standard float64
+		}{
+			{"cost.batch_input_per_1k", cost.BatchInputPer1K, cost.InputPer1K},
+			{"cost.batch_output_per_1k", cost.BatchOutputPer1K, cost.OutputPer1K},
+		} {
+			if bp.batch < 0 || bp.batch > bp.standard {
+				r.Issues = append(r.Issues, Issue{SeverityError, m.Name, bp.field,
+					fmt.Sprintf("value %.6f is negative or exceeds the standard price %.6f", bp.batch, bp.standard)})
+			}
+		}
+		if cost.ReasoningPer1K < 0 || cost.ReasoningPer1K > maxPrice {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "cost.reasoning_per_1k",
+				fmt.Sprintf("value %.6f outside expected range [0, %.2f]", cost.ReasoningPer1K, maxPrice)})
+		} else if cost.ReasoningPer1K > 0 && !reasons(m.Capabilities) {
+			r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, "cost.reasoning_per_1k",
+				"reasoning price set for a model without reasoning or extended_thinking capability"})
+		}
+		if cost.PerImage < 0 || cost.PerRequest < 0 {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "cost",
+				"per_image and per_request prices must not be negative"})
+		}
+		if m.Cost.Unit != "" && catalog.NormalizeUnit(m.Cost.Unit) == catalog.PriceUnitPer1K &&
+			m.Cost.Unit != catalog.PriceUnitPer1K {
+			r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, "cost.unit",
+				fmt.Sprintf("unknown price unit %q, treating as %s", m.Cost.Unit, catalog.PriceUnitPer1K)})
+		}
+		if modelType == catalog.ModelTypeChat && !isEmbedding && cost.OutputPer1K == 0 && cost.PerImage == 0 && cost.PerRequest == 0 {
 			r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, "cost.output_per_1k",
 				"non-embedding model has zero output cost"})
 		}
+		if op := m.Cost.OffPeak; op != nil {
+			if op.Discount <= 0 || op.Discount >= 1 {
+				r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "cost.off_peak.discount",
+					fmt.Sprintf("value %g outside expected range (0, 1)", op.Discount)})
+			}
+			for _, f := range []struct{ field, value string }{{"start", op.Start}, {"end", op.End}} {
+				if _, err := catalog.ParseClock(f.value); err != nil {
+					r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "cost.off_peak." + f.field, err.Error()})
+				}
+			}
+			if op.Start == op.End {
+				r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "cost.off_peak", "start and end are the same, so the window is empty"})
+			}
+		}
+	}
+
+	if m.Batch != nil {
+		if m.Batch.Discount < 0 || m.Batch.Discount >= 1 {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "batch.discount",
+				fmt.Sprintf("value %g outside expected range [0, 1)", m.Batch.Discount)})
+		}
+		if !m.Batch.Supported && m.Batch.Discount != 0 {
+			r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, "batch.discount",
+				"discount set for a model without batch support"})
+		}
+		// The discount and the batch prices say the same thing twice.
+		if m.Batch.Discount > 0 && m.Cost != nil {
+			for _, bp := range []struct {
+				field           string
+				batch, standard float64
+			}{
+				{"cost.batch_input_per_1k", m.Cost.BatchInputPer1K, m.Cost.InputPer1K},
+				{"cost.batch_output_per_1k", m.Cost.BatchOutputPer1K, m.Cost.OutputPer1K},
+			} {
+				if bp.batch <= 0 || bp.standard <= 0 {
+					continue
+				}
+				if off := 1 - bp.batch/bp.standard; math.Abs(off-m.Batch.Discount) > 0.01 {
+					r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, "batch.discount",
+						fmt.Sprintf("discount %g disagrees with %s, which is %.0f%% off the standard price", m.Batch.Discount, bp.field, off*100)})
+				}
+			}
+		}
 	}
 
-	// Limits sanity — embedding models can have smaller max_tokens
+	if ft := m.FineTuning; ft != nil {
+		if ft.TrainingPer1K < 0 {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "fine_tuning.training_per_1k", "price must not be negative"})
+		}
+		if ft.InputPer1K < 0 || ft.InputPer1K > rules.MaxPricePer1K {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "fine_tuning.input_per_1k",
+				fmt.Sprintf("value %.6f outside expected range [0, %.2f]", ft.InputPer1K, rules.MaxPricePer1K)})
+		}
+		if ft.OutputPer1K < 0 || ft.OutputPer1K > rules.MaxPricePer1K {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "fine_tuning.output_per_1k",
+				fmt.Sprintf("value %.6f outside expected range [0, %.2f]", ft.OutputPer1K, rules.MaxPricePer1K)})
+		}
+		if !ft.Supported && (ft.TrainingPer1K != 0 || ft.InputPer1K != 0 || ft.OutputPer1K != 0) {
+			r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, "fine_tuning",
+				"prices set for a model without fine-tuning support"})
+		}
+	}
+
+	if e := m.Embedding; e != nil {
+		if !isEmbedding {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "embedding",
+				"embedding metadata set for a model without the embeddings capability"})
+		}
+		if e.Dimensions < 0 || e.Dimensions > maxEmbeddingDimensions {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "embedding.dimensions",
+				fmt.Sprintf("value %d outside expected range [0, %d]", e.Dimensions, maxEmbeddingDimensions)})
+		}
+		if e.MaxBatchSize < 0 {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "embedding.max_batch_size", "must not be negative"})
+		}
+		if e.Similarity != "" && !slices.Contains(catalog.SimilarityMetrics(), e.Similarity) {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "embedding.similarity",
+				fmt.Sprintf("unknown similarity %q, expected one of: %s", e.Similarity, strings.Join(catalog.SimilarityMetrics(), ", "))})
+		}
+	}
+
+	// Limits sanity — embedding and rerank models can have smaller max_tokens
 	if m.Limits.MaxTokens > 0 {
-		minTokens := 1024
-		if isEmbedding {
-			minTokens = 64
+		minTokens := rules.MinMaxTokens
+		if isEmbedding || modelType == catalog.ModelTypeRerank {
+			minTokens = rules.MinEmbeddingMaxTokens
 		}
-		if m.Limits.MaxTokens < minTokens || m.Limits.MaxTokens > 2_000_000 {
+		if m.Limits.MaxTokens < minTokens || m.Limits.MaxTokens > rules.MaxMaxTokens {
 			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "limits.max_tokens",
-				fmt.Sprintf("value %d outside expected range [%d, 2000000]", m.Limits.MaxTokens, minTokens)})
+				fmt.Sprintf("value %d outside expected range [%d, %d]", m.Limits.MaxTokens, minTokens, rules.MaxMaxTokens)})
 		}
 	}
-	if m.Limits.MaxCompletionTokens > 0 && m.Limits.MaxCompletionTokens > m.Limits.MaxTokens {
+	if m.Limits.MaxCompletionTokens > 0 && modelType == catalog.ModelTypeRerank {
+		r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "limits.max_completion_tokens",
+			"rerank models generate no tokens"})
+	} else if m.Limits.MaxCompletionTokens > 0 && m.Limits.MaxCompletionTokens > m.Limits.MaxTokens {
 		r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "limits.max_completion_tokens",
 			fmt.Sprintf("value %d exceeds max_tokens %d", m.Limits.MaxCompletionTokens, m.Limits.MaxTokens)})
 	}
 
+	tiers := make(map[string]bool, len(m.RateLimits))
+	for _, rl := range m.RateLimits {
+		field := "rate_limits." + rl.Tier
+		switch {
+		case rl.Tier == "":
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "rate_limits", "entry without a tier"})
+		case tiers[rl.Tier]:
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, field, "tier listed twice"})
+		}
+		tiers[rl.Tier] = true
+		if rl.RPM < 0 || rl.RPD < 0 || rl.TPM < 0 || rl.TPD < 0 {
+			r.Issues = append(r.Issues, Issue{SeverityError, m.Name, field, "limits must not be negative"})
+		} else if rl.RPM == 0 && rl.RPD == 0 && rl.TPM == 0 && rl.TPD == 0 {
+			r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, field, "tier sets no limit"})
+		}
+	}
+
 	// Capability taxonomy
 	for _, cap := range m.Capabilities {
 		if !knownCapabilities[cap] {
@@ -207,22 +432,157 @@ func ValidateModel(m *catalog.Model, filename string) *Result {
 		}
 	}
 
+	// Dates
+	if m.KnowledgeCutoff != "" && !catalog.ValidCutoff(m.KnowledgeCutoff) {
+		r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "knowledge_cutoff",
+			fmt.Sprintf("value %q is not a YYYY-MM or YYYY-MM-DD date", m.KnowledgeCutoff)})
+	}
+	if m.ReleasedAt != "" && !catalog.ValidDate(m.ReleasedAt) {
+		r.Issues = append(r.Issues, Issue{SeverityError, m.Name, "released_at",
+			fmt.Sprintf("value %q is not a YYYY-MM-DD date", m.ReleasedAt)})
+	}
+	if m.KnowledgeCutoff != "" && m.ReleasedAt != "" && m.KnowledgeCutoff > m.ReleasedAt {
+		r.Issues = append(r.Issues, Issue{SeverityWarning, m.Name, "knowledge_cutoff",
+			fmt.Sprintf("cutoff %s is after the release date %s", m.KnowledgeCutoff, m.ReleasedAt)})
+	}
+
+	rules.checkCustom(m, filename, r)
+
 	return r
 }
 
-// ValidateCatalog validates all models in a catalog.
+// ValidateCatalog validates all models in a catalog using the default rules.
 func ValidateCatalog(cat *catalog.Catalog) *Result {
+	return defaultRuleset.ValidateCatalog(cat)
+}
+
+// ValidateProvider checks the provider.yaml of the provider directory dir.
+func ValidateProvider(dir string, p *catalog.Provider) *Result {
 	r := &Result{}
-	for providerName, pc := range cat.Providers {
-		for modelName, model := range pc.Models {
-			filename := filepath.Join("providers", providerName, "models", modelName+".yaml")
-			modelResult := ValidateModel(model, filename)
-			r.Issues = append(r.Issues, modelResult.Issues...)
+	filename := filepath.Join("providers", dir, "provider.yaml")
+	add := func(field, msg string) {
+		r.Issues = append(r.Issues, Issue{SeverityError, filename, field, msg})
+	}
+	if p.Name != dir {
+		add("name", fmt.Sprintf("%q does not match the directory %q", p.Name, dir))
+	}
+	if p.DisplayName == "" {
+		r.Issues = append(r.Issues, Issue{SeverityWarning, filename, "display_name", "missing; sync fills it in from the adapter"})
+	}
+	for _, f := range []struct{ field, value string }{
+		{"base_url", p.BaseURL},
+		{"status_page", p.StatusPage},
+	} {
+		if f.value == "" {
+			continue
 		}
+		if u, err := url.Parse(f.value); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			add(f.field, fmt.Sprintf("%q is not an http(s) URL", f.value))
+		}
+	}
+	if p.AuthType != "" && !slices.Contains(catalog.AuthTypes, p.AuthType) {
+		add("auth_type", fmt.Sprintf("unknown auth type %q, want one of %s", p.AuthType, strings.Join(catalog.AuthTypes, ", ")))
 	}
 	return r
 }
 
+// ChangedModelFiles maps catalog-relative paths (e.g. from git diff) to the
+// model files that need validating. A changed provider.yaml pulls in every
+// model of that provider, and a changed contracts file the whole catalog.
+// Files that no longer exist are skipped.
+func ChangedModelFiles(basePath string, paths []string) ([]string, error) {
+	seen := make(map[string]bool)
+	for _, p := range paths {
+		parts := strings.Split(filepath.ToSlash(filepath.Clean(p)), "/")
+		if len(parts) == 1 && parts[0] == ContractsFile {
+			return ModelFiles(basePath)
+		}
+		if len(parts) < 3 || parts[0] != "providers" {
+			continue
+		}
+
+		switch {
+		case len(parts) == 3 && parts[2] == "provider.yaml":
+			modelsDir := filepath.Join(basePath, "providers", parts[1], "models")
+			names, err := catalog.ModelFileNames(modelsDir)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", modelsDir, err)
+			}
+			for _, name := range names {
+				seen[filepath.Join("providers", parts[1], "models", filepath.FromSlash(name))] = true
+			}
+		case len(parts) == 4 && parts[2] == "models" && catalog.IsModelFile(parts[3]),
+			len(parts) == 5 && parts[2] == "models" && parts[3] == catalog.FineTunedDir && catalog.IsModelFile(parts[4]):
+			rel := filepath.Join(parts...)
+			if _, err := os.Stat(filepath.Join(basePath, rel)); errors.Is(err, fs.ErrNotExist) {
+				continue // deleted in this branch
+			}
+			seen[rel] = true
+		}
+	}
+
+	files := make([]string, 0, len(seen))
+	for f := range seen {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ValidateFiles validates model files relative to basePath using the
+// default rules.
+func ValidateFiles(basePath string, files []string) (*Result, error) {
+	return defaultRuleset.ValidateFiles(basePath, files)
+}
+
+// ModelFiles lists every model file in the catalog, in any format,
+// relative to basePath.
+func ModelFiles(basePath string) ([]string, error) {
+	modelDirs, err := filepath.Glob(filepath.Join(basePath, "providers", "*", "models"))
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, dir := range modelDirs {
+		names, err := catalog.ModelFileNames(dir)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", dir, err)
+		}
+		for _, name := range names {
+			rel, err := filepath.Rel(basePath, filepath.Join(dir, filepath.FromSlash(name)))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, rel)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ValidateSchema checks the raw YAML of the given model files, relative to
+// basePath, against the generated JSON Schema. This catches unknown fields
+// and mistyped values that loading into catalog.Model silently accepts.
+func ValidateSchema(basePath string, files []string) (*Result, error) {
+	s := schema.Generate()
+	r := &Result{}
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(basePath, f))
+		if err != nil {
+			return nil, err
+		}
+		violations, err := s.Validate(data)
+		if err != nil {
+			r.Issues = append(r.Issues, Issue{SeverityError, f, "", fmt.Sprintf("invalid YAML: %v", err)})
+			continue
+		}
+		for _, v := range violations {
+			r.Issues = append(r.Issues, Issue{SeverityError, fmt.Sprintf("%s:%d", f, v.Line), v.Path, v.Message})
+		}
+	}
+	return r, nil
+}
+
 // FormatResult formats validation results for display.
 func FormatResult(r *Result) string {
 	if len(r.Issues) == 0 {
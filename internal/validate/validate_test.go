@@ -1,6 +1,9 @@
 package validate
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/everstacklabs/sentinel/internal/catalog"
@@ -156,6 +159,278 @@ func TestMaxCompletionTokensExceedsMaxTokens(t *testing.T) {
 	}
 }
 
+func TestDateFormats(t *testing.T) {
+	tests := []struct {
+		name           string
+		cutoff, rel    string
+		errors, warned []string
+	}{
+		{"month cutoff", "2024-10", "2025-02-24", nil, nil},
+		{"day cutoff", "2024-10-01", "2025-02-24", nil, nil},
+		{"unset", "", "", nil, nil},
+		{"prose cutoff", "Oct 2024", "", []string{"knowledge_cutoff"}, nil},
+		{"month release", "", "2025-02", []string{"released_at"}, nil},
+		{"cutoff after release", "2025-06", "2025-02-24", nil, []string{"knowledge_cutoff"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := validModel()
+			m.KnowledgeCutoff, m.ReleasedAt = tt.cutoff, tt.rel
+			r := ValidateModel(m, "gpt-4o.yaml")
+			for _, f := range tt.errors {
+				if !hasIssue(r.Errors(), f) {
+					t.Errorf("expected %s error, got %v", f, r.Issues)
+				}
+			}
+			for _, f := range tt.warned {
+				if !hasIssue(r.Warnings(), f) {
+					t.Errorf("expected %s warning, got %v", f, r.Issues)
+				}
+			}
+			if len(tt.errors)+len(tt.warned) == 0 && len(r.Issues) > 0 {
+				t.Errorf("expected no issues, got %v", r.Issues)
+			}
+		})
+	}
+}
+
+func TestReasoningPrice(t *testing.T) {
+	m := validModel()
+	m.Capabilities = append(m.Capabilities, "reasoning")
+	m.Cost.ReasoningPer1K = m.Cost.OutputPer1K
+	if r := ValidateModel(m, "gpt-4o.yaml"); len(r.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", r.Issues)
+	}
+
+	m.Cost.ReasoningPer1K = -0.01
+	if r := ValidateModel(m, "gpt-4o.yaml"); !hasIssue(r.Errors(), "cost.reasoning_per_1k") {
+		t.Errorf("expected error for a negative reasoning price, got %v", r.Issues)
+	}
+
+	m = validModel()
+	m.Cost.ReasoningPer1K = m.Cost.OutputPer1K
+	if r := ValidateModel(m, "gpt-4o.yaml"); !hasIssue(r.Warnings(), "cost.reasoning_per_1k") {
+		t.Errorf("expected warning for a reasoning price on a non-reasoning model, got %v", r.Issues)
+	}
+}
+
+func TestCachePrices(t *testing.T) {
+	m := validModel()
+	m.Cost.CacheWritePer1K = m.Cost.InputPer1K * 1.25
+	m.Cost.CacheReadPer1K = m.Cost.InputPer1K * 0.1
+	if r := ValidateModel(m, "gpt-4o.yaml"); len(r.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", r.Issues)
+	}
+
+	m.Cost.CacheWritePer1K = -0.01
+	m.Cost.CacheReadPer1K = m.Cost.InputPer1K * 2
+	r := ValidateModel(m, "gpt-4o.yaml")
+	if !hasIssue(r.Errors(), "cost.cache_write_per_1k") {
+		t.Errorf("expected error for a negative cache write price, got %v", r.Issues)
+	}
+	if !hasIssue(r.Warnings(), "cost.cache_read_per_1k") {
+		t.Errorf("expected warning for a cache read price above the input price, got %v", r.Issues)
+	}
+}
+
+func TestBatchPrices(t *testing.T) {
+	m := validModel()
+	m.Cost.BatchInputPer1K = m.Cost.InputPer1K / 2
+	m.Cost.BatchOutputPer1K = m.Cost.OutputPer1K / 2
+	if r := ValidateModel(m, "gpt-4o.yaml"); len(r.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", r.Issues)
+	}
+
+	m.Cost.BatchInputPer1K = -0.01
+	m.Cost.BatchOutputPer1K = m.Cost.OutputPer1K * 2
+	r := ValidateModel(m, "gpt-4o.yaml")
+	for _, field := range []string{"cost.batch_input_per_1k", "cost.batch_output_per_1k"} {
+		if !hasIssue(r.Errors(), field) {
+			t.Errorf("expected an error on %s, got %v", field, r.Issues)
+		}
+	}
+}
+
+func TestBatchDiscountRange(t *testing.T) {
+	m := validModel()
+	m.Batch = &catalog.Batch{Supported: true, Discount: 0.5}
+	if r := ValidateModel(m, "gpt-4o.yaml"); len(r.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", r.Issues)
+	}
+
+	m.Batch.Discount = 50
+	if r := ValidateModel(m, "gpt-4o.yaml"); !hasIssue(r.Errors(), "batch.discount") {
+		t.Errorf("a percentage instead of a fraction should be an error, got %v", r.Issues)
+	}
+
+	m.Batch = &catalog.Batch{Discount: 0.5}
+	if r := ValidateModel(m, "gpt-4o.yaml"); !hasIssue(r.Warnings(), "batch.discount") {
+		t.Errorf("expected warning for a discount without batch support, got %v", r.Issues)
+	}
+}
+
+func TestBatchDiscountMatchesPrices(t *testing.T) {
+	m := validModel()
+	m.Batch = &catalog.Batch{Supported: true, Discount: 0.5}
+	m.Cost.BatchInputPer1K = m.Cost.InputPer1K / 2
+	m.Cost.BatchOutputPer1K = m.Cost.OutputPer1K / 2
+	if r := ValidateModel(m, "gpt-4o.yaml"); len(r.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", r.Issues)
+	}
+
+	m.Cost.BatchOutputPer1K = m.Cost.OutputPer1K * 0.75
+	r := ValidateModel(m, "gpt-4o.yaml")
+	if !hasIssue(r.Warnings(), "batch.discount") || len(r.Issues) != 1 {
+		t.Errorf("expected one warning for the output price 25%% off, got %v", r.Issues)
+	}
+}
+
+func TestOffPeakPricing(t *testing.T) {
+	m := validModel()
+	m.Cost.OffPeak = &catalog.OffPeak{Discount: 0.5, Start: "16:30", End: "00:30"}
+	if r := ValidateModel(m, "gpt-4o.yaml"); len(r.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", r.Issues)
+	}
+
+	m.Cost.OffPeak = &catalog.OffPeak{Discount: 50, Start: "4:30pm", End: "00:30"}
+	r := ValidateModel(m, "gpt-4o.yaml")
+	for _, field := range []string{"cost.off_peak.discount", "cost.off_peak.start"} {
+		if !hasIssue(r.Errors(), field) {
+			t.Errorf("expected an error on %s, got %v", field, r.Issues)
+		}
+	}
+
+	m.Cost.OffPeak = &catalog.OffPeak{Discount: 0.5, Start: "16:30", End: "16:30"}
+	if r := ValidateModel(m, "gpt-4o.yaml"); !hasIssue(r.Errors(), "cost.off_peak") {
+		t.Errorf("expected an error for an empty window, got %v", r.Issues)
+	}
+}
+
+func TestFineTuningPrices(t *testing.T) {
+	m := validModel()
+	m.FineTuning = &catalog.FineTuning{Supported: true, TrainingPer1K: 0.025, InputPer1K: 0.00375, OutputPer1K: 0.015}
+	if r := ValidateModel(m, "gpt-4o.yaml"); len(r.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", r.Issues)
+	}
+
+	m.FineTuning.TrainingPer1K = -1
+	if r := ValidateModel(m, "gpt-4o.yaml"); !hasIssue(r.Errors(), "fine_tuning.training_per_1k") {
+		t.Errorf("expected error for a negative training price, got %v", r.Issues)
+	}
+
+	m.FineTuning = &catalog.FineTuning{TrainingPer1K: 0.025}
+	if r := ValidateModel(m, "gpt-4o.yaml"); !hasIssue(r.Warnings(), "fine_tuning") {
+		t.Errorf("expected warning for prices without fine-tuning support, got %v", r.Issues)
+	}
+}
+
+func TestEmbeddingMetadata(t *testing.T) {
+	m := validModel()
+	m.Capabilities = []string{"embeddings"}
+	m.Modalities = catalog.Modalities{Input: []string{"text"}, Output: []string{"embedding"}}
+	m.Cost = &catalog.Cost{InputPer1K: 0.00013}
+	m.Limits = catalog.Limits{MaxTokens: 8191}
+	m.Embedding = &catalog.Embedding{Dimensions: 3072, MaxBatchSize: 2048, Similarity: "cosine"}
+	if r := ValidateModel(m, "gpt-4o.yaml"); len(r.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", r.Issues)
+	}
+
+	m.Embedding = &catalog.Embedding{Dimensions: -1, MaxBatchSize: -1, Similarity: "l2"}
+	r := ValidateModel(m, "gpt-4o.yaml")
+	for _, field := range []string{"embedding.dimensions", "embedding.max_batch_size", "embedding.similarity"} {
+		if !hasIssue(r.Errors(), field) {
+			t.Errorf("expected error for %s, got %v", field, r.Issues)
+		}
+	}
+
+	m = validModel()
+	m.Embedding = &catalog.Embedding{Dimensions: 1536}
+	if r := ValidateModel(m, "gpt-4o.yaml"); !hasIssue(r.Errors(), "embedding") {
+		t.Errorf("expected error for embedding metadata on a chat model, got %v", r.Issues)
+	}
+}
+
+func TestModelTypeRules(t *testing.T) {
+	m := &catalog.Model{
+		Name:         "whisper-1",
+		DisplayName:  "Whisper 1",
+		Status:       "stable",
+		ModelType:    catalog.ModelTypeSpeechToText,
+		Capabilities: []string{"speech_to_text"},
+		Modalities:   catalog.Modalities{Input: []string{"audio"}, Output: []string{"text"}},
+		Cost:         &catalog.Cost{PerRequest: 0.006},
+	}
+	// No max_tokens: speech models aren't sized in tokens.
+	if r := ValidateModel(m, "whisper-1.yaml"); len(r.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", r.Issues)
+	}
+
+	m.Modalities.Input = []string{"text"}
+	if r := ValidateModel(m, "whisper-1.yaml"); !hasIssue(r.Warnings(), "modalities.input") {
+		t.Errorf("expected warning for a speech_to_text model without audio input, got %v", r.Issues)
+	}
+
+	m.ModelType = "audio"
+	if r := ValidateModel(m, "whisper-1.yaml"); !hasIssue(r.Errors(), "model_type") {
+		t.Errorf("expected error for an unknown model type, got %v", r.Issues)
+	}
+}
+
+func TestRerankLimits(t *testing.T) {
+	m := &catalog.Model{
+		Name:         "rerank-v3.5",
+		DisplayName:  "Rerank V3.5",
+		Status:       "stable",
+		Capabilities: []string{"rerank"},
+		Limits:       catalog.Limits{MaxTokens: 4096},
+		Modalities:   catalog.Modalities{Input: []string{"text"}, Output: []string{"score"}},
+		Cost:         &catalog.Cost{PerRequest: 0.002},
+	}
+	if r := ValidateModel(m, "rerank-v3.5.yaml"); len(r.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", r.Issues)
+	}
+
+	m.Limits = catalog.Limits{MaxTokens: 512, MaxCompletionTokens: 512}
+	if r := ValidateModel(m, "rerank-v3.5.yaml"); !hasIssue(r.Errors(), "limits.max_completion_tokens") || hasIssue(r.Errors(), "limits.max_tokens") {
+		t.Errorf("expected only a max_completion_tokens error, got %v", r.Issues)
+	}
+}
+
+func TestRateLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits []catalog.RateLimit
+		errors []string
+		warned []string
+	}{
+		{"valid", []catalog.RateLimit{{Tier: "free", RPM: 30, TPD: 500000}, {Tier: "developer", RPM: 1000}}, nil, nil},
+		{"missing tier", []catalog.RateLimit{{RPM: 30}}, []string{"rate_limits"}, nil},
+		{"duplicate tier", []catalog.RateLimit{{Tier: "free", RPM: 30}, {Tier: "free", RPM: 60}}, []string{"rate_limits.free"}, nil},
+		{"negative", []catalog.RateLimit{{Tier: "free", TPM: -1}}, []string{"rate_limits.free"}, nil},
+		{"empty tier", []catalog.RateLimit{{Tier: "free"}}, nil, []string{"rate_limits.free"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := validModel()
+			m.RateLimits = tt.limits
+			r := ValidateModel(m, "gpt-4o.yaml")
+			for _, f := range tt.errors {
+				if !hasIssue(r.Errors(), f) {
+					t.Errorf("expected %s error, got %v", f, r.Issues)
+				}
+			}
+			for _, f := range tt.warned {
+				if !hasIssue(r.Warnings(), f) {
+					t.Errorf("expected %s warning, got %v", f, r.Issues)
+				}
+			}
+			if len(tt.errors)+len(tt.warned) == 0 && len(r.Issues) > 0 {
+				t.Errorf("expected no issues, got %v", r.Issues)
+			}
+		})
+	}
+}
+
 func TestNamespacedModelNameMatchesFilename(t *testing.T) {
 	m := validModel()
 	m.Name = "openai/gpt-4o"
@@ -240,6 +515,59 @@ func TestValidStatusValues(t *testing.T) {
 	}
 }
 
+func TestUnknownLockedField(t *testing.T) {
+	m := validModel()
+	m.Locked = []string{"cost", "limits.max_tokens", "costs"}
+	r := ValidateModel(m, "gpt-4o.yaml")
+
+	var locked []string
+	for _, w := range r.Warnings() {
+		if w.Field == "x_locked" {
+			locked = append(locked, w.Message)
+		}
+	}
+	if len(locked) != 1 || !strings.Contains(locked[0], `"costs"`) {
+		t.Errorf("x_locked warnings = %v, want one for costs", locked)
+	}
+}
+
+func TestValidateProvider(t *testing.T) {
+	valid := catalog.Provider{
+		Name: "openai", DisplayName: "OpenAI", ProviderType: "llm",
+		BaseURL: "https://api.openai.com/v1", AuthType: catalog.AuthBearer, StatusPage: "https://status.openai.com",
+	}
+	tests := []struct {
+		name      string
+		edit      func(p *catalog.Provider)
+		wantField string
+		wantSev   Severity
+	}{
+		{"valid", func(p *catalog.Provider) {}, "", 0},
+		{"name mismatch", func(p *catalog.Provider) { p.Name = "open-ai" }, "name", SeverityError},
+		{"no display name", func(p *catalog.Provider) { p.DisplayName = "" }, "display_name", SeverityWarning},
+		{"relative base URL", func(p *catalog.Provider) { p.BaseURL = "api.openai.com/v1" }, "base_url", SeverityError},
+		{"bad status page", func(p *catalog.Provider) { p.StatusPage = "ftp://status.openai.com" }, "status_page", SeverityError},
+		{"unknown auth type", func(p *catalog.Provider) { p.AuthType = "oauth" }, "auth_type", SeverityError},
+		{"optional fields empty", func(p *catalog.Provider) { p.BaseURL, p.AuthType, p.StatusPage = "", "", "" }, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid
+			tt.edit(&p)
+			r := ValidateProvider("openai", &p)
+			if tt.wantField == "" {
+				if len(r.Issues) != 0 {
+					t.Errorf("unexpected issues: %v", r.Issues)
+				}
+				return
+			}
+			if len(r.Issues) != 1 || r.Issues[0].Field != tt.wantField || r.Issues[0].Severity != tt.wantSev {
+				t.Errorf("issues = %v, want one on %s", r.Issues, tt.wantField)
+			}
+		})
+	}
+}
+
 func TestEmbeddingZeroOutputCostNoWarning(t *testing.T) {
 	m := validModel()
 	m.Capabilities = []string{"embeddings"}
@@ -278,3 +606,304 @@ func TestFormatResultNoIssues(t *testing.T) {
 		t.Errorf("unexpected format: %s", s)
 	}
 }
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChangedModelFiles(t *testing.T) {
+	base := t.TempDir()
+	writeFile(t, filepath.Join(base, "providers/openai/provider.yaml"), "name: openai\n")
+	writeFile(t, filepath.Join(base, "providers/openai/models/gpt-4o.yaml"), "name: gpt-4o\n")
+	writeFile(t, filepath.Join(base, "providers/openai/models/gpt-5.yaml"), "name: gpt-5\n")
+	writeFile(t, filepath.Join(base, "providers/mistral/models/mistral-large.yaml"), "name: mistral-large\n")
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{"model file", []string{"providers/mistral/models/mistral-large.yaml"},
+			[]string{"providers/mistral/models/mistral-large.yaml"}},
+		{"provider.yaml expands to all models", []string{"providers/openai/provider.yaml"},
+			[]string{"providers/openai/models/gpt-4o.yaml", "providers/openai/models/gpt-5.yaml"}},
+		{"deleted model skipped", []string{"providers/openai/models/gpt-3.5-turbo.yaml"}, nil},
+		{"unrelated files ignored", []string{"README.md", "version.txt", "providers/openai/notes.md"}, nil},
+		{"deduplicated", []string{"providers/openai/provider.yaml", "providers/openai/models/gpt-5.yaml"},
+			[]string{"providers/openai/models/gpt-4o.yaml", "providers/openai/models/gpt-5.yaml"}},
+		{"contracts file expands to whole catalog", []string{"contracts.yaml"},
+			[]string{"providers/mistral/models/mistral-large.yaml", "providers/openai/models/gpt-4o.yaml", "providers/openai/models/gpt-5.yaml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ChangedModelFiles(base, tt.paths)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range got {
+				got[i] = filepath.ToSlash(got[i])
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFilesUsesOnDiskFilename(t *testing.T) {
+	base := t.TempDir()
+	rel := filepath.Join("providers", "openai", "models", "gpt4o.yaml")
+	writeFile(t, filepath.Join(base, rel), `name: gpt-4o
+display_name: GPT-4o
+status: stable
+limits:
+  max_tokens: 128000
+capabilities: [chat]
+modalities:
+  input: [text]
+  output: [text]
+`)
+
+	r, err := ValidateFiles(base, []string{rel})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := r.Errors()
+	if len(errs) != 1 || errs[0].Field != "name" {
+		t.Errorf("expected a single filename mismatch error, got %v", errs)
+	}
+}
+
+func hasIssue(issues []Issue, field string) bool {
+	for _, i := range issues {
+		if i.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRulesetProviderOverrides(t *testing.T) {
+	def := DefaultRules()
+	google := def
+	google.MaxMaxTokens = 4_000_000
+	google.MaxPricePer1K = 0.50
+	rs, err := NewRuleset(def, map[string]Rules{"google": google})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := validModel()
+	m.Limits.MaxTokens = 2_097_152
+	m.Cost.OutputPer1K = 0.20
+
+	if r := rs.ValidateModel("openai", m, "gpt-4o.yaml"); !hasIssue(r.Errors(), "limits.max_tokens") || !hasIssue(r.Errors(), "cost.output_per_1k") {
+		t.Errorf("default rules should reject 2M+ context and $0.20/1K, got: %v", r.Issues)
+	}
+	if r := rs.ValidateModel("google", m, "gpt-4o.yaml"); r.HasErrors() {
+		t.Errorf("google overrides should accept the model, got: %v", r.Errors())
+	}
+
+	var nilRules *Ruleset
+	if r := nilRules.ValidateModel("google", m, "gpt-4o.yaml"); !r.HasErrors() {
+		t.Error("nil Ruleset should apply the defaults")
+	}
+}
+
+func TestCustomRequiredFieldsAndPatterns(t *testing.T) {
+	rules := DefaultRules()
+	rules.Required = []string{"cost.cached_input_per_1k", "family"}
+	rules.Patterns = []Pattern{
+		{Field: "name", Regex: `^gpt-`},
+		{Field: "capabilities", Regex: `^(chat|vision)$`, Severity: SeverityWarning, Message: "not allowed here"},
+	}
+	rs, err := NewRuleset(rules, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := validModel()
+	r := rs.ValidateModel("openai", m, "gpt-4o.yaml")
+	if !hasIssue(r.Errors(), "cost.cached_input_per_1k") {
+		t.Errorf("expected missing cached price to be an error, got: %v", r.Issues)
+	}
+	if hasIssue(r.Errors(), "family") || hasIssue(r.Errors(), "name") {
+		t.Errorf("family is set and name matches, got: %v", r.Errors())
+	}
+	warns := r.Warnings()
+	if len(warns) != 1 || warns[0].Field != "capabilities" || warns[0].Message != "not allowed here" {
+		t.Errorf("expected one capabilities warning for function_calling, got: %v", warns)
+	}
+
+	m.Name = "o3"
+	if r := rs.ValidateModel("openai", m, "o3.yaml"); !hasIssue(r.Errors(), "name") {
+		t.Errorf("expected name pattern error, got: %v", r.Issues)
+	}
+}
+
+func TestNewRulesetRejectsBadRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Rules)
+	}{
+		{"bad regex", func(r *Rules) { r.Patterns = []Pattern{{Field: "name", Regex: "("}} }},
+		{"pattern without field", func(r *Rules) { r.Patterns = []Pattern{{Regex: "x"}} }},
+		{"zero max price", func(r *Rules) { r.MaxPricePer1K = 0 }},
+		{"max below min", func(r *Rules) { r.MaxMaxTokens = 512 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultRules()
+			tt.mutate(&rules)
+			if _, err := NewRuleset(DefaultRules(), map[string]Rules{"openai": rules}); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestContracts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ContractsFile)
+	writeFile(t, path, `contracts:
+  - name: billing
+    consumer: billing-service
+    match:
+      capabilities: [chat]
+    required: [cost, limits.max_completion_tokens]
+  - name: families
+    match:
+      providers: [openai]
+    allowed:
+      family: [gpt-4, gpt-4o]
+    severity: warning
+`)
+	contracts, err := LoadContracts(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs, err := NewRuleset(DefaultRules(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs.Contracts = contracts
+
+	if r := rs.ValidateModel("openai", validModel(), "gpt-4o.yaml"); len(r.Issues) != 0 {
+		t.Errorf("model meets both contracts, got: %v", r.Issues)
+	}
+
+	m := validModel()
+	m.Limits.MaxCompletionTokens = 0
+	m.Family = "gpt-5"
+	r := rs.ValidateModel("openai", m, "gpt-4o.yaml")
+	errs := r.Errors()
+	if len(errs) != 1 || errs[0].Field != "limits.max_completion_tokens" || !strings.Contains(errs[0].Message, `contract "billing" of billing-service`) {
+		t.Errorf("expected billing contract error, got: %v", errs)
+	}
+	if warns := r.Warnings(); len(warns) != 1 || warns[0].Field != "family" {
+		t.Errorf("expected family warning, got: %v", warns)
+	}
+
+	// Neither contract matches a non-chat model of another provider.
+	m.Capabilities = []string{"embeddings"}
+	if r := rs.ValidateModel("mistral", m, "gpt-4o.yaml"); len(r.Issues) != 0 {
+		t.Errorf("contracts should not apply, got: %v", r.Issues)
+	}
+}
+
+func TestLoadContracts(t *testing.T) {
+	dir := t.TempDir()
+	if c, err := LoadContracts(filepath.Join(dir, ContractsFile), false); err != nil || c != nil {
+		t.Errorf("missing optional file: got %v, %v", c, err)
+	}
+	if _, err := LoadContracts(filepath.Join(dir, ContractsFile), true); err == nil {
+		t.Error("expected error for missing required file")
+	}
+
+	for name, content := range map[string]string{
+		"no name":      "contracts:\n  - required: [cost]\n",
+		"no checks":    "contracts:\n  - name: empty\n",
+		"bad severity": "contracts:\n  - name: x\n    required: [cost]\n    severity: fatal\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ContractsFile)
+			writeFile(t, path, content)
+			if _, err := LoadContracts(path, true); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestValidateSchemaCatchesWhatLoaderIgnores(t *testing.T) {
+	base := t.TempDir()
+	writeFile(t, filepath.Join(base, "providers", "openai", "models", "gpt-4o.yaml"), `name: gpt-4o
+display_name: GPT-4o
+family: gpt-4o
+status: stable
+limits:
+  max_tokens: 128000
+  max_completion_token: 16384
+capabilities: [chat]
+modalities:
+  input: [text]
+  output: [text]
+`)
+	writeFile(t, filepath.Join(base, "providers", "openai", "provider.yaml"), "name: openai\ndisplay_name: OpenAI\n")
+
+	files, err := ModelFiles(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("files = %v", files)
+	}
+
+	// The struct-based check has nothing to say about the typo...
+	r, err := ValidateFiles(base, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Issues) != 0 {
+		t.Fatalf("unexpected struct issues: %v", r.Issues)
+	}
+
+	// ...but the schema check reports it with its line.
+	r, err = ValidateSchema(base, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := r.Errors()
+	if len(errs) != 1 || errs[0].Field != "limits.max_completion_token" || !strings.HasSuffix(errs[0].Model, ".yaml:7") {
+		t.Errorf("expected unknown field error at line 7, got %v", errs)
+	}
+}
+
+func TestCaseCollidingModelNames(t *testing.T) {
+	upper, lower, other := validModel(), validModel(), validModel()
+	upper.Name, lower.Name, other.Name = "GPT-4o", "gpt-4o", "gpt-4o-mini"
+	cat := &catalog.Catalog{Providers: map[string]*catalog.ProviderCatalog{
+		"openai": {Models: map[string]*catalog.Model{"GPT-4o": upper, "gpt-4o": lower, "gpt-4o-mini": other}},
+	}}
+
+	var collisions []Issue
+	for _, i := range ValidateCatalog(cat).Errors() {
+		if strings.Contains(i.Message, "differs only in case") {
+			collisions = append(collisions, i)
+		}
+	}
+	if len(collisions) != 2 {
+		t.Fatalf("got %d case collision errors, want one per colliding file: %v", len(collisions), collisions)
+	}
+	for _, i := range collisions {
+		if strings.Contains(i.Model, "mini") {
+			t.Errorf("unrelated model reported: %v", i)
+		}
+	}
+}